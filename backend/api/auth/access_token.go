@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	errs "github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	// AccessTokenPrefix is the prefix of the personal access tokens, which tells them apart from the JWT access tokens.
+	AccessTokenPrefix = "bbp_"
+	// accessTokenLength is the length of the random part of the personal access tokens.
+	accessTokenLength = 40
+	// accessTokenLastUsedInterval is the interval to record the last used time of a personal access token,
+	// so that a busy CI pipeline doesn't write the metadata database on every request.
+	accessTokenLastUsedInterval = time.Minute
+)
+
+// readOnlyMethodPrefixes are the prefixes of the gRPC method names which don't mutate anything.
+var readOnlyMethodPrefixes = []string{"Get", "List", "Search"}
+
+// accessTokenScopeMethods are the gRPC methods allowed by the scopes in addition to the read-only methods.
+var accessTokenScopeMethods = map[api.AccessTokenScope]map[string]bool{
+	api.AccessTokenScopeReadOnly: {
+		"/bytebase.v1.SQLService/Pretty": true,
+	},
+	api.AccessTokenScopeIssueCreate: {
+		"/bytebase.v1.SQLService/Pretty": true,
+	},
+	api.AccessTokenScopeSQLQuery: {
+		"/bytebase.v1.SQLService/Pretty": true,
+	},
+}
+
+// GeneratePersonalAccessToken generates a personal access token and returns the token and its hash.
+func GeneratePersonalAccessToken() (string, string, error) {
+	random, err := common.RandomString(accessTokenLength)
+	if err != nil {
+		return "", "", errs.Wrap(err, "failed to generate random string")
+	}
+	token := AccessTokenPrefix + random
+	return token, HashAccessToken(token), nil
+}
+
+// HashAccessToken returns the hex encoded SHA-256 hash of the personal access token.
+// The tokens are long random strings, so a fast hash is enough to protect them at rest.
+func HashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsPersonalAccessToken returns whether the token is a personal access token.
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, AccessTokenPrefix)
+}
+
+// ValidateAccessToken returns the personal access token if it's valid, and records the last used time.
+// A common.NotAuthorized error is returned if the token is unknown, expired or owned by a deactivated user.
+func ValidateAccessToken(ctx context.Context, s *store.Store, token string) (*store.AccessTokenMessage, error) {
+	tokenHash := HashAccessToken(token)
+	accessToken, err := s.GetAccessToken(ctx, &store.FindAccessTokenMessage{TokenHash: &tokenHash})
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to find access token")
+	}
+	if accessToken == nil {
+		return nil, common.Errorf(common.NotAuthorized, "invalid access token")
+	}
+	now := time.Now().Unix()
+	if accessToken.ExpireTs > 0 && accessToken.ExpireTs <= now {
+		return nil, common.Errorf(common.NotAuthorized, "access token %q has expired", accessToken.Title)
+	}
+	user, err := s.GetUserByID(ctx, accessToken.PrincipalUID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to find user ID %d of the access token", accessToken.PrincipalUID)
+	}
+	if user == nil {
+		return nil, common.Errorf(common.NotAuthorized, "user ID %d of the access token not exists", accessToken.PrincipalUID)
+	}
+	if user.MemberDeleted {
+		return nil, common.Errorf(common.NotAuthorized, "user ID %d of the access token has been deactivated by administrators", accessToken.PrincipalUID)
+	}
+
+	if time.Duration(now-accessToken.LastUsedTs)*time.Second >= accessTokenLastUsedInterval {
+		// Failing to record the last used time shouldn't fail the request.
+		if err := s.UpdateAccessTokenLastUsedTs(ctx, accessToken.ID, now); err != nil {
+			log.Warn("Failed to update the last used time of access token", zap.Int("id", accessToken.ID), zap.Error(err))
+		}
+		accessToken.LastUsedTs = now
+	}
+	return accessToken, nil
+}
+
+// IsMethodAllowedByScopes returns whether the gRPC method can be called with a personal access token of the scopes.
+func IsMethodAllowedByScopes(fullMethodName string, scopes []api.AccessTokenScope) bool {
+	methodName := fullMethodName[strings.LastIndex(fullMethodName, "/")+1:]
+	for _, scope := range scopes {
+		if scope == api.AccessTokenScopeAdmin {
+			return true
+		}
+		if _, ok := accessTokenScopeMethods[scope]; !ok {
+			continue
+		}
+		if common.HasPrefixes(methodName, readOnlyMethodPrefixes...) || accessTokenScopeMethods[scope][fullMethodName] {
+			return true
+		}
+	}
+	return false
+}
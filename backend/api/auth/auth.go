@@ -90,6 +90,23 @@ func (in *APIAuthInterceptor) AuthenticationInterceptor(ctx context.Context, req
 		return nil, status.Errorf(codes.Unauthenticated, err.Error())
 	}
 
+	if IsPersonalAccessToken(accessTokenStr) {
+		accessToken, err := ValidateAccessToken(ctx, in.store, accessTokenStr)
+		if err != nil {
+			if common.ErrorCode(err) == common.NotAuthorized {
+				return nil, status.Errorf(codes.Unauthenticated, common.ErrorMessage(err))
+			}
+			return nil, status.Errorf(codes.Internal, "failed to validate access token, error: %v", err)
+		}
+		if !IsMethodAllowedByScopes(serverInfo.FullMethod, accessToken.ScopeList) {
+			return nil, status.Errorf(codes.PermissionDenied, "access token %q with scopes %v is not allowed to access method %q", accessToken.Title, accessToken.ScopeList, serverInfo.FullMethod)
+		}
+		// Stores principalID and the access token into context.
+		childCtx := context.WithValue(ctx, common.PrincipalIDContextKey, accessToken.PrincipalUID)
+		childCtx = context.WithValue(childCtx, common.AccessTokenContextKey, accessToken)
+		return handler(childCtx, request)
+	}
+
 	principalID, err := in.authenticate(ctx, accessTokenStr, refreshTokenStr)
 	if err != nil {
 		if IsAuthenticationAllowed(serverInfo.FullMethod) {
@@ -48,9 +48,15 @@ func (in *ACLInterceptor) ACLInterceptor(ctx context.Context, request any, serve
 	if user == nil {
 		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated for method %q", serverInfo.FullMethod)
 	}
+	role := user.Role
+	// The personal access token restricted to a project acts as a workspace developer,
+	// who only has the project roles in that project.
+	if accessToken, ok := ctx.Value(common.AccessTokenContextKey).(*store.AccessTokenMessage); ok && accessToken.ProjectUID != nil {
+		role = api.Developer
+	}
 	// Store workspace role into context.
-	childCtx := context.WithValue(ctx, common.RoleContextKey, user.Role)
-	if isOwnerOrDBA(user.Role) {
+	childCtx := context.WithValue(ctx, common.RoleContextKey, role)
+	if isOwnerOrDBA(role) {
 		return handler(childCtx, request)
 	}
 
@@ -119,6 +125,19 @@ func (in *ACLInterceptor) getUser(ctx context.Context) (*store.UserMessage, erro
 }
 
 func (in *ACLInterceptor) getProjectRoles(ctx context.Context, user *store.UserMessage, projectID string) (map[api.Role]bool, error) {
+	if accessToken, ok := ctx.Value(common.AccessTokenContextKey).(*store.AccessTokenMessage); ok && accessToken.ProjectUID != nil {
+		project, err := in.store.GetProjectV2(ctx, &store.FindProjectMessage{UID: accessToken.ProjectUID})
+		if err != nil {
+			return nil, err
+		}
+		if project == nil || project.ResourceID != projectID {
+			return map[api.Role]bool{}, nil
+		}
+		// Workspace owner and DBA assume the project owner role.
+		if isOwnerOrDBA(user.Role) {
+			return map[api.Role]bool{api.Owner: true}, nil
+		}
+	}
 	projectPolicy, err := in.store.GetProjectPolicy(ctx, &store.GetProjectPolicyMessage{ProjectID: &projectID})
 	if err != nil {
 		return nil, err
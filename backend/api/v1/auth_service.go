@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/mail"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/exp/slices"
@@ -15,6 +16,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/pquerna/otp/totp"
 
@@ -24,6 +26,7 @@ import (
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	metricAPI "github.com/bytebase/bytebase/backend/metric"
+	"github.com/bytebase/bytebase/backend/plugin/idp/ldap"
 	"github.com/bytebase/bytebase/backend/plugin/idp/oauth2"
 	"github.com/bytebase/bytebase/backend/plugin/idp/oidc"
 	"github.com/bytebase/bytebase/backend/plugin/metric"
@@ -253,11 +256,17 @@ func (s *AuthService) UpdateUser(ctx context.Context, request *v1pb.UpdateUserRe
 		case "title":
 			patch.Name = &request.User.Title
 		case "password":
+			if isAuthenticatedByAccessToken(ctx) {
+				return nil, status.Errorf(codes.PermissionDenied, "password cannot be changed with a personal access token")
+			}
 			if user.Type != api.EndUser {
 				return nil, status.Errorf(codes.InvalidArgument, "password can be mutated for end users only")
 			}
 			passwordPatch = &request.User.Password
 		case "service_key":
+			if isAuthenticatedByAccessToken(ctx) {
+				return nil, status.Errorf(codes.PermissionDenied, "service key cannot be changed with a personal access token")
+			}
 			if user.Type != api.ServiceAccount {
 				return nil, status.Errorf(codes.InvalidArgument, "service key can be mutated for service accounts only")
 			}
@@ -419,6 +428,218 @@ func (s *AuthService) UndeleteUser(ctx context.Context, request *v1pb.UndeleteUs
 	return convertToUser(user), nil
 }
 
+// ListAccessTokens lists the personal access tokens of a user.
+func (s *AuthService) ListAccessTokens(ctx context.Context, request *v1pb.ListAccessTokensRequest) (*v1pb.ListAccessTokensResponse, error) {
+	userID, err := getUserID(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	user, err := s.getAccessTokenUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	accessTokens, err := s.store.ListAccessTokens(ctx, &store.FindAccessTokenMessage{PrincipalUID: &user.ID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list access tokens, error: %v", err)
+	}
+	response := &v1pb.ListAccessTokensResponse{}
+	for _, accessToken := range accessTokens {
+		v, err := s.convertToAccessToken(ctx, accessToken)
+		if err != nil {
+			return nil, err
+		}
+		response.AccessTokens = append(response.AccessTokens, v)
+	}
+	return response, nil
+}
+
+// CreateAccessToken creates a personal access token for a user.
+func (s *AuthService) CreateAccessToken(ctx context.Context, request *v1pb.CreateAccessTokenRequest) (*v1pb.AccessToken, error) {
+	// Otherwise a token restricted by the project and the scopes could create a token without the restrictions.
+	if isAuthenticatedByAccessToken(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "access tokens cannot be created with a personal access token")
+	}
+	userID, err := getUserID(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	user, err := s.getAccessTokenUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MemberDeleted {
+		return nil, status.Errorf(codes.InvalidArgument, "user %d has been deleted", userID)
+	}
+	if request.AccessToken == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "access token must be set")
+	}
+	title := strings.TrimSpace(request.AccessToken.Title)
+	if title == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "access token title must be set")
+	}
+	if len(request.AccessToken.Scopes) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "access token scopes must be set")
+	}
+
+	create := &store.AccessTokenMessage{
+		PrincipalUID: user.ID,
+		Title:        title,
+	}
+	for _, scope := range request.AccessToken.Scopes {
+		v, err := convertAccessTokenScope(scope)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(create.ScopeList, v) {
+			create.ScopeList = append(create.ScopeList, v)
+		}
+	}
+	if request.AccessToken.Project != "" {
+		projectID, err := getProjectID(request.AccessToken.Project)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{ResourceID: &projectID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get project, error: %v", err)
+		}
+		if project == nil {
+			return nil, status.Errorf(codes.NotFound, "project %q not found", projectID)
+		}
+		create.ProjectUID = &project.UID
+	}
+	if request.AccessToken.ExpireTime != nil {
+		if err := request.AccessToken.ExpireTime.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid expire time, error: %v", err)
+		}
+		expireTime := request.AccessToken.ExpireTime.AsTime()
+		if !expireTime.After(time.Now()) {
+			return nil, status.Errorf(codes.InvalidArgument, "expire time must be in the future")
+		}
+		create.ExpireTs = expireTime.Unix()
+	}
+
+	token, tokenHash, err := auth.GeneratePersonalAccessToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate access token, error: %v", err)
+	}
+	create.TokenHash = tokenHash
+	accessToken, err := s.store.CreateAccessToken(ctx, create)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create access token, error: %v", err)
+	}
+	response, err := s.convertToAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	// The token is only returned once, only its hash is stored.
+	response.Token = token
+	return response, nil
+}
+
+// DeleteAccessToken revokes a personal access token.
+func (s *AuthService) DeleteAccessToken(ctx context.Context, request *v1pb.DeleteAccessTokenRequest) (*emptypb.Empty, error) {
+	userID, accessTokenID, err := getUserAccessTokenID(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	user, err := s.getAccessTokenUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := s.store.GetAccessToken(ctx, &store.FindAccessTokenMessage{ID: &accessTokenID, PrincipalUID: &user.ID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get access token, error: %v", err)
+	}
+	if accessToken == nil {
+		return nil, status.Errorf(codes.NotFound, "access token %q not found", request.Name)
+	}
+	if err := s.store.DeleteAccessToken(ctx, accessToken.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete access token, error: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// getAccessTokenUser returns the user owning the access tokens. The access tokens can be managed by
+// the user itself, and the workspace owner can also manage the access tokens of service accounts.
+// isAuthenticatedByAccessToken returns whether the request is authenticated by a personal access token.
+func isAuthenticatedByAccessToken(ctx context.Context) bool {
+	_, ok := ctx.Value(common.AccessTokenContextKey).(*store.AccessTokenMessage)
+	return ok
+}
+
+func (s *AuthService) getAccessTokenUser(ctx context.Context, userID int) (*store.UserMessage, error) {
+	principalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user, error: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user %d not found", userID)
+	}
+	role := ctx.Value(common.RoleContextKey).(api.Role)
+	if principalID != userID && (role != api.Owner || user.Type != api.ServiceAccount) {
+		return nil, status.Errorf(codes.PermissionDenied, "only the user itself, or the workspace owner for service accounts, can manage the access tokens of user %d", userID)
+	}
+	return user, nil
+}
+
+func (s *AuthService) convertToAccessToken(ctx context.Context, accessToken *store.AccessTokenMessage) (*v1pb.AccessToken, error) {
+	v := &v1pb.AccessToken{
+		Name:       fmt.Sprintf("%s%d/%s%d", userNamePrefix, accessToken.PrincipalUID, accessTokenNamePrefix, accessToken.ID),
+		Title:      accessToken.Title,
+		CreateTime: timestamppb.New(time.Unix(accessToken.CreatedTs, 0)),
+	}
+	for _, scope := range accessToken.ScopeList {
+		v.Scopes = append(v.Scopes, convertToAccessTokenScope(scope))
+	}
+	if accessToken.ProjectUID != nil {
+		project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{UID: accessToken.ProjectUID, ShowDeleted: true})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get project, error: %v", err)
+		}
+		if project == nil {
+			return nil, status.Errorf(codes.NotFound, "project %d not found", *accessToken.ProjectUID)
+		}
+		v.Project = fmt.Sprintf("%s%s", projectNamePrefix, project.ResourceID)
+	}
+	if accessToken.ExpireTs > 0 {
+		v.ExpireTime = timestamppb.New(time.Unix(accessToken.ExpireTs, 0))
+	}
+	if accessToken.LastUsedTs > 0 {
+		v.LastUsedTime = timestamppb.New(time.Unix(accessToken.LastUsedTs, 0))
+	}
+	return v, nil
+}
+
+func convertToAccessTokenScope(scope api.AccessTokenScope) v1pb.AccessTokenScope {
+	switch scope {
+	case api.AccessTokenScopeReadOnly:
+		return v1pb.AccessTokenScope_ACCESS_TOKEN_SCOPE_READ_ONLY
+	case api.AccessTokenScopeIssueCreate:
+		return v1pb.AccessTokenScope_ACCESS_TOKEN_SCOPE_ISSUE_CREATE
+	case api.AccessTokenScopeSQLQuery:
+		return v1pb.AccessTokenScope_ACCESS_TOKEN_SCOPE_SQL_QUERY
+	case api.AccessTokenScopeAdmin:
+		return v1pb.AccessTokenScope_ACCESS_TOKEN_SCOPE_ADMIN
+	}
+	return v1pb.AccessTokenScope_ACCESS_TOKEN_SCOPE_UNSPECIFIED
+}
+
+func convertAccessTokenScope(scope v1pb.AccessTokenScope) (api.AccessTokenScope, error) {
+	switch scope {
+	case v1pb.AccessTokenScope_ACCESS_TOKEN_SCOPE_READ_ONLY:
+		return api.AccessTokenScopeReadOnly, nil
+	case v1pb.AccessTokenScope_ACCESS_TOKEN_SCOPE_ISSUE_CREATE:
+		return api.AccessTokenScopeIssueCreate, nil
+	case v1pb.AccessTokenScope_ACCESS_TOKEN_SCOPE_SQL_QUERY:
+		return api.AccessTokenScopeSQLQuery, nil
+	case v1pb.AccessTokenScope_ACCESS_TOKEN_SCOPE_ADMIN:
+		return api.AccessTokenScopeAdmin, nil
+	}
+	return "", status.Errorf(codes.InvalidArgument, "invalid access token scope %s", scope)
+}
+
 func convertToUser(user *store.UserMessage) *v1pb.User {
 	role := v1pb.UserRole_USER_ROLE_UNSPECIFIED
 	switch user.Role {
@@ -644,6 +865,8 @@ func (s *AuthService) getOrCreateUserWithIDP(ctx context.Context, request *v1pb.
 
 	var userInfo *storepb.IdentityProviderUserInfo
 	var fieldMapping *storepb.FieldMapping
+	// ldapGroups is the DNs of the groups the LDAP user belongs to.
+	var ldapGroups []string
 	if idp.Type == storepb.IdentityProviderType_OAUTH2 {
 		oauth2Context := request.IdpContext.GetOauth2Context()
 		if oauth2Context == nil {
@@ -693,6 +916,20 @@ func (s *AuthService) getOrCreateUserWithIDP(ctx context.Context, request *v1pb.
 			return nil, status.Errorf(codes.Internal, "failed to get user info: %v", err)
 		}
 		fieldMapping = idp.Config.GetOidcConfig().FieldMapping
+	} else if idp.Type == storepb.IdentityProviderType_LDAP {
+		ldapIDP, err := newLDAPIdentityProvider(idp.Config.GetLdapConfig())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create new LDAP identity provider: %v", err)
+		}
+		// The LDAP user signs in with the username and password in the login form.
+		userInfo, ldapGroups, err = ldapIDP.Authenticate(request.Email, request.Password)
+		if err != nil {
+			if errors.Is(err, ldap.ErrInvalidCredentials) {
+				return nil, status.Errorf(codes.Unauthenticated, "incorrect username or password")
+			}
+			return nil, status.Errorf(codes.Internal, "failed to authenticate with LDAP: %v", err)
+		}
+		fieldMapping = idp.Config.GetLdapConfig().FieldMapping
 	} else {
 		return nil, status.Errorf(codes.InvalidArgument, "identity provider type %s not supported", idp.Type.String())
 	}
@@ -746,9 +983,35 @@ func (s *AuthService) getOrCreateUserWithIDP(ctx context.Context, request *v1pb.
 		user = users[0]
 	}
 
+	if mappings := idp.Config.GetLdapConfig().GetGroupRoleMappings(); len(mappings) > 0 && !user.MemberDeleted {
+		user, err = s.syncLDAPUserRole(ctx, user, mappings, ldapGroups)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to sync the workspace role from LDAP groups: %v", err)
+		}
+	}
+
 	return user, nil
 }
 
+// syncLDAPUserRole sets the workspace role of the user to the highest role mapped
+// from the LDAP groups, users out of all the mapped groups fall back to Developer.
+func (s *AuthService) syncLDAPUserRole(ctx context.Context, user *store.UserMessage, mappings []*storepb.LDAPGroupRoleMapping, groups []string) (*store.UserMessage, error) {
+	role := api.Developer
+	for _, mapping := range mappings {
+		mappingRole := api.Role(mapping.Role)
+		if !mappingRole.HigherThan(role) {
+			continue
+		}
+		for _, group := range groups {
+			if ldap.MatchGroup(group, mapping.Group) {
+				role = mappingRole
+				break
+			}
+		}
+	}
+	return s.store.UpdateUserWorkspaceRole(ctx, user, role, api.SystemBotID)
+}
+
 func challengeMFACode(user *store.UserMessage, mfaCode string) error {
 	if !validateWithCodeAndSecret(mfaCode, user.MFAConfig.OtpSecret) {
 		return status.Errorf(codes.Unauthenticated, "invalid MFA code")
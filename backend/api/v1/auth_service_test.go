@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestCreateAccessTokenWithAccessToken(t *testing.T) {
+	a := require.New(t)
+	projectUID := 101
+	ctx := context.WithValue(context.Background(), common.PrincipalIDContextKey, 101)
+	ctx = context.WithValue(ctx, common.RoleContextKey, api.Developer)
+	ctx = context.WithValue(ctx, common.AccessTokenContextKey, &store.AccessTokenMessage{
+		PrincipalUID: 101,
+		ScopeList:    []api.AccessTokenScope{api.AccessTokenScopeAdmin},
+		ProjectUID:   &projectUID,
+	})
+
+	_, err := (&AuthService{}).CreateAccessToken(ctx, &v1pb.CreateAccessTokenRequest{
+		Parent: "users/101",
+		AccessToken: &v1pb.AccessToken{
+			Title:  "unrestricted",
+			Scopes: []v1pb.AccessTokenScope{v1pb.AccessTokenScope_ACCESS_TOKEN_SCOPE_ADMIN},
+		},
+	})
+	a.Equal(codes.PermissionDenied, status.Code(err))
+}
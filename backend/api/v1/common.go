@@ -32,6 +32,7 @@ const (
 	reviewPrefix                 = "reviews/"
 	rolePrefix                   = "roles/"
 	secretNamePrefix             = "secrets/"
+	accessTokenNamePrefix        = "accessTokens/"
 
 	deploymentConfigSuffix = "/deploymentConfig"
 	backupSettingSuffix    = "/backupSetting"
@@ -174,6 +175,22 @@ func getUserBookmarkID(name string) (int, int, error) {
 	return userID, bookmarkID, nil
 }
 
+func getUserAccessTokenID(name string) (int, int, error) {
+	tokens, err := getNameParentTokens(name, userNamePrefix, accessTokenNamePrefix)
+	if err != nil {
+		return 0, 0, err
+	}
+	userID, err := strconv.Atoi(tokens[0])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid user ID %q", tokens[0])
+	}
+	accessTokenID, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid access token ID %q", tokens[1])
+	}
+	return userID, accessTokenID, nil
+}
+
 func getExternalVersionControlID(name string) (int, error) {
 	tokens, err := getNameParentTokens(name, externalVersionControlPrefix)
 	if err != nil {
@@ -12,6 +12,8 @@ import (
 	"github.com/pkg/errors"
 
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/idp/ldap"
 	"github.com/bytebase/bytebase/backend/plugin/idp/oauth2"
 	"github.com/bytebase/bytebase/backend/plugin/idp/oidc"
 	"github.com/bytebase/bytebase/backend/store"
@@ -139,6 +141,10 @@ func (s *IdentityProviderService) UpdateIdentityProvider(ctx context.Context, re
 			if request.IdentityProvider.Config.GetOidcConfig().ClientSecret == "" {
 				patch.Config.GetOidcConfig().ClientSecret = identityProvider.Config.GetOidcConfig().ClientSecret
 			}
+		} else if identityProvider.Type == storepb.IdentityProviderType_LDAP {
+			if request.IdentityProvider.Config.GetLdapConfig().BindPassword == "" {
+				patch.Config.GetLdapConfig().BindPassword = identityProvider.Config.GetLdapConfig().BindPassword
+			}
 		}
 	}
 
@@ -197,6 +203,28 @@ func (s *IdentityProviderService) TestIdentityProvider(ctx context.Context, requ
 		return nil, status.Errorf(codes.NotFound, "identity provider not found")
 	}
 
+	// LDAP authenticates with the username and password directly, so neither
+	// the OAuth2 context nor the external URL is needed.
+	if identityProvider.Type == v1pb.IdentityProviderType_LDAP {
+		// Find bind password for those existed identity providers.
+		if request.IdentityProvider.Config.GetLdapConfig().GetBindPassword() == "" && request.IdentityProvider.Name != "" {
+			storedIdentityProvider, err := s.getIdentityProviderMessage(ctx, request.IdentityProvider.Name)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to find identity provider, error: %s", err.Error())
+			}
+			request.IdentityProvider.Config.GetLdapConfig().BindPassword = storedIdentityProvider.Config.GetLdapConfig().BindPassword
+		}
+		identityProviderConfig := convertIdentityProviderConfigToStore(identityProvider.Config)
+		ldapIdentityProvider, err := newLDAPIdentityProvider(identityProviderConfig.GetLdapConfig())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to create new LDAP identity provider: %v", err)
+		}
+		if err := ldapIdentityProvider.Test(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to connect to the LDAP server, error: %s", err.Error())
+		}
+		return &v1pb.TestIdentityProviderResponse{}, nil
+	}
+
 	setting, err := s.store.GetWorkspaceGeneralSetting(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get workspace setting: %v", err)
@@ -346,6 +374,36 @@ func convertIdentityProviderConfigFromStore(identityProviderConfig *storepb.Iden
 				},
 			},
 		}
+	} else if v := identityProviderConfig.GetLdapConfig(); v != nil {
+		fieldMapping := v1pb.FieldMapping{
+			Identifier:  v.FieldMapping.Identifier,
+			DisplayName: v.FieldMapping.DisplayName,
+			Email:       v.FieldMapping.Email,
+		}
+		var groupRoleMappings []*v1pb.LDAPGroupRoleMapping
+		for _, mapping := range v.GroupRoleMappings {
+			groupRoleMappings = append(groupRoleMappings, &v1pb.LDAPGroupRoleMapping{
+				Group: mapping.Group,
+				Role:  mapping.Role,
+			})
+		}
+		return &v1pb.IdentityProviderConfig{
+			Config: &v1pb.IdentityProviderConfig_LdapConfig{
+				LdapConfig: &v1pb.LDAPIdentityProviderConfig{
+					Host:              v.Host,
+					Port:              v.Port,
+					SkipTlsVerify:     v.SkipTlsVerify,
+					BindDn:            v.BindDn,
+					BindPassword:      "", // SECURITY: We do not expose the bind password
+					BaseDn:            v.BaseDn,
+					UserFilter:        v.UserFilter,
+					SecurityProtocol:  v.SecurityProtocol,
+					FieldMapping:      &fieldMapping,
+					GroupAttribute:    v.GroupAttribute,
+					GroupRoleMappings: groupRoleMappings,
+				},
+			},
+		}
 	}
 	return nil
 }
@@ -386,6 +444,36 @@ func convertIdentityProviderConfigToStore(identityProviderConfig *v1pb.IdentityP
 				},
 			},
 		}
+	} else if v := identityProviderConfig.GetLdapConfig(); v != nil {
+		fieldMapping := storepb.FieldMapping{
+			Identifier:  v.FieldMapping.Identifier,
+			DisplayName: v.FieldMapping.DisplayName,
+			Email:       v.FieldMapping.Email,
+		}
+		var groupRoleMappings []*storepb.LDAPGroupRoleMapping
+		for _, mapping := range v.GroupRoleMappings {
+			groupRoleMappings = append(groupRoleMappings, &storepb.LDAPGroupRoleMapping{
+				Group: mapping.Group,
+				Role:  mapping.Role,
+			})
+		}
+		return &storepb.IdentityProviderConfig{
+			Config: &storepb.IdentityProviderConfig_LdapConfig{
+				LdapConfig: &storepb.LDAPIdentityProviderConfig{
+					Host:              v.Host,
+					Port:              v.Port,
+					SkipTlsVerify:     v.SkipTlsVerify,
+					BindDn:            v.BindDn,
+					BindPassword:      v.BindPassword,
+					BaseDn:            v.BaseDn,
+					UserFilter:        v.UserFilter,
+					SecurityProtocol:  v.SecurityProtocol,
+					FieldMapping:      &fieldMapping,
+					GroupAttribute:    v.GroupAttribute,
+					GroupRoleMappings: groupRoleMappings,
+				},
+			},
+		}
 	} else {
 		return nil
 	}
@@ -401,8 +489,42 @@ func validIdentityProviderConfig(identityProviderType v1pb.IdentityProviderType,
 		if identityProviderConfig.GetOidcConfig() == nil {
 			return errors.Errorf("unexpected provider config value")
 		}
+	} else if identityProviderType == v1pb.IdentityProviderType_LDAP {
+		config := identityProviderConfig.GetLdapConfig()
+		if config == nil {
+			return errors.Errorf("unexpected provider config value")
+		}
+		for _, mapping := range config.GroupRoleMappings {
+			if mapping.Group == "" {
+				return errors.Errorf("the group of the group role mapping is empty")
+			}
+			switch api.Role(mapping.Role) {
+			case api.Owner, api.DBA, api.Developer:
+			default:
+				return errors.Errorf("invalid role %q of the group role mapping for group %q", mapping.Role, mapping.Group)
+			}
+		}
 	} else {
 		return errors.Errorf("unexpected provider type %s", identityProviderType)
 	}
 	return nil
 }
+
+// newLDAPIdentityProvider creates the LDAP identity provider with the stored config.
+func newLDAPIdentityProvider(config *storepb.LDAPIdentityProviderConfig) (*ldap.IdentityProvider, error) {
+	if config == nil {
+		return nil, errors.New("missing LDAP config")
+	}
+	return ldap.NewIdentityProvider(ldap.IdentityProviderConfig{
+		Host:             config.Host,
+		Port:             int(config.Port),
+		SkipTLSVerify:    config.SkipTlsVerify,
+		BindDN:           config.BindDn,
+		BindPassword:     config.BindPassword,
+		BaseDN:           config.BaseDn,
+		UserFilter:       config.UserFilter,
+		SecurityProtocol: ldap.SecurityProtocol(config.SecurityProtocol),
+		FieldMapping:     config.FieldMapping,
+		GroupAttribute:   config.GroupAttribute,
+	})
+}
@@ -124,6 +124,9 @@ func (s *OrgPolicyService) UpdatePolicy(ctx context.Context, request *v1pb.Updat
 		case "inherit_from_parent":
 			patch.InheritFromParent = &request.Policy.InheritFromParent
 		case "payload":
+			if err := validatePolicyPayload(request.Policy); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid policy %v", err.Error())
+			}
 			payloadStr, err := convertPolicyPayloadToString(request.Policy)
 			if err != nil {
 				return nil, status.Errorf(codes.InvalidArgument, "invalid policy %v", err.Error())
@@ -311,6 +314,9 @@ func (s *OrgPolicyService) createPolicyMessage(ctx context.Context, creatorID in
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
 
+	if err := validatePolicyPayload(policy); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid policy %v", err.Error())
+	}
 	payloadStr, err := convertPolicyPayloadToString(policy)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid policy %v", err.Error())
@@ -337,6 +343,23 @@ func (s *OrgPolicyService) createPolicyMessage(ctx context.Context, creatorID in
 	return response, nil
 }
 
+// validatePolicyPayload validates the payload of the policy being created or updated.
+// It's kept out of the conversions, so that the stored policies saved before a validation was added can still be read and converted.
+func validatePolicyPayload(policy *v1pb.Policy) error {
+	if policy.Type == v1pb.PolicyType_SQL_REVIEW {
+		for _, rule := range policy.GetSqlReviewPolicy().GetRules() {
+			sqlReviewRule := &advisor.SQLReviewRule{
+				Type:    advisor.SQLReviewRuleType(rule.Type),
+				Payload: rule.Payload,
+			}
+			if err := sqlReviewRule.Validate(); err != nil {
+				return errors.Wrapf(err, "invalid SQL review rule %q", rule.Type)
+			}
+		}
+	}
+	return nil
+}
+
 func convertPolicyPayloadToString(policy *v1pb.Policy) (string, error) {
 	switch policy.Type {
 	case v1pb.PolicyType_DEPLOYMENT_APPROVAL:
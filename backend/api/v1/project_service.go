@@ -11,6 +11,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	webhookPlugin "github.com/bytebase/bytebase/backend/plugin/webhook"
 
@@ -324,6 +325,14 @@ func (s *ProjectService) UpdateDeploymentConfig(ctx context.Context, request *v1
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
+	// The stage gates are only configurable through the legacy API, keep them when the schedule is updated.
+	oldDeploymentConfig, err := s.store.GetDeploymentConfigV2(ctx, project.UID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if oldDeploymentConfig != nil {
+		copyDeploymentGates(oldDeploymentConfig.Schedule, storeDeploymentConfig.Schedule)
+	}
 
 	deploymentConfig, err := s.store.UpsertDeploymentConfigV2(ctx, project.UID, ctx.Value(common.PrincipalIDContextKey).(int), storeDeploymentConfig)
 	if err != nil {
@@ -525,6 +534,7 @@ func (s *ProjectService) TestWebhook(ctx context.Context, request *v1pb.TestWebh
 		webhook.Type,
 		webhookPlugin.Context{
 			URL:          webhook.URL,
+			Secret:       webhook.Secret,
 			Level:        webhookPlugin.WebhookInfo,
 			ActivityType: string(api.ActivityIssueCreate),
 			Title:        fmt.Sprintf("Test webhook %q", webhook.Title),
@@ -541,6 +551,122 @@ func (s *ProjectService) TestWebhook(ctx context.Context, request *v1pb.TestWebh
 	return &v1pb.TestWebhookResponse{Error: err.Error()}, nil
 }
 
+// ListWebhookDeliveries lists the latest deliveries of a webhook.
+func (s *ProjectService) ListWebhookDeliveries(ctx context.Context, request *v1pb.ListWebhookDeliveriesRequest) (*v1pb.ListWebhookDeliveriesResponse, error) {
+	webhook, err := s.getProjectWebhookMessage(ctx, request.Project, request.Webhook)
+	if err != nil {
+		return nil, err
+	}
+	if request.PageSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "page size must not be negative, got %d", request.PageSize)
+	}
+	limit := 50
+	if request.PageSize > 0 {
+		limit = int(request.PageSize)
+	}
+	deliveries, err := s.store.ListProjectWebhookDelivery(ctx, &store.FindProjectWebhookDeliveryMessage{
+		ProjectWebhookID: &webhook.ID,
+		Limit:            &limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	response := &v1pb.ListWebhookDeliveriesResponse{}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, convertToWebhookDelivery(delivery))
+	}
+	return response, nil
+}
+
+// RedeliverWebhook queues a new delivery with the payload of a previous delivery of a webhook.
+func (s *ProjectService) RedeliverWebhook(ctx context.Context, request *v1pb.RedeliverWebhookRequest) (*v1pb.WebhookDelivery, error) {
+	webhook, err := s.getProjectWebhookMessage(ctx, request.Project, request.Webhook)
+	if err != nil {
+		return nil, err
+	}
+	deliveryID := int(request.DeliveryId)
+	delivery, err := s.store.GetProjectWebhookDelivery(ctx, &store.FindProjectWebhookDeliveryMessage{
+		ID:               &deliveryID,
+		ProjectWebhookID: &webhook.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if delivery == nil {
+		return nil, status.Errorf(codes.NotFound, "delivery %d not found", request.DeliveryId)
+	}
+	if !webhook.Enabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "webhook %q is disabled, enable it before redelivering", webhook.Title)
+	}
+
+	// Redelivering queues a new delivery with the same payload, so that the history of the original one is kept.
+	redelivery, err := s.store.CreateProjectWebhookDelivery(ctx, &store.ProjectWebhookDeliveryMessage{
+		ProjectWebhookID: webhook.ID,
+		Status:           api.ProjectWebhookDeliveryPending,
+		NextAttemptTs:    time.Now().Unix(),
+		Payload:          delivery.Payload,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return convertToWebhookDelivery(redelivery), nil
+}
+
+// getProjectWebhookMessage gets the webhook identified by its url in the project, the error is a gRPC status error.
+func (s *ProjectService) getProjectWebhookMessage(ctx context.Context, projectName string, v1Webhook *v1pb.Webhook) (*store.ProjectWebhookMessage, error) {
+	projectID, err := getProjectID(projectName)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if v1Webhook == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "webhook must be set")
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{
+		ResourceID: &projectID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if project == nil {
+		return nil, status.Errorf(codes.NotFound, "project %q not found", projectName)
+	}
+	if project.Deleted {
+		return nil, status.Errorf(codes.InvalidArgument, "project %q has been deleted", projectName)
+	}
+
+	webhook, err := s.store.GetProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
+		ProjectID: &project.UID,
+		URL:       &v1Webhook.Url,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if webhook == nil {
+		return nil, status.Errorf(codes.NotFound, "webhook %q not found", v1Webhook.Url)
+	}
+	return webhook, nil
+}
+
+func convertToWebhookDelivery(delivery *store.ProjectWebhookDeliveryMessage) *v1pb.WebhookDelivery {
+	deliveryStatus := v1pb.WebhookDelivery_STATUS_UNSPECIFIED
+	switch delivery.Status {
+	case api.ProjectWebhookDeliveryPending:
+		deliveryStatus = v1pb.WebhookDelivery_PENDING
+	case api.ProjectWebhookDeliverySuccess:
+		deliveryStatus = v1pb.WebhookDelivery_SUCCESS
+	case api.ProjectWebhookDeliveryFailed:
+		deliveryStatus = v1pb.WebhookDelivery_FAILED
+	}
+	return &v1pb.WebhookDelivery{
+		Id:         int32(delivery.ID),
+		Status:     deliveryStatus,
+		Attempt:    int32(delivery.Attempt),
+		LastError:  delivery.LastError,
+		CreateTime: timestamppb.New(time.Unix(delivery.CreatedTs, 0)),
+		UpdateTime: timestamppb.New(time.Unix(delivery.UpdatedTs, 0)),
+	}
+}
+
 func convertToStoreProjectWebhookMessage(webhook *v1pb.Webhook) (*store.ProjectWebhookMessage, error) {
 	tp, err := convertToAPIWebhookTypeString(webhook.Type)
 	if err != nil {
@@ -678,6 +804,24 @@ func validateAndConvertToStoreDeploymentSchedule(deployment *v1pb.DeploymentConf
 	return convertToStoreDeploymentConfig(deployment)
 }
 
+// copyDeploymentGates copies the gates of the deployments in from to the deployments with the same name in to.
+func copyDeploymentGates(from *store.Schedule, to *store.Schedule) {
+	if from == nil || to == nil {
+		return
+	}
+	gates := make(map[string]*store.DeploymentGate)
+	for _, d := range from.Deployments {
+		if d.Spec != nil && d.Spec.Gate != nil {
+			gates[d.Name] = d.Spec.Gate
+		}
+	}
+	for _, d := range to.Deployments {
+		if gate, ok := gates[d.Name]; ok && d.Spec != nil {
+			d.Spec.Gate = gate
+		}
+	}
+}
+
 func (s *ProjectService) getProjectMessage(ctx context.Context, name string) (*store.ProjectMessage, error) {
 	projectID, err := getProjectID(name)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
@@ -368,3 +369,47 @@ func TestValidateAndConvertToStoreDeploymentSchedule(t *testing.T) {
 		}
 	}
 }
+
+func TestCopyDeploymentGates(t *testing.T) {
+	maxFailurePercent := 10.0
+	gate := &store.DeploymentGate{BakeTimeSeconds: 600, MaxFailurePercent: &maxFailurePercent}
+	from := &store.Schedule{
+		Deployments: []*store.Deployment{
+			{Name: "Staging", Spec: &store.DeploymentSpec{Selector: &store.LabelSelector{}, Gate: gate}},
+			{Name: "Prod", Spec: &store.DeploymentSpec{Selector: &store.LabelSelector{}}},
+		},
+	}
+	to := &store.Schedule{
+		Deployments: []*store.Deployment{
+			{Name: "Test", Spec: &store.DeploymentSpec{Selector: &store.LabelSelector{}}},
+			{Name: "Staging", Spec: &store.DeploymentSpec{Selector: &store.LabelSelector{}}},
+			{Name: "Prod", Spec: &store.DeploymentSpec{Selector: &store.LabelSelector{}}},
+		},
+	}
+	copyDeploymentGates(from, to)
+	require.Nil(t, to.Deployments[0].Spec.Gate)
+	require.Equal(t, gate, to.Deployments[1].Spec.Gate)
+	require.Nil(t, to.Deployments[2].Spec.Gate)
+
+	// A nil schedule is ignored.
+	copyDeploymentGates(nil, to)
+	require.Equal(t, gate, to.Deployments[1].Spec.Gate)
+}
+
+func TestConvertToWebhookDelivery(t *testing.T) {
+	a := require.New(t)
+	delivery := convertToWebhookDelivery(&store.ProjectWebhookDeliveryMessage{
+		ID:        101,
+		Status:    api.ProjectWebhookDeliveryFailed,
+		Attempt:   6,
+		LastError: "failed to POST webhook",
+		CreatedTs: 1682208000,
+		UpdatedTs: 1682211600,
+	})
+	a.Equal(int32(101), delivery.Id)
+	a.Equal(v1pb.WebhookDelivery_FAILED, delivery.Status)
+	a.Equal(int32(6), delivery.Attempt)
+	a.Equal("failed to POST webhook", delivery.LastError)
+	a.Equal(int64(1682208000), delivery.CreateTime.GetSeconds())
+	a.Equal(int64(1682211600), delivery.UpdateTime.GetSeconds())
+}
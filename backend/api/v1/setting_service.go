@@ -55,6 +55,7 @@ var whitelistSettings = []api.SettingName{
 	api.SettingPluginOpenAIEndpoint,
 	api.SettingWorkspaceApproval,
 	api.SettingWorkspaceMailDelivery,
+	api.SettingStageGateMetricAllowlist,
 }
 
 var (
@@ -216,6 +217,12 @@ func (s *SettingService) SetSetting(ctx context.Context, request *v1pb.SetSettin
 			return nil, status.Errorf(codes.Internal, "failed to marshal setting value: %v", err)
 		}
 		storeSettingValue = string(bytes)
+	case api.SettingStageGateMetricAllowlist:
+		settingValue := request.Setting.Value.GetStringValue()
+		if _, err := api.ParseStageGateMetricAllowlist(settingValue); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid stage gate metric allowlist: %v", err)
+		}
+		storeSettingValue = settingValue
 	default:
 		storeSettingValue = request.Setting.Value.GetStringValue()
 	}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/server"
 )
 
@@ -83,6 +84,19 @@ var (
 		backupRegion     string
 		backupBucket     string
 		backupCredential string
+		// backupStorageBackend is derived from the scheme of backupBucket.
+		backupStorageBackend api.BackupStorageBackend
+
+		// Backup codec configs.
+		backupCompressionFlag string
+		backupEncryptionFlag  bool
+		backupCompression     api.BackupCompression
+		backupEncryption      api.BackupEncryption
+
+		// backupVerification is the flag to verify backups by restoring them into scratch databases.
+		backupVerification bool
+		// backupPostgresWALArchive is the flag to archive WAL files and take base backups of PostgreSQL instances for PITR.
+		backupPostgresWALArchive bool
 	}
 
 	rootCmd = &cobra.Command{
@@ -128,9 +142,13 @@ func init() {
 
 	// Cloud backup related flags.
 	// TODO(dragonly): Add GCS usages when it's supported.
-	rootCmd.PersistentFlags().StringVar(&flags.backupBucket, "backup-bucket", "", "bucket where Bytebase stores backup data, e.g., s3://example-bucket. When provided, Bytebase will store data to the S3 bucket.")
+	rootCmd.PersistentFlags().StringVar(&flags.backupBucket, "backup-bucket", "", "bucket where Bytebase stores backup data, e.g., s3://example-bucket, azblob://example-container or file:///mnt/nfs/backup. When provided, Bytebase will store data to the bucket.")
 	rootCmd.PersistentFlags().StringVar(&flags.backupRegion, "backup-region", "", "region of the backup bucket, e.g., us-west-2 for AWS S3.")
-	rootCmd.PersistentFlags().StringVar(&flags.backupCredential, "backup-credential", "", "credentials file to use for the backup bucket. It should be the same format as the AWS/GCP credential files.")
+	rootCmd.PersistentFlags().StringVar(&flags.backupCompressionFlag, "backup-compression", "", "compression algorithm of the backup files, either gzip or zstd. Empty means not compressed.")
+	rootCmd.PersistentFlags().BoolVar(&flags.backupEncryptionFlag, "backup-encryption", false, "whether to encrypt the backup files with a key derived from the workspace secret.")
+	rootCmd.PersistentFlags().BoolVar(&flags.backupVerification, "backup-verification", false, "whether to verify the latest backup of each database by restoring it into a scratch database on the same instance.")
+	rootCmd.PersistentFlags().BoolVar(&flags.backupPostgresWALArchive, "backup-pg-wal-archive", false, "whether to archive WAL files and take base backups of PostgreSQL instances with backup enabled, which is required by PostgreSQL PITR.")
+	rootCmd.PersistentFlags().StringVar(&flags.backupCredential, "backup-credential", "", "credentials file to use for the backup bucket. It should be the same format as the AWS/GCP credential files. For Azure Blob Storage, it is a JSON file with accountName, accountKey and optional serviceURL.")
 }
 
 // -----------------------------------Command Line Config END--------------------------------------
@@ -156,18 +174,52 @@ func checkDataDir() error {
 }
 
 func checkCloudBackupFlags() error {
+	flags.backupStorageBackend = api.BackupStorageBackendLocal
 	if flags.backupBucket == "" {
 		return nil
 	}
-	if !strings.HasPrefix(flags.backupBucket, "s3://") {
-		return errors.Errorf("only support bucket URI starting with s3://")
+	switch {
+	case strings.HasPrefix(flags.backupBucket, "s3://"):
+		flags.backupBucket = strings.TrimPrefix(flags.backupBucket, "s3://")
+		flags.backupStorageBackend = api.BackupStorageBackendS3
+		if flags.backupCredential == "" {
+			return errors.Errorf("must specify --backup-credential when --backup-bucket is present")
+		}
+		if flags.backupRegion == "" {
+			return errors.Errorf("must specify --backup-region for AWS S3 backup")
+		}
+	case strings.HasPrefix(flags.backupBucket, "azblob://"):
+		flags.backupBucket = strings.TrimPrefix(flags.backupBucket, "azblob://")
+		flags.backupStorageBackend = api.BackupStorageBackendAzure
+		if flags.backupCredential == "" {
+			return errors.Errorf("must specify --backup-credential when --backup-bucket is present")
+		}
+	case strings.HasPrefix(flags.backupBucket, "file://"):
+		flags.backupBucket = strings.TrimPrefix(flags.backupBucket, "file://")
+		flags.backupStorageBackend = api.BackupStorageBackendFilesystem
+		if !filepath.IsAbs(flags.backupBucket) {
+			return errors.Errorf("the backup directory %q must be an absolute path, e.g., file:///mnt/nfs/backup", flags.backupBucket)
+		}
+	default:
+		return errors.Errorf("only support bucket URI starting with s3://, azblob:// or file://")
 	}
-	flags.backupBucket = strings.TrimPrefix(flags.backupBucket, "s3://")
-	if flags.backupCredential == "" {
-		return errors.Errorf("must specify --backup-credential when --backup-bucket is present")
+	return nil
+}
+
+func checkBackupCodecFlags() error {
+	switch strings.ToLower(flags.backupCompressionFlag) {
+	case "":
+		flags.backupCompression = api.BackupCompressionNone
+	case "gzip":
+		flags.backupCompression = api.BackupCompressionGzip
+	case "zstd":
+		flags.backupCompression = api.BackupCompressionZstd
+	default:
+		return errors.Errorf("unsupported --backup-compression %q, should be gzip or zstd", flags.backupCompressionFlag)
 	}
-	if flags.backupRegion == "" {
-		return errors.Errorf("must specify --backup-region for AWS S3 backup")
+	flags.backupEncryption = api.BackupEncryptionNone
+	if flags.backupEncryptionFlag {
+		flags.backupEncryption = api.BackupEncryptionAES256GCM
 	}
 	return nil
 }
@@ -206,6 +258,11 @@ func start() {
 		return
 	}
 
+	if err := checkBackupCodecFlags(); err != nil {
+		log.Error("invalid flags for backup codec", zap.Error(err))
+		return
+	}
+
 	profile := activeProfile(flags.dataDir)
 
 	// The ideal bootstrap order is:
@@ -24,6 +24,8 @@ import (
 	_ "github.com/bytebase/bytebase/backend/plugin/advisor/mysql"
 	// Register postgresql advisor.
 	_ "github.com/bytebase/bytebase/backend/plugin/advisor/pg"
+	// Register standard SQL advisor for Oracle and SQL Server.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/standard"
 
 	// Register postgres parser driver.
 	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/engine/pg"
@@ -11,7 +11,6 @@ import (
 
 	"github.com/gosimple/slug"
 
-	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/webhook"
@@ -81,6 +80,12 @@ func (m *Manager) BatchCreateTaskStatusUpdateApprovalActivity(ctx context.Contex
 	if err != nil {
 		return errors.Wrapf(err, "failed to find project webhook after changing the issue status: %v", issue.Title)
 	}
+	webhookList, err = filterWebhookList(webhookList, func() (*webhookEvent, error) {
+		return m.getWebhookEvent(ctx, issue, taskList, api.TaskPending)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to filter project webhook after changing the issue status: %v", issue.Title)
+	}
 	if len(webhookList) == 0 {
 		return nil
 	}
@@ -112,8 +117,8 @@ func (m *Manager) BatchCreateTaskStatusUpdateApprovalActivity(ctx context.Contex
 		CreatorName:  anyActivity.Creator.Name,
 		CreatorEmail: anyActivity.Creator.Email,
 	}
-	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(webhookCtx, webhookList)
+	// The webhook deliveries are queued and posted by the webhook delivery runner to avoid blocking web serving thread.
+	m.createWebhookDeliveryList(ctx, webhookCtx, webhookList)
 
 	return nil
 }
@@ -145,6 +150,15 @@ func (m *Manager) CreateActivity(ctx context.Context, create *api.ActivityCreate
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find project webhook after changing the issue status: %v", meta.Issue.Title)
 	}
+	webhookList, err = filterWebhookList(webhookList, func() (*webhookEvent, error) {
+		return m.getActivityWebhookEvent(ctx, activity, meta.Issue)
+	})
+	if err != nil {
+		log.Warn("Failed to filter project webhooks",
+			zap.String("issue_name", meta.Issue.Title),
+			zap.Error(err))
+		return activity, nil
+	}
 	if len(webhookList) == 0 {
 		return activity, nil
 	}
@@ -164,27 +178,38 @@ func (m *Manager) CreateActivity(ctx context.Context, create *api.ActivityCreate
 			zap.Error(err))
 		return activity, nil
 	}
-	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(webhookCtx, webhookList)
+	// The webhook deliveries are queued and posted by the webhook delivery runner to avoid blocking web serving thread.
+	m.createWebhookDeliveryList(ctx, webhookCtx, webhookList)
 
 	return activity, nil
 }
 
-func postWebhookList(webhookCtx webhook.Context, webhookList []*store.ProjectWebhookMessage) {
+func (m *Manager) createWebhookDeliveryList(ctx context.Context, webhookCtx webhook.Context, webhookList []*store.ProjectWebhookMessage) {
+	webhookCtx.CreatedTs = time.Now().Unix()
+	payload, err := json.Marshal(webhookCtx)
+	if err != nil {
+		log.Warn("Failed to marshal webhook context",
+			zap.String("activity type", webhookCtx.ActivityType),
+			zap.String("title", webhookCtx.Title),
+			zap.Error(err))
+		return
+	}
 	for _, hook := range webhookList {
-		webhookCtx.URL = hook.URL
-		webhookCtx.CreatedTs = time.Now().Unix()
-		if err := common.Retry(func() error {
-			return webhook.Post(hook.Type, webhookCtx)
+		if !hook.Enabled {
+			continue
+		}
+		if _, err := m.store.CreateProjectWebhookDelivery(ctx, &store.ProjectWebhookDeliveryMessage{
+			ProjectWebhookID: hook.ID,
+			Status:           api.ProjectWebhookDeliveryPending,
+			NextAttemptTs:    webhookCtx.CreatedTs,
+			Payload:          string(payload),
 		}); err != nil {
-			// The external webhook endpoint might be invalid which is out of our code control, so we just emit a warning
-			log.Warn("Failed to post webhook event on activity",
+			log.Warn("Failed to create webhook delivery on activity",
 				zap.String("webhook type", hook.Type),
 				zap.String("webhook name", hook.Title),
 				zap.String("activity type", webhookCtx.ActivityType),
 				zap.String("title", webhookCtx.Title),
 				zap.Error(err))
-			return
 		}
 	}
 }
@@ -313,6 +338,10 @@ func (m *Manager) getWebhookContext(ctx context.Context, activity *api.Activity,
 			title = fmt.Sprintf("Stage begins - %s", payload.StageName)
 		case api.StageStatusUpdateTypeEnd:
 			title = fmt.Sprintf("Stage ends - %s", payload.StageName)
+		case api.StageStatusUpdateTypePause:
+			title = fmt.Sprintf("Rollout paused by stage gate - %s", payload.StageName)
+		case api.StageStatusUpdateTypeResume:
+			title = fmt.Sprintf("Rollout resumed - %s", payload.StageName)
 		}
 
 	case api.ActivityPipelineTaskStatusUpdate:
@@ -477,7 +506,14 @@ func shouldPostInbox(activity *api.Activity, createType api.ActivityType) (bool,
 	case api.ActivityPipelineTaskEarliestAllowedTimeUpdate:
 		return true, nil
 	case api.ActivityPipelineStageStatusUpdate:
-		return false, nil
+		update := new(api.ActivityPipelineStageStatusUpdatePayload)
+		if err := json.Unmarshal([]byte(activity.Payload), update); err != nil {
+			return false, err
+		}
+		// Only post to inbox when the rollout is paused by the stage gate, which needs attention.
+		if update.StageStatusUpdateType == api.StageStatusUpdateTypePause {
+			return true, nil
+		}
 	case api.ActivityPipelineTaskStatusUpdate:
 		update := new(api.ActivityPipelineTaskStatusUpdatePayload)
 		if err := json.Unmarshal([]byte(activity.Payload), update); err != nil {
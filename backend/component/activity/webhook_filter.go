@@ -0,0 +1,162 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+// webhookEvent is the attributes of an activity matched against the project webhook filters.
+type webhookEvent struct {
+	// environmentIDs is the set of the environment resource IDs of the tasks.
+	environmentIDs map[string]bool
+	// databaseLabels is the set of the labels of the task databases in the format of "key=value".
+	databaseLabels map[string]bool
+	issueType      api.IssueType
+	// taskStatus is the new task status of the task status update event, it's empty for the other events.
+	taskStatus api.TaskStatus
+	riskLevel  int64
+}
+
+// match returns true if the event matches all the conditions of the filter.
+func (e *webhookEvent) match(filter *api.ProjectWebhookFilter) bool {
+	if len(filter.EnvironmentList) > 0 && !containsAny(e.environmentIDs, filter.EnvironmentList) {
+		return false
+	}
+	if len(filter.DatabaseLabelList) > 0 && !containsAny(e.databaseLabels, filter.DatabaseLabelList) {
+		return false
+	}
+	if len(filter.IssueTypeList) > 0 {
+		matched := false
+		for _, issueType := range filter.IssueTypeList {
+			if issueType == e.issueType {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(filter.TaskStatusList) > 0 && e.taskStatus != "" {
+		matched := false
+		for _, taskStatus := range filter.TaskStatusList {
+			if taskStatus == e.taskStatus {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return e.riskLevel >= filter.MinRiskLevel
+}
+
+func containsAny(set map[string]bool, list []string) bool {
+	for _, v := range list {
+		if set[v] {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmptyWebhookFilter(filter *api.ProjectWebhookFilter) bool {
+	return filter == nil || (len(filter.EnvironmentList) == 0 &&
+		len(filter.DatabaseLabelList) == 0 &&
+		len(filter.IssueTypeList) == 0 &&
+		len(filter.TaskStatusList) == 0 &&
+		filter.MinRiskLevel == 0)
+}
+
+// filterWebhookList returns the webhooks whose filters match the event.
+// The event is only built if any of the webhooks has a filter, because it needs to load the tasks and databases.
+func filterWebhookList(webhookList []*store.ProjectWebhookMessage, getEvent func() (*webhookEvent, error)) ([]*store.ProjectWebhookMessage, error) {
+	var event *webhookEvent
+	var filtered []*store.ProjectWebhookMessage
+	for _, hook := range webhookList {
+		if isEmptyWebhookFilter(hook.Filter) {
+			filtered = append(filtered, hook)
+			continue
+		}
+		if event == nil {
+			e, err := getEvent()
+			if err != nil {
+				return nil, err
+			}
+			event = e
+		}
+		if event.match(hook.Filter) {
+			filtered = append(filtered, hook)
+		}
+	}
+	return filtered, nil
+}
+
+// getWebhookEvent builds the webhook event of the issue activity on the tasks.
+func (m *Manager) getWebhookEvent(ctx context.Context, issue *store.IssueMessage, taskList []*store.TaskMessage, taskStatus api.TaskStatus) (*webhookEvent, error) {
+	event := &webhookEvent{
+		environmentIDs: make(map[string]bool),
+		databaseLabels: make(map[string]bool),
+		issueType:      issue.Type,
+		taskStatus:     taskStatus,
+		riskLevel:      issue.RiskLevel,
+	}
+	for _, task := range taskList {
+		if task.DatabaseID != nil {
+			database, err := m.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID, ShowDeleted: true})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get database %d", *task.DatabaseID)
+			}
+			if database != nil {
+				event.environmentIDs[database.EnvironmentID] = true
+				for key, value := range database.Labels {
+					event.databaseLabels[fmt.Sprintf("%s=%s", key, value)] = true
+				}
+				continue
+			}
+		}
+		instance, err := m.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID, ShowDeleted: true})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get instance %d", task.InstanceID)
+		}
+		if instance != nil {
+			event.environmentIDs[instance.EnvironmentID] = true
+		}
+	}
+	return event, nil
+}
+
+// getActivityWebhookEvent builds the webhook event of the issue activity.
+// The task status update and stage status update events are on the task or the stage tasks, and the others are on all the issue tasks.
+func (m *Manager) getActivityWebhookEvent(ctx context.Context, activity *api.Activity, issue *store.IssueMessage) (*webhookEvent, error) {
+	var taskStatus api.TaskStatus
+	taskFind := &api.TaskFind{PipelineID: &issue.PipelineUID}
+	switch activity.Type {
+	case api.ActivityPipelineTaskStatusUpdate:
+		payload := &api.ActivityPipelineTaskStatusUpdatePayload{}
+		if err := json.Unmarshal([]byte(activity.Payload), payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal activity payload")
+		}
+		taskStatus = payload.NewStatus
+		taskFind = &api.TaskFind{ID: &payload.TaskID}
+	case api.ActivityPipelineStageStatusUpdate:
+		payload := &api.ActivityPipelineStageStatusUpdatePayload{}
+		if err := json.Unmarshal([]byte(activity.Payload), payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal activity payload")
+		}
+		taskFind = &api.TaskFind{StageID: &payload.StageID}
+	}
+	taskFind.StripPayload = true
+	taskList, err := m.store.ListTasks(ctx, taskFind)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list tasks")
+	}
+	return m.getWebhookEvent(ctx, issue, taskList, taskStatus)
+}
@@ -0,0 +1,91 @@
+package activity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func TestWebhookEventMatch(t *testing.T) {
+	event := &webhookEvent{
+		environmentIDs: map[string]bool{"prod": true},
+		databaseLabels: map[string]bool{"tenant=bytebase": true, "bb.environment=prod": true},
+		issueType:      api.IssueDatabaseSchemaUpdate,
+		taskStatus:     api.TaskFailed,
+		riskLevel:      300,
+	}
+	tests := []struct {
+		filter *api.ProjectWebhookFilter
+		want   bool
+	}{
+		{
+			filter: &api.ProjectWebhookFilter{},
+			want:   true,
+		},
+		{
+			filter: &api.ProjectWebhookFilter{EnvironmentList: []string{"test", "prod"}},
+			want:   true,
+		},
+		{
+			filter: &api.ProjectWebhookFilter{EnvironmentList: []string{"test"}},
+			want:   false,
+		},
+		{
+			filter: &api.ProjectWebhookFilter{DatabaseLabelList: []string{"tenant=bytebase"}},
+			want:   true,
+		},
+		{
+			filter: &api.ProjectWebhookFilter{DatabaseLabelList: []string{"tenant=other"}},
+			want:   false,
+		},
+		{
+			filter: &api.ProjectWebhookFilter{IssueTypeList: []api.IssueType{api.IssueDatabaseDataUpdate}},
+			want:   false,
+		},
+		{
+			filter: &api.ProjectWebhookFilter{TaskStatusList: []api.TaskStatus{api.TaskFailed}, MinRiskLevel: 300},
+			want:   true,
+		},
+		{
+			filter: &api.ProjectWebhookFilter{TaskStatusList: []api.TaskStatus{api.TaskDone}},
+			want:   false,
+		},
+		{
+			filter: &api.ProjectWebhookFilter{MinRiskLevel: 400},
+			want:   false,
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, event.match(test.filter), "%+v", test.filter)
+	}
+
+	// The task status filter doesn't apply to the events other than task status update.
+	event.taskStatus = ""
+	require.True(t, event.match(&api.ProjectWebhookFilter{TaskStatusList: []api.TaskStatus{api.TaskDone}}))
+}
+
+func TestFilterWebhookList(t *testing.T) {
+	a := require.New(t)
+	noFilter := &store.ProjectWebhookMessage{ID: 1, Filter: &api.ProjectWebhookFilter{}}
+	prodOnly := &store.ProjectWebhookMessage{ID: 2, Filter: &api.ProjectWebhookFilter{EnvironmentList: []string{"prod"}}}
+	testOnly := &store.ProjectWebhookMessage{ID: 3, Filter: &api.ProjectWebhookFilter{EnvironmentList: []string{"test"}}}
+
+	calls := 0
+	getEvent := func() (*webhookEvent, error) {
+		calls++
+		return &webhookEvent{environmentIDs: map[string]bool{"prod": true}}, nil
+	}
+	got, err := filterWebhookList([]*store.ProjectWebhookMessage{noFilter, prodOnly, testOnly}, getEvent)
+	a.NoError(err)
+	a.Equal([]*store.ProjectWebhookMessage{noFilter, prodOnly}, got)
+	a.Equal(1, calls)
+
+	// The event isn't built without filters.
+	got, err = filterWebhookList([]*store.ProjectWebhookMessage{noFilter}, getEvent)
+	a.NoError(err)
+	a.Equal([]*store.ProjectWebhookMessage{noFilter}, got)
+	a.Equal(1, calls)
+}
@@ -50,6 +50,14 @@ type Profile struct {
 	BackupRegion         string
 	BackupBucket         string
 	BackupCredentialFile string
+	// BackupCompression is the compression algorithm of new backup files.
+	BackupCompression api.BackupCompression
+	// BackupEncryption is the encryption algorithm of new backup files.
+	BackupEncryption api.BackupEncryption
+	// BackupVerification decides whether to verify backups by restoring them into scratch databases.
+	BackupVerification bool
+	// BackupPostgresWALArchive decides whether to archive WAL files and take base backups of PostgreSQL instances for PITR.
+	BackupPostgresWALArchive bool
 
 	// IM integration related fields
 	// FeishuAPIURL is the URL of Feishu API server.
@@ -16,6 +16,8 @@ type State struct {
 	InstanceDatabaseSyncChan chan *api.Instance
 	// InstanceSlowQuerySyncChan is the channel for synchronizing slow query logs for instances.
 	InstanceSlowQuerySyncChan chan *api.Instance
+	// SensitiveColumnScanChan is the channel for classifying the sensitive columns of databases.
+	SensitiveColumnScanChan chan *SensitiveColumnScan
 
 	// RollbackGenerate is the set of tasks for generating rollback statements.
 	RollbackGenerate sync.Map // map[task.ID]*store.TaskMessage
@@ -38,8 +40,25 @@ type State struct {
 	RunningTasks sync.Map // map[taskID]bool
 	// RunningTasksCancel is the cancel's of running tasks.
 	RunningTasksCancel sync.Map // map[taskID]context.CancelFunc
+	// RunningQueries is the set of in-flight SQL editor queries.
+	RunningQueries sync.Map // map[queryID]*RunningQuery
 	// InstanceOutstandingConnections is the maximum number of connections per instance.
 	InstanceOutstandingConnections map[int]int
 
 	sync.Mutex
 }
+
+// RunningQuery is an in-flight SQL editor query.
+type RunningQuery struct {
+	Query *api.SQLRunningQuery
+	// Cancel cancels the query, the statement running on the database server is cancelled as well.
+	Cancel func()
+}
+
+// SensitiveColumnScan is a request to classify the sensitive columns of a database.
+type SensitiveColumnScan struct {
+	DatabaseUID int
+	// Tables is the set of tables to scan in "schema.table" format, the schema is empty for MySQL.
+	// All tables are scanned if it's nil.
+	Tables map[string]bool
+}
@@ -0,0 +1,48 @@
+package api
+
+// AccessGrantStatus is the status of an access grant.
+type AccessGrantStatus string
+
+const (
+	// AccessGrantPending is the access grant status for PENDING, the request issue is waiting for approval.
+	AccessGrantPending AccessGrantStatus = "PENDING"
+	// AccessGrantActive is the access grant status for ACTIVE, the principal can query the database until it expires.
+	AccessGrantActive AccessGrantStatus = "ACTIVE"
+	// AccessGrantExpired is the access grant status for EXPIRED.
+	AccessGrantExpired AccessGrantStatus = "EXPIRED"
+	// AccessGrantRevoked is the access grant status for REVOKED, the grant is revoked before it expires or the request issue is canceled.
+	AccessGrantRevoked AccessGrantStatus = "REVOKED"
+)
+
+const (
+	// AccessGrantMaxDurationSeconds is the max duration of an access grant.
+	AccessGrantMaxDurationSeconds = 7 * 24 * 60 * 60
+)
+
+// AccessGrant is the API message for a time-boxed access to query a database granted by a query access issue.
+type AccessGrant struct {
+	ID int `jsonapi:"primary,accessGrant"`
+
+	// Standard fields
+	CreatedTs int64 `jsonapi:"attr,createdTs"`
+	UpdatedTs int64 `jsonapi:"attr,updatedTs"`
+
+	// Related fields
+	IssueID     int `jsonapi:"attr,issueId"`
+	PrincipalID int `jsonapi:"attr,principalId"`
+	DatabaseID  int `jsonapi:"attr,databaseId"`
+
+	// Domain specific fields
+	TableList       []string          `jsonapi:"attr,tableList"`
+	DurationSeconds int64             `jsonapi:"attr,durationSeconds"`
+	ExpireTs        int64             `jsonapi:"attr,expireTs"`
+	Status          AccessGrantStatus `jsonapi:"attr,status"`
+}
+
+// AccessGrantPatch is the API message for revoking an access grant.
+type AccessGrantPatch struct {
+	ID int `jsonapi:"primary,accessGrantPatch"`
+
+	// Domain specific fields
+	Status *AccessGrantStatus `jsonapi:"attr,status"`
+}
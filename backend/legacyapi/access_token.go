@@ -0,0 +1,16 @@
+package api
+
+// AccessTokenScope is the scope of a personal access token.
+// Every scope allows the read-only operations, and the other scopes allow the extra operations on top of that.
+type AccessTokenScope string
+
+const (
+	// AccessTokenScopeReadOnly is the scope allowing the read-only operations.
+	AccessTokenScopeReadOnly AccessTokenScope = "READ_ONLY"
+	// AccessTokenScopeIssueCreate is the scope allowing to create issues and the sheets used by the issues.
+	AccessTokenScopeIssueCreate AccessTokenScope = "ISSUE_CREATE"
+	// AccessTokenScopeSQLQuery is the scope allowing to run queries in the SQL editor.
+	AccessTokenScopeSQLQuery AccessTokenScope = "SQL_QUERY"
+	// AccessTokenScopeAdmin is the scope allowing all the operations the principal is permitted to.
+	AccessTokenScopeAdmin AccessTokenScope = "ADMIN"
+)
@@ -60,6 +60,8 @@ const (
 
 	// ActivitySQLEditorQuery is the type for executing query.
 	ActivitySQLEditorQuery ActivityType = "bb.sql-editor.query"
+	// ActivitySQLEditorExport is the type for exporting query result.
+	ActivitySQLEditorExport ActivityType = "bb.sql-editor.export"
 
 	// Database related.
 
@@ -240,6 +242,19 @@ type ActivitySQLEditorQueryPayload struct {
 	AdviceList             []advisor.Advice `json:"adviceList"`
 }
 
+// ActivitySQLEditorExportPayload is the API message payloads for the exported query info.
+type ActivitySQLEditorExportPayload struct {
+	// Used by activity table to display info without paying the join cost
+	Statement    string          `json:"statement"`
+	Format       SQLExportFormat `json:"format"`
+	RowCount     int64           `json:"rowCount"`
+	DurationNs   int64           `json:"durationNs"`
+	InstanceID   int             `json:"instanceId"`
+	DatabaseID   int             `json:"databaseId"`
+	DatabaseName string          `json:"databaseName"`
+	Error        string          `json:"error"`
+}
+
 // Activity is the API message for an activity.
 type Activity struct {
 	ID int `jsonapi:"primary,activity"`
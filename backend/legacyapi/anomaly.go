@@ -12,10 +12,14 @@ const (
 	AnomalyDatabaseBackupPolicyViolation AnomalyType = "bb.anomaly.database.backup.policy-violation"
 	// AnomalyDatabaseBackupMissing is the anomaly type for missing backups.
 	AnomalyDatabaseBackupMissing AnomalyType = "bb.anomaly.database.backup.missing"
+	// AnomalyDatabaseBackupUnrestorable is the anomaly type for backups failing the restore verification.
+	AnomalyDatabaseBackupUnrestorable AnomalyType = "bb.anomaly.database.backup.unrestorable"
 	// AnomalyDatabaseConnection is the anomaly type for database connections.
 	AnomalyDatabaseConnection AnomalyType = "bb.anomaly.database.connection"
 	// AnomalyDatabaseSchemaDrift is the anomaly type for database schema drifts.
 	AnomalyDatabaseSchemaDrift AnomalyType = "bb.anomaly.database.schema.drift"
+	// AnomalyDatabaseTableGrowth is the anomaly type for tables growing faster than or approaching the limits of the table growth policy.
+	AnomalyDatabaseTableGrowth AnomalyType = "bb.anomaly.database.table.growth"
 )
 
 // AnomalySeverity is the severity of anomaly.
@@ -37,6 +41,10 @@ func AnomalySeverityFromType(anomalyType AnomalyType) AnomalySeverity {
 		return AnomalySeverityMedium
 	case AnomalyDatabaseBackupMissing:
 		return AnomalySeverityHigh
+	case AnomalyDatabaseBackupUnrestorable:
+		return AnomalySeverityHigh
+	case AnomalyDatabaseTableGrowth:
+		return AnomalySeverityMedium
 	case AnomalyInstanceConnection:
 	case AnomalyInstanceMigrationSchema:
 	case AnomalyDatabaseConnection:
@@ -66,6 +74,16 @@ type AnomalyDatabaseBackupMissingPayload struct {
 	LastBackupTs int64 `json:"lastBackupTs,omitempty"`
 }
 
+// AnomalyDatabaseBackupUnrestorablePayload is the API message for unrestorable backup payloads.
+type AnomalyDatabaseBackupUnrestorablePayload struct {
+	BackupID   int    `json:"backupId,omitempty"`
+	BackupName string `json:"backupName,omitempty"`
+	// Time of the verification
+	VerifiedTs int64 `json:"verifiedTs,omitempty"`
+	// Verification failure detail
+	Detail string `json:"detail,omitempty"`
+}
+
 // AnomalyDatabaseConnectionPayload is the API message for database connection payloads.
 type AnomalyDatabaseConnectionPayload struct {
 	// Connection failure detail
@@ -82,6 +100,25 @@ type AnomalyDatabaseSchemaDriftPayload struct {
 	Actual string `json:"actual,omitempty"`
 }
 
+// AnomalyDatabaseTableGrowthPayload is the API message for table growth payloads.
+type AnomalyDatabaseTableGrowthPayload struct {
+	// The limits of the table growth policy
+	MaxDailyGrowthPercent float64 `json:"maxDailyGrowthPercent,omitempty"`
+	MaxTableSize          int64   `json:"maxTableSize,omitempty"`
+	// The tables violating the policy
+	TableList []*AnomalyTableGrowth `json:"tableList,omitempty"`
+}
+
+// AnomalyTableGrowth is the API message for the growth of a table.
+type AnomalyTableGrowth struct {
+	Schema string `json:"schema,omitempty"`
+	Table  string `json:"table,omitempty"`
+	// Size is the sum of the data and index sizes in bytes.
+	Size int64 `json:"size,omitempty"`
+	// DailyGrowthPercent is the average growth of the size per day in percent over the observed period.
+	DailyGrowthPercent float64 `json:"dailyGrowthPercent,omitempty"`
+}
+
 // Anomaly is the API message for an anomaly.
 type Anomaly struct {
 	ID int `jsonapi:"primary,anomaly"`
@@ -35,12 +35,51 @@ type BackupStorageBackend string
 const (
 	// BackupStorageBackendLocal is the local storage backend for a backup.
 	BackupStorageBackendLocal BackupStorageBackend = "LOCAL"
-	// BackupStorageBackendS3 is the AWS S3 storage backend for a backup. Not used yet.
+	// BackupStorageBackendS3 is the AWS S3 storage backend for a backup.
 	BackupStorageBackendS3 BackupStorageBackend = "S3"
 	// BackupStorageBackendGCS is the Google Cloud Storage (GCS) storage backend for a backup. Not used yet.
 	BackupStorageBackendGCS BackupStorageBackend = "GCS"
 	// BackupStorageBackendOSS is the AliCloud Object Storage Service (OSS) storage backend for a backup. Not used yet.
 	BackupStorageBackendOSS BackupStorageBackend = "OSS"
+	// BackupStorageBackendAzure is the Azure Blob Storage storage backend for a backup.
+	BackupStorageBackendAzure BackupStorageBackend = "AZURE"
+	// BackupStorageBackendFilesystem is the storage backend on a mounted file system, such as an NFS share, for a backup.
+	BackupStorageBackendFilesystem BackupStorageBackend = "FILESYSTEM"
+)
+
+// BackupVerificationStatus is the result of verifying a backup by restoring it into a scratch database.
+type BackupVerificationStatus string
+
+const (
+	// BackupVerificationUnverified is the status for UNVERIFIED, the backup is not verified yet.
+	BackupVerificationUnverified BackupVerificationStatus = "UNVERIFIED"
+	// BackupVerificationPassed is the status for PASSED, the backup is restored and passes the sanity checks.
+	BackupVerificationPassed BackupVerificationStatus = "PASSED"
+	// BackupVerificationFailed is the status for FAILED, the detail of the failure is recorded along with the status.
+	BackupVerificationFailed BackupVerificationStatus = "FAILED"
+)
+
+// BackupCompression is the compression algorithm of a backup file.
+type BackupCompression string
+
+const (
+	// BackupCompressionNone means the backup file is not compressed.
+	BackupCompressionNone BackupCompression = ""
+	// BackupCompressionGzip is the gzip compression algorithm.
+	BackupCompressionGzip BackupCompression = "GZIP"
+	// BackupCompressionZstd is the Zstandard compression algorithm.
+	BackupCompressionZstd BackupCompression = "ZSTD"
+)
+
+// BackupEncryption is the encryption algorithm of a backup file.
+type BackupEncryption string
+
+const (
+	// BackupEncryptionNone means the backup file is not encrypted.
+	BackupEncryptionNone BackupEncryption = ""
+	// BackupEncryptionAES256GCM is the envelope encryption with AES-256-GCM.
+	// Each backup file is encrypted by a random data key, and the data key is encrypted by the workspace key.
+	BackupEncryptionAES256GCM BackupEncryption = "AES_256_GCM"
 )
 
 // BinlogInfo is the binlog coordination for MySQL.
@@ -62,6 +101,19 @@ type BackupPayload struct {
 	// It is recorded within the same transaction as the dump so that the binlog position is consistent with the dump.
 	// Please refer to https://github.com/bytebase/bytebase/blob/main/docs/design/pitr-mysql.md#full-backup for details.
 	BinlogInfo BinlogInfo `json:"binlogInfo"`
+
+	// Codec related fields
+	// Backups taken before the codec was introduced leave these fields empty, and are read as is.
+	// Compression is the compression algorithm of the backup file.
+	Compression BackupCompression `json:"compression,omitempty"`
+	// Encryption is the encryption algorithm of the backup file.
+	Encryption BackupEncryption `json:"encryption,omitempty"`
+	// EncryptedDataKey is the base64 encoded data key of the backup file, encrypted by the workspace key.
+	EncryptedDataKey string `json:"encryptedDataKey,omitempty"`
+	// RawSize is the size of the dump before compression and encryption.
+	RawSize int64 `json:"rawSize,omitempty"`
+	// RawChecksum is the hex encoded SHA-256 checksum of the dump before compression and encryption.
+	RawChecksum string `json:"rawChecksum,omitempty"`
 }
 
 // Backup is the API message for a backup.
@@ -89,6 +141,10 @@ type Backup struct {
 	// Payload contains data such as binlog position info which will not be created at first.
 	// It is filled when the backup task executor takes database backups.
 	Payload BackupPayload `jsonapi:"attr,payload"`
+	// The verification fields are filled when the backup runner verifies the backup.
+	VerificationStatus BackupVerificationStatus `jsonapi:"attr,verificationStatus"`
+	VerificationDetail string                   `jsonapi:"attr,verificationDetail"`
+	VerifiedTs         int64                    `jsonapi:"attr,verifiedTs"`
 }
 
 // BackupCreate is the API message for creating a backup.
@@ -0,0 +1,16 @@
+package api
+
+// DBSchemaSnapshot is the API message for a snapshot of the database schema detected by the schema syncer.
+type DBSchemaSnapshot struct {
+	ID int `jsonapi:"primary,dbSchemaSnapshot"`
+
+	// Standard fields
+	CreatedTs int64 `jsonapi:"attr,createdTs"`
+
+	// Related fields
+	DatabaseID int `jsonapi:"attr,databaseId"`
+
+	// Domain specific fields
+	// Hash is the hash of the schema. The snapshots with the same hash have the same schema.
+	Hash string `jsonapi:"attr,hash"`
+}
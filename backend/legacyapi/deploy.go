@@ -2,10 +2,18 @@ package api
 
 import (
 	"encoding/json"
+	"math"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/common"
 )
 
+// DeploymentGateMaxBakeTimeSeconds is the maximum bake time of a deployment gate, which is 7 days.
+const DeploymentGateMaxBakeTimeSeconds = 7 * 24 * 60 * 60
+
 // DeploymentConfig is the API message for deployment configurations.
 type DeploymentConfig struct {
 	ID int `jsonapi:"primary,deploymentConfig"`
@@ -30,6 +38,32 @@ type Deployment struct {
 // DeploymentSpec is the API message for deployment specification.
 type DeploymentSpec struct {
 	Selector *LabelSelector `json:"selector"`
+	// Gate is checked after the tasks of the stage finish. The tasks of the later stages don't run until it passes.
+	Gate *DeploymentGate `json:"gate,omitempty"`
+}
+
+// DeploymentGate is the API message for the gate of a deployment stage.
+type DeploymentGate struct {
+	// BakeTimeSeconds is how long to wait after the tasks of the stage finish before checking the health.
+	BakeTimeSeconds int64 `json:"bakeTimeSeconds,omitempty"`
+	// MaxFailurePercent is the maximum percentage of the failed tasks in the stage.
+	// The failed tasks within the limit are skipped so that the rollout goes on.
+	// If it's nil, the failed tasks block the rollout until they are retried or skipped by hand.
+	MaxFailurePercent *float64 `json:"maxFailurePercent,omitempty"`
+	// HealthCheck is checked after the bake time.
+	HealthCheck *DeploymentHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// DeploymentHealthCheck is the API message for the health check of a deployment gate.
+// Exactly one of Statement and URL should be set.
+type DeploymentHealthCheck struct {
+	// Statement is a read-only query run against every database in the stage, such as counting the errors.
+	// Its columns must be aggregate functions, i.e. COUNT, SUM, AVG, MIN and MAX, and the first column must be a number.
+	Statement string `json:"statement,omitempty"`
+	// URL is a metric endpoint responding to GET with a number in the body.
+	URL string `json:"url,omitempty"`
+	// MaxValue is the maximum healthy value. The check fails if any value exceeds it.
+	MaxValue float64 `json:"maxValue"`
 }
 
 // LabelSelector is the API message for label selector.
@@ -123,6 +157,37 @@ func ValidateAndGetDeploymentSchedule(payload string) (*DeploymentSchedule, erro
 		if !hasEnv {
 			return nil, common.Errorf(common.Invalid, "deployment should contain %q label", EnvironmentLabelKey)
 		}
+		if err := validateDeploymentGate(d.Spec.Gate); err != nil {
+			return nil, common.Errorf(common.Invalid, "deployment %q has invalid gate: %v", d.Name, err)
+		}
 	}
 	return schedule, nil
 }
+
+func validateDeploymentGate(gate *DeploymentGate) error {
+	if gate == nil {
+		return nil
+	}
+	if gate.BakeTimeSeconds < 0 || gate.BakeTimeSeconds > DeploymentGateMaxBakeTimeSeconds {
+		return errors.Errorf("bake time should be between 0 and %d seconds", DeploymentGateMaxBakeTimeSeconds)
+	}
+	if v := gate.MaxFailurePercent; v != nil && (math.IsNaN(*v) || *v < 0 || *v > 100) {
+		return errors.New("max failure percent should be between 0 and 100")
+	}
+	if check := gate.HealthCheck; check != nil {
+		hasStatement, hasURL := strings.TrimSpace(check.Statement) != "", check.URL != ""
+		if hasStatement == hasURL {
+			return errors.New("health check should set exactly one of statement and url")
+		}
+		if hasURL {
+			u, err := url.Parse(check.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return errors.Errorf("health check url %q should be an http or https url", check.URL)
+			}
+		}
+		if math.IsNaN(check.MaxValue) || math.IsInf(check.MaxValue, 0) {
+			return errors.New("health check max value should be a finite number")
+		}
+	}
+	return nil
+}
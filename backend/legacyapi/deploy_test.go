@@ -7,6 +7,7 @@ import (
 )
 
 func TestGetDeploymentSchedule(t *testing.T) {
+	maxFailurePercent := 5.0
 	tests := []struct {
 		name    string
 		payload string
@@ -97,6 +98,56 @@ func TestGetDeploymentSchedule(t *testing.T) {
 			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod", "dev"]},{"key":"location","operator":"In","values":["us-central1","europe-west1"]}]}}}]}`,
 			nil,
 			"should must use operator",
+		}, {
+			"gate",
+			`{"deployments":[{"name":"canary","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]}]},"gate":{"bakeTimeSeconds":3600,"maxFailurePercent":5,"healthCheck":{"statement":"SELECT count(*) FROM error_log","maxValue":10}}}}]}`,
+			&DeploymentSchedule{
+				Deployments: []*Deployment{
+					{
+						Name: "canary",
+						Spec: &DeploymentSpec{
+							Selector: &LabelSelector{
+								MatchExpressions: []*LabelSelectorRequirement{
+									{
+										Key:      "bb.environment",
+										Operator: "In",
+										Values:   []string{"prod"},
+									},
+								},
+							},
+							Gate: &DeploymentGate{
+								BakeTimeSeconds:   3600,
+								MaxFailurePercent: &maxFailurePercent,
+								HealthCheck: &DeploymentHealthCheck{
+									Statement: "SELECT count(*) FROM error_log",
+									MaxValue:  10,
+								},
+							},
+						},
+					},
+				},
+			},
+			"",
+		}, {
+			"gateNegativeBakeTime",
+			`{"deployments":[{"name":"canary","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]}]},"gate":{"bakeTimeSeconds":-1}}}]}`,
+			nil,
+			"bake time should be between",
+		}, {
+			"gateMaxFailurePercentOutOfRange",
+			`{"deployments":[{"name":"canary","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]}]},"gate":{"maxFailurePercent":101}}}]}`,
+			nil,
+			"max failure percent should be between",
+		}, {
+			"gateHealthCheckBothStatementAndURL",
+			`{"deployments":[{"name":"canary","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]}]},"gate":{"healthCheck":{"statement":"SELECT 1","url":"https://metrics.example.com/errors"}}}}]}`,
+			nil,
+			"exactly one of statement and url",
+		}, {
+			"gateHealthCheckInvalidURL",
+			`{"deployments":[{"name":"canary","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]}]},"gate":{"healthCheck":{"url":"ftp://metrics.example.com/errors"}}}}]}`,
+			nil,
+			"should be an http or https url",
 		},
 	}
 
@@ -33,6 +33,8 @@ const (
 	IssueDatabaseDataUpdate IssueType = "bb.issue.database.data.update"
 	// IssueDatabaseRestorePITR is the issue type for performing a Point-in-time Recovery.
 	IssueDatabaseRestorePITR IssueType = "bb.issue.database.restore.pitr"
+	// IssueDatabaseQueryAccess is the issue type for requesting the time-boxed access to query a database.
+	IssueDatabaseQueryAccess IssueType = "bb.issue.database.query-access"
 )
 
 // IssueFieldID is the field ID for an issue.
@@ -186,6 +188,17 @@ type MigrationFileYAML struct {
 	Statement string                      `yaml:"statement"` // The SQL statement to be executed to specified list of databases
 }
 
+// QueryAccessContext is the issue create context for requesting the time-boxed access to query a database.
+type QueryAccessContext struct {
+	// DatabaseID is the ID of the database to query.
+	DatabaseID int `json:"databaseId"`
+	// TableList is the tables allowed to query in "table" or "schema.table" format.
+	// All tables in the database are allowed if it's empty.
+	TableList []string `json:"tableList"`
+	// DurationSeconds is how long the access lasts after the issue is approved.
+	DurationSeconds int64 `json:"durationSeconds"`
+}
+
 // PITRContext is the issue create context for performing a PITR in a database.
 type PITRContext struct {
 	DatabaseID int `json:"databaseId"`
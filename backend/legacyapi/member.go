@@ -26,6 +26,18 @@ const (
 	UnknownRole Role = "UNKNOWN"
 )
 
+// workspaceRoleRank orders the workspace roles from the lowest to the highest.
+var workspaceRoleRank = map[Role]int{
+	Developer: 1,
+	DBA:       2,
+	Owner:     3,
+}
+
+// HigherThan returns true if the workspace role is higher than the other one, e.g. when picking the highest role mapped from the groups of a user.
+func (r Role) HigherThan(other Role) bool {
+	return workspaceRoleRank[r] > workspaceRoleRank[other]
+}
+
 // Member is the API message for a member.
 type Member struct {
 	ID int `jsonapi:"primary,member"`
@@ -44,6 +44,14 @@ const (
 	PolicyTypeAccessControl PolicyType = "bb.policy.access-control"
 	// PolicyTypeSlowQuery is the slow query policy type.
 	PolicyTypeSlowQuery PolicyType = "bb.policy.slow-query"
+	// PolicyTypeSQLExport is the SQL editor query result export policy type.
+	PolicyTypeSQLExport PolicyType = "bb.policy.sql-export"
+	// PolicyTypeSQLQuery is the SQL editor query policy type.
+	PolicyTypeSQLQuery PolicyType = "bb.policy.sql-query"
+	// PolicyTypeRowFilter is the row-level query restriction policy type.
+	PolicyTypeRowFilter PolicyType = "bb.policy.row-filter"
+	// PolicyTypeTableGrowth is the table growth policy type.
+	PolicyTypeTableGrowth PolicyType = "bb.policy.table-growth"
 
 	// PipelineApprovalValueManualNever means the pipeline will automatically be approved without user intervention.
 	PipelineApprovalValueManualNever PipelineApprovalValue = "MANUAL_APPROVAL_NEVER"
@@ -91,6 +99,10 @@ var (
 		PolicyTypeSensitiveData:    {PolicyResourceTypeDatabase},
 		PolicyTypeAccessControl:    {PolicyResourceTypeEnvironment, PolicyResourceTypeDatabase},
 		PolicyTypeSlowQuery:        {PolicyResourceTypeInstance},
+		PolicyTypeSQLExport:        {PolicyResourceTypeEnvironment},
+		PolicyTypeSQLQuery:         {PolicyResourceTypeEnvironment},
+		PolicyTypeRowFilter:        {PolicyResourceTypeDatabase},
+		PolicyTypeTableGrowth:      {PolicyResourceTypeEnvironment},
 	}
 )
 
@@ -230,6 +242,14 @@ type SensitiveData struct {
 	Table  string                `json:"table"`
 	Column string                `json:"column"`
 	Type   SensitiveDataMaskType `json:"maskType"`
+
+	// The parameters of the mask type, zero values mean the defaults.
+	// KeepLast is the number of trailing characters kept by the PARTIAL mask type, defaults to 4.
+	KeepLast int `json:"keepLast,omitempty"`
+	// RangeWidth is the width of the number ranges for the RANGE mask type, defaults to 10.
+	RangeWidth float64 `json:"rangeWidth,omitempty"`
+	// DateUnit is the unit of the date ranges for the RANGE mask type, one of YEAR, MONTH and DAY, defaults to MONTH.
+	DateUnit string `json:"dateUnit,omitempty"`
 }
 
 // SensitiveDataMaskType is the mask type for sensitive data.
@@ -239,8 +259,43 @@ const (
 	// SensitiveDataMaskTypeDefault is the sensitive data type to hide data with a default method.
 	// The default method is subject to change.
 	SensitiveDataMaskTypeDefault SensitiveDataMaskType = "DEFAULT"
+	// SensitiveDataMaskTypePartial is the sensitive data type to hide data except the last few characters.
+	SensitiveDataMaskTypePartial SensitiveDataMaskType = "PARTIAL"
+	// SensitiveDataMaskTypeHash is the sensitive data type to replace data with its salted hash.
+	SensitiveDataMaskTypeHash SensitiveDataMaskType = "HASH"
+	// SensitiveDataMaskTypeRange is the sensitive data type to replace numbers and dates with the range they belong to.
+	SensitiveDataMaskTypeRange SensitiveDataMaskType = "RANGE"
+	// SensitiveDataMaskTypeEmail is the sensitive data type to hide the local part of emails and keep the domain.
+	SensitiveDataMaskTypeEmail SensitiveDataMaskType = "EMAIL"
+	// SensitiveDataMaskTypeFormatPreserving is the sensitive data type to substitute letters and digits and keep the format of data.
+	SensitiveDataMaskTypeFormatPreserving SensitiveDataMaskType = "FORMAT_PRESERVING"
 )
 
+func (d *SensitiveData) validate() error {
+	if d.Table == "" || d.Column == "" {
+		return errors.Errorf("sensitive data policy rule cannot have empty table or column name")
+	}
+	switch d.Type {
+	case SensitiveDataMaskTypeDefault, SensitiveDataMaskTypeEmail, SensitiveDataMaskTypeHash, SensitiveDataMaskTypeFormatPreserving:
+	case SensitiveDataMaskTypePartial:
+		if d.KeepLast < 0 {
+			return errors.Errorf("sensitive data policy rule for column %q cannot keep negative number of characters", d.Column)
+		}
+	case SensitiveDataMaskTypeRange:
+		if d.RangeWidth < 0 {
+			return errors.Errorf("sensitive data policy rule for column %q cannot have negative range width", d.Column)
+		}
+		switch d.DateUnit {
+		case "", "YEAR", "MONTH", "DAY":
+		default:
+			return errors.Errorf("sensitive data policy rule for column %q has invalid date unit %q", d.Column, d.DateUnit)
+		}
+	default:
+		return errors.Errorf("sensitive data policy rule for column %q has invalid mask type %q", d.Column, d.Type)
+	}
+	return nil
+}
+
 // UnmarshalSensitiveDataPolicy will unmarshal payload to sensitive data policy.
 func UnmarshalSensitiveDataPolicy(payload string) (*SensitiveDataPolicy, error) {
 	var p SensitiveDataPolicy
@@ -313,6 +368,147 @@ func (p *SlowQueryPolicy) String() (string, error) {
 	return string(s), nil
 }
 
+// SQLExportPolicy is the policy configuration for exporting query results in SQL editor.
+// It is only applicable to environment resource type.
+type SQLExportPolicy struct {
+	// DisallowExport disallows the members other than workspace owners and DBAs to export query results.
+	DisallowExport bool `json:"disallowExport"`
+	// MaxRowCount is the maximum row count of an export, no limit if it's zero.
+	MaxRowCount int `json:"maxRowCount"`
+}
+
+// UnmarshalSQLExportPolicy will unmarshal payload to SQL export policy.
+func UnmarshalSQLExportPolicy(payload string) (*SQLExportPolicy, error) {
+	var p SQLExportPolicy
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal SQL export policy %q", payload)
+	}
+	return &p, nil
+}
+
+// String will return the string representation of the policy.
+func (p *SQLExportPolicy) String() (string, error) {
+	s, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// SQLQueryPolicy is the policy configuration for running queries in SQL editor.
+// It is only applicable to environment resource type.
+type SQLQueryPolicy struct {
+	// MaxExecutionSeconds is the maximum execution time of a query, no limit if it's zero.
+	MaxExecutionSeconds int `json:"maxExecutionSeconds"`
+}
+
+// UnmarshalSQLQueryPolicy will unmarshal payload to SQL query policy.
+func UnmarshalSQLQueryPolicy(payload string) (*SQLQueryPolicy, error) {
+	var p SQLQueryPolicy
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal SQL query policy %q", payload)
+	}
+	return &p, nil
+}
+
+// String will return the string representation of the policy.
+func (p *SQLQueryPolicy) String() (string, error) {
+	s, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// RowFilterPolicy is the policy configuration for restricting the rows returned by SQL editor queries.
+// It is only applicable to database resource type.
+type RowFilterPolicy struct {
+	RowFilterList []RowFilter `json:"rowFilterList"`
+}
+
+// RowFilter is the predicate injected into the queries reading the table.
+// The filter applies to the members of the workspace role, or to the principal.
+// Filters applied to the same table are combined with AND.
+// Views and functions reading the table are not filtered, so they should be filtered by their own rules.
+type RowFilter struct {
+	// Schema is only used by PostgreSQL, defaults to "public".
+	Schema string `json:"schema"`
+	Table  string `json:"table"`
+	// Expression is the SQL predicate of the rows visible to the query, such as "region = 'EU'".
+	Expression  string `json:"expression"`
+	Role        Role   `json:"role,omitempty"`
+	PrincipalID int    `json:"principalId,omitempty"`
+}
+
+// Match returns true if the filter applies to the principal with the workspace role.
+func (f *RowFilter) Match(principalID int, role Role) bool {
+	if f.PrincipalID != 0 {
+		return f.PrincipalID == principalID
+	}
+	return f.Role == role
+}
+
+func (f *RowFilter) validate() error {
+	if f.Table == "" || f.Expression == "" {
+		return errors.Errorf("row filter policy rule cannot have empty table name or expression")
+	}
+	if (f.Role == "") == (f.PrincipalID == 0) {
+		return errors.Errorf("row filter policy rule for table %q must apply to either a role or a principal", f.Table)
+	}
+	switch f.Role {
+	case "", Owner, DBA, Developer:
+	default:
+		return errors.Errorf("row filter policy rule for table %q has invalid role %q", f.Table, f.Role)
+	}
+	return nil
+}
+
+// UnmarshalRowFilterPolicy will unmarshal payload to row filter policy.
+func UnmarshalRowFilterPolicy(payload string) (*RowFilterPolicy, error) {
+	var p RowFilterPolicy
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal row filter policy %q", payload)
+	}
+	return &p, nil
+}
+
+// String will return the string representation of the policy.
+func (p *RowFilterPolicy) String() (string, error) {
+	s, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// TableGrowthPolicy is the policy configuration for the table growth anomaly.
+// It is only applicable to environment resource type.
+type TableGrowthPolicy struct {
+	// MaxDailyGrowthPercent is the maximum growth of a table size per day in percent, no limit if it's zero.
+	MaxDailyGrowthPercent float64 `json:"maxDailyGrowthPercent"`
+	// MaxTableSize is the maximum size of a table in bytes, no limit if it's zero.
+	// The anomaly fires when a table is approaching the size.
+	MaxTableSize int64 `json:"maxTableSize"`
+}
+
+// UnmarshalTableGrowthPolicy will unmarshal payload to table growth policy.
+func UnmarshalTableGrowthPolicy(payload string) (*TableGrowthPolicy, error) {
+	var p TableGrowthPolicy
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal table growth policy %q", payload)
+	}
+	return &p, nil
+}
+
+// String will return the string representation of the policy.
+func (p *TableGrowthPolicy) String() (string, error) {
+	s, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
 // UnmarshalEnvironmentTierPolicy will unmarshal payload to environment tier policy.
 func UnmarshalEnvironmentTierPolicy(payload string) (*EnvironmentTierPolicy, error) {
 	var p EnvironmentTierPolicy
@@ -401,11 +597,8 @@ func ValidatePolicy(resourceType PolicyResourceType, pType PolicyType, payload *
 			return err
 		}
 		for _, v := range p.SensitiveDataList {
-			if v.Table == "" || v.Column == "" {
-				return errors.Errorf("sensitive data policy rule cannot have empty table or column name")
-			}
-			if v.Type != SensitiveDataMaskTypeDefault {
-				return errors.Errorf("sensitive data policy rule must have mask type %q", SensitiveDataMaskTypeDefault)
+			if err := v.validate(); err != nil {
+				return err
 			}
 		}
 		return nil
@@ -414,6 +607,47 @@ func ValidatePolicy(resourceType PolicyResourceType, pType PolicyType, payload *
 			return err
 		}
 		return nil
+	case PolicyTypeSQLExport:
+		p, err := UnmarshalSQLExportPolicy(*payload)
+		if err != nil {
+			return err
+		}
+		if p.MaxRowCount < 0 {
+			return errors.Errorf("invalid SQL export policy max row count %d", p.MaxRowCount)
+		}
+		return nil
+	case PolicyTypeSQLQuery:
+		p, err := UnmarshalSQLQueryPolicy(*payload)
+		if err != nil {
+			return err
+		}
+		if p.MaxExecutionSeconds < 0 {
+			return errors.Errorf("invalid SQL query policy max execution seconds %d", p.MaxExecutionSeconds)
+		}
+		return nil
+	case PolicyTypeRowFilter:
+		p, err := UnmarshalRowFilterPolicy(*payload)
+		if err != nil {
+			return err
+		}
+		for _, v := range p.RowFilterList {
+			if err := v.validate(); err != nil {
+				return err
+			}
+		}
+		return nil
+	case PolicyTypeTableGrowth:
+		p, err := UnmarshalTableGrowthPolicy(*payload)
+		if err != nil {
+			return err
+		}
+		if p.MaxDailyGrowthPercent < 0 {
+			return errors.Errorf("invalid table growth policy max daily growth percent %v", p.MaxDailyGrowthPercent)
+		}
+		if p.MaxTableSize < 0 {
+			return errors.Errorf("invalid table growth policy max table size %d", p.MaxTableSize)
+		}
+		return nil
 	}
 	return nil
 }
@@ -443,6 +677,18 @@ func GetDefaultPolicy(pType PolicyType) (string, error) {
 	case PolicyTypeSensitiveData:
 		policy := SensitiveDataPolicy{}
 		return policy.String()
+	case PolicyTypeSQLExport:
+		policy := SQLExportPolicy{}
+		return policy.String()
+	case PolicyTypeSQLQuery:
+		policy := SQLQueryPolicy{}
+		return policy.String()
+	case PolicyTypeRowFilter:
+		policy := RowFilterPolicy{}
+		return policy.String()
+	case PolicyTypeTableGrowth:
+		policy := TableGrowthPolicy{}
+		return policy.String()
 	}
 	return "", nil
 }
@@ -13,6 +13,14 @@ type ProjectWebhook struct {
 	Name         string   `jsonapi:"attr,name"`
 	URL          string   `jsonapi:"attr,url"`
 	ActivityList []string `jsonapi:"attr,activityList"`
+	// HasSecret is true if the payload is signed with a secret, the secret itself is never returned.
+	HasSecret bool `jsonapi:"attr,hasSecret"`
+	// Enabled is false after the webhook is disabled for too many consecutive failed deliveries.
+	Enabled      bool `jsonapi:"attr,enabled"`
+	FailureCount int  `jsonapi:"attr,failureCount"`
+	// Filter is the ProjectWebhookFilter in json format.
+	Filter          string `jsonapi:"attr,filter"`
+	PayloadTemplate string `jsonapi:"attr,payloadTemplate"`
 }
 
 // ProjectWebhookCreate is the API message for creating a project webhook.
@@ -29,6 +37,10 @@ type ProjectWebhookCreate struct {
 	Name         string   `jsonapi:"attr,name"`
 	URL          string   `jsonapi:"attr,url"`
 	ActivityList []string `jsonapi:"attr,activityList"`
+	Secret       string   `jsonapi:"attr,secret"`
+	// Filter is the ProjectWebhookFilter in json format.
+	Filter          string `jsonapi:"attr,filter"`
+	PayloadTemplate string `jsonapi:"attr,payloadTemplate"`
 }
 
 // ProjectWebhookPatch is the API message for patching a project webhook.
@@ -43,9 +55,63 @@ type ProjectWebhookPatch struct {
 	Name         *string `jsonapi:"attr,name"`
 	URL          *string `jsonapi:"attr,url"`
 	ActivityList *string `jsonapi:"attr,activityList"`
+	Secret       *string `jsonapi:"attr,secret"`
+	// Enabled re-enables a webhook disabled for too many consecutive failed deliveries.
+	Enabled *bool `jsonapi:"attr,enabled"`
+	// Filter is the ProjectWebhookFilter in json format.
+	Filter          *string `jsonapi:"attr,filter"`
+	PayloadTemplate *string `jsonapi:"attr,payloadTemplate"`
+}
+
+// ProjectWebhookFilter is the filter of the events posted to a project webhook in addition to the activity types.
+// An empty condition matches all events, and an event is posted only if it matches all the conditions.
+type ProjectWebhookFilter struct {
+	// EnvironmentList is the list of environment resource IDs, matching the events of the tasks in any of them.
+	EnvironmentList []string `json:"environmentList,omitempty"`
+	// DatabaseLabelList is the list of database labels in the format of "key=value",
+	// matching the events of the tasks on a database with any of them.
+	DatabaseLabelList []string `json:"databaseLabelList,omitempty"`
+	// IssueTypeList is the list of issue types.
+	IssueTypeList []IssueType `json:"issueTypeList,omitempty"`
+	// TaskStatusList is the list of the new task statuses, it only applies to the task status update events.
+	TaskStatusList []TaskStatus `json:"taskStatusList,omitempty"`
+	// MinRiskLevel is the minimum risk level of the issue.
+	// The risk level is found by the approval runner after the issue is created, so the issue creation event is always at the default risk level 0.
+	MinRiskLevel int64 `json:"minRiskLevel,omitempty"`
 }
 
 // ProjectWebhookTestResult is the test result of a project webhook.
 type ProjectWebhookTestResult struct {
 	Error string `jsonapi:"attr,error"`
 }
+
+// ProjectWebhookDeliveryStatus is the status of a project webhook delivery.
+type ProjectWebhookDeliveryStatus string
+
+const (
+	// ProjectWebhookDeliveryPending is the delivery status for PENDING, the delivery is waiting for the first attempt or a retry.
+	ProjectWebhookDeliveryPending ProjectWebhookDeliveryStatus = "PENDING"
+	// ProjectWebhookDeliverySuccess is the delivery status for SUCCESS.
+	ProjectWebhookDeliverySuccess ProjectWebhookDeliveryStatus = "SUCCESS"
+	// ProjectWebhookDeliveryFailed is the delivery status for FAILED, all the attempts have failed.
+	ProjectWebhookDeliveryFailed ProjectWebhookDeliveryStatus = "FAILED"
+)
+
+// ProjectWebhookDelivery is the API message for a delivery of a project webhook.
+type ProjectWebhookDelivery struct {
+	ID int `jsonapi:"primary,projectWebhookDelivery"`
+
+	// Standard fields
+	CreatedTs int64 `jsonapi:"attr,createdTs"`
+	UpdatedTs int64 `jsonapi:"attr,updatedTs"`
+
+	// Related fields
+	ProjectWebhookID int `jsonapi:"attr,projectWebhookId"`
+
+	// Domain specific fields
+	Status        ProjectWebhookDeliveryStatus `jsonapi:"attr,status"`
+	Attempt       int                          `jsonapi:"attr,attempt"`
+	NextAttemptTs int64                        `jsonapi:"attr,nextAttemptTs"`
+	LastError     string                       `jsonapi:"attr,lastError"`
+	Payload       string                       `jsonapi:"attr,payload"`
+}
@@ -0,0 +1,54 @@
+package api
+
+// SensitiveColumnClassification is the classification of a sensitive column.
+type SensitiveColumnClassification string
+
+const (
+	// SensitiveColumnEmail is the classification for email addresses.
+	SensitiveColumnEmail SensitiveColumnClassification = "EMAIL"
+	// SensitiveColumnPhone is the classification for phone numbers.
+	SensitiveColumnPhone SensitiveColumnClassification = "PHONE"
+	// SensitiveColumnNationalID is the classification for national ID numbers, such as the social security numbers.
+	SensitiveColumnNationalID SensitiveColumnClassification = "NATIONAL_ID"
+	// SensitiveColumnCreditCard is the classification for credit card numbers.
+	SensitiveColumnCreditCard SensitiveColumnClassification = "CREDIT_CARD"
+)
+
+// SensitiveColumnSuggestionStatus is the status of a sensitive column suggestion.
+type SensitiveColumnSuggestionStatus string
+
+const (
+	// SensitiveColumnSuggestionOpen is the suggestion status for OPEN, the suggestion is waiting for review.
+	SensitiveColumnSuggestionOpen SensitiveColumnSuggestionStatus = "OPEN"
+	// SensitiveColumnSuggestionAccepted is the suggestion status for ACCEPTED, the column is added to the sensitive data policy.
+	SensitiveColumnSuggestionAccepted SensitiveColumnSuggestionStatus = "ACCEPTED"
+	// SensitiveColumnSuggestionDismissed is the suggestion status for DISMISSED, the column won't be suggested again.
+	SensitiveColumnSuggestionDismissed SensitiveColumnSuggestionStatus = "DISMISSED"
+)
+
+// SensitiveColumnSuggestion is the API message for a sensitive column found by the classification scanner.
+type SensitiveColumnSuggestion struct {
+	ID int `jsonapi:"primary,sensitiveColumnSuggestion"`
+
+	// Standard fields
+	CreatedTs int64 `jsonapi:"attr,createdTs"`
+	UpdatedTs int64 `jsonapi:"attr,updatedTs"`
+
+	// Related fields
+	DatabaseID int `jsonapi:"attr,databaseId"`
+
+	// Domain specific fields
+	Schema         string                          `jsonapi:"attr,schema"`
+	Table          string                          `jsonapi:"attr,table"`
+	Column         string                          `jsonapi:"attr,column"`
+	Classification SensitiveColumnClassification   `jsonapi:"attr,classification"`
+	Confidence     float64                         `jsonapi:"attr,confidence"`
+	MaskType       SensitiveDataMaskType           `jsonapi:"attr,maskType"`
+	Reason         string                          `jsonapi:"attr,reason"`
+	Status         SensitiveColumnSuggestionStatus `jsonapi:"attr,status"`
+}
+
+// SensitiveColumnSuggestionReview is the API message for accepting or dismissing sensitive column suggestions in bulk.
+type SensitiveColumnSuggestionReview struct {
+	IDList []int `json:"idList"`
+}
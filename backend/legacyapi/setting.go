@@ -2,6 +2,10 @@ package api
 
 import (
 	"encoding/json"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -38,6 +42,14 @@ const (
 	SettingPluginAgent SettingName = "bb.plugin.agent"
 	// SettingWorkspaceMailDelivery is the setting name for workspace mail delivery.
 	SettingWorkspaceMailDelivery SettingName = "bb.workspace.mail-delivery"
+	// SettingWorkspaceSCIM is the setting name for the SCIM provisioning token and group mappings.
+	SettingWorkspaceSCIM SettingName = "bb.workspace.scim"
+	// SettingSensitiveDataSalt is the setting name for the salt of the HASH and FORMAT_PRESERVING mask types.
+	// It's only used by the server, and never returned to the client, otherwise the masked values could be reversed by brute force.
+	SettingSensitiveDataSalt SettingName = "bb.workspace.sensitive-data-salt"
+	// SettingStageGateMetricAllowlist is the setting name for the private address ranges that the stage gate metric endpoints can resolve to.
+	// The value is a comma-separated list of CIDRs, such as "10.0.0.0/8,192.168.1.0/24".
+	SettingStageGateMetricAllowlist SettingName = "bb.workspace.stage-gate-metric-allowlist"
 )
 
 // IMType is the type of IM.
@@ -108,3 +120,48 @@ type SettingWorkspaceMailDeliveryValue struct {
 	SMTPEncryptionType     storepb.SMTPMailDeliverySetting_Encryption     `json:"smtpEncryptionType"`
 	SMTPTo                 string                                         `json:"sendTo"`
 }
+
+// SettingWorkspaceSCIMValue is the setting value of SettingWorkspaceSCIM type setting.
+type SettingWorkspaceSCIMValue struct {
+	// TokenHash is the hex-encoded SHA-256 of the bearer token used by the identity provider.
+	// The token itself is only shown once when it's generated.
+	TokenHash        string              `json:"tokenHash"`
+	GroupMappingList []*SCIMGroupMapping `json:"groupMappingList"`
+}
+
+// SCIMGroupMapping maps the members of a SCIM group to a workspace role or a project membership.
+type SCIMGroupMapping struct {
+	// Group is the display name of the SCIM group.
+	Group string `json:"group"`
+	// Role is the workspace role granted to the members, the highest one wins if a user is in several groups.
+	Role Role `json:"role,omitempty"`
+	// ProjectID and ProjectRole add the members to the project with the role.
+	ProjectID   int  `json:"projectId,omitempty"`
+	ProjectRole Role `json:"projectRole,omitempty"`
+}
+
+// ParseStageGateMetricAllowlist parses the value of SettingStageGateMetricAllowlist type setting.
+// Only the private address ranges can be allowed.
+func ParseStageGateMetricAllowlist(value string) ([]*net.IPNet, error) {
+	var allowlist []*net.IPNet
+	for _, cidr := range strings.Split(value, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Errorf("invalid CIDR %q", cidr)
+		}
+		// The range is private if both the first and the last addresses are, because the private ranges are also CIDRs.
+		last := make(net.IP, len(ipNet.IP))
+		for i := range ipNet.IP {
+			last[i] = ipNet.IP[i] | ^ipNet.Mask[i]
+		}
+		if !ipNet.IP.IsPrivate() || !last.IsPrivate() {
+			return nil, errors.Errorf("CIDR %q is not a private address range", cidr)
+		}
+		allowlist = append(allowlist, ipNet)
+	}
+	return allowlist, nil
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStageGateMetricAllowlist(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{value: "", want: nil},
+		{value: "10.0.0.0/8, 192.168.1.0/24,,fd12::/16", want: []string{"10.0.0.0/8", "192.168.1.0/24", "fd12::/16"}},
+		{value: "10.1.2.3/32", want: []string{"10.1.2.3/32"}},
+		{value: "10.0.0.0", wantErr: true},
+		{value: "8.8.8.0/24", wantErr: true},
+		{value: "127.0.0.0/8", wantErr: true},
+		{value: "169.254.0.0/16", wantErr: true},
+		{value: "10.0.0.0/7", wantErr: true},
+		{value: "192.168.0.0/15", wantErr: true},
+		{value: "0.0.0.0/0", wantErr: true},
+	}
+
+	for _, test := range tests {
+		allowlist, err := ParseStageGateMetricAllowlist(test.value)
+		if test.wantErr {
+			require.Error(t, err, test.value)
+			continue
+		}
+		require.NoError(t, err, test.value)
+		var got []string
+		for _, ipNet := range allowlist {
+			got = append(got, ipNet.String())
+		}
+		require.Equal(t, test.want, got, test.value)
+	}
+}
@@ -30,6 +30,10 @@ const (
 	SheetFromGitHub SheetSource = "GITHUB"
 	// SheetFromBitbucket is the sheet synced from Bitbucket.
 	SheetFromBitbucket SheetSource = "BITBUCKET"
+	// SheetFromGitea is the sheet synced from Gitea.
+	SheetFromGitea SheetSource = "GITEA"
+	// SheetFromGit is the sheet synced from generic Git servers.
+	SheetFromGit SheetSource = "GIT"
 )
 
 // SheetType is the type of sheet.
@@ -55,6 +55,23 @@ type SQLExecute struct {
 	Limit int `jsonapi:"attr,limit"`
 }
 
+// SQLRunningQuery is the API message for an in-flight SQL editor query.
+type SQLRunningQuery struct {
+	ID string `jsonapi:"primary,sqlRunningQuery"`
+
+	// Standard fields
+	CreatorID int `jsonapi:"attr,creatorId"`
+
+	// Domain specific fields
+	InstanceID   int    `jsonapi:"attr,instanceId"`
+	DatabaseName string `jsonapi:"attr,databaseName"`
+	Statement    string `jsonapi:"attr,statement"`
+	// StartedTs is the Unix timestamp in seconds when the query started.
+	StartedTs int64 `jsonapi:"attr,startedTs"`
+	// MaxExecutionSeconds is the maximum execution time enforced by the environment SQL query policy, no limit if it's zero.
+	MaxExecutionSeconds int `jsonapi:"attr,maxExecutionSeconds"`
+}
+
 // SingleSQLResult is the API message for single SQL result.
 type SingleSQLResult struct {
 	// A list of rows marshalled into a JSON.
@@ -73,6 +90,50 @@ type SQLResultSet struct {
 	AdviceList []advisor.Advice `jsonapi:"attr,adviceList"`
 }
 
+// SQLExplain is the API message for explaining the query plan of a statement.
+type SQLExplain struct {
+	InstanceID int `jsonapi:"attr,instanceId"`
+	// For engines such as MySQL, databaseName can be empty.
+	DatabaseName string `jsonapi:"attr,databaseName"`
+	Statement    string `jsonapi:"attr,statement"`
+	// Analyze executes the statement to collect the actual row count and time of the plan nodes.
+	// It's only allowed for the instances with a read replica as the read-only data source.
+	Analyze bool `jsonapi:"attr,analyze"`
+}
+
+// SQLExplainResult is the API message for the query plan of a statement.
+type SQLExplainResult struct {
+	// The engine-independent plan tree.
+	Plan *db.ExplainNode `jsonapi:"attr,plan"`
+	// A list of advice derived from the plan.
+	AdviceList []advisor.Advice `jsonapi:"attr,adviceList"`
+}
+
+// SQLExportFormat is the file format of the exported query result.
+type SQLExportFormat string
+
+const (
+	// SQLExportFormatCSV is the CSV format with a header line of the column names.
+	SQLExportFormatCSV SQLExportFormat = "CSV"
+	// SQLExportFormatJSON is the JSON Lines format, one JSON object per row.
+	SQLExportFormatJSON SQLExportFormat = "JSON"
+	// SQLExportFormatSQL is the SQL format, one INSERT statement per row.
+	SQLExportFormatSQL SQLExportFormat = "SQL"
+	// SQLExportFormatXLSX is the Excel workbook format.
+	SQLExportFormatXLSX SQLExportFormat = "XLSX"
+)
+
+// SQLExport is the API message for exporting the full result of a readonly / SELECT query.
+type SQLExport struct {
+	InstanceID int `jsonapi:"attr,instanceId"`
+	// For engines such as MySQL, databaseName can be empty.
+	DatabaseName string          `jsonapi:"attr,databaseName"`
+	Statement    string          `jsonapi:"attr,statement"`
+	Format       SQLExportFormat `jsonapi:"attr,format"`
+	// TableName is the table name in the INSERT statements, only applicable to the SQL format.
+	TableName string `jsonapi:"attr,tableName"`
+}
+
 // SQLService is the service for SQL.
 type SQLService interface {
 	Ping(ctx context.Context, config *ConnectionInfo) (*SQLResultSet, error)
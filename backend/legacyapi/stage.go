@@ -13,8 +13,47 @@ const (
 	//   - FAILED
 	//   - CANCELED
 	StageStatusUpdateTypeEnd StageStatusUpdateType = "END"
+	// StageStatusUpdateTypePause means the gate of the stage fails, and the later stages are paused.
+	StageStatusUpdateTypePause StageStatusUpdateType = "PAUSE"
+	// StageStatusUpdateTypeResume means the later stages paused by the gate of the stage are resumed.
+	StageStatusUpdateTypeResume StageStatusUpdateType = "RESUME"
 )
 
+// StageGateStatus is the status of a stage gate.
+type StageGateStatus string
+
+const (
+	// StageGatePending means the gate is waiting for the tasks of the stage to finish or the bake time to pass.
+	StageGatePending StageGateStatus = "PENDING"
+	// StageGatePassed means the gate passed, or it's resumed by hand after failing.
+	StageGatePassed StageGateStatus = "PASSED"
+	// StageGateFailed means the gate failed, and the later stages are paused.
+	StageGateFailed StageGateStatus = "FAILED"
+)
+
+// StageGate is the API message for the gate of a stage.
+type StageGate struct {
+	Status StageGateStatus `json:"status"`
+	Config *DeploymentGate `json:"config"`
+	// Detail is the reason why the gate failed or was resumed.
+	Detail    string `json:"detail"`
+	UpdatedTs int64  `json:"updatedTs"`
+}
+
+// StageGatePatch is the API message for patching the gate of a stage.
+type StageGatePatch struct {
+	ID int
+
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	UpdaterID int
+
+	// Domain specific fields
+	// Status is PASSED to resume the later stages, or PENDING to check the gate again.
+	Status  StageGateStatus `jsonapi:"attr,status"`
+	Comment *string         `jsonapi:"attr,comment"`
+}
+
 // Stage is the API message for a stage.
 type Stage struct {
 	ID int `jsonapi:"primary,stage"`
@@ -28,6 +67,8 @@ type Stage struct {
 
 	// Domain specific fields
 	Name string `jsonapi:"attr,name"`
+	// Gate is nil if the stage has no gate.
+	Gate *StageGate `jsonapi:"attr,gate"`
 }
 
 // StageCreate is the API message for creating a stage.
@@ -44,6 +85,7 @@ type StageCreate struct {
 
 	// Domain specific fields
 	Name string
+	Gate *DeploymentGate
 }
 
 // TaskIndexDAG describes task dependency relationship using array index to represent task.
@@ -0,0 +1,22 @@
+package api
+
+// TableStat is the API message for the statistics of a table recorded by the schema syncer.
+// The stats of a table over time form its growth curve.
+type TableStat struct {
+	ID int64 `jsonapi:"primary,tableStat"`
+
+	// Standard fields
+	CreatedTs int64 `jsonapi:"attr,createdTs"`
+
+	// Related fields
+	DatabaseID int `jsonapi:"attr,databaseId"`
+
+	// Domain specific fields
+	// SchemaName is empty for the engines without schemas, such as MySQL.
+	SchemaName string `jsonapi:"attr,schemaName"`
+	TableName  string `jsonapi:"attr,tableName"`
+	RowCount   int64  `jsonapi:"attr,rowCount"`
+	DataSize   int64  `jsonapi:"attr,dataSize"`
+	IndexSize  int64  `jsonapi:"attr,indexSize"`
+	DataFree   int64  `jsonapi:"attr,dataFree"`
+}
@@ -71,12 +71,16 @@ const (
 	TaskCheckDatabaseStatementTypeReport TaskCheckType = "bb.task-check.database.statement.type.report"
 	// TaskCheckDatabaseStatementAffectedRowsReport is the task check type for statement affected rows.
 	TaskCheckDatabaseStatementAffectedRowsReport TaskCheckType = "bb.task-check.database.statement.affected-rows.report"
+	// TaskCheckDatabaseStatementLockImpactReport is the task check type for statement lock impact.
+	TaskCheckDatabaseStatementLockImpactReport TaskCheckType = "bb.task-check.database.statement.lock-impact.report"
 	// TaskCheckDatabaseConnect is the task check type for database connection.
 	TaskCheckDatabaseConnect TaskCheckType = "bb.task-check.database.connect"
 	// TaskCheckGhostSync is the task check type for the gh-ost sync task.
 	TaskCheckGhostSync TaskCheckType = "bb.task-check.database.ghost.sync"
 	// TaskCheckPITRMySQL is the task check type for MySQL PITR.
 	TaskCheckPITRMySQL TaskCheckType = "bb.task-check.pitr.mysql"
+	// TaskCheckPITRPostgres is the task check type for PostgreSQL PITR.
+	TaskCheckPITRPostgres TaskCheckType = "bb.task-check.pitr.postgres"
 )
 
 // TaskCheckEarliestAllowedTimePayload is the task check payload for earliest allowed time.
@@ -84,6 +88,26 @@ type TaskCheckEarliestAllowedTimePayload struct {
 	EarliestAllowedTs int64 `json:"earliestAllowedTs,omitempty"`
 }
 
+// TaskCheckLockImpact is the lock impact of a statement, which is the content of the lock impact report result.
+type TaskCheckLockImpact struct {
+	// LockLevel is the table lock held by the statement, e.g. ACCESS_EXCLUSIVE for PostgreSQL, SHARED for MySQL.
+	// It's empty if the statement doesn't lock any table.
+	LockLevel string `json:"lockLevel,omitempty"`
+	// Algorithm is the MySQL online DDL algorithm, i.e. INSTANT, INPLACE and COPY.
+	Algorithm string `json:"algorithm,omitempty"`
+	// Rewrite is true if the statement rebuilds the table.
+	Rewrite bool `json:"rewrite,omitempty"`
+	// Scan is true if the statement scans the table, e.g. validating constraints and building indexes.
+	Scan bool `json:"scan,omitempty"`
+	// Table is the locked table.
+	Table string `json:"table,omitempty"`
+	// TableRows and TableSize are from the last synced database schema.
+	TableRows int64 `json:"tableRows,omitempty"`
+	TableSize int64 `json:"tableSize,omitempty"`
+	// BlockingSeconds is the estimated time in seconds that the statement blocks the writes to the table.
+	BlockingSeconds int64 `json:"blockingSeconds,omitempty"`
+}
+
 // Namespace is the namespace for task check result.
 type Namespace string
 
@@ -151,7 +175,7 @@ func IsSyntaxCheckSupported(dbType db.Type) bool {
 
 // IsSQLReviewSupported checks the engine type if SQL review supports it.
 func IsSQLReviewSupported(dbType db.Type) bool {
-	if dbType == db.Postgres || dbType == db.MySQL || dbType == db.TiDB || dbType == db.MariaDB {
+	if dbType == db.Postgres || dbType == db.MySQL || dbType == db.TiDB || dbType == db.MariaDB || dbType == db.Oracle || dbType == db.MSSQL {
 		advisorDB, err := advisorDB.ConvertToAdvisorDBType(string(dbType))
 		if err != nil {
 			return false
@@ -0,0 +1,5 @@
+ALTER TABLE vcs DROP CONSTRAINT IF EXISTS vcs_type_check;
+ALTER TABLE sheet DROP CONSTRAINT IF EXISTS sheet_source_check;
+
+ALTER TABLE vcs ADD CONSTRAINT vcs_type_check CHECK (type IN ('GITLAB', 'GITHUB', 'BITBUCKET', 'GITEA', 'GIT'));
+ALTER TABLE sheet ADD CONSTRAINT sheet_source_check CHECK (source IN ('BYTEBASE', 'GITLAB', 'GITHUB', 'BITBUCKET', 'GITEA', 'GIT', 'BYTEBASE_ARTIFACT'));
@@ -0,0 +1,27 @@
+ALTER TABLE project_webhook ADD COLUMN IF NOT EXISTS secret TEXT NOT NULL DEFAULT '';
+ALTER TABLE project_webhook ADD COLUMN IF NOT EXISTS enabled BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE project_webhook ADD COLUMN IF NOT EXISTS failure_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS project_webhook_delivery (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    project_webhook_id INTEGER NOT NULL REFERENCES project_webhook (id) ON DELETE CASCADE,
+    status TEXT NOT NULL CHECK (status IN ('PENDING', 'SUCCESS', 'FAILED')),
+    attempt INTEGER NOT NULL DEFAULT 0,
+    next_attempt_ts BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_webhook_delivery_project_webhook_id ON project_webhook_delivery(project_webhook_id);
+
+CREATE INDEX IF NOT EXISTS idx_project_webhook_delivery_status_next_attempt_ts ON project_webhook_delivery(status, next_attempt_ts);
+
+ALTER SEQUENCE project_webhook_delivery_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_project_webhook_delivery_updated_ts
+BEFORE
+UPDATE
+    ON project_webhook_delivery FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -0,0 +1,4 @@
+ALTER TABLE project_webhook ADD COLUMN IF NOT EXISTS filter JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE project_webhook ADD COLUMN IF NOT EXISTS payload_template TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE issue ADD COLUMN IF NOT EXISTS risk_level BIGINT NOT NULL DEFAULT 0;
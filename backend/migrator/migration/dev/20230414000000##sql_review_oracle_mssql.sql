@@ -0,0 +1,28 @@
+-- Copy the PostgreSQL rules supported by the standard SQL advisor to Oracle and SQL Server,
+-- so the existing SQL review policies apply to these engines as well.
+UPDATE policy
+SET payload = jsonb_set(
+    payload,
+    '{ruleList}',
+    (payload->'ruleList') || COALESCE((
+        SELECT jsonb_agg(rule || jsonb_build_object('engine', e.engine))
+        FROM jsonb_array_elements(payload->'ruleList') AS rule,
+            (VALUES ('ORACLE'), ('MSSQL')) AS e(engine)
+        WHERE rule->>'engine' = 'POSTGRES'
+            AND rule->>'type' IN (
+                'naming.table',
+                'naming.column',
+                'column.no-null',
+                'table.require-pk',
+                'statement.where.require',
+                'statement.select.no-select-all',
+                'statement.dml-dry-run'
+            )
+    ), '[]'::jsonb)
+)
+WHERE type = 'bb.policy.sql-review'
+    AND jsonb_typeof(payload->'ruleList') = 'array'
+    AND NOT EXISTS (
+        SELECT 1 FROM jsonb_array_elements(payload->'ruleList') AS rule
+        WHERE rule->>'engine' IN ('ORACLE', 'MSSQL')
+    );
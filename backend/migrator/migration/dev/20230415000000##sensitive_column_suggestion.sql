@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS sensitive_column_suggestion (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id),
+    schema_name TEXT NOT NULL,
+    table_name TEXT NOT NULL,
+    column_name TEXT NOT NULL,
+    classification TEXT NOT NULL CHECK (classification IN ('EMAIL', 'PHONE', 'NATIONAL_ID', 'CREDIT_CARD')),
+    confidence DOUBLE PRECISION NOT NULL,
+    mask_type TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL CHECK (status IN ('OPEN', 'ACCEPTED', 'DISMISSED'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_sensitive_column_suggestion_database_id_column ON sensitive_column_suggestion (database_id, schema_name, table_name, column_name);
+
+ALTER SEQUENCE sensitive_column_suggestion_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_sensitive_column_suggestion_updated_ts
+BEFORE
+UPDATE
+    ON sensitive_column_suggestion FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS access_grant (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    issue_id INTEGER NOT NULL REFERENCES issue (id),
+    principal_id INTEGER NOT NULL REFERENCES principal (id),
+    database_id INTEGER NOT NULL REFERENCES db (id),
+    table_list TEXT[] NOT NULL DEFAULT ARRAY[]::TEXT[],
+    duration BIGINT NOT NULL CHECK (duration > 0),
+    expire_ts BIGINT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL CHECK (status IN ('PENDING', 'ACTIVE', 'EXPIRED', 'REVOKED'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_access_grant_issue_id ON access_grant (issue_id);
+
+CREATE INDEX IF NOT EXISTS idx_access_grant_principal_id_database_id ON access_grant (principal_id, database_id);
+
+ALTER SEQUENCE access_grant_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_access_grant_updated_ts
+BEFORE
+UPDATE
+    ON access_grant FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
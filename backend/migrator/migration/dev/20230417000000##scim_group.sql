@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS scim_group (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    external_id TEXT NOT NULL DEFAULT '',
+    display_name TEXT NOT NULL,
+    member_list INTEGER[] NOT NULL DEFAULT ARRAY[]::INTEGER[]
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_scim_group_display_name ON scim_group (display_name);
+
+ALTER SEQUENCE scim_group_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_scim_group_updated_ts
+BEFORE
+UPDATE
+    ON scim_group FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -0,0 +1,3 @@
+ALTER TABLE idp DROP CONSTRAINT IF EXISTS idp_type_check;
+
+ALTER TABLE idp ADD CONSTRAINT idp_type_check CHECK (type IN ('OAUTH2', 'OIDC', 'LDAP'));
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS access_token (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    principal_id INTEGER NOT NULL REFERENCES principal (id),
+    title TEXT NOT NULL,
+    token_hash TEXT NOT NULL,
+    scope_list TEXT[] NOT NULL DEFAULT ARRAY[]::TEXT[],
+    project_id INTEGER REFERENCES project (id),
+    expire_ts BIGINT NOT NULL DEFAULT 0,
+    last_used_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_access_token_token_hash ON access_token (token_hash);
+
+CREATE INDEX IF NOT EXISTS idx_access_token_principal_id ON access_token (principal_id);
+
+ALTER SEQUENCE access_token_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_access_token_updated_ts
+BEFORE
+UPDATE
+    ON access_token FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS db_schema_snapshot (
+    id SERIAL PRIMARY KEY,
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    hash TEXT NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    raw_dump TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_db_schema_snapshot_database_id_created_ts ON db_schema_snapshot(database_id, created_ts);
+
+ALTER SEQUENCE db_schema_snapshot_id_seq RESTART WITH 101;
+
+-- Seed the history with the current schemas. The hash is left empty, so the next change
+-- detected by the schema syncer always appends a snapshot.
+INSERT INTO db_schema_snapshot (creator_id, created_ts, database_id, hash, metadata, raw_dump)
+SELECT updater_id, updated_ts, database_id, '', metadata, raw_dump FROM db_schema;
@@ -0,0 +1,17 @@
+-- table_stat stores the time series of the table statistics recorded by the schema syncer.
+CREATE TABLE table_stat (
+    id BIGSERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    -- schema_name is empty for the engines without schemas, such as MySQL.
+    schema_name TEXT NOT NULL DEFAULT '',
+    table_name TEXT NOT NULL,
+    row_count BIGINT NOT NULL DEFAULT 0,
+    data_size BIGINT NOT NULL DEFAULT 0,
+    index_size BIGINT NOT NULL DEFAULT 0,
+    data_free BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_table_stat_database_id_created_ts ON table_stat(database_id, created_ts);
+
+ALTER SEQUENCE table_stat_id_seq RESTART WITH 101;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS stage_gate (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    pipeline_id INTEGER NOT NULL REFERENCES pipeline (id),
+    stage_id INTEGER NOT NULL REFERENCES stage (id),
+    status TEXT NOT NULL CHECK (status IN ('PENDING', 'PASSED', 'FAILED')),
+    config JSONB NOT NULL DEFAULT '{}',
+    detail TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stage_gate_unique_stage_id ON stage_gate(stage_id);
+
+CREATE INDEX IF NOT EXISTS idx_stage_gate_pipeline_id ON stage_gate(pipeline_id);
+
+CREATE INDEX IF NOT EXISTS idx_stage_gate_status ON stage_gate(status);
+
+ALTER SEQUENCE stage_gate_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_stage_gate_updated_ts
+BEFORE
+UPDATE
+    ON stage_gate FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
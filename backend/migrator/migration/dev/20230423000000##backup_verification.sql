@@ -0,0 +1,3 @@
+ALTER TABLE backup ADD COLUMN IF NOT EXISTS verification_status TEXT NOT NULL DEFAULT 'UNVERIFIED' CHECK (verification_status IN ('UNVERIFIED', 'PASSED', 'FAILED'));
+ALTER TABLE backup ADD COLUMN IF NOT EXISTS verification_detail TEXT NOT NULL DEFAULT '';
+ALTER TABLE backup ADD COLUMN IF NOT EXISTS verified_ts BIGINT NOT NULL DEFAULT 0;
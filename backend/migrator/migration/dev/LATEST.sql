@@ -19,7 +19,7 @@ CREATE TABLE idp (
   resource_id TEXT NOT NULL,
   name TEXT NOT NULL,
   domain TEXT NOT NULL,
-  type TEXT NOT NULL CONSTRAINT idp_type_check CHECK (type IN ('OAUTH2', 'OIDC')),
+  type TEXT NOT NULL CONSTRAINT idp_type_check CHECK (type IN ('OAUTH2', 'OIDC', 'LDAP')),
   -- config stores the corresponding configuration of the IdP, which may vary depending on the type of the IdP.
   config JSONB NOT NULL DEFAULT '{}'
 );
@@ -301,7 +301,17 @@ CREATE TABLE project_webhook (
     type TEXT NOT NULL CHECK (type LIKE 'bb.plugin.webhook.%'),
     name TEXT NOT NULL,
     url TEXT NOT NULL,
-    activity_list TEXT ARRAY NOT NULL
+    activity_list TEXT ARRAY NOT NULL,
+    -- secret is used to sign the payload of the custom webhook.
+    secret TEXT NOT NULL DEFAULT '',
+    -- enabled is set to false after too many consecutive failed deliveries.
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    -- failure_count is the number of consecutive failed deliveries.
+    failure_count INTEGER NOT NULL DEFAULT 0,
+    -- filter stores the conditions of the events that the webhook is interested in.
+    filter JSONB NOT NULL DEFAULT '{}',
+    -- payload_template is the Go template of the custom webhook request body.
+    payload_template TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX idx_project_webhook_project_id ON project_webhook(project_id);
@@ -316,6 +326,32 @@ UPDATE
     ON project_webhook FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- project_webhook_delivery is the outbound delivery queue and history of the project webhooks.
+CREATE TABLE project_webhook_delivery (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    project_webhook_id INTEGER NOT NULL REFERENCES project_webhook (id) ON DELETE CASCADE,
+    status TEXT NOT NULL CHECK (status IN ('PENDING', 'SUCCESS', 'FAILED')),
+    attempt INTEGER NOT NULL DEFAULT 0,
+    next_attempt_ts BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    -- payload saves the webhook context in json format.
+    payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_project_webhook_delivery_project_webhook_id ON project_webhook_delivery(project_webhook_id);
+
+CREATE INDEX idx_project_webhook_delivery_status_next_attempt_ts ON project_webhook_delivery(status, next_attempt_ts);
+
+ALTER SEQUENCE project_webhook_delivery_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_project_webhook_delivery_updated_ts
+BEFORE
+UPDATE
+    ON project_webhook_delivery FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
 -- Instance
 CREATE TABLE instance (
     id SERIAL PRIMARY KEY,
@@ -422,6 +458,40 @@ UPDATE
     ON db_schema FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- db_schema_snapshot stores the immutable history of the database schemas detected by the schema syncer.
+CREATE TABLE db_schema_snapshot (
+    id SERIAL PRIMARY KEY,
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    -- hash is the hash of the schema, which is used to skip the snapshots same as the previous one.
+    hash TEXT NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    raw_dump TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_db_schema_snapshot_database_id_created_ts ON db_schema_snapshot(database_id, created_ts);
+
+ALTER SEQUENCE db_schema_snapshot_id_seq RESTART WITH 101;
+
+-- table_stat stores the time series of the table statistics recorded by the schema syncer.
+CREATE TABLE table_stat (
+    id BIGSERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    -- schema_name is empty for the engines without schemas, such as MySQL.
+    schema_name TEXT NOT NULL DEFAULT '',
+    table_name TEXT NOT NULL,
+    row_count BIGINT NOT NULL DEFAULT 0,
+    data_size BIGINT NOT NULL DEFAULT 0,
+    index_size BIGINT NOT NULL DEFAULT 0,
+    data_free BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_table_stat_database_id_created_ts ON table_stat(database_id, created_ts);
+
+ALTER SEQUENCE table_stat_id_seq RESTART WITH 101;
+
 -- data_source table stores the data source for a particular database
 CREATE TABLE data_source (
     id SERIAL PRIMARY KEY,
@@ -469,11 +539,15 @@ CREATE TABLE backup (
     name TEXT NOT NULL,
     status TEXT NOT NULL CHECK (status IN ('PENDING_CREATE', 'DONE', 'FAILED')),
     type TEXT NOT NULL CHECK (type IN ('MANUAL', 'AUTOMATIC', 'PITR')),
-    storage_backend TEXT NOT NULL CHECK (storage_backend IN ('LOCAL', 'S3', 'GCS', 'OSS')),
+    storage_backend TEXT NOT NULL CHECK (storage_backend IN ('LOCAL', 'S3', 'GCS', 'OSS', 'AZURE', 'FILESYSTEM')),
     migration_history_version TEXT NOT NULL,
     path TEXT NOT NULL,
     comment TEXT NOT NULL DEFAULT '',
-    payload JSONB NOT NULL DEFAULT '{}'
+    payload JSONB NOT NULL DEFAULT '{}',
+    -- verification_status is the result of restoring the backup into a scratch database.
+    verification_status TEXT NOT NULL DEFAULT 'UNVERIFIED' CHECK (verification_status IN ('UNVERIFIED', 'PASSED', 'FAILED')),
+    verification_detail TEXT NOT NULL DEFAULT '',
+    verified_ts BIGINT NOT NULL DEFAULT 0
 );
 
 CREATE INDEX idx_backup_database_id ON backup(database_id);
@@ -563,6 +637,34 @@ UPDATE
     ON stage FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- stage_gate stores the gate of a stage copied from the deployment config when the pipeline is created.
+-- The tasks of the later stages don't run until the gate passes.
+CREATE TABLE stage_gate (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    pipeline_id INTEGER NOT NULL REFERENCES pipeline (id),
+    stage_id INTEGER NOT NULL REFERENCES stage (id),
+    status TEXT NOT NULL CHECK (status IN ('PENDING', 'PASSED', 'FAILED')),
+    config JSONB NOT NULL DEFAULT '{}',
+    detail TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX idx_stage_gate_unique_stage_id ON stage_gate(stage_id);
+
+CREATE INDEX idx_stage_gate_pipeline_id ON stage_gate(pipeline_id);
+
+CREATE INDEX idx_stage_gate_status ON stage_gate(status);
+
+ALTER SEQUENCE stage_gate_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_stage_gate_updated_ts
+BEFORE
+UPDATE
+    ON stage_gate FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
 -- task table stores the task for the stage
 CREATE TABLE task (
     id SERIAL PRIMARY KEY,
@@ -695,7 +797,9 @@ CREATE TABLE issue (
     -- While changing assignee_id, one should only change it to a non-robot DBA/owner.
     assignee_id INTEGER NOT NULL REFERENCES principal (id),
     assignee_need_attention BOOLEAN NOT NULL DEFAULT FALSE, 
-    payload JSONB NOT NULL DEFAULT '{}'
+    payload JSONB NOT NULL DEFAULT '{}',
+    -- risk_level is the highest risk level of the tasks found by the approval runner.
+    risk_level BIGINT NOT NULL DEFAULT 0
 );
 
 CREATE INDEX idx_issue_project_id ON issue(project_id);
@@ -857,7 +961,7 @@ CREATE TABLE vcs (
     updater_id INTEGER NOT NULL REFERENCES principal (id),
     updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
     name TEXT NOT NULL,
-    type TEXT NOT NULL CHECK (type IN ('GITLAB', 'GITHUB', 'BITBUCKET')),
+    type TEXT NOT NULL CHECK (type IN ('GITLAB', 'GITHUB', 'BITBUCKET', 'GITEA', 'GIT')),
     instance_url TEXT NOT NULL CHECK ((instance_url LIKE 'http://%' OR instance_url LIKE 'https://%') AND instance_url = rtrim(instance_url, '/')),
     api_url TEXT NOT NULL CHECK ((api_url LIKE 'http://%' OR api_url LIKE 'https://%') AND api_url = rtrim(api_url, '/')),
     application_id TEXT NOT NULL,
@@ -1070,7 +1174,7 @@ CREATE TABLE sheet (
     name TEXT NOT NULL,
     statement TEXT NOT NULL,
     visibility TEXT NOT NULL CHECK (visibility IN ('PRIVATE', 'PROJECT', 'PUBLIC')) DEFAULT 'PRIVATE',
-    source TEXT NOT NULL CONSTRAINT sheet_source_check CHECK (source IN ('BYTEBASE', 'GITLAB', 'GITHUB', 'BITBUCKET', 'BYTEBASE_ARTIFACT')) DEFAULT 'BYTEBASE',
+    source TEXT NOT NULL CONSTRAINT sheet_source_check CHECK (source IN ('BYTEBASE', 'GITLAB', 'GITHUB', 'BITBUCKET', 'GITEA', 'GIT', 'BYTEBASE_ARTIFACT')) DEFAULT 'BYTEBASE',
     type TEXT NOT NULL CHECK (type IN ('SQL')) DEFAULT 'SQL',
     payload JSONB NOT NULL DEFAULT '{}'
 );
@@ -1185,3 +1289,107 @@ BEFORE
 UPDATE
     ON slow_query FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- sensitive_column_suggestion stores the sensitive columns found by the classification scanner.
+-- The suggestions are reviewed by DBAs, and the accepted ones are added to the sensitive data policy of the database.
+CREATE TABLE sensitive_column_suggestion (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id),
+    schema_name TEXT NOT NULL,
+    table_name TEXT NOT NULL,
+    column_name TEXT NOT NULL,
+    classification TEXT NOT NULL CHECK (classification IN ('EMAIL', 'PHONE', 'NATIONAL_ID', 'CREDIT_CARD')),
+    confidence DOUBLE PRECISION NOT NULL,
+    mask_type TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL CHECK (status IN ('OPEN', 'ACCEPTED', 'DISMISSED'))
+);
+
+CREATE UNIQUE INDEX uk_sensitive_column_suggestion_database_id_column ON sensitive_column_suggestion (database_id, schema_name, table_name, column_name);
+
+ALTER SEQUENCE sensitive_column_suggestion_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_sensitive_column_suggestion_updated_ts
+BEFORE
+UPDATE
+    ON sensitive_column_suggestion FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- access_grant stores the time-boxed access to query a database requested by a query access issue.
+-- The grant becomes ACTIVE after the issue is approved, and EXPIRED after expire_ts.
+CREATE TABLE access_grant (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    issue_id INTEGER NOT NULL REFERENCES issue (id),
+    principal_id INTEGER NOT NULL REFERENCES principal (id),
+    database_id INTEGER NOT NULL REFERENCES db (id),
+    table_list TEXT[] NOT NULL DEFAULT ARRAY[]::TEXT[],
+    duration BIGINT NOT NULL CHECK (duration > 0),
+    expire_ts BIGINT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL CHECK (status IN ('PENDING', 'ACTIVE', 'EXPIRED', 'REVOKED'))
+);
+
+CREATE UNIQUE INDEX uk_access_grant_issue_id ON access_grant (issue_id);
+
+CREATE INDEX idx_access_grant_principal_id_database_id ON access_grant (principal_id, database_id);
+
+ALTER SEQUENCE access_grant_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_access_grant_updated_ts
+BEFORE
+UPDATE
+    ON access_grant FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- scim_group stores the groups provisioned by the identity provider through SCIM.
+-- The groups are mapped to the workspace roles and project memberships by the bb.workspace.scim setting.
+-- member_list is the principal IDs of the group members.
+CREATE TABLE scim_group (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    external_id TEXT NOT NULL DEFAULT '',
+    display_name TEXT NOT NULL,
+    member_list INTEGER[] NOT NULL DEFAULT ARRAY[]::INTEGER[]
+);
+
+CREATE UNIQUE INDEX uk_scim_group_display_name ON scim_group (display_name);
+
+ALTER SEQUENCE scim_group_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_scim_group_updated_ts
+BEFORE
+UPDATE
+    ON scim_group FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- access_token stores the personal access tokens of the users and service accounts.
+-- Only the SHA-256 hash of the token is stored, the token itself is returned once on creation.
+-- project_id restricts the token to the project if it's set, and expire_ts 0 means the token never expires.
+CREATE TABLE access_token (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    principal_id INTEGER NOT NULL REFERENCES principal (id),
+    title TEXT NOT NULL,
+    token_hash TEXT NOT NULL,
+    scope_list TEXT[] NOT NULL DEFAULT ARRAY[]::TEXT[],
+    project_id INTEGER REFERENCES project (id),
+    expire_ts BIGINT NOT NULL DEFAULT 0,
+    last_used_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX uk_access_token_token_hash ON access_token (token_hash);
+
+CREATE INDEX idx_access_token_principal_id ON access_token (principal_id);
+
+ALTER SEQUENCE access_token_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_access_token_updated_ts
+BEFORE
+UPDATE
+    ON access_token FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -314,11 +314,37 @@ const (
 	// PostgreSQLDisallowAddNotNull is an advisor type for PostgreSQl to disallow add not null.
 	PostgreSQLDisallowAddNotNull Type = "bb.plugin.advisor.postgresql.statement.disallow-add-not-null"
 
+	// PostgreSQLCustomRule is an advisor type for PostgreSQL custom rules defined by expressions.
+	PostgreSQLCustomRule Type = "bb.plugin.advisor.postgresql.custom"
+
 	// PostgreSQLTableDropNamingConvention is an advisor type for PostgreSQL table drop with naming convention.
 	PostgreSQLTableDropNamingConvention Type = "bb.plugin.advisor.postgresql.table.drop-naming-convention"
 
 	// PostgreSQLCollationAllowlist is an advisor type for PostgreSQL collation allowlist.
 	PostgreSQLCollationAllowlist Type = "bb.plugin.advisor.postgresql.collation.allowlist"
+
+	// Standard SQL Advisor for Oracle and SQL Server.
+
+	// StandardNamingTableConvention is an advisor type for standard SQL table naming convention.
+	StandardNamingTableConvention Type = "bb.plugin.advisor.standard.naming.table"
+
+	// StandardNamingColumnConvention is an advisor type for standard SQL column naming convention.
+	StandardNamingColumnConvention Type = "bb.plugin.advisor.standard.naming.column"
+
+	// StandardTableRequirePK is an advisor type for standard SQL table require primary key.
+	StandardTableRequirePK Type = "bb.plugin.advisor.standard.table.require-pk"
+
+	// StandardWhereRequirement is an advisor type for standard SQL WHERE clause requirement.
+	StandardWhereRequirement Type = "bb.plugin.advisor.standard.where.require"
+
+	// StandardNoSelectAll is an advisor type for standard SQL no select all.
+	StandardNoSelectAll Type = "bb.plugin.advisor.standard.select.no-select-all"
+
+	// StandardColumnNoNull is an advisor type for standard SQL column no NULL value.
+	StandardColumnNoNull Type = "bb.plugin.advisor.standard.column.no-null"
+
+	// StandardStatementDMLDryRun is an advisor type for standard SQL DML dry run.
+	StandardStatementDMLDryRun Type = "bb.plugin.advisor.standard.statement.dml-dry-run"
 )
 
 // Advice is the result of an advisor.
@@ -447,7 +473,7 @@ func IsSyntaxCheckSupported(dbType db.Type) bool {
 // IsSQLReviewSupported checks the engine type if SQL review supports it.
 func IsSQLReviewSupported(dbType db.Type) bool {
 	switch dbType {
-	case db.MySQL, db.TiDB, db.MariaDB, db.Postgres:
+	case db.MySQL, db.TiDB, db.MariaDB, db.Postgres, db.Oracle, db.MSSQL:
 		return true
 	}
 	return false
@@ -6,6 +6,7 @@ package catalog
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
@@ -350,6 +351,21 @@ func (table *TableState) CountIndex() int {
 	return len(table.indexSet)
 }
 
+// ColumnList returns the column list ordered by the column position.
+func (table *TableState) ColumnList() []*ColumnState {
+	var columnList []*ColumnState
+	for _, column := range table.columnSet {
+		columnList = append(columnList, column)
+	}
+	sort.Slice(columnList, func(i, j int) bool {
+		if columnList[i].position != nil && columnList[j].position != nil && *columnList[i].position != *columnList[j].position {
+			return *columnList[i].position < *columnList[j].position
+		}
+		return columnList[i].name < columnList[j].name
+	})
+	return columnList
+}
+
 func (table *TableState) copy() *TableState {
 	return &TableState{
 		name:      table.name,
@@ -460,6 +476,11 @@ func (col *ColumnState) copy() *ColumnState {
 	}
 }
 
+// Name returns the name for the column.
+func (col *ColumnState) Name() string {
+	return col.name
+}
+
 // Nullable returns nullable for the column.
 func (col *ColumnState) Nullable() bool {
 	return col.nullable != nil && *col.nullable
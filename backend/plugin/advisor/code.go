@@ -129,6 +129,15 @@ const (
 
 	// 1301 ~ 1399 comment error code.
 	CommentTooLong Code = 1301
+
+	// 1401 ~ 1499 custom rule error code.
+	CustomRuleViolation       Code = 1401
+	CustomRuleEvaluationError Code = 1402
+
+	// 1501 ~ 1599 query plan error code.
+	QueryPlanFullTableScan Code = 1501
+	QueryPlanFilesort      Code = 1502
+	QueryPlanNestedLoop    Code = 1503
 )
 
 // Int returns the int type of code.
@@ -0,0 +1,110 @@
+package advisor
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/google/cel-go/cel"
+	"github.com/pkg/errors"
+)
+
+// CustomRuleFactors are the variables for the custom SQL review rule expressions.
+// Each table or column is a map with the keys:
+//
+//	table:  schema, name, columns
+//	column: name, type, nullable, has_default
+var CustomRuleFactors = []cel.EnvOption{
+	// sql_type is the statement type, such as CREATE_TABLE, ALTER_TABLE, INSERT.
+	cel.Variable("sql_type", cel.StringType),
+	// statement is the statement text.
+	cel.Variable("statement", cel.StringType),
+	// table is the table the statement works on. Its columns are the ones after applying the whole SQL.
+	cel.Variable("table", cel.MapType(cel.StringType, cel.DynType)),
+	// columns are the columns defined or changed by the statement.
+	cel.Variable("columns", cel.ListType(cel.MapType(cel.StringType, cel.DynType))),
+}
+
+// CustomRulePayload is the payload for custom rule.
+type CustomRulePayload struct {
+	Title string `json:"title"`
+	// Expression is the CEL expression matching the violating statements.
+	Expression string `json:"expression"`
+	// Message is the Go template of the advice content, executed with the expression variables.
+	// For example, "Table {{ .table.name }} requires column tenant_id".
+	Message string `json:"message"`
+}
+
+// CustomRule is the compiled custom rule.
+type CustomRule struct {
+	Title   string
+	program cel.Program
+	message *template.Template
+}
+
+// NewCustomRule unmarshals the payload and compiles it as a custom rule.
+func NewCustomRule(payload string) (*CustomRule, error) {
+	var p CustomRulePayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal custom rule payload %q", payload)
+	}
+	if p.Title == "" || p.Expression == "" {
+		return nil, errors.Errorf("invalid custom rule payload, title or expression cannot be empty")
+	}
+
+	e, err := cel.NewEnv(CustomRuleFactors...)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := e.Compile(p.Expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, errors.Wrapf(issues.Err(), "failed to compile custom rule expression %q", p.Expression)
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, errors.Errorf("custom rule expression %q must return bool, but got %s", p.Expression, ast.OutputType())
+	}
+	program, err := e.Program(ast)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build custom rule expression %q", p.Expression)
+	}
+
+	rule := &CustomRule{
+		Title:   p.Title,
+		program: program,
+	}
+	if p.Message != "" {
+		message, err := template.New("message").Option("missingkey=zero").Parse(p.Message)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse custom rule message %q", p.Message)
+		}
+		rule.message = message
+	}
+	return rule, nil
+}
+
+// Match returns true if the statement described by the variables violates the rule.
+func (r *CustomRule) Match(variables map[string]any) (bool, error) {
+	res, _, err := r.program.Eval(variables)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to evaluate custom rule %q", r.Title)
+	}
+	val, err := res.ConvertToNative(reflect.TypeOf(false))
+	if err != nil {
+		return false, errors.Wrap(err, "expect bool result")
+	}
+	boolVal, ok := val.(bool)
+	return ok && boolVal, nil
+}
+
+// Message returns the advice content for the violating statement.
+func (r *CustomRule) Message(variables map[string]any) (string, error) {
+	if r.message == nil {
+		return "", nil
+	}
+	var buf strings.Builder
+	if err := r.message.Execute(&buf, variables); err != nil {
+		return "", errors.Wrapf(err, "failed to render custom rule message for %q", r.Title)
+	}
+	return buf.String(), nil
+}
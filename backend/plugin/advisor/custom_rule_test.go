@@ -0,0 +1,88 @@
+package advisor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCustomRule(t *testing.T) {
+	tests := []struct {
+		payload CustomRulePayload
+		err     bool
+	}{
+		{
+			payload: CustomRulePayload{Title: "no json", Expression: `columns.exists(c, c.type == "json")`},
+		},
+		{
+			payload: CustomRulePayload{Expression: `sql_type == "DELETE"`},
+			err:     true,
+		},
+		{
+			// Syntax error.
+			payload: CustomRulePayload{Title: "invalid", Expression: `sql_type ==`},
+			err:     true,
+		},
+		{
+			// Undeclared variable.
+			payload: CustomRulePayload{Title: "invalid", Expression: `environment == "prod"`},
+			err:     true,
+		},
+		{
+			// Non-bool expression.
+			payload: CustomRulePayload{Title: "invalid", Expression: `table.name`},
+			err:     true,
+		},
+		{
+			payload: CustomRulePayload{Title: "invalid", Expression: `true`, Message: "{{ .table.name "},
+			err:     true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		payload, err := json.Marshal(test.payload)
+		a.NoError(err)
+		_, err = NewCustomRule(string(payload))
+		if test.err {
+			a.Error(err, test.payload.Expression)
+		} else {
+			a.NoError(err, test.payload.Expression)
+		}
+	}
+}
+
+func TestCustomRuleMatch(t *testing.T) {
+	a := require.New(t)
+	payload, err := json.Marshal(CustomRulePayload{
+		Title:      "Billing tables require tenant_id",
+		Expression: `table.schema == "billing" && !table.columns.exists(c, c.name == "tenant_id")`,
+		Message:    "Table {{ .table.schema }}.{{ .table.name }} requires column tenant_id",
+	})
+	a.NoError(err)
+	rule, err := NewCustomRule(string(payload))
+	a.NoError(err)
+
+	variables := map[string]any{
+		"sql_type":  "CREATE_TABLE",
+		"statement": "CREATE TABLE billing.invoice(id int)",
+		"table": map[string]any{
+			"schema":  "billing",
+			"name":    "invoice",
+			"columns": []map[string]any{{"name": "id", "type": "integer", "nullable": true, "has_default": false}},
+		},
+		"columns": []map[string]any{},
+	}
+	match, err := rule.Match(variables)
+	a.NoError(err)
+	a.True(match)
+	message, err := rule.Message(variables)
+	a.NoError(err)
+	a.Equal("Table billing.invoice requires column tenant_id", message)
+
+	variables["table"].(map[string]any)["schema"] = "public"
+	match, err = rule.Match(variables)
+	a.NoError(err)
+	a.False(match)
+}
@@ -21,6 +21,10 @@ const (
 	TiDB Type = "TIDB"
 	// MariaDB is the database type for MariaDB.
 	MariaDB Type = "MARIADB"
+	// Oracle is the database type for Oracle.
+	Oracle Type = "ORACLE"
+	// MSSQL is the database type for MS SQL Server.
+	MSSQL Type = "MSSQL"
 )
 
 // ConvertToAdvisorDBType will convert db type into advisor db type.
@@ -32,6 +36,10 @@ func ConvertToAdvisorDBType(dbType string) (Type, error) {
 		return Postgres, nil
 	case string(TiDB):
 		return TiDB, nil
+	case string(Oracle):
+		return Oracle, nil
+	case string(MSSQL):
+		return MSSQL, nil
 	}
 
 	return "", errors.Errorf("unsupported db type %s for advisor", dbType)
@@ -0,0 +1,278 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+)
+
+var (
+	_ advisor.Advisor = (*CustomRuleAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLCustomRule, &CustomRuleAdvisor{})
+}
+
+// CustomRuleAdvisor is the advisor checking for the custom rule defined by an expression.
+type CustomRuleAdvisor struct {
+}
+
+// Check evaluates the custom rule expression for each statement.
+func (*CustomRuleAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	// The broken rule is reported as an advice rather than an error, so that it doesn't block the other rules.
+	rule, err := advisor.NewCustomRule(ctx.Rule.Payload)
+	if err != nil {
+		return []advisor.Advice{
+			{
+				Status:  level,
+				Code:    advisor.CustomRuleEvaluationError,
+				Title:   "Invalid custom rule",
+				Content: err.Error(),
+			},
+		}, nil
+	}
+
+	var adviceList []advisor.Advice
+	for _, stmt := range stmts {
+		variables := newCustomRuleVariables(ctx.Catalog, stmt)
+		match, err := rule.Match(variables)
+		if err != nil {
+			adviceList = append(adviceList, newCustomRuleEvaluationAdvice(level, rule, stmt, err))
+			continue
+		}
+		if !match {
+			continue
+		}
+		content, err := rule.Message(variables)
+		if err != nil {
+			adviceList = append(adviceList, newCustomRuleEvaluationAdvice(level, rule, stmt, err))
+			continue
+		}
+		if content == "" {
+			content = fmt.Sprintf("%q violates the rule %q", stmt.Text(), rule.Title)
+		}
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  level,
+			Code:    advisor.CustomRuleViolation,
+			Title:   rule.Title,
+			Content: content,
+			Line:    stmt.LastLine(),
+		})
+	}
+
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList, nil
+}
+
+func newCustomRuleEvaluationAdvice(level advisor.Status, rule *advisor.CustomRule, stmt ast.Node, err error) advisor.Advice {
+	return advisor.Advice{
+		Status:  level,
+		Code:    advisor.CustomRuleEvaluationError,
+		Title:   rule.Title,
+		Content: fmt.Sprintf("Failed to evaluate the rule %q for %q: %v", rule.Title, stmt.Text(), err),
+		Line:    stmt.LastLine(),
+	}
+}
+
+// newCustomRuleVariables builds the variables of advisor.CustomRuleFactors for the statement.
+func newCustomRuleVariables(finder *catalog.Finder, node ast.Node) map[string]any {
+	var table *ast.TableDef
+	var statementColumnList []*ast.ColumnDef
+	var changedColumnList []string
+	switch node := node.(type) {
+	case *ast.CreateTableStmt:
+		table = node.Name
+		statementColumnList = node.ColumnList
+	case *ast.AlterTableStmt:
+		table = node.Table
+		for _, item := range node.AlterItemList {
+			switch item := item.(type) {
+			case *ast.AddColumnListStmt:
+				statementColumnList = append(statementColumnList, item.ColumnList...)
+			case *ast.AlterColumnTypeStmt:
+				changedColumnList = append(changedColumnList, item.ColumnName)
+			case *ast.RenameColumnStmt:
+				changedColumnList = append(changedColumnList, item.NewName)
+			case *ast.SetNotNullStmt:
+				changedColumnList = append(changedColumnList, item.ColumnName)
+			case *ast.DropNotNullStmt:
+				changedColumnList = append(changedColumnList, item.ColumnName)
+			case *ast.SetDefaultStmt:
+				changedColumnList = append(changedColumnList, item.ColumnName)
+			case *ast.DropDefaultStmt:
+				changedColumnList = append(changedColumnList, item.ColumnName)
+			}
+		}
+	case *ast.DropTableStmt:
+		if len(node.TableList) > 0 {
+			table = node.TableList[0]
+		}
+	case *ast.CreateIndexStmt:
+		table = node.Index.Table
+	case *ast.InsertStmt:
+		table = node.Table
+	case *ast.UpdateStmt:
+		table = node.Table
+	case *ast.DeleteStmt:
+		table = node.Table
+	}
+
+	tableValue := map[string]any{
+		"schema":  "",
+		"name":    "",
+		"columns": []map[string]any{},
+	}
+	columns := []map[string]any{}
+	if table == nil {
+		return map[string]any{
+			"sql_type":  getStatementType(node),
+			"statement": node.Text(),
+			"table":     tableValue,
+			"columns":   columns,
+		}
+	}
+
+	schema := normalizeSchemaName(table.Schema)
+	tableValue["schema"] = schema
+	tableValue["name"] = table.Name
+
+	// The table state after applying the whole SQL, it's missing if the table is dropped.
+	var tableState *catalog.TableState
+	if finder != nil && finder.Final.Usable() {
+		tableState = finder.Final.FindTable(&catalog.TableFind{SchemaName: schema, TableName: table.Name})
+	}
+	columnStateMap := make(map[string]*catalog.ColumnState)
+	if tableState != nil {
+		tableColumns := []map[string]any{}
+		for _, column := range tableState.ColumnList() {
+			columnStateMap[column.Name()] = column
+			tableColumns = append(tableColumns, convertColumnState(column))
+		}
+		tableValue["columns"] = tableColumns
+	}
+
+	primaryKeySet := make(map[string]bool)
+	if createTable, ok := node.(*ast.CreateTableStmt); ok {
+		for _, constraint := range createTable.ConstraintList {
+			if constraint.Type == ast.ConstraintTypePrimary {
+				for _, key := range constraint.KeyList {
+					primaryKeySet[key] = true
+				}
+			}
+		}
+	}
+	for _, column := range statementColumnList {
+		if columnState, ok := columnStateMap[column.ColumnName]; ok {
+			columns = append(columns, convertColumnState(columnState))
+		} else {
+			columns = append(columns, convertColumnDef(column, primaryKeySet[column.ColumnName]))
+		}
+	}
+	for _, name := range changedColumnList {
+		if columnState, ok := columnStateMap[name]; ok {
+			columns = append(columns, convertColumnState(columnState))
+		}
+	}
+	if _, ok := node.(*ast.CreateTableStmt); ok && tableState == nil {
+		tableValue["columns"] = columns
+	}
+
+	return map[string]any{
+		"sql_type":  getStatementType(node),
+		"statement": node.Text(),
+		"table":     tableValue,
+		"columns":   columns,
+	}
+}
+
+func convertColumnState(column *catalog.ColumnState) map[string]any {
+	return map[string]any{
+		"name":        column.Name(),
+		"type":        column.Type(),
+		"nullable":    column.Nullable(),
+		"has_default": column.HasDefault(),
+	}
+}
+
+func convertColumnDef(column *ast.ColumnDef, primaryKey bool) map[string]any {
+	tp, err := parser.Deparse(parser.Postgres, parser.DeparseContext{}, column.Type)
+	if err != nil {
+		tp = column.Type.Text()
+	}
+	nullable := !primaryKey
+	hasDefault := false
+	switch strings.ToLower(tp) {
+	case "serial", "smallserial", "bigserial":
+		hasDefault = true
+	}
+	for _, constraint := range column.ConstraintList {
+		switch constraint.Type {
+		case ast.ConstraintTypeNotNull, ast.ConstraintTypePrimary:
+			nullable = false
+		case ast.ConstraintTypeDefault:
+			hasDefault = true
+		}
+	}
+	return map[string]any{
+		"name":        column.ColumnName,
+		"type":        tp,
+		"nullable":    nullable,
+		"has_default": hasDefault,
+	}
+}
+
+func getStatementType(node ast.Node) string {
+	switch node := node.(type) {
+	case *ast.CreateTableStmt:
+		if node.Name.Type == ast.TableTypeView {
+			return "CREATE_VIEW"
+		}
+		return "CREATE_TABLE"
+	case *ast.AlterTableStmt:
+		if node.Table.Type == ast.TableTypeView {
+			return "ALTER_VIEW"
+		}
+		return "ALTER_TABLE"
+	case *ast.DropTableStmt:
+		return "DROP_TABLE"
+	case *ast.CreateIndexStmt:
+		return "CREATE_INDEX"
+	case *ast.DropIndexStmt:
+		return "DROP_INDEX"
+	case *ast.CreateSchemaStmt:
+		return "CREATE_SCHEMA"
+	case *ast.DropSchemaStmt:
+		return "DROP_SCHEMA"
+	case *ast.InsertStmt:
+		return "INSERT"
+	case *ast.UpdateStmt:
+		return "UPDATE"
+	case *ast.DeleteStmt:
+		return "DELETE"
+	case *ast.SelectStmt:
+		return "SELECT"
+	}
+	return "UNKNOWN"
+}
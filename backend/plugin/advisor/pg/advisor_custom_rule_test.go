@@ -0,0 +1,34 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+)
+
+func TestCustomRuleEvaluationError(t *testing.T) {
+	a := require.New(t)
+	payload, err := json.Marshal(advisor.CustomRulePayload{
+		Title: "First column is id",
+		// The statement defines no column, so the index is out of range.
+		Expression: `sql_type == "CREATE_TABLE" && columns[0].name != "id"`,
+	})
+	a.NoError(err)
+
+	adviceList, err := (&CustomRuleAdvisor{}).Check(advisor.Context{
+		Rule: &advisor.SQLReviewRule{
+			Type:    advisor.SchemaRuleCustom,
+			Level:   advisor.SchemaRuleLevelWarning,
+			Payload: string(payload),
+		},
+	}, "CREATE TABLE t();")
+	a.NoError(err)
+	a.Len(adviceList, 1)
+	a.Equal(advisor.CustomRuleEvaluationError, adviceList[0].Code)
+	a.Equal(advisor.Warn, adviceList[0].Status)
+	a.Equal("First column is id", adviceList[0].Title)
+	a.Equal(1, adviceList[0].Line)
+}
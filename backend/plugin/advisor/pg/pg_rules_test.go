@@ -47,6 +47,7 @@ func TestPostgreSQLRules(t *testing.T) {
 		advisor.SchemaRuleCreateIndexConcurrently,
 		advisor.SchemaRuleStatementAddCheckNotValid,
 		advisor.SchemaRuleStatementDisallowAddNotNull,
+		advisor.SchemaRuleCustom,
 	}
 
 	for _, rule := range pgRules {
@@ -18,7 +18,7 @@ import (
 )
 
 // How to add a SQL review rule:
-//   1. Implement an advisor.(plugin/advisor/mysql or plugin/advisor/pg)
+//   1. Implement an advisor.(plugin/advisor/mysql, plugin/advisor/pg or plugin/advisor/standard)
 //   2. Register this advisor in map[db.Type][AdvisorType].(plugin/advisor.go)
 //   3. Add advisor error code if needed(plugin/advisor/code.go).
 //   4. Map SQLReviewRuleType to advisor.Type in getAdvisorTypeByRule(current file).
@@ -158,6 +158,10 @@ const (
 	// SchemaRuleCommentLength limit comment length.
 	SchemaRuleCommentLength SQLReviewRuleType = "system.comment.length"
 
+	// SchemaRuleCustom is the custom rule defined by an expression.
+	// A policy may have multiple custom rules, identified by their payloads.
+	SchemaRuleCustom SQLReviewRuleType = "custom"
+
 	// TableNameTemplateToken is the token for table name.
 	TableNameTemplateToken = "{{table}}"
 	// ColumnListTemplateToken is the token for column name list.
@@ -270,6 +274,10 @@ func (rule *SQLReviewRule) Validate() error {
 		if _, err := UnmarshalStringArrayTypeRulePayload(rule.Payload); err != nil {
 			return err
 		}
+	case SchemaRuleCustom:
+		if _, err := NewCustomRule(rule.Payload); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -759,6 +767,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine db.Type) (Type, err
 			return MySQLWhereRequirement, nil
 		case db.Postgres:
 			return PostgreSQLWhereRequirement, nil
+		case db.Oracle, db.MSSQL:
+			return StandardWhereRequirement, nil
 		}
 	case SchemaRuleStatementNoLeadingWildcardLike:
 		switch engine {
@@ -773,6 +783,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine db.Type) (Type, err
 			return MySQLNoSelectAll, nil
 		case db.Postgres:
 			return PostgreSQLNoSelectAll, nil
+		case db.Oracle, db.MSSQL:
+			return StandardNoSelectAll, nil
 		}
 	case SchemaRuleSchemaBackwardCompatibility:
 		switch engine {
@@ -787,6 +799,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine db.Type) (Type, err
 			return MySQLNamingTableConvention, nil
 		case db.Postgres:
 			return PostgreSQLNamingTableConvention, nil
+		case db.Oracle, db.MSSQL:
+			return StandardNamingTableConvention, nil
 		}
 	case SchemaRuleIDXNaming:
 		switch engine {
@@ -819,6 +833,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine db.Type) (Type, err
 			return MySQLNamingColumnConvention, nil
 		case db.Postgres:
 			return PostgreSQLNamingColumnConvention, nil
+		case db.Oracle, db.MSSQL:
+			return StandardNamingColumnConvention, nil
 		}
 	case SchemaRuleAutoIncrementColumnNaming:
 		switch engine {
@@ -838,6 +854,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine db.Type) (Type, err
 			return MySQLColumnNoNull, nil
 		case db.Postgres:
 			return PostgreSQLColumnNoNull, nil
+		case db.Oracle, db.MSSQL:
+			return StandardColumnNoNull, nil
 		}
 	case SchemaRuleColumnDisallowChangeType:
 		switch engine {
@@ -918,6 +936,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine db.Type) (Type, err
 			return MySQLTableRequirePK, nil
 		case db.Postgres:
 			return PostgreSQLTableRequirePK, nil
+		case db.Oracle, db.MSSQL:
+			return StandardTableRequirePK, nil
 		}
 	case SchemaRuleTableNoFK:
 		switch engine {
@@ -1069,6 +1089,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine db.Type) (Type, err
 			return MySQLStatementDMLDryRun, nil
 		case db.Postgres:
 			return PostgreSQLStatementDMLDryRun, nil
+		case db.Oracle, db.MSSQL:
+			return StandardStatementDMLDryRun, nil
 		}
 	case SchemaRuleStatementDisallowAddColumnWithDefault:
 		if engine == db.Postgres {
@@ -1086,6 +1108,10 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine db.Type) (Type, err
 		if engine == db.Postgres {
 			return PostgreSQLCommentConvention, nil
 		}
+	case SchemaRuleCustom:
+		if engine == db.Postgres {
+			return PostgreSQLCustomRule, nil
+		}
 	}
 	return Fake, errors.Errorf("unknown SQL review rule type %v for %v", ruleType, engine)
 }
@@ -0,0 +1,92 @@
+package standard
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnNoNullAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Oracle, advisor.StandardColumnNoNull, &ColumnNoNullAdvisor{engine: db.Oracle})
+	advisor.Register(db.MSSQL, advisor.StandardColumnNoNull, &ColumnNoNullAdvisor{engine: db.MSSQL})
+}
+
+// ColumnNoNullAdvisor is the advisor checking for column no NULL value.
+type ColumnNoNullAdvisor struct {
+	engine db.Type
+}
+
+// Check checks for column no NULL value.
+func (a *ColumnNoNullAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmtList, errAdvice := parseStatement(a.engine, statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &columnNoNullChecker{
+		level:  level,
+		title:  string(ctx.Rule.Type),
+		engine: a.engine,
+	}
+	for _, stmt := range stmtList {
+		checker.check(stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type columnNoNullChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	engine     db.Type
+}
+
+func (checker *columnNoNullChecker) check(stmt *statement) {
+	var table tableName
+	var nullableColumns []*columnDef
+	if node := stmt.createTable(); node != nil && node.elementList != nil {
+		table = node.table
+		nullableColumns = node.elementList.nullableColumnList()
+	}
+	if node := stmt.alterTable(); node != nil {
+		table = node.table
+		if node.addElementList != nil {
+			nullableColumns = node.addElementList.nullableColumnList()
+		}
+		for _, column := range node.modifyColumnList {
+			// Oracle MODIFY keeps the nullability unless it's declared,
+			// but SQL Server ALTER COLUMN makes the column nullable without NOT NULL.
+			if column.null || (checker.engine == db.MSSQL && !column.notNull) {
+				nullableColumns = append(nullableColumns, column)
+			}
+		}
+	}
+
+	for _, column := range nullableColumns {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  checker.level,
+			Code:    advisor.ColumnCannotNull,
+			Title:   checker.title,
+			Content: fmt.Sprintf("Column %q in %q cannot have NULL value", column.name.name, table),
+			Line:    stmt.line,
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package standard
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+)
+
+var (
+	_ advisor.Advisor = (*NamingColumnConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Oracle, advisor.StandardNamingColumnConvention, &NamingColumnConventionAdvisor{engine: db.Oracle})
+	advisor.Register(db.MSSQL, advisor.StandardNamingColumnConvention, &NamingColumnConventionAdvisor{engine: db.MSSQL})
+}
+
+// NamingColumnConventionAdvisor is the advisor checking for column naming convention.
+type NamingColumnConventionAdvisor struct {
+	engine db.Type
+}
+
+// Check checks for column naming convention.
+func (a *NamingColumnConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmtList, errAdvice := parseStatement(a.engine, statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, maxLength, err := advisor.UnamrshalNamingRulePayloadAsRegexp(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &namingColumnConventionChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		format:    format,
+		maxLength: maxLength,
+	}
+	for _, stmt := range stmtList {
+		checker.check(stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type namingColumnConventionChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	format     *regexp.Regexp
+	maxLength  int
+}
+
+func (checker *namingColumnConventionChecker) check(stmt *statement) {
+	var table tableName
+	var columnNames []string
+	if node := stmt.createTable(); node != nil && node.elementList != nil {
+		table = node.table
+		for _, column := range node.elementList.columnList {
+			columnNames = append(columnNames, column.name.name)
+		}
+	}
+	if node := stmt.alterTable(); node != nil {
+		table = node.table
+		if node.addElementList != nil {
+			for _, column := range node.addElementList.columnList {
+				columnNames = append(columnNames, column.name.name)
+			}
+		}
+		for _, rename := range node.renameColumnList {
+			columnNames = append(columnNames, rename.new.name)
+		}
+	}
+
+	for _, columnName := range columnNames {
+		if !checker.format.MatchString(columnName) {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    advisor.NamingColumnConventionMismatch,
+				Title:   checker.title,
+				Content: fmt.Sprintf("%q.%q mismatches column naming convention, naming format should be %q", table, columnName, checker.format),
+				Line:    stmt.line,
+			})
+		}
+		if checker.maxLength > 0 && len(columnName) > checker.maxLength {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    advisor.NamingColumnConventionMismatch,
+				Title:   checker.title,
+				Content: fmt.Sprintf("%q.%q mismatches column naming convention, its length should be within %d characters", table, columnName, checker.maxLength),
+				Line:    stmt.line,
+			})
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package standard
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+)
+
+var (
+	_ advisor.Advisor = (*NamingTableConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Oracle, advisor.StandardNamingTableConvention, &NamingTableConventionAdvisor{engine: db.Oracle})
+	advisor.Register(db.MSSQL, advisor.StandardNamingTableConvention, &NamingTableConventionAdvisor{engine: db.MSSQL})
+}
+
+// NamingTableConventionAdvisor is the advisor checking for table naming convention.
+type NamingTableConventionAdvisor struct {
+	engine db.Type
+}
+
+// Check checks for table naming convention.
+func (a *NamingTableConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmtList, errAdvice := parseStatement(a.engine, statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, maxLength, err := advisor.UnamrshalNamingRulePayloadAsRegexp(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &namingTableConventionChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		format:    format,
+		maxLength: maxLength,
+	}
+	for _, stmt := range stmtList {
+		checker.check(stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type namingTableConventionChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	format     *regexp.Regexp
+	maxLength  int
+}
+
+func (checker *namingTableConventionChecker) check(stmt *statement) {
+	var tableNames []string
+	if node := stmt.createTable(); node != nil && !node.table.isTemporary() {
+		tableNames = append(tableNames, node.table.name.name)
+	}
+	if node := stmt.alterTable(); node != nil && node.newName != nil {
+		tableNames = append(tableNames, node.newName.name)
+	}
+	if node := stmt.renameTable(); node != nil {
+		tableNames = append(tableNames, node.new.name)
+	}
+
+	for _, tableName := range tableNames {
+		if !checker.format.MatchString(tableName) {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    advisor.NamingTableConventionMismatch,
+				Title:   checker.title,
+				Content: fmt.Sprintf("%q mismatches table naming convention, naming format should be %q", tableName, checker.format),
+				Line:    stmt.line,
+			})
+		}
+		if checker.maxLength > 0 && len(tableName) > checker.maxLength {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    advisor.NamingTableConventionMismatch,
+				Title:   checker.title,
+				Content: fmt.Sprintf("%q mismatches table naming convention, its length should be within %d characters", tableName, checker.maxLength),
+				Line:    stmt.line,
+			})
+		}
+	}
+}
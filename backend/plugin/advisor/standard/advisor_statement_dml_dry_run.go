@@ -0,0 +1,111 @@
+package standard
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+)
+
+var (
+	_ advisor.Advisor = (*StatementDmlDryRunAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Oracle, advisor.StandardStatementDMLDryRun, &StatementDmlDryRunAdvisor{engine: db.Oracle})
+	advisor.Register(db.MSSQL, advisor.StandardStatementDMLDryRun, &StatementDmlDryRunAdvisor{engine: db.MSSQL})
+}
+
+// StatementDmlDryRunAdvisor is the advisor checking for DML dry run.
+type StatementDmlDryRunAdvisor struct {
+	engine db.Type
+}
+
+// Check checks for DML dry run.
+func (a *StatementDmlDryRunAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmtList, errAdvice := parseStatement(a.engine, statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	if ctx.Driver != nil {
+		for _, stmt := range stmtList {
+			switch stmt.dmlType() {
+			case "INSERT", "UPDATE", "DELETE", "MERGE":
+			default:
+				continue
+			}
+			if err := dryRun(ctx.Context, ctx.Driver, a.engine, stmt.text); err != nil {
+				adviceList = append(adviceList, advisor.Advice{
+					Status:  level,
+					Code:    advisor.StatementDMLDryRunFailed,
+					Title:   string(ctx.Rule.Type),
+					Content: fmt.Sprintf("\"%s\" dry runs failed: %s", stmt.text, err.Error()),
+					Line:    stmt.line,
+				})
+			}
+		}
+	}
+
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList, nil
+}
+
+// dryRun compiles the DML statement with its execution plan, without running it.
+func dryRun(ctx context.Context, connection *sql.DB, engine db.Type, statement string) error {
+	switch engine {
+	case db.Oracle:
+		// EXPLAIN PLAN writes the plan into PLAN_TABLE, so we roll it back.
+		tx, err := connection.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("EXPLAIN PLAN FOR %s", statement))
+		return err
+	case db.MSSQL:
+		// SHOWPLAN is a session option and must be the only statement in its batch,
+		// so we use a dedicated connection and turn it off before releasing the connection.
+		conn, err := connection.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		if _, err := conn.ExecContext(ctx, "SET SHOWPLAN_XML ON"); err != nil {
+			return err
+		}
+		queryErr := queryShowPlan(ctx, conn, statement)
+		if _, err := conn.ExecContext(ctx, "SET SHOWPLAN_XML OFF"); err != nil {
+			// Discard the connection instead of returning it to the pool with SHOWPLAN on.
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+			return err
+		}
+		return queryErr
+	}
+	return nil
+}
+
+func queryShowPlan(ctx context.Context, conn *sql.Conn, statement string) error {
+	rows, err := conn.QueryContext(ctx, statement)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	return rows.Err()
+}
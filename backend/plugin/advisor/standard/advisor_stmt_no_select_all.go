@@ -0,0 +1,58 @@
+package standard
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+)
+
+var (
+	_ advisor.Advisor = (*NoSelectAllAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Oracle, advisor.StandardNoSelectAll, &NoSelectAllAdvisor{engine: db.Oracle})
+	advisor.Register(db.MSSQL, advisor.StandardNoSelectAll, &NoSelectAllAdvisor{engine: db.MSSQL})
+}
+
+// NoSelectAllAdvisor is the advisor checking for no "select *".
+type NoSelectAllAdvisor struct {
+	engine db.Type
+}
+
+// Check checks for no "select *".
+func (a *NoSelectAllAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmtList, errAdvice := parseStatement(a.engine, statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, stmt := range stmtList {
+		if hasSelectAll(stmt.tokens) {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.StatementSelectAll,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("\"%s\" uses SELECT all", stmt.text),
+				Line:    stmt.line,
+			})
+		}
+	}
+
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList, nil
+}
@@ -0,0 +1,83 @@
+package standard
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+)
+
+var (
+	_ advisor.Advisor = (*WhereRequirementAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Oracle, advisor.StandardWhereRequirement, &WhereRequirementAdvisor{engine: db.Oracle})
+	advisor.Register(db.MSSQL, advisor.StandardWhereRequirement, &WhereRequirementAdvisor{engine: db.MSSQL})
+}
+
+// WhereRequirementAdvisor is the advisor checking for the WHERE clause requirement.
+type WhereRequirementAdvisor struct {
+	engine db.Type
+}
+
+// Check checks for the WHERE clause requirement.
+func (a *WhereRequirementAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmtList, errAdvice := parseStatement(a.engine, statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var adviceList []advisor.Advice
+	for _, stmt := range stmtList {
+		if requireWhere(stmt) {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  level,
+				Code:    advisor.StatementNoWhere,
+				Title:   string(ctx.Rule.Type),
+				Content: fmt.Sprintf("\"%s\" requires WHERE clause", stmt.text),
+				Line:    stmt.line,
+			})
+		}
+	}
+
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList, nil
+}
+
+// requireWhere returns true if the UPDATE, DELETE or SELECT statement misses the WHERE clause.
+// For SELECT, each query block reading from a table requires the WHERE clause.
+func requireWhere(stmt *statement) bool {
+	tokens := stmt.mainTokens()
+	switch stmt.dmlType() {
+	case "UPDATE", "DELETE":
+		return findTopLevel(tokens, "WHERE") < 0
+	case "SELECT":
+		for _, block := range selectBlockList(tokens) {
+			from := findTopLevel(block, "FROM")
+			if from < 0 {
+				continue
+			}
+			// Oracle SELECT ... FROM DUAL.
+			if from+1 < len(block) && block[from+1].is("DUAL") {
+				continue
+			}
+			if findTopLevel(block, "WHERE") < 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
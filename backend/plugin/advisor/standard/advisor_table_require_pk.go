@@ -0,0 +1,114 @@
+package standard
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+)
+
+var (
+	_ advisor.Advisor = (*TableRequirePKAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Oracle, advisor.StandardTableRequirePK, &TableRequirePKAdvisor{engine: db.Oracle})
+	advisor.Register(db.MSSQL, advisor.StandardTableRequirePK, &TableRequirePKAdvisor{engine: db.MSSQL})
+}
+
+// TableRequirePKAdvisor is the advisor checking table requires PK.
+type TableRequirePKAdvisor struct {
+	engine db.Type
+}
+
+// Check checks table requires PK.
+func (a *TableRequirePKAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmtList, errAdvice := parseStatement(a.engine, statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &tableRequirePKChecker{
+		level:   level,
+		title:   string(ctx.Rule.Type),
+		engine:  a.engine,
+		catalog: ctx.Catalog,
+	}
+	for _, stmt := range stmtList {
+		checker.check(stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type tableRequirePKChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	engine     db.Type
+	catalog    *catalog.Finder
+}
+
+func (checker *tableRequirePKChecker) check(stmt *statement) {
+	var missingPK *tableName
+	// CREATE TABLE ... AS SELECT is skipped because we don't know its columns.
+	if node := stmt.createTable(); node != nil && node.elementList != nil && !node.elementList.hasPK {
+		missingPK = &node.table
+	}
+	if node := stmt.alterTable(); node != nil {
+		if node.dropPrimaryKey {
+			missingPK = &node.table
+		}
+		for _, constraint := range node.dropConstraintList {
+			if checker.isPrimaryKey(node.table, constraint) {
+				missingPK = &node.table
+			}
+		}
+	}
+
+	if missingPK != nil {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  checker.level,
+			Code:    advisor.TableNoPK,
+			Title:   checker.title,
+			Content: fmt.Sprintf("Table %q requires PRIMARY KEY, related statement: %q", missingPK, stmt.text),
+			Line:    stmt.line,
+		})
+	}
+}
+
+// isPrimaryKey returns true if the constraint is the primary key of the table in the catalog.
+func (checker *tableRequirePKChecker) isPrimaryKey(table tableName, constraint identifier) bool {
+	if checker.catalog == nil {
+		return false
+	}
+	schemaName := table.schema.normalize(checker.engine)
+	if schemaName == "" {
+		switch checker.engine {
+		case db.Oracle:
+			// The Oracle database in Bytebase is the schema.
+			schemaName = checker.catalog.Origin.DatabaseName()
+		case db.MSSQL:
+			schemaName = "dbo"
+		}
+	}
+	_, index := checker.catalog.Origin.FindIndex(&catalog.IndexFind{
+		SchemaName: schemaName,
+		TableName:  table.name.normalize(checker.engine),
+		IndexName:  constraint.normalize(checker.engine),
+	})
+	return index != nil && index.Primary()
+}
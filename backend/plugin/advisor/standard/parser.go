@@ -0,0 +1,646 @@
+// Package standard is the advisor for the engines splitting statements in the standard SQL way, i.e. Oracle and SQL Server.
+//
+// We don't have parsers for these engines yet, so the statements are analyzed on the token level.
+// The analysis only extracts the information needed by the advisors, and it ignores the statements
+// it doesn't understand instead of reporting syntax errors.
+package standard
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+)
+
+type tokenType int
+
+const (
+	// tokenWord is the type for keywords and unquoted identifiers.
+	tokenWord tokenType = iota
+	// tokenQuotedIdentifier is the type for "identifier" and [identifier].
+	tokenQuotedIdentifier
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type token struct {
+	tp tokenType
+	// text is the original text for the words and the unquoted text for the quoted identifiers.
+	text string
+}
+
+// is returns true if the token is any of the words, case-insensitively.
+func (t token) is(words ...string) bool {
+	if t.tp != tokenWord {
+		return false
+	}
+	for _, word := range words {
+		if strings.EqualFold(t.text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t token) isSymbol(symbol string) bool {
+	return t.tp == tokenSymbol && t.text == symbol
+}
+
+func (t token) isIdentifier() bool {
+	return t.tp == tokenWord || t.tp == tokenQuotedIdentifier
+}
+
+// statement is a single SQL statement.
+type statement struct {
+	text   string
+	line   int
+	tokens []token
+}
+
+func parseStatement(engine db.Type, statements string) ([]*statement, []advisor.Advice) {
+	engineType := parser.Oracle
+	if engine == db.MSSQL {
+		engineType = parser.MSSQL
+	}
+	list, err := parser.SplitMultiSQL(engineType, statements)
+	if err != nil {
+		return nil, []advisor.Advice{
+			{
+				Status:  advisor.Error,
+				Code:    advisor.StatementSyntaxError,
+				Title:   advisor.SyntaxErrorTitle,
+				Content: err.Error(),
+				Line:    1,
+			},
+		}
+	}
+
+	var result []*statement
+	for _, sql := range list {
+		tokens, err := tokenize(sql.Text)
+		if err != nil {
+			return nil, []advisor.Advice{
+				{
+					Status:  advisor.Error,
+					Code:    advisor.StatementSyntaxError,
+					Title:   advisor.SyntaxErrorTitle,
+					Content: err.Error(),
+					Line:    sql.LastLine,
+				},
+			}
+		}
+		// Skip the trailing semicolon.
+		if len(tokens) > 0 && tokens[len(tokens)-1].isSymbol(";") {
+			tokens = tokens[:len(tokens)-1]
+		}
+		if len(tokens) == 0 {
+			continue
+		}
+		result = append(result, &statement{
+			text:   strings.TrimSpace(sql.Text),
+			line:   sql.LastLine,
+			tokens: tokens,
+		})
+	}
+	return result, nil
+}
+
+func tokenize(text string) ([]token, error) {
+	runes := []rune(text)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			j := i + 2
+			for j+1 < len(runes) && !(runes[j] == '*' && runes[j+1] == '/') {
+				j++
+			}
+			if j+1 >= len(runes) {
+				return nil, errors.Errorf("unterminated comment")
+			}
+			i = j + 2
+		case r == '\'' || r == '"' || r == '[':
+			closing := r
+			if r == '[' {
+				closing = ']'
+			}
+			var buf strings.Builder
+			j := i + 1
+			for {
+				if j >= len(runes) {
+					return nil, errors.Errorf("unterminated quoted text starting with %c", r)
+				}
+				if runes[j] == closing {
+					// The doubled closing character is the escape of itself.
+					if j+1 < len(runes) && runes[j+1] == closing {
+						buf.WriteRune(closing)
+						j += 2
+						continue
+					}
+					break
+				}
+				buf.WriteRune(runes[j])
+				j++
+			}
+			tp := tokenQuotedIdentifier
+			if r == '\'' {
+				tp = tokenString
+			}
+			tokens = append(tokens, token{tp: tp, text: buf.String()})
+			i = j + 1
+		case isWordStart(r):
+			j := i + 1
+			for j < len(runes) && isWordPart(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, token{tp: tokenWord, text: string(runes[i:j])})
+			i = j
+		case unicode.IsDigit(r):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{tp: tokenNumber, text: string(runes[i:j])})
+			i = j
+		default:
+			tokens = append(tokens, token{tp: tokenSymbol, text: string(r)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+func isWordStart(r rune) bool {
+	// # and ## start the temporary table names and @ starts the variables in SQL Server.
+	return unicode.IsLetter(r) || r == '_' || r == '#' || r == '@'
+}
+
+func isWordPart(r rune) bool {
+	return isWordStart(r) || unicode.IsDigit(r) || r == '$'
+}
+
+// splitList splits the tokens by the commas out of the parentheses.
+func splitList(tokens []token) [][]token {
+	var result [][]token
+	depth, start := 0, 0
+	for i, t := range tokens {
+		switch {
+		case t.isSymbol("("):
+			depth++
+		case t.isSymbol(")"):
+			depth--
+		case t.isSymbol(",") && depth == 0:
+			result = append(result, tokens[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		result = append(result, tokens[start:])
+	}
+	return result
+}
+
+// findTopLevel returns the index of the first word out of the parentheses in the tokens, or -1 if not found.
+func findTopLevel(tokens []token, words ...string) int {
+	depth := 0
+	for i, t := range tokens {
+		switch {
+		case t.isSymbol("("):
+			depth++
+		case t.isSymbol(")"):
+			depth--
+		case depth == 0 && t.is(words...):
+			return i
+		}
+	}
+	return -1
+}
+
+// parenthesized returns the tokens in the parentheses starting at tokens[0] and the number of the consumed tokens.
+func parenthesized(tokens []token) ([]token, int) {
+	if len(tokens) == 0 || !tokens[0].isSymbol("(") {
+		return nil, 0
+	}
+	depth := 0
+	for i, t := range tokens {
+		switch {
+		case t.isSymbol("("):
+			depth++
+		case t.isSymbol(")"):
+			depth--
+			if depth == 0 {
+				return tokens[1:i], i + 1
+			}
+		}
+	}
+	return tokens[1:], len(tokens)
+}
+
+// identifier is an identifier which remembers whether it's quoted.
+type identifier struct {
+	name   string
+	quoted bool
+}
+
+func newIdentifier(t token) identifier {
+	return identifier{name: t.text, quoted: t.tp == tokenQuotedIdentifier}
+}
+
+// normalize returns the name as stored in the catalog. Oracle stores the unquoted identifiers in upper case.
+func (id identifier) normalize(engine db.Type) string {
+	if engine == db.Oracle && !id.quoted {
+		return strings.ToUpper(id.name)
+	}
+	return id.name
+}
+
+// tableName is the possibly schema-qualified table name.
+type tableName struct {
+	schema identifier
+	name   identifier
+}
+
+func (t tableName) String() string {
+	if t.schema.name == "" {
+		return t.name.name
+	}
+	return t.schema.name + "." + t.name.name
+}
+
+// isTemporary returns true for the SQL Server temporary tables, which have the names starting with #.
+func (t tableName) isTemporary() bool {
+	return strings.HasPrefix(t.name.name, "#")
+}
+
+// parseTableName parses the [database.][schema.]table name and returns the number of the consumed tokens.
+func parseTableName(tokens []token) (tableName, int) {
+	var parts []identifier
+	i := 0
+	for i < len(tokens) && tokens[i].isIdentifier() {
+		parts = append(parts, newIdentifier(tokens[i]))
+		i++
+		if i+1 < len(tokens) && tokens[i].isSymbol(".") {
+			i++
+			continue
+		}
+		break
+	}
+	switch len(parts) {
+	case 0:
+		return tableName{}, 0
+	case 1:
+		return tableName{name: parts[0]}, i
+	default:
+		return tableName{schema: parts[len(parts)-2], name: parts[len(parts)-1]}, i
+	}
+}
+
+// columnDef is the column definition in CREATE TABLE, ALTER TABLE ADD, MODIFY and ALTER COLUMN.
+type columnDef struct {
+	name identifier
+	// notNull is true if the column is NOT NULL, PRIMARY KEY or IDENTITY.
+	notNull bool
+	// null is true if the column is declared NULL explicitly.
+	null bool
+}
+
+// tableElementList is the list of the column definitions and the table constraints.
+type tableElementList struct {
+	columnList []*columnDef
+	// pkColumnList is the columns in the PRIMARY KEY table constraint.
+	pkColumnList []string
+	hasPK        bool
+}
+
+func (l *tableElementList) nullableColumnList() []*columnDef {
+	pkColumns := make(map[string]bool)
+	for _, column := range l.pkColumnList {
+		pkColumns[strings.ToLower(column)] = true
+	}
+	var result []*columnDef
+	for _, column := range l.columnList {
+		if !column.notNull && !pkColumns[strings.ToLower(column.name.name)] {
+			result = append(result, column)
+		}
+	}
+	return result
+}
+
+func parseTableElementList(elements [][]token) *tableElementList {
+	result := &tableElementList{}
+	for _, element := range elements {
+		if len(element) == 0 {
+			continue
+		}
+		first := element[0]
+		switch {
+		case first.is("CONSTRAINT", "PRIMARY", "UNIQUE", "FOREIGN", "CHECK", "INDEX", "PERIOD", "SUPPLEMENTAL"):
+			if pk := findTopLevel(element, "PRIMARY"); pk >= 0 {
+				result.hasPK = true
+				for i := pk + 1; i < len(element); i++ {
+					if element[i].isSymbol("(") {
+						list, _ := parenthesized(element[i:])
+						for _, column := range splitList(list) {
+							if len(column) > 0 && column[0].isIdentifier() {
+								result.pkColumnList = append(result.pkColumnList, column[0].text)
+							}
+						}
+						break
+					}
+				}
+			}
+		case first.isIdentifier():
+			// Skip the SQL Server computed columns.
+			if len(element) > 1 && element[1].is("AS") {
+				continue
+			}
+			column := parseColumnDef(element)
+			if findTopLevel(element, "PRIMARY") >= 0 {
+				result.hasPK = true
+			}
+			result.columnList = append(result.columnList, column)
+		}
+	}
+	return result
+}
+
+func parseColumnDef(tokens []token) *columnDef {
+	column := &columnDef{name: newIdentifier(tokens[0])}
+	depth := 0
+	for i := 1; i < len(tokens); i++ {
+		t := tokens[i]
+		switch {
+		case t.isSymbol("("):
+			depth++
+		case t.isSymbol(")"):
+			depth--
+		case depth > 0:
+		case t.is("NULL"):
+			switch {
+			case tokens[i-1].is("NOT"):
+				column.notNull = true
+			case !tokens[i-1].is("DEFAULT"):
+				column.null = true
+			}
+		case t.is("PRIMARY", "IDENTITY"):
+			column.notNull = true
+		}
+	}
+	return column
+}
+
+// createTableStmt is the CREATE TABLE statement.
+type createTableStmt struct {
+	table tableName
+	// elementList is nil for CREATE TABLE ... AS SELECT.
+	elementList *tableElementList
+}
+
+func (s *statement) createTable() *createTableStmt {
+	tokens := s.tokens
+	if len(tokens) < 3 || !tokens[0].is("CREATE") {
+		return nil
+	}
+	i := 1
+	// CREATE [GLOBAL | PRIVATE] [TEMPORARY] | [SHARDED | DUPLICATED] | [IMMUTABLE] [BLOCKCHAIN] TABLE.
+	for i < len(tokens) && tokens[i].is("GLOBAL", "PRIVATE", "TEMPORARY", "SHARDED", "DUPLICATED", "IMMUTABLE", "BLOCKCHAIN") {
+		i++
+	}
+	if i >= len(tokens) || !tokens[i].is("TABLE") {
+		return nil
+	}
+	i++
+	table, n := parseTableName(tokens[i:])
+	if n == 0 {
+		return nil
+	}
+	i += n
+	result := &createTableStmt{table: table}
+	if i < len(tokens) && tokens[i].isSymbol("(") {
+		list, _ := parenthesized(tokens[i:])
+		// CREATE TABLE t (a, b) AS SELECT ... only lists the column names.
+		if findTopLevel(tokens[i:], "AS") < 0 {
+			result.elementList = parseTableElementList(splitList(list))
+		}
+	}
+	return result
+}
+
+// renameColumn is ALTER TABLE ... RENAME COLUMN old TO new.
+type renameColumn struct {
+	old identifier
+	new identifier
+}
+
+// alterTableStmt is the ALTER TABLE statement.
+type alterTableStmt struct {
+	table tableName
+	// addElementList is the columns and constraints added by ALTER TABLE ADD.
+	addElementList *tableElementList
+	// modifyColumnList is the columns changed by Oracle MODIFY and SQL Server ALTER COLUMN.
+	modifyColumnList []*columnDef
+	renameColumnList []*renameColumn
+	// newName is the new table name of Oracle ALTER TABLE ... RENAME TO.
+	newName        *identifier
+	dropPrimaryKey bool
+	// dropConstraintList is the constraints dropped by ALTER TABLE DROP CONSTRAINT.
+	dropConstraintList []identifier
+}
+
+func (s *statement) alterTable() *alterTableStmt {
+	tokens := s.tokens
+	if len(tokens) < 4 || !tokens[0].is("ALTER") || !tokens[1].is("TABLE") {
+		return nil
+	}
+	table, n := parseTableName(tokens[2:])
+	if n == 0 {
+		return nil
+	}
+	result := &alterTableStmt{table: table}
+	rest := tokens[2+n:]
+	if len(rest) == 0 {
+		return result
+	}
+	action, rest := rest[0], rest[1:]
+	switch {
+	case action.is("ADD"):
+		if len(rest) > 0 && rest[0].is("COLUMN") {
+			rest = rest[1:]
+		}
+		if len(rest) > 0 && rest[0].isSymbol("(") {
+			rest, _ = parenthesized(rest)
+		}
+		result.addElementList = parseTableElementList(splitList(rest))
+	case action.is("MODIFY"):
+		if len(rest) > 0 && rest[0].isSymbol("(") {
+			rest, _ = parenthesized(rest)
+		}
+		for _, element := range splitList(rest) {
+			if len(element) == 0 || element[0].is("CONSTRAINT", "PRIMARY", "UNIQUE", "PARTITION", "SUBPARTITION", "DEFAULT", "LOB", "NESTED") {
+				continue
+			}
+			result.modifyColumnList = append(result.modifyColumnList, parseColumnDef(element))
+		}
+	case action.is("ALTER"):
+		if len(rest) > 0 && rest[0].is("COLUMN") {
+			rest = rest[1:]
+		}
+		// ALTER COLUMN c {ADD | DROP} {ROWGUIDCOL | PERSISTED | ...} doesn't change the column definition.
+		if len(rest) > 1 && rest[0].isIdentifier() && !rest[1].is("ADD", "DROP") {
+			result.modifyColumnList = append(result.modifyColumnList, parseColumnDef(rest))
+		}
+	case action.is("RENAME"):
+		switch {
+		case len(rest) >= 4 && rest[0].is("COLUMN") && rest[2].is("TO"):
+			result.renameColumnList = append(result.renameColumnList, &renameColumn{old: newIdentifier(rest[1]), new: newIdentifier(rest[3])})
+		case len(rest) >= 2 && rest[0].is("TO"):
+			newName := newIdentifier(rest[1])
+			result.newName = &newName
+		}
+	case action.is("DROP"):
+		for _, element := range splitList(rest) {
+			switch {
+			case len(element) >= 2 && element[0].is("PRIMARY") && element[1].is("KEY"):
+				result.dropPrimaryKey = true
+			case len(element) >= 2 && element[0].is("CONSTRAINT"):
+				i := 1
+				// SQL Server DROP CONSTRAINT IF EXISTS.
+				if len(element) >= 4 && element[1].is("IF") && element[2].is("EXISTS") {
+					i = 3
+				}
+				result.dropConstraintList = append(result.dropConstraintList, newIdentifier(element[i]))
+			}
+		}
+	}
+	return result
+}
+
+// renameTableStmt is the Oracle RENAME old TO new statement.
+type renameTableStmt struct {
+	old identifier
+	new identifier
+}
+
+func (s *statement) renameTable() *renameTableStmt {
+	tokens := s.tokens
+	if len(tokens) != 4 || !tokens[0].is("RENAME") || !tokens[2].is("TO") {
+		return nil
+	}
+	return &renameTableStmt{old: newIdentifier(tokens[1]), new: newIdentifier(tokens[3])}
+}
+
+// dmlType returns the upper case keyword of the main statement for SELECT, INSERT, UPDATE, DELETE and MERGE,
+// skipping the common table expressions, or empty string for other statements.
+func (s *statement) dmlType() string {
+	tokens := s.tokens
+	if tokens[0].isSymbol("(") {
+		return "SELECT"
+	}
+	if tokens[0].is("WITH") {
+		if i := findTopLevel(tokens[1:], "SELECT", "INSERT", "UPDATE", "DELETE", "MERGE"); i >= 0 {
+			return strings.ToUpper(tokens[i+1].text)
+		}
+		return ""
+	}
+	if tokens[0].is("SELECT", "INSERT", "UPDATE", "DELETE", "MERGE") {
+		return strings.ToUpper(tokens[0].text)
+	}
+	return ""
+}
+
+// mainTokens returns the tokens of the main statement after the common table expressions.
+func (s *statement) mainTokens() []token {
+	if s.tokens[0].is("WITH") {
+		if i := findTopLevel(s.tokens[1:], "SELECT", "INSERT", "UPDATE", "DELETE", "MERGE"); i >= 0 {
+			return s.tokens[i+1:]
+		}
+	}
+	return s.tokens
+}
+
+// selectBlockList returns the query blocks of the top level SELECT statement split by the set operators.
+func selectBlockList(tokens []token) [][]token {
+	var result [][]token
+	depth, start := 0, 0
+	for i, t := range tokens {
+		switch {
+		case t.isSymbol("("):
+			depth++
+		case t.isSymbol(")"):
+			depth--
+		case depth == 0 && t.is("UNION", "INTERSECT", "EXCEPT", "MINUS"):
+			result = append(result, tokens[start:i])
+			start = i + 1
+			// UNION ALL.
+			if start < len(tokens) && tokens[start].is("ALL", "DISTINCT") {
+				start++
+			}
+		}
+	}
+	return append(result, tokens[start:])
+}
+
+// hasSelectAll returns true if any SELECT in the tokens, including the subqueries, selects all columns by *.
+func hasSelectAll(tokens []token) bool {
+	for i, t := range tokens {
+		if !t.isSymbol("*") || i == 0 {
+			continue
+		}
+		prev := tokens[i-1]
+		// SELECT a, *, SELECT t.*.
+		if prev.isSymbol(",") || prev.isSymbol(".") {
+			return true
+		}
+		// Find the start of the select list.
+		j := i - 1
+		for j >= 0 && tokens[j].is("DISTINCT", "ALL", "UNIQUE") {
+			j--
+		}
+		// SQL Server SELECT TOP n [PERCENT] [WITH TIES] *.
+		if j >= 0 && tokens[j].is("TIES") {
+			j -= 2
+		}
+		if j >= 0 && tokens[j].is("PERCENT") {
+			j--
+		}
+		if j >= 1 && tokens[j-1].is("TOP") {
+			j -= 2
+		} else if j >= 0 && tokens[j].isSymbol(")") {
+			depth := 0
+			for ; j >= 0; j-- {
+				if tokens[j].isSymbol(")") {
+					depth++
+				} else if tokens[j].isSymbol("(") {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			if j >= 1 && tokens[j-1].is("TOP") {
+				j -= 2
+			} else {
+				continue
+			}
+		}
+		for j >= 0 && tokens[j].is("DISTINCT", "ALL", "UNIQUE") {
+			j--
+		}
+		if j >= 0 && tokens[j].is("SELECT") {
+			return true
+		}
+	}
+	return false
+}
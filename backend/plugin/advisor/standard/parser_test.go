@@ -0,0 +1,65 @@
+package standard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+)
+
+func TestColumnNoNullAlterColumn(t *testing.T) {
+	tests := []struct {
+		engine    db.Type
+		statement string
+		want      []string
+	}{
+		{
+			// SQL Server ALTER COLUMN makes the column nullable without NOT NULL.
+			engine:    db.MSSQL,
+			statement: "ALTER TABLE t ALTER COLUMN a int;\nALTER TABLE t ALTER COLUMN b int NOT NULL;\nALTER TABLE t ALTER COLUMN c ADD ROWGUIDCOL;",
+			want:      []string{`Column "a" in "t" cannot have NULL value`},
+		},
+		{
+			// Oracle MODIFY keeps the nullability unless it's declared.
+			engine:    db.Oracle,
+			statement: "ALTER TABLE t MODIFY a NUMBER;\nALTER TABLE t MODIFY (b NUMBER NULL, c NUMBER NOT NULL);",
+			want:      []string{`Column "b" in "t" cannot have NULL value`},
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		adviceList, err := (&ColumnNoNullAdvisor{engine: test.engine}).Check(advisor.Context{
+			Rule: &advisor.SQLReviewRule{
+				Type:  advisor.SchemaRuleColumnNotNull,
+				Level: advisor.SchemaRuleLevelWarning,
+			},
+		}, test.statement)
+		a.NoError(err)
+		var got []string
+		for _, advice := range adviceList {
+			got = append(got, advice.Content)
+		}
+		a.Equal(test.want, got, test.statement)
+	}
+}
+
+func TestParseStatement(t *testing.T) {
+	a := require.New(t)
+	stmtList, errAdvice := parseStatement(db.MSSQL, "SELECT 1;\n/* comment; */\nUPDATE [my table]\nSET [a]] b] = 'x;y'\n;")
+	a.Nil(errAdvice)
+	a.Len(stmtList, 2)
+	a.Equal("SELECT", stmtList[0].dmlType())
+	a.Equal(1, stmtList[0].line)
+	a.Equal("UPDATE", stmtList[1].dmlType())
+	a.Equal(5, stmtList[1].line)
+	a.Equal(token{tp: tokenQuotedIdentifier, text: "my table"}, stmtList[1].tokens[1])
+	a.Equal(token{tp: tokenQuotedIdentifier, text: "a] b"}, stmtList[1].tokens[3])
+	a.Equal(token{tp: tokenString, text: "x;y"}, stmtList[1].tokens[5])
+
+	_, errAdvice = parseStatement(db.Oracle, "SELECT 'x FROM t")
+	a.Len(errAdvice, 1)
+	a.Equal(advisor.StatementSyntaxError, errAdvice[0].Code)
+}
@@ -0,0 +1,36 @@
+package standard
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+)
+
+func TestStandardRules(t *testing.T) {
+	standardRules := []advisor.SQLReviewRuleType{
+		// advisor.SchemaRuleTableNaming enforce the table name format.
+		advisor.SchemaRuleTableNaming,
+		// advisor.SchemaRuleColumnNaming enforce the column name format.
+		advisor.SchemaRuleColumnNaming,
+
+		// advisor.SchemaRuleStatementNoSelectAll disallow 'SELECT *'.
+		advisor.SchemaRuleStatementNoSelectAll,
+		// advisor.SchemaRuleStatementRequireWhere require 'WHERE' clause.
+		advisor.SchemaRuleStatementRequireWhere,
+		// advisor.SchemaRuleStatementDMLDryRun dry run the dml.
+		advisor.SchemaRuleStatementDMLDryRun,
+
+		// advisor.SchemaRuleTableRequirePK require the table to have a primary key.
+		advisor.SchemaRuleTableRequirePK,
+
+		// advisor.SchemaRuleColumnNotNull enforce the columns cannot have NULL value.
+		advisor.SchemaRuleColumnNotNull,
+	}
+
+	for _, engine := range []db.Type{db.Oracle, db.MSSQL} {
+		for _, rule := range standardRules {
+			advisor.RunSQLReviewRuleTest(t, rule, engine, false /* record */)
+		}
+	}
+}
@@ -462,6 +462,12 @@ func SetDefaultSQLReviewRulePayload(ruleTp SQLReviewRuleType) (string, error) {
 		payload, err = json.Marshal(StringArrayTypeRulePayload{
 			List: []string{"serial", "bigserial", "int", "bigint"},
 		})
+	case SchemaRuleCustom:
+		payload, err = json.Marshal(CustomRulePayload{
+			Title:      "Tables in schema public require column tenant_id",
+			Expression: `sql_type in ["CREATE_TABLE", "ALTER_TABLE"] && table.schema == "public" && !table.columns.exists(c, c.name == "tenant_id")`,
+			Message:    "Table {{ .table.schema }}.{{ .table.name }} requires column tenant_id",
+		})
 	default:
 		return "", errors.Errorf("unknown SQL review type for default payload: %s", ruleTp)
 	}
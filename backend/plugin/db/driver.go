@@ -100,7 +100,7 @@ type DriverConfig struct {
 	DbBinDir string
 
 	// NOTE, introducing db specific fields is the last resort.
-	// MySQL specific
+	// BinlogDir is the directory to archive the binlog files of MySQL, or the WAL files and base backups of PostgreSQL.
 	BinlogDir string
 }
 
@@ -579,8 +579,32 @@ const (
 	// SensitiveDataMaskTypeDefault is the sensitive data type to hide data with a default method.
 	// The default method is subject to change.
 	SensitiveDataMaskTypeDefault SensitiveDataMaskType = "DEFAULT"
+	// SensitiveDataMaskTypePartial is the sensitive data type to hide data except the last few characters.
+	SensitiveDataMaskTypePartial SensitiveDataMaskType = "PARTIAL"
+	// SensitiveDataMaskTypeHash is the sensitive data type to replace data with its salted hash.
+	SensitiveDataMaskTypeHash SensitiveDataMaskType = "HASH"
+	// SensitiveDataMaskTypeRange is the sensitive data type to replace numbers and dates with the range they belong to.
+	SensitiveDataMaskTypeRange SensitiveDataMaskType = "RANGE"
+	// SensitiveDataMaskTypeEmail is the sensitive data type to hide the local part of emails and keep the domain.
+	SensitiveDataMaskTypeEmail SensitiveDataMaskType = "EMAIL"
+	// SensitiveDataMaskTypeFormatPreserving is the sensitive data type to substitute letters and digits and keep the format of data.
+	SensitiveDataMaskTypeFormatPreserving SensitiveDataMaskType = "FORMAT_PRESERVING"
 )
 
+// SensitiveDataMask is the mask algorithm and its parameters for a sensitive column.
+// The zero value masks the data with the default method.
+type SensitiveDataMask struct {
+	Type SensitiveDataMaskType
+	// KeepLast is the number of trailing characters kept by the PARTIAL mask type.
+	KeepLast int
+	// Salt is the salt used by the HASH and FORMAT_PRESERVING mask types.
+	Salt string
+	// RangeWidth is the width of the number ranges for the RANGE mask type.
+	RangeWidth float64
+	// DateUnit is the unit of the date ranges for the RANGE mask type, one of YEAR, MONTH and DAY.
+	DateUnit string
+}
+
 // SensitiveSchemaInfo is the schema info using to extract sensitive fields.
 type SensitiveSchemaInfo struct {
 	DatabaseList []DatabaseSchema
@@ -602,10 +626,12 @@ type TableSchema struct {
 type ColumnInfo struct {
 	Name      string
 	Sensitive bool
+	Mask      SensitiveDataMask
 }
 
 // SensitiveField is the struct about SELECT fields.
 type SensitiveField struct {
 	Name      string
 	Sensitive bool
+	Mask      SensitiveDataMask
 }
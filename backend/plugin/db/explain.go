@@ -0,0 +1,29 @@
+package db
+
+// ExplainNode is a node of the engine-independent query plan tree.
+type ExplainNode struct {
+	// NodeType is the operation of the node, e.g. "Seq Scan" for Postgres and "TableFullScan" for TiDB.
+	NodeType string `json:"nodeType"`
+	// Relation is the table accessed by the node.
+	Relation string `json:"relation,omitempty"`
+	// Index is the index used by the node.
+	Index string `json:"index,omitempty"`
+	// EstimatedRows is the number of rows estimated by the optimizer.
+	EstimatedRows float64 `json:"estimatedRows"`
+	// EstimatedCost is the cost estimated by the optimizer, the unit is engine specific.
+	EstimatedCost float64 `json:"estimatedCost"`
+	// ActualRows is the total number of rows returned by the node, only available when the plan is analyzed.
+	ActualRows *float64 `json:"actualRows,omitempty"`
+	// ActualTimeMs is the total time spent in the node in milliseconds, only available when the plan is analyzed.
+	ActualTimeMs *float64 `json:"actualTimeMs,omitempty"`
+	// FullScan is true if the node reads all rows of the relation.
+	FullScan bool `json:"fullScan"`
+	// Filesort is true if the node sorts rows with a filesort or on disk.
+	Filesort bool `json:"filesort"`
+	// NestedLoop is true if the node joins its children with nested loops.
+	NestedLoop bool `json:"nestedLoop"`
+	// Detail is the engine-specific description of the node.
+	Detail string `json:"detail,omitempty"`
+
+	Children []*ExplainNode `json:"children,omitempty"`
+}
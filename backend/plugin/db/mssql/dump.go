@@ -2,12 +2,34 @@ package mssql
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
 )
 
+// viewHeaderRegexp matches the comments and the CREATE VIEW clause with the view name of the view definition.
+var viewHeaderRegexp = regexp.MustCompile(`(?is)^(\s|--[^\n]*\n|/\*.*?\*/)*CREATE\s+VIEW\s+((\[[^\]]*\]|"[^"]*"|[^\s.(]+)\s*\.\s*)?(\[[^\]]*\]|"[^"]*"|[^\s.(]+)`)
+
 // Dump dumps the database.
-func (*Driver) Dump(_ context.Context, _ io.Writer, _ bool) (string, error) {
-	// TODO(d): implement it.
+func (driver *Driver) Dump(ctx context.Context, out io.Writer, _ bool) (string, error) {
+	txn, err := driver.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return "", err
+	}
+	defer txn.Rollback()
+
+	if err := dumpTxn(ctx, txn, out); err != nil {
+		return "", errors.Wrapf(err, "failed to dump database %q", driver.databaseName)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return "", err
+	}
+
 	return "", nil
 }
 
@@ -16,3 +38,521 @@ func (*Driver) Restore(_ context.Context, _ io.Reader) (err error) {
 	// TODO(d): implement it.
 	return nil
 }
+
+type dumpTable struct {
+	objectID int64
+	schema   string
+	name     string
+	// lines are the column and constraint definitions in CREATE TABLE.
+	lines []string
+}
+
+// dumpTxn dumps the schemas of the database in the order of sequences, tables, foreign keys, indexes and views.
+// schemaOnly isn't supported yet and true by default.
+// TODO(d): dump the data.
+func dumpTxn(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	if err := dumpSequences(ctx, txn, out); err != nil {
+		return errors.Wrap(err, "failed to dump sequences")
+	}
+	if err := dumpTables(ctx, txn, out); err != nil {
+		return errors.Wrap(err, "failed to dump tables")
+	}
+	if err := dumpForeignKeys(ctx, txn, out); err != nil {
+		return errors.Wrap(err, "failed to dump foreign keys")
+	}
+	if err := dumpIndexes(ctx, txn, out); err != nil {
+		return errors.Wrap(err, "failed to dump indexes")
+	}
+	if err := dumpViews(ctx, txn, out); err != nil {
+		return errors.Wrap(err, "failed to dump views")
+	}
+	return nil
+}
+
+func quote(names ...string) string {
+	var list []string
+	for _, name := range names {
+		list = append(list, fmt.Sprintf("[%s]", strings.ReplaceAll(name, "]", "]]")))
+	}
+	return strings.Join(list, ".")
+}
+
+func dumpSequences(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	query := `
+		SELECT
+			SCHEMA_NAME(s.schema_id),
+			s.name,
+			TYPE_NAME(s.user_type_id),
+			CAST(s.increment AS NVARCHAR(64)),
+			CAST(s.minimum_value AS NVARCHAR(64)),
+			CAST(s.maximum_value AS NVARCHAR(64)),
+			s.is_cycling,
+			s.is_cached,
+			s.cache_size
+		FROM sys.sequences s
+		ORDER BY 1, 2`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var schema, name, tp, increment, minValue, maxValue string
+		var cycling, cached bool
+		var cacheSize sql.NullInt64
+		if err := rows.Scan(&schema, &name, &tp, &increment, &minValue, &maxValue, &cycling, &cached, &cacheSize); err != nil {
+			return err
+		}
+		options := []string{
+			fmt.Sprintf("AS %s", tp),
+			fmt.Sprintf("INCREMENT BY %s", increment),
+			fmt.Sprintf("MINVALUE %s", minValue),
+			fmt.Sprintf("MAXVALUE %s", maxValue),
+		}
+		if cycling {
+			options = append(options, "CYCLE")
+		} else {
+			options = append(options, "NO CYCLE")
+		}
+		switch {
+		case !cached:
+			options = append(options, "NO CACHE")
+		case cacheSize.Valid:
+			options = append(options, fmt.Sprintf("CACHE %d", cacheSize.Int64))
+		default:
+			options = append(options, "CACHE")
+		}
+		// The current value is not dumped, so START WITH is omitted.
+		stmt := fmt.Sprintf("CREATE SEQUENCE %s %s;\n\n", quote(schema, name), strings.Join(options, " "))
+		if _, err := io.WriteString(out, stmt); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func dumpTables(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	var tables []*dumpTable
+	tableMap := make(map[int64]*dumpTable)
+	query := `
+		SELECT object_id, SCHEMA_NAME(schema_id), name
+		FROM sys.tables
+		WHERE is_ms_shipped = 0
+		ORDER BY 2, 3`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		table := &dumpTable{}
+		if err := rows.Scan(&table.objectID, &table.schema, &table.name); err != nil {
+			return err
+		}
+		tables = append(tables, table)
+		tableMap[table.objectID] = table
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	columnQuery := `
+		SELECT
+			c.object_id,
+			c.name,
+			TYPE_NAME(c.user_type_id),
+			c.max_length,
+			c.precision,
+			c.scale,
+			c.is_nullable,
+			CAST(ic.seed_value AS NVARCHAR(64)),
+			CAST(ic.increment_value AS NVARCHAR(64)),
+			cc.definition,
+			cc.is_persisted,
+			dc.name,
+			dc.definition
+		FROM sys.columns c
+		INNER JOIN sys.tables t ON t.object_id = c.object_id
+		LEFT JOIN sys.identity_columns ic ON ic.object_id = c.object_id AND ic.column_id = c.column_id
+		LEFT JOIN sys.computed_columns cc ON cc.object_id = c.object_id AND cc.column_id = c.column_id
+		LEFT JOIN sys.default_constraints dc ON dc.parent_object_id = c.object_id AND dc.parent_column_id = c.column_id
+		WHERE t.is_ms_shipped = 0
+		ORDER BY c.object_id, c.column_id`
+	columnRows, err := txn.QueryContext(ctx, columnQuery)
+	if err != nil {
+		return err
+	}
+	defer columnRows.Close()
+	for columnRows.Next() {
+		var objectID int64
+		var name, tp string
+		var maxLength, precision, scale int
+		var nullable bool
+		var seed, increment, computed, defaultName, defaultExpr sql.NullString
+		var persisted sql.NullBool
+		if err := columnRows.Scan(&objectID, &name, &tp, &maxLength, &precision, &scale, &nullable, &seed, &increment, &computed, &persisted, &defaultName, &defaultExpr); err != nil {
+			return err
+		}
+		table, ok := tableMap[objectID]
+		if !ok {
+			continue
+		}
+		if computed.Valid {
+			line := fmt.Sprintf("%s AS %s", quote(name), computed.String)
+			if persisted.Bool {
+				line += " PERSISTED"
+			}
+			table.lines = append(table.lines, line)
+			continue
+		}
+		parts := []string{quote(name), getColumnType(tp, maxLength, precision, scale)}
+		if seed.Valid {
+			parts = append(parts, fmt.Sprintf("IDENTITY(%s, %s)", seed.String, increment.String))
+		}
+		if defaultExpr.Valid {
+			parts = append(parts, "CONSTRAINT", quote(defaultName.String), "DEFAULT", defaultExpr.String)
+		}
+		if nullable {
+			parts = append(parts, "NULL")
+		} else {
+			parts = append(parts, "NOT NULL")
+		}
+		table.lines = append(table.lines, strings.Join(parts, " "))
+	}
+	if err := columnRows.Err(); err != nil {
+		return err
+	}
+
+	if err := getKeyConstraints(ctx, txn, tableMap); err != nil {
+		return errors.Wrap(err, "failed to get key constraints")
+	}
+
+	checkQuery := `
+		SELECT parent_object_id, name, definition
+		FROM sys.check_constraints
+		WHERE is_ms_shipped = 0
+		ORDER BY parent_object_id, name`
+	checkRows, err := txn.QueryContext(ctx, checkQuery)
+	if err != nil {
+		return err
+	}
+	defer checkRows.Close()
+	for checkRows.Next() {
+		var objectID int64
+		var name, definition string
+		if err := checkRows.Scan(&objectID, &name, &definition); err != nil {
+			return err
+		}
+		if table, ok := tableMap[objectID]; ok {
+			table.lines = append(table.lines, fmt.Sprintf("CONSTRAINT %s CHECK %s", quote(name), definition))
+		}
+	}
+	if err := checkRows.Err(); err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		stmt := fmt.Sprintf("CREATE TABLE %s (\n  %s\n);\n\n", quote(table.schema, table.name), strings.Join(table.lines, ",\n  "))
+		if _, err := io.WriteString(out, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getColumnType returns the column type in the form used in CREATE TABLE.
+func getColumnType(tp string, maxLength, precision, scale int) string {
+	switch tp {
+	case "varchar", "char", "varbinary", "binary":
+		if maxLength == -1 {
+			return tp + "(max)"
+		}
+		return fmt.Sprintf("%s(%d)", tp, maxLength)
+	case "nvarchar", "nchar":
+		if maxLength == -1 {
+			return tp + "(max)"
+		}
+		// The max_length is in bytes, and each character takes two bytes.
+		return fmt.Sprintf("%s(%d)", tp, maxLength/2)
+	case "decimal", "numeric":
+		return fmt.Sprintf("%s(%d, %d)", tp, precision, scale)
+	case "datetime2", "datetimeoffset", "time":
+		return fmt.Sprintf("%s(%d)", tp, scale)
+	default:
+		return tp
+	}
+}
+
+// getKeyConstraints appends the primary keys and the unique constraints to the table definitions.
+func getKeyConstraints(ctx context.Context, txn *sql.Tx, tableMap map[int64]*dumpTable) error {
+	query := `
+		SELECT
+			kc.parent_object_id,
+			kc.name,
+			kc.type,
+			i.type_desc,
+			c.name,
+			ic.is_descending_key
+		FROM sys.key_constraints kc
+		INNER JOIN sys.indexes i ON i.object_id = kc.parent_object_id AND i.index_id = kc.unique_index_id
+		INNER JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+		INNER JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+		WHERE ic.key_ordinal > 0
+		ORDER BY kc.parent_object_id, kc.type, kc.name, ic.key_ordinal`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type keyConstraint struct {
+		table   *dumpTable
+		name    string
+		tp      string
+		index   string
+		columns []string
+	}
+	var constraints []*keyConstraint
+	for rows.Next() {
+		var objectID int64
+		var name, tp, indexType, column string
+		var descending bool
+		if err := rows.Scan(&objectID, &name, &tp, &indexType, &column, &descending); err != nil {
+			return err
+		}
+		table, ok := tableMap[objectID]
+		if !ok {
+			continue
+		}
+		if len(constraints) == 0 || constraints[len(constraints)-1].table != table || constraints[len(constraints)-1].name != name {
+			constraints = append(constraints, &keyConstraint{table: table, name: name, tp: strings.TrimSpace(tp), index: indexType})
+		}
+		c := constraints[len(constraints)-1]
+		if descending {
+			column = quote(column) + " DESC"
+		} else {
+			column = quote(column)
+		}
+		c.columns = append(c.columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, c := range constraints {
+		// Only the index types different from the defaults are dumped.
+		var definition string
+		if c.tp == "PK" {
+			definition = "PRIMARY KEY"
+			if c.index == "NONCLUSTERED" {
+				definition += " NONCLUSTERED"
+			}
+		} else {
+			definition = "UNIQUE"
+			if c.index == "CLUSTERED" {
+				definition += " CLUSTERED"
+			}
+		}
+		c.table.lines = append(c.table.lines, fmt.Sprintf("CONSTRAINT %s %s (%s)", quote(c.name), definition, strings.Join(c.columns, ", ")))
+	}
+	return nil
+}
+
+func dumpForeignKeys(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	query := `
+		SELECT
+			fk.object_id,
+			SCHEMA_NAME(pt.schema_id),
+			pt.name,
+			fk.name,
+			pc.name,
+			SCHEMA_NAME(rt.schema_id),
+			rt.name,
+			rc.name,
+			fk.delete_referential_action_desc,
+			fk.update_referential_action_desc
+		FROM sys.foreign_keys fk
+		INNER JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+		INNER JOIN sys.tables pt ON pt.object_id = fk.parent_object_id
+		INNER JOIN sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+		INNER JOIN sys.tables rt ON rt.object_id = fk.referenced_object_id
+		INNER JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+		WHERE fk.is_ms_shipped = 0
+		ORDER BY 2, 3, 4, fkc.constraint_column_id`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type foreignKey struct {
+		objectID   int64
+		table      string
+		name       string
+		columns    []string
+		refTable   string
+		refColumns []string
+		onDelete   string
+		onUpdate   string
+	}
+	var foreignKeys []*foreignKey
+	for rows.Next() {
+		var objectID int64
+		var schema, table, name, column, refSchema, refTable, refColumn, onDelete, onUpdate string
+		if err := rows.Scan(&objectID, &schema, &table, &name, &column, &refSchema, &refTable, &refColumn, &onDelete, &onUpdate); err != nil {
+			return err
+		}
+		if len(foreignKeys) == 0 || foreignKeys[len(foreignKeys)-1].objectID != objectID {
+			foreignKeys = append(foreignKeys, &foreignKey{
+				objectID: objectID,
+				table:    quote(schema, table),
+				name:     name,
+				refTable: quote(refSchema, refTable),
+				onDelete: onDelete,
+				onUpdate: onUpdate,
+			})
+		}
+		fk := foreignKeys[len(foreignKeys)-1]
+		fk.columns = append(fk.columns, quote(column))
+		fk.refColumns = append(fk.refColumns, quote(refColumn))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, fk := range foreignKeys {
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+			fk.table, quote(fk.name), strings.Join(fk.columns, ", "), fk.refTable, strings.Join(fk.refColumns, ", "))
+		// The referential actions are such as NO_ACTION and SET_NULL.
+		if fk.onDelete != "NO_ACTION" {
+			stmt += " ON DELETE " + strings.ReplaceAll(fk.onDelete, "_", " ")
+		}
+		if fk.onUpdate != "NO_ACTION" {
+			stmt += " ON UPDATE " + strings.ReplaceAll(fk.onUpdate, "_", " ")
+		}
+		if _, err := io.WriteString(out, stmt+";\n\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dumpIndexes(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	// The indexes backing the primary keys and the unique constraints are created with the constraints.
+	query := `
+		SELECT
+			SCHEMA_NAME(t.schema_id),
+			t.name,
+			i.name,
+			i.type_desc,
+			i.is_unique,
+			i.filter_definition,
+			c.name,
+			ic.is_descending_key,
+			ic.is_included_column
+		FROM sys.indexes i
+		INNER JOIN sys.tables t ON t.object_id = i.object_id
+		INNER JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+		INNER JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+		WHERE t.is_ms_shipped = 0
+			AND i.is_primary_key = 0
+			AND i.is_unique_constraint = 0
+			AND i.is_hypothetical = 0
+			AND i.type_desc IN ('CLUSTERED', 'NONCLUSTERED')
+		ORDER BY 1, 2, 3, ic.is_included_column, ic.key_ordinal, ic.index_column_id`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type index struct {
+		table    string
+		name     string
+		unique   bool
+		tp       string
+		filter   string
+		columns  []string
+		included []string
+	}
+	var indexes []*index
+	for rows.Next() {
+		var schema, table, name, tp, column string
+		var unique, descending, included bool
+		var filter sql.NullString
+		if err := rows.Scan(&schema, &table, &name, &tp, &unique, &filter, &column, &descending, &included); err != nil {
+			return err
+		}
+		tableName := quote(schema, table)
+		if len(indexes) == 0 || indexes[len(indexes)-1].table != tableName || indexes[len(indexes)-1].name != name {
+			indexes = append(indexes, &index{table: tableName, name: name, unique: unique, tp: tp, filter: filter.String})
+		}
+		idx := indexes[len(indexes)-1]
+		switch {
+		case included:
+			idx.included = append(idx.included, quote(column))
+		case descending:
+			idx.columns = append(idx.columns, quote(column)+" DESC")
+		default:
+			idx.columns = append(idx.columns, quote(column))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, idx := range indexes {
+		var buf strings.Builder
+		buf.WriteString("CREATE ")
+		if idx.unique {
+			buf.WriteString("UNIQUE ")
+		}
+		if idx.tp == "CLUSTERED" {
+			buf.WriteString("CLUSTERED ")
+		}
+		fmt.Fprintf(&buf, "INDEX %s ON %s (%s)", quote(idx.name), idx.table, strings.Join(idx.columns, ", "))
+		if len(idx.included) > 0 {
+			fmt.Fprintf(&buf, " INCLUDE (%s)", strings.Join(idx.included, ", "))
+		}
+		if idx.filter != "" {
+			fmt.Fprintf(&buf, " WHERE %s", idx.filter)
+		}
+		buf.WriteString(";\n\n")
+		if _, err := io.WriteString(out, buf.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dumpViews(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	query := `
+		SELECT SCHEMA_NAME(v.schema_id), v.name, m.definition
+		FROM sys.views v
+		INNER JOIN sys.sql_modules m ON m.object_id = v.object_id
+		WHERE v.is_ms_shipped = 0
+		ORDER BY 1, 2`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var schema, name, definition string
+		if err := rows.Scan(&schema, &name, &definition); err != nil {
+			return err
+		}
+		// The definition is the original CREATE VIEW statement, in which the view name may be
+		// unqualified or stale after sp_rename, so the name is replaced with the qualified one.
+		header := viewHeaderRegexp.FindString(definition)
+		if header == "" {
+			return errors.Errorf("failed to parse the definition of view %s", quote(schema, name))
+		}
+		body := strings.TrimRight(strings.TrimSpace(definition[len(header):]), ";")
+		stmt := fmt.Sprintf("CREATE VIEW %s %s;\n\n", quote(schema, name), body)
+		if _, err := io.WriteString(out, stmt); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
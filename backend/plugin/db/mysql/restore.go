@@ -32,7 +32,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common/log"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
-	bbs3 "github.com/bytebase/bytebase/backend/plugin/storage/s3"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
 	"github.com/bytebase/bytebase/backend/resources/mysqlutil"
 	"github.com/bytebase/bytebase/backend/store"
 
@@ -348,7 +348,7 @@ func sortBinlogFiles(binlogFiles []BinlogFile) []BinlogFile {
 
 // GetLatestBackupBeforeOrEqualTs finds the latest logical backup and corresponding binlog info whose time is before or equal to `targetTs`.
 // The backupList should only contain DONE backups.
-func (driver *Driver) GetLatestBackupBeforeOrEqualTs(ctx context.Context, backupList []*store.BackupMessage, targetTs int64, client *bbs3.Client) (*store.BackupMessage, *api.BinlogInfo, error) {
+func (driver *Driver) GetLatestBackupBeforeOrEqualTs(ctx context.Context, backupList []*store.BackupMessage, targetTs int64, client storage.Backend) (*store.BackupMessage, *api.BinlogInfo, error) {
 	if len(backupList) == 0 {
 		return nil, nil, errors.Errorf("no valid backup")
 	}
@@ -575,7 +575,7 @@ func binlogMetaAreContinuous(files []binlogFileMeta) bool {
 }
 
 // Download binlog files on server.
-func (driver *Driver) downloadBinlogFilesOnServer(ctx context.Context, metaList []binlogFileMeta, binlogFilesOnServerSorted []BinlogFile, downloadLatestBinlogFile bool, uploader *bbs3.Client) error {
+func (driver *Driver) downloadBinlogFilesOnServer(ctx context.Context, metaList []binlogFileMeta, binlogFilesOnServerSorted []BinlogFile, downloadLatestBinlogFile bool, uploader storage.Backend) error {
 	if len(binlogFilesOnServerSorted) == 0 {
 		log.Debug("No binlog file found on server to download")
 		return nil
@@ -617,7 +617,7 @@ func (driver *Driver) GetBinlogDir() string {
 }
 
 // FetchAllBinlogFiles downloads all binlog files on server to `binlogDir`.
-func (driver *Driver) FetchAllBinlogFiles(ctx context.Context, downloadLatestBinlogFile bool, client *bbs3.Client) error {
+func (driver *Driver) FetchAllBinlogFiles(ctx context.Context, downloadLatestBinlogFile bool, client storage.Backend) error {
 	if err := os.MkdirAll(driver.binlogDir, os.ModePerm); err != nil {
 		return errors.Wrapf(err, "failed to create binlog directory %q", driver.binlogDir)
 	}
@@ -649,7 +649,7 @@ func (driver *Driver) FetchAllBinlogFiles(ctx context.Context, downloadLatestBin
 	return nil
 }
 
-func (driver *Driver) syncBinlogMetaFileFromCloud(ctx context.Context, client *bbs3.Client) error {
+func (driver *Driver) syncBinlogMetaFileFromCloud(ctx context.Context, client storage.Backend) error {
 	metaListToDownload, err := driver.getBinlogMetaFileListToDownload(ctx, client)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get binlog metadata file list on cloud in directory %q", driver.binlogDir)
@@ -664,7 +664,7 @@ func (driver *Driver) syncBinlogMetaFileFromCloud(ctx context.Context, client *b
 		filePathLocal := filepath.Join(driver.binlogDir, metaFileName)
 		// Use path.Join to compose a path on cloud which always uses / as the separator.
 		filePathOnCloud := path.Join(common.GetBinlogRelativeDir(driver.binlogDir), metaFileName)
-		if err := client.DownloadFileFromCloud(ctx, filePathLocal, filePathOnCloud); err != nil {
+		if err := storage.DownloadFileFromCloud(ctx, client, filePathLocal, filePathOnCloud); err != nil {
 			return errors.Wrapf(err, "failed to download binlog metadata file %s from the cloud storage", metaFileName)
 		}
 	}
@@ -672,14 +672,15 @@ func (driver *Driver) syncBinlogMetaFileFromCloud(ctx context.Context, client *b
 	return nil
 }
 
-func (driver *Driver) getBinlogMetaFileListToDownload(ctx context.Context, client *bbs3.Client) ([]string, error) {
-	listOutput, err := client.ListObjects(ctx, driver.binlogDir)
+func (driver *Driver) getBinlogMetaFileListToDownload(ctx context.Context, client storage.Backend) ([]string, error) {
+	binlogDirOnCloud := common.GetBinlogRelativeDir(driver.binlogDir)
+	listOutput, err := client.ListObjects(ctx, binlogDirOnCloud)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to list binlog dir %q in the cloud storage", driver.binlogDir)
+		return nil, errors.Wrapf(err, "failed to list binlog dir %q in the cloud storage", binlogDirOnCloud)
 	}
 	var downloadList []string
 	for _, item := range listOutput {
-		binlogPathOnCloud := *item.Key
+		binlogPathOnCloud := item.Key
 		if !strings.HasSuffix(binlogPathOnCloud, binlogMetaSuffix) {
 			continue
 		}
@@ -761,7 +762,7 @@ func (driver *Driver) downloadBinlogFile(ctx context.Context, binlogFileToDownlo
 	return nil
 }
 
-func (driver *Driver) uploadBinlogFileToCloud(ctx context.Context, uploader *bbs3.Client, binlogFileName string) error {
+func (driver *Driver) uploadBinlogFileToCloud(ctx context.Context, uploader storage.Backend, binlogFileName string) error {
 	binlogFilePath := filepath.Join(driver.binlogDir, binlogFileName)
 	metaFileName := binlogFileName + binlogMetaSuffix
 	metaFilePath := filepath.Join(driver.binlogDir, metaFileName)
@@ -772,7 +773,7 @@ func (driver *Driver) uploadBinlogFileToCloud(ctx context.Context, uploader *bbs
 	defer binlogFile.Close()
 	defer os.Remove(binlogFilePath)
 	relativeDir := common.GetBinlogRelativeDir(driver.binlogDir)
-	if _, err := uploader.UploadObject(ctx, path.Join(relativeDir, binlogFileName), binlogFile); err != nil {
+	if err := uploader.UploadObject(ctx, path.Join(relativeDir, binlogFileName), binlogFile); err != nil {
 		// Remove the local metadata file so that it can be re-uploaded later.
 		if err := os.Remove(metaFilePath); err != nil {
 			log.Warn("Failed to remove binlog metadata file %q when error occurs in uploading binlog file", zap.String("binlogFile", binlogFilePath), zap.Error(err))
@@ -786,7 +787,7 @@ func (driver *Driver) uploadBinlogFileToCloud(ctx context.Context, uploader *bbs
 	}
 	defer metaFile.Close()
 	// We leave the local metadata file to indicate that the binlog file has been uploaded successfully.
-	if _, err := uploader.UploadObject(ctx, path.Join(relativeDir, metaFileName), metaFile); err != nil {
+	if err := uploader.UploadObject(ctx, path.Join(relativeDir, metaFileName), metaFile); err != nil {
 		return errors.Wrapf(err, "failed to upload binlog metadata file %q to cloud storage", metaFileName)
 	}
 	log.Debug("Successfully uploaded binlog file to cloud storage", zap.String("path", binlogFilePath))
@@ -849,7 +850,7 @@ func (driver *Driver) GetSortedBinlogFilesOnServer(ctx context.Context) ([]Binlo
 }
 
 // getBinlogCoordinateByTs converts a timestamp to binlog coordinate using local binlog files.
-func (driver *Driver) getBinlogCoordinateByTs(ctx context.Context, targetTs int64, client *bbs3.Client) (*binlogCoordinate, error) {
+func (driver *Driver) getBinlogCoordinateByTs(ctx context.Context, targetTs int64, client storage.Backend) (*binlogCoordinate, error) {
 	metaList, err := getSortedLocalBinlogFilesMeta(driver.binlogDir)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to read local binlog metadata files")
@@ -887,7 +888,7 @@ func (driver *Driver) getBinlogCoordinateByTs(ctx context.Context, targetTs int6
 		filePathLocal := filepath.Join(driver.binlogDir, targetMeta.binlogName)
 		// Use path.Join to compose a path on cloud which always uses / as the separator.
 		filePathOnCloud := path.Join(common.GetBinlogRelativeDir(driver.binlogDir), targetMeta.binlogName)
-		if err := client.DownloadFileFromCloud(ctx, filePathLocal, filePathOnCloud); err != nil {
+		if err := storage.DownloadFileFromCloud(ctx, client, filePathLocal, filePathOnCloud); err != nil {
 			return nil, errors.Wrapf(err, "failed to download binlog file %s from the cloud storage", targetMeta.binlogName)
 		}
 	}
@@ -2,12 +2,37 @@ package oracle
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"io"
+	"strings"
+
+	"github.com/pkg/errors"
 )
 
+// ownerFilter returns the condition filtering out the schemas maintained by Oracle on the owner column.
+func ownerFilter(column string) string {
+	return fmt.Sprintf(`%s IN (
+			SELECT USERNAME FROM DBA_USERS WHERE ORACLE_MAINTAINED = 'N'
+		) AND %s != 'OPS$ORACLE'`, column, column)
+}
+
 // Dump dumps the database.
-func (*Driver) Dump(_ context.Context, _ io.Writer, _ bool) (string, error) {
-	// TODO(d): implement it.
+func (driver *Driver) Dump(ctx context.Context, out io.Writer, _ bool) (string, error) {
+	txn, err := driver.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return "", err
+	}
+	defer txn.Rollback()
+
+	if err := dumpTxn(ctx, txn, out); err != nil {
+		return "", errors.Wrapf(err, "failed to dump database %q", driver.databaseName)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return "", err
+	}
+
 	return "", nil
 }
 
@@ -16,3 +41,493 @@ func (*Driver) Restore(_ context.Context, _ io.Reader) (err error) {
 	// TODO(d): implement it.
 	return nil
 }
+
+type dumpColumn struct {
+	name     string
+	tp       string
+	nullable bool
+	// defaultExpr is the default expression, or the expression of the virtual column.
+	defaultExpr sql.NullString
+	virtual     bool
+	// identity is the generation type of the identity column, such as ALWAYS or BY DEFAULT.
+	identity string
+}
+
+type dumpConstraint struct {
+	owner     string
+	name      string
+	tp        string
+	table     string
+	columns   []string
+	condition string
+	// generatedName is whether the name is generated by the system, such as SYS_C001.
+	generatedName bool
+	// refOwner and refName are the owner and the name of the referenced constraint of the foreign key.
+	refOwner   string
+	refName    string
+	deleteRule string
+}
+
+type dumpTable struct {
+	owner       string
+	name        string
+	columns     []*dumpColumn
+	constraints []*dumpConstraint
+}
+
+// dumpTxn dumps the schemas of the database in the order of sequences, tables, foreign keys, indexes and views.
+// schemaOnly isn't supported yet and true by default.
+// TODO(d): dump the data.
+func dumpTxn(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	if err := dumpSequences(ctx, txn, out); err != nil {
+		return errors.Wrap(err, "failed to dump sequences")
+	}
+	tables, err := getDumpTables(ctx, txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get tables")
+	}
+	constraints, constraintMap, err := getDumpConstraints(ctx, txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get constraints")
+	}
+	tableMap := make(map[string]*dumpTable)
+	for _, table := range tables {
+		tableMap[quote(table.owner, table.name)] = table
+	}
+	var foreignKeys []*dumpConstraint
+	for _, c := range constraints {
+		table, ok := tableMap[quote(c.owner, c.table)]
+		if !ok {
+			continue
+		}
+		// The NOT NULL constraints are dumped with the columns.
+		if c.tp == "C" && c.generatedName && strings.HasSuffix(c.condition, " IS NOT NULL") {
+			continue
+		}
+		if c.tp == "R" {
+			foreignKeys = append(foreignKeys, c)
+			continue
+		}
+		table.constraints = append(table.constraints, c)
+	}
+	for _, table := range tables {
+		if _, err := io.WriteString(out, getTableStmt(table)); err != nil {
+			return err
+		}
+	}
+	for _, fk := range foreignKeys {
+		stmt, err := getForeignKeyStmt(fk, constraintMap)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(out, stmt); err != nil {
+			return err
+		}
+	}
+	if err := dumpIndexes(ctx, txn, out); err != nil {
+		return errors.Wrap(err, "failed to dump indexes")
+	}
+	return dumpViews(ctx, txn, out)
+}
+
+func quote(names ...string) string {
+	var list []string
+	for _, name := range names {
+		list = append(list, fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`)))
+	}
+	return strings.Join(list, ".")
+}
+
+func quoteList(names []string) string {
+	var list []string
+	for _, name := range names {
+		list = append(list, quote(name))
+	}
+	return strings.Join(list, ", ")
+}
+
+func dumpSequences(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	// The sequences of the identity columns are created with the tables.
+	query := `
+		SELECT SEQUENCE_OWNER, SEQUENCE_NAME, TO_CHAR(MIN_VALUE), TO_CHAR(MAX_VALUE), TO_CHAR(INCREMENT_BY), CYCLE_FLAG, ORDER_FLAG, CACHE_SIZE
+		FROM sys.all_sequences
+		WHERE ` + ownerFilter("SEQUENCE_OWNER") + ` AND SEQUENCE_NAME NOT LIKE 'ISEQ$$%'
+		ORDER BY SEQUENCE_OWNER, SEQUENCE_NAME`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var owner, name, minValue, maxValue, increment, cycle, order string
+		var cache int64
+		if err := rows.Scan(&owner, &name, &minValue, &maxValue, &increment, &cycle, &order, &cache); err != nil {
+			return err
+		}
+		options := []string{
+			fmt.Sprintf("INCREMENT BY %s", increment),
+			fmt.Sprintf("MINVALUE %s", minValue),
+			fmt.Sprintf("MAXVALUE %s", maxValue),
+		}
+		if cycle == "Y" {
+			options = append(options, "CYCLE")
+		} else {
+			options = append(options, "NOCYCLE")
+		}
+		if order == "Y" {
+			options = append(options, "ORDER")
+		} else {
+			options = append(options, "NOORDER")
+		}
+		if cache > 0 {
+			options = append(options, fmt.Sprintf("CACHE %d", cache))
+		} else {
+			options = append(options, "NOCACHE")
+		}
+		// The current value is not dumped, so START WITH is omitted.
+		stmt := fmt.Sprintf("CREATE SEQUENCE %s %s;\n\n", quote(owner, name), strings.Join(options, " "))
+		if _, err := io.WriteString(out, stmt); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func getDumpTables(ctx context.Context, txn *sql.Tx) ([]*dumpTable, error) {
+	var tables []*dumpTable
+	tableMap := make(map[string]*dumpTable)
+	query := `
+		SELECT OWNER, TABLE_NAME
+		FROM sys.all_tables
+		WHERE ` + ownerFilter("OWNER") + ` AND DROPPED = 'NO' AND NESTED = 'NO' AND IOT_TYPE IS NULL
+		ORDER BY OWNER, TABLE_NAME`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		table := &dumpTable{}
+		if err := rows.Scan(&table.owner, &table.name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+		tableMap[quote(table.owner, table.name)] = table
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	identityMap := make(map[string]string)
+	identityQuery := `
+		SELECT OWNER, TABLE_NAME, COLUMN_NAME, GENERATION_TYPE
+		FROM sys.all_tab_identity_cols
+		WHERE ` + ownerFilter("OWNER")
+	identityRows, err := txn.QueryContext(ctx, identityQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer identityRows.Close()
+	for identityRows.Next() {
+		var owner, table, column, generationType string
+		if err := identityRows.Scan(&owner, &table, &column, &generationType); err != nil {
+			return nil, err
+		}
+		identityMap[quote(owner, table, column)] = generationType
+	}
+	if err := identityRows.Err(); err != nil {
+		return nil, err
+	}
+
+	columnQuery := `
+		SELECT OWNER, TABLE_NAME, COLUMN_NAME, DATA_TYPE, DATA_LENGTH, DATA_PRECISION, DATA_SCALE, CHAR_LENGTH, CHAR_USED, NULLABLE, DATA_DEFAULT, VIRTUAL_COLUMN
+		FROM sys.all_tab_cols
+		WHERE ` + ownerFilter("OWNER") + ` AND HIDDEN_COLUMN = 'NO'
+		ORDER BY OWNER, TABLE_NAME, COLUMN_ID`
+	columnRows, err := txn.QueryContext(ctx, columnQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer columnRows.Close()
+	for columnRows.Next() {
+		var owner, tableName, dataType, nullable, virtual string
+		var length, charLength int64
+		var precision, scale sql.NullInt64
+		var charUsed sql.NullString
+		column := &dumpColumn{}
+		if err := columnRows.Scan(&owner, &tableName, &column.name, &dataType, &length, &precision, &scale, &charLength, &charUsed, &nullable, &column.defaultExpr, &virtual); err != nil {
+			return nil, err
+		}
+		table, ok := tableMap[quote(owner, tableName)]
+		if !ok {
+			// The columns of the views and the excluded tables.
+			continue
+		}
+		column.tp = getColumnType(dataType, length, precision, scale, charLength, charUsed.String)
+		column.nullable = nullable == "Y"
+		column.virtual = virtual == "YES"
+		column.identity = identityMap[quote(owner, tableName, column.name)]
+		table.columns = append(table.columns, column)
+	}
+	if err := columnRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// getColumnType returns the column type in the form used in CREATE TABLE.
+func getColumnType(dataType string, length int64, precision, scale sql.NullInt64, charLength int64, charUsed string) string {
+	switch dataType {
+	case "NUMBER":
+		switch {
+		case !precision.Valid && !scale.Valid:
+			return "NUMBER"
+		case !precision.Valid:
+			// NUMBER(*, 0) is shown as INTEGER.
+			if scale.Int64 == 0 {
+				return "INTEGER"
+			}
+			return fmt.Sprintf("NUMBER(*, %d)", scale.Int64)
+		case scale.Int64 == 0:
+			return fmt.Sprintf("NUMBER(%d)", precision.Int64)
+		default:
+			return fmt.Sprintf("NUMBER(%d, %d)", precision.Int64, scale.Int64)
+		}
+	case "FLOAT":
+		if precision.Valid {
+			return fmt.Sprintf("FLOAT(%d)", precision.Int64)
+		}
+		return "FLOAT"
+	case "VARCHAR2", "CHAR":
+		if charUsed == "C" {
+			return fmt.Sprintf("%s(%d CHAR)", dataType, charLength)
+		}
+		return fmt.Sprintf("%s(%d BYTE)", dataType, length)
+	case "NVARCHAR2", "NCHAR":
+		return fmt.Sprintf("%s(%d)", dataType, charLength)
+	case "RAW", "UROWID":
+		return fmt.Sprintf("%s(%d)", dataType, length)
+	default:
+		// The types such as TIMESTAMP(6) and INTERVAL DAY(2) TO SECOND(6) contain the precisions already.
+		return dataType
+	}
+}
+
+func getTableStmt(table *dumpTable) string {
+	var lines []string
+	for _, column := range table.columns {
+		parts := []string{quote(column.name), column.tp}
+		defaultExpr := strings.TrimSpace(column.defaultExpr.String)
+		switch {
+		case column.virtual:
+			parts = append(parts, fmt.Sprintf("GENERATED ALWAYS AS (%s) VIRTUAL", defaultExpr))
+		case column.identity != "":
+			// The default expression of the identity column is the NEXTVAL of the system sequence.
+			parts = append(parts, fmt.Sprintf("GENERATED %s AS IDENTITY", column.identity))
+		case column.defaultExpr.Valid:
+			parts = append(parts, "DEFAULT", defaultExpr)
+		}
+		if !column.nullable {
+			parts = append(parts, "NOT NULL")
+		}
+		lines = append(lines, "  "+strings.Join(parts, " "))
+	}
+	for _, c := range table.constraints {
+		switch c.tp {
+		case "P":
+			lines = append(lines, fmt.Sprintf("  CONSTRAINT %s PRIMARY KEY (%s)", quote(c.name), quoteList(c.columns)))
+		case "U":
+			lines = append(lines, fmt.Sprintf("  CONSTRAINT %s UNIQUE (%s)", quote(c.name), quoteList(c.columns)))
+		case "C":
+			lines = append(lines, fmt.Sprintf("  CONSTRAINT %s CHECK (%s)", quote(c.name), c.condition))
+		}
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n);\n\n", quote(table.owner, table.name), strings.Join(lines, ",\n"))
+}
+
+// getDumpConstraints gets the constraints with their columns. The map key is the quoted owner
+// and constraint name, which is used to look up the referenced constraints of the foreign keys.
+func getDumpConstraints(ctx context.Context, txn *sql.Tx) ([]*dumpConstraint, map[string]*dumpConstraint, error) {
+	var constraints []*dumpConstraint
+	constraintMap := make(map[string]*dumpConstraint)
+	query := `
+		SELECT OWNER, CONSTRAINT_NAME, CONSTRAINT_TYPE, TABLE_NAME, SEARCH_CONDITION_VC, GENERATED, R_OWNER, R_CONSTRAINT_NAME, DELETE_RULE
+		FROM sys.all_constraints
+		WHERE ` + ownerFilter("OWNER") + ` AND CONSTRAINT_TYPE IN ('P', 'U', 'R', 'C')
+		ORDER BY OWNER, TABLE_NAME, CONSTRAINT_TYPE, CONSTRAINT_NAME`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var condition, refOwner, refName, deleteRule sql.NullString
+		var generated string
+		c := &dumpConstraint{}
+		if err := rows.Scan(&c.owner, &c.name, &c.tp, &c.table, &condition, &generated, &refOwner, &refName, &deleteRule); err != nil {
+			return nil, nil, err
+		}
+		c.condition = condition.String
+		c.generatedName = generated == "GENERATED NAME"
+		c.refOwner, c.refName, c.deleteRule = refOwner.String, refName.String, deleteRule.String
+		constraints = append(constraints, c)
+		constraintMap[quote(c.owner, c.name)] = c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	columnQuery := `
+		SELECT OWNER, CONSTRAINT_NAME, COLUMN_NAME
+		FROM sys.all_cons_columns
+		WHERE ` + ownerFilter("OWNER") + `
+		ORDER BY OWNER, CONSTRAINT_NAME, POSITION`
+	columnRows, err := txn.QueryContext(ctx, columnQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer columnRows.Close()
+	for columnRows.Next() {
+		var owner, name, column string
+		if err := columnRows.Scan(&owner, &name, &column); err != nil {
+			return nil, nil, err
+		}
+		if c, ok := constraintMap[quote(owner, name)]; ok {
+			c.columns = append(c.columns, column)
+		}
+	}
+	if err := columnRows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return constraints, constraintMap, nil
+}
+
+func getForeignKeyStmt(c *dumpConstraint, constraintMap map[string]*dumpConstraint) (string, error) {
+	ref, ok := constraintMap[quote(c.refOwner, c.refName)]
+	if !ok {
+		return "", errors.Errorf("referenced constraint %s of foreign key %s not found", quote(c.refOwner, c.refName), quote(c.owner, c.name))
+	}
+	stmt := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		quote(c.owner, c.table), quote(c.name), quoteList(c.columns), quote(ref.owner, ref.table), quoteList(ref.columns))
+	if c.deleteRule == "CASCADE" || c.deleteRule == "SET NULL" {
+		stmt += " ON DELETE " + c.deleteRule
+	}
+	return stmt + ";\n\n", nil
+}
+
+func dumpIndexes(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	// The expressions of the function-based indexes replace the system generated columns.
+	expressionMap := make(map[string]string)
+	expressionQuery := `
+		SELECT INDEX_OWNER, INDEX_NAME, COLUMN_POSITION, COLUMN_EXPRESSION
+		FROM sys.all_ind_expressions
+		WHERE ` + ownerFilter("INDEX_OWNER")
+	expressionRows, err := txn.QueryContext(ctx, expressionQuery)
+	if err != nil {
+		return err
+	}
+	defer expressionRows.Close()
+	for expressionRows.Next() {
+		var owner, name, expression string
+		var position int
+		if err := expressionRows.Scan(&owner, &name, &position, &expression); err != nil {
+			return err
+		}
+		expressionMap[fmt.Sprintf("%s.%d", quote(owner, name), position)] = expression
+	}
+	if err := expressionRows.Err(); err != nil {
+		return err
+	}
+
+	columnMap := make(map[string][]string)
+	columnQuery := `
+		SELECT INDEX_OWNER, INDEX_NAME, COLUMN_POSITION, COLUMN_NAME, DESCEND
+		FROM sys.all_ind_columns
+		WHERE ` + ownerFilter("INDEX_OWNER") + `
+		ORDER BY INDEX_OWNER, INDEX_NAME, COLUMN_POSITION`
+	columnRows, err := txn.QueryContext(ctx, columnQuery)
+	if err != nil {
+		return err
+	}
+	defer columnRows.Close()
+	for columnRows.Next() {
+		var owner, name, column, descend string
+		var position int
+		if err := columnRows.Scan(&owner, &name, &position, &column, &descend); err != nil {
+			return err
+		}
+		key := quote(owner, name)
+		expression, ok := expressionMap[fmt.Sprintf("%s.%d", key, position)]
+		if !ok {
+			expression = quote(column)
+		}
+		if descend == "DESC" {
+			expression += " DESC"
+		}
+		columnMap[key] = append(columnMap[key], expression)
+	}
+	if err := columnRows.Err(); err != nil {
+		return err
+	}
+
+	// The indexes backing the primary keys and the unique keys are created with the constraints.
+	query := `
+		SELECT i.OWNER, i.INDEX_NAME, i.TABLE_OWNER, i.TABLE_NAME, i.UNIQUENESS, i.INDEX_TYPE
+		FROM sys.all_indexes i
+		WHERE ` + ownerFilter("i.OWNER") + `
+			AND i.INDEX_TYPE IN ('NORMAL', 'BITMAP', 'FUNCTION-BASED NORMAL', 'FUNCTION-BASED BITMAP')
+			AND i.TABLE_NAME NOT LIKE 'BIN$%'
+			AND NOT EXISTS (
+				SELECT 1 FROM sys.all_constraints c
+				WHERE c.INDEX_OWNER = i.OWNER AND c.INDEX_NAME = i.INDEX_NAME
+			)
+		ORDER BY i.TABLE_OWNER, i.TABLE_NAME, i.INDEX_NAME`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var owner, name, tableOwner, tableName, uniqueness, indexType string
+		if err := rows.Scan(&owner, &name, &tableOwner, &tableName, &uniqueness, &indexType); err != nil {
+			return err
+		}
+		kind := "INDEX"
+		switch {
+		case uniqueness == "UNIQUE":
+			kind = "UNIQUE INDEX"
+		case strings.HasSuffix(indexType, "BITMAP"):
+			kind = "BITMAP INDEX"
+		}
+		stmt := fmt.Sprintf("CREATE %s %s ON %s (%s);\n\n", kind, quote(owner, name), quote(tableOwner, tableName), strings.Join(columnMap[quote(owner, name)], ", "))
+		if _, err := io.WriteString(out, stmt); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func dumpViews(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	query := `
+		SELECT OWNER, VIEW_NAME, TEXT
+		FROM sys.all_views
+		WHERE ` + ownerFilter("OWNER") + `
+		ORDER BY OWNER, VIEW_NAME`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var owner, name, text string
+		if err := rows.Scan(&owner, &name, &text); err != nil {
+			return err
+		}
+		stmt := fmt.Sprintf("CREATE VIEW %s AS %s;\n\n", quote(owner, name), strings.TrimRight(strings.TrimSpace(text), ";"))
+		if _, err := io.WriteString(out, stmt); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -53,6 +53,7 @@ func init() {
 // Driver is the Postgres driver.
 type Driver struct {
 	dbBinDir      string
+	archiveDir    string
 	connectionCtx db.ConnectionContext
 	config        db.ConnectionConfig
 
@@ -66,7 +67,8 @@ type Driver struct {
 
 func newDriver(config db.DriverConfig) db.Driver {
 	return &Driver{
-		dbBinDir: config.DbBinDir,
+		dbBinDir:   config.DbBinDir,
+		archiveDir: config.BinlogDir,
 	}
 }
 
@@ -0,0 +1,755 @@
+package pg
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/db/util"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+	"github.com/bytebase/bytebase/backend/resources/postgres"
+)
+
+const (
+	// walArchiveSlotName is the physical replication slot to archive the WAL files.
+	// The server retains the WAL files which have not been received through the slot.
+	walArchiveSlotName = "bytebase_wal_archive"
+	walDirName         = "wal"
+	baseBackupDirName  = "base"
+	partialWALSuffix   = ".partial"
+	// restoreWALDirName is the directory in the restored data directory to collect the WAL files to replay.
+	restoreWALDirName = "bytebase_restore_wal"
+	baseBackupSuffix  = ".tar.gz"
+	// minPITRVersionNum is the minimum server_version_num for PITR.
+	// PostgreSQL 12 replaces recovery.conf with recovery.signal.
+	minPITRVersionNum = 120000
+)
+
+var (
+	// walSegmentRegexp matches the name of a WAL segment file, which consists of the timeline, the log and the segment in hex.
+	walSegmentRegexp = regexp.MustCompile(`^[0-9A-F]{24}$`)
+	// backupLabelStartWALRegexp matches the start WAL file in the backup_label file, e.g.
+	// START WAL LOCATION: 0/2000028 (file 000000010000000000000002).
+	backupLabelStartWALRegexp = regexp.MustCompile(`START WAL LOCATION: .* \(file ([0-9A-F]{24})\)`)
+	// postgresVersionRegexp matches the major version in the output of postgres -V, e.g. postgres (PostgreSQL) 14.5.
+	postgresVersionRegexp = regexp.MustCompile(`\(PostgreSQL\) (\d+)`)
+)
+
+// BaseBackup is a base backup of the instance taken by pg_basebackup.
+type BaseBackup struct {
+	Name string
+	// StartTs and EndTs are the time when pg_basebackup starts and finishes.
+	// The base backup can only recover the instance to a time after EndTs.
+	StartTs int64
+	EndTs   int64
+}
+
+// CheckPITR checks whether the instance meets the prerequisites of archiving WAL files and PITR.
+func (driver *Driver) CheckPITR(ctx context.Context) error {
+	version, err := driver.getVersion(ctx)
+	if err != nil {
+		return err
+	}
+	versionNum, err := strconv.Atoi(version)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse server_version_num %q", version)
+	}
+	if versionNum < minPITRVersionNum {
+		return errors.Errorf("PITR requires PostgreSQL 12 or later, but the server_version_num is %d", versionNum)
+	}
+	// The WAL files and the base backups are replayed by the bundled PostgreSQL, which must have the same major version as the server.
+	localMajorVersion, err := driver.getLocalMajorVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if versionNum/10000 != localMajorVersion {
+		return errors.Errorf("PITR requires the major version of the server to be %d as the bundled PostgreSQL, but got %d", localMajorVersion, versionNum/10000)
+	}
+
+	var walLevel string
+	if err := driver.db.QueryRowContext(ctx, "SHOW wal_level").Scan(&walLevel); err != nil {
+		return util.FormatErrorWithQuery(err, "SHOW wal_level")
+	}
+	if walLevel != "replica" && walLevel != "logical" {
+		return errors.Errorf("PITR requires wal_level to be replica or logical, but got %q", walLevel)
+	}
+
+	var maxWALSenders int
+	if err := driver.db.QueryRowContext(ctx, "SHOW max_wal_senders").Scan(&maxWALSenders); err != nil {
+		return util.FormatErrorWithQuery(err, "SHOW max_wal_senders")
+	}
+	if maxWALSenders == 0 {
+		return errors.Errorf("PITR requires max_wal_senders to be greater than 0")
+	}
+
+	query := "SELECT rolsuper OR rolreplication FROM pg_roles WHERE rolname = current_user"
+	var canReplicate bool
+	if err := driver.db.QueryRowContext(ctx, query).Scan(&canReplicate); err != nil {
+		if err == sql.ErrNoRows {
+			return common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return util.FormatErrorWithQuery(err, query)
+	}
+	if !canReplicate {
+		return errors.Errorf("PITR requires user %q to have the REPLICATION privilege", driver.config.Username)
+	}
+
+	// The base backup is restored into a single data directory, so tablespaces elsewhere are not supported.
+	query = "SELECT COUNT(*) FROM pg_tablespace WHERE spcname NOT IN ('pg_default', 'pg_global')"
+	var tablespaceCount int
+	if err := driver.db.QueryRowContext(ctx, query).Scan(&tablespaceCount); err != nil {
+		return util.FormatErrorWithQuery(err, query)
+	}
+	if tablespaceCount > 0 {
+		return errors.Errorf("PITR does not support instances with user-defined tablespaces")
+	}
+	return nil
+}
+
+// CheckWALArchive checks whether the WAL files are being archived, and there is a base backup to recover to targetTs.
+func (driver *Driver) CheckWALArchive(ctx context.Context, client storage.Backend, targetTs int64) error {
+	var slotCount int
+	if err := driver.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pg_replication_slots WHERE slot_name = $1", walArchiveSlotName).Scan(&slotCount); err != nil {
+		return util.FormatErrorWithQuery(err, "SELECT COUNT(*) FROM pg_replication_slots")
+	}
+	if slotCount == 0 {
+		return errors.Errorf("replication slot %q does not exist, WAL files have not been archived yet", walArchiveSlotName)
+	}
+	if _, err := driver.getLatestBaseBackupBeforeTs(ctx, client, targetTs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ArchiveWAL receives the WAL files of the instance up to the current flush position through the replication slot.
+// If client is not nil, the completed WAL segments are uploaded to the storage backend and removed locally,
+// except the latest one which pg_receivewal resumes from.
+func (driver *Driver) ArchiveWAL(ctx context.Context, client storage.Backend) error {
+	walDir := filepath.Join(driver.archiveDir, walDirName)
+	if err := os.MkdirAll(walDir, os.ModePerm); err != nil {
+		return errors.Wrapf(err, "failed to create WAL directory %q", walDir)
+	}
+	if err := driver.createWALArchiveSlot(ctx); err != nil {
+		return err
+	}
+	var endPos string
+	if err := driver.db.QueryRowContext(ctx, "SELECT pg_current_wal_flush_lsn()::text").Scan(&endPos); err != nil {
+		return util.FormatErrorWithQuery(err, "SELECT pg_current_wal_flush_lsn()")
+	}
+
+	args := driver.getReplicationArgs()
+	args = append(args,
+		fmt.Sprintf("--directory=%s", walDir),
+		fmt.Sprintf("--slot=%s", walArchiveSlotName),
+		fmt.Sprintf("--endpos=%s", endPos),
+		"--no-loop",
+	)
+	if err := driver.runReplicationCommand(ctx, "pg_receivewal", args); err != nil {
+		return errors.Wrap(err, "failed to receive WAL files")
+	}
+
+	if client == nil {
+		return nil
+	}
+	return driver.uploadWALFiles(ctx, client)
+}
+
+// TakeBaseBackup takes a base backup of the instance without WAL files, which are archived by ArchiveWAL instead.
+// If client is not nil, the base backup is uploaded to the storage backend and removed locally.
+func (driver *Driver) TakeBaseBackup(ctx context.Context, client storage.Backend) (*BaseBackup, error) {
+	baseBackupDir := filepath.Join(driver.archiveDir, baseBackupDirName)
+	if err := os.MkdirAll(baseBackupDir, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "failed to create base backup directory %q", baseBackupDir)
+	}
+	startTs := time.Now().Unix()
+	tempDir, err := os.MkdirTemp(baseBackupDir, "tmp-")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create temporary base backup directory")
+	}
+	defer os.RemoveAll(tempDir)
+
+	args := driver.getReplicationArgs()
+	args = append(args,
+		fmt.Sprintf("--pgdata=%s", tempDir),
+		"--format=tar",
+		"--gzip",
+		"--wal-method=none",
+		"--checkpoint=fast",
+	)
+	if err := driver.runReplicationCommand(ctx, "pg_basebackup", args); err != nil {
+		return nil, errors.Wrap(err, "failed to take base backup")
+	}
+	baseBackup := &BaseBackup{
+		StartTs: startTs,
+		EndTs:   time.Now().Unix(),
+	}
+	baseBackup.Name = fmt.Sprintf("%d-%d%s", baseBackup.StartTs, baseBackup.EndTs, baseBackupSuffix)
+	baseBackupPath := filepath.Join(baseBackupDir, baseBackup.Name)
+	if err := os.Rename(filepath.Join(tempDir, "base.tar.gz"), baseBackupPath); err != nil {
+		return nil, errors.Wrapf(err, "failed to move base backup to %q", baseBackupPath)
+	}
+
+	if client == nil {
+		return baseBackup, nil
+	}
+	f, err := os.Open(baseBackupPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open base backup %q", baseBackupPath)
+	}
+	defer f.Close()
+	if err := client.UploadObject(ctx, path.Join(driver.getArchiveDirOnCloud(), baseBackupDirName, baseBackup.Name), f); err != nil {
+		return nil, errors.Wrapf(err, "failed to upload base backup %q", baseBackup.Name)
+	}
+	if err := os.Remove(baseBackupPath); err != nil {
+		log.Warn("Failed to remove the uploaded base backup", zap.String("path", baseBackupPath), zap.Error(err))
+	}
+	return baseBackup, nil
+}
+
+// ListBaseBackups lists the base backups on local disk and in the storage backend, sorted by EndTs.
+func (driver *Driver) ListBaseBackups(ctx context.Context, client storage.Backend) ([]*BaseBackup, error) {
+	var names []string
+	baseBackupDir := filepath.Join(driver.archiveDir, baseBackupDirName)
+	entries, err := os.ReadDir(baseBackupDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "failed to read base backup directory %q", baseBackupDir)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	if client != nil {
+		objects, err := client.ListObjects(ctx, path.Join(driver.getArchiveDirOnCloud(), baseBackupDirName)+"/")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list base backups in the storage backend")
+		}
+		for _, object := range objects {
+			names = append(names, path.Base(object.Key))
+		}
+	}
+
+	var baseBackups []*BaseBackup
+	for _, name := range names {
+		baseBackup, err := parseBaseBackupName(name)
+		if err != nil {
+			log.Warn("Skip the unknown file in the base backup directory", zap.String("name", name), zap.Error(err))
+			continue
+		}
+		baseBackups = append(baseBackups, baseBackup)
+	}
+	sort.Slice(baseBackups, func(i, j int) bool {
+		return baseBackups[i].EndTs < baseBackups[j].EndTs
+	})
+	return baseBackups, nil
+}
+
+// PurgeArchive deletes the base backups which finished before purgeBeforeTs, and the WAL files only needed by them.
+// The latest base backup is always kept.
+func (driver *Driver) PurgeArchive(ctx context.Context, client storage.Backend, purgeBeforeTs int64) error {
+	baseBackups, err := driver.ListBaseBackups(ctx, client)
+	if err != nil {
+		return err
+	}
+	if len(baseBackups) == 0 {
+		return nil
+	}
+	var purgeList []*BaseBackup
+	oldestKept := baseBackups[len(baseBackups)-1]
+	for i := len(baseBackups) - 2; i >= 0; i-- {
+		if baseBackups[i].EndTs < purgeBeforeTs {
+			purgeList = append(purgeList, baseBackups[i])
+		} else {
+			oldestKept = baseBackups[i]
+		}
+	}
+
+	cloudDir := driver.getArchiveDirOnCloud()
+	for _, baseBackup := range purgeList {
+		log.Debug("Deleting expired base backup", zap.String("name", baseBackup.Name))
+		if err := os.Remove(filepath.Join(driver.archiveDir, baseBackupDirName, baseBackup.Name)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to delete base backup %q", baseBackup.Name)
+		}
+		if client != nil {
+			if err := client.DeleteObjects(ctx, path.Join(cloudDir, baseBackupDirName, baseBackup.Name)); err != nil {
+				return errors.Wrapf(err, "failed to delete base backup %q in the storage backend", baseBackup.Name)
+			}
+		}
+	}
+
+	// The WAL files written before the oldest kept base backup starts are no longer needed.
+	walPurgeBefore := time.Unix(oldestKept.StartTs, 0)
+	walDir := filepath.Join(driver.archiveDir, walDirName)
+	entries, err := os.ReadDir(walDir)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to read WAL directory %q", walDir)
+	}
+	latestSegment := getLatestWALSegment(entries)
+	for _, entry := range entries {
+		// Keep the latest segment which pg_receivewal resumes from.
+		if !walSegmentRegexp.MatchString(entry.Name()) || entry.Name() == latestSegment {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(walPurgeBefore) {
+			if err := os.Remove(filepath.Join(walDir, entry.Name())); err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "failed to delete WAL file %q", entry.Name())
+			}
+		}
+	}
+	if client != nil {
+		objects, err := client.ListObjects(ctx, path.Join(cloudDir, walDirName)+"/")
+		if err != nil {
+			return errors.Wrap(err, "failed to list WAL files in the storage backend")
+		}
+		var deleteList []string
+		for _, object := range objects {
+			if walSegmentRegexp.MatchString(path.Base(object.Key)) && object.LastModified.Before(walPurgeBefore) {
+				deleteList = append(deleteList, object.Key)
+			}
+		}
+		if len(deleteList) > 0 {
+			if err := client.DeleteObjects(ctx, deleteList...); err != nil {
+				return errors.Wrap(err, "failed to delete WAL files in the storage backend")
+			}
+		}
+	}
+	return nil
+}
+
+// DumpPITRDatabase recovers the instance to targetTs on a temporary server from the latest base backup before targetTs and the archived WAL files,
+// and dumps the database to out.
+func (driver *Driver) DumpPITRDatabase(ctx context.Context, client storage.Backend, databaseName string, targetTs int64, out io.Writer) error {
+	// Write a commit record after targetTs, so that the recovery can stop at targetTs even if the instance has been idle since then.
+	if _, err := driver.db.ExecContext(ctx, "SELECT txid_current()"); err != nil {
+		return util.FormatErrorWithQuery(err, "SELECT txid_current()")
+	}
+	if err := driver.ArchiveWAL(ctx, client); err != nil {
+		return err
+	}
+	baseBackup, err := driver.getLatestBaseBackupBeforeTs(ctx, client, targetTs)
+	if err != nil {
+		return err
+	}
+
+	pitrDir, err := os.MkdirTemp(driver.archiveDir, "pitr-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create PITR directory")
+	}
+	defer os.RemoveAll(pitrDir)
+	// The temporary server may run as another user, who needs to access the data directory inside.
+	if err := os.Chmod(pitrDir, 0755); err != nil {
+		return errors.Wrapf(err, "failed to chmod PITR directory %q", pitrDir)
+	}
+	dataDir := filepath.Join(pitrDir, "data")
+
+	baseBackupPath := filepath.Join(driver.archiveDir, baseBackupDirName, baseBackup.Name)
+	if _, err := os.Stat(baseBackupPath); os.IsNotExist(err) && client != nil {
+		baseBackupPath = filepath.Join(pitrDir, baseBackup.Name)
+		if err := storage.DownloadFileFromCloud(ctx, client, baseBackupPath, path.Join(driver.getArchiveDirOnCloud(), baseBackupDirName, baseBackup.Name)); err != nil {
+			return errors.Wrapf(err, "failed to download base backup %q", baseBackup.Name)
+		}
+	}
+	if err := extractTarGz(baseBackupPath, dataDir); err != nil {
+		return errors.Wrapf(err, "failed to extract base backup %q", baseBackup.Name)
+	}
+	startWAL, err := readStartWALFromBackupLabel(dataDir)
+	if err != nil {
+		return err
+	}
+	// The WAL files are collected inside the data directory, so that they are owned by the same user as the temporary server.
+	restoreWALDir := filepath.Join(dataDir, restoreWALDirName)
+	if err := os.MkdirAll(restoreWALDir, 0700); err != nil {
+		return errors.Wrapf(err, "failed to create directory %q", restoreWALDir)
+	}
+	if client != nil {
+		if err := driver.downloadWALFiles(ctx, client, startWAL, restoreWALDir); err != nil {
+			return err
+		}
+	}
+	if err := driver.copyLocalWALFiles(startWAL, restoreWALDir); err != nil {
+		return err
+	}
+	if err := writeRecoveryConfig(dataDir, restoreWALDir, targetTs); err != nil {
+		return err
+	}
+
+	port, err := getFreePort()
+	if err != nil {
+		return err
+	}
+	log.Info("Starting temporary PostgreSQL server for PITR",
+		zap.String("baseBackup", baseBackup.Name),
+		zap.Int64("targetTs", targetTs),
+		zap.Int("port", port))
+	if err := postgres.StartForRecovery(ctx, port, driver.dbBinDir, dataDir); err != nil {
+		return errors.Wrapf(err, "failed to recover to %s, the WAL files may be incomplete", time.Unix(targetTs, 0).UTC().Format(time.RFC3339))
+	}
+	defer func() {
+		if err := postgres.Stop(driver.dbBinDir, dataDir); err != nil {
+			log.Error("Failed to stop the temporary PostgreSQL server for PITR", zap.String("dataDir", dataDir), zap.Error(err))
+		}
+	}()
+
+	recoveredDriver, err := db.Open(ctx, db.Postgres, db.DriverConfig{DbBinDir: driver.dbBinDir}, db.ConnectionConfig{
+		Host:     common.GetPostgresSocketDir(),
+		Port:     strconv.Itoa(port),
+		Username: driver.config.Username,
+		Database: databaseName,
+	}, db.ConnectionContext{})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to the temporary PostgreSQL server for PITR")
+	}
+	defer recoveredDriver.Close(ctx)
+	var inRecovery bool
+	if err := recoveredDriver.GetDB().QueryRowContext(ctx, "SELECT pg_is_in_recovery()").Scan(&inRecovery); err != nil {
+		return util.FormatErrorWithQuery(err, "SELECT pg_is_in_recovery()")
+	}
+	if inRecovery {
+		return errors.Errorf("the temporary PostgreSQL server for PITR is still in recovery")
+	}
+	if _, err := recoveredDriver.Dump(ctx, out, false /* schemaOnly */); err != nil {
+		return errors.Wrapf(err, "failed to dump database %q from the temporary PostgreSQL server for PITR", databaseName)
+	}
+	return nil
+}
+
+func (driver *Driver) getArchiveDirOnCloud() string {
+	return filepath.ToSlash(common.GetBinlogRelativeDir(driver.archiveDir))
+}
+
+func (driver *Driver) getLocalMajorVersion(ctx context.Context) (int, error) {
+	output, err := exec.CommandContext(ctx, filepath.Join(driver.dbBinDir, "postgres"), "-V").Output()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get the version of the bundled PostgreSQL")
+	}
+	matches := postgresVersionRegexp.FindStringSubmatch(string(output))
+	if len(matches) != 2 {
+		return 0, errors.Errorf("failed to parse the version of the bundled PostgreSQL %q", output)
+	}
+	return strconv.Atoi(matches[1])
+}
+
+func (driver *Driver) createWALArchiveSlot(ctx context.Context) error {
+	// The slot reserves the WAL files immediately, so that the WAL files written before the first pg_receivewal run are retained.
+	query := `
+		SELECT pg_create_physical_replication_slot($1, true)
+		WHERE NOT EXISTS (SELECT 1 FROM pg_replication_slots WHERE slot_name = $1)`
+	if _, err := driver.db.ExecContext(ctx, query, walArchiveSlotName); err != nil {
+		return util.FormatErrorWithQuery(err, query)
+	}
+	return nil
+}
+
+func (driver *Driver) getReplicationArgs() []string {
+	var args []string
+	args = append(args, fmt.Sprintf("--username=%s", driver.config.Username))
+	if driver.config.Password == "" {
+		args = append(args, "--no-password")
+	}
+	args = append(args, fmt.Sprintf("--host=%s", driver.config.Host))
+	args = append(args, fmt.Sprintf("--port=%s", driver.config.Port))
+	return args
+}
+
+func (driver *Driver) runReplicationCommand(ctx context.Context, name string, args []string) error {
+	cmd := exec.CommandContext(ctx, filepath.Join(driver.dbBinDir, name), args...)
+	if driver.config.Password != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("PGPASSWORD=%s", driver.config.Password))
+	}
+	cmd.Env = append(cmd.Env, "OPENSSL_CONF=/etc/ssl/")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "%s error message: %s", name, output)
+	}
+	return nil
+}
+
+func (driver *Driver) uploadWALFiles(ctx context.Context, client storage.Backend) error {
+	walDir := filepath.Join(driver.archiveDir, walDirName)
+	cloudDir := path.Join(driver.getArchiveDirOnCloud(), walDirName)
+	objects, err := client.ListObjects(ctx, cloudDir+"/")
+	if err != nil {
+		return errors.Wrap(err, "failed to list WAL files in the storage backend")
+	}
+	uploaded := make(map[string]bool)
+	for _, object := range objects {
+		uploaded[path.Base(object.Key)] = true
+	}
+
+	entries, err := os.ReadDir(walDir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read WAL directory %q", walDir)
+	}
+	latestSegment := getLatestWALSegment(entries)
+	for _, entry := range entries {
+		name := entry.Name()
+		// The partial segment is still being written, and is only uploaded once completed.
+		if entry.IsDir() || strings.HasSuffix(name, partialWALSuffix) {
+			continue
+		}
+		filePath := filepath.Join(walDir, name)
+		if !uploaded[name] {
+			if err := uploadFile(ctx, client, filePath, path.Join(cloudDir, name)); err != nil {
+				return errors.Wrapf(err, "failed to upload WAL file %q", name)
+			}
+			log.Debug("Successfully uploaded WAL file to cloud storage", zap.String("path", filePath))
+		}
+		// Keep the latest segment which pg_receivewal resumes from, and the small timeline history files.
+		if walSegmentRegexp.MatchString(name) && name != latestSegment {
+			if err := os.Remove(filePath); err != nil {
+				log.Warn("Failed to remove the uploaded WAL file", zap.String("path", filePath), zap.Error(err))
+			}
+		}
+	}
+	return nil
+}
+
+// downloadWALFiles downloads the WAL files since startWAL from the storage backend, as well as the timeline history files.
+func (driver *Driver) downloadWALFiles(ctx context.Context, client storage.Backend, startWAL, dir string) error {
+	objects, err := client.ListObjects(ctx, path.Join(driver.getArchiveDirOnCloud(), walDirName)+"/")
+	if err != nil {
+		return errors.Wrap(err, "failed to list WAL files in the storage backend")
+	}
+	for _, object := range objects {
+		name := path.Base(object.Key)
+		if walSegmentRegexp.MatchString(name) && name < startWAL {
+			continue
+		}
+		if err := storage.DownloadFileFromCloud(ctx, client, filepath.Join(dir, name), object.Key); err != nil {
+			return errors.Wrapf(err, "failed to download WAL file %q", name)
+		}
+	}
+	return nil
+}
+
+// copyLocalWALFiles copies the WAL files since startWAL in the local WAL directory, including the partial segment, to dir.
+func (driver *Driver) copyLocalWALFiles(startWAL, dir string) error {
+	walDir := filepath.Join(driver.archiveDir, walDirName)
+	entries, err := os.ReadDir(walDir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read WAL directory %q", walDir)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			continue
+		}
+		if segment := strings.TrimSuffix(name, partialWALSuffix); walSegmentRegexp.MatchString(segment) && segment < startWAL {
+			continue
+		}
+		if err := copyFile(filepath.Join(walDir, name), filepath.Join(dir, name)); err != nil {
+			return errors.Wrapf(err, "failed to copy WAL file %q", name)
+		}
+	}
+	return nil
+}
+
+func (driver *Driver) getLatestBaseBackupBeforeTs(ctx context.Context, client storage.Backend, targetTs int64) (*BaseBackup, error) {
+	baseBackups, err := driver.ListBaseBackups(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(baseBackups) - 1; i >= 0; i-- {
+		if baseBackups[i].EndTs <= targetTs {
+			return baseBackups[i], nil
+		}
+	}
+	return nil, errors.Errorf("no base backup finished before %s", time.Unix(targetTs, 0).UTC().Format(time.RFC3339))
+}
+
+func getLatestWALSegment(entries []os.DirEntry) string {
+	latest := ""
+	for _, entry := range entries {
+		if walSegmentRegexp.MatchString(entry.Name()) && entry.Name() > latest {
+			latest = entry.Name()
+		}
+	}
+	return latest
+}
+
+func uploadFile(ctx context.Context, client storage.Backend, filePath, filePathOnCloud string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return client.UploadObject(ctx, filePathOnCloud, f)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// parseBaseBackupName parses the base backup name in the format of <startTs>-<endTs>.tar.gz.
+func parseBaseBackupName(name string) (*BaseBackup, error) {
+	if !strings.HasSuffix(name, baseBackupSuffix) {
+		return nil, errors.Errorf("base backup %q does not have suffix %q", name, baseBackupSuffix)
+	}
+	startTsStr, endTsStr, ok := strings.Cut(strings.TrimSuffix(name, baseBackupSuffix), "-")
+	if !ok {
+		return nil, errors.Errorf("invalid base backup name %q", name)
+	}
+	startTs, err := strconv.ParseInt(startTsStr, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid start time in base backup name %q", name)
+	}
+	endTs, err := strconv.ParseInt(endTsStr, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid end time in base backup name %q", name)
+	}
+	return &BaseBackup{Name: name, StartTs: startTs, EndTs: endTs}, nil
+}
+
+func readStartWALFromBackupLabel(dataDir string) (string, error) {
+	labelPath := filepath.Join(dataDir, "backup_label")
+	f, err := os.Open(labelPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to open %q", labelPath)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if matches := backupLabelStartWALRegexp.FindStringSubmatch(scanner.Text()); len(matches) == 2 {
+			return matches[1], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", errors.Wrapf(err, "failed to read %q", labelPath)
+	}
+	return "", errors.Errorf("start WAL location not found in %q", labelPath)
+}
+
+// writeRecoveryConfig replaces the configuration of the restored data directory with a minimal one,
+// which replays the WAL files in walDir until targetTs and then promotes.
+func writeRecoveryConfig(dataDir, walDir string, targetTs int64) error {
+	// The WAL records written since the last completed segment are in the partial segment.
+	restoreCommand := fmt.Sprintf(`cp "%s/%%f" "%%p" 2>/dev/null || cp "%s/%%f%s" "%%p"`, walDir, walDir, partialWALSuffix)
+
+	// The settings of the source instance may refer to files and libraries that do not exist locally, so we start from the defaults.
+	// The hot standby parameters are not checked against the source instance with hot_standby off.
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "restore_command = '%s'\n", strings.ReplaceAll(restoreCommand, "'", "''"))
+	fmt.Fprintf(&buf, "recovery_target_time = '%s'\n", time.Unix(targetTs, 0).UTC().Format("2006-01-02 15:04:05+00"))
+	buf.WriteString("recovery_target_action = 'promote'\n")
+	buf.WriteString("archive_mode = off\n")
+	buf.WriteString("hot_standby = off\n")
+	files := map[string]string{
+		"postgresql.conf":      buf.String(),
+		"postgresql.auto.conf": "",
+		"pg_hba.conf":          "local all all trust\n",
+		"pg_ident.conf":        "",
+		"recovery.signal":      "",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dataDir, name), []byte(content), 0600); err != nil {
+			return errors.Wrapf(err, "failed to write %q", name)
+		}
+	}
+	for _, name := range []string{"standby.signal", "postmaster.pid", "postmaster.opts"} {
+		if err := os.Remove(filepath.Join(dataDir, name)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to remove %q", name)
+		}
+	}
+	return nil
+}
+
+// extractTarGz extracts the tar.gz file taken by pg_basebackup to dir.
+func extractTarGz(src, dir string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gzipReader, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return errors.Errorf("invalid file path %q in the base backup", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tarReader); err != nil {
+				out.Close()
+				return err
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func getFreePort() (int, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to find a free port")
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,66 @@
+package pg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBaseBackupName(t *testing.T) {
+	a := require.New(t)
+	tests := []struct {
+		name    string
+		want    *BaseBackup
+		wantErr bool
+	}{
+		{
+			name: "1680000000-1680000060.tar.gz",
+			want: &BaseBackup{Name: "1680000000-1680000060.tar.gz", StartTs: 1680000000, EndTs: 1680000060},
+		},
+		{
+			name:    "1680000000-1680000060.tar",
+			wantErr: true,
+		},
+		{
+			name:    "1680000000.tar.gz",
+			wantErr: true,
+		},
+		{
+			name:    "start-end.tar.gz",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := parseBaseBackupName(test.name)
+		if test.wantErr {
+			a.Error(err, test.name)
+			continue
+		}
+		a.NoError(err, test.name)
+		a.Equal(test.want, got)
+	}
+}
+
+func TestReadStartWALFromBackupLabel(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	label := `START WAL LOCATION: 0/2000028 (file 000000010000000000000002)
+CHECKPOINT LOCATION: 0/2000060
+BACKUP METHOD: streamed
+BACKUP FROM: primary
+START TIME: 2023-04-01 00:00:00 UTC
+LABEL: pg_basebackup base backup
+START TIMELINE: 1
+`
+	a.NoError(os.WriteFile(filepath.Join(dir, "backup_label"), []byte(label), 0600))
+	startWAL, err := readStartWALFromBackupLabel(dir)
+	a.NoError(err)
+	a.Equal("000000010000000000000002", startWAL)
+
+	a.NoError(os.WriteFile(filepath.Join(dir, "backup_label"), []byte("LABEL: pg_basebackup base backup\n"), 0600))
+	_, err = readStartWALFromBackupLabel(dir)
+	a.Error(err)
+}
@@ -0,0 +1,61 @@
+package util
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+)
+
+// IsSessionCancelSupported returns true if the statement running in a session can be cancelled on the database server by its session ID.
+// For other engines, a running statement can only be cancelled by the driver when its context is done.
+func IsSessionCancelSupported(dbType db.Type) bool {
+	switch dbType {
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase, db.Postgres, db.Redshift:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetSessionID returns the ID of the database server session for the connection,
+// i.e. the connection ID for MySQL and the backend process ID for Postgres.
+func GetSessionID(ctx context.Context, dbType db.Type, conn *sql.Conn) (int64, error) {
+	var query string
+	switch dbType {
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+		query = "SELECT CONNECTION_ID()"
+	case db.Postgres, db.Redshift:
+		query = "SELECT pg_backend_pid()"
+	default:
+		return 0, errors.Errorf("getting session ID is not supported for %s", dbType)
+	}
+	var id int64
+	if err := conn.QueryRowContext(ctx, query).Scan(&id); err != nil {
+		return 0, FormatErrorWithQuery(err, query)
+	}
+	return id, nil
+}
+
+// CancelSession cancels the statement running in the session with a new connection from sqlDB.
+// Closing the connection of the session doesn't stop the running statement on the server for MySQL.
+func CancelSession(ctx context.Context, dbType db.Type, sqlDB *sql.DB, sessionID int64) error {
+	var query string
+	switch dbType {
+	case db.MySQL, db.MariaDB, db.OceanBase:
+		query = fmt.Sprintf("KILL QUERY %d", sessionID)
+	case db.TiDB:
+		query = fmt.Sprintf("KILL TIDB QUERY %d", sessionID)
+	case db.Postgres, db.Redshift:
+		query = fmt.Sprintf("SELECT pg_cancel_backend(%d)", sessionID)
+	default:
+		return errors.Errorf("cancelling session is not supported for %s", dbType)
+	}
+	if _, err := sqlDB.ExecContext(ctx, query); err != nil {
+		return FormatErrorWithQuery(err, query)
+	}
+	return nil
+}
@@ -126,27 +126,9 @@ func Query(ctx context.Context, dbType db.Type, conn *sql.Conn, statement string
 	if !readOnly {
 		return queryAdmin(ctx, dbType, conn, statement, limit)
 	}
-	// Limit SQL query result size.
-	if dbType == db.MySQL || dbType == db.MariaDB {
-		// MySQL 5.7 doesn't support WITH clause.
-		statement = getMySQLStatementWithResultLimit(statement, limit)
-	} else if dbType == db.Oracle {
-		statement = getOracleStatementWithResultLimit(statement, limit)
-	} else if dbType == db.MSSQL {
-		statement = getMSSQLStatementWithResultLimit(statement, limit)
-	} else {
-		statement = getStatementWithResultLimit(statement, limit)
-	}
+	statement = getStatementWithLimit(dbType, statement, limit)
 
-	// TiDB doesn't support READ ONLY transactions. We have to skip the flag for it.
-	// https://github.com/pingcap/tidb/issues/34626
-	// Clickhouse doesn't support READ ONLY transactions (Error: sql: driver does not support read-only transactions).
-	// Snowflake doesn't support READ ONLY transactions.
-	// https://github.com/snowflakedb/gosnowflake/blob/0450f0b16a4679b216baecd3fd6cdce739dbb683/connection.go#L166
-	if dbType == db.TiDB || dbType == db.ClickHouse || dbType == db.Snowflake || dbType == db.Spanner || dbType == db.Redis || dbType == db.Oracle || dbType == db.MSSQL {
-		readOnly = false
-	}
-	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: readOnly})
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: isReadOnlyTxSupported(dbType)})
 	if err != nil {
 		return nil, err
 	}
@@ -205,6 +187,36 @@ func Query(ctx context.Context, dbType db.Type, conn *sql.Conn, statement string
 	return []any{columnNames, columnTypeNames, data, fieldMaskInfo}, nil
 }
 
+// getStatementWithLimit limits the SQL query result size.
+func getStatementWithLimit(dbType db.Type, statement string, limit int) string {
+	switch dbType {
+	case db.MySQL, db.MariaDB:
+		// MySQL 5.7 doesn't support WITH clause.
+		return getMySQLStatementWithResultLimit(statement, limit)
+	case db.Oracle:
+		return getOracleStatementWithResultLimit(statement, limit)
+	case db.MSSQL:
+		return getMSSQLStatementWithResultLimit(statement, limit)
+	default:
+		return getStatementWithResultLimit(statement, limit)
+	}
+}
+
+// isReadOnlyTxSupported returns true if the database supports READ ONLY transactions.
+func isReadOnlyTxSupported(dbType db.Type) bool {
+	// TiDB doesn't support READ ONLY transactions. We have to skip the flag for it.
+	// https://github.com/pingcap/tidb/issues/34626
+	// Clickhouse doesn't support READ ONLY transactions (Error: sql: driver does not support read-only transactions).
+	// Snowflake doesn't support READ ONLY transactions.
+	// https://github.com/snowflakedb/gosnowflake/blob/0450f0b16a4679b216baecd3fd6cdce739dbb683/connection.go#L166
+	switch dbType {
+	case db.TiDB, db.ClickHouse, db.Snowflake, db.Spanner, db.Redis, db.Oracle, db.MSSQL:
+		return false
+	default:
+		return true
+	}
+}
+
 // query will execute a query.
 func queryAdmin(ctx context.Context, dbType db.Type, conn *sql.Conn, statement string, _ int) ([]any, error) {
 	rows, err := conn.QueryContext(ctx, statement)
@@ -255,61 +267,68 @@ func readRows(rows *sql.Rows, dbType db.Type, columnTypes []*sql.ColumnType, col
 	}
 	data := []any{}
 	for rows.Next() {
-		scanArgs := make([]any, len(columnTypes))
-		for i, v := range columnTypeNames {
-			// TODO(steven need help): Consult a common list of data types from database driver documentation. e.g. MySQL,PostgreSQL.
-			switch v {
-			case "VARCHAR", "TEXT", "UUID", "TIMESTAMP":
-				scanArgs[i] = new(sql.NullString)
-			case "BOOL":
-				scanArgs[i] = new(sql.NullBool)
-			case "INT", "INTEGER":
-				scanArgs[i] = new(sql.NullInt64)
-			case "FLOAT":
-				scanArgs[i] = new(sql.NullFloat64)
-			default:
-				scanArgs[i] = new(sql.NullString)
-			}
-		}
-
+		scanArgs := newScanArgs(columnTypeNames)
 		if err := rows.Scan(scanArgs...); err != nil {
 			return nil, err
 		}
+		data = append(data, convertScanArgs(scanArgs, fieldList))
+	}
 
-		rowData := []any{}
-		for i := range columnTypes {
-			if len(fieldList) > 0 && fieldList[i].Sensitive {
-				rowData = append(rowData, "******")
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullBool); ok && v.Valid {
-				rowData = append(rowData, v.Bool)
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullString); ok && v.Valid {
-				rowData = append(rowData, v.String)
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullInt64); ok && v.Valid {
-				rowData = append(rowData, v.Int64)
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullInt32); ok && v.Valid {
-				rowData = append(rowData, v.Int32)
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullFloat64); ok && v.Valid {
-				rowData = append(rowData, v.Float64)
-				continue
-			}
-			// If none of them match, set nil to its value.
-			rowData = append(rowData, nil)
+	return data, nil
+}
+
+// newScanArgs creates the scan destinations for a row by the column type names.
+func newScanArgs(columnTypeNames []string) []any {
+	scanArgs := make([]any, len(columnTypeNames))
+	for i, v := range columnTypeNames {
+		// TODO(steven need help): Consult a common list of data types from database driver documentation. e.g. MySQL,PostgreSQL.
+		switch v {
+		case "VARCHAR", "TEXT", "UUID", "TIMESTAMP":
+			scanArgs[i] = new(sql.NullString)
+		case "BOOL":
+			scanArgs[i] = new(sql.NullBool)
+		case "INT", "INTEGER":
+			scanArgs[i] = new(sql.NullInt64)
+		case "FLOAT":
+			scanArgs[i] = new(sql.NullFloat64)
+		default:
+			scanArgs[i] = new(sql.NullString)
 		}
+	}
+	return scanArgs
+}
 
-		data = append(data, rowData)
+// convertScanArgs converts the scanned row to the values, and masks the sensitive fields.
+func convertScanArgs(scanArgs []any, fieldList []db.SensitiveField) []any {
+	rowData := []any{}
+	for i := range scanArgs {
+		value := convertScanArg(scanArgs[i])
+		if len(fieldList) > 0 && fieldList[i].Sensitive {
+			value = maskValue(value, fieldList[i].Mask)
+		}
+		rowData = append(rowData, value)
 	}
+	return rowData
+}
 
-	return data, nil
+func convertScanArg(scanArg any) any {
+	if v, ok := scanArg.(*sql.NullBool); ok && v.Valid {
+		return v.Bool
+	}
+	if v, ok := scanArg.(*sql.NullString); ok && v.Valid {
+		return v.String
+	}
+	if v, ok := scanArg.(*sql.NullInt64); ok && v.Valid {
+		return v.Int64
+	}
+	if v, ok := scanArg.(*sql.NullInt32); ok && v.Valid {
+		return v.Int32
+	}
+	if v, ok := scanArg.(*sql.NullFloat64); ok && v.Valid {
+		return v.Float64
+	}
+	// If none of them match, set nil to its value.
+	return nil
 }
 
 func getStatementWithResultLimit(stmt string, limit int) string {
@@ -0,0 +1,580 @@
+package util
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+)
+
+// IsExplainSupported returns true if the query plan can be explained for the engine.
+func IsExplainSupported(dbType db.Type) bool {
+	switch dbType {
+	case db.MySQL, db.TiDB, db.Postgres, db.Oracle:
+		return true
+	default:
+		return false
+	}
+}
+
+// Explain returns the query plan tree of the statement.
+// If analyze is true, the statement is executed to collect the actual row count and time of each node.
+func Explain(ctx context.Context, dbType db.Type, conn *sql.Conn, statement string, analyze bool) (*db.ExplainNode, error) {
+	statement = strings.TrimRight(strings.TrimSpace(statement), ";")
+	switch dbType {
+	case db.Postgres:
+		query := "EXPLAIN (FORMAT JSON) " + statement
+		if analyze {
+			query = "EXPLAIN (ANALYZE, FORMAT JSON) " + statement
+		}
+		_, rows, err := queryExplainRows(ctx, dbType, conn, query)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) != 1 {
+			return nil, errors.Errorf("expect one row of the plan, but got %d", len(rows))
+		}
+		return parsePostgresPlan(rows[0][0])
+	case db.MySQL:
+		if analyze {
+			// EXPLAIN ANALYZE only supports the TREE format.
+			_, rows, err := queryExplainRows(ctx, dbType, conn, "EXPLAIN ANALYZE "+statement)
+			if err != nil {
+				return nil, err
+			}
+			if len(rows) != 1 {
+				return nil, errors.Errorf("expect one row of the plan, but got %d", len(rows))
+			}
+			return parseMySQLTreePlan(rows[0][0])
+		}
+		_, rows, err := queryExplainRows(ctx, dbType, conn, "EXPLAIN FORMAT=JSON "+statement)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) != 1 {
+			return nil, errors.Errorf("expect one row of the plan, but got %d", len(rows))
+		}
+		return parseMySQLJSONPlan(rows[0][0])
+	case db.TiDB:
+		query := "EXPLAIN " + statement
+		if analyze {
+			query = "EXPLAIN ANALYZE " + statement
+		}
+		columns, rows, err := queryExplainRows(ctx, dbType, conn, query)
+		if err != nil {
+			return nil, err
+		}
+		return parseTiDBPlan(columns, rows)
+	case db.Oracle:
+		if analyze {
+			return nil, errors.Errorf("analyzing query plan is not supported for %s", dbType)
+		}
+		return explainOracle(ctx, conn, statement)
+	default:
+		return nil, errors.Errorf("explaining query plan is not supported for %s", dbType)
+	}
+}
+
+// queryExplainRows runs the explain query and returns the column names and rows as text, NULL is returned as an empty string.
+func queryExplainRows(ctx context.Context, dbType db.Type, conn *sql.Conn, query string) ([]string, [][]string, error) {
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: isReadOnlyTxSupported(dbType)})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, nil, FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+	var result [][]string
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		scanArgs := make([]any, len(columns))
+		for i := range values {
+			scanArgs[i] = &values[i]
+		}
+		if err := rows.Scan(scanArgs...); err != nil {
+			return nil, nil, err
+		}
+		row := make([]string, len(columns))
+		for i, v := range values {
+			row[i] = v.String
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return columns, result, nil
+}
+
+// explainTreeItem is a node with its depth in the plan tree, for plans printed as indented text.
+type explainTreeItem struct {
+	depth int
+	node  *db.ExplainNode
+}
+
+// buildExplainTree builds the plan tree from the nodes in the pre-order.
+func buildExplainTree(items []explainTreeItem) (*db.ExplainNode, error) {
+	if len(items) == 0 {
+		return nil, errors.New("empty query plan")
+	}
+	root := &db.ExplainNode{NodeType: "Query"}
+	stack := []explainTreeItem{{depth: -1, node: root}}
+	for _, item := range items {
+		for stack[len(stack)-1].depth >= item.depth {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1].node
+		parent.Children = append(parent.Children, item.node)
+		stack = append(stack, item)
+	}
+	// Skip the placeholder root if there is only one top-level node.
+	if len(root.Children) == 1 {
+		return root.Children[0], nil
+	}
+	return root, nil
+}
+
+type postgresPlan struct {
+	NodeType        string          `json:"Node Type"`
+	RelationName    string          `json:"Relation Name"`
+	IndexName       string          `json:"Index Name"`
+	JoinType        string          `json:"Join Type"`
+	PlanRows        float64         `json:"Plan Rows"`
+	TotalCost       float64         `json:"Total Cost"`
+	ActualRows      *float64        `json:"Actual Rows"`
+	ActualTotalTime *float64        `json:"Actual Total Time"`
+	ActualLoops     *float64        `json:"Actual Loops"`
+	SortSpaceType   string          `json:"Sort Space Type"`
+	Plans           []*postgresPlan `json:"Plans"`
+}
+
+func parsePostgresPlan(data string) (*db.ExplainNode, error) {
+	var plans []struct {
+		Plan *postgresPlan `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(data), &plans); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal query plan %q", data)
+	}
+	if len(plans) != 1 || plans[0].Plan == nil {
+		return nil, errors.Errorf("invalid query plan %q", data)
+	}
+	return convertPostgresPlan(plans[0].Plan), nil
+}
+
+func convertPostgresPlan(plan *postgresPlan) *db.ExplainNode {
+	node := &db.ExplainNode{
+		NodeType:      plan.NodeType,
+		Relation:      plan.RelationName,
+		Index:         plan.IndexName,
+		EstimatedRows: plan.PlanRows,
+		EstimatedCost: plan.TotalCost,
+		FullScan:      plan.NodeType == "Seq Scan",
+		Filesort:      plan.SortSpaceType == "Disk",
+		NestedLoop:    plan.NodeType == "Nested Loop",
+	}
+	if plan.JoinType != "" {
+		node.Detail = fmt.Sprintf("%s join", plan.JoinType)
+	}
+	// The actual rows and time are the average values per loop.
+	if plan.ActualRows != nil && plan.ActualTotalTime != nil {
+		loops := 1.0
+		if plan.ActualLoops != nil {
+			loops = *plan.ActualLoops
+		}
+		actualRows := *plan.ActualRows * loops
+		actualTimeMs := *plan.ActualTotalTime * loops
+		node.ActualRows = &actualRows
+		node.ActualTimeMs = &actualTimeMs
+	}
+	for _, child := range plan.Plans {
+		node.Children = append(node.Children, convertPostgresPlan(child))
+	}
+	return node
+}
+
+var (
+	// mysqlJSONPlanKeys are the keys of the nested operations in the MySQL JSON plan, in the order of the children.
+	mysqlJSONPlanKeys = []string{
+		"ordering_operation",
+		"grouping_operation",
+		"duplicates_removal",
+		"windowing",
+		"union_result",
+		"query_specifications",
+		"nested_loop",
+		"table",
+		"materialized_from_subquery",
+		"query_block",
+		"attached_subqueries",
+		"optimized_away_subqueries",
+	}
+	// mysqlAccessTypes maps the MySQL access types to the node types.
+	mysqlAccessTypes = map[string]string{
+		"ALL":    "Table Scan",
+		"index":  "Index Scan",
+		"range":  "Index Range Scan",
+		"ref":    "Index Lookup",
+		"eq_ref": "Unique Index Lookup",
+		"const":  "Constant Lookup",
+		"system": "Constant Lookup",
+	}
+)
+
+func parseMySQLJSONPlan(data string) (*db.ExplainNode, error) {
+	var plan map[string]any
+	if err := json.Unmarshal([]byte(data), &plan); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal query plan %q", data)
+	}
+	queryBlock, ok := plan["query_block"].(map[string]any)
+	if !ok {
+		return nil, errors.Errorf("invalid query plan %q", data)
+	}
+	return convertMySQLJSONPlan("query_block", queryBlock), nil
+}
+
+func convertMySQLJSONPlan(operation string, object map[string]any) *db.ExplainNode {
+	node := &db.ExplainNode{}
+	costInfo, _ := object["cost_info"].(map[string]any)
+	switch operation {
+	case "query_block":
+		node.NodeType = "Query Block"
+		node.EstimatedCost = mysqlJSONNumber(costInfo["query_cost"])
+	case "table":
+		accessType, _ := object["access_type"].(string)
+		node.NodeType = mysqlAccessTypes[accessType]
+		if node.NodeType == "" {
+			node.NodeType = fmt.Sprintf("Table Access (%s)", accessType)
+		}
+		node.Relation, _ = object["table_name"].(string)
+		node.Index, _ = object["key"].(string)
+		node.EstimatedRows = mysqlJSONNumber(object["rows_examined_per_scan"])
+		node.EstimatedCost = mysqlJSONNumber(costInfo["prefix_cost"])
+		node.FullScan = accessType == "ALL" || accessType == "index"
+		node.Detail, _ = object["attached_condition"].(string)
+	case "ordering_operation":
+		node.NodeType = "Sort"
+		node.EstimatedCost = mysqlJSONNumber(costInfo["sort_cost"])
+	case "grouping_operation":
+		node.NodeType = "Group"
+	case "duplicates_removal":
+		node.NodeType = "Distinct"
+	case "windowing":
+		node.NodeType = "Window"
+	case "union_result":
+		node.NodeType = "Union"
+	}
+	if usingFilesort, ok := object["using_filesort"].(bool); ok && usingFilesort {
+		node.Filesort = true
+		node.Detail = "Using filesort"
+	}
+	appendMySQLJSONPlanChildren(node, object)
+	return node
+}
+
+func appendMySQLJSONPlanChildren(node *db.ExplainNode, object map[string]any) {
+	for _, key := range mysqlJSONPlanKeys {
+		value, ok := object[key]
+		if !ok {
+			continue
+		}
+		switch key {
+		case "nested_loop":
+			// The array of objects wrapping the joined tables.
+			loop := &db.ExplainNode{NodeType: "Nested Loop", NestedLoop: true}
+			for _, item := range mysqlJSONObjectList(value) {
+				appendMySQLJSONPlanChildren(loop, item)
+			}
+			node.Children = append(node.Children, loop)
+		case "query_specifications", "attached_subqueries", "optimized_away_subqueries":
+			// The array of objects wrapping the query blocks.
+			for _, item := range mysqlJSONObjectList(value) {
+				appendMySQLJSONPlanChildren(node, item)
+			}
+		case "materialized_from_subquery":
+			// The object wrapping the query block.
+			if item, ok := value.(map[string]any); ok {
+				appendMySQLJSONPlanChildren(node, item)
+			}
+		default:
+			if item, ok := value.(map[string]any); ok {
+				node.Children = append(node.Children, convertMySQLJSONPlan(key, item))
+			}
+		}
+	}
+}
+
+func mysqlJSONObjectList(value any) []map[string]any {
+	list, _ := value.([]any)
+	var result []map[string]any
+	for _, item := range list {
+		if object, ok := item.(map[string]any); ok {
+			result = append(result, object)
+		}
+	}
+	return result
+}
+
+// mysqlJSONNumber returns the number in the MySQL JSON plan, the costs are formatted as strings.
+func mysqlJSONNumber(value any) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0
+		}
+		return f
+	default:
+		return 0
+	}
+}
+
+var (
+	mysqlTreeCostRegexp   = regexp.MustCompile(`\(cost=([0-9.e+]+) rows=([0-9.e+]+)\)`)
+	mysqlTreeActualRegexp = regexp.MustCompile(`\(actual time=[0-9.e+]+\.\.([0-9.e+]+) rows=([0-9.e+]+) loops=([0-9]+)\)`)
+)
+
+// parseMySQLTreePlan parses the TREE format plan, e.g.
+//
+//	-> Sort: t.name  (cost=1.25 rows=10) (actual time=0.081..0.082 rows=10 loops=1)
+//	    -> Table scan on t  (cost=1.25 rows=10) (actual time=0.031..0.047 rows=10 loops=1)
+func parseMySQLTreePlan(data string) (*db.ExplainNode, error) {
+	var items []explainTreeItem
+	for _, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if !strings.HasPrefix(trimmed, "-> ") {
+			// The description of the previous node spans multiple lines.
+			if len(items) > 0 && trimmed != "" {
+				items[len(items)-1].node.Detail += "\n" + trimmed
+			}
+			continue
+		}
+		depth := (len(line) - len(trimmed)) / 4
+		content := strings.TrimPrefix(trimmed, "-> ")
+		description := content
+		if i := strings.Index(content, "  ("); i >= 0 {
+			description = content[:i]
+		}
+		node := &db.ExplainNode{Detail: description}
+		node.NodeType, node.Relation, node.Index = splitMySQLTreeDescription(description)
+		node.FullScan = node.NodeType == "Table scan" || node.NodeType == "Index scan"
+		node.Filesort = node.NodeType == "Sort"
+		node.NestedLoop = strings.HasPrefix(node.NodeType, "Nested loop")
+		if matches := mysqlTreeCostRegexp.FindStringSubmatch(content); matches != nil {
+			node.EstimatedCost, _ = strconv.ParseFloat(matches[1], 64)
+			node.EstimatedRows, _ = strconv.ParseFloat(matches[2], 64)
+		}
+		// The actual time is the average time per loop and the actual rows is the average rows per loop.
+		if matches := mysqlTreeActualRegexp.FindStringSubmatch(content); matches != nil {
+			timeMs, _ := strconv.ParseFloat(matches[1], 64)
+			rows, _ := strconv.ParseFloat(matches[2], 64)
+			loops, _ := strconv.ParseFloat(matches[3], 64)
+			actualRows := rows * loops
+			actualTimeMs := timeMs * loops
+			node.ActualRows = &actualRows
+			node.ActualTimeMs = &actualTimeMs
+		}
+		items = append(items, explainTreeItem{depth: depth, node: node})
+	}
+	return buildExplainTree(items)
+}
+
+// splitMySQLTreeDescription splits the node description such as "Index lookup on t using idx (a=1)" and "Filter: (t.a > 1)".
+func splitMySQLTreeDescription(description string) (string, string, string) {
+	if i := strings.Index(description, " on "); i >= 0 && !strings.Contains(description[:i], ":") {
+		nodeType := description[:i]
+		fields := strings.Fields(description[i+len(" on "):])
+		var relation, index string
+		if len(fields) > 0 {
+			relation = fields[0]
+		}
+		if len(fields) > 2 && fields[1] == "using" {
+			index = fields[2]
+		}
+		return nodeType, relation, index
+	}
+	if i := strings.Index(description, ":"); i >= 0 {
+		return description[:i], "", ""
+	}
+	return description, "", ""
+}
+
+var tidbOperatorIDRegexp = regexp.MustCompile(`_\d+$`)
+
+// parseTiDBPlan parses the rows of EXPLAIN and EXPLAIN ANALYZE in TiDB.
+// The tree structure is printed in the id column, e.g. "└─TableFullScan_5".
+func parseTiDBPlan(columns []string, rows [][]string) (*db.ExplainNode, error) {
+	columnIndex := make(map[string]int)
+	for i, column := range columns {
+		columnIndex[column] = i
+	}
+	for _, column := range []string{"id", "estRows", "access object", "operator info"} {
+		if _, ok := columnIndex[column]; !ok {
+			return nil, errors.Errorf("column %q not found in the query plan", column)
+		}
+	}
+	actRowsIndex, analyzed := columnIndex["actRows"]
+	executionInfoIndex, hasExecutionInfo := columnIndex["execution info"]
+
+	var items []explainTreeItem
+	for _, row := range rows {
+		id := row[columnIndex["id"]]
+		name := strings.TrimLeftFunc(id, func(r rune) bool {
+			return !unicode.IsLetter(r)
+		})
+		// Each level of the tree is indented by two characters.
+		depth := len([]rune(id[:len(id)-len(name)])) / 2
+		name = strings.TrimSuffix(strings.TrimSuffix(name, "(Build)"), "(Probe)")
+		nodeType := tidbOperatorIDRegexp.ReplaceAllString(name, "")
+
+		node := &db.ExplainNode{
+			NodeType:   nodeType,
+			Detail:     row[columnIndex["operator info"]],
+			FullScan:   nodeType == "TableFullScan" || nodeType == "IndexFullScan",
+			NestedLoop: nodeType == "IndexJoin" || nodeType == "IndexHashJoin" || nodeType == "IndexMergeJoin",
+		}
+		node.EstimatedRows, _ = strconv.ParseFloat(row[columnIndex["estRows"]], 64)
+		// The access object is like "table:t, index:idx(a)".
+		for _, object := range strings.Split(row[columnIndex["access object"]], ",") {
+			key, value, ok := strings.Cut(strings.TrimSpace(object), ":")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "table":
+				node.Relation = value
+			case "index":
+				node.Index, _, _ = strings.Cut(value, "(")
+			}
+		}
+		if analyzed {
+			actualRows, err := strconv.ParseFloat(row[actRowsIndex], 64)
+			if err == nil {
+				node.ActualRows = &actualRows
+			}
+		}
+		if hasExecutionInfo {
+			if actualTimeMs, ok := parseTiDBExecutionTime(row[executionInfoIndex]); ok {
+				node.ActualTimeMs = &actualTimeMs
+			}
+		}
+		items = append(items, explainTreeItem{depth: depth, node: node})
+	}
+	return buildExplainTree(items)
+}
+
+// parseTiDBExecutionTime parses the time in milliseconds from the execution info such as "time:1.23ms, loops:2".
+func parseTiDBExecutionTime(executionInfo string) (float64, bool) {
+	for _, item := range strings.Split(executionInfo, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(item), ":")
+		if !ok || key != "time" {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, false
+		}
+		return float64(duration) / float64(time.Millisecond), true
+	}
+	return 0, false
+}
+
+// oraclePlanRow is a row of the Oracle PLAN_TABLE.
+type oraclePlanRow struct {
+	id          int64
+	parentID    sql.NullInt64
+	operation   string
+	options     string
+	objectName  string
+	cardinality float64
+	cost        float64
+}
+
+func explainOracle(ctx context.Context, conn *sql.Conn, statement string) (*db.ExplainNode, error) {
+	// EXPLAIN PLAN writes the plan into PLAN_TABLE, roll back the transaction to clean it up.
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	statementID := fmt.Sprintf("bytebase_%d", time.Now().UnixNano())
+	explain := fmt.Sprintf("EXPLAIN PLAN SET STATEMENT_ID = '%s' FOR %s", statementID, statement)
+	if _, err := tx.ExecContext(ctx, explain); err != nil {
+		return nil, FormatErrorWithQuery(err, explain)
+	}
+	query := "SELECT ID, PARENT_ID, OPERATION, OPTIONS, OBJECT_NAME, CARDINALITY, COST FROM PLAN_TABLE WHERE STATEMENT_ID = :1 ORDER BY ID"
+	rows, err := tx.QueryContext(ctx, query, statementID)
+	if err != nil {
+		return nil, FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+	var planRows []oraclePlanRow
+	for rows.Next() {
+		var row oraclePlanRow
+		var options, objectName sql.NullString
+		var cardinality, cost sql.NullFloat64
+		if err := rows.Scan(&row.id, &row.parentID, &row.operation, &options, &objectName, &cardinality, &cost); err != nil {
+			return nil, err
+		}
+		row.options, row.objectName = options.String, objectName.String
+		row.cardinality, row.cost = cardinality.Float64, cost.Float64
+		planRows = append(planRows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return buildOraclePlan(planRows)
+}
+
+func buildOraclePlan(planRows []oraclePlanRow) (*db.ExplainNode, error) {
+	nodes := make(map[int64]*db.ExplainNode)
+	var root *db.ExplainNode
+	for _, row := range planRows {
+		node := &db.ExplainNode{
+			NodeType:      strings.TrimSpace(row.operation + " " + row.options),
+			EstimatedRows: row.cardinality,
+			EstimatedCost: row.cost,
+			FullScan:      row.operation == "TABLE ACCESS" && row.options == "FULL",
+			NestedLoop:    row.operation == "NESTED LOOPS",
+		}
+		if row.operation == "INDEX" {
+			node.Index = row.objectName
+		} else {
+			node.Relation = row.objectName
+		}
+		nodes[row.id] = node
+		if !row.parentID.Valid {
+			root = node
+			continue
+		}
+		// The rows are ordered by ID, so the parent always comes first.
+		parent, ok := nodes[row.parentID.Int64]
+		if !ok {
+			return nil, errors.Errorf("parent %d of plan row %d not found", row.parentID.Int64, row.id)
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	if root == nil {
+		return nil, errors.New("empty query plan")
+	}
+	return root, nil
+}
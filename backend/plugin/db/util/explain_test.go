@@ -0,0 +1,178 @@
+package util
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+)
+
+func newFloat64(f float64) *float64 {
+	return &f
+}
+
+func TestParsePostgresPlan(t *testing.T) {
+	plan := `[{"Plan": {"Node Type": "Nested Loop", "Join Type": "Inner", "Plan Rows": 100, "Total Cost": 350.5, "Actual Rows": 20, "Actual Total Time": 1.5, "Actual Loops": 1,
+		"Plans": [
+			{"Node Type": "Seq Scan", "Relation Name": "t1", "Plan Rows": 1000, "Total Cost": 15, "Actual Rows": 10, "Actual Total Time": 0.5, "Actual Loops": 1},
+			{"Node Type": "Index Scan", "Relation Name": "t2", "Index Name": "t2_pkey", "Plan Rows": 1, "Total Cost": 0.3, "Actual Rows": 2, "Actual Total Time": 0.1, "Actual Loops": 10}
+		]}}]`
+	node, err := parsePostgresPlan(plan)
+	require.NoError(t, err)
+	require.Equal(t, &db.ExplainNode{
+		NodeType:      "Nested Loop",
+		EstimatedRows: 100,
+		EstimatedCost: 350.5,
+		ActualRows:    newFloat64(20),
+		ActualTimeMs:  newFloat64(1.5),
+		NestedLoop:    true,
+		Detail:        "Inner join",
+		Children: []*db.ExplainNode{
+			{NodeType: "Seq Scan", Relation: "t1", EstimatedRows: 1000, EstimatedCost: 15, ActualRows: newFloat64(10), ActualTimeMs: newFloat64(0.5), FullScan: true},
+			{NodeType: "Index Scan", Relation: "t2", Index: "t2_pkey", EstimatedRows: 1, EstimatedCost: 0.3, ActualRows: newFloat64(20), ActualTimeMs: newFloat64(1)},
+		},
+	}, node)
+}
+
+func TestParseMySQLJSONPlan(t *testing.T) {
+	plan := `{
+  "query_block": {
+    "select_id": 1,
+    "cost_info": {"query_cost": "2.60"},
+    "ordering_operation": {
+      "using_filesort": true,
+      "nested_loop": [
+        {"table": {"table_name": "t1", "access_type": "ALL", "rows_examined_per_scan": 2000, "cost_info": {"prefix_cost": "0.45"}}},
+        {"table": {"table_name": "t2", "access_type": "eq_ref", "key": "PRIMARY", "rows_examined_per_scan": 1, "cost_info": {"prefix_cost": "2.60"}}}
+      ]
+    }
+  }
+}`
+	node, err := parseMySQLJSONPlan(plan)
+	require.NoError(t, err)
+	require.Equal(t, &db.ExplainNode{
+		NodeType:      "Query Block",
+		EstimatedCost: 2.6,
+		Children: []*db.ExplainNode{
+			{
+				NodeType: "Sort",
+				Filesort: true,
+				Detail:   "Using filesort",
+				Children: []*db.ExplainNode{
+					{
+						NodeType:   "Nested Loop",
+						NestedLoop: true,
+						Children: []*db.ExplainNode{
+							{NodeType: "Table Scan", Relation: "t1", EstimatedRows: 2000, EstimatedCost: 0.45, FullScan: true},
+							{NodeType: "Unique Index Lookup", Relation: "t2", Index: "PRIMARY", EstimatedRows: 1, EstimatedCost: 2.6},
+						},
+					},
+				},
+			},
+		},
+	}, node)
+}
+
+func TestParseMySQLTreePlan(t *testing.T) {
+	plan := "-> Sort: t1.name  (cost=2.60 rows=2) (actual time=0.100..0.120 rows=2 loops=1)\n" +
+		"    -> Nested loop inner join  (cost=2.60 rows=2) (actual time=0.050..0.080 rows=2 loops=1)\n" +
+		"        -> Table scan on t1  (cost=0.45 rows=2) (actual time=0.020..0.030 rows=2 loops=1)\n" +
+		"        -> Single-row index lookup on t2 using PRIMARY (id=t1.id)  (cost=1.05 rows=1) (actual time=0.010..0.010 rows=1 loops=2)\n"
+	node, err := parseMySQLTreePlan(plan)
+	require.NoError(t, err)
+	require.Equal(t, &db.ExplainNode{
+		NodeType:      "Sort",
+		EstimatedRows: 2,
+		EstimatedCost: 2.6,
+		ActualRows:    newFloat64(2),
+		ActualTimeMs:  newFloat64(0.12),
+		Filesort:      true,
+		Detail:        "Sort: t1.name",
+		Children: []*db.ExplainNode{
+			{
+				NodeType:      "Nested loop inner join",
+				EstimatedRows: 2,
+				EstimatedCost: 2.6,
+				ActualRows:    newFloat64(2),
+				ActualTimeMs:  newFloat64(0.08),
+				NestedLoop:    true,
+				Detail:        "Nested loop inner join",
+				Children: []*db.ExplainNode{
+					{NodeType: "Table scan", Relation: "t1", EstimatedRows: 2, EstimatedCost: 0.45, ActualRows: newFloat64(2), ActualTimeMs: newFloat64(0.03), FullScan: true, Detail: "Table scan on t1"},
+					{NodeType: "Single-row index lookup", Relation: "t2", Index: "PRIMARY", EstimatedRows: 1, EstimatedCost: 1.05, ActualRows: newFloat64(2), ActualTimeMs: newFloat64(0.02), Detail: "Single-row index lookup on t2 using PRIMARY (id=t1.id)"},
+				},
+			},
+		},
+	}, node)
+}
+
+func TestParseTiDBPlan(t *testing.T) {
+	columns := []string{"id", "estRows", "actRows", "task", "access object", "execution info", "operator info", "memory", "disk"}
+	rows := [][]string{
+		{"HashJoin_8", "12487.50", "3", "root", "", "time:1.5ms, loops:2", "inner join, equal:[eq(t1.a, t2.a)]", "1 KB", "0 Bytes"},
+		{"├─IndexReader_15(Build)", "9990.00", "3", "root", "", "time:500µs, loops:2", "index:IndexFullScan_14", "1 KB", "N/A"},
+		{"│ └─IndexFullScan_14", "9990.00", "3", "cop[tikv]", "table:t2, index:idx(a)", "tikv_task:{time:0s, loops:1}", "keep order:false", "N/A", "N/A"},
+		{"└─TableReader_12(Probe)", "9990.00", "3", "root", "", "time:1ms, loops:2", "data:TableFullScan_11", "1 KB", "N/A"},
+		{"  └─TableFullScan_11", "10000.00", "3", "cop[tikv]", "table:t1", "tikv_task:{time:0s, loops:1}", "keep order:false", "N/A", "N/A"},
+	}
+	node, err := parseTiDBPlan(columns, rows)
+	require.NoError(t, err)
+	require.Equal(t, &db.ExplainNode{
+		NodeType:      "HashJoin",
+		EstimatedRows: 12487.5,
+		ActualRows:    newFloat64(3),
+		ActualTimeMs:  newFloat64(1.5),
+		Detail:        "inner join, equal:[eq(t1.a, t2.a)]",
+		Children: []*db.ExplainNode{
+			{
+				NodeType:      "IndexReader",
+				EstimatedRows: 9990,
+				ActualRows:    newFloat64(3),
+				ActualTimeMs:  newFloat64(0.5),
+				Detail:        "index:IndexFullScan_14",
+				Children: []*db.ExplainNode{
+					{NodeType: "IndexFullScan", Relation: "t2", Index: "idx", EstimatedRows: 9990, ActualRows: newFloat64(3), FullScan: true, Detail: "keep order:false"},
+				},
+			},
+			{
+				NodeType:      "TableReader",
+				EstimatedRows: 9990,
+				ActualRows:    newFloat64(3),
+				ActualTimeMs:  newFloat64(1),
+				Detail:        "data:TableFullScan_11",
+				Children: []*db.ExplainNode{
+					{NodeType: "TableFullScan", Relation: "t1", EstimatedRows: 10000, ActualRows: newFloat64(3), FullScan: true, Detail: "keep order:false"},
+				},
+			},
+		},
+	}, node)
+}
+
+func TestBuildOraclePlan(t *testing.T) {
+	node, err := buildOraclePlan([]oraclePlanRow{
+		{id: 0, operation: "SELECT STATEMENT", cardinality: 100, cost: 10},
+		{id: 1, parentID: sql.NullInt64{Int64: 0, Valid: true}, operation: "NESTED LOOPS", cardinality: 100, cost: 10},
+		{id: 2, parentID: sql.NullInt64{Int64: 1, Valid: true}, operation: "TABLE ACCESS", options: "FULL", objectName: "T1", cardinality: 100, cost: 3},
+		{id: 3, parentID: sql.NullInt64{Int64: 1, Valid: true}, operation: "INDEX", options: "UNIQUE SCAN", objectName: "T2_PK", cardinality: 1, cost: 0},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &db.ExplainNode{
+		NodeType:      "SELECT STATEMENT",
+		EstimatedRows: 100,
+		EstimatedCost: 10,
+		Children: []*db.ExplainNode{
+			{
+				NodeType:      "NESTED LOOPS",
+				EstimatedRows: 100,
+				EstimatedCost: 10,
+				NestedLoop:    true,
+				Children: []*db.ExplainNode{
+					{NodeType: "TABLE ACCESS FULL", Relation: "T1", EstimatedRows: 100, EstimatedCost: 3, FullScan: true},
+					{NodeType: "INDEX UNIQUE SCAN", Index: "T2_PK", EstimatedRows: 1},
+				},
+			},
+		},
+	}, node)
+}
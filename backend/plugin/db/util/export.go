@@ -0,0 +1,87 @@
+package util
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+)
+
+// RowWriter writes the rows of a query result in a specific format.
+type RowWriter interface {
+	// WriteHeader writes the header before any row.
+	WriteHeader(columnNames []string, columnTypeNames []string) error
+	// WriteRow writes a row, the values are the same as the ones returned by Query.
+	WriteRow(row []any) error
+	// Close writes the trailer after all rows.
+	Close() error
+}
+
+// Export executes a readonly / SELECT query and writes the rows to the row writer one by one,
+// so that the whole result set is never loaded into the memory.
+// The sensitive fields are masked the same as Query. The result is not limited unless queryContext.Limit is positive.
+// It returns the number of rows written.
+func Export(ctx context.Context, dbType db.Type, conn *sql.Conn, statement string, queryContext *db.QueryContext, w RowWriter) (int64, error) {
+	if dbType == db.ClickHouse {
+		return 0, errors.Errorf("exporting query result is not supported for %s", dbType)
+	}
+	if queryContext.Limit > 0 {
+		statement = getStatementWithLimit(dbType, statement, queryContext.Limit)
+	}
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: isReadOnlyTxSupported(dbType)})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, statement)
+	if err != nil {
+		return 0, FormatErrorWithQuery(err, statement)
+	}
+	defer rows.Close()
+
+	columnNames, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	fieldList, err := extractSensitiveField(dbType, statement, queryContext.CurrentDatabase, queryContext.SensitiveSchemaInfo)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to extract sensitive fields: %q", statement)
+	}
+	if len(fieldList) != 0 && len(fieldList) != len(columnNames) {
+		return 0, errors.Errorf("failed to extract sensitive fields: %q", statement)
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+	var columnTypeNames []string
+	for _, v := range columnTypes {
+		columnTypeNames = append(columnTypeNames, strings.ToUpper(v.DatabaseTypeName()))
+	}
+
+	if err := w.WriteHeader(columnNames, columnTypeNames); err != nil {
+		return 0, err
+	}
+	var count int64
+	for rows.Next() {
+		scanArgs := newScanArgs(columnTypeNames)
+		if err := rows.Scan(scanArgs...); err != nil {
+			return count, err
+		}
+		if err := w.WriteRow(convertScanArgs(scanArgs, fieldList)); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, err
+	}
+	return count, w.Close()
+}
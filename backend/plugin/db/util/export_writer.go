@@ -0,0 +1,296 @@
+package util
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+)
+
+var (
+	_ RowWriter = (*csvRowWriter)(nil)
+	_ RowWriter = (*jsonRowWriter)(nil)
+	_ RowWriter = (*sqlRowWriter)(nil)
+	_ RowWriter = (*xlsxRowWriter)(nil)
+)
+
+// formatExportValue formats the value as text, nil is formatted as an empty string.
+func formatExportValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// NewCSVRowWriter creates a row writer writing CSV with a header line of the column names.
+func NewCSVRowWriter(w io.Writer) RowWriter {
+	return &csvRowWriter{w: csv.NewWriter(w)}
+}
+
+type csvRowWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvRowWriter) WriteHeader(columnNames []string, _ []string) error {
+	return c.w.Write(columnNames)
+}
+
+func (c *csvRowWriter) WriteRow(row []any) error {
+	record := make([]string, 0, len(row))
+	for _, v := range row {
+		record = append(record, formatExportValue(v))
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvRowWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// NewJSONRowWriter creates a row writer writing JSON Lines, one JSON object per row keyed by the column names.
+func NewJSONRowWriter(w io.Writer) RowWriter {
+	return &jsonRowWriter{w: bufio.NewWriter(w)}
+}
+
+type jsonRowWriter struct {
+	w *bufio.Writer
+	// keys are the JSON encoded column names.
+	keys []string
+}
+
+func (j *jsonRowWriter) WriteHeader(columnNames []string, _ []string) error {
+	for _, name := range columnNames {
+		key, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		j.keys = append(j.keys, string(key))
+	}
+	return nil
+}
+
+func (j *jsonRowWriter) WriteRow(row []any) error {
+	// Write the object manually to keep the column order.
+	if err := j.w.WriteByte('{'); err != nil {
+		return err
+	}
+	for i, v := range row {
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if err := j.w.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		if _, err := j.w.WriteString(j.keys[i]); err != nil {
+			return err
+		}
+		if err := j.w.WriteByte(':'); err != nil {
+			return err
+		}
+		if _, err := j.w.Write(value); err != nil {
+			return err
+		}
+	}
+	if _, err := j.w.WriteString("}\n"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (j *jsonRowWriter) Close() error {
+	return j.w.Flush()
+}
+
+// NewSQLRowWriter creates a row writer writing an INSERT statement for each row into the table.
+func NewSQLRowWriter(w io.Writer, dbType db.Type, tableName string) RowWriter {
+	return &sqlRowWriter{w: bufio.NewWriter(w), dbType: dbType, tableName: tableName}
+}
+
+type sqlRowWriter struct {
+	w         *bufio.Writer
+	dbType    db.Type
+	tableName string
+	// prefix is the INSERT INTO clause with the column names.
+	prefix string
+}
+
+func (s *sqlRowWriter) WriteHeader(columnNames []string, _ []string) error {
+	var columns []string
+	for _, name := range columnNames {
+		columns = append(columns, s.quoteIdentifier(name))
+	}
+	s.prefix = fmt.Sprintf("INSERT INTO %s (%s) VALUES (", s.quoteIdentifier(s.tableName), strings.Join(columns, ", "))
+	return nil
+}
+
+func (s *sqlRowWriter) WriteRow(row []any) error {
+	var values []string
+	for _, v := range row {
+		values = append(values, s.quoteValue(v))
+	}
+	if _, err := s.w.WriteString(s.prefix); err != nil {
+		return err
+	}
+	if _, err := s.w.WriteString(strings.Join(values, ", ")); err != nil {
+		return err
+	}
+	if _, err := s.w.WriteString(");\n"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *sqlRowWriter) Close() error {
+	return s.w.Flush()
+}
+
+func (s *sqlRowWriter) quoteIdentifier(name string) string {
+	switch s.dbType {
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+		return fmt.Sprintf("`%s`", strings.ReplaceAll(name, "`", "``"))
+	default:
+		return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+	}
+}
+
+func (s *sqlRowWriter) quoteValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case bool, int64, int32, float64:
+		return formatExportValue(v)
+	}
+	text := formatExportValue(v)
+	switch s.dbType {
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+		// MySQL treats the backslash as the escape character in string literals by default.
+		text = strings.ReplaceAll(text, `\`, `\\`)
+	}
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(text, "'", "''"))
+}
+
+// NewXLSXRowWriter creates a row writer writing an Excel workbook with a single sheet.
+// The sheet is streamed into the zip archive with inline strings, so it doesn't buffer the rows.
+func NewXLSXRowWriter(w io.Writer) RowWriter {
+	return &xlsxRowWriter{z: zip.NewWriter(w)}
+}
+
+type xlsxRowWriter struct {
+	z     *zip.Writer
+	sheet *bufio.Writer
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetTrailer = `</sheetData></worksheet>`
+)
+
+func (x *xlsxRowWriter) WriteHeader(columnNames []string, _ []string) error {
+	for _, file := range []struct {
+		name    string
+		content string
+	}{
+		{name: "[Content_Types].xml", content: xlsxContentTypes},
+		{name: "_rels/.rels", content: xlsxRootRels},
+		{name: "xl/workbook.xml", content: xlsxWorkbook},
+		{name: "xl/_rels/workbook.xml.rels", content: xlsxWorkbookRels},
+	} {
+		f, err := x.z.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, file.content); err != nil {
+			return err
+		}
+	}
+	// The sheet must be the last file as the zip writer only writes one file at a time.
+	f, err := x.z.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	x.sheet = bufio.NewWriter(f)
+	if _, err := x.sheet.WriteString(xlsxSheetHeader); err != nil {
+		return err
+	}
+	header := make([]any, 0, len(columnNames))
+	for _, name := range columnNames {
+		header = append(header, name)
+	}
+	return x.WriteRow(header)
+}
+
+func (x *xlsxRowWriter) WriteRow(row []any) error {
+	if _, err := x.sheet.WriteString("<row>"); err != nil {
+		return err
+	}
+	for _, v := range row {
+		var cell string
+		switch v := v.(type) {
+		case nil:
+			cell = "<c/>"
+		case bool:
+			value := "0"
+			if v {
+				value = "1"
+			}
+			cell = fmt.Sprintf(`<c t="b"><v>%s</v></c>`, value)
+		case int64, int32, float64:
+			cell = fmt.Sprintf(`<c><v>%s</v></c>`, formatExportValue(v))
+		default:
+			var buf strings.Builder
+			if err := xml.EscapeText(&buf, []byte(formatExportValue(v))); err != nil {
+				return err
+			}
+			cell = fmt.Sprintf(`<c t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, buf.String())
+		}
+		if _, err := x.sheet.WriteString(cell); err != nil {
+			return err
+		}
+	}
+	if _, err := x.sheet.WriteString("</row>"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (x *xlsxRowWriter) Close() error {
+	if _, err := x.sheet.WriteString(xlsxSheetTrailer); err != nil {
+		return err
+	}
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.z.Close()
+}
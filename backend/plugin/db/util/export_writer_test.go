@@ -0,0 +1,93 @@
+package util
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+)
+
+func writeExportRows(t *testing.T, w RowWriter) {
+	require.NoError(t, w.WriteHeader([]string{"id", "name", "active", "score"}, []string{"INT", "VARCHAR", "BOOL", "FLOAT"}))
+	require.NoError(t, w.WriteRow([]any{int64(1), "it's \"a\", b", true, 1.5}))
+	require.NoError(t, w.WriteRow([]any{int64(2), nil, false, nil}))
+	require.NoError(t, w.WriteRow([]any{int64(3), "******", nil, float64(0)}))
+	require.NoError(t, w.Close())
+}
+
+func TestExportRowWriter(t *testing.T) {
+	tests := []struct {
+		name      string
+		newWriter func(w io.Writer) RowWriter
+		want      string
+	}{
+		{
+			name:      "csv",
+			newWriter: NewCSVRowWriter,
+			want: "id,name,active,score\n" +
+				"1,\"it's \"\"a\"\", b\",true,1.5\n" +
+				"2,,false,\n" +
+				"3,******,,0\n",
+		},
+		{
+			name:      "json",
+			newWriter: NewJSONRowWriter,
+			want: `{"id":1,"name":"it's \"a\", b","active":true,"score":1.5}` + "\n" +
+				`{"id":2,"name":null,"active":false,"score":null}` + "\n" +
+				`{"id":3,"name":"******","active":null,"score":0}` + "\n",
+		},
+		{
+			name: "mysql insert",
+			newWriter: func(w io.Writer) RowWriter {
+				return NewSQLRowWriter(w, db.MySQL, "t")
+			},
+			want: "INSERT INTO `t` (`id`, `name`, `active`, `score`) VALUES (1, 'it''s \"a\", b', true, 1.5);\n" +
+				"INSERT INTO `t` (`id`, `name`, `active`, `score`) VALUES (2, NULL, false, NULL);\n" +
+				"INSERT INTO `t` (`id`, `name`, `active`, `score`) VALUES (3, '******', NULL, 0);\n",
+		},
+		{
+			name: "postgres insert",
+			newWriter: func(w io.Writer) RowWriter {
+				return NewSQLRowWriter(w, db.Postgres, "t")
+			},
+			want: `INSERT INTO "t" ("id", "name", "active", "score") VALUES (1, 'it''s "a", b', true, 1.5);` + "\n" +
+				`INSERT INTO "t" ("id", "name", "active", "score") VALUES (2, NULL, false, NULL);` + "\n" +
+				`INSERT INTO "t" ("id", "name", "active", "score") VALUES (3, '******', NULL, 0);` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		writeExportRows(t, test.newWriter(&buf))
+		require.Equal(t, test.want, buf.String(), test.name)
+	}
+}
+
+func TestXLSXRowWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writeExportRows(t, NewXLSXRowWriter(&buf))
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range r.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[f.Name] = string(content)
+	}
+	require.Contains(t, files, "[Content_Types].xml")
+	require.Contains(t, files, "xl/workbook.xml")
+	sheet := files["xl/worksheets/sheet1.xml"]
+	require.Contains(t, sheet, `<row><c t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`)
+	require.Contains(t, sheet, `<row><c><v>1</v></c><c t="inlineStr"><is><t xml:space="preserve">it&#39;s &#34;a&#34;, b</t></is></c><c t="b"><v>1</v></c><c><v>1.5</v></c></row>`)
+	require.Contains(t, sheet, `<row><c><v>2</v></c><c/><c t="b"><v>0</v></c><c/></row>`)
+	require.True(t, strings.HasSuffix(sheet, xlsxSheetTrailer))
+}
@@ -101,6 +101,7 @@ func (extractor *sensitiveFieldExtractor) extractPostgreSQLSensitiveField(statem
 		result = append(result, db.SensitiveField{
 			Name:      field.name,
 			Sensitive: field.sensitive,
+			Mask:      field.mask,
 		})
 	}
 	return result, nil
@@ -150,8 +151,8 @@ func pgMergeJoinField(node *pgquery.Node_JoinExpr, leftField []fieldInfo, rightF
 		// Natural Join will merge the same column name field.
 		for _, field := range leftField {
 			// Merge the sensitive attribute for the same column name field.
-			if rField, exists := rightFieldMap[field.name]; exists && rField.sensitive {
-				field.sensitive = true
+			if rField, exists := rightFieldMap[field.name]; exists {
+				field = mergeFieldMask(field, rField)
 			}
 			result = append(result, field)
 		}
@@ -181,8 +182,8 @@ func pgMergeJoinField(node *pgquery.Node_JoinExpr, leftField []fieldInfo, rightF
 				_, existsInUsingMap := usingMap[field.name]
 				rField, existsInRightField := rightFieldMap[field.name]
 				// Merge the sensitive attribute for the column name field in USING.
-				if existsInUsingMap && existsInRightField && rField.sensitive {
-					field.sensitive = true
+				if existsInUsingMap && existsInRightField {
+					field = mergeFieldMask(field, rField)
 				}
 				result = append(result, field)
 			}
@@ -227,6 +228,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractRangeSubselect(node *pgquery.
 				table:     fmt.Sprintf("public.%s", aliasName),
 				name:      columnName,
 				sensitive: item.sensitive,
+				mask:      item.mask,
 			})
 		}
 		return result, nil
@@ -272,6 +274,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractRangeVar(node *pgquery.Node_R
 				name:      column.Name,
 				table:     tableSchema.Name,
 				sensitive: column.Sensitive,
+				mask:      column.Mask,
 			})
 		}
 	} else {
@@ -293,6 +296,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractRangeVar(node *pgquery.Node_R
 				name:      columnName,
 				table:     tableName,
 				sensitive: column.Sensitive,
+				mask:      column.Mask,
 			})
 		}
 	}
@@ -357,6 +361,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractRecursiveCTE(node *pgquery.No
 			cteInfo.ColumnList = append(cteInfo.ColumnList, db.ColumnInfo{
 				Name:      field.name,
 				Sensitive: field.sensitive,
+				Mask:      field.mask,
 			})
 		}
 
@@ -385,9 +390,8 @@ func (extractor *sensitiveFieldExtractor) pgExtractRecursiveCTE(node *pgquery.No
 
 			changed := false
 			for i, field := range fieldList {
-				if field.sensitive && !cteInfo.ColumnList[i].Sensitive {
+				if mergeColumnMask(&cteInfo.ColumnList[i], field) {
 					changed = true
-					cteInfo.ColumnList[i].Sensitive = true
 				}
 			}
 
@@ -432,6 +436,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractNonRecursiveCTE(node *pgquery
 		result.ColumnList = append(result.ColumnList, db.ColumnInfo{
 			Name:      field.name,
 			Sensitive: field.sensitive,
+			Mask:      field.mask,
 		})
 	}
 
@@ -506,10 +511,12 @@ func (extractor *sensitiveFieldExtractor) pgExtractSelect(node *pgquery.Node_Sel
 		}
 		var result []fieldInfo
 		for i, field := range leftField {
+			merged := mergeFieldMask(field, rightField[i])
 			result = append(result, fieldInfo{
 				name:      field.name,
 				table:     field.table,
-				sensitive: field.sensitive || rightField[i].sensitive,
+				sensitive: merged.sensitive,
+				mask:      merged.mask,
 			})
 		}
 		return result, nil
@@ -571,6 +578,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractSelect(node *pgquery.Node_Sel
 				result = append(result, fieldInfo{
 					name:      columnName,
 					sensitive: sensitive,
+					mask:      extractor.pgFindField(pgNormalizeColumnName(columnRef)).mask,
 				})
 			}
 		default:
@@ -750,6 +758,11 @@ func pgNormalizeColumnName(columnName *ast.ColumnNameDef) (string, string) {
 }
 
 func (extractor *sensitiveFieldExtractor) pgCheckFieldSensitive(tableName string, fieldName string) bool {
+	return extractor.pgFindField(tableName, fieldName).sensitive
+}
+
+// pgFindField finds the field referred by the column name, and returns the zero value if not found.
+func (extractor *sensitiveFieldExtractor) pgFindField(tableName string, fieldName string) fieldInfo {
 	// One sub-query may have multi-outer schemas and the multi-outer schemas can use the same name, such as:
 	//
 	//  select (
@@ -768,7 +781,7 @@ func (extractor *sensitiveFieldExtractor) pgCheckFieldSensitive(tableName string
 		sameTable := (tableName == field.table || tableName == "")
 		sameField := (fieldName == field.name)
 		if sameTable && sameField {
-			return field.sensitive
+			return field
 		}
 	}
 
@@ -776,11 +789,11 @@ func (extractor *sensitiveFieldExtractor) pgCheckFieldSensitive(tableName string
 		sameTable := (tableName == field.table || tableName == "")
 		sameField := (fieldName == field.name)
 		if sameTable && sameField {
-			return field.sensitive
+			return field
 		}
 	}
 
-	return false
+	return fieldInfo{}
 }
 
 func (extractor *sensitiveFieldExtractor) pgExtractColumnRefFromExpressionNode(in *pgquery.Node) (bool, error) {
@@ -941,6 +954,7 @@ func (extractor *sensitiveFieldExtractor) extractMySQLSensitiveField(statement s
 		result = append(result, db.SensitiveField{
 			Name:      field.name,
 			Sensitive: field.sensitive,
+			Mask:      field.mask,
 		})
 	}
 	return result, nil
@@ -951,6 +965,33 @@ type fieldInfo struct {
 	table     string
 	database  string
 	sensitive bool
+	mask      db.SensitiveDataMask
+}
+
+// mergeFieldMask merges the sensitive attribute of the other field into the field whose values are merged together,
+// such as the columns in UNION and JOIN ... USING (...).
+// The merged field is masked with the default method if two fields are masked with different algorithms.
+func mergeFieldMask(field fieldInfo, other fieldInfo) fieldInfo {
+	switch {
+	case !other.sensitive:
+	case !field.sensitive:
+		field.sensitive = true
+		field.mask = other.mask
+	case field.mask != other.mask:
+		field.mask = db.SensitiveDataMask{}
+	}
+	return field
+}
+
+// mergeColumnMask merges the sensitive attribute of the field into the recursive CTE column, and returns true if the column is changed.
+func mergeColumnMask(column *db.ColumnInfo, field fieldInfo) bool {
+	merged := mergeFieldMask(fieldInfo{sensitive: column.Sensitive, mask: column.Mask}, field)
+	if merged.sensitive == column.Sensitive && merged.mask == column.Mask {
+		return false
+	}
+	column.Sensitive = merged.sensitive
+	column.Mask = merged.mask
+	return true
 }
 
 func (extractor *sensitiveFieldExtractor) extractNode(in tidbast.Node) ([]fieldInfo, error) {
@@ -1002,9 +1043,7 @@ func (extractor *sensitiveFieldExtractor) extractSetOpr(node *tidbast.SetOprStmt
 				return nil, errors.Errorf("The used SELECT statements have a different number of columns")
 			}
 			for index := 0; index < len(result); index++ {
-				if fieldList[index].sensitive {
-					result[index].sensitive = true
-				}
+				result[index] = mergeFieldMask(result[index], fieldList[index])
 			}
 		}
 	}
@@ -1082,6 +1121,7 @@ func (extractor *sensitiveFieldExtractor) extractRecursiveCTE(node *tidbast.Comm
 			cteInfo.ColumnList = append(cteInfo.ColumnList, db.ColumnInfo{
 				Name:      field.name,
 				Sensitive: field.sensitive,
+				Mask:      field.mask,
 			})
 		}
 
@@ -1115,9 +1155,8 @@ func (extractor *sensitiveFieldExtractor) extractRecursiveCTE(node *tidbast.Comm
 
 			changed := false
 			for i, field := range fieldList {
-				if field.sensitive && !cteInfo.ColumnList[i].Sensitive {
+				if mergeColumnMask(&cteInfo.ColumnList[i], field) {
 					changed = true
-					cteInfo.ColumnList[i].Sensitive = true
 				}
 			}
 
@@ -1154,6 +1193,7 @@ func (extractor *sensitiveFieldExtractor) extractNonRecursiveCTE(node *tidbast.C
 		result.ColumnList = append(result.ColumnList, db.ColumnInfo{
 			Name:      field.name,
 			Sensitive: field.sensitive,
+			Mask:      field.mask,
 		})
 	}
 	return result, nil
@@ -1215,12 +1255,19 @@ func (extractor *sensitiveFieldExtractor) extractSelect(node *tidbast.SelectStmt
 				if err != nil {
 					return nil, err
 				}
+				// Only the field referring to a column directly keeps the mask algorithm of the column,
+				// other expressions are masked with the default method.
+				var mask db.SensitiveDataMask
+				if columnName, ok := field.Expr.(*tidbast.ColumnNameExpr); ok {
+					mask = extractor.findField(columnName.Name.Schema.O, columnName.Name.Table.O, columnName.Name.Name.O).mask
+				}
 				fieldName := extractFieldName(field)
 				result = append(result, fieldInfo{
 					database:  "",
 					table:     "",
 					name:      fieldName,
 					sensitive: sensitive,
+					mask:      mask,
 				})
 			}
 		}
@@ -1244,6 +1291,11 @@ func extractFieldName(in *tidbast.SelectField) string {
 }
 
 func (extractor *sensitiveFieldExtractor) checkFieldSensitive(databaseName string, tableName string, fieldName string) bool {
+	return extractor.findField(databaseName, tableName, fieldName).sensitive
+}
+
+// findField finds the field referred by the column name, and returns the zero value if not found.
+func (extractor *sensitiveFieldExtractor) findField(databaseName string, tableName string, fieldName string) fieldInfo {
 	// One sub-query may have multi-outer schemas and the multi-outer schemas can use the same name, such as:
 	//
 	//  select (
@@ -1263,7 +1315,7 @@ func (extractor *sensitiveFieldExtractor) checkFieldSensitive(databaseName strin
 		sameTable := (tableName == field.table || tableName == "")
 		sameField := (fieldName == field.name)
 		if sameDatabase && sameTable && sameField {
-			return field.sensitive
+			return field
 		}
 	}
 
@@ -1272,11 +1324,11 @@ func (extractor *sensitiveFieldExtractor) checkFieldSensitive(databaseName strin
 		sameTable := (tableName == field.table || tableName == "")
 		sameField := (fieldName == field.name)
 		if sameDatabase && sameTable && sameField {
-			return field.sensitive
+			return field
 		}
 	}
 
-	return false
+	return fieldInfo{}
 }
 
 func (extractor *sensitiveFieldExtractor) extractColumnFromExprNode(in tidbast.ExprNode) (sensitive bool, err error) {
@@ -1398,6 +1450,7 @@ func (extractor *sensitiveFieldExtractor) extractTableSource(node *tidbast.Table
 				table:     node.AsName.O,
 				database:  field.database,
 				sensitive: field.sensitive,
+				mask:      field.mask,
 			})
 		}
 	} else {
@@ -1452,6 +1505,7 @@ func (extractor *sensitiveFieldExtractor) extractTableName(node *tidbast.TableNa
 			table:     tableSchema.Name,
 			database:  databaseName,
 			sensitive: column.Sensitive,
+			mask:      column.Mask,
 		})
 	}
 	return res, nil
@@ -1489,8 +1543,8 @@ func mergeJoinField(node *tidbast.Join, leftField []fieldInfo, rightField []fiel
 		// Natural Join will merge the same column name field.
 		for _, field := range leftField {
 			// Merge the sensitive attribute for the same column name field.
-			if rField, exists := rightFieldMap[strings.ToLower(field.name)]; exists && rField.sensitive {
-				field.sensitive = true
+			if rField, exists := rightFieldMap[strings.ToLower(field.name)]; exists {
+				field = mergeFieldMask(field, rField)
 			}
 			result = append(result, field)
 		}
@@ -1513,8 +1567,8 @@ func mergeJoinField(node *tidbast.Join, leftField []fieldInfo, rightField []fiel
 				_, existsInUsingMap := usingMap[strings.ToLower(field.name)]
 				rField, existsInRightField := rightFieldMap[strings.ToLower(field.name)]
 				// Merge the sensitive attribute for the column name field in USING.
-				if existsInUsingMap && existsInRightField && rField.sensitive {
-					field.sensitive = true
+				if existsInUsingMap && existsInRightField {
+					field = mergeFieldMask(field, rField)
 				}
 				result = append(result, field)
 			}
@@ -0,0 +1,157 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+)
+
+const (
+	defaultMaskValue = "******"
+	// defaultMaskKeepLast is the number of trailing characters kept by the PARTIAL mask type by default.
+	defaultMaskKeepLast = 4
+	// defaultMaskRangeWidth is the width of the number ranges for the RANGE mask type by default.
+	defaultMaskRangeWidth = 10
+	// defaultMaskDateUnit is the unit of the date ranges for the RANGE mask type by default.
+	defaultMaskDateUnit = "MONTH"
+)
+
+// maskDateLayoutList is the layouts to parse the date values returned by the drivers.
+var maskDateLayoutList = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// maskValue masks the value of a sensitive field with the mask algorithm.
+// The NULL value is also masked, so that the masked field doesn't reveal whether the value is NULL.
+func maskValue(value any, mask db.SensitiveDataMask) any {
+	if value == nil {
+		return defaultMaskValue
+	}
+	s := fmt.Sprint(value)
+	switch mask.Type {
+	case db.SensitiveDataMaskTypePartial:
+		keepLast := mask.KeepLast
+		if keepLast <= 0 {
+			keepLast = defaultMaskKeepLast
+		}
+		return maskPartial(s, keepLast)
+	case db.SensitiveDataMaskTypeHash:
+		return maskHash(s, mask.Salt)
+	case db.SensitiveDataMaskTypeRange:
+		return maskRange(s, mask.RangeWidth, mask.DateUnit)
+	case db.SensitiveDataMaskTypeEmail:
+		return maskEmail(s)
+	case db.SensitiveDataMaskTypeFormatPreserving:
+		return maskFormatPreserving(s, mask.Salt)
+	default:
+		return defaultMaskValue
+	}
+}
+
+// maskPartial keeps the last keepLast characters and masks the others, e.g. "************1234" for a card number.
+// The value is fully masked if it's not longer than keepLast.
+func maskPartial(s string, keepLast int) string {
+	runes := []rune(s)
+	if len(runes) <= keepLast {
+		return defaultMaskValue
+	}
+	return strings.Repeat("*", len(runes)-keepLast) + string(runes[len(runes)-keepLast:])
+}
+
+// maskHash replaces the value with the hex-encoded HMAC-SHA256 keyed by the salt,
+// so that the same values are still equal after masking.
+func maskHash(s string, salt string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	_, _ = mac.Write([]byte(s))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// maskRange replaces the number with the range [lower, upper) of the width, and the date with the YEAR, MONTH or DAY it belongs to.
+// The value is fully masked if it's neither a number nor a date.
+func maskRange(s string, width float64, dateUnit string) string {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		if width <= 0 {
+			width = defaultMaskRangeWidth
+		}
+		lower := math.Floor(f/width) * width
+		return fmt.Sprintf("[%s, %s)", strconv.FormatFloat(lower, 'f', -1, 64), strconv.FormatFloat(lower+width, 'f', -1, 64))
+	}
+	for _, layout := range maskDateLayoutList {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+		if dateUnit == "" {
+			dateUnit = defaultMaskDateUnit
+		}
+		switch dateUnit {
+		case "YEAR":
+			return t.Format("2006")
+		case "DAY":
+			return t.Format("2006-01-02")
+		default:
+			return t.Format("2006-01")
+		}
+	}
+	return defaultMaskValue
+}
+
+// maskEmail masks the local part and keeps the domain of the email, e.g. "******@example.com".
+// The value is fully masked if it's not an email.
+func maskEmail(s string) string {
+	at := strings.LastIndex(s, "@")
+	if at <= 0 || at == len(s)-1 {
+		return defaultMaskValue
+	}
+	return defaultMaskValue + s[at:]
+}
+
+// maskFormatPreserving substitutes each digit with a digit and each letter with a letter of the same case,
+// and keeps the other characters. The substitution is derived from the salted hash of the value,
+// so that the same values are still equal after masking.
+func maskFormatPreserving(s string, salt string) string {
+	var stream []byte
+	var block uint64
+	next := func() byte {
+		if len(stream) == 0 {
+			mac := hmac.New(sha256.New, []byte(salt))
+			_, _ = mac.Write([]byte(s))
+			// The counter makes each block of the stream different.
+			var counter [8]byte
+			binary.BigEndian.PutUint64(counter[:], block)
+			_, _ = mac.Write(counter[:])
+			stream = mac.Sum(nil)
+			block++
+		}
+		b := stream[0]
+		stream = stream[1:]
+		return b
+	}
+
+	var buf strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			buf.WriteByte('0' + next()%10)
+		case r >= 'a' && r <= 'z':
+			buf.WriteByte('a' + next()%26)
+		case r >= 'A' && r <= 'Z':
+			buf.WriteByte('A' + next()%26)
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
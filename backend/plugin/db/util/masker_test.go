@@ -0,0 +1,134 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+)
+
+func TestMaskValue(t *testing.T) {
+	tests := []struct {
+		value any
+		mask  db.SensitiveDataMask
+		want  any
+	}{
+		{value: "4111111111111234", mask: db.SensitiveDataMask{}, want: "******"},
+		{value: nil, mask: db.SensitiveDataMask{Type: db.SensitiveDataMaskTypePartial}, want: "******"},
+		{value: "4111111111111234", mask: db.SensitiveDataMask{Type: db.SensitiveDataMaskTypePartial}, want: "************1234"},
+		{value: int64(13800001234), mask: db.SensitiveDataMask{Type: db.SensitiveDataMaskTypePartial, KeepLast: 2}, want: "*********34"},
+		{value: "1234", mask: db.SensitiveDataMask{Type: db.SensitiveDataMaskTypePartial}, want: "******"},
+		{value: "secret", mask: db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeHash, Salt: "salt"}, want: maskHash("secret", "salt")},
+		{value: int64(123), mask: db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeRange}, want: "[120, 130)"},
+		{value: -1.5, mask: db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeRange, RangeWidth: 1000}, want: "[-1000, 0)"},
+		{value: "2023-04-15", mask: db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeRange}, want: "2023-04"},
+		{value: "2023-04-15 10:20:30", mask: db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeRange, DateUnit: "YEAR"}, want: "2023"},
+		{value: "2023-04-15T10:20:30Z", mask: db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeRange, DateUnit: "DAY"}, want: "2023-04-15"},
+		{value: "abc", mask: db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeRange}, want: "******"},
+		{value: "alice@example.com", mask: db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeEmail}, want: "******@example.com"},
+		{value: "alice", mask: db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeEmail}, want: "******"},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, maskValue(test.value, test.mask), "%v %v", test.value, test.mask)
+	}
+}
+
+func TestMaskHash(t *testing.T) {
+	require.Equal(t, maskHash("secret", "salt"), maskHash("secret", "salt"))
+	require.NotEqual(t, maskHash("secret", "salt"), maskHash("secret", "pepper"))
+	require.Len(t, maskHash("secret", "salt"), 64)
+}
+
+func TestMaskFormatPreserving(t *testing.T) {
+	value := "Ab-1234 5678 9012 3456 7890 1234 5678 9012 3456"
+	masked := maskFormatPreserving(value, "salt")
+	require.Equal(t, masked, maskFormatPreserving(value, "salt"))
+	require.NotEqual(t, masked, maskFormatPreserving(value, "pepper"))
+	require.Equal(t, len(value), len(masked))
+	require.NotEqual(t, value, masked)
+	require.Regexp(t, `^[A-Z][a-z]-\d{4}( \d{4}){8}$`, masked)
+}
+
+func TestExtractSensitiveFieldMask(t *testing.T) {
+	partial := db.SensitiveDataMask{Type: db.SensitiveDataMaskTypePartial}
+	email := db.SensitiveDataMask{Type: db.SensitiveDataMaskTypeEmail}
+	schemaInfo := &db.SensitiveSchemaInfo{
+		DatabaseList: []db.DatabaseSchema{
+			{
+				Name: "db",
+				TableList: []db.TableSchema{
+					{
+						Name: "t",
+						ColumnList: []db.ColumnInfo{
+							{Name: "card", Sensitive: true, Mask: partial},
+							{Name: "email", Sensitive: true, Mask: email},
+							{Name: "name"},
+						},
+					},
+					{
+						Name: "public.t",
+						ColumnList: []db.ColumnInfo{
+							{Name: "card", Sensitive: true, Mask: partial},
+							{Name: "email", Sensitive: true, Mask: email},
+							{Name: "name"},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		dbType    db.Type
+		statement string
+		fieldList []db.SensitiveField
+	}{
+		{
+			dbType:    db.MySQL,
+			statement: "SELECT x.card AS c, email, concat(card, name) FROM (SELECT * FROM t) x",
+			fieldList: []db.SensitiveField{
+				{Name: "c", Sensitive: true, Mask: partial},
+				{Name: "email", Sensitive: true, Mask: email},
+				{Name: "concat(card, name)", Sensitive: true},
+			},
+		},
+		{
+			dbType:    db.MySQL,
+			statement: "SELECT card, name FROM t UNION SELECT email, card FROM t",
+			fieldList: []db.SensitiveField{
+				{Name: "card", Sensitive: true},
+				{Name: "name", Sensitive: true, Mask: partial},
+			},
+		},
+		{
+			dbType:    db.MySQL,
+			statement: "WITH RECURSIVE c(v) AS (SELECT name FROM t UNION ALL SELECT card FROM t) SELECT v FROM c",
+			fieldList: []db.SensitiveField{
+				{Name: "v", Sensitive: true, Mask: partial},
+			},
+		},
+		{
+			dbType:    db.Postgres,
+			statement: "SELECT x.card, email AS e, upper(email) FROM (SELECT * FROM t) x",
+			fieldList: []db.SensitiveField{
+				{Name: "card", Sensitive: true, Mask: partial},
+				{Name: "e", Sensitive: true, Mask: email},
+				{Name: "upper", Sensitive: true},
+			},
+		},
+		{
+			dbType:    db.Postgres,
+			statement: "SELECT card FROM t UNION SELECT name FROM t",
+			fieldList: []db.SensitiveField{
+				{Name: "card", Sensitive: true, Mask: partial},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		res, err := extractSensitiveField(test.dbType, test.statement, "db", schemaInfo)
+		require.NoError(t, err, test.statement)
+		require.Equal(t, test.fieldList, res, test.statement)
+	}
+}
@@ -0,0 +1,510 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	tidbparser "github.com/pingcap/tidb/parser"
+	tidbast "github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+)
+
+// RowFilter is the predicate restricting the rows of a table returned by a query.
+type RowFilter struct {
+	// Database is the database of the table, only used by MySQL.
+	Database string
+	// Schema is the schema of the table, only used by PostgreSQL, defaults to "public".
+	Schema     string
+	Table      string
+	Expression string
+}
+
+// RowFilterView is a view in a database having row filters.
+// The view can read the filtered tables bypassing the row filters, so the queries reading it are rejected.
+type RowFilterView struct {
+	// Database is the database of the view, only used by MySQL.
+	Database string
+	// Schema is the schema of the view, only used by PostgreSQL, defaults to "public".
+	Schema string
+	Name   string
+}
+
+// RewriteRowFilter rewrites the query statement so that every table read by the statement only returns the rows matching its filters.
+// Each filtered table reference is replaced with a derived table selecting the filtered rows under the same alias,
+// so the joins, CTEs and subqueries of the statement keep working.
+// It returns an error if the statement is not a query, because it cannot be rewritten safely.
+// It also returns an error if the statement calls functions outside the allow-list, such as query_to_xml and dblink,
+// because they can read the tables bypassing the rewritten table references.
+// For the same reason, it returns an error if the statement reads any view in viewList.
+func RewriteRowFilter(dbType db.Type, statement string, currentDatabase string, filterList []RowFilter, viewList []RowFilterView) (string, error) {
+	if len(filterList) == 0 {
+		return statement, nil
+	}
+	if err := parser.ValidateQueryFunctions(parser.EngineType(dbType), statement); err != nil {
+		return "", errors.Wrapf(err, "cannot apply row filter")
+	}
+
+	switch dbType {
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+		rewriter := &mysqlRowFilterRewriter{
+			currentDatabase: currentDatabase,
+			filters:         make(map[string][]string),
+			views:           make(map[string]bool),
+		}
+		for _, filter := range filterList {
+			if err := validateMySQLRowFilterExpression(filter.Expression); err != nil {
+				return "", err
+			}
+			key := mysqlRowFilterKey(filter.Database, filter.Table)
+			rewriter.filters[key] = append(rewriter.filters[key], filter.Expression)
+		}
+		for _, view := range viewList {
+			rewriter.views[mysqlRowFilterKey(view.Database, view.Name)] = true
+		}
+		return rewriter.rewrite(statement)
+	case db.Postgres:
+		rewriter := &pgRowFilterRewriter{
+			filters: make(map[string][]string),
+			views:   make(map[string]bool),
+		}
+		for _, filter := range filterList {
+			if err := validatePGRowFilterExpression(filter.Expression); err != nil {
+				return "", err
+			}
+			key := pgNormalizeTableName(filter.Schema, filter.Table)
+			rewriter.filters[key] = append(rewriter.filters[key], filter.Expression)
+		}
+		for _, view := range viewList {
+			rewriter.views[pgNormalizeTableName(view.Schema, view.Name)] = true
+		}
+		return rewriter.rewrite(statement)
+	default:
+		return "", errors.Errorf("row filter is not supported for engine %s", dbType)
+	}
+}
+
+// joinRowFilterExpression combines the filters of a table with AND.
+func joinRowFilterExpression(expressionList []string) string {
+	var list []string
+	for _, expression := range expressionList {
+		list = append(list, fmt.Sprintf("(%s)", expression))
+	}
+	return strings.Join(list, " AND ")
+}
+
+func mysqlRowFilterKey(databaseName string, tableName string) string {
+	// Table names may be case-insensitive depending on lower_case_table_names, so we compare them case-insensitively.
+	// It may filter more tables than needed but never less.
+	return strings.ToLower(fmt.Sprintf("%s.%s", databaseName, tableName))
+}
+
+func validateMySQLRowFilterExpression(expression string) error {
+	where, err := parseMySQLRowFilterQuery(fmt.Sprintf("SELECT 1 FROM DUAL WHERE %s", expression))
+	if err != nil || where.Where == nil {
+		return errors.Errorf("invalid row filter expression %q", expression)
+	}
+	return nil
+}
+
+// parseMySQLRowFilterQuery parses the query wrapping the row filter expression, and makes sure the expression doesn't escape the WHERE clause.
+func parseMySQLRowFilterQuery(query string) (*tidbast.SelectStmt, error) {
+	p := tidbparser.New()
+	p.EnableWindowFunc(true)
+	nodeList, _, err := p.Parse(query, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if len(nodeList) != 1 {
+		return nil, errors.Errorf("expect one statement but found %d", len(nodeList))
+	}
+	selectStmt, ok := nodeList[0].(*tidbast.SelectStmt)
+	if !ok {
+		return nil, errors.Errorf("expect a SELECT statement but found %T", nodeList[0])
+	}
+	if selectStmt.GroupBy != nil || selectStmt.Having != nil || selectStmt.OrderBy != nil || selectStmt.Limit != nil || selectStmt.WindowSpecs != nil || selectStmt.LockInfo != nil || selectStmt.SelectIntoOpt != nil {
+		return nil, errors.Errorf("unexpected clause in row filter expression")
+	}
+	return selectStmt, nil
+}
+
+type mysqlRowFilterRewriter struct {
+	currentDatabase string
+	// filters is the map from the lower case "database.table" to the filter expressions.
+	filters map[string][]string
+	// views is the set of the lower case "database.view" that cannot be read.
+	views map[string]bool
+
+	// cteNames is the CTE names visible to the current node.
+	cteNames []string
+	// scopeStack saves the length of cteNames when entering a query, and restores it when leaving the query.
+	scopeStack []int
+	// recursiveStack is whether the WITH clauses being visited are recursive.
+	recursiveStack []bool
+	rewritten      bool
+	err            error
+}
+
+func (r *mysqlRowFilterRewriter) rewrite(statement string) (string, error) {
+	p := tidbparser.New()
+
+	// To support MySQL8 window function syntax.
+	// See https://github.com/bytebase/bytebase/issues/175.
+	p.EnableWindowFunc(true)
+	nodeList, _, err := p.Parse(statement, "", "")
+	if err != nil {
+		return "", err
+	}
+
+	for _, node := range nodeList {
+		switch n := node.(type) {
+		case *tidbast.SelectStmt:
+			if n.SelectIntoOpt != nil {
+				return "", errors.Errorf("cannot apply row filter to SELECT INTO statement")
+			}
+		case *tidbast.SetOprStmt:
+		default:
+			return "", errors.Errorf("cannot apply row filter to %T, only queries are allowed", node)
+		}
+		node.Accept(r)
+		if r.err != nil {
+			return "", r.err
+		}
+	}
+	if !r.rewritten {
+		return statement, nil
+	}
+
+	var list []string
+	for _, node := range nodeList {
+		var buf strings.Builder
+		if err := node.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags|format.RestoreStringWithoutCharset, &buf)); err != nil {
+			return "", errors.Wrapf(err, "failed to restore the statement with row filter")
+		}
+		list = append(list, buf.String())
+	}
+	return strings.Join(list, ";\n"), nil
+}
+
+// Enter implements the ast.Visitor interface.
+func (r *mysqlRowFilterRewriter) Enter(in tidbast.Node) (tidbast.Node, bool) {
+	switch node := in.(type) {
+	case *tidbast.SelectStmt, *tidbast.SetOprStmt, *tidbast.SetOprSelectList:
+		r.scopeStack = append(r.scopeStack, len(r.cteNames))
+	case *tidbast.WithClause:
+		r.recursiveStack = append(r.recursiveStack, node.IsRecursive)
+	case *tidbast.CommonTableExpression:
+		// The recursive CTE can refer to itself.
+		if len(r.recursiveStack) > 0 && r.recursiveStack[len(r.recursiveStack)-1] {
+			r.cteNames = append(r.cteNames, node.Name.L)
+		}
+	}
+	return in, r.err != nil
+}
+
+// Leave implements the ast.Visitor interface.
+func (r *mysqlRowFilterRewriter) Leave(in tidbast.Node) (tidbast.Node, bool) {
+	switch node := in.(type) {
+	case *tidbast.SelectStmt, *tidbast.SetOprStmt, *tidbast.SetOprSelectList:
+		r.cteNames = r.cteNames[:r.scopeStack[len(r.scopeStack)-1]]
+		r.scopeStack = r.scopeStack[:len(r.scopeStack)-1]
+	case *tidbast.WithClause:
+		r.recursiveStack = r.recursiveStack[:len(r.recursiveStack)-1]
+	case *tidbast.CommonTableExpression:
+		// The following CTEs in the same WITH clause and the query can refer to the CTE.
+		if len(r.recursiveStack) > 0 && !r.recursiveStack[len(r.recursiveStack)-1] {
+			r.cteNames = append(r.cteNames, node.Name.L)
+		}
+	case *tidbast.TableSource:
+		if err := r.rewriteTableSource(node); err != nil {
+			r.err = err
+			return in, false
+		}
+	}
+	return in, true
+}
+
+func (r *mysqlRowFilterRewriter) isCTE(tableName *tidbast.TableName) bool {
+	if tableName.Schema.O != "" {
+		return false
+	}
+	// Visit in reversed order for the same reason as findTableSchema.
+	for i := len(r.cteNames) - 1; i >= 0; i-- {
+		if r.cteNames[i] == tableName.Name.L {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *mysqlRowFilterRewriter) rewriteTableSource(node *tidbast.TableSource) error {
+	tableName, ok := node.Source.(*tidbast.TableName)
+	if !ok || r.isCTE(tableName) {
+		return nil
+	}
+	databaseName := tableName.Schema.O
+	if databaseName == "" {
+		databaseName = r.currentDatabase
+	}
+	key := mysqlRowFilterKey(databaseName, tableName.Name.O)
+	if r.views[key] {
+		return errors.Errorf("cannot apply row filter to view %q", tableName.Name.O)
+	}
+	expressionList, ok := r.filters[key]
+	if !ok {
+		return nil
+	}
+
+	// The table in the query template is replaced with the original table name to keep its partitions and index hints.
+	subquery, err := parseMySQLRowFilterQuery(fmt.Sprintf("SELECT * FROM `t` WHERE %s", joinRowFilterExpression(expressionList)))
+	if err != nil {
+		return errors.Wrapf(err, "failed to build row filter for table %q", tableName.Name.O)
+	}
+	subquery.From.TableRefs.Left = &tidbast.TableSource{Source: tableName}
+
+	if node.AsName.O == "" {
+		node.AsName = model.NewCIStr(tableName.Name.O)
+	}
+	node.Source = subquery
+	r.rewritten = true
+	return nil
+}
+
+func validatePGRowFilterExpression(expression string) error {
+	if _, err := parsePGRowFilterQuery(fmt.Sprintf("SELECT 1 WHERE %s", expression)); err != nil {
+		return errors.Errorf("invalid row filter expression %q", expression)
+	}
+	return nil
+}
+
+// parsePGRowFilterQuery parses the query wrapping the row filter expression, and makes sure the expression doesn't escape the WHERE clause.
+func parsePGRowFilterQuery(query string) (*pgquery.SelectStmt, error) {
+	res, err := pgquery.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Stmts) != 1 {
+		return nil, errors.Errorf("expect one statement but found %d", len(res.Stmts))
+	}
+	node, ok := res.Stmts[0].Stmt.Node.(*pgquery.Node_SelectStmt)
+	if !ok {
+		return nil, errors.Errorf("expect a SELECT statement but found %T", res.Stmts[0].Stmt.Node)
+	}
+	selectStmt := node.SelectStmt
+	if selectStmt.WhereClause == nil || selectStmt.Op != pgquery.SetOperation_SETOP_NONE || len(selectStmt.GroupClause) > 0 || selectStmt.HavingClause != nil || len(selectStmt.SortClause) > 0 || selectStmt.LimitCount != nil || selectStmt.LimitOffset != nil || len(selectStmt.LockingClause) > 0 || len(selectStmt.WindowClause) > 0 {
+		return nil, errors.Errorf("unexpected clause in row filter expression")
+	}
+	return selectStmt, nil
+}
+
+type pgRowFilterRewriter struct {
+	// filters is the map from "schema.table" to the filter expressions.
+	filters map[string][]string
+	// views is the set of "schema.view" that cannot be read.
+	views map[string]bool
+
+	// cteNames is the CTE names visible to the current node.
+	cteNames  []string
+	rewritten bool
+}
+
+func (r *pgRowFilterRewriter) rewrite(statement string) (string, error) {
+	res, err := pgquery.Parse(statement)
+	if err != nil {
+		return "", err
+	}
+	for _, stmt := range res.Stmts {
+		selectStmt, ok := stmt.Stmt.Node.(*pgquery.Node_SelectStmt)
+		if !ok {
+			return "", errors.Errorf("cannot apply row filter to %T, only queries are allowed", stmt.Stmt.Node)
+		}
+		if err := r.rewriteSelect(selectStmt.SelectStmt); err != nil {
+			return "", err
+		}
+	}
+	if !r.rewritten {
+		return statement, nil
+	}
+
+	result, err := pgquery.Deparse(res)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to deparse the statement with row filter")
+	}
+	return result, nil
+}
+
+func (r *pgRowFilterRewriter) rewriteSelect(node *pgquery.SelectStmt) error {
+	if node == nil {
+		return nil
+	}
+	if node.IntoClause != nil {
+		return errors.Errorf("cannot apply row filter to SELECT INTO statement")
+	}
+
+	cteLength := len(r.cteNames)
+	defer func() {
+		r.cteNames = r.cteNames[:cteLength]
+	}()
+	if node.WithClause != nil {
+		for _, cte := range node.WithClause.Ctes {
+			cteNode, ok := cte.Node.(*pgquery.Node_CommonTableExpr)
+			if !ok {
+				return errors.Errorf("expect CommonTableExpr but found %T", cte.Node)
+			}
+			// The recursive CTE can refer to itself, and the following CTEs and the query can refer to the CTE.
+			if node.WithClause.Recursive {
+				r.cteNames = append(r.cteNames, cteNode.CommonTableExpr.Ctename)
+			}
+			if err := r.rewriteNode(cteNode.CommonTableExpr.Ctequery); err != nil {
+				return err
+			}
+			if !node.WithClause.Recursive {
+				r.cteNames = append(r.cteNames, cteNode.CommonTableExpr.Ctename)
+			}
+		}
+	}
+
+	if err := r.rewriteSelect(node.Larg); err != nil {
+		return err
+	}
+	if err := r.rewriteSelect(node.Rarg); err != nil {
+		return err
+	}
+	// The locking clause refers to the aliases which are kept after rewriting, so we skip it.
+	for _, list := range [][]*pgquery.Node{
+		node.DistinctClause,
+		node.TargetList,
+		node.FromClause,
+		{node.WhereClause},
+		node.GroupClause,
+		{node.HavingClause},
+		node.WindowClause,
+		node.ValuesLists,
+		node.SortClause,
+		{node.LimitOffset, node.LimitCount},
+	} {
+		for _, item := range list {
+			if err := r.rewriteNode(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *pgRowFilterRewriter) rewriteNode(node *pgquery.Node) error {
+	if node == nil {
+		return nil
+	}
+	switch n := node.Node.(type) {
+	case *pgquery.Node_SelectStmt:
+		return r.rewriteSelect(n.SelectStmt)
+	case *pgquery.Node_RangeVar:
+		return r.rewriteRangeVar(node, n.RangeVar)
+	case *pgquery.Node_RangeTableSample:
+		if rangeVar, ok := n.RangeTableSample.Relation.Node.(*pgquery.Node_RangeVar); ok && r.getFilters(rangeVar.RangeVar) != nil {
+			return errors.Errorf("cannot apply row filter to table %q with TABLESAMPLE", rangeVar.RangeVar.Relname)
+		}
+	}
+	return r.rewriteMessage(node.ProtoReflect())
+}
+
+// rewriteMessage rewrites the nodes in the fields of the message, such as the subqueries in the expressions.
+func (r *pgRowFilterRewriter) rewriteMessage(message protoreflect.Message) error {
+	var err error
+	message.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind {
+			return true
+		}
+		if fd.IsList() {
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				if err = r.rewriteField(list.Get(i).Message()); err != nil {
+					return false
+				}
+			}
+			return true
+		}
+		if fd.IsMap() {
+			return true
+		}
+		err = r.rewriteField(v.Message())
+		return err == nil
+	})
+	return err
+}
+
+func (r *pgRowFilterRewriter) rewriteField(message protoreflect.Message) error {
+	switch m := message.Interface().(type) {
+	case *pgquery.Node:
+		return r.rewriteNode(m)
+	case *pgquery.SelectStmt:
+		return r.rewriteSelect(m)
+	default:
+		return r.rewriteMessage(message)
+	}
+}
+
+func (r *pgRowFilterRewriter) isCTE(node *pgquery.RangeVar) bool {
+	if node.Schemaname != "" {
+		return false
+	}
+	// Visit in reversed order for the same reason as pgFindTableSchema.
+	for i := len(r.cteNames) - 1; i >= 0; i-- {
+		if r.cteNames[i] == node.Relname {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *pgRowFilterRewriter) getFilters(node *pgquery.RangeVar) []string {
+	if r.isCTE(node) {
+		return nil
+	}
+	return r.filters[pgNormalizeTableName(node.Schemaname, node.Relname)]
+}
+
+func (r *pgRowFilterRewriter) rewriteRangeVar(node *pgquery.Node, rangeVar *pgquery.RangeVar) error {
+	if r.isCTE(rangeVar) {
+		return nil
+	}
+	key := pgNormalizeTableName(rangeVar.Schemaname, rangeVar.Relname)
+	if r.views[key] {
+		return errors.Errorf("cannot apply row filter to view %q", rangeVar.Relname)
+	}
+	expressionList := r.filters[key]
+	if expressionList == nil {
+		return nil
+	}
+
+	// The table in the query template is replaced with the original table to keep ONLY.
+	subquery, err := parsePGRowFilterQuery(fmt.Sprintf("SELECT * FROM t WHERE %s", joinRowFilterExpression(expressionList)))
+	if err != nil {
+		return errors.Wrapf(err, "failed to build row filter for table %q", rangeVar.Relname)
+	}
+	table := proto.Clone(rangeVar).(*pgquery.RangeVar)
+	table.Alias = nil
+	subquery.FromClause = []*pgquery.Node{{Node: &pgquery.Node_RangeVar{RangeVar: table}}}
+
+	alias := rangeVar.Alias
+	if alias == nil {
+		alias = &pgquery.Alias{Aliasname: rangeVar.Relname}
+	}
+	node.Node = &pgquery.Node_RangeSubselect{
+		RangeSubselect: &pgquery.RangeSubselect{
+			Subquery: &pgquery.Node{Node: &pgquery.Node_SelectStmt{SelectStmt: subquery}},
+			Alias:    alias,
+		},
+	}
+	r.rewritten = true
+	return nil
+}
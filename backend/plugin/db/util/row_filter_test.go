@@ -0,0 +1,76 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+)
+
+func TestRewriteRowFilter(t *testing.T) {
+	mysqlFilters := []RowFilter{
+		{Database: "db", Table: "t", Expression: "tenant_id IN (1, 2)"},
+		{Database: "db", Table: "t", Expression: "region = 'EU'"},
+		{Database: "db2", Table: "u", Expression: "a > 0"},
+	}
+	pgFilters := []RowFilter{
+		{Table: "t", Expression: "tenant_id IN (1, 2)"},
+		{Schema: "s", Table: "u", Expression: "region = 'EU'"},
+	}
+	mysqlViews := []RowFilterView{{Database: "db", Name: "v"}}
+	pgViews := []RowFilterView{{Schema: "s", Name: "v"}}
+	tests := []struct {
+		dbType    db.Type
+		statement string
+		filters   []RowFilter
+		views     []RowFilterView
+		want      string
+		wantErr   bool
+	}{
+		// MySQL.
+		{dbType: db.MySQL, statement: "SELECT * FROM t", filters: nil, want: "SELECT * FROM t"},
+		{dbType: db.MySQL, statement: "SELECT * FROM x", filters: mysqlFilters, want: "SELECT * FROM x"},
+		{dbType: db.MySQL, statement: "SELECT a FROM t", filters: mysqlFilters, want: "SELECT `a` FROM (SELECT * FROM `t` WHERE (`tenant_id` IN (1,2)) AND (`region`='EU')) AS `t`"},
+		{dbType: db.MySQL, statement: "SELECT t1.a FROM T t1 JOIN db2.u ON t1.a = u.a WHERE t1.b IN (SELECT b FROM t)", filters: mysqlFilters, want: "SELECT `t1`.`a` FROM (SELECT * FROM `T` WHERE (`tenant_id` IN (1,2)) AND (`region`='EU')) AS `t1` JOIN (SELECT * FROM `db2`.`u` WHERE (`a`>0)) AS `u` ON `t1`.`a`=`u`.`a` WHERE `t1`.`b` IN (SELECT `b` FROM (SELECT * FROM `t` WHERE (`tenant_id` IN (1,2)) AND (`region`='EU')) AS `t`)"},
+		{dbType: db.MySQL, statement: "WITH t AS (SELECT * FROM t) SELECT * FROM t", filters: mysqlFilters, want: "WITH `t` AS (SELECT * FROM (SELECT * FROM `t` WHERE (`tenant_id` IN (1,2)) AND (`region`='EU')) AS `t`) SELECT * FROM `t`"},
+		{dbType: db.MySQL, statement: "WITH RECURSIVE t AS (SELECT 1 AS a UNION ALL SELECT a + 1 FROM t WHERE a < 3) SELECT * FROM t, db.t AS x", filters: mysqlFilters, want: "WITH RECURSIVE `t` AS (SELECT 1 AS `a` UNION ALL SELECT `a`+1 FROM `t` WHERE `a`<3) SELECT * FROM (`t`) JOIN (SELECT * FROM `db`.`t` WHERE (`tenant_id` IN (1,2)) AND (`region`='EU')) AS `x`"},
+		{dbType: db.MySQL, statement: "SELECT * FROM t UNION SELECT * FROM db2.u", filters: mysqlFilters, want: "SELECT * FROM (SELECT * FROM `t` WHERE (`tenant_id` IN (1,2)) AND (`region`='EU')) AS `t` UNION SELECT * FROM (SELECT * FROM `db2`.`u` WHERE (`a`>0)) AS `u`"},
+		{dbType: db.MySQL, statement: "DELETE FROM t", filters: mysqlFilters, wantErr: true},
+		{dbType: db.MySQL, statement: "SELECT COUNT(*) FROM t", filters: mysqlFilters, want: "SELECT COUNT(1) FROM (SELECT * FROM `t` WHERE (`tenant_id` IN (1,2)) AND (`region`='EU')) AS `t`"},
+		{dbType: db.MySQL, statement: "SELECT db.read_t() FROM x", filters: mysqlFilters, wantErr: true},
+		{dbType: db.MySQL, statement: "SELECT * FROM t", filters: []RowFilter{{Database: "db", Table: "t", Expression: "1 UNION SELECT * FROM x"}}, wantErr: true},
+		{dbType: db.MySQL, statement: "SELECT * FROM v", filters: mysqlFilters, views: mysqlViews, wantErr: true},
+		{dbType: db.MySQL, statement: "SELECT * FROM x WHERE a IN (SELECT a FROM db.V)", filters: mysqlFilters, views: mysqlViews, wantErr: true},
+		{dbType: db.MySQL, statement: "WITH v AS (SELECT 1 AS a) SELECT * FROM v", filters: mysqlFilters, views: mysqlViews, want: "WITH v AS (SELECT 1 AS a) SELECT * FROM v"},
+		{dbType: db.MySQL, statement: "SELECT * FROM db2.v", filters: mysqlFilters, views: mysqlViews, want: "SELECT * FROM db2.v"},
+		// PostgreSQL.
+		{dbType: db.Postgres, statement: "SELECT * FROM x", filters: pgFilters, want: "SELECT * FROM x"},
+		{dbType: db.Postgres, statement: "SELECT a FROM t", filters: pgFilters, want: "SELECT a FROM (SELECT * FROM t WHERE tenant_id IN (1, 2)) t"},
+		{dbType: db.Postgres, statement: "SELECT x.a FROM public.t x JOIN s.u ON x.a = u.a WHERE x.b IN (SELECT b FROM t)", filters: pgFilters, want: "SELECT x.a FROM (SELECT * FROM public.t WHERE tenant_id IN (1, 2)) x JOIN (SELECT * FROM s.u WHERE region = 'EU') u ON x.a = u.a WHERE x.b IN (SELECT b FROM (SELECT * FROM t WHERE tenant_id IN (1, 2)) t)"},
+		{dbType: db.Postgres, statement: "WITH t AS (SELECT * FROM t) SELECT * FROM t", filters: pgFilters, want: "WITH t AS (SELECT * FROM (SELECT * FROM t WHERE tenant_id IN (1, 2)) t) SELECT * FROM t"},
+		{dbType: db.Postgres, statement: "WITH RECURSIVE t AS (SELECT 1 AS a UNION ALL SELECT a + 1 FROM t WHERE a < 3) SELECT * FROM t, public.t AS x", filters: pgFilters, want: "WITH RECURSIVE t AS (SELECT 1 AS a UNION ALL SELECT a + 1 FROM t WHERE a < 3) SELECT * FROM t, (SELECT * FROM public.t WHERE tenant_id IN (1, 2)) x"},
+		{dbType: db.Postgres, statement: "SELECT * FROM t TABLESAMPLE SYSTEM (10)", filters: pgFilters, wantErr: true},
+		{dbType: db.Postgres, statement: "SELECT * INTO v FROM t", filters: pgFilters, wantErr: true},
+		{dbType: db.Postgres, statement: "DELETE FROM t", filters: pgFilters, wantErr: true},
+		{dbType: db.Postgres, statement: "SELECT * FROM query_to_xml('SELECT * FROM t', true, false, '')", filters: pgFilters, wantErr: true},
+		{dbType: db.Postgres, statement: "SELECT query_to_xml_and_xmlschema('SELECT * FROM t', true, false, '')", filters: pgFilters, wantErr: true},
+		{dbType: db.Postgres, statement: "SELECT * FROM dblink('dbname=db', 'SELECT a FROM t') AS x(a int)", filters: pgFilters, wantErr: true},
+		{dbType: db.Postgres, statement: "SELECT * FROM t", filters: []RowFilter{{Table: "t", Expression: "true) UNION (SELECT * FROM x"}}, wantErr: true},
+		{dbType: db.Postgres, statement: "SELECT * FROM s.v", filters: pgFilters, views: pgViews, wantErr: true},
+		{dbType: db.Postgres, statement: "SELECT * FROM x WHERE a IN (SELECT a FROM s.v TABLESAMPLE SYSTEM (10))", filters: pgFilters, views: pgViews, wantErr: true},
+		{dbType: db.Postgres, statement: "SELECT * FROM v", filters: pgFilters, views: pgViews, want: "SELECT * FROM v"},
+		// Other engines.
+		{dbType: db.Oracle, statement: "SELECT * FROM t", filters: pgFilters, wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := RewriteRowFilter(test.dbType, test.statement, "db", test.filters, test.views)
+		if test.wantErr {
+			require.Error(t, err, test.statement)
+			continue
+		}
+		require.NoError(t, err, test.statement)
+		require.Equal(t, test.want, got, test.statement)
+	}
+}
@@ -0,0 +1,248 @@
+// Package ldap is the plugin for LDAP Identity Provider, e.g. OpenLDAP and
+// Active Directory.
+package ldap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// SecurityProtocol is the protocol used to secure the connection to the LDAP server.
+type SecurityProtocol string
+
+const (
+	// SecurityProtocolNone is the plain LDAP protocol.
+	SecurityProtocolNone SecurityProtocol = ""
+	// SecurityProtocolStartTLS upgrades the plain LDAP connection with the StartTLS extended operation.
+	SecurityProtocolStartTLS SecurityProtocol = "starttls"
+	// SecurityProtocolLDAPS is LDAP over TLS.
+	SecurityProtocolLDAPS SecurityProtocol = "ldaps"
+)
+
+// DefaultGroupAttribute is the user attribute listing the groups of the user,
+// which is supported by both Active Directory and OpenLDAP with the memberOf overlay.
+const DefaultGroupAttribute = "memberOf"
+
+// dialTimeout is the timeout of connecting to the LDAP server.
+const dialTimeout = 10 * time.Second
+
+// ErrInvalidCredentials is returned when the user is not found or the password is wrong.
+// The two cases are not distinguished to avoid leaking the existence of the users.
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// IdentityProvider represents an LDAP Identity Provider.
+type IdentityProvider struct {
+	config IdentityProviderConfig
+}
+
+// IdentityProviderConfig is the configuration to be consumed by the LDAP
+// Identity Provider.
+type IdentityProviderConfig struct {
+	Host          string `json:"host"`
+	Port          int    `json:"port"`
+	SkipTLSVerify bool   `json:"skipTlsVerify"`
+	// BindDN and BindPassword are the credentials of the service account used to
+	// search the users. The anonymous bind is used if BindDN is empty.
+	BindDN       string `json:"bindDn"`
+	BindPassword string `json:"bindPassword"`
+	BaseDN       string `json:"baseDn"`
+	// UserFilter is the filter to search the user, the "%s" is replaced by the
+	// escaped username, e.g. "(uid=%s)" or "(sAMAccountName=%s)".
+	UserFilter       string                `json:"userFilter"`
+	SecurityProtocol SecurityProtocol      `json:"securityProtocol"`
+	FieldMapping     *storepb.FieldMapping `json:"fieldMapping"`
+	// GroupAttribute is the user attribute listing the group DNs of the user.
+	GroupAttribute string `json:"groupAttribute"`
+}
+
+// NewIdentityProvider initializes a new LDAP Identity Provider with the given
+// configuration.
+func NewIdentityProvider(config IdentityProviderConfig) (*IdentityProvider, error) {
+	if config.FieldMapping == nil {
+		return nil, errors.New(`the field "fieldMapping" is empty but required`)
+	}
+	for v, field := range map[string]string{
+		config.Host:                    "host",
+		config.BaseDN:                  "baseDn",
+		config.UserFilter:              "userFilter",
+		config.FieldMapping.Identifier: "fieldMapping.identifier",
+	} {
+		if v == "" {
+			return nil, errors.Errorf("the field %q is empty but required", field)
+		}
+	}
+	if config.Port <= 0 || config.Port > 65535 {
+		return nil, errors.Errorf("invalid port %d", config.Port)
+	}
+	if strings.Count(config.UserFilter, "%s") != 1 {
+		return nil, errors.Errorf(`the user filter %q must contain exactly one "%%s" placeholder for the username`, config.UserFilter)
+	}
+	switch config.SecurityProtocol {
+	case SecurityProtocolNone, SecurityProtocolStartTLS, SecurityProtocolLDAPS:
+	default:
+		return nil, errors.Errorf("unsupported security protocol %q", config.SecurityProtocol)
+	}
+	if config.GroupAttribute == "" {
+		config.GroupAttribute = DefaultGroupAttribute
+	}
+
+	return &IdentityProvider{
+		config: config,
+	}, nil
+}
+
+// dial connects to the LDAP server and secures the connection as configured.
+func (p *IdentityProvider) dial() (*ldap.Conn, error) {
+	addr := net.JoinHostPort(p.config.Host, strconv.Itoa(p.config.Port))
+	tlsConfig := &tls.Config{
+		ServerName:         p.config.Host,
+		InsecureSkipVerify: p.config.SkipTLSVerify,
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+
+	var conn *ldap.Conn
+	var err error
+	if p.config.SecurityProtocol == SecurityProtocolLDAPS {
+		conn, err = ldap.DialURL(fmt.Sprintf("ldaps://%s", addr), ldap.DialWithDialer(dialer), ldap.DialWithTLSConfig(tlsConfig))
+	} else {
+		conn, err = ldap.DialURL(fmt.Sprintf("ldap://%s", addr), ldap.DialWithDialer(dialer))
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "dial %q", addr)
+	}
+
+	if p.config.SecurityProtocol == SecurityProtocolStartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "start TLS")
+		}
+	}
+	return conn, nil
+}
+
+// connect dials the LDAP server and binds the service account.
+func (p *IdentityProvider) connect() (*ldap.Conn, error) {
+	conn, err := p.dial()
+	if err != nil {
+		return nil, err
+	}
+	if p.config.BindDN != "" {
+		if err := conn.Bind(p.config.BindDN, p.config.BindPassword); err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "bind the service account")
+		}
+	}
+	return conn, nil
+}
+
+// Test checks that the LDAP server is reachable and the service account
+// credentials are valid.
+func (p *IdentityProvider) Test() error {
+	conn, err := p.connect()
+	if err != nil {
+		return err
+	}
+	conn.Close()
+	return nil
+}
+
+// Authenticate verifies the password of the user, and returns the user
+// information and the DNs of the groups the user belongs to.
+func (p *IdentityProvider) Authenticate(username, password string) (*storepb.IdentityProviderUserInfo, []string, error) {
+	// An empty password would be an unauthenticated bind, which succeeds on
+	// most servers without checking anything.
+	if username == "" || password == "" {
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	conn, err := p.connect()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer conn.Close()
+
+	attributes := []string{"dn", p.config.FieldMapping.Identifier, p.config.GroupAttribute}
+	if p.config.FieldMapping.DisplayName != "" {
+		attributes = append(attributes, p.config.FieldMapping.DisplayName)
+	}
+	if p.config.FieldMapping.Email != "" {
+		attributes = append(attributes, p.config.FieldMapping.Email)
+	}
+	result, err := conn.Search(ldap.NewSearchRequest(
+		p.config.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2, /* sizeLimit */
+		int(dialTimeout.Seconds()),
+		false, /* typesOnly */
+		fmt.Sprintf(p.config.UserFilter, ldap.EscapeFilter(username)),
+		attributes,
+		nil, /* controls */
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, nil, errors.Wrap(err, "search user")
+	}
+	if result == nil || len(result.Entries) == 0 {
+		return nil, nil, ErrInvalidCredentials
+	}
+	if len(result.Entries) > 1 {
+		return nil, nil, errors.Errorf("found multiple users matching %q, the user filter must identify a unique user", username)
+	}
+	entry := result.Entries[0]
+	log.Debug("LDAP user entry", zap.String("dn", entry.DN))
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, nil, ErrInvalidCredentials
+		}
+		return nil, nil, errors.Wrap(err, "bind user")
+	}
+
+	userInfo := &storepb.IdentityProviderUserInfo{
+		Identifier: entry.GetEqualFoldAttributeValue(p.config.FieldMapping.Identifier),
+	}
+	if userInfo.Identifier == "" {
+		return nil, nil, errors.Errorf("the attribute %q is not found in the user entry or has empty value", p.config.FieldMapping.Identifier)
+	}
+
+	// Best effort to map optional fields
+	if p.config.FieldMapping.DisplayName != "" {
+		userInfo.DisplayName = entry.GetEqualFoldAttributeValue(p.config.FieldMapping.DisplayName)
+	}
+	if userInfo.DisplayName == "" {
+		userInfo.DisplayName = userInfo.Identifier
+	}
+	if p.config.FieldMapping.Email != "" {
+		userInfo.Email = entry.GetEqualFoldAttributeValue(p.config.FieldMapping.Email)
+	}
+	return userInfo, entry.GetEqualFoldAttributeValues(p.config.GroupAttribute), nil
+}
+
+// MatchGroup returns true if the group DN matches the configured group, which is
+// either the full DN or the CN of the group, case-insensitively.
+func MatchGroup(groupDN, group string) bool {
+	if strings.EqualFold(strings.TrimSpace(groupDN), strings.TrimSpace(group)) {
+		return true
+	}
+	dn, err := ldap.ParseDN(groupDN)
+	if err != nil || len(dn.RDNs) == 0 {
+		return false
+	}
+	for _, attr := range dn.RDNs[0].Attributes {
+		if strings.EqualFold(attr.Type, "cn") && strings.EqualFold(attr.Value, strings.TrimSpace(group)) {
+			return true
+		}
+	}
+	return false
+}
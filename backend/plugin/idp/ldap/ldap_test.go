@@ -0,0 +1,265 @@
+package ldap
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+type fakeEntry struct {
+	password   string
+	attributes map[string][]string
+}
+
+// fakeServer is a minimal in-process LDAP server that supports simple bind,
+// search with equality filters on "uid", and unbind.
+type fakeServer struct {
+	listener net.Listener
+	entries  map[string]*fakeEntry
+}
+
+func newFakeServer(t *testing.T, entries map[string]*fakeEntry) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeServer{
+		listener: listener,
+		entries:  entries,
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	bound := false
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := op.Children[1].Value.(string)
+			password := string(op.Children[2].Data.Bytes())
+			code := ldap.LDAPResultInvalidCredentials
+			if entry, ok := s.entries[dn]; ok && entry.password == password {
+				code, bound = ldap.LDAPResultSuccess, true
+			}
+			s.write(conn, messageID, newResult(ldap.ApplicationBindResponse, code))
+		case ldap.ApplicationSearchRequest:
+			if !bound {
+				s.write(conn, messageID, newResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultInsufficientAccessRights))
+				continue
+			}
+			filter, err := ldap.DecompileFilter(op.Children[6])
+			if err != nil {
+				return
+			}
+			for dn, entry := range s.entries {
+				for _, uid := range entry.attributes["uid"] {
+					if fmt.Sprintf("(uid=%s)", ldap.EscapeFilter(uid)) == filter {
+						s.write(conn, messageID, newSearchResultEntry(dn, entry.attributes))
+					}
+				}
+			}
+			s.write(conn, messageID, newResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func (*fakeServer) write(conn net.Conn, messageID int64, op *ber.Packet) {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+	packet.AppendChild(op)
+	_, _ = conn.Write(packet.Bytes())
+}
+
+func newResult(tag ber.Tag, code int) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return op
+}
+
+func newSearchResultEntry(dn string, attributes map[string][]string) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "DN"))
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for name, values := range attributes {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+		vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, value := range values {
+			vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+		}
+		attr.AppendChild(vals)
+		attrs.AppendChild(attr)
+	}
+	op.AppendChild(attrs)
+	return op
+}
+
+func newTestIdentityProvider(t *testing.T) *IdentityProvider {
+	s := newFakeServer(t, map[string]*fakeEntry{
+		"cn=admin,dc=example,dc=com": {
+			password: "admin-secret",
+		},
+		"uid=alice,ou=people,dc=example,dc=com": {
+			password: "alice-secret",
+			attributes: map[string][]string{
+				"uid":      {"alice"},
+				"cn":       {"Alice"},
+				"mail":     {"alice@example.com"},
+				"memberOf": {"cn=dba,ou=groups,dc=example,dc=com", "cn=eng,ou=groups,dc=example,dc=com"},
+			},
+		},
+	})
+	p, err := NewIdentityProvider(IdentityProviderConfig{
+		Host:         "127.0.0.1",
+		Port:         s.port(),
+		BindDN:       "cn=admin,dc=example,dc=com",
+		BindPassword: "admin-secret",
+		BaseDN:       "dc=example,dc=com",
+		UserFilter:   "(uid=%s)",
+		FieldMapping: &storepb.FieldMapping{
+			Identifier:  "uid",
+			DisplayName: "cn",
+			Email:       "mail",
+		},
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func TestNewIdentityProvider(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      IdentityProviderConfig
+		containsErr string
+	}{
+		{
+			name: "no host",
+			config: IdentityProviderConfig{
+				Port:         389,
+				BaseDN:       "dc=example,dc=com",
+				UserFilter:   "(uid=%s)",
+				FieldMapping: &storepb.FieldMapping{Identifier: "uid"},
+			},
+			containsErr: `the field "host" is empty but required`,
+		},
+		{
+			name: "no port",
+			config: IdentityProviderConfig{
+				Host:         "ldap.example.com",
+				BaseDN:       "dc=example,dc=com",
+				UserFilter:   "(uid=%s)",
+				FieldMapping: &storepb.FieldMapping{Identifier: "uid"},
+			},
+			containsErr: "invalid port 0",
+		},
+		{
+			name: "no placeholder in user filter",
+			config: IdentityProviderConfig{
+				Host:         "ldap.example.com",
+				Port:         389,
+				BaseDN:       "dc=example,dc=com",
+				UserFilter:   "(uid=alice)",
+				FieldMapping: &storepb.FieldMapping{Identifier: "uid"},
+			},
+			containsErr: `must contain exactly one "%s" placeholder`,
+		},
+		{
+			name: "unsupported security protocol",
+			config: IdentityProviderConfig{
+				Host:             "ldap.example.com",
+				Port:             389,
+				BaseDN:           "dc=example,dc=com",
+				UserFilter:       "(uid=%s)",
+				SecurityProtocol: "ssl",
+				FieldMapping:     &storepb.FieldMapping{Identifier: "uid"},
+			},
+			containsErr: `unsupported security protocol "ssl"`,
+		},
+		{
+			name: "no field mapping identifier",
+			config: IdentityProviderConfig{
+				Host:         "ldap.example.com",
+				Port:         389,
+				BaseDN:       "dc=example,dc=com",
+				UserFilter:   "(uid=%s)",
+				FieldMapping: &storepb.FieldMapping{},
+			},
+			containsErr: `the field "fieldMapping.identifier" is empty but required`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewIdentityProvider(test.config)
+			assert.ErrorContains(t, err, test.containsErr)
+		})
+	}
+}
+
+func TestIdentityProvider_Test(t *testing.T) {
+	p := newTestIdentityProvider(t)
+	require.NoError(t, p.Test())
+
+	p.config.BindPassword = "wrong"
+	assert.Error(t, p.Test())
+}
+
+func TestIdentityProvider_Authenticate(t *testing.T) {
+	p := newTestIdentityProvider(t)
+
+	userInfo, groups, err := p.Authenticate("alice", "alice-secret")
+	require.NoError(t, err)
+	wantUserInfo := &storepb.IdentityProviderUserInfo{
+		Identifier:  "alice",
+		DisplayName: "Alice",
+		Email:       "alice@example.com",
+	}
+	assert.Equal(t, wantUserInfo, userInfo)
+	assert.Equal(t, []string{"cn=dba,ou=groups,dc=example,dc=com", "cn=eng,ou=groups,dc=example,dc=com"}, groups)
+
+	for _, test := range []struct{ username, password string }{
+		{"alice", "wrong"},
+		{"alice", ""},
+		{"bob", "alice-secret"},
+		{"*", "alice-secret"},
+	} {
+		_, _, err := p.Authenticate(test.username, test.password)
+		assert.ErrorIs(t, err, ErrInvalidCredentials, test)
+	}
+}
+
+func TestMatchGroup(t *testing.T) {
+	assert.True(t, MatchGroup("cn=dba,ou=groups,dc=example,dc=com", "CN=DBA,ou=groups,dc=example,dc=com"))
+	assert.True(t, MatchGroup("cn=dba,ou=groups,dc=example,dc=com", "DBA"))
+	assert.False(t, MatchGroup("cn=dba,ou=groups,dc=example,dc=com", "groups"))
+	assert.False(t, MatchGroup("not a dn", "dba"))
+}
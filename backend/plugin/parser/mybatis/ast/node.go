@@ -3,12 +3,46 @@ package ast
 
 import (
 	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// parameterRegexp matches the #{...} parameters, which are bound by prepared statements.
+	parameterRegexp = regexp.MustCompile(`#\{([^}]*)\}`)
+	// substitutionRegexp matches the ${...} string substitutions, which are spliced into the statement.
+	substitutionRegexp = regexp.MustCompile(`\$\{([^}]*)\}`)
 )
 
+// RestoreContext is the context for restoring the SQL statements from the AST.
+type RestoreContext struct {
+	// SQLMap is the reusable SQL fragments defined by <sql> elements, keyed by id.
+	SQLMap map[string]*SQLNode
+	// Branch is the index of the branch restored for <choose> elements.
+	// The last branch is restored for the <choose> elements with fewer branches.
+	Branch int
+	// Placeholder returns the placeholder of the n-th parameter, such as "?" for MySQL and "$1" for PostgreSQL.
+	// The n is 1-based. "?" is used if it's nil.
+	Placeholder func(n int) string
+
+	parameterCount int
+	includeStack   []string
+}
+
+func (ctx *RestoreContext) placeholder() string {
+	ctx.parameterCount++
+	if ctx.Placeholder == nil {
+		return "?"
+	}
+	return ctx.Placeholder(ctx.parameterCount)
+}
+
 // Node is the interface implemented by all AST node types.
 type Node interface {
-	// RestoreSQL restores the node to the original SQL statement.
-	RestoreSQL(w io.Writer) error
+	// RestoreSQL restores the node to the SQL statement.
+	RestoreSQL(ctx *RestoreContext, w io.Writer) error
 }
 
 // TextNode represents a text node.
@@ -17,44 +51,234 @@ type TextNode struct {
 }
 
 // RestoreSQL implements Node interface.
-func (n *TextNode) RestoreSQL(w io.Writer) error {
-	_, err := w.Write([]byte(n.Text))
+// The #{...} parameters are replaced by the placeholders, and the ${...} string substitutions are replaced by the
+// property names, which are usually the column or table names.
+func (n *TextNode) RestoreSQL(ctx *RestoreContext, w io.Writer) error {
+	text := parameterRegexp.ReplaceAllStringFunc(n.Text, func(string) string {
+		return ctx.placeholder()
+	})
+	text = substitutionRegexp.ReplaceAllStringFunc(text, func(s string) string {
+		return propertyName(substitutionRegexp.FindStringSubmatch(s)[1])
+	})
+	_, err := w.Write([]byte(text))
 	return err
 }
 
+// propertyName returns the last segment of the property, such as "name" for "user.name,jdbcType=VARCHAR".
+func propertyName(property string) string {
+	if i := strings.Index(property, ","); i >= 0 {
+		property = property[:i]
+	}
+	property = strings.TrimSpace(property)
+	if i := strings.LastIndex(property, "."); i >= 0 {
+		property = property[i+1:]
+	}
+	return property
+}
+
 // MapperNode represents a mapper node in mybatis mapper xml begin with <mapper>.
 type MapperNode struct {
 	Namespace  string
+	SQLNodes   []*SQLNode
 	QueryNodes []*QueryNode
 }
 
 // RestoreSQL implements Node interface.
-func (n *MapperNode) RestoreSQL(w io.Writer) error {
+func (n *MapperNode) RestoreSQL(ctx *RestoreContext, w io.Writer) error {
+	if ctx.SQLMap == nil {
+		ctx.SQLMap = n.SQLMap()
+	}
 	for _, node := range n.QueryNodes {
-		if err := node.RestoreSQL(w); err != nil {
+		if err := node.RestoreSQL(ctx, w); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// SQLMap returns the SQL fragments of the mapper keyed by both the id and the id qualified by the namespace.
+func (n *MapperNode) SQLMap() map[string]*SQLNode {
+	sqlMap := make(map[string]*SQLNode)
+	for _, node := range n.SQLNodes {
+		sqlMap[node.ID] = node
+		if n.Namespace != "" {
+			sqlMap[n.Namespace+"."+node.ID] = node
+		}
+	}
+	return sqlMap
+}
+
 // QueryNode represents a query node.
 type QueryNode struct {
 	// ID is the id of the query node.
 	ID string
+	// Type is the element name of the query node, which is one of select, insert, update and delete.
+	Type string
+	// Line is the line number of the query node in the mapper xml, 1-based.
+	Line int
 	// Children is the children of the query node.
 	Children []Node
 }
 
 // RestoreSQL implements Node interface.
-func (n *QueryNode) RestoreSQL(w io.Writer) error {
-	for _, node := range n.Children {
-		if err := node.RestoreSQL(w); err != nil {
-			return err
-		}
+func (n *QueryNode) RestoreSQL(ctx *RestoreContext, w io.Writer) error {
+	ctx.parameterCount = 0
+	if err := restoreChildren(ctx, w, n.Children); err != nil {
+		return errors.Wrapf(err, "failed to restore %s %q", n.Type, n.ID)
 	}
 	if _, err := w.Write([]byte(";\n")); err != nil {
 		return err
 	}
 	return nil
 }
+
+// SQLNode represents a reusable SQL fragment defined by <sql>.
+type SQLNode struct {
+	ID       string
+	Children []Node
+}
+
+// RestoreSQL implements Node interface.
+func (n *SQLNode) RestoreSQL(ctx *RestoreContext, w io.Writer) error {
+	return restoreChildren(ctx, w, n.Children)
+}
+
+// IncludeNode represents an <include> referencing a SQL fragment.
+type IncludeNode struct {
+	RefID string
+}
+
+// RestoreSQL implements Node interface.
+func (n *IncludeNode) RestoreSQL(ctx *RestoreContext, w io.Writer) error {
+	sqlNode, ok := ctx.SQLMap[n.RefID]
+	if !ok {
+		return errors.Errorf("sql fragment %q not found", n.RefID)
+	}
+	for _, id := range ctx.includeStack {
+		if id == sqlNode.ID {
+			return errors.Errorf("sql fragment %q includes itself", n.RefID)
+		}
+	}
+	ctx.includeStack = append(ctx.includeStack, sqlNode.ID)
+	defer func() {
+		ctx.includeStack = ctx.includeStack[:len(ctx.includeStack)-1]
+	}()
+	return sqlNode.RestoreSQL(ctx, w)
+}
+
+// IfNode represents an <if>. The children are always restored, so the statement covers the most SQL.
+type IfNode struct {
+	Test     string
+	Children []Node
+}
+
+// RestoreSQL implements Node interface.
+func (n *IfNode) RestoreSQL(ctx *RestoreContext, w io.Writer) error {
+	return restoreChildren(ctx, w, n.Children)
+}
+
+// ChooseNode represents a <choose>. The branches are the <when> elements followed by the optional <otherwise>.
+type ChooseNode struct {
+	Branches []*WhenNode
+}
+
+// RestoreSQL implements Node interface.
+func (n *ChooseNode) RestoreSQL(ctx *RestoreContext, w io.Writer) error {
+	if len(n.Branches) == 0 {
+		return nil
+	}
+	branch := ctx.Branch
+	if branch >= len(n.Branches) {
+		branch = len(n.Branches) - 1
+	}
+	return n.Branches[branch].RestoreSQL(ctx, w)
+}
+
+// WhenNode represents a <when> or an <otherwise> in a <choose>. The test is empty for <otherwise>.
+type WhenNode struct {
+	Test     string
+	Children []Node
+}
+
+// RestoreSQL implements Node interface.
+func (n *WhenNode) RestoreSQL(ctx *RestoreContext, w io.Writer) error {
+	return restoreChildren(ctx, w, n.Children)
+}
+
+// TrimNode represents a <trim>. The <where> and <set> are parsed to the equivalent <trim>.
+type TrimNode struct {
+	Prefix          string
+	Suffix          string
+	PrefixOverrides []string
+	SuffixOverrides []string
+	Children        []Node
+}
+
+// RestoreSQL implements Node interface.
+func (n *TrimNode) RestoreSQL(ctx *RestoreContext, w io.Writer) error {
+	var sb strings.Builder
+	if err := restoreChildren(ctx, &sb, n.Children); err != nil {
+		return err
+	}
+	content := strings.TrimSpace(sb.String())
+	if content == "" {
+		return nil
+	}
+	for _, override := range n.PrefixOverrides {
+		if len(content) >= len(override) && strings.EqualFold(content[:len(override)], override) {
+			content = strings.TrimSpace(content[len(override):])
+			break
+		}
+	}
+	for _, override := range n.SuffixOverrides {
+		if len(content) >= len(override) && strings.EqualFold(content[len(content)-len(override):], override) {
+			content = strings.TrimSpace(content[:len(content)-len(override)])
+			break
+		}
+	}
+	var parts []string
+	for _, part := range []string{n.Prefix, content, n.Suffix} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	_, err := w.Write([]byte(strings.Join(parts, " ")))
+	return err
+}
+
+// ForEachNode represents a <foreach>. The children are restored once as a single iteration.
+type ForEachNode struct {
+	Collection string
+	Item       string
+	Index      string
+	Open       string
+	Close      string
+	Separator  string
+	Children   []Node
+}
+
+// RestoreSQL implements Node interface.
+func (n *ForEachNode) RestoreSQL(ctx *RestoreContext, w io.Writer) error {
+	var sb strings.Builder
+	if err := restoreChildren(ctx, &sb, n.Children); err != nil {
+		return err
+	}
+	_, err := w.Write([]byte(n.Open + strings.TrimSpace(sb.String()) + n.Close))
+	return err
+}
+
+// restoreChildren restores the children separated by a space, skipping the empty ones.
+func restoreChildren(ctx *RestoreContext, w io.Writer, children []Node) error {
+	var parts []string
+	for _, child := range children {
+		var sb strings.Builder
+		if err := child.RestoreSQL(ctx, &sb); err != nil {
+			return err
+		}
+		if part := strings.TrimSpace(sb.String()); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	_, err := w.Write([]byte(strings.Join(parts, " ")))
+	return err
+}
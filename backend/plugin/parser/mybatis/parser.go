@@ -11,6 +11,12 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/parser/mybatis/ast"
 )
 
+var (
+	// whereNodePrefixOverrides and setNodeSuffixOverrides are the overrides of <where> and <set> defined by MyBatis.
+	whereNodePrefixOverrides = []string{"AND ", "OR ", "AND\n", "OR\n", "AND\r", "OR\r", "AND\t", "OR\t"}
+	setNodeSuffixOverrides   = []string{","}
+)
+
 // Parse parses the mybatis mapper xml statement and returns the AST node.
 func Parse(stmt string) (ast.Node, error) {
 	reader := strings.NewReader(stmt)
@@ -42,6 +48,8 @@ func parseMapper(d *xml.Decoder, mapperStartElement *xml.StartElement) (*ast.Map
 	}
 
 	for {
+		// The position before reading the token is the start of the element.
+		line, _ := d.InputPos()
 		token, err := d.Token()
 		if err != nil {
 			if err == io.EOF {
@@ -56,7 +64,19 @@ func parseMapper(d *xml.Decoder, mapperStartElement *xml.StartElement) (*ast.Map
 				if err != nil {
 					return nil, errors.Wrapf(err, "failed to parse query node in mapper node %v", mapperNode)
 				}
+				queryNode.Line = line
 				mapperNode.QueryNodes = append(mapperNode.QueryNodes, queryNode)
+			case "sql":
+				sqlNode := &ast.SQLNode{ID: getAttribute(&startEle, "id")}
+				if sqlNode.Children, err = parseChildren(d, &startEle); err != nil {
+					return nil, errors.Wrapf(err, "failed to parse sql node %q", sqlNode.ID)
+				}
+				mapperNode.SQLNodes = append(mapperNode.SQLNodes, sqlNode)
+			default:
+				// Skip the elements irrelevant to the SQL statements, such as <resultMap> and <cache>.
+				if err := d.Skip(); err != nil {
+					return nil, errors.Wrapf(err, "failed to skip element <%s>", startEle.Name.Local)
+				}
 			}
 		}
 		if endEle, ok := token.(xml.EndElement); ok && endEle.Name.Local == mapperStartElement.Name.Local {
@@ -66,32 +86,171 @@ func parseMapper(d *xml.Decoder, mapperStartElement *xml.StartElement) (*ast.Map
 }
 
 // parseQuery assumes that <select>, <update>, <insert>, <delete> start element has been consumed, and will consume all tokens until </select>, </update>, </insert>, </delete> end element.
-func parseQuery(d *xml.Decoder, mapperStartElement *xml.StartElement) (*ast.QueryNode, error) {
-	queryNode := &ast.QueryNode{}
-	for _, attr := range mapperStartElement.Attr {
-		if attr.Name.Local == "id" {
-			queryNode.ID = attr.Value
-		}
+func parseQuery(d *xml.Decoder, queryStartElement *xml.StartElement) (*ast.QueryNode, error) {
+	queryNode := &ast.QueryNode{
+		ID:   getAttribute(queryStartElement, "id"),
+		Type: queryStartElement.Name.Local,
+	}
+	children, err := parseChildren(d, queryStartElement)
+	if err != nil {
+		return nil, err
 	}
+	queryNode.Children = children
+	return queryNode, nil
+}
 
+// parseChildren assumes that the start element has been consumed, and will consume all tokens until the end element.
+func parseChildren(d *xml.Decoder, startElement *xml.StartElement) ([]ast.Node, error) {
+	var children []ast.Node
 	for {
 		token, err := d.Token()
 		if err != nil {
 			if err == io.EOF {
-				return nil, errors.New("expected read </select>, </update>, </insert>, </delete> end element, but got EOF")
+				return nil, errors.Errorf("expected read </%s> end element, but got EOF", startElement.Name.Local)
 			}
 			return nil, errors.Wrapf(err, "failed to get token from xml decoder")
 		}
-		if charDataEle, ok := token.(xml.CharData); ok {
+		switch ele := token.(type) {
+		case xml.CharData:
 			// Trim the leading and trailing space.
-			trimCharData := strings.TrimSpace(string(charDataEle))
-			textNode := &ast.TextNode{
-				Text: trimCharData,
+			children = append(children, &ast.TextNode{
+				Text: strings.TrimSpace(string(ele)),
+			})
+		case xml.StartElement:
+			node, err := parseDynamicElement(d, &ele)
+			if err != nil {
+				return nil, err
+			}
+			if node != nil {
+				children = append(children, node)
+			}
+		case xml.EndElement:
+			if ele.Name.Local == startElement.Name.Local {
+				return children, nil
 			}
-			queryNode.Children = append(queryNode.Children, textNode)
 		}
-		if endEle, ok := token.(xml.EndElement); ok && endEle.Name.Local == mapperStartElement.Name.Local {
-			return queryNode, nil
+	}
+}
+
+// parseDynamicElement assumes that the start element has been consumed, and will consume all tokens until the end element.
+// It returns nil for the elements producing no SQL, such as <bind> and <selectKey>.
+func parseDynamicElement(d *xml.Decoder, startElement *xml.StartElement) (ast.Node, error) {
+	switch startElement.Name.Local {
+	case "if":
+		children, err := parseChildren(d, startElement)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.IfNode{Test: getAttribute(startElement, "test"), Children: children}, nil
+	case "choose":
+		return parseChoose(d, startElement)
+	case "where":
+		children, err := parseChildren(d, startElement)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.TrimNode{Prefix: "WHERE", PrefixOverrides: whereNodePrefixOverrides, Children: children}, nil
+	case "set":
+		children, err := parseChildren(d, startElement)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.TrimNode{Prefix: "SET", SuffixOverrides: setNodeSuffixOverrides, Children: children}, nil
+	case "trim":
+		children, err := parseChildren(d, startElement)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.TrimNode{
+			Prefix:          getAttribute(startElement, "prefix"),
+			Suffix:          getAttribute(startElement, "suffix"),
+			PrefixOverrides: splitOverrides(getAttribute(startElement, "prefixOverrides")),
+			SuffixOverrides: splitOverrides(getAttribute(startElement, "suffixOverrides")),
+			Children:        children,
+		}, nil
+	case "foreach":
+		children, err := parseChildren(d, startElement)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.ForEachNode{
+			Collection: getAttribute(startElement, "collection"),
+			Item:       getAttribute(startElement, "item"),
+			Index:      getAttribute(startElement, "index"),
+			Open:       getAttribute(startElement, "open"),
+			Close:      getAttribute(startElement, "close"),
+			Separator:  getAttribute(startElement, "separator"),
+			Children:   children,
+		}, nil
+	case "include":
+		if err := d.Skip(); err != nil {
+			return nil, errors.Wrapf(err, "failed to skip element <include>")
+		}
+		return &ast.IncludeNode{RefID: getAttribute(startElement, "refid")}, nil
+	case "bind", "selectKey":
+		if err := d.Skip(); err != nil {
+			return nil, errors.Wrapf(err, "failed to skip element <%s>", startElement.Name.Local)
 		}
+		return nil, nil
+	default:
+		return nil, errors.Errorf("unsupported element <%s>", startElement.Name.Local)
+	}
+}
+
+// parseChoose assumes that <choose> start element has been consumed, and will consume all tokens until </choose> end element.
+func parseChoose(d *xml.Decoder, chooseStartElement *xml.StartElement) (*ast.ChooseNode, error) {
+	chooseNode := &ast.ChooseNode{}
+	var otherwiseNode *ast.WhenNode
+	for {
+		token, err := d.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, errors.New("expected read </choose> end element, but got EOF")
+			}
+			return nil, errors.Wrapf(err, "failed to get token from xml decoder")
+		}
+		switch ele := token.(type) {
+		case xml.StartElement:
+			switch ele.Name.Local {
+			case "when", "otherwise":
+				children, err := parseChildren(d, &ele)
+				if err != nil {
+					return nil, err
+				}
+				whenNode := &ast.WhenNode{Test: getAttribute(&ele, "test"), Children: children}
+				if ele.Name.Local == "otherwise" {
+					otherwiseNode = whenNode
+				} else {
+					chooseNode.Branches = append(chooseNode.Branches, whenNode)
+				}
+			default:
+				return nil, errors.Errorf("unexpected element <%s> in <choose>", ele.Name.Local)
+			}
+		case xml.EndElement:
+			if ele.Name.Local == chooseStartElement.Name.Local {
+				// The <otherwise> is always the last branch.
+				if otherwiseNode != nil {
+					chooseNode.Branches = append(chooseNode.Branches, otherwiseNode)
+				}
+				return chooseNode, nil
+			}
+		}
+	}
+}
+
+func getAttribute(startElement *xml.StartElement, name string) string {
+	for _, attr := range startElement.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// splitOverrides splits the prefixOverrides and suffixOverrides attributes of <trim>, which are separated by "|".
+func splitOverrides(overrides string) []string {
+	if overrides == "" {
+		return nil
 	}
+	return strings.Split(overrides, "|")
 }
@@ -11,6 +11,8 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/mybatis/ast"
 )
 
 // TestData is the test data for mybatis parser. It contains the xml and the expected sql.
@@ -39,7 +41,7 @@ func runTest(t *testing.T, filepath string, record bool) {
 		assert.NotNil(t, node)
 
 		var stringsBuilder strings.Builder
-		err = node.RestoreSQL(&stringsBuilder)
+		err = node.RestoreSQL(&ast.RestoreContext{}, &stringsBuilder)
 		assert.NoError(t, err)
 		if record {
 			testCases[i].SQL = stringsBuilder.String()
@@ -61,8 +63,9 @@ func runTest(t *testing.T, filepath string, record bool) {
 func TestParser(t *testing.T) {
 	testFileList := []string{
 		"test-data/test_simple_mapper.yaml",
+		"test-data/test_dynamic_mapper.yaml",
 	}
 	for _, filepath := range testFileList {
-		runTest(t, filepath, true)
+		runTest(t, filepath, false)
 	}
 }
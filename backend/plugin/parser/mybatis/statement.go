@@ -0,0 +1,92 @@
+package mybatis
+
+import (
+	"strings"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/mybatis/ast"
+)
+
+// maxStatementsPerQuery is the maximum number of the representative statements expanded from a query.
+const maxStatementsPerQuery = 16
+
+// Statement is a representative SQL statement expanded from a query in the mapper xml.
+type Statement struct {
+	// QueryID is the id of the query.
+	QueryID string
+	// Line is the line number of the query in the mapper xml, 1-based.
+	Line int
+	// Text is the SQL statement ending with a semicolon.
+	Text string
+}
+
+// ExpandStatements expands the dynamic SQL of the queries in the mapper into representative statements.
+// The <if> elements are always included, and each branch of the <choose> elements is restored in a separate statement,
+// so every SQL fragment in the mapper is covered by at least one statement. The parameters are replaced by the placeholders.
+func ExpandStatements(mapper *ast.MapperNode, placeholder func(n int) string) ([]*Statement, error) {
+	sqlMap := mapper.SQLMap()
+	var statements []*Statement
+	for _, query := range mapper.QueryNodes {
+		branches := countBranches(query.Children, sqlMap, 0)
+		if branches > maxStatementsPerQuery {
+			branches = maxStatementsPerQuery
+		}
+		seen := make(map[string]bool)
+		for branch := 0; branch < branches; branch++ {
+			ctx := &ast.RestoreContext{
+				SQLMap:      sqlMap,
+				Branch:      branch,
+				Placeholder: placeholder,
+			}
+			var sb strings.Builder
+			if err := query.RestoreSQL(ctx, &sb); err != nil {
+				return nil, err
+			}
+			text := sb.String()
+			if seen[text] {
+				continue
+			}
+			seen[text] = true
+			statements = append(statements, &Statement{
+				QueryID: query.ID,
+				Line:    query.Line,
+				Text:    text,
+			})
+		}
+	}
+	return statements, nil
+}
+
+// countBranches returns the number of the statements needed to cover all branches of the <choose> elements in the nodes.
+func countBranches(nodes []ast.Node, sqlMap map[string]*ast.SQLNode, depth int) int {
+	// Guard against the fragments including each other, which fail to restore anyway.
+	if depth > len(sqlMap)+1 {
+		return 1
+	}
+	branches := 1
+	for _, node := range nodes {
+		n := 1
+		switch node := node.(type) {
+		case *ast.ChooseNode:
+			n = len(node.Branches)
+			for _, branch := range node.Branches {
+				if b := countBranches(branch.Children, sqlMap, depth); b > n {
+					n = b
+				}
+			}
+		case *ast.IfNode:
+			n = countBranches(node.Children, sqlMap, depth)
+		case *ast.TrimNode:
+			n = countBranches(node.Children, sqlMap, depth)
+		case *ast.ForEachNode:
+			n = countBranches(node.Children, sqlMap, depth)
+		case *ast.IncludeNode:
+			if sqlNode, ok := sqlMap[node.RefID]; ok {
+				n = countBranches(sqlNode.Children, sqlMap, depth+1)
+			}
+		}
+		if n > branches {
+			branches = n
+		}
+	}
+	return branches
+}
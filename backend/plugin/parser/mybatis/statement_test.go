@@ -0,0 +1,57 @@
+package mybatis
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/mybatis/ast"
+)
+
+func TestExpandStatements(t *testing.T) {
+	xml := `<?xml version="1.0" encoding="UTF-8" ?>
+<mapper namespace="com.bytebase.test.UserMapper">
+    <sql id="byStatus">
+        <choose>
+            <when test="status == 1">and status = 'active'</when>
+            <otherwise>and status = #{status}</otherwise>
+        </choose>
+    </sql>
+    <select id="selectUser" resultType="hashmap">
+        select * from user
+        <where>
+            <choose>
+                <when test="id != null">id = #{id}</when>
+                <when test="name != null">name = #{name}</when>
+                <otherwise>deleted = false</otherwise>
+            </choose>
+            <include refid="com.bytebase.test.UserMapper.byStatus"/>
+        </where>
+    </select>
+
+    <delete id="deleteUser">
+        delete from user where id = #{id}
+    </delete>
+</mapper>`
+	node, err := Parse(xml)
+	require.NoError(t, err)
+	mapper, ok := node.(*ast.MapperNode)
+	require.True(t, ok)
+
+	statements, err := ExpandStatements(mapper, func(n int) string {
+		return fmt.Sprintf("$%d", n)
+	})
+	require.NoError(t, err)
+	want := []*Statement{
+		{QueryID: "selectUser", Line: 9, Text: "select * from user WHERE id = $1 and status = 'active';\n"},
+		{QueryID: "selectUser", Line: 9, Text: "select * from user WHERE name = $1 and status = $2;\n"},
+		{QueryID: "selectUser", Line: 9, Text: "select * from user WHERE deleted = false and status = $1;\n"},
+		{QueryID: "deleteUser", Line: 21, Text: "delete from user where id = $1;\n"},
+	}
+	require.Equal(t, want, statements)
+
+	mapper.SQLNodes = nil
+	_, err = ExpandStatements(mapper, nil)
+	require.ErrorContains(t, err, `sql fragment "com.bytebase.test.UserMapper.byStatus" not found`)
+}
@@ -0,0 +1,470 @@
+// Package standard provides the schema differ plugin for Oracle and SQL Server.
+//
+// There is no full parser for these engines yet, so the differ parses the subset of
+// DDL used by the schema dumps: CREATE TABLE, CREATE INDEX, CREATE VIEW, CREATE SEQUENCE
+// and ALTER TABLE ... ADD CONSTRAINT.
+package standard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/differ"
+)
+
+var (
+	_ differ.SchemaDiffer = (*SchemaDiffer)(nil)
+)
+
+func init() {
+	differ.Register(parser.Oracle, NewSchemaDiffer(parser.Oracle))
+	differ.Register(parser.MSSQL, NewSchemaDiffer(parser.MSSQL))
+}
+
+// SchemaDiffer is the schema differ for Oracle and SQL Server.
+type SchemaDiffer struct {
+	d dialect
+}
+
+// NewSchemaDiffer creates a schema differ for the engine.
+func NewSchemaDiffer(engine parser.EngineType) *SchemaDiffer {
+	return &SchemaDiffer{d: dialect{engine: engine}}
+}
+
+// diffNode defines different modification types as the safe change order.
+// The safe change order means we can change them with no dependency conflicts as this order.
+type diffNode struct {
+	d dialect
+
+	// Drop nodes
+	dropViewList       []string
+	dropForeignKeyList []string
+	dropConstraintList []string
+	dropIndexList      []string
+	dropDefaultList    []string
+	dropColumnList     []string
+	dropTableList      []string
+	dropSequenceList   []string
+
+	// Create nodes
+	createSequenceList        []string
+	alterSequenceList         []string
+	createTableList           []string
+	addColumnList             []string
+	alterColumnList           []string
+	addDefaultList            []string
+	addConstraintExceptFkList []string
+	createIndexList           []string
+	addForeignKeyList         []string
+	createOrReplaceViewList   []string
+}
+
+// SchemaDiff returns the schema diff between old and new statements.
+// The objects are matched by name, and the objects only in the old schema are dropped.
+func (differ *SchemaDiffer) SchemaDiff(oldStmt, newStmt string) (string, error) {
+	oldSchema, err := differ.d.parseSchema(oldStmt)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse old schema")
+	}
+	newSchema, err := differ.d.parseSchema(newStmt)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse new schema")
+	}
+
+	diff := &diffNode{d: differ.d}
+	diff.diffSequences(oldSchema, newSchema)
+	if err := diff.diffTables(oldSchema, newSchema); err != nil {
+		return "", err
+	}
+	diff.diffIndexes(oldSchema, newSchema)
+	diff.diffViews(oldSchema, newSchema)
+	return diff.deparse(), nil
+}
+
+func (diff *diffNode) diffSequences(oldSchema, newSchema *schema) {
+	for _, newSequence := range newSchema.sequences {
+		oldSequence, ok := oldSchema.sequenceMap[diff.d.objectKey(newSequence.name)]
+		if !ok {
+			diff.createSequenceList = append(diff.createSequenceList, newSequence.text)
+			continue
+		}
+		if stmt := diff.alterSequence(oldSequence, newSequence); stmt != "" {
+			diff.alterSequenceList = append(diff.alterSequenceList, stmt)
+		}
+	}
+	for _, oldSequence := range oldSchema.sequences {
+		if _, ok := newSchema.sequenceMap[diff.d.objectKey(oldSequence.name)]; !ok {
+			diff.dropSequenceList = append(diff.dropSequenceList, fmt.Sprintf("DROP SEQUENCE %s", diff.d.quoteObject(oldSequence.name)))
+		}
+	}
+}
+
+// alterSequence returns the ALTER SEQUENCE statement for the options specified in the new sequence
+// and different from the old one. The options not specified are left as they are, and START WITH
+// and the data type are ignored because they cannot be altered.
+func (diff *diffNode) alterSequence(oldSequence, newSequence *sequence) string {
+	oldOptions := make(map[string]string)
+	for _, option := range oldSequence.options {
+		oldOptions[option.name] = option.value
+	}
+	var options []string
+	for _, option := range newSequence.options {
+		if option.name == "START" || option.name == "AS" {
+			continue
+		}
+		if value, ok := oldOptions[option.name]; !ok || value != option.value {
+			options = append(options, option.text)
+		}
+	}
+	if len(options) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("ALTER SEQUENCE %s %s", diff.d.quoteObject(oldSequence.name), strings.Join(options, " "))
+}
+
+func (diff *diffNode) diffTables(oldSchema, newSchema *schema) error {
+	for _, newTable := range newSchema.tables {
+		oldTable, ok := oldSchema.tableMap[diff.d.objectKey(newTable.name)]
+		if !ok {
+			diff.createTable(newTable)
+			continue
+		}
+		if err := diff.diffColumns(oldTable, newTable); err != nil {
+			return err
+		}
+		if err := diff.diffConstraints(oldTable, newTable); err != nil {
+			return err
+		}
+	}
+	for _, oldTable := range oldSchema.tables {
+		if _, ok := newSchema.tableMap[diff.d.objectKey(oldTable.name)]; ok {
+			continue
+		}
+		// Drop the foreign keys first, so that the tables can be dropped in any order.
+		for _, c := range oldTable.constraints {
+			if c.tp != foreignKey || c.name == "" {
+				continue
+			}
+			diff.dropForeignKeyList = append(diff.dropForeignKeyList, diff.dropConstraintStmt(oldTable.name, c.name))
+		}
+		diff.dropTableList = append(diff.dropTableList, fmt.Sprintf("DROP TABLE %s", diff.d.quoteObject(oldTable.name)))
+	}
+	return nil
+}
+
+func (diff *diffNode) createTable(t *table) {
+	diff.createTableList = append(diff.createTableList, t.text)
+	for _, c := range t.constraints {
+		if !c.inline {
+			diff.addConstraint(t.name, c)
+		}
+	}
+	for _, col := range t.columns {
+		if col.defaultExpr != "" && !col.defaultInline {
+			diff.addDefaultList = append(diff.addDefaultList, diff.addDefaultStmt(t.name, col))
+		}
+	}
+}
+
+func (diff *diffNode) diffColumns(oldTable, newTable *table) error {
+	for _, newColumn := range newTable.columns {
+		oldColumn, ok := oldTable.columnMap[diff.d.key(newColumn.name)]
+		if !ok {
+			diff.addColumnList = append(diff.addColumnList, diff.addColumnStmt(oldTable.name, newColumn))
+			continue
+		}
+		if err := diff.modifyColumn(oldTable.name, oldColumn, newColumn); err != nil {
+			return err
+		}
+	}
+	for _, oldColumn := range oldTable.columns {
+		if _, ok := newTable.columnMap[diff.d.key(oldColumn.name)]; ok {
+			continue
+		}
+		// SQL Server does not drop the column with a default constraint.
+		if diff.d.engine == parser.MSSQL && oldColumn.defaultExpr != "" {
+			if err := diff.dropDefault(oldTable.name, oldColumn); err != nil {
+				return err
+			}
+		}
+		diff.dropColumnList = append(diff.dropColumnList, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", diff.d.quoteObject(oldTable.name), diff.d.quote(oldColumn.name)))
+	}
+	return nil
+}
+
+func (diff *diffNode) modifyColumn(tableName objectName, oldColumn, newColumn *column) error {
+	if oldColumn.generatedKey != newColumn.generatedKey {
+		return errors.Errorf("cannot change the identity or computed definition of column %s.%s", diff.d.quoteObject(tableName), diff.d.quote(newColumn.name))
+	}
+	typeChanged := oldColumn.tpKey != newColumn.tpKey
+	nullableChanged := oldColumn.nullable != newColumn.nullable
+	defaultChanged := oldColumn.defaultKey != newColumn.defaultKey
+
+	if diff.d.engine == parser.MSSQL {
+		if typeChanged || nullableChanged {
+			nullable := "NULL"
+			if !newColumn.nullable {
+				nullable = "NOT NULL"
+			}
+			diff.alterColumnList = append(diff.alterColumnList, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s %s", diff.d.quoteObject(tableName), diff.d.quote(newColumn.name), newColumn.tp, nullable))
+		}
+		if defaultChanged {
+			if oldColumn.defaultExpr != "" {
+				if err := diff.dropDefault(tableName, oldColumn); err != nil {
+					return err
+				}
+			}
+			if newColumn.defaultExpr != "" {
+				diff.addDefaultList = append(diff.addDefaultList, diff.addDefaultStmt(tableName, newColumn))
+			}
+		}
+		return nil
+	}
+
+	// Oracle fails to modify a column to NOT NULL if it's already NOT NULL, so only the changes are modified.
+	var changes []string
+	if typeChanged {
+		changes = append(changes, newColumn.tp)
+	}
+	if defaultChanged {
+		if newColumn.defaultExpr == "" {
+			changes = append(changes, "DEFAULT NULL")
+		} else {
+			changes = append(changes, "DEFAULT "+newColumn.defaultExpr)
+		}
+	}
+	if nullableChanged {
+		if newColumn.nullable {
+			changes = append(changes, "NULL")
+		} else {
+			changes = append(changes, "NOT NULL")
+		}
+	}
+	if len(changes) > 0 {
+		diff.alterColumnList = append(diff.alterColumnList, fmt.Sprintf("ALTER TABLE %s MODIFY (%s %s)", diff.d.quoteObject(tableName), diff.d.quote(newColumn.name), strings.Join(changes, " ")))
+	}
+	return nil
+}
+
+func (diff *diffNode) dropDefault(tableName objectName, col *column) error {
+	if col.defaultName == "" {
+		return errors.Errorf("cannot drop the unnamed default constraint of column %s.%s", diff.d.quoteObject(tableName), diff.d.quote(col.name))
+	}
+	diff.dropDefaultList = append(diff.dropDefaultList, diff.dropConstraintStmt(tableName, col.defaultName))
+	return nil
+}
+
+func (diff *diffNode) addDefaultStmt(tableName objectName, col *column) string {
+	var name string
+	if col.defaultName != "" {
+		name = fmt.Sprintf("CONSTRAINT %s ", diff.d.quote(col.defaultName))
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD %sDEFAULT %s FOR %s", diff.d.quoteObject(tableName), name, col.defaultExpr, diff.d.quote(col.name))
+}
+
+func (diff *diffNode) addColumnStmt(tableName objectName, col *column) string {
+	parts := []string{diff.d.quote(col.name)}
+	if col.tp != "" {
+		parts = append(parts, col.tp)
+	}
+	if col.generated != "" {
+		parts = append(parts, col.generated)
+	}
+	if col.defaultExpr != "" {
+		if col.defaultName != "" && diff.d.engine == parser.MSSQL {
+			parts = append(parts, "CONSTRAINT "+diff.d.quote(col.defaultName))
+		}
+		parts = append(parts, "DEFAULT "+col.defaultExpr)
+	}
+	if !col.nullable {
+		parts = append(parts, "NOT NULL")
+	}
+	if diff.d.engine == parser.Oracle {
+		return fmt.Sprintf("ALTER TABLE %s ADD (%s)", diff.d.quoteObject(tableName), strings.Join(parts, " "))
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD %s", diff.d.quoteObject(tableName), strings.Join(parts, " "))
+}
+
+// diffConstraints matches the constraints by name, and the unnamed constraints by definition,
+// because the databases generate the names of the unnamed constraints.
+func (diff *diffNode) diffConstraints(oldTable, newTable *table) error {
+	matched := make(map[*constraint]*constraint)
+	matchedOld := make(map[*constraint]bool)
+	for _, newConstraint := range newTable.constraints {
+		if newConstraint.name == "" {
+			continue
+		}
+		for _, oldConstraint := range oldTable.constraints {
+			if !matchedOld[oldConstraint] && diff.d.key(oldConstraint.name) == diff.d.key(newConstraint.name) {
+				matched[newConstraint] = oldConstraint
+				matchedOld[oldConstraint] = true
+				break
+			}
+		}
+	}
+	for _, newConstraint := range newTable.constraints {
+		if _, ok := matched[newConstraint]; ok {
+			continue
+		}
+		for _, oldConstraint := range oldTable.constraints {
+			if matchedOld[oldConstraint] || oldConstraint.key != newConstraint.key {
+				continue
+			}
+			if newConstraint.name != "" && oldConstraint.name != "" {
+				continue
+			}
+			matched[newConstraint] = oldConstraint
+			matchedOld[oldConstraint] = true
+			break
+		}
+	}
+
+	for _, oldConstraint := range oldTable.constraints {
+		if !matchedOld[oldConstraint] {
+			if err := diff.dropConstraint(oldTable.name, oldConstraint); err != nil {
+				return err
+			}
+		}
+	}
+	for _, newConstraint := range newTable.constraints {
+		oldConstraint, ok := matched[newConstraint]
+		if ok && oldConstraint.key == newConstraint.key {
+			continue
+		}
+		if ok {
+			if err := diff.dropConstraint(oldTable.name, oldConstraint); err != nil {
+				return err
+			}
+		}
+		diff.addConstraint(oldTable.name, newConstraint)
+	}
+	return nil
+}
+
+func (diff *diffNode) dropConstraintStmt(tableName objectName, name string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", diff.d.quoteObject(tableName), diff.d.quote(name))
+}
+
+func (diff *diffNode) dropConstraint(tableName objectName, c *constraint) error {
+	var stmt string
+	switch {
+	case c.name != "":
+		stmt = diff.dropConstraintStmt(tableName, c.name)
+	case diff.d.engine == parser.Oracle && c.tp == primaryKey:
+		stmt = fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY", diff.d.quoteObject(tableName))
+	case diff.d.engine == parser.Oracle && c.tp == uniqueKey:
+		stmt = fmt.Sprintf("ALTER TABLE %s DROP %s", diff.d.quoteObject(tableName), c.definition)
+	default:
+		return errors.Errorf("cannot drop the unnamed constraint %q of table %s", c.definition, diff.d.quoteObject(tableName))
+	}
+	if c.tp == foreignKey {
+		diff.dropForeignKeyList = append(diff.dropForeignKeyList, stmt)
+	} else {
+		diff.dropConstraintList = append(diff.dropConstraintList, stmt)
+	}
+	return nil
+}
+
+func (diff *diffNode) addConstraint(tableName objectName, c *constraint) {
+	var name string
+	if c.name != "" {
+		name = fmt.Sprintf("CONSTRAINT %s ", diff.d.quote(c.name))
+	}
+	stmt := fmt.Sprintf("ALTER TABLE %s ADD %s%s", diff.d.quoteObject(tableName), name, c.definition)
+	if c.tp == foreignKey {
+		diff.addForeignKeyList = append(diff.addForeignKeyList, stmt)
+	} else {
+		diff.addConstraintExceptFkList = append(diff.addConstraintExceptFkList, stmt)
+	}
+}
+
+func (diff *diffNode) diffIndexes(oldSchema, newSchema *schema) {
+	for _, newIndex := range newSchema.indexes {
+		oldIndex, ok := oldSchema.indexMap[diff.d.objectKey(newIndex.table)+"."+diff.d.key(newIndex.name.name)]
+		if ok && oldIndex.key == newIndex.key {
+			continue
+		}
+		if ok {
+			diff.dropIndex(oldIndex)
+		}
+		diff.createIndexList = append(diff.createIndexList, newIndex.text)
+	}
+	for _, oldIndex := range oldSchema.indexes {
+		if _, ok := newSchema.tableMap[diff.d.objectKey(oldIndex.table)]; !ok {
+			// The index is dropped with the table.
+			continue
+		}
+		if _, ok := newSchema.indexMap[diff.d.objectKey(oldIndex.table)+"."+diff.d.key(oldIndex.name.name)]; !ok {
+			diff.dropIndex(oldIndex)
+		}
+	}
+}
+
+func (diff *diffNode) dropIndex(idx *index) {
+	if diff.d.engine == parser.MSSQL {
+		diff.dropIndexList = append(diff.dropIndexList, fmt.Sprintf("DROP INDEX %s ON %s", diff.d.quote(idx.name.name), diff.d.quoteObject(idx.table)))
+		return
+	}
+	name := idx.name
+	if name.schema == "" {
+		name.schema = idx.table.schema
+	}
+	diff.dropIndexList = append(diff.dropIndexList, fmt.Sprintf("DROP INDEX %s", diff.d.quoteObject(name)))
+}
+
+func (diff *diffNode) diffViews(oldSchema, newSchema *schema) {
+	for _, newView := range newSchema.views {
+		oldView, ok := oldSchema.viewMap[diff.d.objectKey(newView.name)]
+		switch {
+		case !ok:
+			diff.createOrReplaceViewList = append(diff.createOrReplaceViewList, fmt.Sprintf("CREATE VIEW %s %s", diff.d.quoteObject(newView.name), newView.body))
+		case oldView.key != newView.key:
+			if diff.d.engine == parser.MSSQL {
+				diff.createOrReplaceViewList = append(diff.createOrReplaceViewList, fmt.Sprintf("ALTER VIEW %s %s", diff.d.quoteObject(oldView.name), newView.body))
+			} else {
+				diff.createOrReplaceViewList = append(diff.createOrReplaceViewList, fmt.Sprintf("CREATE OR REPLACE VIEW %s %s", diff.d.quoteObject(oldView.name), newView.body))
+			}
+		}
+	}
+	for _, oldView := range oldSchema.views {
+		if _, ok := newSchema.viewMap[diff.d.objectKey(oldView.name)]; !ok {
+			diff.dropViewList = append(diff.dropViewList, fmt.Sprintf("DROP VIEW %s", diff.d.quoteObject(oldView.name)))
+		}
+	}
+}
+
+// deparse statements as the safe change order.
+func (diff *diffNode) deparse() string {
+	var buf strings.Builder
+	for _, list := range [][]string{
+		diff.dropViewList,
+		diff.dropForeignKeyList,
+		diff.dropConstraintList,
+		diff.dropIndexList,
+		diff.dropDefaultList,
+		diff.dropColumnList,
+		diff.dropTableList,
+		diff.dropSequenceList,
+
+		diff.createSequenceList,
+		diff.alterSequenceList,
+		diff.createTableList,
+		diff.addColumnList,
+		diff.alterColumnList,
+		diff.addDefaultList,
+		diff.addConstraintExceptFkList,
+		diff.createIndexList,
+		diff.addForeignKeyList,
+		diff.createOrReplaceViewList,
+	} {
+		for _, stmt := range list {
+			buf.WriteString(stmt)
+			buf.WriteString(";\n\n")
+		}
+	}
+	return buf.String()
+}
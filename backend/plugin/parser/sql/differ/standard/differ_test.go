@@ -0,0 +1,66 @@
+package standard
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+)
+
+type DifferTestData struct {
+	OldSchema string `yaml:"oldSchema"`
+	NewSchema string `yaml:"newSchema"`
+	Diff      string `yaml:"diff"`
+}
+
+func runDifferTest(t *testing.T, engine parser.EngineType, file string, record bool) {
+	differ := NewSchemaDiffer(engine)
+
+	var tests []DifferTestData
+	filepath := filepath.Join("test-data", file)
+	yamlFile, err := os.Open(filepath)
+	require.NoError(t, err)
+	defer yamlFile.Close()
+
+	byteValue, err := io.ReadAll(yamlFile)
+	require.NoError(t, err)
+	err = yaml.Unmarshal(byteValue, &tests)
+	require.NoError(t, err)
+
+	for i, test := range tests {
+		diff, err := differ.SchemaDiff(test.OldSchema, test.NewSchema)
+		require.NoError(t, err)
+		if record {
+			tests[i].Diff = diff
+		} else {
+			require.Equal(t, test.Diff, diff, test.OldSchema)
+		}
+	}
+
+	if record {
+		err := yamlFile.Close()
+		require.NoError(t, err)
+		byteValue, err = yaml.Marshal(tests)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath, byteValue, 0644)
+		require.NoError(t, err)
+	}
+}
+
+func TestComputeDiff(t *testing.T) {
+	tests := []struct {
+		engine parser.EngineType
+		file   string
+	}{
+		{engine: parser.Oracle, file: "test_differ_oracle.yaml"},
+		{engine: parser.MSSQL, file: "test_differ_mssql.yaml"},
+	}
+	for _, test := range tests {
+		runDifferTest(t, test.engine, test.file, false /* record */)
+	}
+}
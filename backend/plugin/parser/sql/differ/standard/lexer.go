@@ -0,0 +1,193 @@
+package standard
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+type tokenType int
+
+const (
+	// tokenWord is an unquoted identifier or a keyword.
+	tokenWord tokenType = iota
+	// tokenQuoted is a quoted identifier, such as "a" or [a].
+	tokenQuoted
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type token struct {
+	tp tokenType
+	// text is the raw text of the token.
+	text string
+	// value is the identifier of the quoted identifier without the quotes.
+	value string
+	// start and end are the byte offsets of the token in the statement.
+	start int
+	end   int
+}
+
+// is returns whether the token is one of the keywords.
+func (t token) is(keywords ...string) bool {
+	if t.tp != tokenWord {
+		return false
+	}
+	for _, keyword := range keywords {
+		if strings.EqualFold(t.text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t token) isSymbol(symbol string) bool {
+	return t.tp == tokenSymbol && t.text == symbol
+}
+
+// tokenize splits the statement into tokens, skipping the whitespaces and comments.
+func tokenize(statement string) ([]token, error) {
+	var tokens []token
+	s := []rune(statement)
+	// offsets maps the rune index to the byte offset.
+	offsets := make([]int, len(s)+1)
+	offset := 0
+	for i, r := range s {
+		offsets[i] = offset
+		offset += len(string(r))
+	}
+	offsets[len(s)] = offset
+
+	for i := 0; i < len(s); {
+		r := s[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(s) && s[i+1] == '-':
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(s) && s[i+1] == '*':
+			j := i + 2
+			for j+1 < len(s) && (s[j] != '*' || s[j+1] != '/') {
+				j++
+			}
+			if j+1 >= len(s) {
+				return nil, errors.Errorf("unterminated comment at offset %d", offsets[i])
+			}
+			i = j + 2
+		case r == '\'' || ((r == 'N' || r == 'n') && i+1 < len(s) && s[i+1] == '\''):
+			start := i
+			if r != '\'' {
+				i++
+			}
+			j, err := scanQuoted(s, i, '\'')
+			if err != nil {
+				return nil, errors.Wrapf(err, "unterminated string at offset %d", offsets[start])
+			}
+			tokens = append(tokens, token{tp: tokenString, text: string(s[start:j]), start: offsets[start], end: offsets[j]})
+			i = j
+		case r == '"' || r == '[':
+			closing := '"'
+			if r == '[' {
+				closing = ']'
+			}
+			j, err := scanQuoted(s, i, closing)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unterminated identifier at offset %d", offsets[i])
+			}
+			text := string(s[i:j])
+			value := text[1 : len(text)-1]
+			value = strings.ReplaceAll(value, string([]rune{closing, closing}), string(closing))
+			tokens = append(tokens, token{tp: tokenQuoted, text: text, value: value, start: offsets[i], end: offsets[j]})
+			i = j
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(s) && (unicode.IsDigit(s[j]) || s[j] == '.') {
+				j++
+			}
+			if j < len(s) && (s[j] == 'e' || s[j] == 'E') {
+				j++
+				if j < len(s) && (s[j] == '+' || s[j] == '-') {
+					j++
+				}
+				for j < len(s) && unicode.IsDigit(s[j]) {
+					j++
+				}
+			}
+			tokens = append(tokens, token{tp: tokenNumber, text: string(s[i:j]), start: offsets[i], end: offsets[j]})
+			i = j
+		case isWordRune(r):
+			j := i
+			for j < len(s) && (isWordRune(s[j]) || unicode.IsDigit(s[j])) {
+				j++
+			}
+			tokens = append(tokens, token{tp: tokenWord, text: string(s[i:j]), start: offsets[i], end: offsets[j]})
+			i = j
+		default:
+			tokens = append(tokens, token{tp: tokenSymbol, text: string(r), start: offsets[i], end: offsets[i+1]})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// scanQuoted returns the index after the closing quote of the quoted text starting at i.
+// The closing quote is escaped by doubling it.
+func scanQuoted(s []rune, i int, closing rune) (int, error) {
+	for j := i + 1; j < len(s); j++ {
+		if s[j] != closing {
+			continue
+		}
+		if j+1 < len(s) && s[j+1] == closing {
+			j++
+			continue
+		}
+		return j + 1, nil
+	}
+	return 0, errors.New("missing the closing quote")
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || r == '_' || r == '$' || r == '#' || r == '@'
+}
+
+// matchParen returns the index of the parenthesis closing the one at i.
+func matchParen(tokens []token, i int) (int, error) {
+	depth := 0
+	for j := i; j < len(tokens); j++ {
+		switch {
+		case tokens[j].isSymbol("("):
+			depth++
+		case tokens[j].isSymbol(")"):
+			depth--
+			if depth == 0 {
+				return j, nil
+			}
+		}
+	}
+	return 0, errors.New("unbalanced parentheses")
+}
+
+// splitByComma splits the tokens by the commas outside the parentheses.
+func splitByComma(tokens []token) [][]token {
+	var result [][]token
+	depth, start := 0, 0
+	for i, t := range tokens {
+		switch {
+		case t.isSymbol("("):
+			depth++
+		case t.isSymbol(")"):
+			depth--
+		case t.isSymbol(",") && depth == 0:
+			result = append(result, tokens[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		result = append(result, tokens[start:])
+	}
+	return result
+}
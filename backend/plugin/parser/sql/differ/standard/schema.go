@@ -0,0 +1,875 @@
+package standard
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+)
+
+// dialect contains the differences between Oracle and SQL Server.
+type dialect struct {
+	engine parser.EngineType
+}
+
+// identifier returns the identifier of the word or quoted identifier token.
+// Oracle folds the unquoted identifiers to upper case.
+func (d dialect) identifier(t token) string {
+	if t.tp == tokenQuoted {
+		return t.value
+	}
+	if d.engine == parser.Oracle {
+		return strings.ToUpper(t.text)
+	}
+	return t.text
+}
+
+// key returns the identifier used to look up the objects.
+// SQL Server identifiers are case-insensitive with the default collation.
+func (d dialect) key(name string) string {
+	if d.engine == parser.MSSQL {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+func (d dialect) quote(name string) string {
+	if d.engine == parser.MSSQL {
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+type objectName struct {
+	schema string
+	name   string
+}
+
+func (d dialect) objectKey(n objectName) string {
+	schema := n.schema
+	if schema == "" && d.engine == parser.MSSQL {
+		schema = "dbo"
+	}
+	return d.key(schema) + "." + d.key(n.name)
+}
+
+func (d dialect) quoteObject(n objectName) string {
+	if n.schema == "" {
+		return d.quote(n.name)
+	}
+	return d.quote(n.schema) + "." + d.quote(n.name)
+}
+
+// normalize returns the text of the tokens for comparison, so that the differences in
+// whitespaces, letter cases and quotes of identifiers are ignored.
+func (d dialect) normalize(tokens []token) string {
+	var buf strings.Builder
+	for i, t := range tokens {
+		if i > 0 && !t.isSymbol(")") && !t.isSymbol(",") && !t.isSymbol(".") && !t.isSymbol("(") &&
+			!tokens[i-1].isSymbol("(") && !tokens[i-1].isSymbol(".") {
+			buf.WriteString(" ")
+		}
+		switch t.tp {
+		case tokenWord, tokenQuoted:
+			text := d.identifier(t)
+			if t.tp == tokenWord {
+				text = strings.ToUpper(text)
+			}
+			buf.WriteString(d.key(text))
+		default:
+			buf.WriteString(t.text)
+		}
+	}
+	return buf.String()
+}
+
+var (
+	oracleTypeReplacer = []struct {
+		re   *regexp.Regexp
+		repl string
+	}{
+		{regexp.MustCompile(`^(INT|SMALLINT)$`), "INTEGER"},
+		{regexp.MustCompile(`^(DEC|DECIMAL|NUMERIC)\b`), "NUMBER"},
+		{regexp.MustCompile(`^NUMBER\((\d+), 0\)$`), "NUMBER($1)"},
+		{regexp.MustCompile(`^VARCHAR\(`), "VARCHAR2("},
+		{regexp.MustCompile(`^(N?VARCHAR2|N?CHAR|RAW)\((\d+) BYTE\)$`), "$1($2)"},
+		{regexp.MustCompile(`^(CHAR|NCHAR)$`), "$1(1)"},
+		{regexp.MustCompile(`^(FLOAT|DOUBLE PRECISION)$`), "FLOAT(126)"},
+		{regexp.MustCompile(`^REAL$`), "FLOAT(63)"},
+		{regexp.MustCompile(`^TIMESTAMP\b(\s|$)`), "TIMESTAMP(6)$1"},
+	}
+	mssqlTypeReplacer = []struct {
+		re   *regexp.Regexp
+		repl string
+	}{
+		{regexp.MustCompile(`^integer$`), "int"},
+		{regexp.MustCompile(`^numeric\b`), "decimal"},
+		{regexp.MustCompile(`^decimal$`), "decimal(18, 0)"},
+		{regexp.MustCompile(`^decimal\((\d+)\)$`), "decimal($1, 0)"},
+		{regexp.MustCompile(`^(varchar|nvarchar|char|nchar|binary|varbinary)$`), "$1(1)"},
+		{regexp.MustCompile(`^(datetime2|datetimeoffset|time)$`), "$1(7)"},
+		{regexp.MustCompile(`^(float\(53\)|double precision)$`), "float"},
+		{regexp.MustCompile(`^rowversion$`), "timestamp"},
+	}
+)
+
+// normalizeType returns the canonical type for comparison, so that the type aliases
+// and the default lengths are treated as the same type.
+func (d dialect) normalizeType(tokens []token) string {
+	text := d.normalize(tokens)
+	replacer := oracleTypeReplacer
+	if d.engine == parser.MSSQL {
+		replacer = mssqlTypeReplacer
+	}
+	for _, r := range replacer {
+		text = r.re.ReplaceAllString(text, r.repl)
+	}
+	return text
+}
+
+// normalizeExpression returns the default or check expression for comparison.
+// SQL Server stores the expressions with redundant parentheses, such as ((0)) and ([a]>(0)),
+// so the outer parentheses and the parentheses around a single operand are removed.
+func (d dialect) normalizeExpression(tokens []token) string {
+	for {
+		for len(tokens) > 1 && tokens[0].isSymbol("(") {
+			end, err := matchParen(tokens, 0)
+			if err != nil || end != len(tokens)-1 {
+				break
+			}
+			tokens = tokens[1:end]
+		}
+		var result []token
+		for i := 0; i < len(tokens); i++ {
+			// Keep the parentheses of the function calls and the IN lists, such as f(a) and IN (1).
+			if i+2 < len(tokens) && tokens[i].isSymbol("(") && tokens[i+2].isSymbol(")") &&
+				!tokens[i+1].isSymbol("(") && !tokens[i+1].isSymbol(")") &&
+				(i == 0 || (tokens[i-1].tp != tokenWord && tokens[i-1].tp != tokenQuoted)) {
+				result = append(result, tokens[i+1])
+				i += 2
+				continue
+			}
+			result = append(result, tokens[i])
+		}
+		if len(result) == len(tokens) {
+			return d.normalize(tokens)
+		}
+		tokens = result
+	}
+}
+
+type schema struct {
+	tables      []*table
+	tableMap    map[string]*table
+	indexes     []*index
+	indexMap    map[string]*index
+	views       []*view
+	viewMap     map[string]*view
+	sequences   []*sequence
+	sequenceMap map[string]*sequence
+}
+
+type table struct {
+	name        objectName
+	text        string
+	columns     []*column
+	columnMap   map[string]*column
+	constraints []*constraint
+}
+
+type column struct {
+	name string
+	tp   string
+	// generated is the identity or the computed column definition.
+	generated    string
+	defaultExpr  string
+	defaultName  string
+	nullable     bool
+	tpKey        string
+	generatedKey string
+	defaultKey   string
+	// defaultInline is whether the default is defined in CREATE TABLE. SQL Server defaults
+	// can also be added by ALTER TABLE ... ADD CONSTRAINT ... DEFAULT ... FOR ....
+	defaultInline bool
+}
+
+type constraintType int
+
+const (
+	primaryKey constraintType = iota
+	uniqueKey
+	foreignKey
+	checkConstraint
+)
+
+type constraint struct {
+	name string
+	tp   constraintType
+	// definition is the constraint definition without the name, such as PRIMARY KEY (id).
+	definition string
+	key        string
+	// columns are the primary key columns, which are implicitly NOT NULL.
+	columns []string
+	// inline is whether the constraint is defined in CREATE TABLE.
+	inline bool
+}
+
+type index struct {
+	name  objectName
+	table objectName
+	text  string
+	key   string
+}
+
+type view struct {
+	name objectName
+	// body is the view definition after the view name, such as AS SELECT ....
+	body string
+	key  string
+}
+
+type sequence struct {
+	name    objectName
+	text    string
+	options []*sequenceOption
+}
+
+type sequenceOption struct {
+	// name is the canonical option name, such as INCREMENT and MINVALUE.
+	name  string
+	value string
+	text  string
+}
+
+// parseSchema parses the schema in the DDL statements supported by the differ.
+func (d dialect) parseSchema(statement string) (*schema, error) {
+	s := &schema{
+		tableMap:    make(map[string]*table),
+		indexMap:    make(map[string]*index),
+		viewMap:     make(map[string]*view),
+		sequenceMap: make(map[string]*sequence),
+	}
+	list, err := parser.SplitMultiSQL(d.engine, statement)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to split statements")
+	}
+	for _, single := range list {
+		tokens, err := tokenize(single.Text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to tokenize statement %q", single.Text)
+		}
+		// Skip the batch separators in SQL Server scripts.
+		for len(tokens) > 0 && tokens[0].is("GO") {
+			tokens = tokens[1:]
+		}
+		for len(tokens) > 0 && tokens[len(tokens)-1].isSymbol(";") {
+			tokens = tokens[:len(tokens)-1]
+		}
+		if len(tokens) == 0 {
+			continue
+		}
+		p := &statementParser{d: d, text: single.Text, tokens: tokens}
+		if err := p.parse(s); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse statement %q", strings.TrimSpace(single.Text))
+		}
+	}
+
+	for _, t := range s.tables {
+		for _, c := range t.constraints {
+			for _, name := range c.columns {
+				if col, ok := t.columnMap[d.key(name)]; ok {
+					col.nullable = false
+				}
+			}
+		}
+	}
+	return s, nil
+}
+
+type statementParser struct {
+	d      dialect
+	text   string
+	tokens []token
+}
+
+// raw returns the original text of the tokens.
+func (p *statementParser) raw(tokens []token) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+	return p.text[tokens[0].start:tokens[len(tokens)-1].end]
+}
+
+func (p *statementParser) parse(s *schema) error {
+	tokens := p.tokens
+	switch {
+	case tokens[0].is("CREATE"):
+		return p.parseCreate(s, tokens[1:])
+	case len(tokens) > 1 && tokens[0].is("ALTER") && tokens[1].is("TABLE"):
+		return p.parseAlterTable(s, tokens[2:])
+	default:
+		return errors.New("only CREATE TABLE, CREATE INDEX, CREATE VIEW, CREATE SEQUENCE and ALTER TABLE ... ADD statements are supported")
+	}
+}
+
+// parseName parses the object name, such as schema.table, and returns the number of tokens used.
+func (p *statementParser) parseName(tokens []token) (objectName, int, error) {
+	var parts []string
+	i := 0
+	for {
+		if i >= len(tokens) || (tokens[i].tp != tokenWord && tokens[i].tp != tokenQuoted) {
+			return objectName{}, 0, errors.New("expect an object name")
+		}
+		parts = append(parts, p.d.identifier(tokens[i]))
+		i++
+		if i >= len(tokens) || !tokens[i].isSymbol(".") {
+			break
+		}
+		i++
+	}
+	// The database name of SQL Server three-part names is ignored.
+	if len(parts) > 2 {
+		parts = parts[len(parts)-2:]
+	}
+	if len(parts) == 1 {
+		return objectName{name: parts[0]}, i, nil
+	}
+	return objectName{schema: parts[0], name: parts[1]}, i, nil
+}
+
+func (p *statementParser) parseCreate(s *schema, tokens []token) error {
+	var flags []string
+	for len(tokens) > 0 {
+		t := tokens[0]
+		if t.is("OR", "NO") && len(tokens) > 1 {
+			tokens = tokens[2:]
+			continue
+		}
+		if t.is("UNIQUE", "CLUSTERED", "BITMAP") {
+			flags = append(flags, strings.ToUpper(t.text))
+		} else if !t.is("NONCLUSTERED", "FORCE", "NOFORCE", "EDITIONABLE", "EDITIONING", "NONEDITIONABLE") {
+			break
+		}
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 {
+		return errors.New("unexpected end of statement")
+	}
+	switch {
+	case tokens[0].is("TABLE"):
+		return p.parseCreateTable(s, tokens[1:])
+	case tokens[0].is("INDEX"):
+		return p.parseCreateIndex(s, flags, tokens[1:])
+	case tokens[0].is("VIEW"):
+		return p.parseCreateView(s, tokens[1:])
+	case tokens[0].is("SEQUENCE"):
+		return p.parseCreateSequence(s, tokens[1:])
+	default:
+		return errors.Errorf("unsupported CREATE %s statement", tokens[0].text)
+	}
+}
+
+func (p *statementParser) parseCreateTable(s *schema, tokens []token) error {
+	name, n, err := p.parseName(tokens)
+	if err != nil {
+		return err
+	}
+	tokens = tokens[n:]
+	if len(tokens) == 0 || !tokens[0].isSymbol("(") {
+		return errors.New("expect the table definition")
+	}
+	end, err := matchParen(tokens, 0)
+	if err != nil {
+		return err
+	}
+	t := &table{
+		name:      name,
+		text:      p.raw(p.tokens),
+		columnMap: make(map[string]*column),
+	}
+	for _, element := range splitByComma(tokens[1:end]) {
+		if len(element) == 0 {
+			return errors.New("empty table element")
+		}
+		if element[0].is("CONSTRAINT", "PRIMARY", "UNIQUE", "FOREIGN", "CHECK") {
+			c, err := p.parseConstraint(element)
+			if err != nil {
+				return err
+			}
+			c.inline = true
+			t.constraints = append(t.constraints, c)
+			continue
+		}
+		if err := p.parseColumn(t, element); err != nil {
+			return err
+		}
+	}
+
+	key := p.d.objectKey(name)
+	if _, ok := s.tableMap[key]; ok {
+		return errors.Errorf("table %s is defined more than once", p.d.quoteObject(name))
+	}
+	s.tables = append(s.tables, t)
+	s.tableMap[key] = t
+	return nil
+}
+
+var (
+	// columnOptionKeywords are the keywords starting the column options after the data type.
+	columnOptionKeywords = []string{"CONSTRAINT", "DEFAULT", "NOT", "NULL", "PRIMARY", "UNIQUE", "CHECK", "FOREIGN", "REFERENCES", "IDENTITY", "GENERATED", "AS", "ENABLE", "DISABLE", "VISIBLE", "INVISIBLE", "SPARSE", "ROWGUIDCOL"}
+	// columnConstraintKeywords are the keywords ending the default expressions and the references.
+	columnConstraintKeywords = []string{"CONSTRAINT", "NOT", "NULL", "PRIMARY", "UNIQUE", "CHECK", "FOREIGN", "REFERENCES", "ENABLE", "DISABLE"}
+)
+
+// referencesEnd returns the index after the inline REFERENCES clause starting at i, including
+// the referential actions such as ON DELETE SET NULL.
+func referencesEnd(tokens []token, i int) int {
+	j := nextKeyword(tokens, i+1, append([]string{"ON"}, columnConstraintKeywords...))
+	for j+2 < len(tokens) && tokens[j].is("ON") && tokens[j+1].is("DELETE", "UPDATE") {
+		j += 2
+		if tokens[j].is("SET", "NO") && j+1 < len(tokens) {
+			j++
+		}
+		j++
+	}
+	return j
+}
+
+// nextKeyword returns the index of the first keyword outside the parentheses from i.
+func nextKeyword(tokens []token, i int, keywords []string) int {
+	depth := 0
+	for ; i < len(tokens); i++ {
+		switch {
+		case tokens[i].isSymbol("("):
+			depth++
+		case tokens[i].isSymbol(")"):
+			depth--
+		case depth == 0 && tokens[i].is(keywords...):
+			return i
+		}
+	}
+	return len(tokens)
+}
+
+func (p *statementParser) parseColumn(t *table, tokens []token) error {
+	if tokens[0].tp != tokenWord && tokens[0].tp != tokenQuoted {
+		return errors.Errorf("expect a column name, but got %q", tokens[0].text)
+	}
+	col := &column{
+		name:          p.d.identifier(tokens[0]),
+		nullable:      true,
+		defaultInline: true,
+	}
+	i := nextKeyword(tokens, 1, columnOptionKeywords)
+	col.tp = p.raw(tokens[1:i])
+	col.tpKey = p.d.normalizeType(tokens[1:i])
+
+	var constraintName string
+	for i < len(tokens) {
+		tok := tokens[i]
+		switch {
+		case tok.is("CONSTRAINT"):
+			if i+1 >= len(tokens) {
+				return errors.New("expect a constraint name")
+			}
+			constraintName = p.d.identifier(tokens[i+1])
+			i += 2
+			continue
+		case tok.is("DEFAULT"):
+			// The default expression has at least one token, so DEFAULT NULL works.
+			j := nextKeyword(tokens, i+1, columnConstraintKeywords)
+			if j == i+1 {
+				j = nextKeyword(tokens, i+2, columnConstraintKeywords)
+			}
+			col.defaultExpr = p.raw(tokens[i+1 : j])
+			col.defaultKey = p.d.normalizeExpression(tokens[i+1 : j])
+			col.defaultName = constraintName
+			i = j
+		case tok.is("NOT") && i+1 < len(tokens) && tokens[i+1].is("NULL"):
+			col.nullable = false
+			i += 2
+		case tok.is("NULL"):
+			col.nullable = true
+			i++
+		case tok.is("FOREIGN") && i+2 < len(tokens) && tokens[i+1].is("KEY") && tokens[i+2].is("REFERENCES"):
+			// SQL Server allows FOREIGN KEY before the inline REFERENCES.
+			i += 2
+			continue
+		case tok.is("PRIMARY", "UNIQUE", "REFERENCES", "CHECK"):
+			var j int
+			var definition string
+			quotedName := p.d.quote(col.name)
+			switch {
+			case tok.is("CHECK"):
+				end, err := matchParen(tokens, i+1)
+				if err != nil {
+					return err
+				}
+				j = end + 1
+				definition = p.raw(tokens[i:j])
+			case tok.is("REFERENCES"):
+				j = referencesEnd(tokens, i)
+				definition = fmt.Sprintf("FOREIGN KEY (%s) %s", quotedName, p.raw(tokens[i:j]))
+			default:
+				j = i + 1
+				if tok.is("PRIMARY") {
+					j++
+				}
+				// Keep the CLUSTERED and NONCLUSTERED options of SQL Server.
+				definition = p.raw(tokens[i:j])
+				for j < len(tokens) && tokens[j].is("CLUSTERED", "NONCLUSTERED") {
+					definition += " " + strings.ToUpper(tokens[j].text)
+					j++
+				}
+				definition = fmt.Sprintf("%s (%s)", definition, quotedName)
+				j = nextKeyword(tokens, j, columnConstraintKeywords)
+			}
+			definitionTokens, err := tokenize(definition)
+			if err != nil {
+				return err
+			}
+			c, err := (&statementParser{d: p.d, text: definition, tokens: definitionTokens}).parseConstraint(definitionTokens)
+			if err != nil {
+				return err
+			}
+			c.name = constraintName
+			c.inline = true
+			t.constraints = append(t.constraints, c)
+			i = j
+		case tok.is("IDENTITY", "GENERATED", "AS"):
+			j := i + 1
+			identity := tok.is("IDENTITY")
+			if tok.is("GENERATED") {
+				for j < len(tokens) && !tokens[j].is("AS") {
+					j++
+				}
+				j++
+				if j < len(tokens) && tokens[j].is("IDENTITY") {
+					identity = true
+					j++
+				}
+			}
+			// The identity options, such as the seed, are not compared because they cannot be altered.
+			keyEnd := j
+			if j < len(tokens) && tokens[j].isSymbol("(") {
+				end, err := matchParen(tokens, j)
+				if err != nil {
+					return err
+				}
+				j = end + 1
+			}
+			if !identity {
+				keyEnd = j
+			}
+			for j < len(tokens) && tokens[j].is("VIRTUAL", "PERSISTED") {
+				j++
+			}
+			if j+2 < len(tokens) && tokens[j].is("NOT") && tokens[j+1].is("FOR") && tokens[j+2].is("REPLICATION") {
+				j += 3
+			}
+			if identity {
+				col.nullable = false
+			}
+			col.generated = p.raw(tokens[i:j])
+			col.generatedKey = p.d.normalize(tokens[i:keyEnd])
+			i = j
+		default:
+			// Skip the other options, such as ENABLE and SPARSE.
+			i++
+		}
+		constraintName = ""
+	}
+
+	key := p.d.key(col.name)
+	if _, ok := t.columnMap[key]; ok {
+		return errors.Errorf("column %s is defined more than once", p.d.quote(col.name))
+	}
+	t.columns = append(t.columns, col)
+	t.columnMap[key] = col
+	return nil
+}
+
+var constraintOptionKeywords = []string{"USING", "ENABLE", "DISABLE", "VALIDATE", "NOVALIDATE", "DEFERRABLE", "INITIALLY", "RELY", "NORELY", "WITH", "ON", "NOT", "EXCEPTIONS"}
+
+// parseConstraint parses the table constraint, such as CONSTRAINT pk PRIMARY KEY (id).
+func (p *statementParser) parseConstraint(tokens []token) (*constraint, error) {
+	c := &constraint{}
+	if tokens[0].is("CONSTRAINT") {
+		if len(tokens) < 3 {
+			return nil, errors.New("expect a constraint definition")
+		}
+		c.name = p.d.identifier(tokens[1])
+		tokens = tokens[2:]
+	}
+	switch {
+	case tokens[0].is("PRIMARY"):
+		c.tp = primaryKey
+		for i, t := range tokens {
+			if !t.isSymbol("(") {
+				continue
+			}
+			end, err := matchParen(tokens, i)
+			if err != nil {
+				return nil, err
+			}
+			for _, part := range splitByComma(tokens[i+1 : end]) {
+				if len(part) > 0 {
+					c.columns = append(c.columns, p.d.identifier(part[0]))
+				}
+			}
+			break
+		}
+	case tokens[0].is("UNIQUE"):
+		c.tp = uniqueKey
+	case tokens[0].is("FOREIGN"):
+		c.tp = foreignKey
+	case tokens[0].is("CHECK"):
+		c.tp = checkConstraint
+	default:
+		return nil, errors.Errorf("unsupported constraint %q", p.raw(tokens))
+	}
+	c.definition = p.raw(tokens)
+	if c.tp == checkConstraint {
+		if len(tokens) < 2 || !tokens[1].isSymbol("(") {
+			return nil, errors.New("expect the check expression")
+		}
+		end, err := matchParen(tokens, 1)
+		if err != nil {
+			return nil, err
+		}
+		c.key = "CHECK " + p.d.normalizeExpression(tokens[2:end])
+		return c, nil
+	}
+
+	// Ignore the options that do not change the constraint, such as the index storage.
+	end := len(tokens)
+	depth := 0
+	for i, t := range tokens {
+		if t.isSymbol("(") {
+			depth++
+		} else if t.isSymbol(")") {
+			depth--
+		}
+		if depth != 0 || !t.is(constraintOptionKeywords...) {
+			continue
+		}
+		if t.is("ON") && i+1 < len(tokens) && tokens[i+1].is("DELETE", "UPDATE") {
+			continue
+		}
+		end = i
+		break
+	}
+	var keyTokens []token
+	for i := 0; i < end; i++ {
+		t := tokens[i]
+		if t.is("ASC") || (c.tp == primaryKey && t.is("CLUSTERED")) || (c.tp == uniqueKey && t.is("NONCLUSTERED")) {
+			continue
+		}
+		if t.is("REFERENCES") {
+			// Qualify the referenced table for comparison.
+			name, n, err := p.parseName(tokens[i+1 : end])
+			if err != nil {
+				return nil, err
+			}
+			c.key = fmt.Sprintf("%s REFERENCES %s ", p.d.normalize(keyTokens), p.d.objectKey(name))
+			keyTokens = nil
+			i += n
+			continue
+		}
+		keyTokens = append(keyTokens, t)
+	}
+	c.key += p.d.normalize(keyTokens)
+	return c, nil
+}
+
+func (p *statementParser) parseAlterTable(s *schema, tokens []token) error {
+	name, n, err := p.parseName(tokens)
+	if err != nil {
+		return err
+	}
+	tokens = tokens[n:]
+	// Skip WITH CHECK and WITH NOCHECK generated by SQL Server.
+	if len(tokens) > 1 && tokens[0].is("WITH") && tokens[1].is("CHECK", "NOCHECK") {
+		tokens = tokens[2:]
+	}
+	if len(tokens) < 2 || !tokens[0].is("ADD") {
+		return errors.New("only ALTER TABLE ... ADD CONSTRAINT is supported")
+	}
+	tokens = tokens[1:]
+	if tokens[0].isSymbol("(") {
+		end, err := matchParen(tokens, 0)
+		if err != nil {
+			return err
+		}
+		if end == len(tokens)-1 {
+			tokens = tokens[1:end]
+		}
+	}
+	t, ok := s.tableMap[p.d.objectKey(name)]
+	if !ok {
+		return errors.Errorf("table %s must be created before it is altered", p.d.quoteObject(name))
+	}
+
+	// CONSTRAINT name DEFAULT expr FOR column in SQL Server.
+	if (len(tokens) > 2 && tokens[0].is("CONSTRAINT") && tokens[2].is("DEFAULT")) || tokens[0].is("DEFAULT") {
+		var defaultName string
+		if tokens[0].is("CONSTRAINT") {
+			defaultName = p.d.identifier(tokens[1])
+			tokens = tokens[2:]
+		}
+		j := nextKeyword(tokens, 1, []string{"FOR"})
+		if j+1 >= len(tokens) {
+			return errors.New("expect the column of the default constraint")
+		}
+		col, ok := t.columnMap[p.d.key(p.d.identifier(tokens[j+1]))]
+		if !ok {
+			return errors.Errorf("column %s does not exist", tokens[j+1].text)
+		}
+		col.defaultExpr = p.raw(tokens[1:j])
+		col.defaultKey = p.d.normalizeExpression(tokens[1:j])
+		col.defaultName = defaultName
+		col.defaultInline = false
+		return nil
+	}
+
+	c, err := p.parseConstraint(tokens)
+	if err != nil {
+		return err
+	}
+	t.constraints = append(t.constraints, c)
+	return nil
+}
+
+func (p *statementParser) parseCreateIndex(s *schema, flags []string, tokens []token) error {
+	name, n, err := p.parseName(tokens)
+	if err != nil {
+		return err
+	}
+	tokens = tokens[n:]
+	if len(tokens) == 0 || !tokens[0].is("ON") {
+		return errors.New("expect ON in CREATE INDEX")
+	}
+	tableName, n, err := p.parseName(tokens[1:])
+	if err != nil {
+		return err
+	}
+	tokens = tokens[1+n:]
+	if len(tokens) == 0 || !tokens[0].isSymbol("(") {
+		return errors.New("expect the index columns")
+	}
+	end, err := matchParen(tokens, 0)
+	if err != nil {
+		return err
+	}
+	var keyTokens []token
+	for _, t := range tokens[:end+1] {
+		if !t.is("ASC") {
+			keyTokens = append(keyTokens, t)
+		}
+	}
+	key := fmt.Sprintf("%s ON %s %s", strings.Join(flags, " "), p.d.objectKey(tableName), p.d.normalize(keyTokens))
+	// SQL Server INCLUDE and WHERE change the index, but the storage options do not.
+	// The Oracle index options are all about the storage.
+	if p.d.engine == parser.MSSQL {
+		rest := tokens[end+1:]
+		rest = rest[:nextKeyword(rest, 0, []string{"WITH", "ON"})]
+		where := nextKeyword(rest, 0, []string{"WHERE"})
+		if where > 0 {
+			key += " " + p.d.normalize(rest[:where])
+		}
+		if where < len(rest) {
+			key += " WHERE " + p.d.normalizeExpression(rest[where+1:])
+		}
+	}
+	idx := &index{
+		name:  name,
+		table: tableName,
+		text:  p.raw(p.tokens),
+		key:   key,
+	}
+	mapKey := p.d.objectKey(tableName) + "." + p.d.key(name.name)
+	if _, ok := s.indexMap[mapKey]; ok {
+		return errors.Errorf("index %s is defined more than once", p.d.quoteObject(name))
+	}
+	s.indexes = append(s.indexes, idx)
+	s.indexMap[mapKey] = idx
+	return nil
+}
+
+func (p *statementParser) parseCreateView(s *schema, tokens []token) error {
+	name, n, err := p.parseName(tokens)
+	if err != nil {
+		return err
+	}
+	tokens = tokens[n:]
+	if len(tokens) == 0 {
+		return errors.New("expect the view definition")
+	}
+	v := &view{
+		name: name,
+		body: p.raw(tokens),
+		key:  p.d.normalize(tokens),
+	}
+	key := p.d.objectKey(name)
+	if _, ok := s.viewMap[key]; ok {
+		return errors.Errorf("view %s is defined more than once", p.d.quoteObject(name))
+	}
+	s.views = append(s.views, v)
+	s.viewMap[key] = v
+	return nil
+}
+
+// sequenceOptionKeywords are the keywords starting the sequence options.
+var sequenceOptionKeywords = []string{"START", "INCREMENT", "MINVALUE", "NOMINVALUE", "MAXVALUE", "NOMAXVALUE", "CYCLE", "NOCYCLE", "CACHE", "NOCACHE", "ORDER", "NOORDER", "NO", "AS", "KEEP", "NOKEEP", "SCALE", "NOSCALE", "SESSION", "GLOBAL"}
+
+func (p *statementParser) parseCreateSequence(s *schema, tokens []token) error {
+	name, n, err := p.parseName(tokens)
+	if err != nil {
+		return err
+	}
+	seq := &sequence{
+		name: name,
+		text: p.raw(p.tokens),
+	}
+	tokens = tokens[n:]
+	for i := 0; i < len(tokens); {
+		if !tokens[i].is(sequenceOptionKeywords...) {
+			return errors.Errorf("unsupported sequence option %q", tokens[i].text)
+		}
+		start := i
+		word := strings.ToUpper(tokens[i].text)
+		i++
+		if word == "NO" && i < len(tokens) {
+			word = "NO" + strings.ToUpper(tokens[i].text)
+			i++
+		}
+		// Skip the WITH and BY in START WITH and INCREMENT BY.
+		if i < len(tokens) && tokens[i].is("WITH", "BY") {
+			i++
+		}
+		valueStart := i
+		for i < len(tokens) && !tokens[i].is(sequenceOptionKeywords...) {
+			i++
+		}
+		option := &sequenceOption{
+			name:  strings.TrimPrefix(word, "NO"),
+			value: p.d.normalize(tokens[valueStart:i]),
+			text:  p.raw(tokens[start:i]),
+		}
+		if strings.HasPrefix(word, "NO") {
+			option.value = "NO"
+		}
+		seq.options = append(seq.options, option)
+	}
+
+	key := p.d.objectKey(name)
+	if _, ok := s.sequenceMap[key]; ok {
+		return errors.Errorf("sequence %s is defined more than once", p.d.quoteObject(name))
+	}
+	s.sequences = append(s.sequences, seq)
+	s.sequenceMap[key] = seq
+	return nil
+}
@@ -0,0 +1,275 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+	tidbast "github.com/pingcap/tidb/parser/ast"
+	"github.com/pkg/errors"
+)
+
+// mysqlQueryFunctionAllowList are the MySQL built-in functions which only compute on their arguments.
+// The other functions, such as LOAD_FILE and the stored functions, may read the data bypassing the table references.
+var mysqlQueryFunctionAllowList = newFunctionAllowList(
+	// Aggregate and window functions.
+	"count", "sum", "avg", "min", "max", "group_concat", "bit_and", "bit_or", "bit_xor",
+	"std", "stddev", "stddev_pop", "stddev_samp", "var_pop", "var_samp", "variance",
+	"json_arrayagg", "json_objectagg",
+	"row_number", "rank", "dense_rank", "percent_rank", "cume_dist", "ntile",
+	"lag", "lead", "first_value", "last_value", "nth_value",
+	// Control flow functions.
+	"if", "ifnull", "nullif", "coalesce", "greatest", "least", "isnull", "interval",
+	// String functions.
+	"ascii", "bin", "bit_length", "char", "char_length", "character_length", "concat", "concat_ws",
+	"elt", "field", "find_in_set", "format", "hex", "insert", "instr", "lcase", "left", "length",
+	"locate", "lower", "lpad", "ltrim", "mid", "oct", "octet_length", "ord", "position", "quote",
+	"repeat", "replace", "reverse", "right", "rpad", "rtrim", "space", "strcmp", "substr",
+	"substring", "substring_index", "trim", "ucase", "unhex", "upper", "convert",
+	"regexp_like", "regexp_instr", "regexp_replace", "regexp_substr",
+	// Numeric functions.
+	"abs", "ceil", "ceiling", "floor", "round", "truncate", "mod", "pow", "power", "sqrt", "exp",
+	"ln", "log", "log2", "log10", "sign", "pi", "degrees", "radians", "sin", "cos", "tan",
+	"asin", "acos", "atan", "atan2", "cot", "conv", "crc32",
+	// Date and time functions.
+	"adddate", "addtime", "convert_tz", "curdate", "current_date", "current_time", "current_timestamp",
+	"curtime", "date", "date_add", "date_format", "date_sub", "datediff", "day", "dayname",
+	"dayofmonth", "dayofweek", "dayofyear", "extract", "from_days", "from_unixtime", "hour",
+	"last_day", "localtime", "localtimestamp", "makedate", "maketime", "microsecond", "minute",
+	"month", "monthname", "now", "period_add", "period_diff", "quarter", "sec_to_time", "second",
+	"str_to_date", "subdate", "subtime", "sysdate", "time", "time_format", "time_to_sec", "timediff",
+	"timestamp", "timestampadd", "timestampdiff", "to_days", "to_seconds", "unix_timestamp",
+	"utc_date", "utc_time", "utc_timestamp", "week", "weekday", "weekofyear", "year", "yearweek",
+	tidbast.DateLiteral, tidbast.TimeLiteral, tidbast.TimestampLiteral,
+	// JSON functions.
+	"json_array", "json_contains", "json_contains_path", "json_depth", "json_extract", "json_keys",
+	"json_length", "json_object", "json_quote", "json_type", "json_unquote", "json_valid",
+	// Hash functions.
+	"md5", "sha", "sha1", "sha2",
+)
+
+// pgQueryFunctionAllowList are the Postgres built-in functions which only compute on their arguments.
+// The other functions, such as query_to_xml, dblink and the user-defined functions, may read the data
+// bypassing the table references.
+var pgQueryFunctionAllowList = newFunctionAllowList(
+	// Aggregate and window functions.
+	"count", "sum", "avg", "min", "max", "string_agg", "array_agg", "json_agg", "jsonb_agg",
+	"json_object_agg", "jsonb_object_agg", "bool_and", "bool_or", "every", "bit_and", "bit_or",
+	"stddev", "stddev_pop", "stddev_samp", "variance", "var_pop", "var_samp",
+	"percentile_cont", "percentile_disc", "mode",
+	"row_number", "rank", "dense_rank", "percent_rank", "cume_dist", "ntile",
+	"lag", "lead", "first_value", "last_value", "nth_value",
+	// String functions.
+	"ascii", "bit_length", "btrim", "char_length", "character_length", "chr", "concat", "concat_ws",
+	"format", "initcap", "left", "length", "lower", "lpad", "ltrim", "octet_length", "overlay",
+	"position", "repeat", "replace", "reverse", "right", "rpad", "rtrim", "split_part", "strpos",
+	"substr", "substring", "translate", "trim", "upper", "starts_with", "to_hex",
+	"regexp_match", "regexp_matches", "regexp_replace", "regexp_split_to_array",
+	// Numeric functions.
+	"abs", "cbrt", "ceil", "ceiling", "degrees", "div", "exp", "floor", "ln", "log", "log10", "mod",
+	"pi", "power", "radians", "round", "sign", "sqrt", "trunc", "width_bucket",
+	"sin", "cos", "tan", "asin", "acos", "atan", "atan2", "cot",
+	// Date and time functions.
+	"age", "clock_timestamp", "date_part", "date_trunc", "extract", "isfinite", "justify_days",
+	"justify_hours", "justify_interval", "make_date", "make_interval", "make_time", "make_timestamp",
+	"make_timestamptz", "now", "statement_timestamp", "timeofday", "timezone", "to_char", "to_date",
+	"to_number", "to_timestamp", "transaction_timestamp",
+	// JSON and array functions.
+	"array_length", "array_position", "array_to_string", "cardinality", "json_build_array",
+	"json_build_object", "jsonb_build_array", "jsonb_build_object", "json_extract_path",
+	"json_extract_path_text", "jsonb_extract_path", "jsonb_extract_path_text", "json_typeof",
+	"jsonb_typeof", "to_json", "to_jsonb", "unnest", "generate_series",
+	// Hash functions.
+	"md5",
+)
+
+func newFunctionAllowList(names ...string) map[string]bool {
+	allowList := make(map[string]bool)
+	for _, name := range names {
+		allowList[name] = true
+	}
+	return allowList
+}
+
+// ValidateQueryFunctions returns an error if the statement calls any function that may read the data
+// bypassing the table references, such as query_to_xml of Postgres and the user-defined functions.
+// It's used when the access to the statement is granted by the tables it references.
+func ValidateQueryFunctions(engineType EngineType, statement string) error {
+	var functionList []string
+	var allowList map[string]bool
+	var err error
+	switch engineType {
+	case MySQL, TiDB, MariaDB, OceanBase:
+		functionList, err = extractMySQLFunctionList(statement)
+		allowList = mysqlQueryFunctionAllowList
+	case Postgres:
+		functionList, err = extractPostgresFunctionList(statement)
+		allowList = pgQueryFunctionAllowList
+	default:
+		return errors.Errorf("engine type is not supported: %s", engineType)
+	}
+	if err != nil {
+		return err
+	}
+	for _, function := range functionList {
+		if !allowList[function] {
+			return errors.Errorf("function %q is not allowed", function)
+		}
+	}
+	return nil
+}
+
+// extractMySQLFunctionList extracts the functions called by the statement in lower case.
+// The stored functions are qualified by the database.
+func extractMySQLFunctionList(statement string) ([]string, error) {
+	p := newMySQLParser()
+	nodeList, _, err := p.Parse(statement, "", "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parser statement %q", statement)
+	}
+
+	visitor := &functionListVisitor{functionMap: make(map[string]bool)}
+	for _, node := range nodeList {
+		node.Accept(visitor)
+	}
+	return sortedKeys(visitor.functionMap), nil
+}
+
+type functionListVisitor struct {
+	functionMap map[string]bool
+}
+
+// Enter implements the ast.Visitor interface.
+func (v *functionListVisitor) Enter(in tidbast.Node) (tidbast.Node, bool) {
+	switch node := in.(type) {
+	case *tidbast.FuncCallExpr:
+		name := node.FnName.L
+		if node.Schema.L != "" {
+			name = node.Schema.L + "." + name
+		}
+		v.functionMap[name] = true
+	case *tidbast.AggregateFuncExpr:
+		v.functionMap[strings.ToLower(node.F)] = true
+	case *tidbast.WindowFuncExpr:
+		v.functionMap[strings.ToLower(node.F)] = true
+	}
+	return in, false
+}
+
+// Leave implements the ast.Visitor interface.
+func (*functionListVisitor) Leave(in tidbast.Node) (tidbast.Node, bool) {
+	return in, true
+}
+
+// extractPostgresFunctionList extracts the functions called by the statement in lower case.
+// The built-in functions qualified by pg_catalog, such as the ones for EXTRACT and AT TIME ZONE, are unqualified.
+func extractPostgresFunctionList(statement string) ([]string, error) {
+	jsonText, err := pgquery.ParseToJSON(statement)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parser statement %q", statement)
+	}
+	var jsonData map[string]any
+	if err := json.Unmarshal([]byte(jsonText), &jsonData); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal JSON %q", jsonText)
+	}
+
+	functionMap := make(map[string]bool)
+	var walk func(value any)
+	walk = func(value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			for key, item := range v {
+				if m, ok := item.(map[string]any); ok && key == "FuncCall" {
+					functionMap[pgFunctionName(m)] = true
+				}
+				walk(item)
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(jsonData)
+	return sortedKeys(functionMap), nil
+}
+
+// pgFunctionName returns the name of the FuncCall node in JSON.
+func pgFunctionName(funcCall map[string]any) string {
+	var names []string
+	list, _ := funcCall["funcname"].([]any)
+	for _, item := range list {
+		m, _ := item.(map[string]any)
+		s, _ := m["String"].(map[string]any)
+		str, _ := s["str"].(string)
+		names = append(names, strings.ToLower(str))
+	}
+	if len(names) == 2 && names[0] == "pg_catalog" {
+		names = names[1:]
+	}
+	return strings.Join(names, ".")
+}
+
+// aggregateFunctionList are the aggregate functions allowed by ValidateAggregateQuery.
+var aggregateFunctionList = newFunctionAllowList("count", "sum", "avg", "min", "max")
+
+// ValidateAggregateQuery returns an error if the statement isn't a single SELECT statement whose columns are all
+// aggregate functions, such as SELECT COUNT(*) FROM t WHERE ..., so that it only returns a summary of the rows.
+func ValidateAggregateQuery(engineType EngineType, statement string) error {
+	switch engineType {
+	case MySQL, TiDB, MariaDB, OceanBase:
+		if err := validateMySQLAggregateQuery(statement); err != nil {
+			return err
+		}
+	case Postgres:
+		if err := validatePostgresAggregateQuery(statement); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("engine type is not supported: %s", engineType)
+	}
+	return ValidateQueryFunctions(engineType, statement)
+}
+
+func validateMySQLAggregateQuery(statement string) error {
+	p := newMySQLParser()
+	nodeList, _, err := p.Parse(statement, "", "")
+	if err != nil {
+		return errors.Wrapf(err, "failed to parser statement %q", statement)
+	}
+	if len(nodeList) != 1 {
+		return errors.Errorf("expect one statement but found %d", len(nodeList))
+	}
+	selectStmt, ok := nodeList[0].(*tidbast.SelectStmt)
+	if !ok || selectStmt.SelectIntoOpt != nil || selectStmt.Fields == nil {
+		return errors.New("expect a SELECT statement")
+	}
+	for _, field := range selectStmt.Fields.Fields {
+		aggregate, ok := field.Expr.(*tidbast.AggregateFuncExpr)
+		if !ok || !aggregateFunctionList[strings.ToLower(aggregate.F)] {
+			return errors.New("expect every column to be one of COUNT, SUM, AVG, MIN and MAX")
+		}
+	}
+	return nil
+}
+
+func validatePostgresAggregateQuery(statement string) error {
+	res, err := pgquery.Parse(statement)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parser statement %q", statement)
+	}
+	if len(res.Stmts) != 1 {
+		return errors.Errorf("expect one statement but found %d", len(res.Stmts))
+	}
+	selectStmt := res.Stmts[0].GetStmt().GetSelectStmt()
+	if selectStmt == nil || selectStmt.GetOp() != pgquery.SetOperation_SETOP_NONE || selectStmt.GetIntoClause() != nil || len(selectStmt.GetTargetList()) == 0 {
+		return errors.New("expect a SELECT statement")
+	}
+	for _, target := range selectStmt.GetTargetList() {
+		funcCall := target.GetResTarget().GetVal().GetFuncCall()
+		if funcCall == nil || funcCall.GetOver() != nil || len(funcCall.GetFuncname()) != 1 || !aggregateFunctionList[funcCall.GetFuncname()[0].GetString_().GetStr()] {
+			return errors.New("expect every column to be one of count, sum, avg, min and max")
+		}
+	}
+	return nil
+}
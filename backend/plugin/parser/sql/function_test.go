@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateQueryFunctions(t *testing.T) {
+	tests := []struct {
+		engine EngineType
+		stmt   string
+		valid  bool
+	}{
+		{
+			engine: MySQL,
+			stmt:   "SELECT COUNT(*), MAX(a), LOWER(b), DATE_FORMAT(NOW(), '%Y') FROM t WHERE c = DATE '2023-01-01' GROUP BY b",
+			valid:  true,
+		},
+		{
+			engine: MySQL,
+			stmt:   "SELECT ROW_NUMBER() OVER (ORDER BY a) FROM t",
+			valid:  true,
+		},
+		{
+			engine: MySQL,
+			stmt:   "SELECT LOAD_FILE('/etc/passwd')",
+			valid:  false,
+		},
+		{
+			engine: MySQL,
+			stmt:   "SELECT db.secret_reader(a) FROM t",
+			valid:  false,
+		},
+		{
+			engine: Postgres,
+			stmt:   "SELECT count(*), lower(b), extract(year FROM c), c AT TIME ZONE 'UTC' FROM t GROUP BY b, c",
+			valid:  true,
+		},
+		{
+			engine: Postgres,
+			stmt:   "SELECT * FROM query_to_xml('select * from secret', true, false, '')",
+			valid:  false,
+		},
+		{
+			engine: Postgres,
+			stmt:   "SELECT * FROM t WHERE a IN (SELECT x FROM dblink('dbname=db', 'select x from secret') AS s(x int))",
+			valid:  false,
+		},
+		{
+			engine: Postgres,
+			stmt:   "SELECT public.secret_reader(a) FROM t",
+			valid:  false,
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateQueryFunctions(test.engine, test.stmt)
+		if test.valid {
+			require.NoError(t, err, test.stmt)
+		} else {
+			require.Error(t, err, test.stmt)
+		}
+	}
+}
+
+func TestValidateAggregateQuery(t *testing.T) {
+	tests := []struct {
+		engine EngineType
+		stmt   string
+		valid  bool
+	}{
+		{engine: MySQL, stmt: "SELECT COUNT(*) FROM t WHERE status = 'FAILED'", valid: true},
+		{engine: MySQL, stmt: "SELECT MAX(delay), AVG(delay) FROM t", valid: true},
+		{engine: MySQL, stmt: "SELECT secret FROM t", valid: false},
+		{engine: MySQL, stmt: "SELECT LENGTH(secret) FROM t", valid: false},
+		{engine: MySQL, stmt: "SELECT COUNT(*) FROM t UNION SELECT secret FROM t", valid: false},
+		{engine: MySQL, stmt: "SELECT COUNT(*) FROM t; SELECT secret FROM t", valid: false},
+		{engine: MySQL, stmt: "SELECT MAX(LOAD_FILE('/etc/passwd')) FROM t", valid: false},
+		{engine: Postgres, stmt: "SELECT count(*) FROM t WHERE status = 'FAILED'", valid: true},
+		{engine: Postgres, stmt: "SELECT pg_catalog.max(secret) FROM t", valid: false},
+		{engine: Postgres, stmt: "SELECT secret FROM t", valid: false},
+		{engine: Postgres, stmt: "SELECT count(*) OVER () FROM t", valid: false},
+		{engine: Postgres, stmt: "SELECT max((SELECT x FROM query_to_xml('select * from secret', true, false, '') AS x)) FROM t", valid: false},
+	}
+
+	for _, test := range tests {
+		err := ValidateAggregateQuery(test.engine, test.stmt)
+		if test.valid {
+			require.NoError(t, err, test.stmt)
+		} else {
+			require.Error(t, err, test.stmt)
+		}
+	}
+}
@@ -224,6 +224,22 @@ func TestPGSplitMultiSQL(t *testing.T) {
 				},
 			},
 		},
+		{
+			statement: `SELECT * FROM t WHERE a = $1 AND b = $2;
+						UPDATE t SET b = $1 WHERE a = $2;`,
+			want: resData{
+				res: []SingleSQL{
+					{
+						Text:     `SELECT * FROM t WHERE a = $1 AND b = $2;`,
+						LastLine: 1,
+					},
+					{
+						Text:     `UPDATE t SET b = $1 WHERE a = $2;`,
+						LastLine: 2,
+					},
+				},
+			},
+		},
 		{
 			// test for Windows
 			statement: `CREATE TABLE t` + "\r\n" + `(a int);` + "\r\n" + `CREATE TABLE t1(b int);`,
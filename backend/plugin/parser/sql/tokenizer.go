@@ -725,6 +725,11 @@ func (t *tokenizer) scanString(delimiter rune) error {
 // - $tag$ string $tag$
 // See https://www.postgresql.org/docs/current/sql-syntax-lexical.html.
 func (t *tokenizer) scanDoubleDollarQuotedString() error {
+	// A dollar sign followed by digits is a positional parameter such as $1, because the tag cannot start with a digit.
+	if unicode.IsDigit(t.char(1)) {
+		t.skip(1)
+		return nil
+	}
 	startPos := t.pos()
 	// scan the tag string quoted by the dollar sign($)
 	if err := t.scanString('$'); err != nil {
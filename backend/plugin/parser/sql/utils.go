@@ -2,12 +2,14 @@ package parser
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"regexp"
 	"sort"
 	"strings"
 
+	pgquery "github.com/pganalyze/pg_query_go/v2"
 	tidbparser "github.com/pingcap/tidb/parser"
 	tidbast "github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
@@ -472,6 +474,175 @@ func ExtractDatabaseList(engineType EngineType, statement string) ([]string, err
 	}
 }
 
+// ExtractTableList extracts all tables referenced by the statement in "schema.table" format, or "table" if the schema is omitted.
+// For MySQL, the schema is the database. The references to the common table expressions are excluded.
+func ExtractTableList(engineType EngineType, statement string) ([]string, error) {
+	switch engineType {
+	case MySQL, TiDB, MariaDB, OceanBase:
+		return extractMySQLTableList(statement)
+	case Postgres:
+		return extractPostgresTableList(statement)
+	default:
+		return nil, errors.Errorf("engine type is not supported: %s", engineType)
+	}
+}
+
+func extractMySQLTableList(statement string) ([]string, error) {
+	p := newMySQLParser()
+	nodeList, _, err := p.Parse(statement, "", "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parser statement %q", statement)
+	}
+
+	visitor := &tableListVisitor{tableMap: make(map[string]bool)}
+	for _, node := range nodeList {
+		node.Accept(visitor)
+	}
+	return sortedKeys(visitor.tableMap), nil
+}
+
+type tableListVisitor struct {
+	tableMap map[string]bool
+	// cteNames is the CTE names visible to the current node.
+	cteNames []string
+	// scopeStack saves the length of cteNames when entering a statement, and restores it when leaving the statement.
+	scopeStack []int
+	// recursiveStack is whether the WITH clauses being visited are recursive.
+	recursiveStack []bool
+}
+
+// Enter implements the ast.Visitor interface.
+func (v *tableListVisitor) Enter(in tidbast.Node) (tidbast.Node, bool) {
+	switch node := in.(type) {
+	case *tidbast.SelectStmt, *tidbast.SetOprStmt, *tidbast.SetOprSelectList, *tidbast.UpdateStmt, *tidbast.DeleteStmt:
+		v.scopeStack = append(v.scopeStack, len(v.cteNames))
+	case *tidbast.WithClause:
+		v.recursiveStack = append(v.recursiveStack, node.IsRecursive)
+	case *tidbast.CommonTableExpression:
+		// The recursive CTE can refer to itself.
+		if len(v.recursiveStack) > 0 && v.recursiveStack[len(v.recursiveStack)-1] {
+			v.cteNames = append(v.cteNames, node.Name.L)
+		}
+	case *tidbast.TableName:
+		if node.Schema.O != "" {
+			v.tableMap[fmt.Sprintf("%s.%s", node.Schema.O, node.Name.O)] = true
+		} else if !v.isCTE(node.Name.L) {
+			v.tableMap[node.Name.O] = true
+		}
+	}
+	return in, false
+}
+
+// Leave implements the ast.Visitor interface.
+func (v *tableListVisitor) Leave(in tidbast.Node) (tidbast.Node, bool) {
+	switch node := in.(type) {
+	case *tidbast.SelectStmt, *tidbast.SetOprStmt, *tidbast.SetOprSelectList, *tidbast.UpdateStmt, *tidbast.DeleteStmt:
+		v.cteNames = v.cteNames[:v.scopeStack[len(v.scopeStack)-1]]
+		v.scopeStack = v.scopeStack[:len(v.scopeStack)-1]
+	case *tidbast.WithClause:
+		v.recursiveStack = v.recursiveStack[:len(v.recursiveStack)-1]
+	case *tidbast.CommonTableExpression:
+		// The following CTEs in the same WITH clause and the statement can refer to the CTE.
+		if len(v.recursiveStack) > 0 && !v.recursiveStack[len(v.recursiveStack)-1] {
+			v.cteNames = append(v.cteNames, node.Name.L)
+		}
+	}
+	return in, true
+}
+
+func (v *tableListVisitor) isCTE(name string) bool {
+	for i := len(v.cteNames) - 1; i >= 0; i-- {
+		if v.cteNames[i] == name {
+			return true
+		}
+	}
+	return false
+}
+
+func extractPostgresTableList(statement string) ([]string, error) {
+	jsonText, err := pgquery.ParseToJSON(statement)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parser statement %q", statement)
+	}
+	var jsonData map[string]any
+	if err := json.Unmarshal([]byte(jsonText), &jsonData); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal JSON %q", jsonText)
+	}
+
+	tableMap := make(map[string]bool)
+	// cteNames is the CTE names visible to the current node.
+	var cteNames []string
+	isCTE := func(name string) bool {
+		for i := len(cteNames) - 1; i >= 0; i-- {
+			if cteNames[i] == name {
+				return true
+			}
+		}
+		return false
+	}
+	var walk func(value any)
+	// walkWithClause walks the CTEs in the WITH clause and makes them visible to the following nodes.
+	walkWithClause := func(withClause map[string]any) {
+		recursive, _ := withClause["recursive"].(bool)
+		ctes, _ := withClause["ctes"].([]any)
+		for _, cte := range ctes {
+			node, _ := cte.(map[string]any)
+			cteNode, _ := node["CommonTableExpr"].(map[string]any)
+			name, _ := cteNode["ctename"].(string)
+			// The recursive CTE can refer to itself, and the following CTEs and the statement can refer to the CTE.
+			if recursive {
+				cteNames = append(cteNames, name)
+			}
+			walk(cteNode["ctequery"])
+			if !recursive {
+				cteNames = append(cteNames, name)
+			}
+		}
+	}
+	walk = func(value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			if withClause, ok := v["withClause"].(map[string]any); ok {
+				cteLength := len(cteNames)
+				defer func() {
+					cteNames = cteNames[:cteLength]
+				}()
+				walkWithClause(withClause)
+			}
+			for key, item := range v {
+				if key == "withClause" {
+					continue
+				}
+				if rangeVar, ok := item.(map[string]any); ok && key == "RangeVar" {
+					schemaName, _ := rangeVar["schemaname"].(string)
+					tableName, _ := rangeVar["relname"].(string)
+					if schemaName != "" {
+						tableMap[fmt.Sprintf("%s.%s", schemaName, tableName)] = true
+					} else if !isCTE(tableName) {
+						tableMap[tableName] = true
+					}
+				}
+				walk(item)
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(jsonData)
+	return sortedKeys(tableMap), nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	var list []string
+	for key := range m {
+		list = append(list, key)
+	}
+	sort.Strings(list)
+	return list
+}
+
 func newMySQLParser() *tidbparser.Parser {
 	p := tidbparser.New()
 
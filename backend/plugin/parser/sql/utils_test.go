@@ -216,6 +216,77 @@ func TestExtractDatabaseList(t *testing.T) {
 	}
 }
 
+func TestExtractTableList(t *testing.T) {
+	tests := []struct {
+		engine EngineType
+		stmt   string
+		want   []string
+	}{
+		{
+			engine: MySQL,
+			stmt:   "SELECT * FROM t JOIN db1.t1 ON t.a = t1.a WHERE t.b IN (SELECT b FROM t2)",
+			want:   []string{"db1.t1", "t", "t2"},
+		},
+		{
+			engine: MySQL,
+			stmt:   "WITH c AS (SELECT * FROM t) SELECT * FROM c",
+			want:   []string{"t"},
+		},
+		{
+			engine: MySQL,
+			stmt:   "SELECT 1",
+			want:   nil,
+		},
+		{
+			engine: Postgres,
+			stmt:   "SELECT * FROM t JOIN s1.t1 ON t.a = t1.a WHERE t.b IN (SELECT b FROM t2)",
+			want:   []string{"s1.t1", "t", "t2"},
+		},
+		{
+			engine: Postgres,
+			stmt:   "WITH c AS (SELECT * FROM public.t) SELECT * FROM c",
+			want:   []string{"public.t"},
+		},
+		// The CTE is not visible in its own definition unless it's recursive.
+		{
+			engine: MySQL,
+			stmt:   "WITH secret AS (SELECT * FROM secret) SELECT * FROM secret",
+			want:   []string{"secret"},
+		},
+		{
+			engine: MySQL,
+			stmt:   "WITH RECURSIVE c AS (SELECT 1 AS n UNION ALL SELECT n + 1 FROM c WHERE n < 3) SELECT * FROM c",
+			want:   nil,
+		},
+		{
+			engine: MySQL,
+			stmt:   "SELECT * FROM (WITH c AS (SELECT * FROM t) SELECT * FROM c) AS x JOIN c",
+			want:   []string{"c", "t"},
+		},
+		{
+			engine: Postgres,
+			stmt:   "WITH secret AS (SELECT * FROM secret) SELECT * FROM secret",
+			want:   []string{"secret"},
+		},
+		{
+			engine: Postgres,
+			stmt:   "WITH RECURSIVE c AS (SELECT 1 AS n UNION ALL SELECT n + 1 FROM c WHERE n < 3) SELECT * FROM c",
+			want:   nil,
+		},
+		{
+			engine: Postgres,
+			stmt:   "SELECT * FROM (WITH c AS (SELECT * FROM t) SELECT * FROM c) AS x JOIN c ON true",
+			want:   []string{"c", "t"},
+		},
+	}
+
+	for _, test := range tests {
+		res, err := ExtractTableList(test.engine, test.stmt)
+		require.NoError(t, err)
+		require.Equal(t, test.want, res, test.stmt)
+	}
+}
+
 func TestGetMySQLFingerprint(t *testing.T) {
 	tests := []struct {
 		stmt string
@@ -0,0 +1,117 @@
+package scim
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// Condition is an equality comparison in a filter, e.g. `userName eq "alice@example.com"`.
+type Condition struct {
+	// Attribute is the lower-cased attribute path, e.g. "username" or "emails.value".
+	Attribute string
+	Value     string
+}
+
+// Filter is the conjunction of the conditions.
+// Identity providers only use equality filters joined by "and" to look up the
+// resources before provisioning, so that is all we support.
+type Filter struct {
+	Conditions []*Condition
+}
+
+// ParseFilter parses the filter query parameter.
+// An empty filter matches all resources.
+func ParseFilter(filter string) (*Filter, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+	result := &Filter{}
+	for i := 0; i < len(tokens); {
+		if i > 0 {
+			if !strings.EqualFold(tokens[i], "and") {
+				return nil, errors.Errorf("unsupported logical operator %q, only \"and\" is supported", tokens[i])
+			}
+			i++
+		}
+		if i+3 > len(tokens) {
+			return nil, errors.Errorf("incomplete filter expression %q", filter)
+		}
+		attribute, operator, value := tokens[i], tokens[i+1], tokens[i+2]
+		if !strings.EqualFold(operator, "eq") {
+			return nil, errors.Errorf("unsupported operator %q, only \"eq\" is supported", operator)
+		}
+		if strings.HasPrefix(value, `"`) {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid string value %s", value)
+			}
+			value = unquoted
+		}
+		result.Conditions = append(result.Conditions, &Condition{
+			Attribute: strings.ToLower(attribute),
+			Value:     value,
+		})
+		i += 3
+	}
+	return result, nil
+}
+
+// tokenize splits the filter by white spaces, keeping the quoted strings together with their quotes.
+func tokenize(filter string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inString, escaped := false, false
+	for _, r := range filter {
+		switch {
+		case inString:
+			current.WriteRune(r)
+			if escaped {
+				escaped = false
+			} else if r == '\\' {
+				escaped = true
+			} else if r == '"' {
+				inString = false
+			}
+		case r == '"':
+			current.WriteRune(r)
+			inString = true
+		case unicode.IsSpace(r):
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		case r == '(' || r == ')' || r == '[' || r == ']':
+			return nil, errors.Errorf("grouping and complex attribute filters are not supported")
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inString {
+		return nil, errors.Errorf("unterminated string in filter %q", filter)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// ParseMemberPath parses the PATCH path selecting a group member, e.g. `members[value eq "101"]`.
+// It returns the member value and whether the path is a member selector.
+func ParseMemberPath(path string) (string, bool, error) {
+	trimmed := strings.TrimSpace(path)
+	if !strings.HasPrefix(strings.ToLower(trimmed), "members[") || !strings.HasSuffix(trimmed, "]") {
+		return "", false, nil
+	}
+	filter, err := ParseFilter(trimmed[len("members[") : len(trimmed)-1])
+	if err != nil {
+		return "", true, err
+	}
+	if len(filter.Conditions) != 1 || filter.Conditions[0].Attribute != "value" {
+		return "", true, errors.Errorf("unsupported member path %q", path)
+	}
+	return filter.Conditions[0].Value, true, nil
+}
@@ -0,0 +1,83 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		filter  string
+		want    []*Condition
+		wantErr bool
+	}{
+		{
+			filter: "",
+			want:   nil,
+		},
+		{
+			filter: `userName eq "alice@example.com"`,
+			want:   []*Condition{{Attribute: "username", Value: "alice@example.com"}},
+		},
+		{
+			filter: `displayName EQ "Data \"Platform\" Team" and externalId eq "00g1"`,
+			want: []*Condition{
+				{Attribute: "displayname", Value: `Data "Platform" Team`},
+				{Attribute: "externalid", Value: "00g1"},
+			},
+		},
+		{
+			filter: `active eq true`,
+			want:   []*Condition{{Attribute: "active", Value: "true"}},
+		},
+		{
+			filter:  `userName sw "alice"`,
+			wantErr: true,
+		},
+		{
+			filter:  `userName eq "a" or userName eq "b"`,
+			wantErr: true,
+		},
+		{
+			filter:  `emails[type eq "work"]`,
+			wantErr: true,
+		},
+		{
+			filter:  `userName eq "alice`,
+			wantErr: true,
+		},
+		{
+			filter:  `userName eq`,
+			wantErr: true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		filter, err := ParseFilter(test.filter)
+		if test.wantErr {
+			a.Error(err, test.filter)
+			continue
+		}
+		a.NoError(err, test.filter)
+		a.Equal(test.want, filter.Conditions, test.filter)
+	}
+}
+
+func TestParseMemberPath(t *testing.T) {
+	a := require.New(t)
+
+	value, ok, err := ParseMemberPath(`members[value eq "101"]`)
+	a.NoError(err)
+	a.True(ok)
+	a.Equal("101", value)
+
+	_, ok, err = ParseMemberPath("members")
+	a.NoError(err)
+	a.False(ok)
+
+	_, ok, err = ParseMemberPath(`members[display eq "alice"]`)
+	a.Error(err)
+	a.True(ok)
+}
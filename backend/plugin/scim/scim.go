@@ -0,0 +1,186 @@
+// Package scim is the plugin for the SCIM 2.0 protocol (RFC 7643 and RFC 7644).
+// It contains the wire format of the resources and the request filter parser,
+// the provisioning itself is implemented by the server.
+package scim
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SchemaUser is the schema URN of the User resource.
+	SchemaUser = "urn:ietf:params:scim:schemas:core:2.0:User"
+	// SchemaGroup is the schema URN of the Group resource.
+	SchemaGroup = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	// SchemaListResponse is the schema URN of the list response.
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	// SchemaPatchOp is the schema URN of the PATCH request.
+	SchemaPatchOp = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	// SchemaError is the schema URN of the error response.
+	SchemaError = "urn:ietf:params:scim:api:messages:2.0:Error"
+	// SchemaServiceProviderConfig is the schema URN of the service provider configuration.
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	// ContentType is the media type of SCIM requests and responses.
+	ContentType = "application/scim+json"
+)
+
+// Error types defined in RFC 7644 section 3.12.
+const (
+	ErrorTypeInvalidFilter = "invalidFilter"
+	ErrorTypeUniqueness    = "uniqueness"
+	ErrorTypeInvalidSyntax = "invalidSyntax"
+	ErrorTypeInvalidPath   = "invalidPath"
+	ErrorTypeInvalidValue  = "invalidValue"
+	ErrorTypeMutability    = "mutability"
+)
+
+// Meta is the resource metadata.
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+// Name is the components of the user's name.
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+// DisplayName returns the formatted name, or the given and family names joined.
+func (n *Name) DisplayName() string {
+	if n == nil {
+		return ""
+	}
+	if n.Formatted != "" {
+		return n.Formatted
+	}
+	return strings.TrimSpace(n.GivenName + " " + n.FamilyName)
+}
+
+// MultiValuedAttribute is an item of a multi-valued attribute such as emails and members.
+type MultiValuedAttribute struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// User is the SCIM User resource.
+type User struct {
+	Schemas     []string               `json:"schemas"`
+	ID          string                 `json:"id,omitempty"`
+	ExternalID  string                 `json:"externalId,omitempty"`
+	UserName    string                 `json:"userName"`
+	Name        *Name                  `json:"name,omitempty"`
+	DisplayName string                 `json:"displayName,omitempty"`
+	Emails      []MultiValuedAttribute `json:"emails,omitempty"`
+	// Active is a pointer because an absent value means active on creation.
+	Active *bool                  `json:"active,omitempty"`
+	Groups []MultiValuedAttribute `json:"groups,omitempty"`
+	Meta   *Meta                  `json:"meta,omitempty"`
+}
+
+// PrimaryEmail returns the primary email of the user, falling back to the first email.
+func (u *User) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// Group is the SCIM Group resource.
+type Group struct {
+	Schemas     []string               `json:"schemas"`
+	ID          string                 `json:"id,omitempty"`
+	ExternalID  string                 `json:"externalId,omitempty"`
+	DisplayName string                 `json:"displayName"`
+	Members     []MultiValuedAttribute `json:"members"`
+	Meta        *Meta                  `json:"meta,omitempty"`
+}
+
+// ListResponse is the response of querying resources.
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// NewListResponse returns the page of the resources starting at the 1-based startIndex.
+// A negative count returns all the remaining resources.
+func NewListResponse(resources []any, startIndex, count int) *ListResponse {
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	page := []any{}
+	if startIndex <= len(resources) {
+		page = resources[startIndex-1:]
+	}
+	if count >= 0 && count < len(page) {
+		page = page[:count]
+	}
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	}
+}
+
+// PatchRequest is the request of the PATCH operation.
+type PatchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is a single operation of a PATCH request.
+type PatchOperation struct {
+	// Op is one of "add", "remove" and "replace", case insensitive.
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Error is the error response.
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+	// Status is the HTTP status code in string form.
+	Status string `json:"status"`
+}
+
+// ParseBool parses the boolean value of PATCH operations.
+// Some identity providers, e.g. Azure AD, send booleans as strings.
+func ParseBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return false, errors.Errorf("invalid boolean value %s", string(value))
+	}
+	switch strings.ToLower(s) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, errors.Errorf("invalid boolean value %q", s)
+}
@@ -0,0 +1,142 @@
+// Package azure provides the client for Azure Blob Storage.
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+)
+
+var _ storage.Backend = (*Client)(nil)
+
+// Credentials is the shared key credentials of an Azure storage account.
+type Credentials struct {
+	AccountName string `json:"accountName"`
+	AccountKey  string `json:"accountKey"`
+	// ServiceURL is optional, and defaults to https://<accountName>.blob.core.windows.net/.
+	// It can be set to the endpoint of a local emulator such as Azurite.
+	ServiceURL string `json:"serviceURL"`
+}
+
+// Client wraps the Azure Blob Storage client.
+type Client struct {
+	c         *azblob.Client
+	container string
+}
+
+// GetCredentialsFromFile loads Azure storage account credentials from a JSON file.
+func GetCredentialsFromFile(credentialsFileName string) (*Credentials, error) {
+	content, err := os.ReadFile(credentialsFileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read Azure credentials file %q", credentialsFileName)
+	}
+	credentials := &Credentials{}
+	if err := json.Unmarshal(content, credentials); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal Azure credentials file %q", credentialsFileName)
+	}
+	if credentials.AccountName == "" || credentials.AccountKey == "" {
+		return nil, errors.Errorf("accountName and accountKey are required in Azure credentials file %q", credentialsFileName)
+	}
+	return credentials, nil
+}
+
+// NewClient returns a new Azure Blob Storage client.
+func NewClient(container string, credentials *Credentials) (*Client, error) {
+	credential, err := azblob.NewSharedKeyCredential(credentials.AccountName, credentials.AccountKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Azure shared key credential")
+	}
+	serviceURL := credentials.ServiceURL
+	if serviceURL == "" {
+		serviceURL = fmt.Sprintf("https://%s.blob.core.windows.net/", credentials.AccountName)
+	}
+	c, err := azblob.NewClientWithSharedKeyCredential(serviceURL, credential, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Azure Blob Storage client")
+	}
+	return &Client{
+		c:         c,
+		container: container,
+	}, nil
+}
+
+// ListObjects lists objects with prefix in their names.
+func (c *Client) ListObjects(ctx context.Context, prefix string) ([]storage.ObjectInfo, error) {
+	var ret []storage.ObjectInfo
+	pager := c.c.NewListBlobsFlatPager(c.container, &azblob.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the next page of Azure blobs")
+		}
+		if page.Segment == nil {
+			continue
+		}
+		for _, item := range page.Segment.BlobItems {
+			if item.Name == nil {
+				continue
+			}
+			object := storage.ObjectInfo{
+				Key: *item.Name,
+			}
+			if item.Properties != nil {
+				if item.Properties.LastModified != nil {
+					object.LastModified = *item.Properties.LastModified
+				}
+				if item.Properties.ContentLength != nil {
+					object.Size = *item.Properties.ContentLength
+				}
+			}
+			ret = append(ret, object)
+		}
+	}
+	return ret, nil
+}
+
+// DownloadObject downloads the object with path.
+func (c *Client) DownloadObject(ctx context.Context, path string, w io.WriterAt) (int64, error) {
+	resp, err := c.c.DownloadStream(ctx, c.container, path, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to download blob %q", path)
+	}
+	body := resp.NewRetryReader(ctx, nil)
+	defer body.Close()
+	n, err := io.Copy(io.NewOffsetWriter(w, 0), body)
+	if err != nil {
+		return n, errors.Wrapf(err, "failed to read blob %q", path)
+	}
+	return n, nil
+}
+
+// UploadObject uploads an object with the path.
+func (c *Client) UploadObject(ctx context.Context, path string, body io.Reader) error {
+	if _, err := c.c.UploadStream(ctx, c.container, path, body, nil); err != nil {
+		return errors.Wrapf(err, "failed to upload blob %q", path)
+	}
+	return nil
+}
+
+// DeleteObjects deletes the objects with path.
+// Azure Blob Storage has no batch delete in the SDK, so the blobs are deleted one by one.
+func (c *Client) DeleteObjects(ctx context.Context, pathList ...string) error {
+	for _, path := range pathList {
+		if _, err := c.c.DeleteBlob(ctx, c.container, path, nil); err != nil {
+			return errors.Wrapf(err, "failed to delete blob %q", path)
+		}
+	}
+	return nil
+}
+
+// GetBucket returns the container.
+func (c *Client) GetBucket() string {
+	return c.container
+}
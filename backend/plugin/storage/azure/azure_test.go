@@ -0,0 +1,84 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+)
+
+const (
+	container = "bytebase-dev"
+)
+
+func TestGetCredentialsFromFile(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "credentials.json")
+	a.NoError(os.WriteFile(path, []byte(`{"accountName": "devstoreaccount1", "accountKey": "a2V5", "serviceURL": "http://127.0.0.1:10000/devstoreaccount1"}`), 0600))
+	credentials, err := GetCredentialsFromFile(path)
+	a.NoError(err)
+	a.Equal(&Credentials{AccountName: "devstoreaccount1", AccountKey: "a2V5", ServiceURL: "http://127.0.0.1:10000/devstoreaccount1"}, credentials)
+	client, err := NewClient(container, credentials)
+	a.NoError(err)
+	a.Equal(container, client.GetBucket())
+
+	missingKeyPath := filepath.Join(dir, "missing-key.json")
+	a.NoError(os.WriteFile(missingKeyPath, []byte(`{"accountName": "devstoreaccount1"}`), 0600))
+	_, err = GetCredentialsFromFile(missingKeyPath)
+	a.Error(err)
+
+	_, err = GetCredentialsFromFile(filepath.Join(dir, "not-exist.json"))
+	a.Error(err)
+}
+
+// Only for manual test against Azure or a local Azurite emulator.
+// Should be skipped in CI.
+func TestAzureOperations(t *testing.T) {
+	t.Skip()
+	a := require.New(t)
+	ctx := context.Background()
+	client, err := NewClient(container, &Credentials{
+		AccountName: os.Getenv("AZURE_STORAGE_ACCOUNT"),
+		AccountKey:  os.Getenv("AZURE_STORAGE_KEY"),
+		ServiceURL:  os.Getenv("AZURE_STORAGE_SERVICE_URL"),
+	})
+	a.NoError(err)
+
+	t.Run("UploadObjects", func(t *testing.T) {
+		buf := make([]byte, 10*1024*1024)
+		blob := bytes.NewReader(buf)
+		err := client.UploadObject(ctx, "backup/test/blob", blob)
+		a.NoError(err)
+		log.Info("Uploaded", zap.String("name", "backup/test/blob"))
+	})
+
+	t.Run("ListObjects", func(t *testing.T) {
+		list, err := client.ListObjects(ctx, "backup/")
+		a.NoError(err)
+		for _, obj := range list {
+			log.Info("Object", zap.String("Key", obj.Key), zap.Time("LastModified", obj.LastModified))
+		}
+	})
+
+	t.Run("DownloadObjects", func(t *testing.T) {
+		file, err := os.CreateTemp(t.TempDir(), "blob")
+		a.NoError(err)
+		n, err := client.DownloadObject(ctx, "backup/test/blob", file)
+		a.NoError(err)
+		log.Info("Downloaded", zap.Int64("length", n))
+	})
+
+	t.Run("DeleteObjects", func(t *testing.T) {
+		err := client.DeleteObjects(ctx, "backup/test/blob")
+		a.NoError(err)
+		log.Info("Deleted", zap.String("name", "backup/test/blob"))
+	})
+}
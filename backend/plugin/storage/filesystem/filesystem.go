@@ -0,0 +1,137 @@
+// Package filesystem provides the storage backend on a mounted file system, such as an NFS share.
+package filesystem
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+)
+
+var _ storage.Backend = (*Client)(nil)
+
+// tempSuffix is the suffix of files being uploaded.
+const tempSuffix = ".uploading"
+
+// Client stores objects as files under a root directory.
+type Client struct {
+	root string
+}
+
+// NewClient returns a new file system storage client with root directory.
+func NewClient(root string) (*Client, error) {
+	if !filepath.IsAbs(root) {
+		return nil, errors.Errorf("root directory %q must be an absolute path", root)
+	}
+	if err := os.MkdirAll(root, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "failed to create root directory %q", root)
+	}
+	return &Client{root: root}, nil
+}
+
+// ListObjects lists objects with prefix in their names.
+func (c *Client) ListObjects(_ context.Context, prefix string) ([]storage.ObjectInfo, error) {
+	var ret []storage.ObjectInfo
+	err := filepath.WalkDir(c.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(c.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) || strings.HasSuffix(key, tempSuffix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				// The file is deleted during the walk.
+				return nil
+			}
+			return err
+		}
+		ret = append(ret, storage.ObjectInfo{
+			Key:          key,
+			LastModified: info.ModTime(),
+			Size:         info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list objects with prefix %q", prefix)
+	}
+	return ret, nil
+}
+
+// DownloadObject downloads the object with path.
+func (c *Client) DownloadObject(_ context.Context, path string, w io.WriterAt) (int64, error) {
+	f, err := os.Open(c.getFilePath(path))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to open object %q", path)
+	}
+	defer f.Close()
+	n, err := io.Copy(io.NewOffsetWriter(w, 0), f)
+	if err != nil {
+		return n, errors.Wrapf(err, "failed to read object %q", path)
+	}
+	return n, nil
+}
+
+// UploadObject uploads an object with the path.
+// The object is first written to a temporary file and then renamed, so that readers never see a partially written object.
+func (c *Client) UploadObject(_ context.Context, path string, body io.Reader) error {
+	filePath := c.getFilePath(path)
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return errors.Wrapf(err, "failed to create directory for object %q", path)
+	}
+	filePathTemp := filePath + tempSuffix
+	f, err := os.Create(filePathTemp)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create object %q", path)
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close()
+		os.Remove(filePathTemp)
+		return errors.Wrapf(err, "failed to write object %q", path)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(filePathTemp)
+		return errors.Wrapf(err, "failed to write object %q", path)
+	}
+	if err := os.Rename(filePathTemp, filePath); err != nil {
+		return errors.Wrapf(err, "failed to rename %q to %q", filePathTemp, filePath)
+	}
+	return nil
+}
+
+// DeleteObjects deletes the objects with path.
+// Deleting an object that does not exist is not an error.
+func (c *Client) DeleteObjects(_ context.Context, pathList ...string) error {
+	for _, path := range pathList {
+		if err := os.Remove(c.getFilePath(path)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to delete object %q", path)
+		}
+	}
+	return nil
+}
+
+// GetBucket returns the root directory.
+func (c *Client) GetBucket() string {
+	return c.root
+}
+
+func (c *Client) getFilePath(path string) string {
+	// filepath.Join cleans the path, so that ".." cannot escape the root directory.
+	return filepath.Join(c.root, filepath.FromSlash(filepath.Join("/", path)))
+}
@@ -0,0 +1,52 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemOperations(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	client, err := NewClient(t.TempDir())
+	a.NoError(err)
+
+	a.NoError(client.UploadObject(ctx, "backup/db/1/a.sql", strings.NewReader("CREATE TABLE a(id INT);")))
+	a.NoError(client.UploadObject(ctx, "backup/db/2/b.sql", strings.NewReader("CREATE TABLE b(id INT);")))
+	a.NoError(client.UploadObject(ctx, "binlog/1/binlog.000001", strings.NewReader("binlog")))
+
+	list, err := client.ListObjects(ctx, "backup/")
+	a.NoError(err)
+	var keys []string
+	for _, object := range list {
+		keys = append(keys, object.Key)
+	}
+	sort.Strings(keys)
+	a.Equal([]string{"backup/db/1/a.sql", "backup/db/2/b.sql"}, keys)
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "a.sql"))
+	a.NoError(err)
+	defer file.Close()
+	n, err := client.DownloadObject(ctx, "backup/db/1/a.sql", file)
+	a.NoError(err)
+	a.Equal(int64(len("CREATE TABLE a(id INT);")), n)
+	content, err := os.ReadFile(file.Name())
+	a.NoError(err)
+	a.Equal("CREATE TABLE a(id INT);", string(content))
+
+	a.NoError(client.DeleteObjects(ctx, "backup/db/1/a.sql", "backup/db/1/not-exist.sql"))
+	list, err = client.ListObjects(ctx, "backup/db/1/")
+	a.NoError(err)
+	a.Len(list, 0)
+
+	// Paths cannot escape the root directory.
+	a.NoError(client.UploadObject(ctx, "../../escape", strings.NewReader("x")))
+	_, err = os.Stat(filepath.Join(client.GetBucket(), "escape"))
+	a.NoError(err)
+}
@@ -4,7 +4,6 @@ package s3
 import (
 	"context"
 	"io"
-	"os"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
@@ -13,8 +12,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/storage"
 )
 
+var _ storage.Backend = (*Client)(nil)
+
 // Client wraps the AWS S3 client.
 type Client struct {
 	c      *s3.Client
@@ -52,8 +55,8 @@ func NewClient(ctx context.Context, region, bucket string, credentials aws.Crede
 }
 
 // ListObjects lists objects with prefix in their names.
-func (c *Client) ListObjects(ctx context.Context, prefix string) ([]types.Object, error) {
-	var ret []types.Object
+func (c *Client) ListObjects(ctx context.Context, prefix string) ([]storage.ObjectInfo, error) {
+	var ret []storage.ObjectInfo
 	paginator := s3.NewListObjectsV2Paginator(c.c, &s3.ListObjectsV2Input{
 		Bucket: &c.bucket,
 		Prefix: &prefix,
@@ -63,7 +66,13 @@ func (c *Client) ListObjects(ctx context.Context, prefix string) ([]types.Object
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to load the next page of S3 objects")
 		}
-		ret = append(ret, output.Contents...)
+		for _, object := range output.Contents {
+			ret = append(ret, storage.ObjectInfo{
+				Key:          aws.ToString(object.Key),
+				LastModified: aws.ToTime(object.LastModified),
+				Size:         object.Size,
+			})
+		}
 	}
 	return ret, nil
 }
@@ -80,48 +89,36 @@ func (c *Client) DownloadObject(ctx context.Context, path string, w io.WriterAt)
 
 // UploadObject uploads an object with the path.
 // Defaults to multipart upload with chunk size 5MB.
-func (c *Client) UploadObject(ctx context.Context, path string, body io.Reader) (*manager.UploadOutput, error) {
+func (c *Client) UploadObject(ctx context.Context, path string, body io.Reader) error {
 	uploader := manager.NewUploader(c.c)
-	return uploader.Upload(ctx, &s3.PutObjectInput{
+	if _, err := uploader.Upload(ctx, &s3.PutObjectInput{
 		Bucket:            &c.bucket,
 		Key:               &path,
 		Body:              body,
 		ChecksumAlgorithm: types.ChecksumAlgorithmSha256,
-	})
+	}); err != nil {
+		return errors.Wrapf(err, "failed to upload object %q", path)
+	}
+	return nil
 }
 
 // DeleteObjects deletes the objects with path.
-func (c *Client) DeleteObjects(ctx context.Context, pathList ...string) (*s3.DeleteObjectsOutput, error) {
+func (c *Client) DeleteObjects(ctx context.Context, pathList ...string) error {
 	var oidList []types.ObjectIdentifier
 	for _, path := range pathList {
 		path := path // create a new 'path'.
 		oidList = append(oidList, types.ObjectIdentifier{Key: &path})
 	}
-	return c.c.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+	if _, err := c.c.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 		Bucket: &c.bucket,
 		Delete: &types.Delete{Objects: oidList},
-	})
+	}); err != nil {
+		return errors.Wrapf(err, "failed to delete %d objects", len(pathList))
+	}
+	return nil
 }
 
 // GetBucket returns the bucket.
 func (c *Client) GetBucket() string {
 	return c.bucket
 }
-
-// DownloadFileFromCloud downloads a binlog or metadata file from the cloud storage.
-// In case of network errors which will get partially downloaded files, we first download to a temporary file.
-// After that, we then rename it to the target file path.
-func (c *Client) DownloadFileFromCloud(ctx context.Context, filePathLocal, filePathOnCloud string) error {
-	filePathTemp := filePathLocal + ".tmp"
-	fileTemp, err := os.Create(filePathTemp)
-	if err != nil {
-		return errors.Wrapf(err, "failed to create the local temporary file %s", filePathTemp)
-	}
-	if _, err := c.DownloadObject(ctx, filePathOnCloud, fileTemp); err != nil {
-		return errors.Wrapf(err, "failed to download file %q from the cloud storage", filePathOnCloud)
-	}
-	if err := os.Rename(filePathTemp, filePathLocal); err != nil {
-		return errors.Wrapf(err, "failed to rename %q to %q", filePathTemp, filePathLocal)
-	}
-	return nil
-}
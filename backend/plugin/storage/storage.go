@@ -0,0 +1,58 @@
+// Package storage defines the interface of the storage backends for backups and binlogs.
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ObjectInfo is the information of an object in the storage backend.
+type ObjectInfo struct {
+	// Key is the path of the object relative to the root of the storage backend.
+	// It always uses / as the separator.
+	Key          string
+	LastModified time.Time
+	Size         int64
+}
+
+// Backend is the interface of a storage backend, such as AWS S3, Azure Blob Storage or a mounted file system.
+type Backend interface {
+	// ListObjects lists objects with prefix in their names.
+	ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// DownloadObject downloads the object with path and writes it to w.
+	// It returns the number of bytes written.
+	DownloadObject(ctx context.Context, path string, w io.WriterAt) (int64, error)
+	// UploadObject uploads an object with the path.
+	UploadObject(ctx context.Context, path string, body io.Reader) error
+	// DeleteObjects deletes the objects with path.
+	DeleteObjects(ctx context.Context, pathList ...string) error
+	// GetBucket returns the bucket, container or root directory of the storage backend.
+	GetBucket() string
+}
+
+// DownloadFileFromCloud downloads a backup, binlog or metadata file from the storage backend.
+// In case of network errors which will get partially downloaded files, we first download to a temporary file.
+// After that, we then rename it to the target file path.
+func DownloadFileFromCloud(ctx context.Context, backend Backend, filePathLocal, filePathOnCloud string) error {
+	filePathTemp := filePathLocal + ".tmp"
+	fileTemp, err := os.Create(filePathTemp)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the local temporary file %s", filePathTemp)
+	}
+	if _, err := backend.DownloadObject(ctx, filePathOnCloud, fileTemp); err != nil {
+		fileTemp.Close()
+		os.Remove(filePathTemp)
+		return errors.Wrapf(err, "failed to download file %q from the cloud storage", filePathOnCloud)
+	}
+	if err := fileTemp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close the local temporary file %s", filePathTemp)
+	}
+	if err := os.Rename(filePathTemp, filePathLocal); err != nil {
+		return errors.Wrapf(err, "failed to rename %q to %q", filePathTemp, filePathLocal)
+	}
+	return nil
+}
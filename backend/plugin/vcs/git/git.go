@@ -0,0 +1,692 @@
+// Package git is the plugin for generic Git servers accessed via plain HTTPS or SSH.
+//
+// Generic Git servers have no REST API, so the provider drives the git CLI
+// against a temporary bare mirror of the repository, which is fetched for each
+// operation and removed afterwards. They have no webhooks either,
+// so new commits are detected by polling the branch heads, see ListBranchHeads
+// and ListCommits.
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+)
+
+const (
+	// emptyTreeID is the ID of the empty tree object, used to diff against the root commit.
+	emptyTreeID = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+	// zeroCommitID is the commit ID used by Git when the branch does not exist before or after a push.
+	zeroCommitID = "0000000000000000000000000000000000000000"
+	// defaultUsername is the username for HTTPS basic auth if the application ID is not set.
+	defaultUsername = "git"
+)
+
+// scpLikeURLRegexp matches the SCP-like SSH URLs such as "git@git.example.com:octocat/hello.git".
+// The host must not start with "-", otherwise it's taken as an option by SSH.
+var scpLikeURLRegexp = regexp.MustCompile(`^git@[A-Za-z0-9][A-Za-z0-9.\-]*:[^/\-]`)
+
+func init() {
+	vcs.Register(vcs.Git, newProvider)
+}
+
+var _ vcs.Provider = (*Provider)(nil)
+
+// Provider is a generic Git VCS provider.
+type Provider struct {
+	// tempDir is the directory to create the temporary mirrors of the repositories in.
+	tempDir string
+	// allowFileURL allows the file:// URLs, which are only used in tests.
+	allowFileURL bool
+}
+
+func newProvider(vcs.ProviderConfig) vcs.Provider {
+	return &Provider{
+		tempDir: filepath.Join(os.TempDir(), "bytebase", "git"),
+	}
+}
+
+// APIURL returns the instance URL as generic Git servers have no API.
+func (*Provider) APIURL(instanceURL string) string {
+	return instanceURL
+}
+
+// ExchangeOAuthToken returns the code as the access token.
+//
+// Generic Git servers have no OAuth, so the code is the static credential used
+// for HTTPS basic auth, e.g. a password or a personal access token.
+func (*Provider) ExchangeOAuthToken(_ context.Context, _ string, oauthExchange *common.OAuthExchange) (*vcs.OAuthToken, error) {
+	return &vcs.OAuthToken{
+		AccessToken: oauthExchange.Code,
+	}, nil
+}
+
+// TryLogin tries to fetch the user info from the current OAuth context.
+//
+// WARNING: This is not supported by generic Git servers.
+func (*Provider) TryLogin(context.Context, common.OauthContext, string) (*vcs.UserInfo, error) {
+	return nil, errors.New("not supported")
+}
+
+// FetchCommitByID fetches the commit data by its ID from the repository.
+func (p *Provider) FetchCommitByID(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, commitID string) (*vcs.Commit, error) {
+	r, err := p.open(ctx, oauthCtx, instanceURL, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer r.close()
+
+	if _, err := r.git(ctx, nil, "cat-file", "-e", commitID+"^{commit}"); err != nil {
+		return nil, common.Errorf(common.NotFound, "commit %q not found in repository %s", commitID, r.url)
+	}
+	return r.readCommit(ctx, commitID)
+}
+
+// GetDiffFileList gets the diff files list between two commits.
+func (p *Provider) GetDiffFileList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, beforeCommit, afterCommit string) ([]vcs.FileDiff, error) {
+	r, err := p.open(ctx, oauthCtx, instanceURL, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer r.close()
+
+	if beforeCommit == "" || beforeCommit == zeroCommitID {
+		beforeCommit = emptyTreeID
+	}
+	return r.diff(ctx, beforeCommit, afterCommit)
+}
+
+// FetchAllRepositoryList fetches all repositories within a given user's scope.
+//
+// WARNING: This is not supported by generic Git servers, the repository must be specified by its path.
+func (*Provider) FetchAllRepositoryList(context.Context, common.OauthContext, string) ([]*vcs.Repository, error) {
+	return nil, errors.New("not supported")
+}
+
+// FetchRepositoryFileList fetches the all files from the given repository tree
+// recursively.
+func (p *Provider) FetchRepositoryFileList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, ref, filePath string) ([]*vcs.RepositoryTreeNode, error) {
+	r, err := p.open(ctx, oauthCtx, instanceURL, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer r.close()
+
+	out, err := r.git(ctx, nil, "ls-tree", "-r", "-z", "--full-tree", ref)
+	if err != nil {
+		return nil, common.Errorf(common.NotFound, "failed to list files of %q in repository %s: %v", ref, r.url, err)
+	}
+
+	if filePath != "" && !strings.HasSuffix(filePath, "/") {
+		filePath += "/"
+	}
+
+	var allTreeNodes []*vcs.RepositoryTreeNode
+	for _, line := range strings.Split(out, "\x00") {
+		// Each line is formatted as "<mode> SP <type> SP <object> TAB <file>".
+		meta, path, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		if strings.HasPrefix(path, filePath) {
+			allTreeNodes = append(allTreeNodes,
+				&vcs.RepositoryTreeNode{
+					Path: path,
+					Type: fields[1],
+				},
+			)
+		}
+	}
+	return allTreeNodes, nil
+}
+
+// CreateFile creates a file at given path in the repository.
+//
+// Same as GitHub, the existing file is overwritten if the SHA of the file is provided.
+func (p *Provider) CreateFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath string, fileCommitCreate vcs.FileCommitCreate) error {
+	return p.writeFile(ctx, oauthCtx, instanceURL, repositoryID, filePath, fileCommitCreate, false /* overwrite */)
+}
+
+// OverwriteFile overwrites an existing file at given path in the repository.
+func (p *Provider) OverwriteFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath string, fileCommitCreate vcs.FileCommitCreate) error {
+	return p.writeFile(ctx, oauthCtx, instanceURL, repositoryID, filePath, fileCommitCreate, true /* overwrite */)
+}
+
+func (p *Provider) writeFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath string, fileCommitCreate vcs.FileCommitCreate, overwrite bool) error {
+	r, err := p.open(ctx, oauthCtx, instanceURL, repositoryID)
+	if err != nil {
+		return err
+	}
+	defer r.close()
+
+	parent, err := r.resolveBranch(ctx, fileCommitCreate.Branch)
+	if err != nil {
+		return err
+	}
+	var sha string
+	found := false
+	if parent != "" {
+		if sha, _, found, err = r.lookupFile(ctx, parent, filePath); err != nil {
+			return err
+		}
+	}
+	switch {
+	case overwrite && !found:
+		return common.Errorf(common.NotFound, "file %q not found on branch %q", filePath, fileCommitCreate.Branch)
+	case !overwrite && found && fileCommitCreate.SHA == "":
+		return errors.Errorf("file %q already exists on branch %q", filePath, fileCommitCreate.Branch)
+	}
+
+	if found {
+		// Detect conflicting writes the same way as the hosted providers, the file
+		// must not be changed since the caller read it.
+		if fileCommitCreate.SHA != "" && fileCommitCreate.SHA != sha {
+			return errors.Errorf("file %q has been changed, expect blob %s but got %s", filePath, fileCommitCreate.SHA, sha)
+		}
+		if fileCommitCreate.LastCommitID != "" {
+			lastCommitID, err := r.git(ctx, nil, "log", "-1", "--format=%H", parent, "--", filePath)
+			if err != nil {
+				return err
+			}
+			if lastCommitID = strings.TrimSpace(lastCommitID); lastCommitID != fileCommitCreate.LastCommitID {
+				return errors.Errorf("file %q has been changed, expect last commit %s but got %s", filePath, fileCommitCreate.LastCommitID, lastCommitID)
+			}
+		}
+	}
+	return r.commitFile(ctx, parent, filePath, fileCommitCreate)
+}
+
+// ReadFileMeta reads the metadata of the given file in the repository.
+func (p *Provider) ReadFileMeta(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath, ref string) (*vcs.FileMeta, error) {
+	r, err := p.open(ctx, oauthCtx, instanceURL, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer r.close()
+
+	sha, size, found, err := r.lookupFile(ctx, ref, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, common.Errorf(common.NotFound, "file %q not found at %q in repository %s", filePath, ref, r.url)
+	}
+	lastCommitID, err := r.git(ctx, nil, "log", "-1", "--format=%H", ref, "--", filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vcs.FileMeta{
+		Name:         filepath.Base(filePath),
+		Path:         filePath,
+		Size:         size,
+		SHA:          sha,
+		LastCommitID: strings.TrimSpace(lastCommitID),
+	}, nil
+}
+
+// ReadFileContent reads the content of the given file in the repository.
+func (p *Provider) ReadFileContent(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath, ref string) (string, error) {
+	r, err := p.open(ctx, oauthCtx, instanceURL, repositoryID)
+	if err != nil {
+		return "", err
+	}
+	defer r.close()
+
+	sha, _, found, err := r.lookupFile(ctx, ref, filePath)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", common.Errorf(common.NotFound, "file %q not found at %q in repository %s", filePath, ref, r.url)
+	}
+	return r.git(ctx, nil, "cat-file", "blob", sha)
+}
+
+// GetBranch gets the given branch in the repository.
+func (p *Provider) GetBranch(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, branchName string) (*vcs.BranchInfo, error) {
+	heads, err := p.ListBranchHeads(ctx, oauthCtx, instanceURL, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	commitID, ok := heads[branchName]
+	if !ok {
+		return nil, common.Errorf(common.NotFound, "branch %q not found in repository %s", branchName, repositoryID)
+	}
+	return &vcs.BranchInfo{
+		Name:         branchName,
+		LastCommitID: commitID,
+	}, nil
+}
+
+// CreateBranch creates the branch in the repository.
+func (p *Provider) CreateBranch(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID string, branch *vcs.BranchInfo) error {
+	r, err := p.open(ctx, oauthCtx, instanceURL, repositoryID)
+	if err != nil {
+		return err
+	}
+	defer r.close()
+
+	if _, err := r.git(ctx, nil, "push", "--", r.url, fmt.Sprintf("%s:refs/heads/%s", branch.LastCommitID, branch.Name)); err != nil {
+		return errors.Wrapf(err, "failed to create branch %q", branch.Name)
+	}
+	_, err = r.git(ctx, nil, "update-ref", "refs/heads/"+branch.Name, branch.LastCommitID)
+	return err
+}
+
+// ListPullRequestFile lists the changed files in the pull request.
+//
+// WARNING: This is not supported by generic Git servers.
+func (*Provider) ListPullRequestFile(context.Context, common.OauthContext, string, string, string) ([]*vcs.PullRequestFile, error) {
+	return nil, errors.New("not supported")
+}
+
+// CreatePullRequest creates the pull request in the repository.
+//
+// WARNING: This is not supported by generic Git servers.
+func (*Provider) CreatePullRequest(context.Context, common.OauthContext, string, string, *vcs.PullRequestCreate) (*vcs.PullRequest, error) {
+	return nil, errors.New("not supported")
+}
+
+// UpsertEnvironmentVariable creates or updates the environment variable in the repository.
+//
+// WARNING: This is not supported by generic Git servers.
+func (*Provider) UpsertEnvironmentVariable(context.Context, common.OauthContext, string, string, string, string) error {
+	return errors.New("not supported")
+}
+
+// CreateWebhook is a no-op and returns an empty webhook ID, because generic Git
+// servers have no webhooks and the push events are detected by polling.
+func (*Provider) CreateWebhook(context.Context, common.OauthContext, string, string, []byte) (string, error) {
+	return "", nil
+}
+
+// PatchWebhook is a no-op because generic Git servers have no webhooks.
+func (*Provider) PatchWebhook(context.Context, common.OauthContext, string, string, string, []byte) error {
+	return nil
+}
+
+// DeleteWebhook is a no-op because generic Git servers have no webhooks.
+func (*Provider) DeleteWebhook(context.Context, common.OauthContext, string, string, string) error {
+	return nil
+}
+
+// ListBranchHeads lists the branches of the repository along with the commit IDs they point to.
+func (p *Provider) ListBranchHeads(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID string) (map[string]string, error) {
+	url, err := p.cloneURL(instanceURL, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	out, err := runGit(ctx, "", p.env(oauthCtx, url), nil, "ls-remote", "--heads", "--", url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list branches of repository %s", url)
+	}
+
+	heads := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		commitID, ref, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok {
+			continue
+		}
+		heads[strings.TrimPrefix(ref, "refs/heads/")] = commitID
+	}
+	return heads, nil
+}
+
+// ListCommits lists the commits reachable from the after commit but not from the
+// before commit in chronological order, with the files added and modified by each commit.
+// If before is empty or the zero commit ID, only the after commit is listed.
+func (p *Provider) ListCommits(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, before, after string) ([]vcs.Commit, error) {
+	r, err := p.open(ctx, oauthCtx, instanceURL, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer r.close()
+
+	var commitIDs []string
+	if before == "" || before == zeroCommitID {
+		commitIDs = []string{after}
+	} else {
+		out, err := r.git(ctx, nil, "rev-list", "--reverse", before+".."+after)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list commits between %s and %s", before, after)
+		}
+		commitIDs = strings.Fields(out)
+	}
+
+	var commits []vcs.Commit
+	for _, commitID := range commitIDs {
+		commit, err := r.readCommit(ctx, commitID)
+		if err != nil {
+			return nil, err
+		}
+		parent := emptyTreeID
+		if out, err := r.git(ctx, nil, "rev-parse", "--verify", "--quiet", commitID+"^1"); err == nil {
+			parent = strings.TrimSpace(out)
+		}
+		fileDiffList, err := r.diff(ctx, parent, commitID)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range fileDiffList {
+			switch f.Type {
+			case vcs.FileDiffTypeAdded:
+				commit.AddedList = append(commit.AddedList, f.Path)
+			case vcs.FileDiffTypeModified:
+				commit.ModifiedList = append(commit.ModifiedList, f.Path)
+			}
+		}
+		commits = append(commits, *commit)
+	}
+	return commits, nil
+}
+
+// repository is a temporary bare mirror of a remote repository, it's removed when closed.
+type repository struct {
+	dir string
+	url string
+	env []string
+}
+
+// open fetches the remote repository into a temporary bare mirror.
+func (p *Provider) open(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID string) (*repository, error) {
+	url, err := p.cloneURL(instanceURL, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(p.tempDir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "failed to create directory for the mirror of repository %s", url)
+	}
+	dir, err := os.MkdirTemp(p.tempDir, "mirror-")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create directory for the mirror of repository %s", url)
+	}
+
+	r := &repository{
+		dir: dir,
+		url: url,
+		env: p.env(oauthCtx, url),
+	}
+	if err := r.fetch(ctx); err != nil {
+		r.close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *repository) close() {
+	_ = os.RemoveAll(r.dir)
+}
+
+func (r *repository) fetch(ctx context.Context) error {
+	if _, err := r.git(ctx, nil, "init", "--bare", "--quiet"); err != nil {
+		return err
+	}
+	// The URL is passed to fetch instead of being saved as a remote, and "--"
+	// makes sure it's never taken as an option.
+	if _, err := r.git(ctx, nil, "fetch", "--quiet", "--prune", "--force", "--", r.url, "+refs/heads/*:refs/heads/*"); err != nil {
+		return errors.Wrapf(err, "failed to fetch repository %s", r.url)
+	}
+	return nil
+}
+
+func (r *repository) git(ctx context.Context, env []string, args ...string) (string, error) {
+	return runGit(ctx, r.dir, append(r.env, env...), nil, args...)
+}
+
+// resolveBranch returns the commit ID the branch points to, or empty if the branch does not exist.
+func (r *repository) resolveBranch(ctx context.Context, branch string) (string, error) {
+	if branch == "" {
+		return "", errors.New("branch is required")
+	}
+	out, err := r.git(ctx, nil, "for-each-ref", "--format=%(objectname)", "refs/heads/"+branch)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// lookupFile returns the blob ID and the size of the file at the given ref.
+func (r *repository) lookupFile(ctx context.Context, ref, filePath string) (sha string, size int64, found bool, err error) {
+	out, err := r.git(ctx, nil, "ls-tree", "-l", "-z", "--full-tree", ref, "--", filePath)
+	if err != nil {
+		return "", 0, false, common.Errorf(common.NotFound, "failed to read %q in repository %s: %v", ref, r.url, err)
+	}
+	// The output is formatted as "<mode> SP <type> SP <object> SP <object size> TAB <file>".
+	meta, _, ok := strings.Cut(strings.TrimSuffix(out, "\x00"), "\t")
+	if !ok {
+		return "", 0, false, nil
+	}
+	fields := strings.Fields(meta)
+	if len(fields) != 4 || fields[1] != "blob" {
+		return "", 0, false, nil
+	}
+	size, err = strconv.ParseInt(fields[3], 10, 64)
+	if err != nil {
+		return "", 0, false, errors.Wrapf(err, "failed to parse size of file %q", filePath)
+	}
+	return fields[2], size, true, nil
+}
+
+// commitFile commits the file on top of the parent commit and pushes it to the branch.
+// The push is rejected if the branch has moved since it was fetched.
+func (r *repository) commitFile(ctx context.Context, parent, filePath string, fileCommitCreate vcs.FileCommitCreate) error {
+	blob, err := runGit(ctx, r.dir, r.env, strings.NewReader(fileCommitCreate.Content), "hash-object", "-w", "--stdin")
+	if err != nil {
+		return err
+	}
+	blob = strings.TrimSpace(blob)
+
+	index, err := os.CreateTemp("", "bytebase-git-index-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary index")
+	}
+	_ = index.Close()
+	// git refuses to read an empty file as index, so it's removed and git will create it.
+	_ = os.Remove(index.Name())
+	defer os.Remove(index.Name())
+	indexEnv := []string{"GIT_INDEX_FILE=" + index.Name()}
+
+	if parent != "" {
+		if _, err := r.git(ctx, indexEnv, "read-tree", parent); err != nil {
+			return err
+		}
+	}
+	if _, err := r.git(ctx, indexEnv, "update-index", "--add", "--cacheinfo", fmt.Sprintf("100644,%s,%s", blob, filePath)); err != nil {
+		return err
+	}
+	tree, err := r.git(ctx, indexEnv, "write-tree")
+	if err != nil {
+		return err
+	}
+
+	authorName, authorEmail := fileCommitCreate.AuthorName, fileCommitCreate.AuthorEmail
+	if authorName == "" || authorEmail == "" {
+		authorName, authorEmail = vcs.BytebaseAuthorName, vcs.BytebaseAuthorEmail
+	}
+	commitEnv := []string{
+		"GIT_AUTHOR_NAME=" + authorName,
+		"GIT_AUTHOR_EMAIL=" + authorEmail,
+		"GIT_COMMITTER_NAME=" + vcs.BytebaseAuthorName,
+		"GIT_COMMITTER_EMAIL=" + vcs.BytebaseAuthorEmail,
+	}
+	args := []string{"commit-tree", strings.TrimSpace(tree), "-m", fileCommitCreate.CommitMessage}
+	if parent != "" {
+		args = append(args, "-p", parent)
+	}
+	commit, err := r.git(ctx, commitEnv, args...)
+	if err != nil {
+		return err
+	}
+	commit = strings.TrimSpace(commit)
+
+	if _, err := r.git(ctx, nil, "push", "--quiet", "--", r.url, fmt.Sprintf("%s:refs/heads/%s", commit, fileCommitCreate.Branch)); err != nil {
+		return errors.Wrapf(err, "failed to push commit to branch %q", fileCommitCreate.Branch)
+	}
+	_, err = r.git(ctx, nil, "update-ref", "refs/heads/"+fileCommitCreate.Branch, commit)
+	return err
+}
+
+// readCommit reads the commit data without the file list.
+func (r *repository) readCommit(ctx context.Context, commitID string) (*vcs.Commit, error) {
+	out, err := r.git(ctx, nil, "log", "-1", "--format=%H%x00%an%x00%ae%x00%ct%x00%B", commitID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read commit %q", commitID)
+	}
+	fields := strings.SplitN(out, "\x00", 5)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("unexpected format of commit %q: %q", commitID, out)
+	}
+	createdTs, err := strconv.ParseInt(fields[3], 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse timestamp of commit %q", commitID)
+	}
+	message := strings.TrimRight(fields[4], "\n")
+	// Per Git convention, the message title and body are separated by two new line characters.
+	messages := strings.SplitN(message, "\n\n", 2)
+
+	return &vcs.Commit{
+		ID:          fields[0],
+		Title:       messages[0],
+		Message:     message,
+		CreatedTs:   createdTs,
+		AuthorName:  fields[1],
+		AuthorEmail: fields[2],
+	}, nil
+}
+
+// diff gets the diff files list between two tree-ish objects.
+func (r *repository) diff(ctx context.Context, before, after string) ([]vcs.FileDiff, error) {
+	out, err := r.git(ctx, nil, "diff-tree", "-r", "-z", "--name-status", "--no-renames", before, after)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to diff %s and %s", before, after)
+	}
+
+	var diffs []vcs.FileDiff
+	// The output is a list of "<status> NUL <path> NUL".
+	fields := strings.Split(strings.TrimSuffix(out, "\x00"), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		diff := vcs.FileDiff{
+			Path: fields[i+1],
+		}
+		switch fields[i] {
+		case "A":
+			diff.Type = vcs.FileDiffTypeAdded
+		case "M":
+			diff.Type = vcs.FileDiffTypeModified
+		case "D":
+			diff.Type = vcs.FileDiffTypeRemoved
+		default:
+			// Skip because we don't care about file diff in other status
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// cloneURL returns the URL to clone the repository. The repository ID is the
+// path of the repository on the Git server, or a full URL by itself.
+func (p *Provider) cloneURL(instanceURL, repositoryID string) (string, error) {
+	cloneURL := repositoryID
+	if !strings.Contains(repositoryID, "://") && !strings.HasPrefix(repositoryID, "git@") {
+		cloneURL = fmt.Sprintf("%s/%s", strings.TrimRight(instanceURL, "/"), strings.TrimLeft(repositoryID, "/"))
+	}
+	if err := validateCloneURL(cloneURL, p.allowFileURL); err != nil {
+		return "", err
+	}
+	return cloneURL, nil
+}
+
+// validateCloneURL validates the URL passed to the git CLI. Only HTTP(S) and SSH
+// URLs are allowed, other transports such as "file://" and "ext::" may read the
+// files or run commands on the Bytebase server.
+func validateCloneURL(cloneURL string, allowFileURL bool) error {
+	if strings.HasPrefix(cloneURL, "-") {
+		return common.Errorf(common.Invalid, "invalid repository URL %q", cloneURL)
+	}
+	if scpLikeURLRegexp.MatchString(cloneURL) {
+		return nil
+	}
+	u, err := url.Parse(cloneURL)
+	if err != nil {
+		return common.Errorf(common.Invalid, "invalid repository URL %q: %v", cloneURL, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "ssh":
+		if u.Hostname() == "" || strings.HasPrefix(u.Hostname(), "-") {
+			return common.Errorf(common.Invalid, "invalid host in repository URL %q", cloneURL)
+		}
+		return nil
+	case "file":
+		if allowFileURL {
+			return nil
+		}
+	}
+	return common.Errorf(common.Invalid, "unsupported repository URL %q, only HTTP(S) and SSH URLs are allowed", cloneURL)
+}
+
+// env returns the environment variables to run the git CLI against the URL.
+func (p *Provider) env(oauthCtx common.OauthContext, url string) []string {
+	// Restrict the transports git may use, including the ones of the submodules and redirects.
+	allowProtocol := "http:https:ssh"
+	if p.allowFileURL {
+		allowProtocol += ":file"
+	}
+	return append([]string{"GIT_ALLOW_PROTOCOL=" + allowProtocol}, authEnv(oauthCtx, url)...)
+}
+
+// authEnv returns the environment variables to authenticate HTTPS requests with
+// basic auth. SSH relies on the SSH configuration of the Bytebase server instead.
+//
+// The credentials are passed as environment variables rather than command line
+// arguments so that they are not exposed in the process list.
+func authEnv(oauthCtx common.OauthContext, url string) []string {
+	if oauthCtx.AccessToken == "" || !(strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://")) {
+		return nil
+	}
+	username := oauthCtx.ClientID
+	if username == "" {
+		username = defaultUsername
+	}
+	credential := base64.StdEncoding.EncodeToString([]byte(username + ":" + oauthCtx.AccessToken))
+	return []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.extraHeader",
+		"GIT_CONFIG_VALUE_0=Authorization: Basic " + credential,
+	}
+}
+
+func runGit(ctx context.Context, dir string, env []string, stdin io.Reader, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	// Never prompt for credentials, which would hang the server.
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Env = append(cmd.Env, env...)
+	cmd.Stdin = stdin
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "git %s: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
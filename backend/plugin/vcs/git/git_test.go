@@ -0,0 +1,247 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+)
+
+// newTestRepository creates a bare repository "octocat/hello.git" with a commit on main,
+// and returns the instance URL along with a function to commit files to main.
+func newTestRepository(t *testing.T) (string, func(files map[string]string, message string) string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	a := require.New(t)
+	instanceDir := t.TempDir()
+	bareDir := filepath.Join(instanceDir, "octocat", "hello.git")
+	workDir := t.TempDir()
+
+	gitCmd := func(dir string, args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=The Octocat",
+			"GIT_AUTHOR_EMAIL=octocat@example.com",
+			"GIT_COMMITTER_NAME=The Octocat",
+			"GIT_COMMITTER_EMAIL=octocat@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		a.NoError(err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	a.NoError(os.MkdirAll(bareDir, 0700))
+	gitCmd(bareDir, "init", "--bare", "--quiet", "--initial-branch=main")
+	gitCmd(workDir, "init", "--quiet", "--initial-branch=main")
+
+	commit := func(files map[string]string, message string) string {
+		for path, content := range files {
+			a.NoError(os.MkdirAll(filepath.Join(workDir, filepath.Dir(path)), 0700))
+			a.NoError(os.WriteFile(filepath.Join(workDir, path), []byte(content), 0600))
+		}
+		gitCmd(workDir, "add", "-A")
+		gitCmd(workDir, "commit", "--quiet", "-m", message)
+		gitCmd(workDir, "push", "--quiet", "--force", bareDir, "main")
+		return gitCmd(workDir, "rev-parse", "HEAD")
+	}
+	commit(map[string]string{"README.md": "hello"}, "Initial commit")
+	return "file://" + instanceDir, commit
+}
+
+func newTestProvider(t *testing.T) *Provider {
+	return &Provider{tempDir: t.TempDir(), allowFileURL: true}
+}
+
+func TestProvider_ReadAndDiff(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	instanceURL, commit := newTestRepository(t)
+	p := newTestProvider(t)
+
+	heads, err := p.ListBranchHeads(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git")
+	a.NoError(err)
+	before := heads["main"]
+	a.NotEmpty(before)
+
+	commit(map[string]string{"migrations/1__init.sql": "CREATE TABLE t(id INT);"}, "Add init migration")
+	after := commit(map[string]string{"README.md": "hello world", "migrations/2__add.sql": "ALTER TABLE t ADD c INT;"}, "Add column\n\nAdd the column c.")
+
+	branch, err := p.GetBranch(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "main")
+	a.NoError(err)
+	a.Equal(&vcs.BranchInfo{Name: "main", LastCommitID: after}, branch)
+	_, err = p.GetBranch(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "missing")
+	a.Equal(common.NotFound, common.ErrorCode(err))
+
+	got, err := p.FetchCommitByID(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", after)
+	a.NoError(err)
+	a.Equal(after, got.ID)
+	a.Equal("Add column", got.Title)
+	a.Equal("Add column\n\nAdd the column c.", got.Message)
+	a.Equal("The Octocat", got.AuthorName)
+	a.Equal("octocat@example.com", got.AuthorEmail)
+
+	diffs, err := p.GetDiffFileList(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", before, after)
+	a.NoError(err)
+	a.Equal([]vcs.FileDiff{
+		{Path: "README.md", Type: vcs.FileDiffTypeModified},
+		{Path: "migrations/1__init.sql", Type: vcs.FileDiffTypeAdded},
+		{Path: "migrations/2__add.sql", Type: vcs.FileDiffTypeAdded},
+	}, diffs)
+
+	commits, err := p.ListCommits(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", before, after)
+	a.NoError(err)
+	a.Len(commits, 2)
+	a.Equal("Add init migration", commits[0].Title)
+	a.Equal([]string{"migrations/1__init.sql"}, commits[0].AddedList)
+	a.Empty(commits[0].ModifiedList)
+	a.Equal(after, commits[1].ID)
+	a.Equal([]string{"migrations/2__add.sql"}, commits[1].AddedList)
+	a.Equal([]string{"README.md"}, commits[1].ModifiedList)
+
+	nodes, err := p.FetchRepositoryFileList(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "main", "migrations")
+	a.NoError(err)
+	a.Equal([]*vcs.RepositoryTreeNode{
+		{Path: "migrations/1__init.sql", Type: "blob"},
+		{Path: "migrations/2__add.sql", Type: "blob"},
+	}, nodes)
+
+	content, err := p.ReadFileContent(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "migrations/2__add.sql", "main")
+	a.NoError(err)
+	a.Equal("ALTER TABLE t ADD c INT;", content)
+	_, err = p.ReadFileContent(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "missing.sql", "main")
+	a.Equal(common.NotFound, common.ErrorCode(err))
+
+	meta, err := p.ReadFileMeta(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "migrations/1__init.sql", "main")
+	a.NoError(err)
+	a.Equal("1__init.sql", meta.Name)
+	a.Equal(int64(len("CREATE TABLE t(id INT);")), meta.Size)
+	a.Equal(commits[0].ID, meta.LastCommitID)
+	a.NotEmpty(meta.SHA)
+
+	// The temporary mirrors are removed after each operation.
+	entries, err := os.ReadDir(p.tempDir)
+	a.NoError(err)
+	a.Empty(entries)
+}
+
+func TestProvider_WriteFile(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	instanceURL, commit := newTestRepository(t)
+	p := newTestProvider(t)
+
+	err := p.CreateFile(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "LATEST.sql", vcs.FileCommitCreate{
+		Branch:        "main",
+		Content:       "CREATE TABLE t(id INT);",
+		CommitMessage: "Write latest schema",
+	})
+	a.NoError(err)
+
+	// Creating an existing file fails.
+	err = p.CreateFile(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "LATEST.sql", vcs.FileCommitCreate{
+		Branch:        "main",
+		Content:       "CREATE TABLE t(id INT);",
+		CommitMessage: "Write latest schema",
+	})
+	a.Error(err)
+
+	meta, err := p.ReadFileMeta(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "LATEST.sql", "main")
+	a.NoError(err)
+	latest, err := p.FetchCommitByID(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", meta.LastCommitID)
+	a.NoError(err)
+	a.Equal("Write latest schema", latest.Title)
+	a.Equal(vcs.BytebaseAuthorName, latest.AuthorName)
+	a.Equal(vcs.BytebaseAuthorEmail, latest.AuthorEmail)
+
+	// The file has been changed by someone else since the metadata was read.
+	commit(map[string]string{"LATEST.sql": "CREATE TABLE t(id BIGINT);"}, "Change latest schema")
+	err = p.OverwriteFile(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "LATEST.sql", vcs.FileCommitCreate{
+		Branch:        "main",
+		Content:       "CREATE TABLE t(id INT, c INT);",
+		CommitMessage: "Update latest schema",
+		LastCommitID:  meta.LastCommitID,
+		SHA:           meta.SHA,
+	})
+	a.Error(err)
+
+	meta, err = p.ReadFileMeta(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "LATEST.sql", "main")
+	a.NoError(err)
+	err = p.OverwriteFile(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "LATEST.sql", vcs.FileCommitCreate{
+		Branch:        "main",
+		Content:       "CREATE TABLE t(id BIGINT, c INT);",
+		CommitMessage: "Update latest schema",
+		LastCommitID:  meta.LastCommitID,
+		SHA:           meta.SHA,
+	})
+	a.NoError(err)
+	content, err := p.ReadFileContent(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "LATEST.sql", "main")
+	a.NoError(err)
+	a.Equal("CREATE TABLE t(id BIGINT, c INT);", content)
+
+	// The file is also visible to a fresh mirror.
+	content, err = newTestProvider(t).ReadFileContent(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "LATEST.sql", "main")
+	a.NoError(err)
+	a.Equal("CREATE TABLE t(id BIGINT, c INT);", content)
+
+	branch, err := p.GetBranch(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", "main")
+	a.NoError(err)
+	err = p.CreateBranch(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git", &vcs.BranchInfo{Name: "feature", LastCommitID: branch.LastCommitID})
+	a.NoError(err)
+	heads, err := p.ListBranchHeads(ctx, common.OauthContext{}, instanceURL, "octocat/hello.git")
+	a.NoError(err)
+	a.Equal(map[string]string{"main": branch.LastCommitID, "feature": branch.LastCommitID}, heads)
+}
+
+func TestCloneURL(t *testing.T) {
+	a := require.New(t)
+	p := &Provider{}
+	tests := []struct {
+		instanceURL  string
+		repositoryID string
+		want         string
+	}{
+		{"https://git.example.com/", "/octocat/hello.git", "https://git.example.com/octocat/hello.git"},
+		{"https://git.example.com", "ssh://git@git.example.com:2222/octocat/hello.git", "ssh://git@git.example.com:2222/octocat/hello.git"},
+		{"https://git.example.com", "git@git.example.com:octocat/hello.git", "git@git.example.com:octocat/hello.git"},
+	}
+	for _, test := range tests {
+		got, err := p.cloneURL(test.instanceURL, test.repositoryID)
+		a.NoError(err)
+		a.Equal(test.want, got)
+	}
+
+	for _, repositoryID := range []string{
+		"--upload-pack=touch /tmp/pwned;://",
+		"file:///var/lib/git/secret.git",
+		"ext::sh -c touch% /tmp/pwned",
+		"ssh://-oProxyCommand=touch/octocat/hello.git",
+		"git@-oProxyCommand=touch:octocat/hello.git",
+		"git@git.example.com:-octocat/hello.git",
+	} {
+		_, err := p.cloneURL("https://git.example.com", repositoryID)
+		a.Error(err, repositoryID)
+	}
+	// The instance URL is validated as well.
+	_, err := p.cloneURL("/var/lib/git", "octocat/hello.git")
+	a.Error(err)
+}
+
+func TestAuthEnv(t *testing.T) {
+	a := require.New(t)
+	a.Nil(authEnv(common.OauthContext{}, "https://git.example.com/octocat/hello.git"))
+	a.Nil(authEnv(common.OauthContext{AccessToken: "token"}, "git@git.example.com:octocat/hello.git"))
+	// base64("git:token")
+	a.Equal([]string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.extraHeader",
+		"GIT_CONFIG_VALUE_0=Authorization: Basic Z2l0OnRva2Vu",
+	}, authEnv(common.OauthContext{AccessToken: "token"}, "https://git.example.com/octocat/hello.git"))
+}
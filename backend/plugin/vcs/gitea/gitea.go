@@ -0,0 +1,1385 @@
+// Package gitea is the plugin for Gitea.
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+	"github.com/bytebase/bytebase/backend/plugin/vcs/internal/oauth"
+)
+
+const (
+	// apiPageSize is the default page size when making API requests.
+	// Gitea caps the page size with the MAX_RESPONSE_ITEMS setting, which defaults to 50.
+	apiPageSize = 50
+)
+
+func init() {
+	vcs.Register(vcs.Gitea, newProvider)
+}
+
+var _ vcs.Provider = (*Provider)(nil)
+
+// Provider is a Gitea VCS provider.
+type Provider struct {
+	client *http.Client
+}
+
+func newProvider(config vcs.ProviderConfig) vcs.Provider {
+	if config.Client == nil {
+		config.Client = &http.Client{}
+	}
+	return &Provider{
+		client: config.Client,
+	}
+}
+
+// APIURL returns the API URL path of a Gitea instance.
+func (*Provider) APIURL(instanceURL string) string {
+	return fmt.Sprintf("%s/api/v1", instanceURL)
+}
+
+// oauthResponse is a Gitea OAuth response.
+type oauthResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// toVCSOAuthToken converts the response to *vcs.OAuthToken.
+func (o oauthResponse) toVCSOAuthToken() *vcs.OAuthToken {
+	oauthToken := &vcs.OAuthToken{
+		AccessToken:  o.AccessToken,
+		RefreshToken: o.RefreshToken,
+		ExpiresIn:    o.ExpiresIn,
+		CreatedAt:    time.Now().Unix(),
+	}
+	if o.ExpiresIn != 0 {
+		oauthToken.ExpiresTs = oauthToken.CreatedAt + o.ExpiresIn
+	}
+	return oauthToken
+}
+
+// ExchangeOAuthToken exchanges OAuth content with the provided authorization code.
+//
+// Docs: https://docs.gitea.com/development/oauth2-provider
+func (p *Provider) ExchangeOAuthToken(ctx context.Context, instanceURL string, oauthExchange *common.OAuthExchange) (*vcs.OAuthToken, error) {
+	params := &url.Values{}
+	params.Set("client_id", oauthExchange.ClientID)
+	params.Set("client_secret", oauthExchange.ClientSecret)
+	params.Set("code", oauthExchange.Code)
+	params.Set("redirect_uri", oauthExchange.RedirectURL)
+	params.Set("grant_type", "authorization_code")
+	url := fmt.Sprintf("%s/login/oauth/access_token", instanceURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, errors.Wrapf(err, "construct POST %s", url)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exchange OAuth token")
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read OAuth response body, code %v", resp.StatusCode)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	oauthResp := new(oauthResponse)
+	if err := json.Unmarshal(body, oauthResp); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal OAuth response body, code %v", resp.StatusCode)
+	}
+	if oauthResp.Error != "" {
+		return nil, errors.Errorf("failed to exchange OAuth token, error: %v, error_description: %v", oauthResp.Error, oauthResp.ErrorDescription)
+	}
+	return oauthResp.toVCSOAuthToken(), nil
+}
+
+// User represents a Gitea API response for a user.
+type User struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+}
+
+// TryLogin tries to fetch the user info from the current OAuth context.
+//
+// Docs: https://try.gitea.io/api/swagger#/user/userGetCurrent
+func (p *Provider) TryLogin(ctx context.Context, oauthCtx common.OauthContext, instanceURL string) (*vcs.UserInfo, error) {
+	url := fmt.Sprintf("%s/user", p.APIURL(instanceURL))
+	code, _, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to read user info from URL %s", url)
+	} else if code >= 300 {
+		return nil, errors.Errorf("failed to read user info from URL %s, status code: %d, body: %s", url, code, body)
+	}
+
+	var user User
+	if err := json.Unmarshal([]byte(body), &user); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+	name := user.FullName
+	if name == "" {
+		name = user.Login
+	}
+	return &vcs.UserInfo{
+		PublicEmail: user.Email,
+		Name:        name,
+		State:       vcs.StateActive,
+	}, nil
+}
+
+// CommitUser represents a Gitea API response for the author or committer of a commit.
+type CommitUser struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	// Date expects corresponding JSON value is a string in RFC 3339 format,
+	// see https://pkg.go.dev/time#Time.MarshalJSON.
+	Date time.Time `json:"date"`
+}
+
+// RepoCommit represents a Gitea API response for the git data of a commit.
+type RepoCommit struct {
+	Message string     `json:"message"`
+	Author  CommitUser `json:"author"`
+}
+
+// CommitAffectedFile represents a Gitea API response for a file affected by a commit.
+type CommitAffectedFile struct {
+	Filename string `json:"filename"`
+	// The status of the file, possible values are "added", "modified", "removed".
+	Status string `json:"status"`
+}
+
+// Commit represents a Gitea API response for a commit.
+type Commit struct {
+	SHA     string                `json:"sha"`
+	HTMLURL string                `json:"html_url"`
+	Commit  RepoCommit            `json:"commit"`
+	Files   []*CommitAffectedFile `json:"files"`
+}
+
+// FetchCommitByID fetches the commit data by its ID from the repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoGetSingleCommit
+func (p *Provider) FetchCommitByID(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, commitID string) (*vcs.Commit, error) {
+	url := fmt.Sprintf("%s/repos/%s/git/commits/%s", p.APIURL(instanceURL), repositoryID, commitID)
+	code, _, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "GET")
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to fetch commit data from URL %s", url)
+	} else if code >= 300 {
+		return nil, errors.Errorf("failed to fetch commit data from URL %s, status code: %d, body: %s", url, code, body)
+	}
+
+	commit := &Commit{}
+	if err := json.Unmarshal([]byte(body), commit); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+
+	return &vcs.Commit{
+		ID:          commit.SHA,
+		AuthorName:  commit.Commit.Author.Name,
+		AuthorEmail: commit.Commit.Author.Email,
+		CreatedTs:   commit.Commit.Author.Date.Unix(),
+	}, nil
+}
+
+// Compare represents a Gitea API response for comparing two commits.
+type Compare struct {
+	TotalCommits int       `json:"total_commits"`
+	Commits      []*Commit `json:"commits"`
+}
+
+// GetDiffFileList gets the diff files list between two commits.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoCompareDiff
+func (p *Provider) GetDiffFileList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, beforeCommit, afterCommit string) ([]vcs.FileDiff, error) {
+	url := fmt.Sprintf("%s/repos/%s/compare/%s...%s", p.APIURL(instanceURL), repositoryID, beforeCommit, afterCommit)
+	code, _, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to get file diff list from URL %s", url)
+	} else if code >= 300 {
+		return nil, errors.Errorf("failed to get file diff list from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	compare := &Compare{}
+	if err := json.Unmarshal([]byte(body), compare); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal file diff data from Gitea instance %s", instanceURL)
+	}
+
+	// Gitea only reports the affected files per commit, so we fold them into the
+	// overall diff in commit order, e.g. a file added and then modified is added.
+	var paths []string
+	diffTypes := make(map[string]vcs.FileDiffType)
+	for _, commit := range compare.Commits {
+		for _, file := range commit.Files {
+			var diffType vcs.FileDiffType
+			switch file.Status {
+			case "added":
+				diffType = vcs.FileDiffTypeAdded
+			case "modified":
+				diffType = vcs.FileDiffTypeModified
+			case "removed":
+				diffType = vcs.FileDiffTypeRemoved
+			default:
+				// Skip because we don't care about file diff in other status
+				continue
+			}
+
+			prev, ok := diffTypes[file.Filename]
+			if !ok {
+				paths = append(paths, file.Filename)
+				diffTypes[file.Filename] = diffType
+				continue
+			}
+			switch {
+			case prev == vcs.FileDiffTypeAdded && diffType == vcs.FileDiffTypeModified:
+				// Still a new file compared with the before commit.
+			case prev == vcs.FileDiffTypeRemoved && diffType == vcs.FileDiffTypeAdded:
+				diffTypes[file.Filename] = vcs.FileDiffTypeModified
+			default:
+				diffTypes[file.Filename] = diffType
+			}
+		}
+	}
+
+	var ret []vcs.FileDiff
+	for _, path := range paths {
+		ret = append(ret, vcs.FileDiff{
+			Path: path,
+			Type: diffTypes[path],
+		})
+	}
+	return ret, nil
+}
+
+// RepositoryPermission represents a Gitea API response for the permissions of
+// the authenticated user on a repository.
+type RepositoryPermission struct {
+	Admin bool `json:"admin"`
+	Push  bool `json:"push"`
+	Pull  bool `json:"pull"`
+}
+
+// Repository represents a Gitea API response for a repository.
+type Repository struct {
+	ID          int64                `json:"id"`
+	Name        string               `json:"name"`
+	FullName    string               `json:"full_name"`
+	HTMLURL     string               `json:"html_url"`
+	Permissions RepositoryPermission `json:"permissions"`
+}
+
+// FetchAllRepositoryList fetches all repositories where the authenticated user
+// has admin permissions, which is required to create webhook in the repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/user/userCurrentListRepos
+func (p *Provider) FetchAllRepositoryList(ctx context.Context, oauthCtx common.OauthContext, instanceURL string) ([]*vcs.Repository, error) {
+	var giteaRepos []*Repository
+	page := 1
+	for {
+		repos, err := p.fetchPaginatedRepositoryList(ctx, oauthCtx, instanceURL, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+		giteaRepos = append(giteaRepos, repos...)
+
+		if len(repos) < apiPageSize {
+			break
+		}
+		page++
+	}
+
+	var repos []*vcs.Repository
+	for _, r := range giteaRepos {
+		// We need admin permission to create webhooks in the repository.
+		if !r.Permissions.Admin {
+			continue
+		}
+		repos = append(repos,
+			&vcs.Repository{
+				ID:       r.FullName,
+				Name:     r.Name,
+				FullPath: r.FullName,
+				WebURL:   r.HTMLURL,
+			},
+		)
+	}
+	return repos, nil
+}
+
+// fetchPaginatedRepositoryList fetches repositories of the authenticated user
+// in given page.
+func (p *Provider) fetchPaginatedRepositoryList(ctx context.Context, oauthCtx common.OauthContext, instanceURL string, page int) ([]*Repository, error) {
+	params := url.Values{}
+	params.Add("limit", strconv.Itoa(apiPageSize))
+	params.Add("page", strconv.Itoa(page))
+	url := fmt.Sprintf("%s/user/repos?%s", p.APIURL(instanceURL), params.Encode())
+	code, _, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to fetch repository list from URL %s", url)
+	} else if code >= 300 {
+		return nil,
+			errors.Errorf("failed to fetch repository list from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	var repos []*Repository
+	if err := json.Unmarshal([]byte(body), &repos); err != nil {
+		return nil, errors.Wrap(err, "unmarshal")
+	}
+	return repos, nil
+}
+
+// TreeEntry represents a Gitea API response for a repository tree entry.
+type TreeEntry struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	SHA  string `json:"sha"`
+	Size int64  `json:"size"`
+}
+
+// RepositoryTree represents a Gitea API response for a repository tree.
+type RepositoryTree struct {
+	SHA       string       `json:"sha"`
+	Tree      []*TreeEntry `json:"tree"`
+	Truncated bool         `json:"truncated"`
+}
+
+// FetchRepositoryFileList fetches the all files from the given repository tree
+// recursively.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/GetTree
+func (p *Provider) FetchRepositoryFileList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, ref, filePath string) ([]*vcs.RepositoryTreeNode, error) {
+	if filePath != "" && !strings.HasSuffix(filePath, "/") {
+		filePath += "/"
+	}
+
+	var allTreeNodes []*vcs.RepositoryTreeNode
+	page := 1
+	for {
+		repoTree, err := p.fetchPaginatedRepositoryTree(ctx, oauthCtx, instanceURL, repositoryID, ref, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+
+		for _, n := range repoTree.Tree {
+			// Gitea does not support filtering by path prefix, thus simulating the
+			// behavior here.
+			if n.Type == "blob" && strings.HasPrefix(n.Path, filePath) {
+				allTreeNodes = append(allTreeNodes,
+					&vcs.RepositoryTreeNode{
+						Path: n.Path,
+						Type: n.Type,
+					},
+				)
+			}
+		}
+
+		if !repoTree.Truncated {
+			break
+		}
+		page++
+	}
+	return allTreeNodes, nil
+}
+
+// fetchPaginatedRepositoryTree fetches the repository tree recursively in
+// given page. The tree is truncated if there are more entries in next pages.
+func (p *Provider) fetchPaginatedRepositoryTree(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, ref string, page int) (*RepositoryTree, error) {
+	params := url.Values{}
+	params.Add("recursive", "true")
+	params.Add("page", strconv.Itoa(page))
+	url := fmt.Sprintf("%s/repos/%s/git/trees/%s?%s", p.APIURL(instanceURL), repositoryID, url.PathEscape(ref), params.Encode())
+	code, _, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to fetch repository file list from URL %s", url)
+	} else if code >= 300 {
+		return nil,
+			errors.Errorf("failed to fetch repository file list from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	var repoTree RepositoryTree
+	if err := json.Unmarshal([]byte(body), &repoTree); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+	return &repoTree, nil
+}
+
+// Identity represents a Gitea API request for the author of a file commit.
+type Identity struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// FileCommit represents a Gitea API request for committing a file.
+type FileCommit struct {
+	Message string    `json:"message"`
+	Content string    `json:"content"`
+	Branch  string    `json:"branch,omitempty"`
+	SHA     string    `json:"sha,omitempty"`
+	Author  *Identity `json:"author,omitempty"`
+}
+
+// CreateFile creates a file at given path in the repository.
+//
+// Same as GitHub, the existing file is overwritten if the SHA of the file is provided.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoCreateFile
+func (p *Provider) CreateFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath string, fileCommitCreate vcs.FileCommitCreate) error {
+	if fileCommitCreate.SHA != "" {
+		return p.OverwriteFile(ctx, oauthCtx, instanceURL, repositoryID, filePath, fileCommitCreate)
+	}
+	body, err := marshalFileCommit(fileCommitCreate)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/contents/%s", p.APIURL(instanceURL), repositoryID, escapeFilePath(filePath))
+	code, _, resp, err := oauth.Post(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(body),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to create file through URL %s", url)
+	} else if code >= 300 {
+		return errors.Errorf("failed to create file through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			resp,
+		)
+	}
+	return nil
+}
+
+// OverwriteFile overwrites an existing file at given path in the repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoUpdateFile
+func (p *Provider) OverwriteFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath string, fileCommitCreate vcs.FileCommitCreate) error {
+	// Gitea requires the blob SHA of the file being replaced.
+	if fileCommitCreate.SHA == "" {
+		return errors.Errorf("the SHA of file %q to be overwritten is required", filePath)
+	}
+	body, err := marshalFileCommit(fileCommitCreate)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/contents/%s", p.APIURL(instanceURL), repositoryID, escapeFilePath(filePath))
+	code, _, resp, err := oauth.Put(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(body),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "PUT %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to overwrite file through URL %s", url)
+	} else if code >= 300 {
+		return errors.Errorf("failed to overwrite file through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			resp,
+		)
+	}
+	return nil
+}
+
+// escapeFilePath escapes each segment of the file path while keeping the
+// slashes, since Gitea matches the file path against the rest of the URL path.
+func escapeFilePath(filePath string) string {
+	segments := strings.Split(filePath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func marshalFileCommit(fileCommitCreate vcs.FileCommitCreate) ([]byte, error) {
+	fileCommit := FileCommit{
+		Message: fileCommitCreate.CommitMessage,
+		Content: base64.StdEncoding.EncodeToString([]byte(fileCommitCreate.Content)),
+		Branch:  fileCommitCreate.Branch,
+		SHA:     fileCommitCreate.SHA,
+	}
+	if fileCommitCreate.AuthorName != "" && fileCommitCreate.AuthorEmail != "" {
+		fileCommit.Author = &Identity{
+			Name:  fileCommitCreate.AuthorName,
+			Email: fileCommitCreate.AuthorEmail,
+		}
+	}
+	body, err := json.Marshal(fileCommit)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal file commit")
+	}
+	return body, nil
+}
+
+// ContentsResponse represents a Gitea API response for the contents of a file.
+type ContentsResponse struct {
+	Name          string `json:"name"`
+	Path          string `json:"path"`
+	SHA           string `json:"sha"`
+	LastCommitSHA string `json:"last_commit_sha"`
+	Type          string `json:"type"`
+	Size          int64  `json:"size"`
+}
+
+// ReadFileMeta reads the metadata of the given file in the repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoGetContents
+func (p *Provider) ReadFileMeta(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath, ref string) (*vcs.FileMeta, error) {
+	url := fmt.Sprintf("%s/repos/%s/contents/%s?ref=%s", p.APIURL(instanceURL), repositoryID, escapeFilePath(filePath), url.QueryEscape(ref))
+	code, _, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to read file from URL %s", url)
+	} else if code >= 300 {
+		return nil,
+			errors.Errorf("failed to read file from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	var file ContentsResponse
+	if err = json.Unmarshal([]byte(body), &file); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+
+	if file.Type != "file" {
+		return nil, errors.Errorf("%q is not a file", filePath)
+	}
+
+	return &vcs.FileMeta{
+		Name:         file.Name,
+		Path:         file.Path,
+		Size:         file.Size,
+		SHA:          file.SHA,
+		LastCommitID: file.LastCommitSHA,
+	}, nil
+}
+
+// ReadFileContent reads the content of the given file in the repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoGetRawFile
+func (p *Provider) ReadFileContent(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath, ref string) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/raw/%s?ref=%s", p.APIURL(instanceURL), repositoryID, escapeFilePath(filePath), url.QueryEscape(ref))
+	code, _, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return "", errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return "", common.Errorf(common.NotFound, "failed to read file from URL %s", url)
+	} else if code >= 300 {
+		return "",
+			errors.Errorf("failed to read file from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+	return body, nil
+}
+
+// PayloadCommit represents a Gitea API response for the commit a branch points to.
+type PayloadCommit struct {
+	ID string `json:"id"`
+}
+
+// Branch represents a Gitea API response for a branch.
+type Branch struct {
+	Name   string        `json:"name"`
+	Commit PayloadCommit `json:"commit"`
+}
+
+// GetBranch gets the given branch in the repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoGetBranch
+func (p *Provider) GetBranch(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, branchName string) (*vcs.BranchInfo, error) {
+	url := fmt.Sprintf("%s/repos/%s/branches/%s", p.APIURL(instanceURL), repositoryID, url.PathEscape(branchName))
+	code, _, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to get branch from URL %s", url)
+	} else if code >= 300 {
+		return nil, errors.Errorf("failed to get branch from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	var branch Branch
+	if err := json.Unmarshal([]byte(body), &branch); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+
+	return &vcs.BranchInfo{
+		Name:         branch.Name,
+		LastCommitID: branch.Commit.ID,
+	}, nil
+}
+
+type branchCreate struct {
+	NewBranchName string `json:"new_branch_name"`
+	OldRefName    string `json:"old_ref_name"`
+}
+
+// CreateBranch creates the branch in the repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoCreateBranch
+func (p *Provider) CreateBranch(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID string, branch *vcs.BranchInfo) error {
+	body, err := json.Marshal(
+		branchCreate{
+			NewBranchName: branch.Name,
+			OldRefName:    branch.LastCommitID,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "marshal branch create")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/branches", p.APIURL(instanceURL), repositoryID)
+	code, _, resp, err := oauth.Post(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(body),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to create branch from URL %s", url)
+	} else if code >= 300 {
+		return errors.Errorf("failed to create branch from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			resp,
+		)
+	}
+	return nil
+}
+
+// PullRequestBranch represents a Gitea API response for the head or base branch of a pull request.
+type PullRequestBranch struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// PullRequest represents a Gitea API response for a pull request.
+type PullRequest struct {
+	Number  int64             `json:"number"`
+	HTMLURL string            `json:"html_url"`
+	Head    PullRequestBranch `json:"head"`
+	Base    PullRequestBranch `json:"base"`
+}
+
+// ChangedFile represents a Gitea API response for a file changed in a pull request.
+type ChangedFile struct {
+	Filename string `json:"filename"`
+	// The status of the file, possible values are "added", "changed", "deleted", "renamed", "copied".
+	Status string `json:"status"`
+}
+
+// ListPullRequestFile lists the changed files in the pull request.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoGetPullRequestFiles
+func (p *Provider) ListPullRequestFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID string) ([]*vcs.PullRequestFile, error) {
+	// The changed files don't carry the commit they are read from, so we use the
+	// head commit of the pull request.
+	pullRequest, err := p.getPullRequest(ctx, oauthCtx, instanceURL, repositoryID, pullRequestID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get pull request")
+	}
+
+	var allPRFiles []*ChangedFile
+	page := 1
+	for {
+		files, err := p.listPaginatedPullRequestFile(ctx, oauthCtx, instanceURL, repositoryID, pullRequestID, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+		allPRFiles = append(allPRFiles, files...)
+
+		if len(files) < apiPageSize {
+			break
+		}
+		page++
+	}
+
+	var res []*vcs.PullRequestFile
+	for _, file := range allPRFiles {
+		res = append(res, &vcs.PullRequestFile{
+			Path:         file.Filename,
+			LastCommitID: pullRequest.Head.SHA,
+			IsDeleted:    file.Status == "deleted",
+		})
+	}
+	return res, nil
+}
+
+// getPullRequest gets the pull request by its index in the repository.
+func (p *Provider) getPullRequest(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID string) (*PullRequest, error) {
+	url := fmt.Sprintf("%s/repos/%s/pulls/%s", p.APIURL(instanceURL), repositoryID, pullRequestID)
+	code, _, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to get pull request from URL %s", url)
+	} else if code >= 300 {
+		return nil, errors.Errorf("failed to get pull request from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	var pullRequest PullRequest
+	if err := json.Unmarshal([]byte(body), &pullRequest); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+	return &pullRequest, nil
+}
+
+// listPaginatedPullRequestFile lists the changed files in the pull request with pagination.
+func (p *Provider) listPaginatedPullRequestFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID string, page int) ([]*ChangedFile, error) {
+	url := fmt.Sprintf("%s/repos/%s/pulls/%s/files?limit=%d&page=%d", p.APIURL(instanceURL), repositoryID, pullRequestID, apiPageSize, page)
+	code, _, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to list pull request file from URL %s", url)
+	} else if code >= 300 {
+		return nil, errors.Errorf("failed to list pull request file from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	var files []*ChangedFile
+	if err := json.Unmarshal([]byte(body), &files); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+	return files, nil
+}
+
+type pullRequestCreate struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+}
+
+// CreatePullRequest creates the pull request in the repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoCreatePullRequest
+func (p *Provider) CreatePullRequest(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID string, create *vcs.PullRequestCreate) (*vcs.PullRequest, error) {
+	payload, err := json.Marshal(
+		pullRequestCreate{
+			Title: create.Title,
+			Body:  create.Body,
+			Head:  create.Head,
+			Base:  create.Base,
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal pull request create")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/pulls", p.APIURL(instanceURL), repositoryID)
+	code, _, body, err := oauth.Post(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, "failed to create pull request from URL %s", url)
+	} else if code >= 300 {
+		return nil, errors.Errorf("failed to create pull request from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	var pullRequest PullRequest
+	if err := json.Unmarshal([]byte(body), &pullRequest); err != nil {
+		return nil, err
+	}
+
+	return &vcs.PullRequest{
+		URL: pullRequest.HTMLURL,
+	}, nil
+}
+
+type secretUpdate struct {
+	Data string `json:"data"`
+}
+
+// UpsertEnvironmentVariable creates or updates the environment variable in the repository.
+// Gitea Actions reads the variable as a repository secret.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/updateRepoSecret
+func (p *Provider) UpsertEnvironmentVariable(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, key, value string) error {
+	body, err := json.Marshal(secretUpdate{Data: value})
+	if err != nil {
+		return errors.Wrap(err, "marshal environment variable")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/actions/secrets/%s", p.APIURL(instanceURL), repositoryID, key)
+	code, _, resp, err := oauth.Put(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(body),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "PUT %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to upsert environment variable from URL %s", url)
+	} else if code >= 300 {
+		return errors.Errorf("failed to upsert environment variable from URL %s, status code: %d, body: %s",
+			url,
+			code,
+			resp,
+		)
+	}
+	return nil
+}
+
+// WebhookConfig represents the Gitea API message for webhook configuration.
+type WebhookConfig struct {
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Secret      string `json:"secret,omitempty"`
+}
+
+// WebhookCreateOrUpdate represents a Gitea API request for creating or
+// updating a webhook.
+type WebhookCreateOrUpdate struct {
+	// Type is the type of webhook, "gitea" is the native webhook type.
+	// It is only used when creating webhooks.
+	Type   string        `json:"type,omitempty"`
+	Config WebhookConfig `json:"config"`
+	Events []string      `json:"events"`
+	Active bool          `json:"active"`
+}
+
+// Webhook represents a Gitea API response for the webhook information.
+type Webhook struct {
+	ID int64 `json:"id"`
+}
+
+// CreateWebhook creates a webhook in the repository with given payload.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoCreateHook
+func (p *Provider) CreateWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID string, payload []byte) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/hooks", p.APIURL(instanceURL), repositoryID)
+	code, _, body, err := oauth.Post(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return "", errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return "", common.Errorf(common.NotFound, "failed to create webhook through URL %s", url)
+	} else if code >= 300 {
+		return "", errors.Errorf("failed to create webhook through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+
+	var webhook Webhook
+	if err = json.Unmarshal([]byte(body), &webhook); err != nil {
+		return "", errors.Wrap(err, "unmarshal body")
+	}
+	return strconv.FormatInt(webhook.ID, 10), nil
+}
+
+// PatchWebhook patches the webhook in the repository with given payload.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoEditHook
+func (p *Provider) PatchWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, webhookID string, payload []byte) error {
+	url := fmt.Sprintf("%s/repos/%s/hooks/%s", p.APIURL(instanceURL), repositoryID, webhookID)
+	code, _, body, err := oauth.Patch(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "PATCH %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, "failed to patch webhook through URL %s", url)
+	} else if code >= 300 {
+		return errors.Errorf("failed to patch webhook through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+	return nil
+}
+
+// DeleteWebhook deletes the webhook from the repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoDeleteHook
+func (p *Provider) DeleteWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, webhookID string) error {
+	url := fmt.Sprintf("%s/repos/%s/hooks/%s", p.APIURL(instanceURL), repositoryID, webhookID)
+	code, _, body, err := oauth.Delete(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "DELETE %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil // It is OK if the webhook has already gone
+	} else if code >= 300 {
+		return errors.Errorf("failed to delete webhook through URL %s, status code: %d, body: %s",
+			url,
+			code,
+			body,
+		)
+	}
+	return nil
+}
+
+// WebhookCommitAuthor is the API message for webhook commit author.
+type WebhookCommitAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// WebhookCommit is the API message for webhook commit.
+type WebhookCommit struct {
+	ID        string              `json:"id"`
+	Message   string              `json:"message"`
+	URL       string              `json:"url"`
+	Author    WebhookCommitAuthor `json:"author"`
+	Timestamp time.Time           `json:"timestamp"`
+	Added     []string            `json:"added"`
+	Removed   []string            `json:"removed"`
+	Modified  []string            `json:"modified"`
+}
+
+// WebhookPushEvent is the API message for webhook push event.
+//
+// Docs: https://docs.gitea.com/usage/webhooks#event-information
+type WebhookPushEvent struct {
+	Ref        string          `json:"ref"`
+	Before     string          `json:"before"`
+	After      string          `json:"after"`
+	Commits    []WebhookCommit `json:"commits"`
+	Repository Repository      `json:"repository"`
+	Pusher     User            `json:"pusher"`
+}
+
+// ToVCS converts the Gitea webhook push event to the VCS push event.
+func (p WebhookPushEvent) ToVCS() vcs.PushEvent {
+	var commitList []vcs.Commit
+	for _, commit := range p.Commits {
+		// Per Git convention, the message title and body are separated by two new line characters.
+		messages := strings.SplitN(commit.Message, "\n\n", 2)
+		messageTitle := strings.TrimSpace(messages[0])
+
+		commitList = append(commitList, vcs.Commit{
+			ID:           commit.ID,
+			Title:        messageTitle,
+			Message:      commit.Message,
+			CreatedTs:    commit.Timestamp.Unix(),
+			URL:          commit.URL,
+			AuthorName:   commit.Author.Name,
+			AuthorEmail:  commit.Author.Email,
+			AddedList:    commit.Added,
+			ModifiedList: commit.Modified,
+		})
+	}
+	return vcs.PushEvent{
+		VCSType:            vcs.Gitea,
+		Ref:                p.Ref,
+		Before:             p.Before,
+		After:              p.After,
+		RepositoryID:       p.Repository.FullName,
+		RepositoryURL:      p.Repository.HTMLURL,
+		RepositoryFullPath: p.Repository.FullName,
+		AuthorName:         p.Pusher.Login,
+		CommitList:         commitList,
+	}
+}
+
+// oauthContext is the request context for refreshing OAuth token.
+type oauthContext struct {
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+}
+
+type refreshOAuthResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	// token_type is not used.
+}
+
+func tokenRefresher(instanceURL string, oauthCtx oauthContext, refresher common.TokenRefresher) oauth.TokenRefresher {
+	return func(ctx context.Context, client *http.Client, oldToken *string) error {
+		params := &url.Values{}
+		params.Set("client_id", oauthCtx.ClientID)
+		params.Set("client_secret", oauthCtx.ClientSecret)
+		params.Set("grant_type", "refresh_token")
+		params.Set("refresh_token", oauthCtx.RefreshToken)
+
+		url := fmt.Sprintf("%s/login/oauth/access_token", instanceURL)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(params.Encode()))
+		if err != nil {
+			return errors.Wrapf(err, "construct POST %s", url)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return errors.Wrapf(err, "POST %s", url)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrapf(err, "read body of POST %s", url)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusOK {
+			return errors.Errorf("non-200 POST %s status code %d with body %q", url, resp.StatusCode, body)
+		}
+
+		var r refreshOAuthResponse
+		if err = json.Unmarshal(body, &r); err != nil {
+			return errors.Wrapf(err, "unmarshal body from POST %s", url)
+		}
+
+		// Update the old token to new value for retries.
+		*oldToken = r.AccessToken
+
+		var expireAt int64
+		if r.ExpiresIn != 0 {
+			expireAt = time.Now().Add(time.Duration(r.ExpiresIn) * time.Second).Unix()
+		}
+		return refresher(r.AccessToken, r.RefreshToken, expireAt)
+	}
+}
@@ -0,0 +1,619 @@
+package gitea
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+)
+
+const testInstanceURL = "https://gitea.example.com"
+
+func TestProvider_ExchangeOAuthToken(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/login/oauth/access_token", r.URL.Path)
+
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "authorization_code", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "test_client_id", r.PostForm.Get("client_id"))
+		assert.Equal(t, "test_code", r.PostForm.Get("code"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`
+{
+  "access_token": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjAifQ",
+  "token_type": "bearer",
+  "expires_in": 3600,
+  "refresh_token": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjEifQ"
+}
+`)),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	got, err := p.ExchangeOAuthToken(ctx, testInstanceURL,
+		&common.OAuthExchange{
+			ClientID:     "test_client_id",
+			ClientSecret: "test_client_secret",
+			Code:         "test_code",
+			RedirectURL:  "http://localhost:3000",
+		},
+	)
+	require.NoError(t, err)
+
+	// We use time.Now() to compute the values of CreatedAt and ExpiresTs so there
+	// is no point to assert.
+	got.CreatedAt = 0
+	got.ExpiresTs = 0
+
+	want := &vcs.OAuthToken{
+		AccessToken:  "eyJhbGciOiJSUzI1NiIsImtpZCI6IjAifQ",
+		RefreshToken: "eyJhbGciOiJSUzI1NiIsImtpZCI6IjEifQ",
+		ExpiresIn:    3600,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestProvider_FetchCommitByID(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/repos/octocat/hello/git/commits/7fd1a60", r.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`
+{
+  "sha": "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+  "html_url": "https://gitea.example.com/octocat/hello/commit/7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+  "commit": {
+    "message": "Merge pull request #6\n",
+    "author": {
+      "name": "The Octocat",
+      "email": "octocat@example.com",
+      "date": "2023-03-06T20:17:24Z"
+    }
+  }
+}
+`)),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	got, err := p.FetchCommitByID(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", "7fd1a60")
+	require.NoError(t, err)
+
+	want := &vcs.Commit{
+		ID:          "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		AuthorName:  "The Octocat",
+		AuthorEmail: "octocat@example.com",
+		CreatedTs:   1678133844,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestProvider_GetDiffFileList(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/repos/octocat/hello/compare/aaa...ccc", r.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`
+{
+  "total_commits": 2,
+  "commits": [
+    {
+      "sha": "bbb",
+      "files": [
+        {"filename": "migrations/1__init.sql", "status": "added"},
+        {"filename": "migrations/0__base.sql", "status": "modified"},
+        {"filename": "README.md", "status": "removed"}
+      ]
+    },
+    {
+      "sha": "ccc",
+      "files": [
+        {"filename": "migrations/1__init.sql", "status": "modified"},
+        {"filename": "README.md", "status": "added"}
+      ]
+    }
+  ]
+}
+`)),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	got, err := p.GetDiffFileList(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", "aaa", "ccc")
+	require.NoError(t, err)
+
+	want := []vcs.FileDiff{
+		{Path: "migrations/1__init.sql", Type: vcs.FileDiffTypeAdded},
+		{Path: "migrations/0__base.sql", Type: vcs.FileDiffTypeModified},
+		{Path: "README.md", Type: vcs.FileDiffTypeModified},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestProvider_FetchAllRepositoryList(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/user/repos", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("page"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`
+[
+  {
+    "id": 1,
+    "name": "hello",
+    "full_name": "octocat/hello",
+    "html_url": "https://gitea.example.com/octocat/hello",
+    "permissions": {"admin": true, "push": true, "pull": true}
+  },
+  {
+    "id": 2,
+    "name": "readonly",
+    "full_name": "octocat/readonly",
+    "html_url": "https://gitea.example.com/octocat/readonly",
+    "permissions": {"admin": false, "push": false, "pull": true}
+  }
+]
+`)),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	got, err := p.FetchAllRepositoryList(ctx, common.OauthContext{}, testInstanceURL)
+	require.NoError(t, err)
+
+	want := []*vcs.Repository{
+		{
+			ID:       "octocat/hello",
+			Name:     "hello",
+			FullPath: "octocat/hello",
+			WebURL:   "https://gitea.example.com/octocat/hello",
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestProvider_FetchRepositoryFileList(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/repos/octocat/hello/git/trees/main", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("recursive"))
+		if r.URL.Query().Get("page") == "1" {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(`
+{
+  "sha": "f45fa4d",
+  "tree": [
+    {"path": "README.md", "type": "blob"},
+    {"path": "migrations", "type": "tree"}
+  ],
+  "truncated": true
+}
+`)),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`
+{
+  "sha": "f45fa4d",
+  "tree": [
+    {"path": "migrations/1__init.sql", "type": "blob"}
+  ],
+  "truncated": false
+}
+`)),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	got, err := p.FetchRepositoryFileList(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", "main", "migrations")
+	require.NoError(t, err)
+
+	want := []*vcs.RepositoryTreeNode{
+		{Path: "migrations/1__init.sql", Type: "blob"},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestProvider_CreateFile(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/repos/octocat/hello/contents/migrations/1__init.sql", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var fileCommit FileCommit
+		require.NoError(t, json.Unmarshal(body, &fileCommit))
+		assert.Equal(t, "Initial commit", fileCommit.Message)
+		assert.Equal(t, "main", fileCommit.Branch)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("CREATE TABLE t(id INT);")), fileCommit.Content)
+		assert.Equal(t, &Identity{Name: vcs.BytebaseAuthorName, Email: vcs.BytebaseAuthorEmail}, fileCommit.Author)
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	err := p.CreateFile(
+		ctx,
+		common.OauthContext{},
+		testInstanceURL,
+		"octocat/hello",
+		"migrations/1__init.sql",
+		vcs.FileCommitCreate{
+			Branch:        "main",
+			Content:       "CREATE TABLE t(id INT);",
+			CommitMessage: "Initial commit",
+			AuthorName:    vcs.BytebaseAuthorName,
+			AuthorEmail:   vcs.BytebaseAuthorEmail,
+		},
+	)
+	require.NoError(t, err)
+}
+
+func TestProvider_OverwriteFile(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/repos/octocat/hello/contents/LATEST.sql", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var fileCommit FileCommit
+		require.NoError(t, json.Unmarshal(body, &fileCommit))
+		assert.Equal(t, "7638417db6d59f3c431d3e1f261cc637155684cd", fileCommit.SHA)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	fileCommitCreate := vcs.FileCommitCreate{
+		Branch:        "main",
+		Content:       "CREATE TABLE t(id INT);",
+		CommitMessage: "Update schema",
+	}
+	err := p.OverwriteFile(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", "LATEST.sql", fileCommitCreate)
+	require.Error(t, err)
+
+	fileCommitCreate.SHA = "7638417db6d59f3c431d3e1f261cc637155684cd"
+	err = p.OverwriteFile(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", "LATEST.sql", fileCommitCreate)
+	require.NoError(t, err)
+}
+
+func TestProvider_ReadFileMeta(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/repos/octocat/hello/contents/migrations/1__init.sql", r.URL.Path)
+		assert.Equal(t, "main", r.URL.Query().Get("ref"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`
+{
+  "name": "1__init.sql",
+  "path": "migrations/1__init.sql",
+  "sha": "3d21ec53a331a6f037a91c368710b99387d012c1",
+  "last_commit_sha": "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+  "type": "file",
+  "size": 23
+}
+`)),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	got, err := p.ReadFileMeta(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", "migrations/1__init.sql", "main")
+	require.NoError(t, err)
+
+	want := &vcs.FileMeta{
+		Name:         "1__init.sql",
+		Path:         "migrations/1__init.sql",
+		Size:         23,
+		SHA:          "3d21ec53a331a6f037a91c368710b99387d012c1",
+		LastCommitID: "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestProvider_ReadFileContent(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/api/v1/repos/octocat/hello/raw/migrations/1__init.sql", r.URL.Path)
+		assert.Equal(t, "main", r.URL.Query().Get("ref"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("CREATE TABLE t(id INT);")),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	got, err := p.ReadFileContent(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", "migrations/1__init.sql", "main")
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE t(id INT);", got)
+}
+
+func TestProvider_GetBranch(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/api/v1/repos/octocat/hello/branches/missing" {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(strings.NewReader(`{"message": "branch not found"}`)),
+			}, nil
+		}
+		assert.Equal(t, "/api/v1/repos/octocat/hello/branches/main", r.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`
+{
+  "name": "main",
+  "commit": {"id": "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"}
+}
+`)),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	got, err := p.GetBranch(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", "main")
+	require.NoError(t, err)
+	assert.Equal(t, &vcs.BranchInfo{Name: "main", LastCommitID: "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"}, got)
+
+	_, err = p.GetBranch(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", "missing")
+	require.Error(t, err)
+	assert.Equal(t, common.NotFound, common.ErrorCode(err))
+}
+
+func TestProvider_CreateBranch(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/repos/octocat/hello/branches", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"new_branch_name": "bytebase-vcs-1", "old_ref_name": "7fd1a60"}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	err := p.CreateBranch(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", &vcs.BranchInfo{Name: "bytebase-vcs-1", LastCommitID: "7fd1a60"})
+	require.NoError(t, err)
+}
+
+func TestProvider_ListPullRequestFile(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/api/v1/repos/octocat/hello/pulls/3":
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(`
+{
+  "number": 3,
+  "html_url": "https://gitea.example.com/octocat/hello/pulls/3",
+  "head": {"ref": "feature", "sha": "5e8a0b9"},
+  "base": {"ref": "main", "sha": "7fd1a60"}
+}
+`)),
+			}, nil
+		case "/api/v1/repos/octocat/hello/pulls/3/files":
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(`
+[
+  {"filename": "migrations/2__add_column.sql", "status": "added"},
+  {"filename": "migrations/0__obsolete.sql", "status": "deleted"}
+]
+`)),
+			}, nil
+		}
+		t.Fatalf("unexpected request %s", r.URL.Path)
+		return nil, nil
+	},
+	)
+
+	ctx := context.Background()
+	got, err := p.ListPullRequestFile(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", "3")
+	require.NoError(t, err)
+
+	want := []*vcs.PullRequestFile{
+		{Path: "migrations/2__add_column.sql", LastCommitID: "5e8a0b9", IsDeleted: false},
+		{Path: "migrations/0__obsolete.sql", LastCommitID: "5e8a0b9", IsDeleted: true},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestProvider_CreatePullRequest(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/repos/octocat/hello/pulls", r.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(strings.NewReader(`{"number": 4, "html_url": "https://gitea.example.com/octocat/hello/pulls/4"}`)),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	got, err := p.CreatePullRequest(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello",
+		&vcs.PullRequestCreate{
+			Title: "Add column",
+			Head:  "feature",
+			Base:  "main",
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitea.example.com/octocat/hello/pulls/4", got.URL)
+}
+
+func TestProvider_UpsertEnvironmentVariable(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/repos/octocat/hello/actions/secrets/SQL_REVIEW_API_SECRET", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data": "secret"}`, string(body))
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	err := p.UpsertEnvironmentVariable(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", vcs.SQLReviewAPISecretName, "secret")
+	require.NoError(t, err)
+}
+
+func TestProvider_CreateWebhook(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/repos/octocat/hello/hooks", r.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(strings.NewReader(`{"id": 12, "type": "gitea", "active": true}`)),
+		}, nil
+	},
+	)
+
+	payload, err := json.Marshal(
+		WebhookCreateOrUpdate{
+			Type: "gitea",
+			Config: WebhookConfig{
+				URL:         "https://bytebase.example.com/hook/gitea/abc",
+				ContentType: "json",
+				Secret:      "secret",
+			},
+			Events: []string{"push"},
+			Active: true,
+		},
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	got, err := p.CreateWebhook(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", payload)
+	require.NoError(t, err)
+	assert.Equal(t, "12", got)
+}
+
+func TestProvider_PatchWebhook(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/v1/repos/octocat/hello/hooks/12", r.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	err := p.PatchWebhook(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", "12", []byte("{}"))
+	require.NoError(t, err)
+}
+
+func TestProvider_DeleteWebhook(t *testing.T) {
+	p := newMockProvider(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/api/v1/repos/octocat/hello/hooks/12", r.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	},
+	)
+
+	ctx := context.Background()
+	err := p.DeleteWebhook(ctx, common.OauthContext{}, testInstanceURL, "octocat/hello", "12")
+	require.NoError(t, err)
+}
+
+func TestWebhookPushEvent_ToVCS(t *testing.T) {
+	var pushEvent WebhookPushEvent
+	err := json.Unmarshal([]byte(`
+{
+  "ref": "refs/heads/main",
+  "before": "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+  "after": "5e8a0b9f6a7dd0b2ee2a4b7fa2d7c7c3d14a06f7",
+  "commits": [
+    {
+      "id": "5e8a0b9f6a7dd0b2ee2a4b7fa2d7c7c3d14a06f7",
+      "message": "Add column\n\nAdd the email column.\n",
+      "url": "https://gitea.example.com/octocat/hello/commit/5e8a0b9f6a7dd0b2ee2a4b7fa2d7c7c3d14a06f7",
+      "author": {"name": "The Octocat", "email": "octocat@example.com"},
+      "timestamp": "2023-03-06T20:17:24Z",
+      "added": ["migrations/2__add_column.sql"],
+      "removed": [],
+      "modified": ["LATEST.sql"]
+    }
+  ],
+  "repository": {
+    "id": 1,
+    "name": "hello",
+    "full_name": "octocat/hello",
+    "html_url": "https://gitea.example.com/octocat/hello"
+  },
+  "pusher": {"login": "octocat"}
+}
+`), &pushEvent)
+	require.NoError(t, err)
+
+	want := vcs.PushEvent{
+		VCSType:            vcs.Gitea,
+		Ref:                "refs/heads/main",
+		Before:             "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		After:              "5e8a0b9f6a7dd0b2ee2a4b7fa2d7c7c3d14a06f7",
+		RepositoryID:       "octocat/hello",
+		RepositoryURL:      "https://gitea.example.com/octocat/hello",
+		RepositoryFullPath: "octocat/hello",
+		AuthorName:         "octocat",
+		CommitList: []vcs.Commit{
+			{
+				ID:           "5e8a0b9f6a7dd0b2ee2a4b7fa2d7c7c3d14a06f7",
+				Title:        "Add column",
+				Message:      "Add column\n\nAdd the email column.\n",
+				CreatedTs:    time.Date(2023, 3, 6, 20, 17, 24, 0, time.UTC).Unix(),
+				URL:          "https://gitea.example.com/octocat/hello/commit/5e8a0b9f6a7dd0b2ee2a4b7fa2d7c7c3d14a06f7",
+				AuthorName:   "The Octocat",
+				AuthorEmail:  "octocat@example.com",
+				AddedList:    []string{"migrations/2__add_column.sql"},
+				ModifiedList: []string{"LATEST.sql"},
+			},
+		},
+	}
+	assert.Equal(t, want, pushEvent.ToVCS())
+}
+
+func newMockProvider(mockRoundTrip func(r *http.Request) (*http.Response, error)) vcs.Provider {
+	return newProvider(
+		vcs.ProviderConfig{
+			Client: &http.Client{
+				Transport: &common.MockRoundTripper{
+					MockRoundTrip: mockRoundTrip,
+				},
+			},
+		},
+	)
+}
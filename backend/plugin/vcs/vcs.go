@@ -24,6 +24,11 @@ const (
 	GitHub Type = "GITHUB"
 	// Bitbucket is the VCS type for Bitbucket Cloud (bitbucket.org).
 	Bitbucket Type = "BITBUCKET"
+	// Gitea is the VCS type for Gitea (self-hosted).
+	Gitea Type = "GITEA"
+	// Git is the VCS type for generic Git servers accessed via plain HTTPS or SSH.
+	// It has no webhook support, so new commits are detected by polling.
+	Git Type = "GIT"
 
 	// SQLReviewAPISecretName is the api secret name used in GitHub action or GitLab CI workflow.
 	SQLReviewAPISecretName = "SQL_REVIEW_API_SECRET"
@@ -2,13 +2,24 @@ package webhook
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
+	"text/template"
 
 	"github.com/pkg/errors"
 )
 
+// CustomWebhookType is the webhook type of the custom receiver.
+const CustomWebhookType = "bb.plugin.webhook.custom"
+
+// SignatureHeader is the header of the HMAC-SHA256 signature of the custom webhook request body.
+// The value is in the format of "sha256=<hex digest>", the same as the GitHub webhook.
+const SignatureHeader = "X-Bytebase-Signature-256"
+
 // CustomWebhookResponse is the API message for Custom webhook response.
 type CustomWebhookResponse struct {
 	Code    int    `json:"code"`
@@ -30,30 +41,39 @@ type CustomWebhookRequest struct {
 }
 
 func init() {
-	register("bb.plugin.webhook.custom", &CustomReceiver{})
+	register(CustomWebhookType, &CustomReceiver{})
 }
 
 // CustomReceiver is the receiver for custom.
 type CustomReceiver struct{}
 
 func (*CustomReceiver) post(context Context) error {
-	// TODO(p0ny): handle context.Task
-	payload := CustomWebhookRequest{
-		Level:        context.Level,
-		ActivityType: context.ActivityType,
-		Title:        context.Title,
-		Description:  context.Description,
-		Link:         context.Link,
-		CreatorID:    context.CreatorID,
-		CreatorName:  context.CreatorName,
-		CreatedTS:    context.CreatedTs,
-		Issue:        context.Issue,
-		Project:      context.Project,
-	}
-
-	body, err := json.Marshal(&payload)
-	if err != nil {
-		return errors.Wrapf(err, "failed to marshal webhook POST request to %s", context.URL)
+	var body []byte
+	if context.PayloadTemplate != "" {
+		b, err := renderPayloadTemplate(context.PayloadTemplate, context)
+		if err != nil {
+			return &PayloadTemplateError{Err: err}
+		}
+		body = b
+	} else {
+		// TODO(p0ny): handle context.Task
+		payload := CustomWebhookRequest{
+			Level:        context.Level,
+			ActivityType: context.ActivityType,
+			Title:        context.Title,
+			Description:  context.Description,
+			Link:         context.Link,
+			CreatorID:    context.CreatorID,
+			CreatorName:  context.CreatorName,
+			CreatedTS:    context.CreatedTs,
+			Issue:        context.Issue,
+			Project:      context.Project,
+		}
+		b, err := json.Marshal(&payload)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal webhook POST request to %s", context.URL)
+		}
+		body = b
 	}
 	req, err := http.NewRequest("POST",
 		context.URL, bytes.NewBuffer(body))
@@ -62,6 +82,9 @@ func (*CustomReceiver) post(context Context) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if context.Secret != "" {
+		req.Header.Set(SignatureHeader, signPayload(context.Secret, body))
+	}
 	client := &http.Client{
 		Timeout: timeout,
 	}
@@ -76,6 +99,14 @@ func (*CustomReceiver) post(context Context) error {
 	}
 	defer resp.Body.Close()
 
+	if context.PayloadTemplate != "" {
+		// The templated payload is for the third-party services, whose response formats are unknown.
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return errors.Errorf("failed to POST webhook %s, status code: %d, response body: %s", context.URL, resp.StatusCode, b)
+		}
+		return nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return errors.Errorf("failed to POST webhook %s, status code: %d, response body: %s", context.URL, resp.StatusCode, b)
 	}
@@ -91,3 +122,76 @@ func (*CustomReceiver) post(context Context) error {
 
 	return nil
 }
+
+// signPayload returns the HMAC-SHA256 signature of the payload.
+func signPayload(secret string, payload []byte) string {
+	m := hmac.New(sha256.New, []byte(secret))
+	m.Write(payload)
+	return "sha256=" + hex.EncodeToString(m.Sum(nil))
+}
+
+var payloadTemplateFuncs = template.FuncMap{
+	// json encodes the value in json, e.g. {{ json .Title }} renders a quoted and escaped json string.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	},
+}
+
+// PayloadTemplateError is the error of rendering the payload template.
+// Retrying the delivery never helps, because the template is rendered against the same context again.
+type PayloadTemplateError struct {
+	Err error
+}
+
+func (e *PayloadTemplateError) Error() string {
+	return "failed to render payload template: " + e.Err.Error()
+}
+
+// Unwrap returns the underlying template error.
+func (e *PayloadTemplateError) Unwrap() error {
+	return e.Err
+}
+
+// ValidatePayloadTemplate validates the Go template of the custom webhook request body.
+// The Issue, Project and TaskResult are absent for some activities, so the template has to guard them, e.g. {{ with .Issue }}{{ .Name }}{{ end }}.
+func ValidatePayloadTemplate(payloadTemplate string) error {
+	for _, context := range []Context{
+		{
+			Level:        WebhookInfo,
+			ActivityType: "bb.issue.create",
+			Title:        "Issue created",
+			Issue:        &Issue{},
+			Project:      &Project{},
+			TaskResult:   &TaskResult{},
+		},
+		{
+			Level:        WebhookInfo,
+			ActivityType: "bb.project.member.create",
+			Title:        "Project member created",
+		},
+	} {
+		if _, err := renderPayloadTemplate(payloadTemplate, context); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderPayloadTemplate executes the payload template with the webhook context.
+func renderPayloadTemplate(payloadTemplate string, context Context) ([]byte, error) {
+	tmpl, err := template.New("payload").Funcs(payloadTemplateFuncs).Option("missingkey=error").Parse(payloadTemplate)
+	if err != nil {
+		return nil, err
+	}
+	// Never expose the delivery settings to the template.
+	context.URL, context.Secret, context.PayloadTemplate = "", "", ""
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, context); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
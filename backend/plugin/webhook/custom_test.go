@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomReceiver_Signature(t *testing.T) {
+	a := require.New(t)
+
+	var gotBody []byte
+	var gotSignature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		a.NoError(err)
+		gotBody = body
+		gotSignature = r.Header.Get(SignatureHeader)
+		_, _ = w.Write([]byte(`{"code":0}`))
+	}))
+	defer server.Close()
+
+	err := Post("bb.plugin.webhook.custom", Context{
+		URL:    server.URL,
+		Secret: "s3cr3t",
+		Title:  "Issue created",
+	})
+	a.NoError(err)
+	m := hmac.New(sha256.New, []byte("s3cr3t"))
+	m.Write(gotBody)
+	a.Equal("sha256="+hex.EncodeToString(m.Sum(nil)), gotSignature)
+
+	// The request is not signed without a secret.
+	err = Post("bb.plugin.webhook.custom", Context{
+		URL:   server.URL,
+		Title: "Issue created",
+	})
+	a.NoError(err)
+	a.Empty(gotSignature)
+}
+
+func TestCustomReceiver_PayloadTemplate(t *testing.T) {
+	a := require.New(t)
+
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		a.NoError(err)
+		gotBody = body
+		// The response format of the templated payload is not checked.
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	err := Post(CustomWebhookType, Context{
+		URL:             server.URL,
+		Secret:          "s3cr3t",
+		PayloadTemplate: `{"summary": {{ json .Title }}, "severity": "{{ if eq .Level "ERROR" }}critical{{ else }}info{{ end }}", "issue": {{ .Issue.ID }}, "secret": "{{ .Secret }}"}`,
+		Level:           WebhookError,
+		Title:           `Task failed - "add column"`,
+		Issue:           &Issue{ID: 101},
+	})
+	a.NoError(err)
+	a.Equal(`{"summary": "Task failed - \"add column\"", "severity": "critical", "issue": 101, "secret": ""}`, string(gotBody))
+}
+
+func TestCustomReceiver_PayloadTemplateError(t *testing.T) {
+	a := require.New(t)
+
+	posted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		posted = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	err := Post("bb.plugin.webhook.custom", Context{
+		URL:             server.URL,
+		PayloadTemplate: `{"issue": {{ .Issue.ID }}}`,
+		Title:           "Project member created",
+	})
+	var templateErr *PayloadTemplateError
+	a.ErrorAs(err, &templateErr)
+	a.False(posted)
+}
+
+func TestValidatePayloadTemplate(t *testing.T) {
+	a := require.New(t)
+	a.NoError(ValidatePayloadTemplate(`{"text": {{ json .Title }}{{ with .Issue }}, "issue": {{ json .Name }}{{ end }}}`))
+	// The Issue, Project and TaskResult might be nil.
+	a.Error(ValidatePayloadTemplate(`{"text": {{ json .Title }}, "issue": {{ json .Issue.Name }}}`))
+	a.Error(ValidatePayloadTemplate(`{"project": {{ json .Project.Name }}}`))
+	a.Error(ValidatePayloadTemplate(`{"task": {{ json .TaskResult.Status }}}`))
+	a.Error(ValidatePayloadTemplate(`{"text": {{ .Title }`))
+	a.Error(ValidatePayloadTemplate(`{"text": {{ .NoSuchField }}}`))
+}
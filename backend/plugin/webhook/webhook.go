@@ -64,7 +64,12 @@ type Project struct {
 
 // Context is the context of webhook.
 type Context struct {
-	URL          string
+	URL string
+	// Secret is used to sign the request payload, only the custom receiver supports it.
+	Secret string `json:"-"`
+	// PayloadTemplate is the Go template of the request body, only the custom receiver supports it.
+	PayloadTemplate string `json:"-"`
+
 	Level        Level
 	ActivityType string
 	Title        string
@@ -173,6 +173,48 @@ func Stop(pgBinDir, pgDataDir string) error {
 	return p.Run()
 }
 
+// StartForRecovery starts a postgres instance on a data directory restored from a base backup, and waits until the recovery finishes.
+// The instance only listens on the unix socket of the port.
+func StartForRecovery(ctx context.Context, port int, binDir, dataDir string) error {
+	if err := os.Chmod(dataDir, 0700); err != nil {
+		return errors.Wrapf(err, "failed to chmod postgres data directory %q to 0700", dataDir)
+	}
+	pgbin := filepath.Join(binDir, "pg_ctl")
+	// With hot_standby off, the instance accepts connections only after the recovery finishes.
+	// The recovery may replay WAL files for a long time, so we do not use the default 60 seconds timeout of pg_ctl.
+	p := exec.CommandContext(ctx, pgbin, "start", "-w",
+		"-t", "86400",
+		"-D", dataDir,
+		"-o", fmt.Sprintf(`-p %d -k %s -h "" -c hot_standby=off`, port, common.GetPostgresSocketDir()))
+
+	uid, gid, sameUser, err := shouldSwitchUser()
+	if err != nil {
+		return err
+	}
+	if !sameUser {
+		p.SysProcAttr = &syscall.SysProcAttr{
+			Setpgid:    true,
+			Credential: &syscall.Credential{Uid: uint32(uid)},
+		}
+		if err := filepath.Walk(dataDir, func(path string, _ os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			return os.Lchown(path, uid, gid)
+		}); err != nil {
+			return errors.Wrapf(err, "failed to change owner of data directory %q to bytebase", dataDir)
+		}
+	}
+
+	// Suppress log spam
+	p.Stdout = nil
+	p.Stderr = os.Stderr
+	if err := p.Run(); err != nil {
+		return errors.Wrapf(err, "failed to start postgres for recovery %q", p.String())
+	}
+	return nil
+}
+
 // InitDB inits a postgres database if not yet.
 func InitDB(pgBinDir, pgDataDir, pgUser string) error {
 	versionPath := filepath.Join(pgDataDir, "PG_VERSION")
@@ -0,0 +1,169 @@
+// Package accessgrant is the runner activating the approved query access and revoking the expired ones.
+package accessgrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/activity"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+)
+
+const (
+	// accessGrantRunnerInterval is the interval to check the approval and the expiration of the access grants.
+	accessGrantRunnerInterval = 10 * time.Second
+)
+
+// NewRunner creates an access grant runner.
+func NewRunner(store *store.Store, activityManager *activity.Manager) *Runner {
+	return &Runner{
+		store:           store,
+		activityManager: activityManager,
+	}
+}
+
+// Runner is the access grant runner.
+type Runner struct {
+	store           *store.Store
+	activityManager *activity.Manager
+}
+
+// Run will run the access grant runner.
+func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(accessGrantRunnerInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	log.Debug(fmt.Sprintf("Access grant runner started and will run every %v", accessGrantRunnerInterval))
+	for {
+		select {
+		case <-ticker.C:
+			r.run(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Runner) run(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok {
+				err = errors.Errorf("%v", r)
+			}
+			log.Error("Access grant runner PANIC RECOVER", zap.Error(err), zap.Stack("panic-stack"))
+		}
+	}()
+
+	if err := r.activatePendingGrants(ctx); err != nil {
+		log.Error("Failed to activate access grants", zap.Error(err))
+	}
+	if err := r.expireActiveGrants(ctx); err != nil {
+		log.Error("Failed to expire access grants", zap.Error(err))
+	}
+}
+
+// activatePendingGrants activates the grants whose issue is approved, and revokes the grants whose issue is closed without approval.
+func (r *Runner) activatePendingGrants(ctx context.Context) error {
+	grants, err := r.store.ListAccessGrants(ctx, &store.FindAccessGrantMessage{
+		StatusList: []api.AccessGrantStatus{api.AccessGrantPending},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list pending access grants")
+	}
+	for _, grant := range grants {
+		issue, err := r.store.GetIssueV2(ctx, &store.FindIssueMessage{UID: &grant.IssueUID})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get issue %d", grant.IssueUID)
+		}
+		approved, err := utils.CheckIssueApproved(issue)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check if issue %d is approved", issue.UID)
+		}
+
+		switch {
+		case issue.Status == api.IssueOpen && approved:
+			if err := r.activateGrant(ctx, grant, issue); err != nil {
+				return err
+			}
+		case issue.Status != api.IssueOpen && !approved:
+			revoked := api.AccessGrantRevoked
+			if _, err := r.store.UpdateAccessGrant(ctx, &store.UpdateAccessGrantMessage{ID: grant.ID, Status: &revoked}); err != nil {
+				return errors.Wrapf(err, "failed to revoke access grant %d", grant.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// activateGrant starts the access from now on, and resolves the issue.
+func (r *Runner) activateGrant(ctx context.Context, grant *store.AccessGrantMessage, issue *store.IssueMessage) error {
+	active := api.AccessGrantActive
+	expireTs := time.Now().Unix() + grant.Duration
+	if _, err := r.store.UpdateAccessGrant(ctx, &store.UpdateAccessGrantMessage{
+		ID:       grant.ID,
+		Status:   &active,
+		ExpireTs: &expireTs,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to activate access grant %d", grant.ID)
+	}
+
+	done := api.IssueDone
+	updatedIssue, err := r.store.UpdateIssueV2(ctx, issue.UID, &store.UpdateIssueMessage{Status: &done}, api.SystemBotID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to resolve issue %q", issue.Title)
+	}
+
+	// It's ok to fail to create activity.
+	if err := func() error {
+		payload, err := json.Marshal(api.ActivityIssueStatusUpdatePayload{
+			OldStatus: issue.Status,
+			NewStatus: done,
+			IssueName: updatedIssue.Title,
+		})
+		if err != nil {
+			return err
+		}
+		activityCreate := &api.ActivityCreate{
+			CreatorID:   api.SystemBotID,
+			ContainerID: issue.UID,
+			Type:        api.ActivityIssueStatusUpdate,
+			Level:       api.ActivityInfo,
+			Comment:     fmt.Sprintf("Query access is granted until %s.", time.Unix(expireTs, 0).UTC().Format(time.RFC3339)),
+			Payload:     string(payload),
+		}
+		_, err = r.activityManager.CreateActivity(ctx, activityCreate, &activity.Metadata{Issue: updatedIssue})
+		return err
+	}(); err != nil {
+		log.Error("failed to create activity after granting query access", zap.Int("issue", issue.UID), zap.Error(err))
+	}
+	return nil
+}
+
+// expireActiveGrants expires the active grants after the expiration time.
+func (r *Runner) expireActiveGrants(ctx context.Context) error {
+	now := time.Now().Unix()
+	grants, err := r.store.ListAccessGrants(ctx, &store.FindAccessGrantMessage{
+		StatusList:     []api.AccessGrantStatus{api.AccessGrantActive},
+		ExpireTsBefore: &now,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list expired access grants")
+	}
+	for _, grant := range grants {
+		expired := api.AccessGrantExpired
+		if _, err := r.store.UpdateAccessGrant(ctx, &store.UpdateAccessGrantMessage{ID: grant.ID, Status: &expired}); err != nil {
+			return errors.Wrapf(err, "failed to expire access grant %d", grant.ID)
+		}
+	}
+	return nil
+}
@@ -24,6 +24,12 @@ import (
 const (
 	// The chosen interval is a balance between anomaly staleness tolerance and background load.
 	anomalyScanInterval = time.Duration(10) * time.Minute
+	// tableGrowthWindow is the period of the table stats used to compute the table growth.
+	tableGrowthWindow = time.Duration(7*24) * time.Hour
+	// tableGrowthMinPeriod is the minimal observed period to compute the table growth because the growth over a short period is noisy.
+	tableGrowthMinPeriod = time.Duration(24) * time.Hour
+	// tableSizeWarningRatio is the ratio of the max table size at which a table is considered approaching the size.
+	tableSizeWarningRatio = 0.8
 )
 
 // NewScanner creates a anomaly scanner.
@@ -85,6 +91,18 @@ func (s *Scanner) Run(ctx context.Context, wg *sync.WaitGroup) {
 					backupPlanPolicyMap[environment.UID] = policy
 				}
 
+				tableGrowthPolicyMap := make(map[int]*api.TableGrowthPolicy)
+				for _, environment := range environments {
+					policy, err := s.store.GetTableGrowthPolicyByEnvID(ctx, environment.UID)
+					if err != nil {
+						log.Error("Failed to retrieve table growth policy",
+							zap.String("environment", environment.Title),
+							zap.Error(err))
+						return
+					}
+					tableGrowthPolicyMap[environment.UID] = policy
+				}
+
 				instances, err := s.store.ListInstancesV2(ctx, &store.FindInstanceMessage{})
 				if err != nil {
 					log.Error("Failed to retrieve instance list", zap.Error(err))
@@ -136,6 +154,7 @@ func (s *Scanner) Run(ctx context.Context, wg *sync.WaitGroup) {
 							}
 							s.checkDatabaseAnomaly(ctx, instance, database)
 							s.checkBackupAnomaly(ctx, environment, instance, database, backupPlanPolicyMap)
+							s.checkTableGrowthAnomaly(ctx, environment, instance, database, tableGrowthPolicyMap)
 						}
 					}(environment, instance)
 
@@ -427,9 +446,10 @@ func (s *Scanner) checkBackupAnomaly(ctx context.Context, environment *store.Env
 						zap.Error(err))
 				}
 
+				// Use the created time as the backup time, because recording the verification result also updates the backup.
 				hasValidBackup := false
 				if len(backupList) > 0 {
-					if backupList[0].UpdatedTs >= time.Now().Add(-backupMaxAge).Unix() {
+					if backupList[0].CreatedTs >= time.Now().Add(-backupMaxAge).Unix() {
 						hasValidBackup = true
 					}
 				}
@@ -439,7 +459,7 @@ func (s *Scanner) checkBackupAnomaly(ctx context.Context, environment *store.Env
 						ExpectedBackupSchedule: expectedSchedule,
 					}
 					if len(backupList) > 0 {
-						backupMissingAnomalyPayload.LastBackupTs = backupList[0].UpdatedTs
+						backupMissingAnomalyPayload.LastBackupTs = backupList[0].CreatedTs
 					}
 				}
 			}
@@ -481,6 +501,203 @@ func (s *Scanner) checkBackupAnomaly(ctx context.Context, environment *store.Env
 			}
 		}
 	}
+
+	// Check backup unrestorable
+	{
+		// The backup runner verifies the latest backup of the database by restoring it into a scratch database.
+		var backupUnrestorableAnomalyPayload *api.AnomalyDatabaseBackupUnrestorablePayload
+		verifiedBackup, err := s.getLatestVerifiedBackup(ctx, database.UID)
+		if err != nil {
+			log.Error("Failed to get the latest verified backup",
+				zap.String("instance", instance.ResourceID),
+				zap.String("database", database.DatabaseName),
+				zap.Error(err))
+			return
+		}
+		if verifiedBackup != nil && verifiedBackup.VerificationStatus == api.BackupVerificationFailed {
+			backupUnrestorableAnomalyPayload = &api.AnomalyDatabaseBackupUnrestorablePayload{
+				BackupID:   verifiedBackup.UID,
+				BackupName: verifiedBackup.Name,
+				VerifiedTs: verifiedBackup.VerifiedTs,
+				Detail:     verifiedBackup.VerificationDetail,
+			}
+		}
+
+		if backupUnrestorableAnomalyPayload != nil {
+			payload, err := json.Marshal(*backupUnrestorableAnomalyPayload)
+			if err != nil {
+				log.Error("Failed to marshal anomaly payload",
+					zap.String("instance", instance.ResourceID),
+					zap.String("database", database.DatabaseName),
+					zap.String("type", string(api.AnomalyDatabaseBackupUnrestorable)),
+					zap.Error(err))
+			} else {
+				if _, err = s.store.UpsertActiveAnomalyV2(ctx, api.SystemBotID, &store.AnomalyMessage{
+					InstanceUID: instance.UID,
+					DatabaseUID: &database.UID,
+					Type:        api.AnomalyDatabaseBackupUnrestorable,
+					Payload:     string(payload),
+				}); err != nil {
+					log.Error("Failed to create anomaly",
+						zap.String("instance", instance.ResourceID),
+						zap.String("database", database.DatabaseName),
+						zap.String("type", string(api.AnomalyDatabaseBackupUnrestorable)),
+						zap.Error(err))
+				}
+			}
+		} else {
+			err := s.store.ArchiveAnomalyV2(ctx, &store.ArchiveAnomalyMessage{
+				DatabaseUID: &database.UID,
+				Type:        api.AnomalyDatabaseBackupUnrestorable,
+			})
+			if err != nil && common.ErrorCode(err) != common.NotFound {
+				log.Error("Failed to close anomaly",
+					zap.String("instance", instance.ResourceID),
+					zap.String("database", database.DatabaseName),
+					zap.String("type", string(api.AnomalyDatabaseBackupUnrestorable)),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
+// getLatestVerifiedBackup returns the latest backup of the database verified by the backup runner, or nil if there is none.
+func (s *Scanner) getLatestVerifiedBackup(ctx context.Context, databaseUID int) (*store.BackupMessage, error) {
+	rowStatus := api.Normal
+	status := api.BackupStatusDone
+	backupList, err := s.store.ListBackupV2(ctx, &store.FindBackupMessage{
+		DatabaseUID: &databaseUID,
+		RowStatus:   &rowStatus,
+		Status:      &status,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var latest *store.BackupMessage
+	for _, backup := range backupList {
+		if backup.VerificationStatus == api.BackupVerificationUnverified {
+			continue
+		}
+		if latest == nil || backup.UID > latest.UID {
+			latest = backup
+		}
+	}
+	return latest, nil
+}
+
+func (s *Scanner) checkTableGrowthAnomaly(ctx context.Context, environment *store.EnvironmentMessage, instance *store.InstanceMessage, database *store.DatabaseMessage, policyMap map[int]*api.TableGrowthPolicy) {
+	policy := policyMap[environment.UID]
+	var tableGrowthAnomalyPayload *api.AnomalyDatabaseTableGrowthPayload
+	if policy.MaxDailyGrowthPercent > 0 || policy.MaxTableSize > 0 {
+		createdTsAfter := time.Now().Add(-tableGrowthWindow).Unix()
+		stats, err := s.store.ListTableStats(ctx, &store.FindTableStatMessage{
+			DatabaseUID:    &database.UID,
+			CreatedTsAfter: &createdTsAfter,
+		})
+		if err != nil {
+			log.Error("Failed to retrieve table stats",
+				zap.String("instance", instance.ResourceID),
+				zap.String("database", database.DatabaseName),
+				zap.Error(err))
+			return
+		}
+		if tableList := getTableGrowthList(stats, policy); len(tableList) > 0 {
+			tableGrowthAnomalyPayload = &api.AnomalyDatabaseTableGrowthPayload{
+				MaxDailyGrowthPercent: policy.MaxDailyGrowthPercent,
+				MaxTableSize:          policy.MaxTableSize,
+				TableList:             tableList,
+			}
+		}
+	}
+
+	if tableGrowthAnomalyPayload != nil {
+		payload, err := json.Marshal(*tableGrowthAnomalyPayload)
+		if err != nil {
+			log.Error("Failed to marshal anomaly payload",
+				zap.String("instance", instance.ResourceID),
+				zap.String("database", database.DatabaseName),
+				zap.String("type", string(api.AnomalyDatabaseTableGrowth)),
+				zap.Error(err))
+		} else {
+			if _, err = s.store.UpsertActiveAnomalyV2(ctx, api.SystemBotID, &store.AnomalyMessage{
+				InstanceUID: instance.UID,
+				DatabaseUID: &database.UID,
+				Type:        api.AnomalyDatabaseTableGrowth,
+				Payload:     string(payload),
+			}); err != nil {
+				log.Error("Failed to create anomaly",
+					zap.String("instance", instance.ResourceID),
+					zap.String("database", database.DatabaseName),
+					zap.String("type", string(api.AnomalyDatabaseTableGrowth)),
+					zap.Error(err))
+			}
+		}
+	} else {
+		err := s.store.ArchiveAnomalyV2(ctx, &store.ArchiveAnomalyMessage{
+			DatabaseUID: &database.UID,
+			Type:        api.AnomalyDatabaseTableGrowth,
+		})
+		if err != nil && common.ErrorCode(err) != common.NotFound {
+			log.Error("Failed to close anomaly",
+				zap.String("instance", instance.ResourceID),
+				zap.String("database", database.DatabaseName),
+				zap.String("type", string(api.AnomalyDatabaseTableGrowth)),
+				zap.Error(err))
+		}
+	}
+}
+
+// getTableGrowthList returns the growth of the tables violating the table growth policy.
+// The stats must be ordered by the created time. The tables missing in the latest recording are skipped because they have been dropped.
+func getTableGrowthList(stats []*store.TableStatMessage, policy *api.TableGrowthPolicy) []*api.AnomalyTableGrowth {
+	type tableKey struct {
+		schema string
+		table  string
+	}
+	var keys []tableKey
+	var latestTs int64
+	firstStats := make(map[tableKey]*store.TableStatMessage)
+	lastStats := make(map[tableKey]*store.TableStatMessage)
+	for _, stat := range stats {
+		key := tableKey{schema: stat.SchemaName, table: stat.TableName}
+		if _, ok := firstStats[key]; !ok {
+			firstStats[key] = stat
+			keys = append(keys, key)
+		}
+		lastStats[key] = stat
+		if stat.CreatedTs > latestTs {
+			latestTs = stat.CreatedTs
+		}
+	}
+
+	var tableList []*api.AnomalyTableGrowth
+	for _, key := range keys {
+		first, last := firstStats[key], lastStats[key]
+		if last.CreatedTs != latestTs {
+			continue
+		}
+		growth := &api.AnomalyTableGrowth{
+			Schema: key.schema,
+			Table:  key.table,
+			Size:   last.DataSize + last.IndexSize,
+		}
+		violated := false
+		if policy.MaxTableSize > 0 && float64(growth.Size) >= float64(policy.MaxTableSize)*tableSizeWarningRatio {
+			violated = true
+		}
+		firstSize := first.DataSize + first.IndexSize
+		period := time.Duration(last.CreatedTs-first.CreatedTs) * time.Second
+		if firstSize > 0 && period >= tableGrowthMinPeriod {
+			growth.DailyGrowthPercent = float64(growth.Size-firstSize) / float64(firstSize) * 100 / period.Hours() * 24
+			if policy.MaxDailyGrowthPercent > 0 && growth.DailyGrowthPercent > policy.MaxDailyGrowthPercent {
+				violated = true
+			}
+		}
+		if violated {
+			tableList = append(tableList, growth)
+		}
+	}
+	return tableList
 }
 
 func disableBackupAnomalyCheck(dbTp db.Type) bool {
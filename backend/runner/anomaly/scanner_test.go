@@ -0,0 +1,60 @@
+package anomaly
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func TestGetTableGrowthList(t *testing.T) {
+	const day = int64(24 * 60 * 60)
+	stats := []*store.TableStatMessage{
+		{CreatedTs: 0, SchemaName: "public", TableName: "orders", DataSize: 800, IndexSize: 200},
+		{CreatedTs: 0, SchemaName: "public", TableName: "users", DataSize: 100},
+		{CreatedTs: 0, SchemaName: "public", TableName: "dropped", DataSize: 100},
+		{CreatedTs: 2 * day, SchemaName: "public", TableName: "orders", DataSize: 1600, IndexSize: 400},
+		{CreatedTs: 2 * day, SchemaName: "public", TableName: "users", DataSize: 110},
+		// The table is created recently, so the growth rate is not computed.
+		{CreatedTs: day + day/2, SchemaName: "public", TableName: "events", DataSize: 100},
+		{CreatedTs: 2 * day, SchemaName: "public", TableName: "events", DataSize: 900},
+	}
+
+	tests := []struct {
+		policy *api.TableGrowthPolicy
+		want   []*api.AnomalyTableGrowth
+	}{
+		{
+			policy: &api.TableGrowthPolicy{},
+			want:   nil,
+		},
+		{
+			policy: &api.TableGrowthPolicy{MaxDailyGrowthPercent: 10},
+			want: []*api.AnomalyTableGrowth{
+				{Schema: "public", Table: "orders", Size: 2000, DailyGrowthPercent: 50},
+			},
+		},
+		{
+			policy: &api.TableGrowthPolicy{MaxDailyGrowthPercent: 1},
+			want: []*api.AnomalyTableGrowth{
+				{Schema: "public", Table: "orders", Size: 2000, DailyGrowthPercent: 50},
+				{Schema: "public", Table: "users", Size: 110, DailyGrowthPercent: 5},
+			},
+		},
+		{
+			// The tables reaching 80% of the max table size are reported.
+			policy: &api.TableGrowthPolicy{MaxTableSize: 1100},
+			want: []*api.AnomalyTableGrowth{
+				{Schema: "public", Table: "orders", Size: 2000, DailyGrowthPercent: 50},
+				{Schema: "public", Table: "events", Size: 900},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		got := getTableGrowthList(stats, test.policy)
+		require.Equal(t, test.want, got)
+	}
+}
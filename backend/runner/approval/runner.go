@@ -42,9 +42,15 @@ var RiskFactors = []cel.EnvOption{
 	cel.Variable("database_name", cel.StringType),
 	cel.Variable("db_engine", cel.StringType),
 	cel.Variable("sql_type", cel.StringType),
+	// the table lock mode of the statement, such as ACCESS_EXCLUSIVE for PostgreSQL and EXCLUSIVE for MySQL.
+	cel.Variable("lock_level", cel.StringType),
 
 	// number factors
 	cel.Variable("affected_rows", cel.IntType),
+	// the row count of the table locked by the statement.
+	cel.Variable("table_rows", cel.IntType),
+	// the estimated seconds the statement blocks the writes to the table.
+	cel.Variable("lock_seconds", cel.IntType),
 }
 
 // ApprovalFactors are the variables when finding the approval template.
@@ -64,6 +70,7 @@ var issueTypeToRiskSource = map[api.IssueType]store.RiskSource{
 	// RiskSourceUnknown
 	api.IssueGeneral:             store.RiskSourceUnknown,
 	api.IssueDatabaseRestorePITR: store.RiskSourceUnknown,
+	api.IssueDatabaseQueryAccess: store.RiskSourceUnknown,
 }
 
 // Runner is the runner for finding approval templates for issues.
@@ -161,6 +168,15 @@ func (r *Runner) findApprovalTemplateForIssue(ctx context.Context, issue *store.
 		return true, nil
 	}
 
+	// The query access issue always requires approval, no matter whether the custom approval is enabled.
+	if issue.Type == api.IssueDatabaseQueryAccess {
+		approvalTemplate, err := r.getQueryAccessApprovalTemplate(ctx)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to get query access approval template")
+		}
+		return r.updateApprovalTemplate(ctx, issue, approvalTemplate, nil /* riskLevel */)
+	}
+
 	// no need to find if
 	// - feature is not enabled
 	// - risk source is RiskSourceUnknown
@@ -172,7 +188,7 @@ func (r *Runner) findApprovalTemplateForIssue(ctx context.Context, issue *store.
 				ApprovalTemplates:   nil,
 				Approvers:           nil,
 			},
-		}); err != nil {
+		}, nil /* riskLevel */); err != nil {
 			return false, errors.Wrap(err, "failed to update issue payload")
 		}
 		return true, nil
@@ -186,7 +202,7 @@ func (r *Runner) findApprovalTemplateForIssue(ctx context.Context, issue *store.
 				ApprovalFindingDone:  true,
 				ApprovalFindingError: err.Error(),
 			},
-		}); updateErr != nil {
+		}, nil /* riskLevel */); updateErr != nil {
 			return false, multierr.Append(errors.Wrap(updateErr, "failed to update issue payload"), err)
 		}
 		return false, err
@@ -194,7 +210,6 @@ func (r *Runner) findApprovalTemplateForIssue(ctx context.Context, issue *store.
 	if !done {
 		return false, nil
 	}
-
 	approvalTemplate, err := getApprovalTemplate(approvalSetting, riskLevel, issueTypeToRiskSource[issue.Type])
 	if err != nil {
 		err = errors.Wrapf(err, "failed to get approval template, riskLevel: %v", riskLevel)
@@ -203,13 +218,19 @@ func (r *Runner) findApprovalTemplateForIssue(ctx context.Context, issue *store.
 				ApprovalFindingDone:  true,
 				ApprovalFindingError: err.Error(),
 			},
-		}); updateErr != nil {
+		}, &riskLevel); updateErr != nil {
 			return false, multierr.Append(errors.Wrap(updateErr, "failed to update issue payload"), err)
 		}
 		return false, err
 	}
 
-	payload = &storepb.IssuePayload{
+	return r.updateApprovalTemplate(ctx, issue, approvalTemplate, &riskLevel)
+}
+
+// updateApprovalTemplate saves the approval template found for the issue, and skips the steps no one can approve.
+// The risk level is saved along with the template for the consumers other than approval, such as the project webhook filters.
+func (r *Runner) updateApprovalTemplate(ctx context.Context, issue *store.IssueMessage, approvalTemplate *storepb.ApprovalTemplate, riskLevel *int64) (bool, error) {
+	payload := &storepb.IssuePayload{
 		Approval: &storepb.IssuePayloadApproval{
 			ApprovalFindingDone: true,
 			ApprovalTemplates:   nil,
@@ -225,7 +246,7 @@ func (r *Runner) findApprovalTemplateForIssue(ctx context.Context, issue *store.
 		return false, errors.Wrap(err, "failed to skip approval step if needed")
 	}
 
-	if err := updateIssuePayload(ctx, r.store, issue.UID, payload); err != nil {
+	if err := updateIssuePayload(ctx, r.store, issue.UID, payload, riskLevel); err != nil {
 		return false, errors.Wrap(err, "failed to update issue payload")
 	}
 
@@ -267,6 +288,43 @@ func (r *Runner) findApprovalTemplateForIssue(ctx context.Context, issue *store.
 	return true, nil
 }
 
+// getQueryAccessApprovalTemplate returns the approval template for the query access issues.
+// The query access is approved by a workspace DBA, or a workspace owner if there is no DBA.
+func (r *Runner) getQueryAccessApprovalTemplate(ctx context.Context) (*storepb.ApprovalTemplate, error) {
+	role := api.DBA
+	principalType := api.EndUser
+	limit := 1
+	users, err := r.store.ListUsers(ctx, &store.FindUserMessage{
+		Role:  &role,
+		Type:  &principalType,
+		Limit: &limit,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list users for role %s", role)
+	}
+	groupValue := storepb.ApprovalNode_WORKSPACE_DBA
+	if len(users) == 0 {
+		groupValue = storepb.ApprovalNode_WORKSPACE_OWNER
+	}
+	return &storepb.ApprovalTemplate{
+		Title:       "Query access",
+		Description: "The time-boxed access to query the database.",
+		Flow: &storepb.ApprovalFlow{
+			Steps: []*storepb.ApprovalStep{
+				{
+					Type: storepb.ApprovalStep_ANY,
+					Nodes: []*storepb.ApprovalNode{
+						{
+							Type:    storepb.ApprovalNode_ANY_IN_GROUP,
+							Payload: &storepb.ApprovalNode_GroupValue_{GroupValue: groupValue},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
 func getApprovalTemplate(approvalSetting *storepb.WorkspaceApprovalSetting, riskLevel int64, riskSource store.RiskSource) (*storepb.ApprovalTemplate, error) {
 	e, err := cel.NewEnv(ApprovalFactors...)
 	if err != nil {
@@ -355,6 +413,22 @@ func getReportResult(ctx context.Context, s *store.Store, task *store.TaskMessag
 	return payload.ResultList, true, nil
 }
 
+// getOptionalReportResult is the same as getReportResult, except that it's done with no result if the task check is never scheduled,
+// e.g. for the tasks created before the task check type is introduced.
+func getOptionalReportResult(ctx context.Context, s *store.Store, task *store.TaskMessage, taskCheckType api.TaskCheckType) ([]api.TaskCheckResult, bool, error) {
+	reports, err := s.ListTaskCheckRuns(ctx, &store.TaskCheckRunFind{
+		TaskID: &task.ID,
+		Type:   &taskCheckType,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if len(reports) == 0 {
+		return nil, true, nil
+	}
+	return getReportResult(ctx, s, task, taskCheckType)
+}
+
 func getTaskRiskLevel(ctx context.Context, s *store.Store, issue *store.IssueMessage, task *store.TaskMessage, risks []*store.RiskMessage) (int64, bool, error) {
 	// Fall through to "DEFAULT" risk level if risks are empty.
 	if len(risks) == 0 {
@@ -368,7 +442,7 @@ func getTaskRiskLevel(ctx context.Context, s *store.Store, issue *store.IssueMes
 		return 0, false, err
 	}
 
-	var affectedRowsReportResult, statementTypeReportResult []api.TaskCheckResult
+	var affectedRowsReportResult, statementTypeReportResult, lockImpactReportResult []api.TaskCheckResult
 	if api.IsTaskCheckReportSupported(instance.Engine) && api.IsTaskCheckReportNeededForTaskType(task.Type) {
 		affectedRowsReportResultInner, done, err := getReportResult(ctx, s, task, api.TaskCheckDatabaseStatementAffectedRowsReport)
 		if err != nil {
@@ -387,6 +461,15 @@ func getTaskRiskLevel(ctx context.Context, s *store.Store, issue *store.IssueMes
 			return 0, false, nil
 		}
 		statementTypeReportResult = statementTypeReportResultInner
+
+		lockImpactReportResultInner, done, err := getOptionalReportResult(ctx, s, task, api.TaskCheckDatabaseStatementLockImpactReport)
+		if err != nil {
+			return 0, false, err
+		}
+		if !done {
+			return 0, false, nil
+		}
+		lockImpactReportResult = lockImpactReportResultInner
 	}
 
 	if len(affectedRowsReportResult) != len(statementTypeReportResult) {
@@ -444,6 +527,9 @@ func getTaskRiskLevel(ctx context.Context, s *store.Store, issue *store.IssueMes
 			"db_engine":     string(instance.Engine),
 			"sql_type":      "UNKNOWN",
 			"affected_rows": 0,
+			"lock_level":    "",
+			"table_rows":    0,
+			"lock_seconds":  0,
 		}
 
 		// eval for each statement
@@ -461,6 +547,18 @@ func getTaskRiskLevel(ctx context.Context, s *store.Store, issue *store.IssueMes
 				if statementTypeReportResult[i].Code == common.Ok.Int() {
 					args["sql_type"] = statementTypeReportResult[i].Content
 				}
+				args["lock_level"], args["table_rows"], args["lock_seconds"] = "", int64(0), int64(0)
+				// The lock impact report is missing for the unsupported engines and the tasks created before it's introduced.
+				if len(lockImpactReportResult) == len(statementTypeReportResult) && lockImpactReportResult[i].Code == common.Ok.Int() {
+					lockImpact := &api.TaskCheckLockImpact{}
+					if err := json.Unmarshal([]byte(lockImpactReportResult[i].Content), lockImpact); err != nil {
+						log.Warn("failed to unmarshal lock impact report, will ignore the lock factors", zap.Error(err))
+					} else {
+						args["lock_level"] = lockImpact.LockLevel
+						args["table_rows"] = lockImpact.TableRows
+						args["lock_seconds"] = lockImpact.BlockingSeconds
+					}
+				}
 
 				res, _, err := prg.Eval(args)
 				if err != nil {
@@ -495,14 +593,16 @@ func getTaskRiskLevel(ctx context.Context, s *store.Store, issue *store.IssueMes
 	return maxRisk, true, nil
 }
 
-func updateIssuePayload(ctx context.Context, s *store.Store, issueID int, payload *storepb.IssuePayload) error {
+// updateIssuePayload updates the issue payload, and the risk level if it's not nil.
+func updateIssuePayload(ctx context.Context, s *store.Store, issueID int, payload *storepb.IssuePayload, riskLevel *int64) error {
 	payloadBytes, err := protojson.Marshal(payload)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal issue payload")
 	}
 	payloadStr := string(payloadBytes)
 	if _, err := s.UpdateIssueV2(ctx, issueID, &store.UpdateIssueMessage{
-		Payload: &payloadStr,
+		Payload:   &payloadStr,
+		RiskLevel: riskLevel,
 	}, api.SystemBotID); err != nil {
 		return errors.Wrap(err, "failed to update issue payload")
 	}
@@ -0,0 +1,362 @@
+package backuprun
+
+import (
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/hkdf"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+const (
+	// encryptionChunkSize is the plaintext size of each encrypted chunk in a backup file.
+	encryptionChunkSize = 64 * 1024
+	// dataKeySize is the size of the AES-256 data key.
+	dataKeySize = 32
+	// workspaceKeyInfo is the HKDF info to derive the workspace key from the auth secret.
+	workspaceKeyInfo = "bytebase backup encryption key"
+)
+
+// NewEncoder returns a writer which compresses and then encrypts the backup written to it into w.
+// The codec related fields of payload are set accordingly, and RawSize and RawChecksum are set when the writer is closed.
+// The caller must close the returned writer to flush the data. Closing it does not close w.
+func NewEncoder(w io.Writer, compression api.BackupCompression, encryption api.BackupEncryption, secret string, payload *api.BackupPayload) (io.WriteCloser, error) {
+	e := &encoder{payload: payload, checksum: sha256.New()}
+	out := w
+
+	switch encryption {
+	case api.BackupEncryptionNone:
+	case api.BackupEncryptionAES256GCM:
+		dataKey := make([]byte, dataKeySize)
+		if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+			return nil, errors.Wrap(err, "failed to generate data key")
+		}
+		encryptedDataKey, err := encryptDataKey(secret, dataKey)
+		if err != nil {
+			return nil, err
+		}
+		encryptWriter, err := newEncryptWriter(out, dataKey)
+		if err != nil {
+			return nil, err
+		}
+		out = encryptWriter
+		e.closers = append(e.closers, encryptWriter)
+		payload.EncryptedDataKey = encryptedDataKey
+	default:
+		return nil, errors.Errorf("unsupported backup encryption %q", encryption)
+	}
+
+	switch compression {
+	case api.BackupCompressionNone:
+	case api.BackupCompressionGzip:
+		gzipWriter := gzip.NewWriter(out)
+		out = gzipWriter
+		e.closers = append(e.closers, gzipWriter)
+	case api.BackupCompressionZstd:
+		zstdWriter, err := zstd.NewWriter(out)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create zstd writer")
+		}
+		out = zstdWriter
+		e.closers = append(e.closers, zstdWriter)
+	default:
+		return nil, errors.Errorf("unsupported backup compression %q", compression)
+	}
+
+	payload.Compression = compression
+	payload.Encryption = encryption
+	e.w = out
+	return e, nil
+}
+
+// NewDecoder returns a reader which decrypts and then decompresses the backup file r according to the codec in payload.
+// Backups without codec, including those taken before the codec was introduced, are returned as is.
+// Closing the returned reader does not close r.
+func NewDecoder(r io.Reader, payload api.BackupPayload, secret string) (io.ReadCloser, error) {
+	in := r
+
+	switch payload.Encryption {
+	case api.BackupEncryptionNone:
+	case api.BackupEncryptionAES256GCM:
+		dataKey, err := decryptDataKey(secret, payload.EncryptedDataKey)
+		if err != nil {
+			return nil, err
+		}
+		decryptReader, err := newDecryptReader(in, dataKey)
+		if err != nil {
+			return nil, err
+		}
+		in = decryptReader
+	default:
+		return nil, errors.Errorf("unsupported backup encryption %q", payload.Encryption)
+	}
+
+	switch payload.Compression {
+	case api.BackupCompressionNone:
+		return io.NopCloser(in), nil
+	case api.BackupCompressionGzip:
+		gzipReader, err := gzip.NewReader(in)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create gzip reader")
+		}
+		return gzipReader, nil
+	case api.BackupCompressionZstd:
+		zstdReader, err := zstd.NewReader(in)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create zstd reader")
+		}
+		return zstdReader.IOReadCloser(), nil
+	default:
+		return nil, errors.Errorf("unsupported backup compression %q", payload.Compression)
+	}
+}
+
+type encoder struct {
+	w        io.Writer
+	closers  []io.Closer
+	payload  *api.BackupPayload
+	rawSize  int64
+	checksum hash.Hash
+}
+
+func (e *encoder) Write(p []byte) (int, error) {
+	n, err := e.w.Write(p)
+	e.rawSize += int64(n)
+	e.checksum.Write(p[:n])
+	return n, err
+}
+
+// Close closes the compressor before the encryptor so that the compressed data is flushed to the encryptor.
+func (e *encoder) Close() error {
+	for i := len(e.closers) - 1; i >= 0; i-- {
+		if err := e.closers[i].Close(); err != nil {
+			return errors.Wrap(err, "failed to flush backup encoder")
+		}
+	}
+	e.payload.RawSize = e.rawSize
+	e.payload.RawChecksum = hex.EncodeToString(e.checksum.Sum(nil))
+	return nil
+}
+
+// VerifyChecksum reads the decoded backup r to the end, and checks its size and checksum against the ones recorded in payload.
+// Backups taken before the checksum was introduced are only checked to be readable.
+func VerifyChecksum(r io.Reader, payload api.BackupPayload) error {
+	checksum := sha256.New()
+	n, err := io.Copy(checksum, r)
+	if err != nil {
+		return errors.Wrap(err, "failed to read backup")
+	}
+	if payload.RawChecksum == "" {
+		return nil
+	}
+	if n != payload.RawSize {
+		return errors.Errorf("backup size %d does not match the recorded size %d, the backup may be truncated", n, payload.RawSize)
+	}
+	if got := hex.EncodeToString(checksum.Sum(nil)); got != payload.RawChecksum {
+		return errors.Errorf("backup checksum %s does not match the recorded checksum %s", got, payload.RawChecksum)
+	}
+	return nil
+}
+
+// deriveWorkspaceKey derives the AES-256 workspace key from the auth secret.
+func deriveWorkspaceKey(secret string) ([]byte, error) {
+	if secret == "" {
+		return nil, errors.Errorf("workspace secret is empty")
+	}
+	key := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, []byte(secret), nil, []byte(workspaceKeyInfo)), key); err != nil {
+		return nil, errors.Wrap(err, "failed to derive workspace key")
+	}
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AES cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create GCM")
+	}
+	return aead, nil
+}
+
+// encryptDataKey encrypts the data key with the workspace key, and returns base64(nonce || ciphertext).
+func encryptDataKey(secret string, dataKey []byte) (string, error) {
+	workspaceKey, err := deriveWorkspaceKey(secret)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newGCM(workspaceKey)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Wrap(err, "failed to generate nonce")
+	}
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, dataKey, nil)), nil
+}
+
+func decryptDataKey(secret string, encryptedDataKey string) ([]byte, error) {
+	workspaceKey, err := deriveWorkspaceKey(secret)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(workspaceKey)
+	if err != nil {
+		return nil, err
+	}
+	content, err := base64.StdEncoding.DecodeString(encryptedDataKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode encrypted data key")
+	}
+	if len(content) < aead.NonceSize() {
+		return nil, errors.Errorf("invalid encrypted data key")
+	}
+	dataKey, err := aead.Open(nil, content[:aead.NonceSize()], content[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt data key, the workspace secret may have changed since the backup was taken")
+	}
+	return dataKey, nil
+}
+
+// chunkNonce returns the nonce of the seq-th chunk.
+// The data key is unique per backup file, so a counter is enough to make the nonce unique.
+// The last byte marks the final chunk, so that a truncated backup file fails to decrypt.
+func chunkNonce(aead cipher.AEAD, seq uint64, final bool) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce, seq)
+	if final {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce
+}
+
+// encryptWriter splits the plaintext into chunks and seals each chunk with AES-GCM.
+type encryptWriter struct {
+	w    io.Writer
+	aead cipher.AEAD
+	buf  []byte
+	seq  uint64
+}
+
+func newEncryptWriter(w io.Writer, dataKey []byte) (*encryptWriter, error) {
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptWriter{
+		w:    w,
+		aead: aead,
+		buf:  make([]byte, 0, encryptionChunkSize),
+	}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := copy(e.buf[len(e.buf):cap(e.buf)], p)
+		e.buf = e.buf[:len(e.buf)+n]
+		p = p[n:]
+		written += n
+		if len(e.buf) == cap(e.buf) {
+			if err := e.flush(false /* final */); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Close writes the final chunk, which may be empty.
+func (e *encryptWriter) Close() error {
+	return e.flush(true /* final */)
+}
+
+func (e *encryptWriter) flush(final bool) error {
+	ciphertext := e.aead.Seal(nil, chunkNonce(e.aead, e.seq, final), e.buf, nil)
+	if _, err := e.w.Write(ciphertext); err != nil {
+		return errors.Wrap(err, "failed to write encrypted chunk")
+	}
+	e.seq++
+	e.buf = e.buf[:0]
+	return nil
+}
+
+// decryptReader reads the chunks written by encryptWriter.
+type decryptReader struct {
+	r     io.Reader
+	aead  cipher.AEAD
+	buf   []byte
+	plain []byte
+	seq   uint64
+	done  bool
+}
+
+func newDecryptReader(r io.Reader, dataKey []byte) (*decryptReader, error) {
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &decryptReader{
+		r:    r,
+		aead: aead,
+		buf:  make([]byte, encryptionChunkSize+aead.Overhead()),
+	}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		if err := d.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+func (d *decryptReader) next() error {
+	n, err := io.ReadFull(d.r, d.buf)
+	if err == io.EOF {
+		return errors.Errorf("backup file is truncated")
+	}
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return errors.Wrap(err, "failed to read encrypted chunk")
+	}
+	ciphertext := d.buf[:n]
+	if n == len(d.buf) {
+		if plain, err := d.aead.Open(nil, chunkNonce(d.aead, d.seq, false /* final */), ciphertext, nil); err == nil {
+			d.plain = plain
+			d.seq++
+			return nil
+		}
+	}
+	plain, err := d.aead.Open(nil, chunkNonce(d.aead, d.seq, true /* final */), ciphertext, nil)
+	if err != nil {
+		return errors.Errorf("failed to decrypt chunk %d of the backup file, the backup file is corrupted or truncated", d.seq)
+	}
+	// Nothing should follow the final chunk.
+	if m, _ := d.r.Read(make([]byte, 1)); m > 0 {
+		return errors.Errorf("unexpected data after the final chunk of the backup file")
+	}
+	d.plain = plain
+	d.done = true
+	return nil
+}
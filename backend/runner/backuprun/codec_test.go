@@ -0,0 +1,119 @@
+package backuprun
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+func TestBackupCodec(t *testing.T) {
+	a := require.New(t)
+	const secret = "workspace-secret"
+	// Cover the empty dump, a dump smaller than a chunk and a dump exactly on the chunk boundary.
+	dumps := []string{
+		"",
+		"CREATE TABLE t(id INT);\nINSERT INTO t VALUES (1);\n",
+		strings.Repeat("a", encryptionChunkSize),
+		strings.Repeat("INSERT INTO t VALUES (1);\n", 10000),
+	}
+	compressions := []api.BackupCompression{api.BackupCompressionNone, api.BackupCompressionGzip, api.BackupCompressionZstd}
+	encryptions := []api.BackupEncryption{api.BackupEncryptionNone, api.BackupEncryptionAES256GCM}
+
+	for _, dump := range dumps {
+		for _, compression := range compressions {
+			for _, encryption := range encryptions {
+				var buf bytes.Buffer
+				payload := api.BackupPayload{}
+				encoder, err := NewEncoder(&buf, compression, encryption, secret, &payload)
+				a.NoError(err)
+				_, err = io.WriteString(encoder, dump)
+				a.NoError(err)
+				a.NoError(encoder.Close())
+				a.Equal(compression, payload.Compression)
+				a.Equal(encryption, payload.Encryption)
+				a.Equal(int64(len(dump)), payload.RawSize)
+				if encryption != api.BackupEncryptionNone && len(dump) > 0 {
+					a.NotContains(buf.String(), dump)
+				}
+
+				decoder, err := NewDecoder(bytes.NewReader(buf.Bytes()), payload, secret)
+				a.NoError(err)
+				got, err := io.ReadAll(decoder)
+				a.NoError(err)
+				a.NoError(decoder.Close())
+				a.Equal(dump, string(got))
+
+				decoder, err = NewDecoder(bytes.NewReader(buf.Bytes()), payload, secret)
+				a.NoError(err)
+				a.NoError(VerifyChecksum(decoder, payload))
+			}
+		}
+	}
+}
+
+func TestBackupCodecLegacyBackup(t *testing.T) {
+	a := require.New(t)
+	dump := "CREATE TABLE t(id INT);\n"
+	// Backups taken before the codec was introduced have no codec in the payload.
+	decoder, err := NewDecoder(strings.NewReader(dump), api.BackupPayload{}, "")
+	a.NoError(err)
+	got, err := io.ReadAll(decoder)
+	a.NoError(err)
+	a.Equal(dump, string(got))
+}
+
+func TestBackupCodecTamper(t *testing.T) {
+	a := require.New(t)
+	dump := strings.Repeat("INSERT INTO t VALUES (1);\n", 10000)
+	var buf bytes.Buffer
+	payload := api.BackupPayload{}
+	encoder, err := NewEncoder(&buf, api.BackupCompressionNone, api.BackupEncryptionAES256GCM, "secret", &payload)
+	a.NoError(err)
+	_, err = io.WriteString(encoder, dump)
+	a.NoError(err)
+	a.NoError(encoder.Close())
+
+	// A backup truncated on the chunk boundary.
+	truncated := buf.Bytes()[:encryptionChunkSize+16]
+	decoder, err := NewDecoder(bytes.NewReader(truncated), payload, "secret")
+	a.NoError(err)
+	_, err = io.ReadAll(decoder)
+	a.Error(err)
+
+	// A backup truncated in the middle of a chunk.
+	truncated = buf.Bytes()[:buf.Len()-10]
+	decoder, err = NewDecoder(bytes.NewReader(truncated), payload, "secret")
+	a.NoError(err)
+	_, err = io.ReadAll(decoder)
+	a.Error(err)
+
+	// The workspace secret has changed.
+	_, err = NewDecoder(bytes.NewReader(buf.Bytes()), payload, "another-secret")
+	a.Error(err)
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	a := require.New(t)
+	dump := "CREATE TABLE t(id INT);\nINSERT INTO t VALUES (1);\n"
+	var buf bytes.Buffer
+	payload := api.BackupPayload{}
+	encoder, err := NewEncoder(&buf, api.BackupCompressionNone, api.BackupEncryptionNone, "", &payload)
+	a.NoError(err)
+	_, err = io.WriteString(encoder, dump)
+	a.NoError(err)
+	a.NoError(encoder.Close())
+	a.NotEmpty(payload.RawChecksum)
+
+	a.NoError(VerifyChecksum(strings.NewReader(dump), payload))
+	// A plain dump truncated without any codec.
+	a.Error(VerifyChecksum(strings.NewReader(dump[:len(dump)-10]), payload))
+	// A plain dump corrupted with the same size.
+	a.Error(VerifyChecksum(strings.NewReader(strings.Replace(dump, "1", "2", 1)), payload))
+	// Backups taken before the checksum was introduced.
+	a.NoError(VerifyChecksum(strings.NewReader(dump), api.BackupPayload{}))
+}
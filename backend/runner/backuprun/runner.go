@@ -25,18 +25,21 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
-	"github.com/bytebase/bytebase/backend/plugin/storage/s3"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
 )
 
 // NewRunner creates a new backup runner.
-func NewRunner(store *store.Store, dbFactory *dbfactory.DBFactory, s3Client *s3.Client, stateCfg *state.State, profile *config.Profile) *Runner {
+// The storageBackend is nil if backups are stored in the local data directory.
+// The secret is used to decrypt the backups to verify.
+func NewRunner(store *store.Store, dbFactory *dbfactory.DBFactory, storageBackend storage.Backend, stateCfg *state.State, secret string, profile *config.Profile) *Runner {
 	return &Runner{
 		store:                     store,
 		dbFactory:                 dbFactory,
-		s3Client:                  s3Client,
+		storageBackend:            storageBackend,
 		stateCfg:                  stateCfg,
+		secret:                    secret,
 		profile:                   profile,
 		downloadBinlogInstanceIDs: make(map[int]bool),
 	}
@@ -46,13 +49,17 @@ func NewRunner(store *store.Store, dbFactory *dbfactory.DBFactory, s3Client *s3.
 type Runner struct {
 	store                     *store.Store
 	dbFactory                 *dbfactory.DBFactory
-	s3Client                  *s3.Client
+	storageBackend            storage.Backend
 	stateCfg                  *state.State
+	secret                    string
 	profile                   *config.Profile
 	downloadBinlogInstanceIDs map[int]bool
 	backupWg                  sync.WaitGroup
 	downloadBinlogWg          sync.WaitGroup
 	downloadBinlogMu          sync.Mutex
+	verifyingBackup           bool
+	verifyBackupWg            sync.WaitGroup
+	verifyBackupMu            sync.Mutex
 }
 
 // Run is the runner for backup runner.
@@ -77,10 +84,12 @@ func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
 				r.startAutoBackups(ctx)
 				r.downloadBinlogFiles(ctx)
 				r.purgeExpiredBackupData(ctx)
+				r.verifyBackups(ctx)
 			}()
 		case <-ctx.Done(): // if cancel() execute
 			r.backupWg.Wait()
 			r.downloadBinlogWg.Wait()
+			r.verifyBackupWg.Wait()
 			return
 		}
 	}
@@ -127,24 +136,31 @@ func (r *Runner) purgeExpiredBackupData(ctx context.Context) {
 	}
 
 	for _, instance := range instanceList {
-		if instance.Engine != db.MySQL && instance.Engine != db.MariaDB {
+		isPostgresWALArchive := instance.Engine == db.Postgres && r.profile.BackupPostgresWALArchive
+		if instance.Engine != db.MySQL && instance.Engine != db.MariaDB && !isPostgresWALArchive {
 			continue
 		}
-		maxRetentionPeriodTs, err := r.getMaxRetentionPeriodTsForMySQLInstance(ctx, instance)
+		maxRetentionPeriodTs, err := r.getMaxRetentionPeriodTsForInstance(ctx, instance)
 		if err != nil {
-			log.Error("Failed to get max retention period for MySQL instance", zap.String("instance", instance.Name), zap.Error(err))
+			log.Error("Failed to get max retention period for instance", zap.String("instance", instance.Name), zap.Error(err))
 			continue
 		}
 		if maxRetentionPeriodTs == math.MaxInt {
 			continue
 		}
+		if isPostgresWALArchive {
+			if err := r.purgeWALArchive(ctx, instance.ID, maxRetentionPeriodTs); err != nil {
+				log.Error("Failed to purge WAL archive for instance", zap.String("instance", instance.Name), zap.Int("retentionPeriodTs", maxRetentionPeriodTs), zap.Error(err))
+			}
+			continue
+		}
 		if err := r.purgeBinlogFiles(ctx, instance.ID, maxRetentionPeriodTs); err != nil {
 			log.Error("Failed to purge binlog files for instance", zap.String("instance", instance.Name), zap.Int("retentionPeriodTs", maxRetentionPeriodTs), zap.Error(err))
 		}
 	}
 }
 
-func (r *Runner) getMaxRetentionPeriodTsForMySQLInstance(ctx context.Context, instance *api.Instance) (int, error) {
+func (r *Runner) getMaxRetentionPeriodTsForInstance(ctx context.Context, instance *api.Instance) (int, error) {
 	backupSettingList, err := r.store.ListBackupSettingV2(ctx, &store.FindBackupSettingMessage{InstanceUID: &instance.ID})
 	if err != nil {
 		log.Error("Failed to find backup settings for instance.", zap.String("instance", instance.Name), zap.Error(err))
@@ -161,19 +177,18 @@ func (r *Runner) getMaxRetentionPeriodTsForMySQLInstance(ctx context.Context, in
 
 func (r *Runner) purgeBinlogFiles(ctx context.Context, instanceID, retentionPeriodTs int) error {
 	binlogDir := common.GetBinlogAbsDir(r.profile.DataDir, instanceID)
-	switch r.profile.BackupStorageBackend {
-	case api.BackupStorageBackendLocal:
+	if r.profile.BackupStorageBackend == api.BackupStorageBackendLocal {
 		return r.purgeBinlogFilesLocal(binlogDir, retentionPeriodTs)
-	case api.BackupStorageBackendS3:
-		return r.purgeBinlogFilesOnCloud(ctx, binlogDir, retentionPeriodTs)
-	default:
+	}
+	if r.storageBackend == nil {
 		return errors.Errorf("purge binlog files not implemented for storage backend %s", r.profile.BackupStorageBackend)
 	}
+	return r.purgeBinlogFilesOnCloud(ctx, binlogDir, retentionPeriodTs)
 }
 
 func (r *Runner) purgeBinlogFilesOnCloud(ctx context.Context, binlogDir string, retentionPeriodTs int) error {
 	binlogDirOnCloud := common.GetBinlogRelativeDir(binlogDir)
-	listOutput, err := r.s3Client.ListObjects(ctx, binlogDirOnCloud)
+	listOutput, err := r.storageBackend.ListObjects(ctx, binlogDirOnCloud)
 	if err != nil {
 		return errors.Wrapf(err, "failed to list binlog dir %q in the cloud storage", binlogDirOnCloud)
 	}
@@ -181,12 +196,12 @@ func (r *Runner) purgeBinlogFilesOnCloud(ctx context.Context, binlogDir string,
 	for _, item := range listOutput {
 		expireTime := item.LastModified.Add(time.Duration(retentionPeriodTs) * time.Second)
 		if time.Now().After(expireTime) {
-			purgeBinlogPathList = append(purgeBinlogPathList, *item.Key)
+			purgeBinlogPathList = append(purgeBinlogPathList, item.Key)
 		}
 	}
 	if len(purgeBinlogPathList) > 0 {
 		log.Debug(fmt.Sprintf("Deleting %d expired binlog files from the cloud storage.", len(purgeBinlogPathList)))
-		if _, err := r.s3Client.DeleteObjects(ctx, purgeBinlogPathList...); err != nil {
+		if err := r.storageBackend.DeleteObjects(ctx, purgeBinlogPathList...); err != nil {
 			return errors.Wrapf(err, "failed to delete %d expired binlog files from the cloud storage", len(purgeBinlogPathList))
 		}
 	}
@@ -239,20 +254,22 @@ func (r *Runner) purgeBackup(ctx context.Context, backup *store.BackupMessage) e
 	}
 	log.Debug("Archived expired backup record", zap.String("name", backup.Name), zap.Int("id", backup.UID))
 
-	switch backup.StorageBackend {
-	case api.BackupStorageBackendLocal:
+	if backup.StorageBackend == api.BackupStorageBackendLocal {
 		backupFilePath := GetBackupAbsFilePath(r.profile.DataDir, backup.DatabaseUID, backup.Name)
 		if err := os.Remove(backupFilePath); err != nil {
 			return errors.Wrapf(err, "failed to delete an expired backup file %q", backupFilePath)
 		}
 		log.Debug(fmt.Sprintf("Deleted expired local backup file %s", backupFilePath))
-	case api.BackupStorageBackendS3:
-		backupFilePath := getBackupRelativeFilePath(backup.DatabaseUID, backup.Name)
-		if _, err := r.s3Client.DeleteObjects(ctx, backupFilePath); err != nil {
-			return errors.Wrapf(err, "failed to delete backup file %s in the cloud storage", backupFilePath)
-		}
-		log.Debug(fmt.Sprintf("Deleted expired backup file %s in the cloud storage", backupFilePath))
+		return nil
+	}
+	if backup.StorageBackend != r.profile.BackupStorageBackend || r.storageBackend == nil {
+		return errors.Errorf("cannot delete backup file %q in storage backend %s, the current storage backend is %s", backup.Path, backup.StorageBackend, r.profile.BackupStorageBackend)
 	}
+	backupFilePath := getBackupRelativeFilePath(backup.DatabaseUID, backup.Name)
+	if err := r.storageBackend.DeleteObjects(ctx, backupFilePath); err != nil {
+		return errors.Wrapf(err, "failed to delete backup file %s in the cloud storage", backupFilePath)
+	}
+	log.Debug(fmt.Sprintf("Deleted expired backup file %s in the cloud storage", backupFilePath))
 
 	return nil
 }
@@ -267,12 +284,17 @@ func (r *Runner) downloadBinlogFiles(ctx context.Context) {
 	r.downloadBinlogMu.Lock()
 	defer r.downloadBinlogMu.Unlock()
 	for _, instance := range instances {
-		if instance.Engine != db.MySQL && instance.Engine != db.MariaDB {
+		isPostgresWALArchive := instance.Engine == db.Postgres && r.profile.BackupPostgresWALArchive
+		if instance.Engine != db.MySQL && instance.Engine != db.MariaDB && !isPostgresWALArchive {
 			continue
 		}
 		if _, ok := r.downloadBinlogInstanceIDs[instance.UID]; !ok {
 			r.downloadBinlogInstanceIDs[instance.UID] = true
-			go r.downloadBinlogFilesForInstance(ctx, instance)
+			if isPostgresWALArchive {
+				go r.archiveWALForInstance(ctx, instance)
+			} else {
+				go r.downloadBinlogFilesForInstance(ctx, instance)
+			}
 			r.downloadBinlogWg.Add(1)
 		}
 	}
@@ -301,7 +323,7 @@ func (r *Runner) downloadBinlogFilesForInstance(ctx context.Context, instance *s
 		log.Error("Failed to cast driver to mysql.Driver", zap.String("instance", instance.ResourceID))
 		return
 	}
-	if err := mysqlDriver.FetchAllBinlogFiles(ctx, false /* downloadLatestBinlogFile */, r.s3Client); err != nil {
+	if err := mysqlDriver.FetchAllBinlogFiles(ctx, false /* downloadLatestBinlogFile */, r.storageBackend); err != nil {
 		log.Error("Failed to download all binlog files for instance", zap.String("instance", instance.ResourceID), zap.Error(err))
 		return
 	}
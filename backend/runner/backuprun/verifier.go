@@ -0,0 +1,325 @@
+package backuprun
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// maxReportedTableCount is the max number of tables reported in the verification failure detail.
+const maxReportedTableCount = 10
+
+// verifyBackups starts verifying the latest backups in the background if it is not running yet.
+// Verification restores a backup into a scratch database on the same instance, so it runs one backup at a time.
+func (r *Runner) verifyBackups(ctx context.Context) {
+	if !r.profile.BackupVerification {
+		return
+	}
+	r.verifyBackupMu.Lock()
+	defer r.verifyBackupMu.Unlock()
+	if r.verifyingBackup {
+		return
+	}
+	r.verifyingBackup = true
+	r.verifyBackupWg.Add(1)
+	go func() {
+		defer func() {
+			r.verifyBackupMu.Lock()
+			r.verifyingBackup = false
+			r.verifyBackupMu.Unlock()
+			r.verifyBackupWg.Done()
+		}()
+		r.verifyLatestBackups(ctx)
+	}()
+}
+
+// verifyLatestBackups verifies the latest successful backup of each database with automatic backup enabled.
+// The verification results are recorded on the backups and turned into anomalies by the anomaly scanner.
+func (r *Runner) verifyLatestBackups(ctx context.Context) {
+	backupSettingList, err := r.store.ListBackupSettingV2(ctx, &store.FindBackupSettingMessage{})
+	if err != nil {
+		log.Error("Failed to find all the backup settings.", zap.Error(err))
+		return
+	}
+	for _, bs := range backupSettingList {
+		if ctx.Err() != nil {
+			return
+		}
+		if !bs.Enabled {
+			continue
+		}
+		backup, err := r.getLatestBackup(ctx, bs.DatabaseUID)
+		if err != nil {
+			log.Error("Failed to get the latest backup", zap.Int("databaseID", bs.DatabaseUID), zap.Error(err))
+			continue
+		}
+		if backup == nil {
+			continue
+		}
+		if backup.VerificationStatus != api.BackupVerificationUnverified {
+			continue
+		}
+		database, err := r.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: &bs.DatabaseUID})
+		if err != nil {
+			log.Error("Failed to get database", zap.Int("databaseID", bs.DatabaseUID), zap.Error(err))
+			continue
+		}
+		if database == nil {
+			continue
+		}
+		instance, err := r.store.GetInstanceV2(ctx, &store.FindInstanceMessage{ResourceID: &database.InstanceID})
+		if err != nil {
+			log.Error("Failed to get instance", zap.String("instance", database.InstanceID), zap.Error(err))
+			continue
+		}
+		if instance == nil || !supportBackupVerification(instance.Engine) {
+			continue
+		}
+
+		log.Debug("Verifying backup", zap.String("instance", instance.ResourceID), zap.String("database", database.DatabaseName), zap.String("backup", backup.Name))
+		verifyErr := r.verifyBackup(ctx, instance, database, backup)
+		if verifyErr != nil {
+			if ctx.Err() != nil {
+				// The server is shutting down, the backup is not necessarily broken.
+				return
+			}
+			log.Warn("Backup verification failed", zap.String("instance", instance.ResourceID), zap.String("database", database.DatabaseName), zap.String("backup", backup.Name), zap.Error(verifyErr))
+		}
+		if _, err := r.store.UpdateBackupV2(ctx, getBackupVerificationUpdate(backup.UID, verifyErr, time.Now().Unix())); err != nil {
+			log.Error("Failed to record backup verification", zap.String("instance", instance.ResourceID), zap.String("database", database.DatabaseName), zap.String("backup", backup.Name), zap.Error(err))
+		}
+	}
+}
+
+// getBackupVerificationUpdate returns the update recording the verification result of the backup.
+func getBackupVerificationUpdate(backupUID int, verifyErr error, verifiedTs int64) *store.UpdateBackupMessage {
+	status, detail := api.BackupVerificationPassed, ""
+	if verifyErr != nil {
+		status, detail = api.BackupVerificationFailed, verifyErr.Error()
+	}
+	return &store.UpdateBackupMessage{
+		UID:                backupUID,
+		UpdaterID:          api.SystemBotID,
+		VerificationStatus: &status,
+		VerificationDetail: &detail,
+		VerifiedTs:         &verifiedTs,
+	}
+}
+
+func (r *Runner) getLatestBackup(ctx context.Context, databaseUID int) (*store.BackupMessage, error) {
+	statusNormal := api.Normal
+	statusDone := api.BackupStatusDone
+	backupList, err := r.store.ListBackupV2(ctx, &store.FindBackupMessage{
+		DatabaseUID: &databaseUID,
+		RowStatus:   &statusNormal,
+		Status:      &statusDone,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var latest *store.BackupMessage
+	for _, backup := range backupList {
+		if latest == nil || backup.UID > latest.UID {
+			latest = backup
+		}
+	}
+	return latest, nil
+}
+
+// verifyBackup checks the checksum of the backup, restores it into a scratch database on the same instance,
+// and checks the restored database against the recorded schema of the database.
+func (r *Runner) verifyBackup(ctx context.Context, instance *store.InstanceMessage, database *store.DatabaseMessage, backup *store.BackupMessage) error {
+	backupPathLocal := filepath.Join(r.profile.DataDir, backup.Path)
+	if backup.StorageBackend != api.BackupStorageBackendLocal {
+		if backup.StorageBackend != r.profile.BackupStorageBackend {
+			return errors.Errorf("backup %q is stored in storage backend %s, but the current storage backend is %s", backup.Name, backup.StorageBackend, r.profile.BackupStorageBackend)
+		}
+		if r.storageBackend == nil {
+			return errors.Errorf("storage backend %s is not configured", backup.StorageBackend)
+		}
+		// Download to a separate file, so that it does not collide with a restore task downloading the same backup.
+		backupPathLocal = fmt.Sprintf("%s.verify", backupPathLocal)
+		if err := storage.DownloadFileFromCloud(ctx, r.storageBackend, backupPathLocal, backup.Path); err != nil {
+			return errors.Wrapf(err, "failed to download backup %q from %s", backup.Path, backup.StorageBackend)
+		}
+		defer os.Remove(backupPathLocal)
+	}
+
+	if err := r.verifyBackupChecksum(backupPathLocal, backup); err != nil {
+		return err
+	}
+
+	defaultDriver, err := r.dbFactory.GetAdminDatabaseDriver(ctx, instance, "" /* databaseName */)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect instance")
+	}
+	defer defaultDriver.Close(ctx)
+	scratchDatabaseName := getVerificationDatabaseName(backup.UID, time.Now().Unix())
+	if _, err := defaultDriver.GetDB().ExecContext(ctx, fmt.Sprintf("CREATE DATABASE %s;", scratchDatabaseName)); err != nil {
+		return errors.Wrapf(err, "failed to create the scratch database %q", scratchDatabaseName)
+	}
+	defer func() {
+		if _, err := defaultDriver.GetDB().ExecContext(ctx, fmt.Sprintf("DROP DATABASE IF EXISTS %s;", scratchDatabaseName)); err != nil {
+			log.Error("Failed to drop the scratch database for backup verification", zap.String("instance", instance.ResourceID), zap.String("database", scratchDatabaseName), zap.Error(err))
+		}
+	}()
+
+	restoredMetadata, err := r.restoreToScratchDatabase(ctx, instance, scratchDatabaseName, backupPathLocal, backup)
+	if err != nil {
+		return err
+	}
+
+	// The recorded schema is only comparable if there is no schema change since the backup is taken.
+	if database.SchemaVersion != backup.MigrationHistoryVersion {
+		return nil
+	}
+	dbSchema, err := r.store.GetDBSchema(ctx, database.UID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the recorded schema")
+	}
+	if dbSchema == nil || dbSchema.Metadata == nil {
+		return nil
+	}
+	return checkRestoredTables(dbSchema.Metadata, restoredMetadata)
+}
+
+func (r *Runner) verifyBackupChecksum(backupPathLocal string, backup *store.BackupMessage) error {
+	backupFile, err := os.Open(backupPathLocal)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open backup file %q", backupPathLocal)
+	}
+	defer backupFile.Close()
+	backupReader, err := NewDecoder(backupFile, backup.Payload, r.secret)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decode backup file %q", backupPathLocal)
+	}
+	defer backupReader.Close()
+	return VerifyChecksum(backupReader, backup.Payload)
+}
+
+// restoreToScratchDatabase restores the backup into the scratch database, and returns the metadata of the restored database
+// with the row count of each table set to 1 if the table has any row, and 0 otherwise.
+// The driver is closed before returning, so that the scratch database can be dropped afterwards.
+func (r *Runner) restoreToScratchDatabase(ctx context.Context, instance *store.InstanceMessage, scratchDatabaseName string, backupPathLocal string, backup *store.BackupMessage) (*storepb.DatabaseMetadata, error) {
+	driver, err := r.dbFactory.GetAdminDatabaseDriver(ctx, instance, scratchDatabaseName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect the scratch database %q", scratchDatabaseName)
+	}
+	defer driver.Close(ctx)
+
+	backupFile, err := os.Open(backupPathLocal)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open backup file %q", backupPathLocal)
+	}
+	defer backupFile.Close()
+	backupReader, err := NewDecoder(backupFile, backup.Payload, r.secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode backup file %q", backupPathLocal)
+	}
+	defer backupReader.Close()
+	if err := driver.Restore(ctx, backupReader); err != nil {
+		return nil, errors.Wrap(err, "failed to restore backup")
+	}
+
+	metadata, err := driver.SyncDBSchema(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sync the restored schema")
+	}
+	// The row count synced from the database is an estimate, which is not updated right after the restore,
+	// so we check whether each table has any row instead.
+	for _, schema := range metadata.Schemas {
+		for _, table := range schema.Tables {
+			var one int
+			table.RowCount = 1
+			if err := driver.GetDB().QueryRowContext(ctx, fmt.Sprintf("SELECT 1 FROM %s LIMIT 1;", quoteTableName(instance.Engine, schema.Name, table.Name))).Scan(&one); err != nil {
+				if !errors.Is(err, sql.ErrNoRows) {
+					return nil, errors.Wrapf(err, "failed to count rows of the restored table %q", table.Name)
+				}
+				table.RowCount = 0
+			}
+		}
+	}
+	return metadata, nil
+}
+
+// checkRestoredTables checks that every recorded table is restored, and tables having rows in the recorded schema are not empty.
+func checkRestoredTables(recorded, restored *storepb.DatabaseMetadata) error {
+	restoredTables := make(map[string]*storepb.TableMetadata)
+	for _, schema := range restored.Schemas {
+		for _, table := range schema.Tables {
+			restoredTables[getTableKey(schema.Name, table.Name)] = table
+		}
+	}
+	var missingTables, emptyTables []string
+	for _, schema := range recorded.Schemas {
+		for _, table := range schema.Tables {
+			key := getTableKey(schema.Name, table.Name)
+			restoredTable, ok := restoredTables[key]
+			if !ok {
+				missingTables = append(missingTables, key)
+				continue
+			}
+			if table.RowCount > 0 && restoredTable.RowCount == 0 {
+				emptyTables = append(emptyTables, key)
+			}
+		}
+	}
+	if len(missingTables) > 0 {
+		return errors.Errorf("%d tables are missing in the restored database: %s", len(missingTables), formatTableList(missingTables))
+	}
+	if len(emptyTables) > 0 {
+		return errors.Errorf("%d tables are empty in the restored database: %s", len(emptyTables), formatTableList(emptyTables))
+	}
+	return nil
+}
+
+func getTableKey(schemaName, tableName string) string {
+	if schemaName == "" {
+		return tableName
+	}
+	return fmt.Sprintf("%s.%s", schemaName, tableName)
+}
+
+func formatTableList(tables []string) string {
+	if len(tables) > maxReportedTableCount {
+		return fmt.Sprintf("%s and %d more", strings.Join(tables[:maxReportedTableCount], ", "), len(tables)-maxReportedTableCount)
+	}
+	return strings.Join(tables, ", ")
+}
+
+func quoteTableName(engine db.Type, schemaName, tableName string) string {
+	if engine == db.Postgres {
+		return fmt.Sprintf(`"%s"."%s"`, strings.ReplaceAll(schemaName, `"`, `""`), strings.ReplaceAll(tableName, `"`, `""`))
+	}
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(tableName, "`", "``"))
+}
+
+// getVerificationDatabaseName returns the name of the scratch database to verify the backup.
+// The name is lowercase so that it needs no quoting in MySQL and PostgreSQL.
+func getVerificationDatabaseName(backupUID int, ts int64) string {
+	return fmt.Sprintf("bytebase_verify_%d_%d", backupUID, ts)
+}
+
+// supportBackupVerification returns whether the backup of the engine can be restored into a scratch database.
+func supportBackupVerification(engine db.Type) bool {
+	switch engine {
+	case db.MySQL, db.TiDB, db.Postgres:
+		return true
+	default:
+		return false
+	}
+}
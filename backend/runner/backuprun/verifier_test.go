@@ -0,0 +1,68 @@
+package backuprun
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestCheckRestoredTables(t *testing.T) {
+	a := require.New(t)
+	recorded := &storepb.DatabaseMetadata{
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "public",
+				Tables: []*storepb.TableMetadata{
+					{Name: "t1", RowCount: 100},
+					{Name: "t2", RowCount: 0},
+				},
+			},
+		},
+	}
+
+	restored := &storepb.DatabaseMetadata{
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "public",
+				Tables: []*storepb.TableMetadata{
+					{Name: "t1", RowCount: 1},
+					{Name: "t2", RowCount: 0},
+					{Name: "t3", RowCount: 0},
+				},
+			},
+		},
+	}
+	a.NoError(checkRestoredTables(recorded, restored))
+
+	// A truncated dump misses the tables after the truncation.
+	restored.Schemas[0].Tables = restored.Schemas[0].Tables[:1]
+	err := checkRestoredTables(recorded, restored)
+	a.Error(err)
+	a.Contains(err.Error(), "public.t2")
+
+	// A truncated dump misses the rows of the table.
+	restored.Schemas[0].Tables = []*storepb.TableMetadata{
+		{Name: "t1", RowCount: 0},
+		{Name: "t2", RowCount: 0},
+	}
+	err = checkRestoredTables(recorded, restored)
+	a.Error(err)
+	a.Contains(err.Error(), "public.t1")
+}
+
+func TestGetBackupVerificationUpdate(t *testing.T) {
+	a := require.New(t)
+	update := getBackupVerificationUpdate(101, nil, 1682208000)
+	a.Equal(101, update.UID)
+	a.Equal(api.BackupVerificationPassed, *update.VerificationStatus)
+	a.Equal("", *update.VerificationDetail)
+	a.Equal(int64(1682208000), *update.VerifiedTs)
+
+	update = getBackupVerificationUpdate(101, errors.New("checksum mismatch"), 1682208000)
+	a.Equal(api.BackupVerificationFailed, *update.VerificationStatus)
+	a.Equal("checksum mismatch", *update.VerificationDetail)
+}
@@ -0,0 +1,90 @@
+package backuprun
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/plugin/db/pg"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+// baseBackupInterval is the interval to take base backups of PostgreSQL instances.
+// A shorter interval means fewer WAL files to replay in PITR.
+const baseBackupInterval = 24 * time.Hour
+
+// archiveWALForInstance archives the WAL files of the PostgreSQL instance, and takes a base backup if the latest one is too old.
+func (r *Runner) archiveWALForInstance(ctx context.Context, instance *store.InstanceMessage) {
+	defer func() {
+		r.downloadBinlogMu.Lock()
+		delete(r.downloadBinlogInstanceIDs, instance.UID)
+		r.downloadBinlogMu.Unlock()
+		r.downloadBinlogWg.Done()
+	}()
+	driver, err := r.dbFactory.GetAdminDatabaseDriver(ctx, instance, "" /* databaseName */)
+	if err != nil {
+		if common.ErrorCode(err) == common.DbConnectionFailure {
+			log.Debug("Cannot connect to instance", zap.String("instance", instance.ResourceID), zap.Error(err))
+			return
+		}
+		log.Error("Failed to get driver for PostgreSQL instance when archiving WAL files", zap.String("instance", instance.ResourceID), zap.Error(err))
+		return
+	}
+	defer driver.Close(ctx)
+
+	pgDriver, ok := driver.(*pg.Driver)
+	if !ok {
+		log.Error("Failed to cast driver to pg.Driver", zap.String("instance", instance.ResourceID))
+		return
+	}
+	if err := pgDriver.CheckPITR(ctx); err != nil {
+		log.Debug("Skip archiving WAL files for instance not ready for PITR", zap.String("instance", instance.ResourceID), zap.Error(err))
+		return
+	}
+	// Archive the WAL files first, so that the replication slot retaining the WAL files of the base backup exists.
+	if err := pgDriver.ArchiveWAL(ctx, r.storageBackend); err != nil {
+		log.Error("Failed to archive WAL files for instance", zap.String("instance", instance.ResourceID), zap.Error(err))
+		return
+	}
+
+	baseBackups, err := pgDriver.ListBaseBackups(ctx, r.storageBackend)
+	if err != nil {
+		log.Error("Failed to list base backups for instance", zap.String("instance", instance.ResourceID), zap.Error(err))
+		return
+	}
+	if len(baseBackups) > 0 && time.Since(time.Unix(baseBackups[len(baseBackups)-1].EndTs, 0)) < baseBackupInterval {
+		return
+	}
+	baseBackup, err := pgDriver.TakeBaseBackup(ctx, r.storageBackend)
+	if err != nil {
+		log.Error("Failed to take base backup for instance", zap.String("instance", instance.ResourceID), zap.Error(err))
+		return
+	}
+	log.Info("Took base backup for instance", zap.String("instance", instance.ResourceID), zap.String("baseBackup", baseBackup.Name))
+}
+
+// purgeWALArchive deletes the base backups and WAL files of the PostgreSQL instance older than the retention period.
+func (r *Runner) purgeWALArchive(ctx context.Context, instanceID, retentionPeriodTs int) error {
+	instance, err := r.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &instanceID})
+	if err != nil {
+		return err
+	}
+	if instance == nil {
+		return errors.Errorf("instance %d not found", instanceID)
+	}
+	driver, err := r.dbFactory.GetAdminDatabaseDriver(ctx, instance, "" /* databaseName */)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+	pgDriver, ok := driver.(*pg.Driver)
+	if !ok {
+		return errors.Errorf("failed to cast driver to pg.Driver")
+	}
+	purgeBeforeTs := time.Now().Add(-time.Duration(retentionPeriodTs) * time.Second).Unix()
+	return pgDriver.PurgeArchive(ctx, r.storageBackend, purgeBeforeTs)
+}
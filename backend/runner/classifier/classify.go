@@ -0,0 +1,212 @@
+package classifier
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+const (
+	// nameWeight and valueWeight are the weights of the column name and the sampled values in the confidence.
+	nameWeight  = 0.4
+	valueWeight = 0.6
+	// nameOnlyConfidence is the confidence when there is no sampled value to check, e.g. for empty tables.
+	nameOnlyConfidence = 0.5
+	// minConfidence is the minimum confidence to suggest the classification.
+	minConfidence = 0.5
+)
+
+type rule struct {
+	classification api.SensitiveColumnClassification
+	// namePattern matches the normalized column name, see normalizeColumnName.
+	namePattern *regexp.Regexp
+	// matchValue returns true if the value looks like the classification.
+	matchValue func(string) bool
+	// maskType is the mask type suggested for the classification.
+	maskType api.SensitiveDataMaskType
+}
+
+var (
+	emailRegexp      = regexp.MustCompile(`^[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}$`)
+	phoneRegexp      = regexp.MustCompile(`^\+?[0-9][0-9 \-().]{5,22}[0-9]$`)
+	ssnRegexp        = regexp.MustCompile(`^\d{3}-\d{2}-\d{4}$`)
+	residentIDRegexp = regexp.MustCompile(`^\d{17}[\dXx]$`)
+
+	// ruleList is in the order of specificity, the former rule wins if two rules have the same confidence,
+	// e.g. the social security number "123-45-6789" looks like a phone number as well.
+	ruleList = []rule{
+		{
+			classification: api.SensitiveColumnEmail,
+			namePattern:    regexp.MustCompile(`(^|_)e_?mail(_?addr(ess)?)?($|_)`),
+			matchValue:     emailRegexp.MatchString,
+			maskType:       api.SensitiveDataMaskTypeEmail,
+		},
+		{
+			classification: api.SensitiveColumnNationalID,
+			namePattern:    regexp.MustCompile(`(^|_)(ssn|social_?security(_?(no|num|number))?|national_?id|id_?card(_?(no|num|number))?|id_?number|passport(_?(no|num|number))?|citizen_?id|resident_?id)($|_)`),
+			matchValue:     isNationalID,
+			maskType:       api.SensitiveDataMaskTypePartial,
+		},
+		{
+			classification: api.SensitiveColumnCreditCard,
+			namePattern:    regexp.MustCompile(`(^|_)(credit_?card(_?(no|num|number))?|card_?(no|num|number)|cc_?(no|num|number)|pan)($|_)`),
+			matchValue:     isCreditCardNumber,
+			maskType:       api.SensitiveDataMaskTypePartial,
+		},
+		{
+			classification: api.SensitiveColumnPhone,
+			namePattern:    regexp.MustCompile(`(^|_)(phone|mobile|tel|telephone|cellphone|fax)(_?(no|num|number))?($|_)`),
+			matchValue:     isPhoneNumber,
+			maskType:       api.SensitiveDataMaskTypePartial,
+		},
+	}
+)
+
+// Classification is the classification of a column with the confidence in [0, 1].
+type Classification struct {
+	Classification api.SensitiveColumnClassification
+	Confidence     float64
+	MaskType       api.SensitiveDataMaskType
+	// Reason explains how the classification is found.
+	Reason string
+}
+
+// Classify classifies the column by the column name and the sampled non-NULL values.
+// It returns nil if the column doesn't look sensitive.
+func Classify(columnName string, sampleList []string) *Classification {
+	name := normalizeColumnName(columnName)
+	var best *Classification
+	for _, r := range ruleList {
+		nameMatched := r.namePattern.MatchString(name)
+		matched := 0
+		for _, value := range sampleList {
+			if r.matchValue(strings.TrimSpace(value)) {
+				matched++
+			}
+		}
+
+		var confidence float64
+		var reasonList []string
+		if nameMatched {
+			reasonList = append(reasonList, "column name matches")
+		}
+		if len(sampleList) == 0 {
+			if nameMatched {
+				confidence = nameOnlyConfidence
+			}
+		} else {
+			ratio := float64(matched) / float64(len(sampleList))
+			if nameMatched {
+				confidence += nameWeight
+			}
+			confidence += valueWeight * ratio
+			if matched > 0 {
+				reasonList = append(reasonList, fmt.Sprintf("%d of %d sampled values match", matched, len(sampleList)))
+			}
+		}
+		if confidence < minConfidence {
+			continue
+		}
+		if best == nil || confidence > best.Confidence {
+			best = &Classification{
+				Classification: r.classification,
+				Confidence:     confidence,
+				MaskType:       r.maskType,
+				Reason:         strings.Join(reasonList, ", "),
+			}
+		}
+	}
+	return best
+}
+
+// normalizeColumnName converts the column name to lower snake case, e.g. "emailAddress" to "email_address" and "IDCard" to "id_card".
+func normalizeColumnName(name string) string {
+	var buf strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Split "emailAddress" and the acronyms like "IDCard".
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				buf.WriteByte('_')
+			}
+			buf.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		if r == '-' || r == ' ' {
+			r = '_'
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+func isPhoneNumber(s string) bool {
+	if !phoneRegexp.MatchString(s) {
+		return false
+	}
+	digits := countDigits(s)
+	return digits >= 7 && digits <= 15
+}
+
+// isNationalID returns true for the US social security numbers and the Chinese resident ID numbers with valid check digit.
+func isNationalID(s string) bool {
+	if ssnRegexp.MatchString(s) {
+		return true
+	}
+	if !residentIDRegexp.MatchString(s) {
+		return false
+	}
+	weights := []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	sum := 0
+	for i, w := range weights {
+		sum += int(s[i]-'0') * w
+	}
+	return strings.ToUpper(s[17:]) == string("10X98765432"[sum%11])
+}
+
+// isCreditCardNumber returns true for 13 to 19 digits with valid Luhn check digit, the digits can be separated by spaces or dashes.
+func isCreditCardNumber(s string) bool {
+	var digits []int
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits = append(digits, int(r-'0'))
+		case r == ' ' || r == '-':
+		default:
+			return false
+		}
+	}
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	return luhnValid(digits)
+}
+
+func luhnValid(digits []int) bool {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		if (len(digits)-1-i)%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+func countDigits(s string) int {
+	count := 0
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,47 @@
+package classifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		column         string
+		sampleList     []string
+		classification api.SensitiveColumnClassification
+		confidence     float64
+	}{
+		{column: "email", sampleList: []string{"alice@example.com", "bob@example.org"}, classification: api.SensitiveColumnEmail, confidence: 1},
+		{column: "contactEmailAddress", classification: api.SensitiveColumnEmail, confidence: nameOnlyConfidence},
+		{column: "remark", sampleList: []string{"alice@example.com", "bob@example.org"}, classification: api.SensitiveColumnEmail, confidence: 0.6},
+		{column: "mobile_no", sampleList: []string{"+86 138-0013-8000", "n/a"}, classification: api.SensitiveColumnPhone, confidence: 0.7},
+		{column: "ssn", sampleList: []string{"123-45-6789"}, classification: api.SensitiveColumnNationalID, confidence: 1},
+		{column: "id_card", sampleList: []string{"11010519491231002X"}, classification: api.SensitiveColumnNationalID, confidence: 1},
+		{column: "card_number", sampleList: []string{"4111 1111 1111 1111", "5500-0000-0000-0004"}, classification: api.SensitiveColumnCreditCard, confidence: 1},
+		// The values failing the Luhn check are not credit card numbers.
+		{column: "note", sampleList: []string{"4111111111111112"}},
+		{column: "name", sampleList: []string{"alice", "bob"}},
+		{column: "email_sent", sampleList: []string{"yes"}},
+	}
+
+	for _, test := range tests {
+		got := Classify(test.column, test.sampleList)
+		if test.classification == "" {
+			require.Nil(t, got, test.column)
+			continue
+		}
+		require.NotNil(t, got, test.column)
+		require.Equal(t, test.classification, got.Classification, test.column)
+		require.InDelta(t, test.confidence, got.Confidence, 1e-9, test.column)
+	}
+}
+
+func TestNormalizeColumnName(t *testing.T) {
+	require.Equal(t, "email_address", normalizeColumnName("emailAddress"))
+	require.Equal(t, "user_id_card", normalizeColumnName("User-IDCard"))
+	require.Equal(t, "phone_2", normalizeColumnName("phone_2"))
+}
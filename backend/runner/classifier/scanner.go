@@ -0,0 +1,232 @@
+// Package classifier is a runner that classifies the sensitive columns and suggests them for the sensitive data policy.
+package classifier
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/state"
+	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	// sampleLimit is the number of rows sampled from each table.
+	sampleLimit = 100
+)
+
+// NewScanner creates a sensitive column scanner.
+func NewScanner(store *store.Store, dbFactory *dbfactory.DBFactory, stateCfg *state.State, licenseService enterpriseAPI.LicenseService) *Scanner {
+	return &Scanner{
+		store:          store,
+		dbFactory:      dbFactory,
+		stateCfg:       stateCfg,
+		licenseService: licenseService,
+	}
+}
+
+// Scanner is the sensitive column scanner.
+type Scanner struct {
+	store          *store.Store
+	dbFactory      *dbfactory.DBFactory
+	stateCfg       *state.State
+	licenseService enterpriseAPI.LicenseService
+}
+
+// Run will run the sensitive column scanner.
+// The scan is requested by the schema syncer for the new and changed tables, or by the user for the whole database.
+func (s *Scanner) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	log.Debug("Sensitive column scanner started")
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("Sensitive column scanner received context cancellation")
+			return
+		case scan := <-s.stateCfg.SensitiveColumnScanChan:
+			s.scanDatabase(ctx, scan)
+		}
+	}
+}
+
+func (s *Scanner) scanDatabase(ctx context.Context, scan *state.SensitiveColumnScan) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok {
+				err = errors.Errorf("%v", r)
+			}
+			log.Error("Sensitive column scanner PANIC RECOVER", zap.Error(err), zap.Stack("panic-stack"))
+		}
+	}()
+
+	if !s.licenseService.IsFeatureEnabled(api.FeatureSensitiveData) {
+		return
+	}
+	if err := s.scanDatabaseImpl(ctx, scan); err != nil {
+		log.Warn("Failed to scan sensitive columns",
+			zap.Int("database", scan.DatabaseUID),
+			zap.Error(err))
+	}
+}
+
+func (s *Scanner) scanDatabaseImpl(ctx context.Context, scan *state.SensitiveColumnScan) error {
+	database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: &scan.DatabaseUID})
+	if err != nil {
+		return err
+	}
+	if database == nil {
+		return nil
+	}
+	instance, err := s.store.GetInstanceV2(ctx, &store.FindInstanceMessage{ResourceID: &database.InstanceID})
+	if err != nil {
+		return err
+	}
+	if instance == nil {
+		return nil
+	}
+	switch instance.Engine {
+	case db.MySQL, db.TiDB, db.Postgres:
+	default:
+		return nil
+	}
+
+	dbSchema, err := s.store.GetDBSchema(ctx, database.UID)
+	if err != nil {
+		return err
+	}
+	if dbSchema == nil {
+		return nil
+	}
+	policy, err := s.store.GetSensitiveDataPolicy(ctx, database.UID)
+	if err != nil {
+		return err
+	}
+	// The columns already in the sensitive data policy don't need suggestions.
+	sensitiveColumns := make(map[string]bool)
+	for _, data := range policy.SensitiveDataList {
+		sensitiveColumns[fmt.Sprintf("%s.%s.%s", data.Schema, data.Table, data.Column)] = true
+	}
+
+	driver, err := s.dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, database.DatabaseName)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+	sqlDB := driver.GetDB()
+
+	for _, schema := range dbSchema.Metadata.GetSchemas() {
+		for _, table := range schema.GetTables() {
+			if scan.Tables != nil && !scan.Tables[fmt.Sprintf("%s.%s", schema.Name, table.Name)] {
+				continue
+			}
+			var columnList []string
+			for _, column := range table.GetColumns() {
+				if sensitiveColumns[fmt.Sprintf("%s.%s.%s", schema.Name, table.Name, column.Name)] {
+					continue
+				}
+				if !isTextColumn(column) {
+					continue
+				}
+				columnList = append(columnList, column.Name)
+			}
+			if len(columnList) == 0 {
+				continue
+			}
+
+			sampleMap, err := sampleTable(ctx, sqlDB, instance.Engine, schema.Name, table.Name, columnList)
+			if err != nil {
+				log.Warn("Failed to sample table for sensitive columns",
+					zap.String("database", database.DatabaseName),
+					zap.String("schema", schema.Name),
+					zap.String("table", table.Name),
+					zap.Error(err))
+				continue
+			}
+			for _, column := range columnList {
+				classification := Classify(column, sampleMap[column])
+				if classification == nil {
+					continue
+				}
+				if err := s.store.UpsertSensitiveColumnSuggestion(ctx, &store.SensitiveColumnSuggestionMessage{
+					DatabaseID:     database.UID,
+					SchemaName:     schema.Name,
+					TableName:      table.Name,
+					ColumnName:     column,
+					Classification: classification.Classification,
+					Confidence:     classification.Confidence,
+					MaskType:       classification.MaskType,
+					Reason:         classification.Reason,
+				}); err != nil {
+					return errors.Wrapf(err, "failed to upsert sensitive column suggestion for column %q", column)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isTextColumn returns true if the column can store the email, phone, national ID or credit card numbers.
+func isTextColumn(column *storepb.ColumnMetadata) bool {
+	tp := strings.ToLower(column.Type)
+	return strings.Contains(tp, "char") || strings.Contains(tp, "text") || strings.Contains(tp, "string")
+}
+
+// sampleTable returns the non-empty values of the columns in at most sampleLimit rows.
+func sampleTable(ctx context.Context, sqlDB *sql.DB, engine db.Type, schemaName, tableName string, columnList []string) (map[string][]string, error) {
+	var quotedColumnList []string
+	for _, column := range columnList {
+		quotedColumnList = append(quotedColumnList, quoteIdentifier(engine, column))
+	}
+	table := quoteIdentifier(engine, tableName)
+	if schemaName != "" {
+		table = fmt.Sprintf("%s.%s", quoteIdentifier(engine, schemaName), table)
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s LIMIT %d", strings.Join(quotedColumnList, ", "), table, sampleLimit)
+
+	rows, err := sqlDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sampleMap := make(map[string][]string)
+	values := make([]sql.NullString, len(columnList))
+	scanArgs := make([]any, len(columnList))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(scanArgs...); err != nil {
+			return nil, err
+		}
+		for i, value := range values {
+			if !value.Valid || strings.TrimSpace(value.String) == "" {
+				continue
+			}
+			sampleMap[columnList[i]] = append(sampleMap[columnList[i]], value.String)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sampleMap, nil
+}
+
+func quoteIdentifier(engine db.Type, identifier string) string {
+	if engine == db.Postgres {
+		return fmt.Sprintf(`"%s"`, strings.ReplaceAll(identifier, `"`, `""`))
+	}
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(identifier, "`", "``"))
+}
@@ -294,13 +294,56 @@ func (s *Syncer) SyncDatabaseSchema(ctx context.Context, database *store.Databas
 		return errors.Errorf("failed to update database %q for instance %q", database.DatabaseName, database.InstanceID)
 	}
 
-	return syncDBSchema(ctx, s.store, database, databaseMetadata, driver, force)
+	oldDatabaseMetadata, err := syncDBSchema(ctx, s.store, database, databaseMetadata, driver, force)
+	if err != nil {
+		return err
+	}
+	s.requestSensitiveColumnScan(database, oldDatabaseMetadata, databaseMetadata)
+	return nil
+}
+
+// requestSensitiveColumnScan requests the sensitive column classification for the new tables and the tables with changed columns.
+func (s *Syncer) requestSensitiveColumnScan(database *store.DatabaseMessage, oldDatabaseMetadata, databaseMetadata *storepb.DatabaseMetadata) {
+	tables := getColumnChangedTables(oldDatabaseMetadata, databaseMetadata)
+	if len(tables) == 0 {
+		return
+	}
+	select {
+	case s.stateCfg.SensitiveColumnScanChan <- &state.SensitiveColumnScan{DatabaseUID: database.UID, Tables: tables}:
+	default:
+		// Don't block the schema sync, the tables can be scanned on demand.
+		log.Warn("Sensitive column scan queue is full, skip the scan",
+			zap.String("instance", database.InstanceID),
+			zap.String("database", database.DatabaseName))
+	}
 }
 
-func syncDBSchema(ctx context.Context, stores *store.Store, database *store.DatabaseMessage, databaseMetadata *storepb.DatabaseMetadata, driver db.Driver, force bool) error {
+// getColumnChangedTables returns the set of new tables and tables with changed columns in "schema.table" format.
+func getColumnChangedTables(oldDatabaseMetadata, databaseMetadata *storepb.DatabaseMetadata) map[string]bool {
+	oldTables := make(map[string]*storepb.TableMetadata)
+	for _, schema := range oldDatabaseMetadata.GetSchemas() {
+		for _, table := range schema.GetTables() {
+			oldTables[fmt.Sprintf("%s.%s", schema.Name, table.Name)] = table
+		}
+	}
+	tables := make(map[string]bool)
+	for _, schema := range databaseMetadata.GetSchemas() {
+		for _, table := range schema.GetTables() {
+			key := fmt.Sprintf("%s.%s", schema.Name, table.Name)
+			oldTable, ok := oldTables[key]
+			if !ok || !cmp.Equal(oldTable.Columns, table.Columns, protocmp.Transform()) {
+				tables[key] = true
+			}
+		}
+	}
+	return tables
+}
+
+// syncDBSchema saves the database metadata and schema dump, and returns the previous database metadata.
+func syncDBSchema(ctx context.Context, stores *store.Store, database *store.DatabaseMessage, databaseMetadata *storepb.DatabaseMetadata, driver db.Driver, force bool) (*storepb.DatabaseMetadata, error) {
 	dbSchema, err := stores.GetDBSchema(ctx, database.UID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	var oldDatabaseMetadata *storepb.DatabaseMetadata
 	if dbSchema != nil {
@@ -317,7 +360,7 @@ func syncDBSchema(ctx context.Context, stores *store.Store, database *store.Data
 		if force || !equalDatabaseMetadata(oldDatabaseMetadata, databaseMetadata) {
 			var schemaBuf bytes.Buffer
 			if _, err := driver.Dump(ctx, &schemaBuf, true /* schemaOnly */); err != nil {
-				return err
+				return nil, err
 			}
 			rawDump = schemaBuf.Bytes()
 		}
@@ -326,10 +369,19 @@ func syncDBSchema(ctx context.Context, stores *store.Store, database *store.Data
 			Metadata: databaseMetadata,
 			Schema:   rawDump,
 		}, api.SystemBotID); err != nil {
-			return err
+			return nil, err
 		}
 	}
-	return nil
+
+	// The table stats are ignored by the comparison above, so they are recorded separately for the growth trends.
+	if err := stores.CreateTableStats(ctx, database.UID, databaseMetadata); err != nil {
+		// Don't fail the schema sync because of the stats.
+		log.Error("Failed to record table stats",
+			zap.String("instance", database.InstanceID),
+			zap.String("database", database.DatabaseName),
+			zap.Error(err))
+	}
+	return oldDatabaseMetadata, nil
 }
 
 func equalDatabaseMetadata(x, y *storepb.DatabaseMetadata) bool {
@@ -0,0 +1,97 @@
+package taskcheck
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/component/config"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db/pg"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+// NewPITRPostgresExecutor creates a task check PostgreSQL PITR executor.
+func NewPITRPostgresExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, storageBackend storage.Backend, profile config.Profile) Executor {
+	return &PITRPostgresExecutor{
+		store:          store,
+		dbFactory:      dbFactory,
+		storageBackend: storageBackend,
+		profile:        profile,
+	}
+}
+
+// PITRPostgresExecutor is the task check PostgreSQL PITR executor.
+type PITRPostgresExecutor struct {
+	store          *store.Store
+	dbFactory      *dbfactory.DBFactory
+	storageBackend storage.Backend
+	profile        config.Profile
+}
+
+// Run will run the task check PostgreSQL PITR executor once.
+func (e *PITRPostgresExecutor) Run(ctx context.Context, _ *store.TaskCheckRunMessage, task *store.TaskMessage) (result []api.TaskCheckResult, err error) {
+	payload := api.TaskDatabasePITRRestorePayload{}
+	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
+		return nil, errors.Wrapf(err, "invalid PITR restore payload: %s", task.Payload)
+	}
+
+	if payload.BackupID != nil {
+		return []api.TaskCheckResult{
+			{
+				Status:    api.TaskCheckStatusSuccess,
+				Namespace: api.BBNamespace,
+				Code:      common.Ok.Int(),
+				Title:     "OK",
+				Content:   "Ready to do backup restore",
+			},
+		}, nil
+	}
+
+	if !e.profile.BackupPostgresWALArchive {
+		return wrapTaskCheckError(errors.Errorf("PostgreSQL PITR requires WAL archiving, please start Bytebase with --backup-pg-wal-archive")), nil
+	}
+	if payload.PointInTimeTs == nil {
+		return nil, errors.Errorf("point in time is not set in the PITR restore payload")
+	}
+
+	// Unlike MySQL, the WAL files are replayed on a temporary server, so only the source instance is checked.
+	instance, err := e.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get instance by ID %d", task.InstanceID)
+	}
+	if instance == nil {
+		return wrapTaskCheckError(errors.Errorf("instance %d not found", task.InstanceID)), nil
+	}
+	driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, "" /* databaseName */)
+	if err != nil {
+		return nil, err
+	}
+	defer driver.Close(ctx)
+	pgDriver, ok := driver.(*pg.Driver)
+	if !ok {
+		return nil, errors.Errorf("Failed to cast driver to pg.Driver")
+	}
+
+	if err := pgDriver.CheckPITR(ctx); err != nil {
+		return wrapTaskCheckError(err), nil
+	}
+
+	if err := pgDriver.CheckWALArchive(ctx, e.storageBackend, *payload.PointInTimeTs); err != nil {
+		return wrapTaskCheckError(err), nil
+	}
+
+	return []api.TaskCheckResult{
+		{
+			Status:    api.TaskCheckStatusSuccess,
+			Namespace: api.BBNamespace,
+			Code:      common.Ok.Int(),
+			Title:     "OK",
+			Content:   "Ready to do PITR",
+		},
+	}, nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/store"
 )
 
@@ -219,7 +220,7 @@ func (s *Scheduler) Register(taskType api.TaskCheckType, executor Executor) {
 func (s *Scheduler) getTaskCheck(ctx context.Context, task *store.TaskMessage, creatorID int) ([]*store.TaskCheckRunMessage, error) {
 	var createList []*store.TaskCheckRunMessage
 
-	create, err := s.getPITRTaskCheck(task, creatorID)
+	create, err := s.getPITRTaskCheck(ctx, task, creatorID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to schedule backup/PITR task check")
 	}
@@ -309,6 +310,14 @@ func (s *Scheduler) getTaskCheck(ctx context.Context, task *store.TaskMessage, c
 		createList = append(createList, create...)
 	}
 
+	create, err = getStatementLockImpactReportTaskCheck(task, instance, creatorID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to schedule statement lock impact report task check")
+	}
+	if create != nil {
+		createList = append(createList, create...)
+	}
+
 	return createList, nil
 }
 
@@ -383,15 +392,26 @@ func (*Scheduler) getGhostTaskCheck(task *store.TaskMessage, creatorID int) ([]*
 	}, nil
 }
 
-func (*Scheduler) getPITRTaskCheck(task *store.TaskMessage, creatorID int) ([]*store.TaskCheckRunMessage, error) {
+func (s *Scheduler) getPITRTaskCheck(ctx context.Context, task *store.TaskMessage, creatorID int) ([]*store.TaskCheckRunMessage, error) {
 	if task.Type != api.TaskDatabaseRestorePITRRestore {
 		return nil, nil
 	}
+	instance, err := s.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, errors.Errorf("instance %d not found", task.InstanceID)
+	}
+	checkType := api.TaskCheckPITRMySQL
+	if instance.Engine == db.Postgres {
+		checkType = api.TaskCheckPITRPostgres
+	}
 	return []*store.TaskCheckRunMessage{
 		{
 			CreatorID: creatorID,
 			TaskID:    task.ID,
-			Type:      api.TaskCheckPITRMySQL,
+			Type:      checkType,
 		},
 	}, nil
 }
@@ -429,6 +449,23 @@ func getStatementAffectedRowsReportTaskCheck(task *store.TaskMessage, instance *
 	}, nil
 }
 
+func getStatementLockImpactReportTaskCheck(task *store.TaskMessage, instance *store.InstanceMessage, creatorID int) ([]*store.TaskCheckRunMessage, error) {
+	if !api.IsTaskCheckReportSupported(instance.Engine) {
+		return nil, nil
+	}
+	if !api.IsTaskCheckReportNeededForTaskType(task.Type) {
+		return nil, nil
+	}
+
+	return []*store.TaskCheckRunMessage{
+		{
+			CreatorID: creatorID,
+			TaskID:    task.ID,
+			Type:      api.TaskCheckDatabaseStatementLockImpactReport,
+		},
+	}, nil
+}
+
 // SchedulePipelineTaskCheck schedules the task checks for a pipeline.
 func (s *Scheduler) SchedulePipelineTaskCheck(ctx context.Context, pipelineID int) error {
 	var createList []*store.TaskCheckRunMessage
@@ -0,0 +1,674 @@
+package taskcheck
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	tidbparser "github.com/pingcap/tidb/parser"
+	tidbast "github.com/pingcap/tidb/parser/ast"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	// rewriteBytesPerSecond is the rough speed of rebuilding a table with its indexes.
+	rewriteBytesPerSecond = 32 * 1024 * 1024
+	// scanBytesPerSecond is the rough speed of scanning a table, e.g. validating constraints and building indexes.
+	scanBytesPerSecond = 128 * 1024 * 1024
+)
+
+// NewStatementLockImpactReportExecutor creates a task check statement lock impact report executor.
+func NewStatementLockImpactReportExecutor(store *store.Store) Executor {
+	return &StatementLockImpactReportExecutor{
+		store: store,
+	}
+}
+
+// StatementLockImpactReportExecutor is the task check statement lock impact report executor.
+// It predicts the table lock and rewrite behavior of each statement, and estimates the blocking time with the table size
+// from the last synced database schema.
+type StatementLockImpactReportExecutor struct {
+	store *store.Store
+}
+
+// Run will run the task check statement lock impact report executor once.
+func (s *StatementLockImpactReportExecutor) Run(ctx context.Context, _ *store.TaskCheckRunMessage, task *store.TaskMessage) ([]api.TaskCheckResult, error) {
+	if !api.IsTaskCheckReportNeededForTaskType(task.Type) {
+		return nil, nil
+	}
+	payload := &TaskPayload{}
+	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+		return nil, err
+	}
+	instance, err := s.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return wrapTaskCheckError(errors.Errorf("instance %d not found", task.InstanceID)), nil
+	}
+	if !api.IsTaskCheckReportSupported(instance.Engine) {
+		return nil, nil
+	}
+	if payload.SheetID > 0 {
+		return []api.TaskCheckResult{
+			{
+				Status:    api.TaskCheckStatusSuccess,
+				Namespace: api.BBNamespace,
+				Code:      common.Ok.Int(),
+				Title:     "Large SQL lock impact report is disabled",
+				Content:   "",
+			},
+		}, nil
+	}
+	database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return nil, err
+	}
+	if database == nil {
+		return wrapTaskCheckError(errors.Errorf("database not found for task %d", task.ID)), nil
+	}
+	dbSchema, err := s.store.GetDBSchema(ctx, database.UID)
+	if err != nil {
+		return nil, err
+	}
+	metadata := &storepb.DatabaseMetadata{}
+	if dbSchema != nil && dbSchema.Metadata != nil {
+		metadata = dbSchema.Metadata
+	}
+
+	materials := utils.GetSecretMapFromDatabaseMessage(database)
+	// To avoid leaking the rendered statement, the error message should use the original statement and not the rendered statement.
+	renderedStatement := utils.RenderStatement(payload.Statement, materials)
+
+	switch instance.Engine {
+	case db.Postgres:
+		return reportStatementLockImpactForPostgres(renderedStatement, metadata)
+	case db.MySQL:
+		return reportStatementLockImpactForMySQL(renderedStatement, instance.EngineVersion, metadata)
+	default:
+		// TiDB runs the DDL online without blocking the writes.
+		return nil, nil
+	}
+}
+
+func newLockImpactResult(impact *api.TaskCheckLockImpact) (api.TaskCheckResult, error) {
+	content, err := json.Marshal(impact)
+	if err != nil {
+		return api.TaskCheckResult{}, err
+	}
+	return api.TaskCheckResult{
+		Status:    api.TaskCheckStatusSuccess,
+		Namespace: api.BBNamespace,
+		Code:      common.Ok.Int(),
+		Title:     "OK",
+		Content:   string(content),
+	}, nil
+}
+
+// fillTableSize fills the table size and estimates the blocking time.
+func fillTableSize(impact *api.TaskCheckLockImpact, metadata *storepb.DatabaseMetadata, schemaName string, blockWrites bool) {
+	table := findTableMetadata(metadata, schemaName, impact.Table)
+	if table == nil {
+		return
+	}
+	impact.TableRows = table.RowCount
+	impact.TableSize = table.DataSize + table.IndexSize
+	if !blockWrites {
+		return
+	}
+	switch {
+	case impact.Rewrite:
+		impact.BlockingSeconds = impact.TableSize / rewriteBytesPerSecond
+	case impact.Scan:
+		impact.BlockingSeconds = table.DataSize / scanBytesPerSecond
+	}
+}
+
+func findTableMetadata(metadata *storepb.DatabaseMetadata, schemaName string, tableName string) *storepb.TableMetadata {
+	for _, schema := range metadata.Schemas {
+		if schema.Name != schemaName {
+			continue
+		}
+		for _, table := range schema.Tables {
+			if table.Name == tableName {
+				return table
+			}
+		}
+	}
+	return nil
+}
+
+func findColumnMetadata(metadata *storepb.DatabaseMetadata, schemaName string, tableName string, columnName string) *storepb.ColumnMetadata {
+	table := findTableMetadata(metadata, schemaName, tableName)
+	if table == nil {
+		return nil
+	}
+	for _, column := range table.Columns {
+		if column.Name == columnName {
+			return column
+		}
+	}
+	return nil
+}
+
+// Postgres
+
+// postgresLockMode is the PostgreSQL table-level lock mode, in the increasing order of strength.
+// https://www.postgresql.org/docs/current/explicit-locking.html#LOCKING-TABLES
+type postgresLockMode int
+
+const (
+	postgresLockNone postgresLockMode = iota
+	postgresLockAccessShare
+	postgresLockRowShare
+	postgresLockRowExclusive
+	postgresLockShareUpdateExclusive
+	postgresLockShare
+	postgresLockShareRowExclusive
+	postgresLockExclusive
+	postgresLockAccessExclusive
+)
+
+func (m postgresLockMode) String() string {
+	switch m {
+	case postgresLockAccessShare:
+		return "ACCESS_SHARE"
+	case postgresLockRowShare:
+		return "ROW_SHARE"
+	case postgresLockRowExclusive:
+		return "ROW_EXCLUSIVE"
+	case postgresLockShareUpdateExclusive:
+		return "SHARE_UPDATE_EXCLUSIVE"
+	case postgresLockShare:
+		return "SHARE"
+	case postgresLockShareRowExclusive:
+		return "SHARE_ROW_EXCLUSIVE"
+	case postgresLockExclusive:
+		return "EXCLUSIVE"
+	case postgresLockAccessExclusive:
+		return "ACCESS_EXCLUSIVE"
+	}
+	return ""
+}
+
+// blockWrites returns true if the lock mode conflicts with ROW EXCLUSIVE acquired by INSERT, UPDATE and DELETE.
+func (m postgresLockMode) blockWrites() bool {
+	return m >= postgresLockShare
+}
+
+var (
+	// postgresVolatileDefaultRegexp matches the volatile default values, which rewrite the table when adding a column.
+	postgresVolatileDefaultRegexp = regexp.MustCompile(`(?i)\b(random|clock_timestamp|timeofday|gen_random_uuid|uuid_generate_v[14]|nextval)\s*\(`)
+	// postgresVarcharRegexp matches the varchar types.
+	postgresVarcharRegexp = regexp.MustCompile(`^(?:character varying|varchar)(?:\((\d+)\))?$`)
+)
+
+func reportStatementLockImpactForPostgres(statement string, metadata *storepb.DatabaseMetadata) ([]api.TaskCheckResult, error) {
+	stmts, err := parser.Parse(parser.Postgres, parser.ParseContext{}, statement)
+	if err != nil {
+		// nolint:nilerr
+		return []api.TaskCheckResult{
+			{
+				Status:    api.TaskCheckStatusError,
+				Namespace: api.AdvisorNamespace,
+				Code:      advisor.StatementSyntaxError.Int(),
+				Title:     "Syntax error",
+				Content:   err.Error(),
+			},
+		}, nil
+	}
+
+	var result []api.TaskCheckResult
+	for _, stmt := range stmts {
+		impact, table, lock := getLockImpactForPostgres(stmt, metadata)
+		impact.LockLevel = lock.String()
+		if table != nil {
+			impact.Table = table.Name
+			fillTableSize(impact, metadata, postgresSchemaName(table), lock.blockWrites())
+		}
+		r, err := newLockImpactResult(impact)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+func postgresSchemaName(table *ast.TableDef) string {
+	if table.Schema == "" {
+		return "public"
+	}
+	return table.Schema
+}
+
+func getLockImpactForPostgres(node ast.Node, metadata *storepb.DatabaseMetadata) (*api.TaskCheckLockImpact, *ast.TableDef, postgresLockMode) {
+	impact := &api.TaskCheckLockImpact{}
+	switch node := node.(type) {
+	case *ast.AlterTableStmt:
+		lock := postgresLockNone
+		for _, item := range node.AlterItemList {
+			itemLock := getAlterTableItemLockImpactForPostgres(impact, node.Table, item, metadata)
+			if itemLock > lock {
+				lock = itemLock
+			}
+		}
+		return impact, node.Table, lock
+	case *ast.CreateIndexStmt:
+		impact.Scan = true
+		if node.Concurrently {
+			return impact, node.Index.Table, postgresLockShareUpdateExclusive
+		}
+		return impact, node.Index.Table, postgresLockShare
+	case *ast.DropIndexStmt:
+		return impact, nil, postgresLockAccessExclusive
+	case *ast.DropTableStmt:
+		var table *ast.TableDef
+		if len(node.TableList) > 0 {
+			table = node.TableList[0]
+		}
+		return impact, table, postgresLockAccessExclusive
+	case *ast.InsertStmt:
+		return impact, node.Table, postgresLockRowExclusive
+	case *ast.UpdateStmt:
+		return impact, node.Table, postgresLockRowExclusive
+	case *ast.DeleteStmt:
+		return impact, node.Table, postgresLockRowExclusive
+	case *ast.UnconvertedStmt:
+		text := strings.ToUpper(strings.Join(strings.Fields(node.Text()), " "))
+		switch {
+		case strings.HasPrefix(text, "TRUNCATE"):
+			return impact, nil, postgresLockAccessExclusive
+		case strings.HasPrefix(text, "VACUUM FULL"), strings.HasPrefix(text, "CLUSTER"):
+			impact.Rewrite = true
+			return impact, nil, postgresLockAccessExclusive
+		}
+	}
+	return impact, nil, postgresLockNone
+}
+
+func getAlterTableItemLockImpactForPostgres(impact *api.TaskCheckLockImpact, table *ast.TableDef, item ast.Node, metadata *storepb.DatabaseMetadata) postgresLockMode {
+	switch item := item.(type) {
+	case *ast.AddColumnListStmt:
+		for _, column := range item.ColumnList {
+			tp, err := parser.Deparse(parser.Postgres, parser.DeparseContext{}, column.Type)
+			if err != nil {
+				tp = column.Type.Text()
+			}
+			switch strings.ToLower(tp) {
+			case "serial", "smallserial", "bigserial":
+				impact.Rewrite = true
+			}
+			for _, constraint := range column.ConstraintList {
+				switch constraint.Type {
+				case ast.ConstraintTypeDefault:
+					if constraint.Expression != nil && postgresVolatileDefaultRegexp.MatchString(constraint.Expression.Text()) {
+						impact.Rewrite = true
+					}
+				case ast.ConstraintTypePrimary, ast.ConstraintTypeUnique, ast.ConstraintTypeCheck:
+					impact.Scan = true
+				}
+			}
+		}
+	case *ast.AlterColumnTypeStmt:
+		newType, err := parser.Deparse(parser.Postgres, parser.DeparseContext{}, item.Type)
+		if err != nil {
+			newType = item.Type.Text()
+		}
+		column := findColumnMetadata(metadata, postgresSchemaName(table), table.Name, item.ColumnName)
+		if column == nil || !isBinaryCoercibleForPostgres(column.Type, newType) {
+			impact.Rewrite = true
+		}
+	case *ast.SetNotNullStmt:
+		impact.Scan = true
+	case *ast.AddConstraintStmt:
+		constraint := item.Constraint
+		switch constraint.Type {
+		case ast.ConstraintTypeForeign:
+			if !constraint.SkipValidation {
+				impact.Scan = true
+			}
+			return postgresLockShareRowExclusive
+		case ast.ConstraintTypeCheck:
+			if !constraint.SkipValidation {
+				impact.Scan = true
+			}
+		case ast.ConstraintTypePrimary, ast.ConstraintTypeUnique, ast.ConstraintTypeExclusion:
+			// Building the index.
+			impact.Scan = true
+		}
+	}
+	return postgresLockAccessExclusive
+}
+
+// isBinaryCoercibleForPostgres returns true if changing the column type doesn't rewrite the table,
+// e.g. increasing the varchar length, or changing varchar to text.
+func isBinaryCoercibleForPostgres(oldType, newType string) bool {
+	oldType, newType = strings.ToLower(oldType), strings.ToLower(newType)
+	if oldType == newType {
+		return true
+	}
+	oldMatches := postgresVarcharRegexp.FindStringSubmatch(oldType)
+	if oldMatches == nil {
+		return false
+	}
+	if newType == "text" {
+		return true
+	}
+	newMatches := postgresVarcharRegexp.FindStringSubmatch(newType)
+	if newMatches == nil {
+		return false
+	}
+	// Unlimited length.
+	if newMatches[1] == "" {
+		return true
+	}
+	if oldMatches[1] == "" {
+		return false
+	}
+	return len(newMatches[1]) > len(oldMatches[1]) || (len(newMatches[1]) == len(oldMatches[1]) && newMatches[1] >= oldMatches[1])
+}
+
+// MySQL
+
+// mysqlAlgorithm is the MySQL online DDL algorithm, in the increasing order of cost.
+// https://dev.mysql.com/doc/refman/8.0/en/innodb-online-ddl-operations.html
+type mysqlAlgorithm int
+
+const (
+	mysqlAlgorithmNone mysqlAlgorithm = iota
+	mysqlAlgorithmInstant
+	mysqlAlgorithmInplace
+	mysqlAlgorithmCopy
+)
+
+func (a mysqlAlgorithm) String() string {
+	switch a {
+	case mysqlAlgorithmInstant:
+		return "INSTANT"
+	case mysqlAlgorithmInplace:
+		return "INPLACE"
+	case mysqlAlgorithmCopy:
+		return "COPY"
+	}
+	return ""
+}
+
+// mysqlLock is the MySQL table lock during the DDL, in the increasing order of strength.
+type mysqlLock int
+
+const (
+	mysqlLockNone mysqlLock = iota
+	mysqlLockShared
+	mysqlLockExclusive
+)
+
+func (l mysqlLock) String() string {
+	switch l {
+	case mysqlLockNone:
+		return "NONE"
+	case mysqlLockShared:
+		return "SHARED"
+	case mysqlLockExclusive:
+		return "EXCLUSIVE"
+	}
+	return ""
+}
+
+// mysqlOnlineDDL is the online DDL behavior of a statement.
+type mysqlOnlineDDL struct {
+	algorithm mysqlAlgorithm
+	lock      mysqlLock
+	rebuild   bool
+	scan      bool
+}
+
+func (d *mysqlOnlineDDL) merge(algorithm mysqlAlgorithm, lock mysqlLock, rebuild bool, scan bool) {
+	if algorithm > d.algorithm {
+		d.algorithm = algorithm
+	}
+	if lock > d.lock {
+		d.lock = lock
+	}
+	d.rebuild = d.rebuild || rebuild
+	d.scan = d.scan || scan
+}
+
+func reportStatementLockImpactForMySQL(statement string, version string, metadata *storepb.DatabaseMetadata) ([]api.TaskCheckResult, error) {
+	singleSQLs, err := parser.SplitMultiSQL(parser.MySQL, statement)
+	if err != nil {
+		// nolint:nilerr
+		return []api.TaskCheckResult{
+			{
+				Status:    api.TaskCheckStatusError,
+				Namespace: api.AdvisorNamespace,
+				Code:      advisor.StatementSyntaxError.Int(),
+				Title:     "Syntax error",
+				Content:   err.Error(),
+			},
+		}, nil
+	}
+
+	var result []api.TaskCheckResult
+
+	p := tidbparser.New()
+	p.EnableWindowFunc(true)
+
+	for _, stmt := range singleSQLs {
+		if stmt.Empty {
+			continue
+		}
+		impact := &api.TaskCheckLockImpact{}
+		if !parser.IsTiDBUnsupportDDLStmt(stmt.Text) {
+			root, _, err := p.Parse(stmt.Text, metadata.CharacterSet, metadata.Collation)
+			if err != nil {
+				result = append(result, api.TaskCheckResult{
+					Status:    api.TaskCheckStatusError,
+					Namespace: api.AdvisorNamespace,
+					Code:      advisor.StatementSyntaxError.Int(),
+					Title:     "Syntax error",
+					Content:   err.Error(),
+				})
+				continue
+			}
+			if len(root) != 1 {
+				result = append(result, api.TaskCheckResult{
+					Status:    api.TaskCheckStatusError,
+					Namespace: api.BBNamespace,
+					Code:      common.Internal.Int(),
+					Title:     "Failed to report statement lock impact",
+					Content:   "Expect to get one node from parser",
+				})
+				continue
+			}
+			impact = getLockImpactForMySQL(root[0], version, metadata)
+		}
+		r, err := newLockImpactResult(impact)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+func getLockImpactForMySQL(node tidbast.StmtNode, version string, metadata *storepb.DatabaseMetadata) *api.TaskCheckLockImpact {
+	impact := &api.TaskCheckLockImpact{}
+	var tableName string
+	var ddl *mysqlOnlineDDL
+	switch node := node.(type) {
+	case *tidbast.AlterTableStmt:
+		tableName = node.Table.Name.O
+		ddl = getAlterTableOnlineDDLForMySQL(node, version, metadata)
+	case *tidbast.CreateIndexStmt:
+		tableName = node.Table.Name.O
+		ddl = &mysqlOnlineDDL{algorithm: mysqlAlgorithmInplace, scan: true}
+		if node.KeyType == tidbast.IndexKeyTypeFullText || node.KeyType == tidbast.IndexKeyTypeSpatial {
+			ddl.lock = mysqlLockShared
+		}
+		if node.LockAlg != nil {
+			applyMySQLLockAndAlgorithm(ddl, node.LockAlg.LockTp, node.LockAlg.AlgorithmTp)
+		}
+	case *tidbast.DropIndexStmt:
+		tableName = node.Table.Name.O
+		ddl = &mysqlOnlineDDL{algorithm: mysqlAlgorithmInplace}
+	case *tidbast.DropTableStmt:
+		if len(node.Tables) > 0 {
+			tableName = node.Tables[0].Name.O
+		}
+		ddl = &mysqlOnlineDDL{lock: mysqlLockExclusive}
+	case *tidbast.TruncateTableStmt:
+		tableName = node.Table.Name.O
+		ddl = &mysqlOnlineDDL{lock: mysqlLockExclusive}
+	case *tidbast.RenameTableStmt:
+		if len(node.TableToTables) > 0 {
+			tableName = node.TableToTables[0].OldTable.Name.O
+		}
+		ddl = &mysqlOnlineDDL{lock: mysqlLockExclusive}
+	default:
+		return impact
+	}
+
+	impact.Table = tableName
+	impact.Algorithm = ddl.algorithm.String()
+	impact.LockLevel = ddl.lock.String()
+	impact.Rewrite = ddl.rebuild || ddl.algorithm == mysqlAlgorithmCopy
+	impact.Scan = ddl.scan
+	// MySQL database has the only schema whose name is empty.
+	fillTableSize(impact, metadata, "", ddl.lock != mysqlLockNone)
+	return impact
+}
+
+func getAlterTableOnlineDDLForMySQL(node *tidbast.AlterTableStmt, version string, metadata *storepb.DatabaseMetadata) *mysqlOnlineDDL {
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		v = semver.Version{}
+	}
+	// Instant ADD COLUMN is supported since 8.0.12 for the last column, and since 8.0.29 for any position.
+	// Instant DROP COLUMN is supported since 8.0.29.
+	instantAddColumn := v.GTE(semver.MustParse("8.0.12"))
+	instantColumnAnyPosition := v.GTE(semver.MustParse("8.0.29"))
+
+	ddl := &mysqlOnlineDDL{}
+	addPrimaryKey := false
+	lockType, algorithmType := tidbast.LockTypeDefault, tidbast.AlgorithmTypeDefault
+	for _, spec := range node.Specs {
+		switch spec.Tp {
+		case tidbast.AlterTableAddColumns:
+			positioned := spec.Position != nil && spec.Position.Tp != tidbast.ColumnPositionNone
+			instant := instantAddColumn && (!positioned || instantColumnAnyPosition)
+			for _, column := range spec.NewColumns {
+				for _, option := range column.Options {
+					switch option.Tp {
+					case tidbast.ColumnOptionAutoIncrement:
+						ddl.merge(mysqlAlgorithmCopy, mysqlLockShared, true, false)
+					case tidbast.ColumnOptionPrimaryKey, tidbast.ColumnOptionUniqKey:
+						instant = false
+						ddl.merge(mysqlAlgorithmInplace, mysqlLockNone, false, true)
+					case tidbast.ColumnOptionGenerated:
+						if option.Stored {
+							ddl.merge(mysqlAlgorithmCopy, mysqlLockShared, true, false)
+						}
+					}
+				}
+			}
+			if instant {
+				ddl.merge(mysqlAlgorithmInstant, mysqlLockNone, false, false)
+			} else {
+				ddl.merge(mysqlAlgorithmInplace, mysqlLockNone, true, false)
+			}
+		case tidbast.AlterTableDropColumn:
+			if instantColumnAnyPosition {
+				ddl.merge(mysqlAlgorithmInstant, mysqlLockNone, false, false)
+			} else {
+				ddl.merge(mysqlAlgorithmInplace, mysqlLockNone, true, false)
+			}
+		case tidbast.AlterTableRenameColumn, tidbast.AlterTableAlterColumn, tidbast.AlterTableRenameTable, tidbast.AlterTableIndexInvisible:
+			ddl.merge(mysqlAlgorithmInstant, mysqlLockNone, false, false)
+		case tidbast.AlterTableDropIndex, tidbast.AlterTableRenameIndex:
+			ddl.merge(mysqlAlgorithmInplace, mysqlLockNone, false, false)
+		case tidbast.AlterTableDropForeignKey:
+			ddl.merge(mysqlAlgorithmInplace, mysqlLockNone, false, false)
+		case tidbast.AlterTableAddConstraint:
+			switch spec.Constraint.Tp {
+			case tidbast.ConstraintKey, tidbast.ConstraintIndex, tidbast.ConstraintUniq, tidbast.ConstraintUniqKey, tidbast.ConstraintUniqIndex:
+				ddl.merge(mysqlAlgorithmInplace, mysqlLockNone, false, true)
+			case tidbast.ConstraintFulltext:
+				ddl.merge(mysqlAlgorithmInplace, mysqlLockShared, false, true)
+			case tidbast.ConstraintPrimaryKey:
+				addPrimaryKey = true
+				ddl.merge(mysqlAlgorithmInplace, mysqlLockNone, true, false)
+			default:
+				// Adding foreign keys with foreign_key_checks enabled and check constraints copy the table.
+				ddl.merge(mysqlAlgorithmCopy, mysqlLockShared, true, false)
+			}
+		case tidbast.AlterTableDropPrimaryKey:
+			ddl.merge(mysqlAlgorithmCopy, mysqlLockShared, true, false)
+		case tidbast.AlterTableModifyColumn, tidbast.AlterTableChangeColumn:
+			columnName := spec.NewColumns[0].Name.Name.O
+			if spec.OldColumnName != nil {
+				columnName = spec.OldColumnName.Name.O
+			}
+			column := findColumnMetadata(metadata, "", node.Table.Name.O, columnName)
+			if column != nil && strings.EqualFold(column.Type, spec.NewColumns[0].Tp.CompactStr()) {
+				ddl.merge(mysqlAlgorithmInplace, mysqlLockNone, true, false)
+			} else {
+				// Changing the column data type copies the table.
+				ddl.merge(mysqlAlgorithmCopy, mysqlLockShared, true, false)
+			}
+		case tidbast.AlterTableForce:
+			ddl.merge(mysqlAlgorithmInplace, mysqlLockNone, true, false)
+		case tidbast.AlterTableOption:
+			for _, option := range spec.Options {
+				switch option.Tp {
+				case tidbast.TableOptionCharset, tidbast.TableOptionCollate:
+					ddl.merge(mysqlAlgorithmCopy, mysqlLockShared, true, false)
+				case tidbast.TableOptionEngine, tidbast.TableOptionRowFormat:
+					ddl.merge(mysqlAlgorithmInplace, mysqlLockNone, true, false)
+				default:
+					ddl.merge(mysqlAlgorithmInplace, mysqlLockNone, false, false)
+				}
+			}
+		case tidbast.AlterTableLock:
+			lockType = spec.LockType
+		case tidbast.AlterTableAlgorithm:
+			algorithmType = spec.Algorithm
+		default:
+			ddl.merge(mysqlAlgorithmCopy, mysqlLockShared, true, false)
+		}
+	}
+	// Dropping the primary key and adding another one in the same statement rebuilds the table in place.
+	if addPrimaryKey && ddl.algorithm == mysqlAlgorithmCopy {
+		ddl.algorithm = mysqlAlgorithmInplace
+		ddl.lock = mysqlLockNone
+	}
+	applyMySQLLockAndAlgorithm(ddl, lockType, algorithmType)
+	return ddl
+}
+
+// applyMySQLLockAndAlgorithm applies the explicit LOCK and ALGORITHM clauses.
+func applyMySQLLockAndAlgorithm(ddl *mysqlOnlineDDL, lockType tidbast.LockType, algorithmType tidbast.AlgorithmType) {
+	if algorithmType == tidbast.AlgorithmTypeCopy {
+		ddl.merge(mysqlAlgorithmCopy, mysqlLockShared, true, false)
+	}
+	switch lockType {
+	case tidbast.LockTypeShared:
+		ddl.merge(mysqlAlgorithmNone, mysqlLockShared, false, false)
+	case tidbast.LockTypeExclusive:
+		ddl.merge(mysqlAlgorithmNone, mysqlLockExclusive, false, false)
+	}
+}
@@ -0,0 +1,155 @@
+package taskcheck
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func newLockImpactTestMetadata(schemaName string, columnType string) *storepb.DatabaseMetadata {
+	return &storepb.DatabaseMetadata{
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: schemaName,
+				Tables: []*storepb.TableMetadata{
+					{
+						Name:      "t",
+						RowCount:  1000000,
+						DataSize:  64 * 1024 * 1024 * 10,
+						IndexSize: 64 * 1024 * 1024 * 6,
+						Columns: []*storepb.ColumnMetadata{
+							{Name: "id", Type: "int"},
+							{Name: "name", Type: columnType},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestReportStatementLockImpactForPostgres(t *testing.T) {
+	metadata := newLockImpactTestMetadata("public", "character varying(20)")
+	tests := []struct {
+		statement string
+		want      api.TaskCheckLockImpact
+	}{
+		{
+			statement: "ALTER TABLE t ADD COLUMN c int DEFAULT 0",
+			want:      api.TaskCheckLockImpact{LockLevel: "ACCESS_EXCLUSIVE", Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024},
+		},
+		{
+			statement: "ALTER TABLE t ADD COLUMN c timestamptz DEFAULT clock_timestamp()",
+			want:      api.TaskCheckLockImpact{LockLevel: "ACCESS_EXCLUSIVE", Rewrite: true, Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024, BlockingSeconds: 32},
+		},
+		{
+			statement: "ALTER TABLE t ALTER COLUMN name TYPE varchar(50)",
+			want:      api.TaskCheckLockImpact{LockLevel: "ACCESS_EXCLUSIVE", Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024},
+		},
+		{
+			statement: "ALTER TABLE t ALTER COLUMN id TYPE bigint",
+			want:      api.TaskCheckLockImpact{LockLevel: "ACCESS_EXCLUSIVE", Rewrite: true, Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024, BlockingSeconds: 32},
+		},
+		{
+			statement: "ALTER TABLE t ADD CONSTRAINT fk FOREIGN KEY (id) REFERENCES t2(id)",
+			want:      api.TaskCheckLockImpact{LockLevel: "SHARE_ROW_EXCLUSIVE", Scan: true, Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024, BlockingSeconds: 5},
+		},
+		{
+			statement: "ALTER TABLE t ADD CONSTRAINT fk FOREIGN KEY (id) REFERENCES t2(id) NOT VALID",
+			want:      api.TaskCheckLockImpact{LockLevel: "SHARE_ROW_EXCLUSIVE", Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024},
+		},
+		{
+			statement: "CREATE INDEX idx ON t(name)",
+			want:      api.TaskCheckLockImpact{LockLevel: "SHARE", Scan: true, Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024, BlockingSeconds: 5},
+		},
+		{
+			statement: "CREATE INDEX CONCURRENTLY idx ON t(name)",
+			want:      api.TaskCheckLockImpact{LockLevel: "SHARE_UPDATE_EXCLUSIVE", Scan: true, Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024},
+		},
+		{
+			statement: "UPDATE t SET name = 'a'",
+			want:      api.TaskCheckLockImpact{LockLevel: "ROW_EXCLUSIVE", Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024},
+		},
+		{
+			statement: "CREATE TABLE t2(id int)",
+			want:      api.TaskCheckLockImpact{},
+		},
+	}
+
+	for _, test := range tests {
+		results, err := reportStatementLockImpactForPostgres(test.statement, metadata)
+		require.NoError(t, err)
+		require.Len(t, results, 1, test.statement)
+		var impact api.TaskCheckLockImpact
+		require.NoError(t, json.Unmarshal([]byte(results[0].Content), &impact))
+		require.Equal(t, test.want, impact, test.statement)
+	}
+}
+
+func TestReportStatementLockImpactForMySQL(t *testing.T) {
+	metadata := newLockImpactTestMetadata("", "varchar(20)")
+	tests := []struct {
+		statement string
+		version   string
+		want      api.TaskCheckLockImpact
+	}{
+		{
+			statement: "ALTER TABLE t ADD COLUMN c int",
+			version:   "8.0.32",
+			want:      api.TaskCheckLockImpact{LockLevel: "NONE", Algorithm: "INSTANT", Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024},
+		},
+		{
+			statement: "ALTER TABLE t ADD COLUMN c int AFTER id",
+			version:   "8.0.20",
+			want:      api.TaskCheckLockImpact{LockLevel: "NONE", Algorithm: "INPLACE", Rewrite: true, Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024},
+		},
+		{
+			statement: "ALTER TABLE t ADD COLUMN c int",
+			version:   "5.7.40-log",
+			want:      api.TaskCheckLockImpact{LockLevel: "NONE", Algorithm: "INPLACE", Rewrite: true, Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024},
+		},
+		{
+			statement: "ALTER TABLE t MODIFY COLUMN name varchar(20) NOT NULL",
+			version:   "8.0.32",
+			want:      api.TaskCheckLockImpact{LockLevel: "NONE", Algorithm: "INPLACE", Rewrite: true, Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024},
+		},
+		{
+			statement: "ALTER TABLE t MODIFY COLUMN id bigint",
+			version:   "8.0.32",
+			want:      api.TaskCheckLockImpact{LockLevel: "SHARED", Algorithm: "COPY", Rewrite: true, Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024, BlockingSeconds: 32},
+		},
+		{
+			statement: "ALTER TABLE t ADD INDEX idx(name)",
+			version:   "8.0.32",
+			want:      api.TaskCheckLockImpact{LockLevel: "NONE", Algorithm: "INPLACE", Scan: true, Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024},
+		},
+		{
+			statement: "ALTER TABLE t ADD INDEX idx(name), LOCK=EXCLUSIVE",
+			version:   "8.0.32",
+			want:      api.TaskCheckLockImpact{LockLevel: "EXCLUSIVE", Algorithm: "INPLACE", Scan: true, Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024, BlockingSeconds: 5},
+		},
+		{
+			statement: "CREATE FULLTEXT INDEX idx ON t(name)",
+			version:   "8.0.32",
+			want:      api.TaskCheckLockImpact{LockLevel: "SHARED", Algorithm: "INPLACE", Scan: true, Table: "t", TableRows: 1000000, TableSize: 1024 * 1024 * 1024, BlockingSeconds: 5},
+		},
+		{
+			statement: "INSERT INTO t VALUES (1, 'a')",
+			version:   "8.0.32",
+			want:      api.TaskCheckLockImpact{},
+		},
+	}
+
+	for _, test := range tests {
+		results, err := reportStatementLockImpactForMySQL(test.statement, test.version, metadata)
+		require.NoError(t, err)
+		require.Len(t, results, 1, test.statement)
+		var impact api.TaskCheckLockImpact
+		require.NoError(t, json.Unmarshal([]byte(results[0].Content), &impact))
+		require.Equal(t, test.want, impact, test.statement)
+	}
+}
@@ -16,7 +16,7 @@ import (
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
-	bbs3 "github.com/bytebase/bytebase/backend/plugin/storage/s3"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
 	"github.com/bytebase/bytebase/backend/runner/backuprun"
 	"github.com/bytebase/bytebase/backend/store"
 )
@@ -27,21 +27,23 @@ const (
 )
 
 // NewDatabaseBackupExecutor creates a new database backup task executor.
-func NewDatabaseBackupExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, profile config.Profile) Executor {
+func NewDatabaseBackupExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, storageBackend storage.Backend, secret string, profile config.Profile) Executor {
 	return &DatabaseBackupExecutor{
-		store:     store,
-		dbFactory: dbFactory,
-		s3Client:  s3Client,
-		profile:   profile,
+		store:          store,
+		dbFactory:      dbFactory,
+		storageBackend: storageBackend,
+		secret:         secret,
+		profile:        profile,
 	}
 }
 
 // DatabaseBackupExecutor is the task executor for database backup.
 type DatabaseBackupExecutor struct {
-	store     *store.Store
-	dbFactory *dbfactory.DBFactory
-	s3Client  *bbs3.Client
-	profile   config.Profile
+	store          *store.Store
+	dbFactory      *dbfactory.DBFactory
+	storageBackend storage.Backend
+	secret         string
+	profile        config.Profile
 }
 
 // RunOnce will run database backup once.
@@ -81,7 +83,7 @@ func (exec *DatabaseBackupExecutor) RunOnce(ctx context.Context, task *store.Tas
 		}
 	}
 	log.Debug("Start database backup.", zap.String("instance", instance.Title), zap.String("database", database.DatabaseName), zap.String("backup", backup.Name))
-	backupPayload, backupErr := exec.backupDatabase(ctx, exec.dbFactory, exec.s3Client, exec.profile, instance, database.DatabaseName, backup)
+	backupPayload, backupErr := exec.backupDatabase(ctx, exec.dbFactory, exec.storageBackend, exec.secret, exec.profile, instance, database.DatabaseName, backup)
 	backupStatus := string(api.BackupStatusDone)
 	comment := ""
 	if backupErr != nil {
@@ -138,21 +140,44 @@ func getAvailableFSSpace(path string) (uint64, error) {
 	return stat.Bavail * uint64(stat.Bsize), nil
 }
 
-func dumpBackupFile(ctx context.Context, driver db.Driver, backupFilePath string) (string, error) {
+// dumpBackupFile dumps the database to the backup file through the backup encoder,
+// and returns the backup payload with the codec and checksum recorded.
+func dumpBackupFile(ctx context.Context, driver db.Driver, backupFilePath string, compression api.BackupCompression, encryption api.BackupEncryption, secret string) (string, error) {
 	backupFile, err := os.Create(backupFilePath)
 	if err != nil {
 		return "", errors.Errorf("failed to open backup path %q", backupFilePath)
 	}
 	defer backupFile.Close()
-	payload, err := driver.Dump(ctx, backupFile, false /* schemaOnly */)
+	backupPayload := api.BackupPayload{}
+	encoder, err := backuprun.NewEncoder(backupFile, compression, encryption, secret, &backupPayload)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create backup encoder")
+	}
+	payload, err := driver.Dump(ctx, encoder, false /* schemaOnly */)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to dump database to local backup file %q", backupFilePath)
 	}
-	return payload, nil
+	if err := encoder.Close(); err != nil {
+		return "", errors.Wrapf(err, "failed to flush local backup file %q", backupFilePath)
+	}
+
+	// Merge the codec and checksum into the database specific payload returned by Dump.
+	if payload != "" {
+		var dumpPayload api.BackupPayload
+		if err := json.Unmarshal([]byte(payload), &dumpPayload); err != nil {
+			return "", errors.Wrapf(err, "failed to unmarshal backup payload %q", payload)
+		}
+		backupPayload.BinlogInfo = dumpPayload.BinlogInfo
+	}
+	bytes, err := json.Marshal(backupPayload)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal backup payload")
+	}
+	return string(bytes), nil
 }
 
 // backupDatabase will take a backup of a database.
-func (*DatabaseBackupExecutor) backupDatabase(ctx context.Context, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, profile config.Profile, instance *store.InstanceMessage, databaseName string, backup *store.BackupMessage) (string, error) {
+func (*DatabaseBackupExecutor) backupDatabase(ctx context.Context, dbFactory *dbfactory.DBFactory, storageBackend storage.Backend, secret string, profile config.Profile, instance *store.InstanceMessage, databaseName string, backup *store.BackupMessage) (string, error) {
 	driver, err := dbFactory.GetAdminDatabaseDriver(ctx, instance, databaseName)
 	if err != nil {
 		return "", err
@@ -160,34 +185,34 @@ func (*DatabaseBackupExecutor) backupDatabase(ctx context.Context, dbFactory *db
 	defer driver.Close(ctx)
 
 	backupFilePathLocal := filepath.Join(profile.DataDir, backup.Path)
-	payload, err := dumpBackupFile(ctx, driver, backupFilePathLocal)
+	payload, err := dumpBackupFile(ctx, driver, backupFilePathLocal, profile.BackupCompression, profile.BackupEncryption, secret)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to dump backup file %q", backupFilePathLocal)
 	}
 
-	switch backup.StorageBackend {
-	case api.BackupStorageBackendLocal:
+	if backup.StorageBackend == api.BackupStorageBackendLocal {
 		return payload, nil
-	case api.BackupStorageBackendS3:
-		log.Debug("Uploading backup to s3 bucket.", zap.String("bucket", s3Client.GetBucket()), zap.String("path", backupFilePathLocal))
-		bucketFileToUpload, err := os.Open(backupFilePathLocal)
-		if err != nil {
-			return "", errors.Wrapf(err, "failed to open backup file %q for uploading to s3 bucket", backupFilePathLocal)
-		}
-		defer bucketFileToUpload.Close()
+	}
+	if storageBackend == nil {
+		return "", errors.Errorf("backup to %s not implemented yet", backup.StorageBackend)
+	}
 
-		if _, err := s3Client.UploadObject(ctx, backup.Path, bucketFileToUpload); err != nil {
-			return "", errors.Wrapf(err, "failed to upload backup to AWS S3")
-		}
-		log.Debug("Successfully uploaded backup to s3 bucket.")
+	log.Debug("Uploading backup to the cloud storage.", zap.String("storageBackend", string(backup.StorageBackend)), zap.String("bucket", storageBackend.GetBucket()), zap.String("path", backupFilePathLocal))
+	bucketFileToUpload, err := os.Open(backupFilePathLocal)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to open backup file %q for uploading to the cloud storage", backupFilePathLocal)
+	}
+	defer bucketFileToUpload.Close()
 
-		if err := os.Remove(backupFilePathLocal); err != nil {
-			log.Warn("Failed to remove the local backup file after uploading to s3 bucket.", zap.String("path", backupFilePathLocal), zap.Error(err))
-		} else {
-			log.Debug("Successfully removed the local backup file after uploading to s3 bucket.", zap.String("path", backupFilePathLocal))
-		}
-		return payload, nil
-	default:
-		return "", errors.Errorf("backup to %s not implemented yet", backup.StorageBackend)
+	if err := storageBackend.UploadObject(ctx, backup.Path, bucketFileToUpload); err != nil {
+		return "", errors.Wrapf(err, "failed to upload backup to %s", backup.StorageBackend)
 	}
+	log.Debug("Successfully uploaded backup to the cloud storage.")
+
+	if err := os.Remove(backupFilePathLocal); err != nil {
+		log.Warn("Failed to remove the local backup file after uploading to the cloud storage.", zap.String("path", backupFilePathLocal), zap.Error(err))
+	} else {
+		log.Debug("Successfully removed the local backup file after uploading to the cloud storage.", zap.String("path", backupFilePathLocal))
+	}
+	return payload, nil
 }
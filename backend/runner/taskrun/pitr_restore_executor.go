@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -23,7 +24,7 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
 	"github.com/bytebase/bytebase/backend/plugin/db/pg"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
-	bbs3 "github.com/bytebase/bytebase/backend/plugin/storage/s3"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
 	"github.com/bytebase/bytebase/backend/runner/backuprun"
 	"github.com/bytebase/bytebase/backend/runner/schemasync"
 	"github.com/bytebase/bytebase/backend/store"
@@ -31,25 +32,27 @@ import (
 )
 
 // NewPITRRestoreExecutor creates a PITR restore task executor.
-func NewPITRRestoreExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, schemaSyncer *schemasync.Syncer, stateCfg *state.State, profile config.Profile) Executor {
+func NewPITRRestoreExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, storageBackend storage.Backend, schemaSyncer *schemasync.Syncer, stateCfg *state.State, secret string, profile config.Profile) Executor {
 	return &PITRRestoreExecutor{
-		store:        store,
-		dbFactory:    dbFactory,
-		s3Client:     s3Client,
-		schemaSyncer: schemaSyncer,
-		stateCfg:     stateCfg,
-		profile:      profile,
+		store:          store,
+		dbFactory:      dbFactory,
+		storageBackend: storageBackend,
+		schemaSyncer:   schemaSyncer,
+		stateCfg:       stateCfg,
+		secret:         secret,
+		profile:        profile,
 	}
 }
 
 // PITRRestoreExecutor is the PITR restore task executor.
 type PITRRestoreExecutor struct {
-	store        *store.Store
-	dbFactory    *dbfactory.DBFactory
-	s3Client     *bbs3.Client
-	schemaSyncer *schemasync.Syncer
-	stateCfg     *state.State
-	profile      config.Profile
+	store          *store.Store
+	dbFactory      *dbfactory.DBFactory
+	storageBackend storage.Backend
+	schemaSyncer   *schemasync.Syncer
+	stateCfg       *state.State
+	secret         string
+	profile        config.Profile
 }
 
 // RunOnce will run the PITR restore task executor once.
@@ -74,15 +77,15 @@ func (exec *PITRRestoreExecutor) RunOnce(ctx context.Context, task *store.TaskMe
 
 	if payload.BackupID != nil {
 		// Restore Backup
-		resultPayload, err := exec.doBackupRestore(ctx, exec.store, exec.dbFactory, exec.s3Client, exec.schemaSyncer, exec.profile, task, payload)
+		resultPayload, err := exec.doBackupRestore(ctx, exec.store, exec.dbFactory, exec.storageBackend, exec.schemaSyncer, exec.profile, task, payload)
 		return true, resultPayload, err
 	}
 
-	resultPayload, err := exec.doPITRRestore(ctx, exec.dbFactory, exec.s3Client, exec.profile, task, payload)
+	resultPayload, err := exec.doPITRRestore(ctx, exec.dbFactory, exec.storageBackend, exec.profile, task, payload)
 	return true, resultPayload, err
 }
 
-func (exec *PITRRestoreExecutor) doBackupRestore(ctx context.Context, stores *store.Store, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, schemaSyncer *schemasync.Syncer, profile config.Profile, task *store.TaskMessage, payload api.TaskDatabasePITRRestorePayload) (*api.TaskRunResultPayload, error) {
+func (exec *PITRRestoreExecutor) doBackupRestore(ctx context.Context, stores *store.Store, dbFactory *dbfactory.DBFactory, storageBackend storage.Backend, schemaSyncer *schemasync.Syncer, profile config.Profile, task *store.TaskMessage, payload api.TaskDatabasePITRRestorePayload) (*api.TaskRunResultPayload, error) {
 	instance, err := stores.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to find database for the backup")
@@ -120,6 +123,9 @@ func (exec *PITRRestoreExecutor) doBackupRestore(ctx context.Context, stores *st
 	if err != nil {
 		return nil, err
 	}
+	if targetInstance == nil {
+		return nil, errors.Errorf("target instance %d not found", *payload.TargetInstanceID)
+	}
 	targetDatabase, err := exec.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{EnvironmentID: &targetInstance.EnvironmentID, InstanceID: &targetInstance.ResourceID, DatabaseName: payload.DatabaseName})
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find target database %q in instance %q", *payload.DatabaseName, instance.Title)
@@ -139,7 +145,7 @@ func (exec *PITRRestoreExecutor) doBackupRestore(ctx context.Context, stores *st
 	)
 
 	// Restore the database to the target database.
-	if err := exec.restoreDatabase(ctx, dbFactory, s3Client, profile, targetInstance, targetDatabase.DatabaseName, backup); err != nil {
+	if err := exec.restoreDatabase(ctx, dbFactory, storageBackend, profile, targetInstance, targetDatabase.DatabaseName, backup); err != nil {
 		return nil, err
 	}
 	// TODO(zp): This should be done in the same transaction as restoreDatabase to guarantee consistency.
@@ -178,7 +184,7 @@ func (exec *PITRRestoreExecutor) doBackupRestore(ctx context.Context, stores *st
 	}, nil
 }
 
-func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, profile config.Profile, task *store.TaskMessage, payload api.TaskDatabasePITRRestorePayload) (*api.TaskRunResultPayload, error) {
+func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *dbfactory.DBFactory, storageBackend storage.Backend, profile config.Profile, task *store.TaskMessage, payload api.TaskDatabasePITRRestorePayload) (*api.TaskRunResultPayload, error) {
 	instance, err := exec.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
 	if err != nil {
 		return nil, err
@@ -187,6 +193,9 @@ func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *d
 	if err != nil {
 		return nil, err
 	}
+	if instance.Engine == db.Postgres {
+		return exec.doPITRRestorePostgres(ctx, dbFactory, storageBackend, instance, database, task, payload)
+	}
 
 	sourceDriver, err := dbFactory.GetAdminDatabaseDriver(ctx, instance, "")
 	if err != nil {
@@ -200,6 +209,9 @@ func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *d
 		if err != nil {
 			return nil, err
 		}
+		if targetInstance == nil {
+			return nil, errors.Errorf("target instance %d not found", *payload.TargetInstanceID)
+		}
 		if targetDriver, err = dbFactory.GetAdminDatabaseDriver(ctx, targetInstance, ""); err != nil {
 			return nil, err
 		}
@@ -230,13 +242,13 @@ func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *d
 	}
 
 	log.Debug("Downloading all binlog files")
-	if err := mysqlSourceDriver.FetchAllBinlogFiles(ctx, true /* downloadLatestBinlogFile */, s3Client); err != nil {
+	if err := mysqlSourceDriver.FetchAllBinlogFiles(ctx, true /* downloadLatestBinlogFile */, storageBackend); err != nil {
 		return nil, err
 	}
 
 	targetTs := *payload.PointInTimeTs
 	log.Debug("Getting latest backup before or equal to targetTs", zap.Int64("targetTs", targetTs))
-	backup, targetBinlogInfo, err := mysqlSourceDriver.GetLatestBackupBeforeOrEqualTs(ctx, backupList, targetTs, s3Client)
+	backup, targetBinlogInfo, err := mysqlSourceDriver.GetLatestBackupBeforeOrEqualTs(ctx, backupList, targetTs, storageBackend)
 	if err != nil {
 		targetTsHuman := time.Unix(targetTs, 0).Format(time.RFC822)
 		log.Error("Failed to get backup before or equal to time",
@@ -250,14 +262,14 @@ func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *d
 	log.Debug("Got latest backup before or equal to targetTs", zap.String("backup", backup.Name))
 
 	backupAbsPathLocal := backuprun.GetBackupAbsFilePath(profile.DataDir, backup.DatabaseUID, backup.Name)
-	if backup.StorageBackend == api.BackupStorageBackendS3 {
-		if err := downloadBackupFileFromCloud(ctx, s3Client, backup.Path, backupAbsPathLocal); err != nil {
-			return nil, errors.Wrapf(err, "failed to download backup %q from S3", backup.Path)
+	if backup.StorageBackend != api.BackupStorageBackendLocal {
+		if err := downloadBackupFileFromCloud(ctx, storageBackend, profile.BackupStorageBackend, backup, backupAbsPathLocal); err != nil {
+			return nil, errors.Wrapf(err, "failed to download backup %q from %s", backup.Path, backup.StorageBackend)
 		}
 		defer os.Remove(backupAbsPathLocal)
-		replayBinlogPathList, err := downloadBinlogFilesFromCloud(ctx, s3Client, startBinlogInfo, *targetBinlogInfo, binlogDir)
+		replayBinlogPathList, err := downloadBinlogFilesFromCloud(ctx, storageBackend, startBinlogInfo, *targetBinlogInfo, binlogDir)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to download binlog files from %s to %s from S3", startBinlogInfo.FileName, targetBinlogInfo.FileName)
+			return nil, errors.Wrapf(err, "failed to download binlog files from %s to %s from %s", startBinlogInfo.FileName, targetBinlogInfo.FileName, backup.StorageBackend)
 		}
 		defer func() {
 			for _, binlogPath := range replayBinlogPathList {
@@ -274,19 +286,24 @@ func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *d
 	}
 	defer backupFile.Close()
 	log.Debug("Successfully opened backup file", zap.String("filename", backupAbsPathLocal))
+	backupReader, err := backuprun.NewDecoder(backupFile, backup.Payload, exec.secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode backup file %q", backupAbsPathLocal)
+	}
+	defer backupReader.Close()
 
 	log.Debug("Start creating and restoring PITR database",
 		zap.String("instance", instance.ResourceID),
 		zap.String("database", database.DatabaseName),
 	)
 
-	if err := exec.updateProgress(ctx, mysqlTargetDriver, task.ID, backupFile, startBinlogInfo, *targetBinlogInfo, binlogDir); err != nil {
+	if err := exec.updateProgress(ctx, mysqlTargetDriver, task.ID, backup, backupFile, startBinlogInfo, *targetBinlogInfo, binlogDir); err != nil {
 		return nil, errors.Wrap(err, "failed to setup progress update process")
 	}
 
 	if payload.DatabaseName != nil {
 		// case 1: PITR to a new database.
-		if err := mysqlTargetDriver.RestoreBackupToDatabase(ctx, backupFile, *payload.DatabaseName); err != nil {
+		if err := mysqlTargetDriver.RestoreBackupToDatabase(ctx, backupReader, *payload.DatabaseName); err != nil {
 			log.Error("failed to restore full backup in the new database",
 				zap.Int("issueID", issue.UID),
 				zap.String("databaseName", *payload.DatabaseName),
@@ -302,7 +319,7 @@ func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *d
 		}
 	} else {
 		// case 2: in-place PITR.
-		if err := mysqlTargetDriver.RestoreBackupToPITRDatabase(ctx, backupFile, database.DatabaseName, issue.CreatedTime.Unix()); err != nil {
+		if err := mysqlTargetDriver.RestoreBackupToPITRDatabase(ctx, backupReader, database.DatabaseName, issue.CreatedTime.Unix()); err != nil {
 			log.Error("failed to restore full backup in the PITR database",
 				zap.Int("issueID", issue.UID),
 				zap.String("databaseName", database.DatabaseName),
@@ -328,7 +345,7 @@ func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *d
 	}, nil
 }
 
-func downloadBinlogFilesFromCloud(ctx context.Context, client *bbs3.Client, startBinlogInfo, targetBinlogInfo api.BinlogInfo, binlogDir string) ([]string, error) {
+func downloadBinlogFilesFromCloud(ctx context.Context, storageBackend storage.Backend, startBinlogInfo, targetBinlogInfo api.BinlogInfo, binlogDir string) ([]string, error) {
 	replayBinlogPathList, err := mysql.GetBinlogReplayList(startBinlogInfo, targetBinlogInfo, binlogDir)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get binlog replay list in directory %s", binlogDir)
@@ -336,14 +353,14 @@ func downloadBinlogFilesFromCloud(ctx context.Context, client *bbs3.Client, star
 	for _, binlogFilePath := range replayBinlogPathList {
 		// Use path.Join to compose a path on cloud which always uses / as the separator.
 		filePathOnCloud := path.Join(common.GetBinlogRelativeDir(binlogDir), filepath.Base(binlogFilePath))
-		if err := client.DownloadFileFromCloud(ctx, binlogFilePath, filePathOnCloud); err != nil {
+		if err := storage.DownloadFileFromCloud(ctx, storageBackend, binlogFilePath, filePathOnCloud); err != nil {
 			return nil, errors.Wrapf(err, "failed to download binlog file %s from the cloud storage", binlogFilePath)
 		}
 	}
 	return replayBinlogPathList, nil
 }
 
-func (*PITRRestoreExecutor) doRestoreInPlacePostgres(ctx context.Context, stores *store.Store, dbFactory *dbfactory.DBFactory, profile config.Profile, issue *store.IssueMessage, task *store.TaskMessage, payload api.TaskDatabasePITRRestorePayload) (*api.TaskRunResultPayload, error) {
+func (exec *PITRRestoreExecutor) doRestoreInPlacePostgres(ctx context.Context, stores *store.Store, dbFactory *dbfactory.DBFactory, profile config.Profile, issue *store.IssueMessage, task *store.TaskMessage, payload api.TaskDatabasePITRRestorePayload) (*api.TaskRunResultPayload, error) {
 	if payload.BackupID == nil {
 		return nil, errors.Errorf("PITR for Postgres is not implemented")
 	}
@@ -361,6 +378,11 @@ func (*PITRRestoreExecutor) doRestoreInPlacePostgres(ctx context.Context, stores
 		return nil, errors.Wrapf(err, "failed to open backup file %q", backupFileName)
 	}
 	defer backupFile.Close()
+	backupReader, err := backuprun.NewDecoder(backupFile, backup.Payload, exec.secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode backup file %q", backupFileName)
+	}
+	defer backupReader.Close()
 
 	instance, err := stores.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
 	if err != nil {
@@ -376,51 +398,144 @@ func (*PITRRestoreExecutor) doRestoreInPlacePostgres(ctx context.Context, stores
 	}
 	defer driver.Close(ctx)
 
-	pgDriver, ok := driver.(*pg.Driver)
+	pitrDatabaseName := util.GetPITRDatabaseName(database.DatabaseName, issue.CreatedTime.Unix())
+	if err := createPostgresPITRDatabase(ctx, dbFactory, instance, driver, database.DatabaseName, pitrDatabaseName); err != nil {
+		return nil, err
+	}
+
+	pitrDBDriver, err := dbFactory.GetAdminDatabaseDriver(ctx, instance, "")
+	if err != nil {
+		return nil, err
+	}
+	defer pitrDBDriver.Close(ctx)
+	if err := pitrDBDriver.Restore(ctx, backupReader); err != nil {
+		return nil, errors.Wrapf(err, "failed to restore backup to the PITR database %q", pitrDatabaseName)
+	}
+	return &api.TaskRunResultPayload{
+		Detail: fmt.Sprintf("Restored backup %q to the temporary PITR database %q", backup.Name, pitrDatabaseName),
+	}, nil
+}
+
+// doPITRRestorePostgres recovers the instance to the point in time on a temporary server from the archived base backup and WAL files,
+// and restores the dump of the database to the PITR database or the new database.
+func (exec *PITRRestoreExecutor) doPITRRestorePostgres(ctx context.Context, dbFactory *dbfactory.DBFactory, storageBackend storage.Backend, instance *store.InstanceMessage, database *store.DatabaseMessage, task *store.TaskMessage, payload api.TaskDatabasePITRRestorePayload) (*api.TaskRunResultPayload, error) {
+	issue, err := exec.store.GetIssueV2(ctx, &store.FindIssueMessage{PipelineID: &task.PipelineID})
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, errors.Errorf("issue not found for pipeline %v", task.PipelineID)
+	}
+
+	sourceDriver, err := dbFactory.GetAdminDatabaseDriver(ctx, instance, database.DatabaseName)
+	if err != nil {
+		return nil, err
+	}
+	defer sourceDriver.Close(ctx)
+	pgSourceDriver, ok := sourceDriver.(*pg.Driver)
 	if !ok {
 		log.Error("Failed to cast driver to pg.Driver")
 		return nil, errors.Errorf("[internal] cast driver to pg.Driver failed")
 	}
+
+	targetInstance := instance
+	var targetDatabaseName string
+	if payload.DatabaseName != nil {
+		// case 1: PITR to a new database.
+		if payload.TargetInstanceID == nil {
+			return nil, errors.Errorf("target instance is required to restore to database %q", *payload.DatabaseName)
+		}
+		if targetInstance, err = exec.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: payload.TargetInstanceID}); err != nil {
+			return nil, err
+		}
+		if targetInstance == nil {
+			return nil, errors.Errorf("target instance %d not found", *payload.TargetInstanceID)
+		}
+		targetDatabaseName = *payload.DatabaseName
+	} else {
+		// case 2: in-place PITR.
+		targetDatabaseName = util.GetPITRDatabaseName(database.DatabaseName, issue.CreatedTime.Unix())
+		if err := createPostgresPITRDatabase(ctx, dbFactory, instance, sourceDriver, database.DatabaseName, targetDatabaseName); err != nil {
+			return nil, err
+		}
+	}
+	targetDriver, err := dbFactory.GetAdminDatabaseDriver(ctx, targetInstance, targetDatabaseName)
+	if err != nil {
+		return nil, err
+	}
+	defer targetDriver.Close(ctx)
+
+	targetTs := *payload.PointInTimeTs
+	log.Debug("Start PostgreSQL PITR",
+		zap.String("instance", instance.ResourceID),
+		zap.String("database", database.DatabaseName),
+		zap.String("targetDatabase", targetDatabaseName),
+		zap.Int64("targetTs", targetTs),
+	)
+	// The dump of the recovered database is streamed to the target database, so that it does not need to fit on disk.
+	pr, pw := io.Pipe()
+	dumpErr := make(chan error, 1)
+	go func() {
+		err := pgSourceDriver.DumpPITRDatabase(ctx, storageBackend, database.DatabaseName, targetTs, pw)
+		pw.CloseWithError(err)
+		dumpErr <- err
+	}()
+	restoreErr := targetDriver.Restore(ctx, pr)
+	// Unblock the dump if the restore fails halfway, and wait for the temporary server to stop.
+	pr.CloseWithError(errors.New("restore aborted"))
+	if err := <-dumpErr; err != nil {
+		return nil, errors.Wrapf(err, "failed to recover database %q to %s", database.DatabaseName, time.Unix(targetTs, 0).Format(time.RFC822))
+	}
+	if restoreErr != nil {
+		return nil, errors.Wrapf(restoreErr, "failed to restore the recovered database to %q", targetDatabaseName)
+	}
+
+	log.Info("PITR restore success", zap.String("target database", targetDatabaseName))
+	return &api.TaskRunResultPayload{
+		Detail: fmt.Sprintf("PITR restore success for target database %q", targetDatabaseName),
+	}, nil
+}
+
+// createPostgresPITRDatabase creates the PITR database with the same owner as the original database.
+// driver must be connected to the original database.
+func createPostgresPITRDatabase(ctx context.Context, dbFactory *dbfactory.DBFactory, instance *store.InstanceMessage, driver db.Driver, databaseName, pitrDatabaseName string) error {
+	pgDriver, ok := driver.(*pg.Driver)
+	if !ok {
+		log.Error("Failed to cast driver to pg.Driver")
+		return errors.Errorf("[internal] cast driver to pg.Driver failed")
+	}
 	originalOwner, err := pgDriver.GetCurrentDatabaseOwner()
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get the OWNER of database %q", database.DatabaseName)
+		return errors.Wrapf(err, "failed to get the OWNER of database %q", databaseName)
 	}
 
 	defaultDBDriver, err := dbFactory.GetAdminDatabaseDriver(ctx, instance, "")
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer defaultDBDriver.Close(ctx)
 	db := defaultDBDriver.GetDB()
-	pitrDatabaseName := util.GetPITRDatabaseName(database.DatabaseName, issue.CreatedTime.Unix())
 	// If there's already a PITR database, it means there's a failed trial before this task execution.
 	// We need to clean up the dirty state and start clean for idempotent task execution.
 	if _, err := db.ExecContext(ctx, fmt.Sprintf("DROP DATABASE IF EXISTS %s;", pitrDatabaseName)); err != nil {
-		return nil, errors.Wrapf(err, "failed to drop the dirty PITR database %q left from a former task execution", pitrDatabaseName)
+		return errors.Wrapf(err, "failed to drop the dirty PITR database %q left from a former task execution", pitrDatabaseName)
 	}
 	if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE %s WITH OWNER %s;", pitrDatabaseName, originalOwner)); err != nil {
-		return nil, errors.Wrapf(err, "failed to create the PITR database %q", pitrDatabaseName)
-	}
-
-	pitrDBDriver, err := dbFactory.GetAdminDatabaseDriver(ctx, instance, "")
-	if err != nil {
-		return nil, err
-	}
-	defer pitrDBDriver.Close(ctx)
-	if err := pitrDBDriver.Restore(ctx, backupFile); err != nil {
-		return nil, errors.Wrapf(err, "failed to restore backup to the PITR database %q", pitrDatabaseName)
+		return errors.Wrapf(err, "failed to create the PITR database %q", pitrDatabaseName)
 	}
-	return &api.TaskRunResultPayload{
-		Detail: fmt.Sprintf("Restored backup %q to the temporary PITR database %q", backup.Name, pitrDatabaseName),
-	}, nil
+	return nil
 }
 
-func (exec *PITRRestoreExecutor) updateProgress(ctx context.Context, driver *mysql.Driver, taskID int, backupFile *os.File, startBinlogInfo, targetBinlogInfo api.BinlogInfo, binlogDir string) error {
-	backupFileInfo, err := backupFile.Stat()
-	if err != nil {
-		return errors.Wrapf(err, "failed to get stat of backup file %q", backupFile.Name())
+func (exec *PITRRestoreExecutor) updateProgress(ctx context.Context, driver *mysql.Driver, taskID int, backup *store.BackupMessage, backupFile *os.File, startBinlogInfo, targetBinlogInfo api.BinlogInfo, binlogDir string) error {
+	// The restored bytes are counted on the decoded backup, so we use the raw size for compressed or encrypted backups.
+	backupFileBytes := backup.Payload.RawSize
+	if backupFileBytes == 0 {
+		backupFileInfo, err := backupFile.Stat()
+		if err != nil {
+			return errors.Wrapf(err, "failed to get stat of backup file %q", backupFile.Name())
+		}
+		backupFileBytes = backupFileInfo.Size()
 	}
-	backupFileBytes := backupFileInfo.Size()
 	replayBinlogPaths, err := mysql.GetBinlogReplayList(startBinlogInfo, targetBinlogInfo, binlogDir)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get binlog replay list from %s to %s in binlog directory %q", startBinlogInfo.FileName, targetBinlogInfo.FileName, binlogDir)
@@ -460,7 +575,7 @@ func (exec *PITRRestoreExecutor) updateProgress(ctx context.Context, driver *mys
 }
 
 // restoreDatabase will restore the database to the instance from the backup.
-func (*PITRRestoreExecutor) restoreDatabase(ctx context.Context, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, profile config.Profile, instance *store.InstanceMessage, databaseName string, backup *store.BackupMessage) error {
+func (exec *PITRRestoreExecutor) restoreDatabase(ctx context.Context, dbFactory *dbfactory.DBFactory, storageBackend storage.Backend, profile config.Profile, instance *store.InstanceMessage, databaseName string, backup *store.BackupMessage) error {
 	driver, err := dbFactory.GetAdminDatabaseDriver(ctx, instance, databaseName)
 	if err != nil {
 		return err
@@ -469,9 +584,9 @@ func (*PITRRestoreExecutor) restoreDatabase(ctx context.Context, dbFactory *dbfa
 
 	backupAbsPathLocal := filepath.Join(profile.DataDir, backup.Path)
 
-	if backup.StorageBackend == api.BackupStorageBackendS3 {
-		if err := downloadBackupFileFromCloud(ctx, s3Client, backup.Path, backupAbsPathLocal); err != nil {
-			return errors.Wrapf(err, "failed to download backup %q from S3", backup.Path)
+	if backup.StorageBackend != api.BackupStorageBackendLocal {
+		if err := downloadBackupFileFromCloud(ctx, storageBackend, profile.BackupStorageBackend, backup, backupAbsPathLocal); err != nil {
+			return errors.Wrapf(err, "failed to download backup %q from %s", backup.Path, backup.StorageBackend)
 		}
 		defer os.Remove(backupAbsPathLocal)
 	}
@@ -481,25 +596,33 @@ func (*PITRRestoreExecutor) restoreDatabase(ctx context.Context, dbFactory *dbfa
 		return errors.Wrapf(err, "failed to open backup file at %s", backupAbsPathLocal)
 	}
 	defer backupFileLocal.Close()
+	backupReader, err := backuprun.NewDecoder(backupFileLocal, backup.Payload, exec.secret)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decode backup file at %s", backupAbsPathLocal)
+	}
+	defer backupReader.Close()
 
-	if err := driver.Restore(ctx, backupFileLocal); err != nil {
+	if err := driver.Restore(ctx, backupReader); err != nil {
 		return errors.Wrap(err, "failed to restore backup")
 	}
 
 	return nil
 }
 
-func downloadBackupFileFromCloud(ctx context.Context, s3Client *bbs3.Client, backupPath, backupAbsPathLocal string) error {
-	log.Debug("Downloading backup file from s3 bucket.", zap.String("path", backupPath))
-	backupFileDownload, err := os.Create(backupAbsPathLocal)
-	if err != nil {
-		return errors.Wrapf(err, "failed to create local backup file %q for downloading from s3 bucket", backupAbsPathLocal)
+// downloadBackupFileFromCloud downloads the backup file from the storage backend configured for the server.
+// It returns an error if the backup was taken with another storage backend, since the file cannot be found in the current one.
+func downloadBackupFileFromCloud(ctx context.Context, storageBackend storage.Backend, storageBackendType api.BackupStorageBackend, backup *store.BackupMessage, backupAbsPathLocal string) error {
+	if backup.StorageBackend != storageBackendType {
+		return errors.Errorf("backup %q is stored in storage backend %s, but the current storage backend is %s", backup.Name, backup.StorageBackend, storageBackendType)
+	}
+	if storageBackend == nil {
+		return errors.Errorf("storage backend %s is not configured", backup.StorageBackend)
 	}
-	defer backupFileDownload.Close()
-	if _, err := s3Client.DownloadObject(ctx, backupPath, backupFileDownload); err != nil {
-		return errors.Wrapf(err, "failed to download backup file %q from s3 bucket", backupPath)
+	log.Debug("Downloading backup file from the cloud storage.", zap.String("storageBackend", string(backup.StorageBackend)), zap.String("path", backup.Path))
+	if err := storage.DownloadFileFromCloud(ctx, storageBackend, backupAbsPathLocal, backup.Path); err != nil {
+		return errors.Wrapf(err, "failed to download backup file %q from the cloud storage", backup.Path)
 	}
-	log.Debug("Successfully downloaded backup file from s3 bucket.")
+	log.Debug("Successfully downloaded backup file from the cloud storage.")
 	return nil
 }
 
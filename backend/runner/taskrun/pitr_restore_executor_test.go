@@ -0,0 +1,27 @@
+package taskrun
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/storage/filesystem"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func TestDownloadBackupFileFromCloudStorageBackendMismatch(t *testing.T) {
+	a := require.New(t)
+	backend, err := filesystem.NewClient(t.TempDir())
+	a.NoError(err)
+	backup := &store.BackupMessage{
+		Name:           "backup",
+		StorageBackend: api.BackupStorageBackendFilesystem,
+		Path:           "backup/db/backup.sql",
+	}
+
+	err = downloadBackupFileFromCloud(context.Background(), backend, api.BackupStorageBackendAzure, backup, filepath.Join(t.TempDir(), "backup.sql"))
+	a.ErrorContains(err, "the current storage backend is AZURE")
+}
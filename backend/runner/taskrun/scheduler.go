@@ -19,6 +19,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/activity"
 	"github.com/bytebase/bytebase/backend/component/config"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
@@ -61,6 +62,7 @@ var (
 // NewScheduler creates a new task scheduler.
 func NewScheduler(
 	store *store.Store,
+	dbFactory *dbfactory.DBFactory,
 	applicationRunner *apprun.Runner,
 	schemaSyncer *schemasync.Syncer,
 	activityManager *activity.Manager,
@@ -70,6 +72,7 @@ func NewScheduler(
 	metricReporter *metricreport.Reporter) *Scheduler {
 	return &Scheduler{
 		store:             store,
+		dbFactory:         dbFactory,
 		applicationRunner: applicationRunner,
 		schemaSyncer:      schemaSyncer,
 		activityManager:   activityManager,
@@ -84,6 +87,7 @@ func NewScheduler(
 // Scheduler is the task scheduler.
 type Scheduler struct {
 	store             *store.Store
+	dbFactory         *dbfactory.DBFactory
 	applicationRunner *apprun.Runner
 	schemaSyncer      *schemasync.Syncer
 	activityManager   *activity.Manager
@@ -92,6 +96,8 @@ type Scheduler struct {
 	profile           config.Profile
 	executorMap       map[api.TaskType]Executor
 	metricReporter    *metricreport.Reporter
+	// checkingStageGates is the set of the stage IDs whose gates are being checked.
+	checkingStageGates sync.Map
 }
 
 // Register will register a task executor factory.
@@ -132,6 +138,11 @@ func (s *Scheduler) Run(ctx context.Context, wg *sync.WaitGroup) {
 					return
 				}
 
+				if err := s.scheduleStageGates(ctx); err != nil {
+					log.Error("Failed to schedule stage gates", zap.Error(err))
+					return
+				}
+
 				if err := s.schedulePendingTasks(ctx); err != nil {
 					log.Error("Failed to schedule tasks in the active stage",
 						zap.Error(err),
@@ -499,6 +510,11 @@ func (s *Scheduler) PatchTask(ctx context.Context, task *store.TaskMessage, task
 					TaskID:    task.ID,
 					Type:      api.TaskCheckDatabaseStatementTypeReport,
 				},
+				&store.TaskCheckRunMessage{
+					CreatorID: taskPatched.CreatorID,
+					TaskID:    task.ID,
+					Type:      api.TaskCheckDatabaseStatementLockImpactReport,
+				},
 			); err != nil {
 				// It's OK if we failed to trigger a check, just emit an error log
 				log.Error("Failed to trigger task report check after changing the task statement", zap.Int("task_id", task.ID), zap.String("task_name", task.Name), zap.Error(err))
@@ -720,6 +736,7 @@ func canUpdateTaskStatement(task *store.TaskMessage) *echo.HTTPError {
 // scheduleIfNeeded schedules the task if
 //  2. it has no blocking tasks.
 //  3. it has passed the earliest allowed time.
+//  4. the gates of the earlier stages have passed.
 func (s *Scheduler) scheduleIfNeeded(ctx context.Context, task *store.TaskMessage) error {
 	blocked, err := s.isTaskBlocked(ctx, task)
 	if err != nil {
@@ -728,6 +745,13 @@ func (s *Scheduler) scheduleIfNeeded(ctx context.Context, task *store.TaskMessag
 	if blocked {
 		return nil
 	}
+	gated, err := s.isTaskGated(ctx, task)
+	if err != nil {
+		return errors.Wrap(err, "failed to check if task is gated")
+	}
+	if gated {
+		return nil
+	}
 	if task.EarliestAllowedTs != 0 && time.Now().Before(time.Unix(task.EarliestAllowedTs, 0)) {
 		return nil
 	}
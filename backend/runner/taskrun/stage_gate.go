@@ -0,0 +1,487 @@
+package taskrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/activity"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	// stageGateHealthCheckTimeout is the timeout of checking the health of a database or a metric endpoint.
+	stageGateHealthCheckTimeout = 30 * time.Second
+	// stageGateMetricResponseLimit is the maximum size of the metric endpoint response body.
+	stageGateMetricResponseLimit = 1024
+)
+
+// scheduleStageGates checks the pending stage gates in the background.
+func (s *Scheduler) scheduleStageGates(ctx context.Context) error {
+	status := api.StageGatePending
+	gates, err := s.store.ListStageGates(ctx, &store.FindStageGateMessage{Status: &status})
+	if err != nil {
+		return err
+	}
+	for _, gate := range gates {
+		// Skip the gate that is already being checked, because the health check may take a while.
+		if _, loaded := s.checkingStageGates.LoadOrStore(gate.StageID, true); loaded {
+			continue
+		}
+		go func(gate *store.StageGateMessage) {
+			defer s.checkingStageGates.Delete(gate.StageID)
+			if err := s.checkStageGate(ctx, gate); err != nil {
+				log.Error("Failed to check stage gate",
+					zap.Int("pipeline_id", gate.PipelineID),
+					zap.Int("stage_id", gate.StageID),
+					zap.Error(err),
+				)
+			}
+		}(gate)
+	}
+	return nil
+}
+
+// checkStageGate checks the gate after the tasks of the stage finish. The gate
+//  1. fails if the failed tasks exceed the maximum failure percentage, or skips them otherwise.
+//  2. waits for the bake time after all tasks are done.
+//  3. fails if the health check fails, or passes otherwise.
+//
+// The gate stays pending if the failed tasks aren't allowed by the gate, since they block the rollout anyway.
+func (s *Scheduler) checkStageGate(ctx context.Context, gate *store.StageGateMessage) error {
+	issue, err := s.store.GetIssueV2(ctx, &store.FindIssueMessage{PipelineID: &gate.PipelineID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get issue by pipeline %d", gate.PipelineID)
+	}
+	if issue == nil || issue.Status != api.IssueOpen {
+		return nil
+	}
+	tasks, err := s.store.ListTasks(ctx, &api.TaskFind{PipelineID: &gate.PipelineID, StageID: &gate.StageID})
+	if err != nil {
+		return err
+	}
+
+	evaluation := evaluateStageGate(tasks, gate.Config, time.Now())
+	switch evaluation.action {
+	case stageGateWait:
+		return nil
+	case stageGateFail:
+		return s.failStageGate(ctx, issue, gate, evaluation.detail)
+	case stageGateSkipFailed:
+		skipped := true
+		for _, task := range evaluation.failedTasks {
+			if err := s.PatchTaskStatus(ctx, task, &api.TaskStatusPatch{
+				ID:            task.ID,
+				UpdaterID:     api.SystemBotID,
+				Status:        api.TaskDone,
+				Skipped:       &skipped,
+				SkippedReason: &evaluation.detail,
+				Comment:       &evaluation.detail,
+			}); err != nil {
+				return errors.Wrapf(err, "failed to skip the failed task %d", task.ID)
+			}
+		}
+		// The bake time starts after skipping the failed tasks, so check the gate again in the next round.
+		return nil
+	case stageGateCheckHealth:
+		if check := gate.Config.HealthCheck; check != nil {
+			if err := s.checkStageHealth(ctx, tasks, check); err != nil {
+				return s.failStageGate(ctx, issue, gate, fmt.Sprintf("Health check failed: %v", err))
+			}
+		}
+	}
+
+	status, fromStatus, detail := api.StageGatePassed, api.StageGatePending, ""
+	if _, err := s.store.UpdateStageGate(ctx, &store.UpdateStageGateMessage{
+		StageID:    gate.StageID,
+		UpdaterID:  api.SystemBotID,
+		FromStatus: &fromStatus,
+		Status:     &status,
+		Detail:     &detail,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to pass the gate of stage %d", gate.StageID)
+	}
+	return nil
+}
+
+// stageGateAction is the action to take on a gate after evaluating the tasks of its stage.
+type stageGateAction int
+
+const (
+	// stageGateWait keeps the gate pending.
+	stageGateWait stageGateAction = iota
+	// stageGateFail fails the gate.
+	stageGateFail
+	// stageGateSkipFailed skips the failed tasks within the maximum failure percentage.
+	stageGateSkipFailed
+	// stageGateCheckHealth runs the health check, and passes the gate if it succeeds.
+	stageGateCheckHealth
+)
+
+// stageGateEvaluation is the result of evaluating the tasks of a gated stage.
+type stageGateEvaluation struct {
+	action stageGateAction
+	// detail is the reason of failing the gate or skipping the failed tasks.
+	detail string
+	// failedTasks are the tasks to skip.
+	failedTasks []*store.TaskMessage
+}
+
+// evaluateStageGate decides the action on the gate by the tasks of the stage, see checkStageGate for the steps.
+func evaluateStageGate(tasks []*store.TaskMessage, config *store.DeploymentGate, now time.Time) *stageGateEvaluation {
+	// The failure percentage is counted by databases, since a database may have several tasks.
+	databaseFailed := make(map[int]bool)
+	var failedTasks []*store.TaskMessage
+	var finishedTs int64
+	for _, task := range tasks {
+		if !terminatedTaskStatus[task.Status] {
+			return &stageGateEvaluation{action: stageGateWait}
+		}
+		key := -task.ID
+		if task.DatabaseID != nil {
+			key = *task.DatabaseID
+		}
+		if task.Status == api.TaskFailed {
+			failedTasks = append(failedTasks, task)
+			databaseFailed[key] = true
+		} else if _, ok := databaseFailed[key]; !ok {
+			databaseFailed[key] = false
+		}
+		if task.UpdatedTs > finishedTs {
+			finishedTs = task.UpdatedTs
+		}
+	}
+
+	if len(failedTasks) > 0 {
+		maxFailurePercent := config.MaxFailurePercent
+		if maxFailurePercent == nil {
+			return &stageGateEvaluation{action: stageGateWait}
+		}
+		failedCount := 0
+		for _, failed := range databaseFailed {
+			if failed {
+				failedCount++
+			}
+		}
+		failurePercent := float64(failedCount) * 100 / float64(len(databaseFailed))
+		if failurePercent > *maxFailurePercent {
+			return &stageGateEvaluation{
+				action: stageGateFail,
+				detail: fmt.Sprintf("%d of %d databases failed (%.1f%%), exceeding the maximum failure percentage %.1f%%", failedCount, len(databaseFailed), failurePercent, *maxFailurePercent),
+			}
+		}
+		return &stageGateEvaluation{
+			action:      stageGateSkipFailed,
+			detail:      fmt.Sprintf("Skipped by the stage gate, %d of %d databases failed (%.1f%%) within the maximum failure percentage %.1f%%.", failedCount, len(databaseFailed), failurePercent, *maxFailurePercent),
+			failedTasks: failedTasks,
+		}
+	}
+
+	for _, task := range tasks {
+		// The canceled tasks block the rollout until they are retried or skipped by hand.
+		if task.Status != api.TaskDone {
+			return &stageGateEvaluation{action: stageGateWait}
+		}
+	}
+	if now.Before(time.Unix(finishedTs+config.BakeTimeSeconds, 0)) {
+		return &stageGateEvaluation{action: stageGateWait}
+	}
+	return &stageGateEvaluation{action: stageGateCheckHealth}
+}
+
+// failStageGate marks the gate as failed, which pauses the later stages, and creates a stage pause activity.
+func (s *Scheduler) failStageGate(ctx context.Context, issue *store.IssueMessage, gate *store.StageGateMessage, detail string) error {
+	status, fromStatus := api.StageGateFailed, api.StageGatePending
+	updated, err := s.store.UpdateStageGate(ctx, &store.UpdateStageGateMessage{
+		StageID:    gate.StageID,
+		UpdaterID:  api.SystemBotID,
+		FromStatus: &fromStatus,
+		Status:     &status,
+		Detail:     &detail,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to fail the gate of stage %d", gate.StageID)
+	}
+	// The gate has been resumed by hand in the meantime.
+	if updated == nil {
+		return nil
+	}
+
+	stages, err := s.store.ListStageV2(ctx, gate.PipelineID)
+	if err != nil {
+		return err
+	}
+	var stageName string
+	for _, stage := range stages {
+		if stage.ID == gate.StageID {
+			stageName = stage.Name
+		}
+	}
+	bytes, err := json.Marshal(api.ActivityPipelineStageStatusUpdatePayload{
+		StageID:               gate.StageID,
+		StageStatusUpdateType: api.StageStatusUpdateTypePause,
+		IssueName:             issue.Title,
+		StageName:             stageName,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create ActivityPipelineStageStatusUpdate activity")
+	}
+	if _, err := s.activityManager.CreateActivity(ctx, &api.ActivityCreate{
+		CreatorID:   api.SystemBotID,
+		ContainerID: issue.PipelineUID,
+		Type:        api.ActivityPipelineStageStatusUpdate,
+		Level:       api.ActivityWarn,
+		Payload:     string(bytes),
+		Comment:     detail,
+	}, &activity.Metadata{Issue: issue}); err != nil {
+		return errors.Wrap(err, "failed to create ActivityPipelineStageStatusUpdate activity")
+	}
+	return nil
+}
+
+// checkStageHealth runs the health check against the databases of the stage, or the metric endpoint.
+// It returns an error if the check fails or any value exceeds the maximum.
+func (s *Scheduler) checkStageHealth(ctx context.Context, tasks []*store.TaskMessage, check *store.DeploymentHealthCheck) error {
+	if check.URL != "" {
+		allowlist, err := s.getStageGateMetricAllowlist(ctx)
+		if err != nil {
+			return err
+		}
+		value, err := getMetricValue(ctx, check.URL, allowlist)
+		if err != nil {
+			return err
+		}
+		if value > check.MaxValue {
+			return errors.Errorf("metric endpoint exceeded the maximum %v", check.MaxValue)
+		}
+		return nil
+	}
+
+	databaseChecked := make(map[int]bool)
+	for _, task := range tasks {
+		if task.DatabaseID == nil || databaseChecked[*task.DatabaseID] {
+			continue
+		}
+		databaseChecked[*task.DatabaseID] = true
+		database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get database %d", *task.DatabaseID)
+		}
+		if database == nil {
+			return errors.Errorf("database %d not found", *task.DatabaseID)
+		}
+		instance, err := s.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get instance %d", task.InstanceID)
+		}
+		if instance == nil {
+			return errors.Errorf("instance %d not found", task.InstanceID)
+		}
+		value, err := s.getDatabaseHealthValue(ctx, instance, database.DatabaseName, check.Statement)
+		if err != nil {
+			return errors.Wrapf(err, "database %q", database.DatabaseName)
+		}
+		// The value isn't published, since the gate detail and the activity are visible to all project members.
+		if value > check.MaxValue {
+			return errors.Errorf("database %q exceeded the maximum %v", database.DatabaseName, check.MaxValue)
+		}
+	}
+	return nil
+}
+
+// getDatabaseHealthValue runs the read-only statement against the database, and returns the first column of the first row as a number.
+// The statement runs with the admin data source bypassing the masking and the row filters, so it's restricted to aggregate queries,
+// and the errors don't contain the returned values.
+func (s *Scheduler) getDatabaseHealthValue(ctx context.Context, instance *store.InstanceMessage, databaseName string, statement string) (float64, error) {
+	if err := parser.ValidateAggregateQuery(parser.EngineType(instance.Engine), statement); err != nil {
+		return 0, errors.Wrapf(err, "health check statement should be a query of aggregate functions")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, stageGateHealthCheckTimeout)
+	defer cancel()
+
+	driver, err := s.dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, databaseName)
+	if err != nil {
+		return 0, err
+	}
+	defer driver.Close(ctx)
+	sqlDB := driver.GetDB()
+	if sqlDB == nil {
+		return 0, errors.Errorf("health check statement is not supported for %s", instance.Engine)
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	result, err := driver.QueryConn(ctx, conn, statement, &db.QueryContext{
+		Limit:           1,
+		ReadOnly:        true,
+		CurrentDatabase: databaseName,
+	})
+	if err != nil {
+		// The database error may contain the values, such as a failed conversion.
+		log.Warn("Failed to run health check statement",
+			zap.String("instance", instance.ResourceID),
+			zap.String("database", databaseName),
+			zap.Error(err),
+		)
+		return 0, errors.New("failed to run health check statement")
+	}
+	// The query result is [columnNames, columnTypeNames, rows, sensitiveMasks].
+	if len(result) < 3 {
+		return 0, errors.New("unexpected query result")
+	}
+	rows, ok := result[2].([]any)
+	if !ok || len(rows) == 0 {
+		return 0, errors.New("statement returned no rows")
+	}
+	row, ok := rows[0].([]any)
+	if !ok || len(row) == 0 || row[0] == nil {
+		return 0, errors.New("statement returned no value")
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(fmt.Sprint(row[0])), 64)
+	if err != nil {
+		return 0, errors.New("statement returned a value which is not a number")
+	}
+	return value, nil
+}
+
+// stageGateMetadataIPs are the cloud metadata service addresses, which are never allowed.
+var stageGateMetadataIPs = []net.IP{
+	net.ParseIP("169.254.169.254"),
+	net.ParseIP("fd00:ec2::254"),
+	net.ParseIP("100.100.100.200"),
+}
+
+// getStageGateMetricAllowlist returns the private address ranges allowed for the metric endpoints by the workspace.
+func (s *Scheduler) getStageGateMetricAllowlist(ctx context.Context) ([]*net.IPNet, error) {
+	settingName := api.SettingStageGateMetricAllowlist
+	setting, err := s.store.GetSettingV2(ctx, &store.FindSettingMessage{Name: &settingName})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get setting %q", settingName)
+	}
+	if setting == nil {
+		return nil, nil
+	}
+	return api.ParseStageGateMetricAllowlist(setting.Value)
+}
+
+// newStageGateMetricClient creates the client getting the metric endpoints. It refuses to connect to the internal addresses,
+// such as the cloud metadata service, after resolving the host names, which also covers the redirects.
+// The private addresses in the allowlist are allowed.
+func newStageGateMetricClient(allowlist []*net.IPNet) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: (&net.Dialer{
+				Timeout: stageGateHealthCheckTimeout,
+				Control: func(_, address string, _ syscall.RawConn) error {
+					host, _, err := net.SplitHostPort(address)
+					if err != nil {
+						return err
+					}
+					if ip := net.ParseIP(host); ip == nil || !isMetricIPAllowed(ip, allowlist) {
+						return errors.Errorf("metric endpoint address %q is not allowed", host)
+					}
+					return nil
+				},
+			}).DialContext,
+			TLSHandshakeTimeout: stageGateHealthCheckTimeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return errors.New("metric endpoint redirected too many times")
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return errors.Errorf("metric endpoint redirected to unsupported scheme %q", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+// isMetricIPAllowed returns false if the IP is an internal address, unless it's a private address in the allowlist.
+// The loopback, link-local, multicast, unspecified and cloud metadata addresses are never allowed.
+func isMetricIPAllowed(ip net.IP, allowlist []*net.IPNet) bool {
+	for _, metadataIP := range stageGateMetadataIPs {
+		if ip.Equal(metadataIP) {
+			return false
+		}
+	}
+	if ip.IsPrivate() {
+		for _, ipNet := range allowlist {
+			if ipNet.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	return !isInternalIP(ip)
+}
+
+// isInternalIP returns true if the IP is a loopback, private, link-local, multicast or unspecified address.
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()
+}
+
+// getMetricValue gets the metric endpoint, which should respond with a number in the body.
+func getMetricValue(ctx context.Context, url string, allowlist []*net.IPNet) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, stageGateHealthCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := newStageGateMetricClient(allowlist).Do(req)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get metric endpoint")
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, stageGateMetricResponseLimit))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to read metric endpoint response")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, errors.Errorf("metric endpoint responded with status %d", resp.StatusCode)
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(body)), 64)
+	if err != nil {
+		return 0, errors.New("metric endpoint responded with a body which is not a number")
+	}
+	return value, nil
+}
+
+// isTaskGated returns true if the gate of any earlier stage in the pipeline hasn't passed.
+func (s *Scheduler) isTaskGated(ctx context.Context, task *store.TaskMessage) (bool, error) {
+	gates, err := s.store.ListStageGates(ctx, &store.FindStageGateMessage{PipelineID: &task.PipelineID})
+	if err != nil {
+		return true, errors.Wrapf(err, "failed to list stage gates of pipeline %d", task.PipelineID)
+	}
+	return isGatedByStageGates(gates, task.StageID), nil
+}
+
+// isGatedByStageGates returns true if the gate of any stage before the stage hasn't passed.
+func isGatedByStageGates(gates []*store.StageGateMessage, stageID int) bool {
+	for _, gate := range gates {
+		if gate.StageID < stageID && gate.Status != api.StageGatePassed {
+			return true
+		}
+	}
+	return false
+}
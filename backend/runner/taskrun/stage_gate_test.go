@@ -0,0 +1,226 @@
+package taskrun
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func TestGetMetricValueRejectsInternalAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("secret"))
+	}))
+	defer server.Close()
+
+	_, err := getMetricValue(context.Background(), server.URL, nil)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "secret")
+
+	// The loopback address cannot be allowed.
+	allowlist, err := api.ParseStageGateMetricAllowlist("10.0.0.0/8")
+	require.NoError(t, err)
+	_, err = getMetricValue(context.Background(), server.URL, allowlist)
+	require.Error(t, err)
+}
+
+func TestIsInternalIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "127.0.0.1", want: true},
+		{ip: "::1", want: true},
+		{ip: "10.1.2.3", want: true},
+		{ip: "172.16.0.1", want: true},
+		{ip: "192.168.1.1", want: true},
+		{ip: "169.254.169.254", want: true},
+		{ip: "fe80::1", want: true},
+		{ip: "fd00::1", want: true},
+		{ip: "0.0.0.0", want: true},
+		{ip: "8.8.8.8", want: false},
+		{ip: "2001:4860:4860::8888", want: false},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, isInternalIP(net.ParseIP(test.ip)), test.ip)
+	}
+}
+
+func TestIsMetricIPAllowed(t *testing.T) {
+	allowlist, err := api.ParseStageGateMetricAllowlist("10.0.0.0/8,fd00::/8")
+	require.NoError(t, err)
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "8.8.8.8", want: true},
+		{ip: "10.1.2.3", want: true},
+		{ip: "fd00::1", want: true},
+		{ip: "192.168.1.1", want: false},
+		{ip: "127.0.0.1", want: false},
+		{ip: "169.254.169.254", want: false},
+		{ip: "fd00:ec2::254", want: false},
+		{ip: "100.100.100.200", want: false},
+		{ip: "0.0.0.0", want: false},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, isMetricIPAllowed(net.ParseIP(test.ip), allowlist), test.ip)
+	}
+	require.False(t, isMetricIPAllowed(net.ParseIP("10.1.2.3"), nil))
+}
+
+func TestEvaluateStageGate(t *testing.T) {
+	databaseA, databaseB, databaseC, databaseD := 1, 2, 3, 4
+	maxFailurePercent := 50.0
+	now := time.Unix(10000, 0)
+	gate := &store.DeploymentGate{BakeTimeSeconds: 600, MaxFailurePercent: &maxFailurePercent}
+	newTask := func(id int, databaseID *int, status api.TaskStatus, updatedTs int64) *store.TaskMessage {
+		return &store.TaskMessage{ID: id, DatabaseID: databaseID, Status: status, UpdatedTs: updatedTs}
+	}
+
+	tests := []struct {
+		name        string
+		tasks       []*store.TaskMessage
+		config      *store.DeploymentGate
+		want        stageGateAction
+		wantSkipped []int
+	}{
+		{
+			name: "running",
+			tasks: []*store.TaskMessage{
+				newTask(1, &databaseA, api.TaskDone, 100),
+				newTask(2, &databaseB, api.TaskRunning, 100),
+			},
+			config: gate,
+			want:   stageGateWait,
+		},
+		{
+			name: "baking",
+			tasks: []*store.TaskMessage{
+				newTask(1, &databaseA, api.TaskDone, 9000),
+				newTask(2, &databaseB, api.TaskDone, 9500),
+			},
+			config: gate,
+			want:   stageGateWait,
+		},
+		{
+			name: "baked",
+			tasks: []*store.TaskMessage{
+				newTask(1, &databaseA, api.TaskDone, 9000),
+				newTask(2, &databaseB, api.TaskDone, 9400),
+			},
+			config: gate,
+			want:   stageGateCheckHealth,
+		},
+		{
+			name: "canceled",
+			tasks: []*store.TaskMessage{
+				newTask(1, &databaseA, api.TaskDone, 100),
+				newTask(2, &databaseB, api.TaskCanceled, 100),
+			},
+			config: gate,
+			want:   stageGateWait,
+		},
+		{
+			name: "failed without the maximum failure percentage",
+			tasks: []*store.TaskMessage{
+				newTask(1, &databaseA, api.TaskDone, 100),
+				newTask(2, &databaseB, api.TaskFailed, 100),
+			},
+			config: &store.DeploymentGate{BakeTimeSeconds: 600},
+			want:   stageGateWait,
+		},
+		{
+			name: "failed within the maximum failure percentage",
+			tasks: []*store.TaskMessage{
+				newTask(1, &databaseA, api.TaskDone, 100),
+				newTask(2, &databaseB, api.TaskFailed, 100),
+				newTask(3, &databaseC, api.TaskDone, 100),
+				newTask(4, &databaseD, api.TaskDone, 100),
+			},
+			config:      gate,
+			want:        stageGateSkipFailed,
+			wantSkipped: []int{2},
+		},
+		{
+			// The failure percentage is counted by databases, 2 of 4 databases failed.
+			name: "failed tasks of the same database",
+			tasks: []*store.TaskMessage{
+				newTask(1, &databaseA, api.TaskFailed, 100),
+				newTask(2, &databaseA, api.TaskFailed, 100),
+				newTask(3, &databaseB, api.TaskFailed, 100),
+				newTask(4, &databaseC, api.TaskDone, 100),
+				newTask(5, &databaseD, api.TaskDone, 100),
+			},
+			config:      gate,
+			want:        stageGateSkipFailed,
+			wantSkipped: []int{1, 2, 3},
+		},
+		{
+			name: "failed exceeding the maximum failure percentage",
+			tasks: []*store.TaskMessage{
+				newTask(1, &databaseA, api.TaskFailed, 100),
+				newTask(2, &databaseB, api.TaskFailed, 100),
+				newTask(3, &databaseC, api.TaskDone, 100),
+			},
+			config: gate,
+			want:   stageGateFail,
+		},
+		{
+			// The tasks without databases are counted by themselves.
+			name: "failed tasks without databases",
+			tasks: []*store.TaskMessage{
+				newTask(1, nil, api.TaskFailed, 100),
+				newTask(2, nil, api.TaskDone, 100),
+			},
+			config:      gate,
+			want:        stageGateSkipFailed,
+			wantSkipped: []int{1},
+		},
+	}
+
+	for _, test := range tests {
+		evaluation := evaluateStageGate(test.tasks, test.config, now)
+		require.Equal(t, test.want, evaluation.action, test.name)
+		var skipped []int
+		for _, task := range evaluation.failedTasks {
+			skipped = append(skipped, task.ID)
+		}
+		require.Equal(t, test.wantSkipped, skipped, test.name)
+		if test.want == stageGateFail || test.want == stageGateSkipFailed {
+			require.NotEmpty(t, evaluation.detail, test.name)
+		}
+	}
+}
+
+func TestIsGatedByStageGates(t *testing.T) {
+	gates := []*store.StageGateMessage{
+		{StageID: 101, Status: api.StageGatePassed},
+		{StageID: 102, Status: api.StageGatePending},
+		{StageID: 103, Status: api.StageGateFailed},
+	}
+	tests := []struct {
+		stageID int
+		want    bool
+	}{
+		{stageID: 101, want: false},
+		{stageID: 102, want: false},
+		{stageID: 103, want: true},
+		{stageID: 104, want: true},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, isGatedByStageGates(gates, test.stageID), test.stageID)
+	}
+	require.False(t, isGatedByStageGates([]*store.StageGateMessage{{StageID: 101, Status: api.StageGatePassed}}, 102))
+	require.False(t, isGatedByStageGates(nil, 102))
+}
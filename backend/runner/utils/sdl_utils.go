@@ -40,13 +40,21 @@ func ComputeDatabaseSchemaDiff(ctx context.Context, instance *store.InstanceMess
 		engine = parser.Postgres
 	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
 		engine = parser.MySQL
+	case db.Oracle:
+		engine = parser.Oracle
+	case db.MSSQL:
+		engine = parser.MSSQL
 	default:
 		return "", errors.Errorf("unsupported database engine %q", instance.Engine)
 	}
 
-	sdlFormat, err := transform.SchemaTransform(engine, schema.String())
-	if err != nil {
-		return "", errors.Wrapf(err, "failed to transform SDL format")
+	sdlFormat := schema.String()
+	// The Oracle and SQL Server dumps are in SDL format already.
+	if engine != parser.Oracle && engine != parser.MSSQL {
+		sdlFormat, err = transform.SchemaTransform(engine, sdlFormat)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to transform SDL format")
+		}
 	}
 	diff, err := differ.SchemaDiff(engine, sdlFormat, newSchema)
 	if err != nil {
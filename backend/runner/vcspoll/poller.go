@@ -0,0 +1,204 @@
+// Package vcspoll is a runner that polls generic Git repositories for new commits.
+package vcspoll
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/vcs"
+	"github.com/bytebase/bytebase/backend/plugin/vcs/git"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	pollInterval = 1 * time.Minute
+)
+
+// PushEventProcessor processes the push event for the repositories, the same as receiving a push webhook.
+type PushEventProcessor func(ctx context.Context, repositoryList []*api.Repository, pushEvent vcs.PushEvent) ([]string, error)
+
+// NewPoller creates a new poller.
+func NewPoller(store *store.Store, processPushEvent PushEventProcessor) *Poller {
+	return &Poller{
+		store:            store,
+		processPushEvent: processPushEvent,
+		branchHeads:      make(map[int]map[string]string),
+	}
+}
+
+// Poller is the runner that detects pushes to generic Git repositories, which have no webhooks.
+type Poller struct {
+	store            *store.Store
+	processPushEvent PushEventProcessor
+
+	// branchHeads is the last seen commit ID of each branch by repository ID.
+	// It's kept in memory, so the pushes while Bytebase is down are not processed.
+	branchHeads map[int]map[string]string
+}
+
+// Run will run the poller.
+func (p *Poller) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	log.Debug(fmt.Sprintf("VCS poller started and will run every %v", pollInterval))
+	for {
+		select {
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err, ok := r.(error)
+						if !ok {
+							err = errors.Errorf("%v", r)
+						}
+						log.Error("VCS poller PANIC RECOVER", zap.Error(err), zap.Stack("panic-stack"))
+						debug.PrintStack()
+					}
+				}()
+				p.poll(ctx)
+			}()
+		case <-ctx.Done(): // if cancel() execute
+			return
+		}
+	}
+}
+
+func (p *Poller) poll(ctx context.Context) {
+	repositoryList, err := p.store.FindRepository(ctx, &api.RepositoryFind{})
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.Error("Failed to find repositories to poll", zap.Error(err))
+		}
+		return
+	}
+
+	polled := make(map[int]bool)
+	for _, repo := range repositoryList {
+		if repo.VCS == nil || repo.VCS.Type != vcs.Git {
+			continue
+		}
+		if repo.Project != nil && repo.Project.RowStatus == api.Archived {
+			continue
+		}
+		polled[repo.ID] = true
+		if err := p.pollRepository(ctx, repo); err != nil {
+			log.Error("Failed to poll repository",
+				zap.Int("repository_id", repo.ID),
+				zap.String("repository_url", repo.WebURL),
+				zap.Error(err),
+			)
+		}
+	}
+	// Forget the repositories that have been deleted.
+	for repoID := range p.branchHeads {
+		if !polled[repoID] {
+			delete(p.branchHeads, repoID)
+		}
+	}
+}
+
+func (p *Poller) pollRepository(ctx context.Context, repo *api.Repository) error {
+	provider, ok := vcs.Get(vcs.Git, vcs.ProviderConfig{}).(*git.Provider)
+	if !ok {
+		return errors.Errorf("unexpected provider for VCS type %s", vcs.Git)
+	}
+	oauthCtx := common.OauthContext{
+		ClientID:    repo.VCS.ApplicationID,
+		AccessToken: repo.AccessToken,
+	}
+	heads, err := provider.ListBranchHeads(ctx, oauthCtx, repo.VCS.InstanceURL, repo.ExternalID)
+	if err != nil {
+		return err
+	}
+
+	lastHeads, ok := p.branchHeads[repo.ID]
+	if !ok {
+		// The first observation only records the branch heads as there is nothing to compare with.
+		p.branchHeads[repo.ID] = heads
+		return nil
+	}
+
+	for branch, after := range heads {
+		before, ok := lastHeads[branch]
+		if ok && before == after {
+			continue
+		}
+		if !ok {
+			// The branch is newly created.
+			before = ""
+		}
+		matched, err := filepath.Match(repo.BranchFilter, branch)
+		if err != nil {
+			return errors.Wrapf(err, "failed to match branch filter")
+		}
+		if !matched {
+			lastHeads[branch] = after
+			continue
+		}
+
+		commitList, err := provider.ListCommits(ctx, oauthCtx, repo.VCS.InstanceURL, repo.ExternalID, before, after)
+		if err != nil {
+			// Keep the last seen commit to retry in the next round.
+			log.Error("Failed to list new commits of branch",
+				zap.Int("repository_id", repo.ID),
+				zap.String("branch", branch),
+				zap.Error(err),
+			)
+			continue
+		}
+		lastHeads[branch] = after
+
+		var nonBytebaseCommitList []vcs.Commit
+		for _, commit := range commitList {
+			if commit.AuthorName == vcs.BytebaseAuthorName && commit.AuthorEmail == vcs.BytebaseAuthorEmail {
+				continue
+			}
+			nonBytebaseCommitList = append(nonBytebaseCommitList, commit)
+		}
+		if len(nonBytebaseCommitList) == 0 {
+			continue
+		}
+
+		if before == "" {
+			// The before commit ID is all zeros when the branch is just created, same as the webhook push event.
+			before = strings.Repeat("0", 40)
+		}
+		pushEvent := vcs.PushEvent{
+			VCSType:            vcs.Git,
+			Ref:                "refs/heads/" + branch,
+			Before:             before,
+			After:              after,
+			RepositoryID:       repo.ExternalID,
+			RepositoryURL:      repo.WebURL,
+			RepositoryFullPath: repo.FullPath,
+			AuthorName:         nonBytebaseCommitList[len(nonBytebaseCommitList)-1].AuthorName,
+			CommitList:         nonBytebaseCommitList,
+		}
+		if _, err := p.processPushEvent(ctx, []*api.Repository{repo}, pushEvent); err != nil {
+			log.Error("Failed to process push event of branch",
+				zap.Int("repository_id", repo.ID),
+				zap.String("branch", branch),
+				zap.String("after", after),
+				zap.Error(err),
+			)
+		}
+	}
+	for branch := range lastHeads {
+		if _, ok := heads[branch]; !ok {
+			delete(lastHeads, branch)
+		}
+	}
+	return nil
+}
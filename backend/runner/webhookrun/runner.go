@@ -0,0 +1,218 @@
+// Package webhookrun is the runner delivering the project webhooks.
+package webhookrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/webhook"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	deliveryInterval = 5 * time.Second
+	// deliveryBatchSize is the max number of deliveries attempted in one round.
+	deliveryBatchSize = 100
+	// maxAttempts is the max number of attempts of a delivery before it's marked as failed.
+	maxAttempts = 6
+	// maxFailureCount is the number of consecutive failed deliveries after which the webhook is disabled.
+	maxFailureCount = 10
+	// The backoff before the retry is doubled after each failed attempt, from 30 seconds to 1 hour at most.
+	minBackoff = 30 * time.Second
+	maxBackoff = 1 * time.Hour
+)
+
+// NewRunner creates a new webhook delivery runner.
+func NewRunner(store *store.Store) *Runner {
+	return &Runner{
+		store: store,
+	}
+}
+
+// Runner is the runner delivering the queued project webhook deliveries.
+type Runner struct {
+	store *store.Store
+}
+
+// Run will run the webhook delivery runner.
+func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(deliveryInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	log.Debug(fmt.Sprintf("Webhook delivery runner started and will run every %v", deliveryInterval))
+	for {
+		select {
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err, ok := r.(error)
+						if !ok {
+							err = errors.Errorf("%v", r)
+						}
+						log.Error("Webhook delivery runner PANIC RECOVER", zap.Error(err), zap.Stack("panic-stack"))
+						debug.PrintStack()
+					}
+				}()
+				r.deliverPending(ctx)
+			}()
+		case <-ctx.Done(): // if cancel() execute
+			return
+		}
+	}
+}
+
+func (r *Runner) deliverPending(ctx context.Context) {
+	status := api.ProjectWebhookDeliveryPending
+	now := time.Now().Unix()
+	limit := deliveryBatchSize
+	deliveryList, err := r.store.ListProjectWebhookDelivery(ctx, &store.FindProjectWebhookDeliveryMessage{
+		Status:               &status,
+		NextAttemptTsBefore:  &now,
+		OrderByNextAttemptTs: true,
+		Limit:                &limit,
+	})
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.Error("Failed to find pending webhook deliveries", zap.Error(err))
+		}
+		return
+	}
+	for _, delivery := range deliveryList {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := r.deliver(ctx, delivery); err != nil {
+			log.Error("Failed to deliver webhook",
+				zap.Int("delivery_id", delivery.ID),
+				zap.Int("webhook_id", delivery.ProjectWebhookID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func (r *Runner) deliver(ctx context.Context, delivery *store.ProjectWebhookDeliveryMessage) error {
+	hook, err := r.store.GetProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{ID: &delivery.ProjectWebhookID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get project webhook %d", delivery.ProjectWebhookID)
+	}
+	if hook == nil || !hook.Enabled {
+		failed := api.ProjectWebhookDeliveryFailed
+		lastError := "webhook is disabled"
+		if hook == nil {
+			lastError = "webhook not found"
+		}
+		_, err := r.store.UpdateProjectWebhookDelivery(ctx, &store.UpdateProjectWebhookDeliveryMessage{
+			ID:        delivery.ID,
+			Status:    &failed,
+			LastError: &lastError,
+		})
+		return err
+	}
+
+	var webhookCtx webhook.Context
+	if err := json.Unmarshal([]byte(delivery.Payload), &webhookCtx); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal payload of webhook delivery %d", delivery.ID)
+	}
+	webhookCtx.URL = hook.URL
+	webhookCtx.Secret = hook.Secret
+	webhookCtx.PayloadTemplate = hook.PayloadTemplate
+
+	attempt := delivery.Attempt + 1
+	update := &store.UpdateProjectWebhookDeliveryMessage{
+		ID:      delivery.ID,
+		Attempt: &attempt,
+	}
+	postErr := webhook.Post(hook.Type, webhookCtx)
+	if postErr == nil {
+		success := api.ProjectWebhookDeliverySuccess
+		lastError := ""
+		update.Status, update.LastError = &success, &lastError
+		if _, err := r.store.UpdateProjectWebhookDelivery(ctx, update); err != nil {
+			return err
+		}
+		if hook.FailureCount > 0 {
+			failureCount := 0
+			return r.updateWebhook(ctx, hook, &store.UpdateProjectWebhookMessage{FailureCount: &failureCount})
+		}
+		return nil
+	}
+
+	// The external webhook endpoint might be invalid which is out of our code control, so we just emit a warning.
+	log.Warn("Failed to post webhook event",
+		zap.String("webhook type", hook.Type),
+		zap.String("webhook name", hook.Title),
+		zap.String("activity type", webhookCtx.ActivityType),
+		zap.String("title", webhookCtx.Title),
+		zap.Int("attempt", attempt),
+		zap.Error(postErr))
+	lastError := postErr.Error()
+	update.LastError = &lastError
+	failed := api.ProjectWebhookDeliveryFailed
+	// The payload template error is on our side rather than the endpoint, retrying never helps,
+	// and it shouldn't count toward disabling the webhook either.
+	var templateErr *webhook.PayloadTemplateError
+	if errors.As(postErr, &templateErr) {
+		update.Status = &failed
+		_, err := r.store.UpdateProjectWebhookDelivery(ctx, update)
+		return err
+	}
+	if attempt < maxAttempts {
+		nextAttemptTs := time.Now().Add(getBackoff(attempt)).Unix()
+		update.NextAttemptTs = &nextAttemptTs
+		_, err := r.store.UpdateProjectWebhookDelivery(ctx, update)
+		return err
+	}
+
+	update.Status = &failed
+	if _, err := r.store.UpdateProjectWebhookDelivery(ctx, update); err != nil {
+		return err
+	}
+	failureCount := hook.FailureCount + 1
+	hookUpdate := &store.UpdateProjectWebhookMessage{FailureCount: &failureCount}
+	if failureCount >= maxFailureCount {
+		log.Warn("Disable webhook after too many consecutive failed deliveries",
+			zap.String("webhook type", hook.Type),
+			zap.String("webhook name", hook.Title),
+			zap.Int("failure count", failureCount))
+		enabled := false
+		hookUpdate.Enabled = &enabled
+	}
+	return r.updateWebhook(ctx, hook, hookUpdate)
+}
+
+func (r *Runner) updateWebhook(ctx context.Context, hook *store.ProjectWebhookMessage, update *store.UpdateProjectWebhookMessage) error {
+	project, err := r.store.GetProjectV2(ctx, &store.FindProjectMessage{UID: &hook.ProjectID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get project %d", hook.ProjectID)
+	}
+	if project == nil {
+		return errors.Errorf("project %d not found", hook.ProjectID)
+	}
+	if _, err := r.store.UpdateProjectWebhookV2(ctx, api.SystemBotID, project.UID, project.ResourceID, hook.ID, update); err != nil {
+		return errors.Wrapf(err, "failed to update project webhook %d", hook.ID)
+	}
+	return nil
+}
+
+// getBackoff returns the backoff before the next attempt after the given number of attempts.
+func getBackoff(attempt int) time.Duration {
+	backoff := minBackoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,17 @@
+package webhookrun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBackoff(t *testing.T) {
+	a := require.New(t)
+	a.Equal(30*time.Second, getBackoff(1))
+	a.Equal(1*time.Minute, getBackoff(2))
+	a.Equal(8*time.Minute, getBackoff(5))
+	a.Equal(1*time.Hour, getBackoff(8))
+	a.Equal(1*time.Hour, getBackoff(100))
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/jsonapi"
+	"github.com/labstack/echo/v4"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func (s *Server) registerAccessGrantRoutes(g *echo.Group) {
+	g.GET("/access-grant", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principalID := c.Get(getPrincipalIDContextKey()).(int)
+		role := c.Get(getRoleContextKey()).(api.Role)
+
+		find := &store.FindAccessGrantMessage{}
+		if principalIDStr := c.QueryParam("principal"); principalIDStr != "" {
+			id, err := strconv.Atoi(principalIDStr)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query parameter principal is not a number: %s", principalIDStr)).SetInternal(err)
+			}
+			find.PrincipalUID = &id
+		}
+		// Developers can only list their own grants.
+		if role != api.Owner && role != api.DBA {
+			find.PrincipalUID = &principalID
+		}
+		if databaseIDStr := c.QueryParam("database"); databaseIDStr != "" {
+			databaseID, err := strconv.Atoi(databaseIDStr)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query parameter database is not a number: %s", databaseIDStr)).SetInternal(err)
+			}
+			find.DatabaseUID = &databaseID
+		}
+		if issueIDStr := c.QueryParam("issue"); issueIDStr != "" {
+			issueID, err := strconv.Atoi(issueIDStr)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query parameter issue is not a number: %s", issueIDStr)).SetInternal(err)
+			}
+			find.IssueUID = &issueID
+		}
+		if status := c.QueryParam("status"); status != "" {
+			find.StatusList = []api.AccessGrantStatus{api.AccessGrantStatus(status)}
+		}
+
+		grants, err := s.store.ListAccessGrants(ctx, find)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch access grant list").SetInternal(err)
+		}
+		apiGrantList := []*api.AccessGrant{}
+		for _, grant := range grants {
+			apiGrantList = append(apiGrantList, grant.ToAPIAccessGrant())
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, apiGrantList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal access grant list response").SetInternal(err)
+		}
+		return nil
+	})
+
+	// The grant can be revoked before it expires by the grantee, workspace owners and DBAs.
+	g.PATCH("/access-grant/:grantID", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := strconv.Atoi(c.Param("grantID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("grantID"))).SetInternal(err)
+		}
+		principalID := c.Get(getPrincipalIDContextKey()).(int)
+		role := c.Get(getRoleContextKey()).(api.Role)
+
+		patch := &api.AccessGrantPatch{}
+		if err := jsonapi.UnmarshalPayload(c.Request().Body, patch); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed patch access grant request").SetInternal(err)
+		}
+		if patch.Status == nil || *patch.Status != api.AccessGrantRevoked {
+			return echo.NewHTTPError(http.StatusBadRequest, "Access grant can only be revoked")
+		}
+
+		grant, err := s.store.GetAccessGrant(ctx, &store.FindAccessGrantMessage{ID: &id})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch access grant ID: %v", id)).SetInternal(err)
+		}
+		if grant == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Access grant ID not found: %d", id))
+		}
+		if role != api.Owner && role != api.DBA && grant.PrincipalUID != principalID {
+			return echo.NewHTTPError(http.StatusForbidden, "Only the grantee, workspace owners and DBAs can revoke the access grant")
+		}
+		if grant.Status != api.AccessGrantPending && grant.Status != api.AccessGrantActive {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Cannot revoke the %s access grant", grant.Status))
+		}
+
+		grant, err = s.store.UpdateAccessGrant(ctx, &store.UpdateAccessGrantMessage{ID: id, Status: patch.Status})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to revoke access grant ID: %v", id)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, grant.ToAPIAccessGrant()); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal access grant ID response: %v", id)).SetInternal(err)
+		}
+		return nil
+	})
+}
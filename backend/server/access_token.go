@@ -0,0 +1,69 @@
+package server
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+type accessTokenRoute struct {
+	method string
+	path   *regexp.Regexp
+}
+
+// accessTokenScopeRoutes are the routes allowed by the scopes in addition to the GET routes.
+var accessTokenScopeRoutes = map[api.AccessTokenScope][]accessTokenRoute{
+	api.AccessTokenScopeReadOnly: {},
+	api.AccessTokenScopeIssueCreate: {
+		{method: http.MethodPost, path: regexp.MustCompile(`^/issue$`)},
+		{method: http.MethodPost, path: regexp.MustCompile(`^/sheet$`)},
+	},
+	api.AccessTokenScopeSQLQuery: {
+		{method: http.MethodPost, path: regexp.MustCompile(`^/sql/execute$`)},
+		{method: http.MethodPost, path: regexp.MustCompile(`^/sql/explain$`)},
+		{method: http.MethodPost, path: regexp.MustCompile(`^/sql/export$`)},
+		{method: http.MethodDelete, path: regexp.MustCompile(`^/sql/query/[^/]+$`)},
+	},
+}
+
+// isRouteAllowedByScopes returns whether the route can be requested with a personal access token of the scopes.
+func isRouteAllowedByScopes(method, path string, scopes []api.AccessTokenScope) bool {
+	for _, scope := range scopes {
+		if scope == api.AccessTokenScopeAdmin {
+			return true
+		}
+		routes, ok := accessTokenScopeRoutes[scope]
+		if !ok {
+			continue
+		}
+		if method == http.MethodGet {
+			return true
+		}
+		for _, route := range routes {
+			if route.method == method && route.path.MatchString(path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getAccessTokenProjectUID returns the project that the personal access token authenticating the request is
+// restricted to. It returns nil if the request isn't authenticated by a restricted personal access token.
+func getAccessTokenProjectUID(c echo.Context) *int {
+	accessToken, ok := c.Get(getAccessTokenContextKey()).(*store.AccessTokenMessage)
+	if !ok {
+		return nil
+	}
+	return accessToken.ProjectUID
+}
+
+// isProjectAllowedByAccessToken returns whether the project can be accessed with a personal access token restricted
+// to the project if it's not nil.
+func isProjectAllowedByAccessToken(restrictedProjectUID *int, projectUID int) bool {
+	return restrictedProjectUID == nil || *restrictedProjectUID == projectUID
+}
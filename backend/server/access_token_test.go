@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func TestIsRouteAllowedByScopes(t *testing.T) {
+	type test struct {
+		desc   string
+		method string
+		path   string
+		scopes []api.AccessTokenScope
+		want   bool
+	}
+
+	tests := []test{
+		{
+			desc:   "Read with read-only scope",
+			method: "GET",
+			path:   "/database/101",
+			scopes: []api.AccessTokenScope{api.AccessTokenScopeReadOnly},
+			want:   true,
+		},
+		{
+			desc:   "Create issue with read-only scope",
+			method: "POST",
+			path:   "/issue",
+			scopes: []api.AccessTokenScope{api.AccessTokenScopeReadOnly},
+			want:   false,
+		},
+		{
+			desc:   "Create issue with issue-create scope",
+			method: "POST",
+			path:   "/issue",
+			scopes: []api.AccessTokenScope{api.AccessTokenScopeIssueCreate},
+			want:   true,
+		},
+		{
+			desc:   "Update issue status with issue-create scope",
+			method: "PATCH",
+			path:   "/issue/101/status",
+			scopes: []api.AccessTokenScope{api.AccessTokenScopeIssueCreate},
+			want:   false,
+		},
+		{
+			desc:   "Query with issue-create scope",
+			method: "POST",
+			path:   "/sql/execute",
+			scopes: []api.AccessTokenScope{api.AccessTokenScopeIssueCreate},
+			want:   false,
+		},
+		{
+			desc:   "Query with issue-create and sql-query scopes",
+			method: "POST",
+			path:   "/sql/execute",
+			scopes: []api.AccessTokenScope{api.AccessTokenScopeIssueCreate, api.AccessTokenScopeSQLQuery},
+			want:   true,
+		},
+		{
+			desc:   "Execute in admin mode with sql-query scope",
+			method: "POST",
+			path:   "/sql/execute/admin",
+			scopes: []api.AccessTokenScope{api.AccessTokenScopeSQLQuery},
+			want:   false,
+		},
+		{
+			desc:   "Cancel query with sql-query scope",
+			method: "DELETE",
+			path:   "/sql/query/abc",
+			scopes: []api.AccessTokenScope{api.AccessTokenScopeSQLQuery},
+			want:   true,
+		},
+		{
+			desc:   "Delete project with admin scope",
+			method: "DELETE",
+			path:   "/project/101",
+			scopes: []api.AccessTokenScope{api.AccessTokenScopeAdmin},
+			want:   true,
+		},
+		{
+			desc:   "Read with unknown scope",
+			method: "GET",
+			path:   "/database/101",
+			scopes: []api.AccessTokenScope{"UNKNOWN"},
+			want:   false,
+		},
+		{
+			desc:   "Read without scopes",
+			method: "GET",
+			path:   "/database/101",
+			scopes: nil,
+			want:   false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.want, isRouteAllowedByScopes(tc.method, tc.path, tc.scopes))
+		})
+	}
+}
+
+func TestAccessTokenProjectRestriction(t *testing.T) {
+	a := assert.New(t)
+	projectUID := 101
+	newContext := func(accessToken *store.AccessTokenMessage) echo.Context {
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/sql/execute", nil), httptest.NewRecorder())
+		if accessToken != nil {
+			c.Set(getAccessTokenContextKey(), accessToken)
+		}
+		return c
+	}
+
+	// Not authenticated by a personal access token.
+	restricted := getAccessTokenProjectUID(newContext(nil))
+	a.Nil(restricted)
+	a.True(isProjectAllowedByAccessToken(restricted, 102))
+
+	// Authenticated by a personal access token without project restriction.
+	restricted = getAccessTokenProjectUID(newContext(&store.AccessTokenMessage{}))
+	a.Nil(restricted)
+	a.True(isProjectAllowedByAccessToken(restricted, 102))
+
+	// Authenticated by a personal access token restricted to a project.
+	restricted = getAccessTokenProjectUID(newContext(&store.AccessTokenMessage{ProjectUID: &projectUID}))
+	a.Equal(&projectUID, restricted)
+	a.True(isProjectAllowedByAccessToken(restricted, 101))
+	a.False(isProjectAllowedByAccessToken(restricted, 102))
+
+	// The restricted token must specify the database to query, otherwise it may reach the databases outside
+	// the project through the instance.
+	s := &Server{}
+	_, err := s.checkQueryAccess(context.Background(), 101, api.Developer, restricted, &store.InstanceMessage{Engine: db.Postgres}, "", "SELECT * FROM secret")
+	httpErr, ok := err.(*echo.HTTPError)
+	a.True(ok)
+	a.Equal(http.StatusForbidden, httpErr.Code)
+}
@@ -76,6 +76,14 @@ func aclMiddleware(s *Server, pathPrefix string, ce *casbin.Enforcer, next echo.
 		if !s.licenseService.IsFeatureEnabled(api.FeatureRBAC) {
 			role = api.Owner
 		}
+		workspaceRole := role
+		// The personal access token restricted to a project acts as a workspace developer,
+		// who only has the project roles in that project.
+		var restrictedProjectID *int
+		if accessToken, ok := c.Get(getAccessTokenContextKey()).(*store.AccessTokenMessage); ok && accessToken.ProjectUID != nil {
+			restrictedProjectID = accessToken.ProjectUID
+			role = api.Developer
+		}
 
 		// Performs the ACL check.
 		pass, err := ce.Enforce(string(role), path, method)
@@ -112,6 +120,14 @@ func aclMiddleware(s *Server, pathPrefix string, ce *casbin.Enforcer, next echo.
 		if role != api.Owner && role != api.DBA {
 			var aclErr *echo.HTTPError
 			projectRolesFinder := func(projectID int, principalID int) (map[common.ProjectRole]bool, error) {
+				if restrictedProjectID != nil {
+					if projectID != *restrictedProjectID {
+						return map[common.ProjectRole]bool{}, nil
+					}
+					if workspaceRole == api.Owner || workspaceRole == api.DBA {
+						return map[common.ProjectRole]bool{common.ProjectOwner: true}, nil
+					}
+				}
 				policy, err := s.store.GetProjectPolicy(ctx, &store.GetProjectPolicyMessage{UID: &projectID})
 				if err != nil {
 					return nil, err
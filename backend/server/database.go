@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/google/jsonapi"
 	"github.com/labstack/echo/v4"
@@ -19,6 +20,7 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/differ"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/edit"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/transform"
 	"github.com/bytebase/bytebase/backend/store"
@@ -212,9 +214,26 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database not found with ID %d", id))
 		}
 
-		dbSchema, err := s.store.GetDBSchema(ctx, id)
-		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get dbSchema for database %q", database.DatabaseName)).SetInternal(err)
+		var dbSchema *store.DBSchema
+		if at := c.QueryParam("at"); at != "" {
+			// Return the schema as of the timestamp from the schema snapshots.
+			ts, err := strconv.ParseInt(at, 10, 64)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Timestamp is not a number: %s", at)).SetInternal(err)
+			}
+			snapshot, err := s.store.GetDBSchemaSnapshot(ctx, &store.FindDBSchemaSnapshotMessage{DatabaseUID: &id, CreatedTsBefore: &ts, WithSchema: true})
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get schema snapshot for database %q", database.DatabaseName)).SetInternal(err)
+			}
+			if snapshot == nil {
+				return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Schema snapshot not found for database %q at %d", database.DatabaseName, ts))
+			}
+			dbSchema = snapshot.Schema
+		} else {
+			dbSchema, err = s.store.GetDBSchema(ctx, id)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get dbSchema for database %q", database.DatabaseName)).SetInternal(err)
+			}
 		}
 		if dbSchema == nil {
 			// TODO(d): make SyncDatabaseSchema return the updated database schema.
@@ -250,20 +269,21 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 			if err != nil {
 				return err
 			}
-			// We only support MySQL now.
-			var engineType parser.EngineType
+			// We only support MySQL, Oracle and SQL Server now.
+			var sdlSchema string
 			switch instance.Engine {
 			case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
-				engineType = parser.MySQL
+				sdlSchema, err = transform.SchemaTransform(parser.MySQL, string(dbSchema.Schema))
+				if err != nil {
+					return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to transform SDL format for database %q", database.DatabaseName)).SetInternal(err)
+				}
+			case db.Oracle, db.MSSQL:
+				// The Oracle and SQL Server dumps are in SDL format already.
+				sdlSchema = string(dbSchema.Schema)
 			default:
 				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Not support SDL format for %s instance", instance.Engine))
 			}
 
-			sdlSchema, err := transform.SchemaTransform(engineType, string(dbSchema.Schema))
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to transform SDL format for database %q", database.DatabaseName)).SetInternal(err)
-			}
-
 			c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextPlainCharsetUTF8)
 			if _, err := c.Response().Write([]byte(sdlSchema)); err != nil {
 				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to write schema response for database %q", database.DatabaseName)).SetInternal(err)
@@ -277,6 +297,151 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 		return nil
 	})
 
+	g.GET("/database/:databaseID/schema-snapshot", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := strconv.Atoi(c.Param("databaseID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("databaseID"))).SetInternal(err)
+		}
+
+		database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: &id})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch database ID: %v", id)).SetInternal(err)
+		}
+		if database == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database not found with ID %d", id))
+		}
+
+		snapshots, err := s.store.ListDBSchemaSnapshots(ctx, &store.FindDBSchemaSnapshotMessage{DatabaseUID: &id})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list schema snapshots for database %q", database.DatabaseName)).SetInternal(err)
+		}
+		var apiSnapshots []*api.DBSchemaSnapshot
+		for _, snapshot := range snapshots {
+			apiSnapshots = append(apiSnapshots, snapshot.ToAPIDBSchemaSnapshot())
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, apiSnapshots); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal schema snapshot list response: %v", id)).SetInternal(err)
+		}
+		return nil
+	})
+
+	// The diff is the DDL statements migrating the schema of the "from" snapshot to the "to" snapshot.
+	g.GET("/database/:databaseID/schema-snapshot/diff", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := strconv.Atoi(c.Param("databaseID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("databaseID"))).SetInternal(err)
+		}
+
+		database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: &id})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch database ID: %v", id)).SetInternal(err)
+		}
+		if database == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database not found with ID %d", id))
+		}
+		instance, err := s.store.GetInstanceV2(ctx, &store.FindInstanceMessage{EnvironmentID: &database.EnvironmentID, ResourceID: &database.InstanceID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch instance %q", database.InstanceID)).SetInternal(err)
+		}
+		if instance == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Instance %q not found", database.InstanceID))
+		}
+		var engine parser.EngineType
+		switch instance.Engine {
+		case db.Postgres:
+			engine = parser.Postgres
+		case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+			engine = parser.MySQL
+		case db.Oracle:
+			engine = parser.Oracle
+		case db.MSSQL:
+			engine = parser.MSSQL
+		default:
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Not support schema diff for %s instance", instance.Engine))
+		}
+
+		var schemas []string
+		for _, param := range []string{"from", "to"} {
+			snapshotID, err := strconv.Atoi(c.QueryParam(param))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query parameter %q is not a snapshot ID: %s", param, c.QueryParam(param))).SetInternal(err)
+			}
+			snapshot, err := s.store.GetDBSchemaSnapshot(ctx, &store.FindDBSchemaSnapshotMessage{ID: &snapshotID, DatabaseUID: &id, WithSchema: true})
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get schema snapshot %d", snapshotID)).SetInternal(err)
+			}
+			if snapshot == nil {
+				return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Schema snapshot %d not found for database %q", snapshotID, database.DatabaseName))
+			}
+			schemas = append(schemas, string(snapshot.Schema.Schema))
+		}
+
+		diff, err := differ.SchemaDiff(engine, schemas[0], schemas[1])
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to compute diff between schema snapshots").SetInternal(err)
+		}
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextPlainCharsetUTF8)
+		if _, err := c.Response().Write([]byte(diff)); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to write schema diff response for database %q", database.DatabaseName)).SetInternal(err)
+		}
+		return nil
+	})
+
+	// The table stats of a table over time form its growth curve.
+	// The stats are returned for the last 30 days unless the "since" timestamp is specified.
+	g.GET("/database/:databaseID/table-stat", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := strconv.Atoi(c.Param("databaseID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("databaseID"))).SetInternal(err)
+		}
+
+		database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: &id})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch database ID: %v", id)).SetInternal(err)
+		}
+		if database == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database not found with ID %d", id))
+		}
+
+		createdTsAfter := time.Now().AddDate(0, 0, -30).Unix()
+		if since := c.QueryParam("since"); since != "" {
+			ts, err := strconv.ParseInt(since, 10, 64)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Timestamp is not a number: %s", since)).SetInternal(err)
+			}
+			createdTsAfter = ts
+		}
+		find := &store.FindTableStatMessage{
+			DatabaseUID:    &id,
+			CreatedTsAfter: &createdTsAfter,
+		}
+		if schemaName := c.QueryParam("schema"); schemaName != "" {
+			find.SchemaName = &schemaName
+		}
+		if tableName := c.QueryParam("table"); tableName != "" {
+			find.TableName = &tableName
+		}
+		stats, err := s.store.ListTableStats(ctx, find)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list table stats for database %q", database.DatabaseName)).SetInternal(err)
+		}
+		var apiStats []*api.TableStat
+		for _, stat := range stats {
+			apiStats = append(apiStats, stat.ToAPITableStat())
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, apiStats); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal table stat list response: %v", id)).SetInternal(err)
+		}
+		return nil
+	})
+
 	g.POST("/database/:databaseID/backup", func(c echo.Context) error {
 		ctx := c.Request().Context()
 		id, err := strconv.Atoi(c.Param("databaseID"))
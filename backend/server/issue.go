@@ -381,10 +381,20 @@ func (s *Server) createIssue(ctx context.Context, issueCreate *api.IssueCreate,
 	if err != nil {
 		return nil, err
 	}
-	if len(pipelineCreate.StageList) == 0 {
-		return nil, echo.NewHTTPError(http.StatusBadRequest, "no database matched for deployment")
+	var accessGrantCreate *store.AccessGrantMessage
+	var firstEnvironmentID int
+	if issueCreate.Type == api.IssueDatabaseQueryAccess {
+		// The query access issue has no task to run, the assignee is found in the environment of the requested database.
+		accessGrantCreate, firstEnvironmentID, err = s.getAccessGrantCreate(ctx, issueCreate, creatorID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if len(pipelineCreate.StageList) == 0 {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "no database matched for deployment")
+		}
+		firstEnvironmentID = pipelineCreate.StageList[0].EnvironmentID
 	}
-	firstEnvironmentID := pipelineCreate.StageList[0].EnvironmentID
 
 	if issueCreate.AssigneeID == api.UnknownID {
 		return nil, echo.NewHTTPError(http.StatusBadRequest, "Failed to create issue, assignee missing")
@@ -425,7 +435,8 @@ func (s *Server) createIssue(ctx context.Context, issueCreate *api.IssueCreate,
 			ApprovalFindingDone: false,
 		},
 	}
-	if !s.licenseService.IsFeatureEnabled(api.FeatureCustomApproval) {
+	// The query access issue is always approved with the approval runner.
+	if !s.licenseService.IsFeatureEnabled(api.FeatureCustomApproval) && issueCreate.Type != api.IssueDatabaseQueryAccess {
 		issueCreatePayload.Approval.ApprovalFindingDone = true
 	}
 
@@ -461,6 +472,12 @@ func (s *Server) createIssue(ctx context.Context, issueCreate *api.IssueCreate,
 	if err != nil {
 		return nil, err
 	}
+	if accessGrantCreate != nil {
+		accessGrantCreate.IssueUID = issue.UID
+		if _, err := s.store.CreateAccessGrant(ctx, accessGrantCreate); err != nil {
+			return nil, errors.Wrapf(err, "failed to create access grant for issue %v", issue.Title)
+		}
+	}
 	composedIssue, err := s.store.GetIssueByID(ctx, issue.UID)
 	if err != nil {
 		return nil, err
@@ -544,6 +561,24 @@ func (s *Server) createPipeline(ctx context.Context, creatorID int, pipelineCrea
 		return nil, errors.Errorf("failed to create stages, expect to have created %d stages, got %d", len(stageCreates), len(createdStages))
 	}
 
+	var stageGateCreates []*store.StageGateMessage
+	// The gate of the last stage is dropped, since there is no later stage to hold.
+	for i := 0; i+1 < len(pipelineCreate.StageList); i++ {
+		if gate := pipelineCreate.StageList[i].Gate; gate != nil {
+			stageGateCreates = append(stageGateCreates, &store.StageGateMessage{
+				PipelineID: pipelineCreated.ID,
+				StageID:    createdStages[i].ID,
+				Status:     api.StageGatePending,
+				Config:     convertToStoreDeploymentGate(gate),
+			})
+		}
+	}
+	if len(stageGateCreates) > 0 {
+		if err := s.store.CreateStageGates(ctx, stageGateCreates, creatorID); err != nil {
+			return nil, errors.Wrap(err, "failed to create stage gates for issue")
+		}
+	}
+
 	for i, stageCreate := range pipelineCreate.StageList {
 		createdStage := createdStages[i]
 
@@ -582,11 +617,58 @@ func (s *Server) getPipelineCreate(ctx context.Context, issueCreate *api.IssueCr
 		return s.getPipelineCreateForDatabasePITR(ctx, issueCreate)
 	case api.IssueDatabaseSchemaUpdate, api.IssueDatabaseDataUpdate, api.IssueDatabaseSchemaUpdateGhost:
 		return s.getPipelineCreateForDatabaseSchemaAndDataUpdate(ctx, issueCreate)
+	case api.IssueDatabaseQueryAccess:
+		// The query access is granted by the access grant runner after approval, there is no task to run.
+		return &api.PipelineCreate{Name: "Request query access"}, nil
 	default:
 		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid issue type %q", issueCreate.Type))
 	}
 }
 
+// getAccessGrantCreate returns the access grant to create for the query access issue, and the environment ID of the database.
+func (s *Server) getAccessGrantCreate(ctx context.Context, issueCreate *api.IssueCreate, creatorID int) (*store.AccessGrantMessage, int, error) {
+	c := api.QueryAccessContext{}
+	if err := json.Unmarshal([]byte(issueCreate.CreateContext), &c); err != nil {
+		return nil, 0, echo.NewHTTPError(http.StatusBadRequest, "Malformed query access context").SetInternal(err)
+	}
+	if c.DurationSeconds <= 0 || c.DurationSeconds > api.AccessGrantMaxDurationSeconds {
+		return nil, 0, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query access duration must be between 1 and %d seconds", api.AccessGrantMaxDurationSeconds))
+	}
+	database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: &c.DatabaseID})
+	if err != nil {
+		return nil, 0, err
+	}
+	if database == nil {
+		return nil, 0, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Database ID not found: %d", c.DatabaseID))
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{UID: &issueCreate.ProjectID})
+	if err != nil {
+		return nil, 0, err
+	}
+	if project == nil || project.ResourceID != database.ProjectID {
+		return nil, 0, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Database %q is not in the project of the issue", database.DatabaseName))
+	}
+	environment, err := s.store.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{ResourceID: &database.EnvironmentID})
+	if err != nil {
+		return nil, 0, err
+	}
+	if environment == nil {
+		return nil, 0, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Environment %q not found", database.EnvironmentID))
+	}
+	var tableList []string
+	for _, table := range c.TableList {
+		if table = strings.TrimSpace(table); table != "" {
+			tableList = append(tableList, table)
+		}
+	}
+	return &store.AccessGrantMessage{
+		PrincipalUID: creatorID,
+		DatabaseUID:  database.UID,
+		TableList:    tableList,
+		Duration:     c.DurationSeconds,
+	}, environment.UID, nil
+}
+
 func (s *Server) getPipelineCreateForDatabaseCreate(ctx context.Context, issueCreate *api.IssueCreate) (*api.PipelineCreate, error) {
 	c := api.CreateDatabaseContext{}
 	if err := json.Unmarshal([]byte(issueCreate.CreateContext), &c); err != nil {
@@ -934,6 +1016,7 @@ func (s *Server) getPipelineCreateForDatabaseSchemaAndDataUpdate(ctx context.Con
 				EnvironmentID:    environment.UID,
 				TaskList:         taskCreateList,
 				TaskIndexDAGList: taskIndexDAGList,
+				Gate:             deploySchedule.Deployments[i].Spec.Gate,
 			})
 		}
 		return create, nil
@@ -987,6 +1070,7 @@ func (s *Server) getPipelineCreateForDatabaseSchemaAndDataUpdate(ctx context.Con
 			EnvironmentID:    environment.UID,
 			TaskList:         taskCreateList,
 			TaskIndexDAGList: taskIndexDAGList,
+			Gate:             deploySchedule.Deployments[i].Spec.Gate,
 		})
 	}
 	return create, nil
@@ -22,6 +22,8 @@ const (
 	// The key name used to store principal id in the context
 	// principal id is extracted from the jwt token subject field.
 	principalIDContextKey = "principal-id"
+	// The key name used to store the personal access token authenticating the request in the context.
+	accessTokenContextKey = "access-token"
 )
 
 // Claims creates a struct that will be encoded to a JWT.
@@ -35,6 +37,10 @@ func getPrincipalIDContextKey() string {
 	return principalIDContextKey
 }
 
+func getAccessTokenContextKey() string {
+	return accessTokenContextKey
+}
+
 // GenerateTokensAndSetCookies generates jwt token and saves it to the http-only cookie.
 func GenerateTokensAndSetCookies(c echo.Context, user *store.UserMessage, mode common.ReleaseMode, secret string) error {
 	accessToken, err := auth.GenerateAccessToken(user.Name, user.ID, mode, secret)
@@ -99,6 +105,10 @@ func extractTokenFromHeader(c echo.Context) (string, error) {
 }
 
 func findAccessToken(c echo.Context) (string, error) {
+	// Personal access tokens are always sent in the header.
+	if token, err := extractTokenFromHeader(c); err == nil && auth.IsPersonalAccessToken(token) {
+		return token, nil
+	}
 	if common.HasPrefixes(c.Path(), openAPIPrefix) {
 		return extractTokenFromHeader(c)
 	}
@@ -145,6 +155,23 @@ func JWTMiddleware(pathPrefix string, principalStore *store.Store, next echo.Han
 			return echo.NewHTTPError(http.StatusUnauthorized, "Missing access token")
 		}
 
+		if auth.IsPersonalAccessToken(token) {
+			accessToken, err := auth.ValidateAccessToken(c.Request().Context(), principalStore, token)
+			if err != nil {
+				if common.ErrorCode(err) == common.NotAuthorized {
+					return echo.NewHTTPError(http.StatusUnauthorized, common.ErrorMessage(err))
+				}
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to validate access token").SetInternal(err)
+			}
+			if !isRouteAllowedByScopes(method, path, accessToken.ScopeList) {
+				return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("Access token %q with scopes %v is not allowed to %s %s", accessToken.Title, accessToken.ScopeList, method, path))
+			}
+			// Stores principalID and the access token into context.
+			c.Set(getPrincipalIDContextKey(), accessToken.PrincipalUID)
+			c.Set(getAccessTokenContextKey(), accessToken)
+			return next(c)
+		}
+
 		claims := &Claims{}
 		accessToken, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
 			if t.Method.Alg() != jwt.SigningMethodHS256.Name {
@@ -220,6 +220,10 @@ func schemaDiff(c echo.Context) error {
 		engine = parser.Postgres
 	case parser.EngineType(db.MySQL), parser.EngineType(db.MariaDB):
 		engine = parser.MySQL
+	case parser.EngineType(db.Oracle):
+		engine = parser.Oracle
+	case parser.EngineType(db.MSSQL):
+		engine = parser.MSSQL
 	default:
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid database engine %s", request.EngineType))
 	}
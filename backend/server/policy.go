@@ -430,6 +430,10 @@ func (s *Server) hasAccessToUpsertPolicy(pType api.PolicyType, policyUpsert *api
 		if !s.licenseService.IsFeatureEnabled(api.FeatureSensitiveData) {
 			return errors.Errorf(api.FeatureSensitiveData.AccessErrorMessage())
 		}
+	case api.PolicyTypeRowFilter:
+		if !s.licenseService.IsFeatureEnabled(api.FeatureAccessControl) {
+			return errors.Errorf(api.FeatureAccessControl.AccessErrorMessage())
+		}
 	}
 	return nil
 }
@@ -440,13 +444,18 @@ func mergeSQLReviewRule(payload string) string {
 		return payload
 	}
 
-	ruleMap := make(map[advisor.SQLReviewRuleType]bool)
+	ruleMap := make(map[string]bool)
 	var ruleList []*advisor.SQLReviewRule
 	for _, rule := range policy.RuleList {
-		if _, exists := ruleMap[rule.Type]; exists {
+		key := string(rule.Type)
+		if rule.Type == advisor.SchemaRuleCustom {
+			// A policy may have multiple custom rules.
+			key = fmt.Sprintf("%s:%s", rule.Type, rule.Payload)
+		}
+		if _, exists := ruleMap[key]; exists {
 			continue
 		}
-		ruleMap[rule.Type] = true
+		ruleMap[key] = true
 
 		ruleList = append(ruleList, &advisor.SQLReviewRule{
 			Type:    rule.Type,
@@ -514,6 +523,24 @@ func splitSQLReviewRule(payload *string) *string {
 					Payload: rule.Payload,
 				})
 			}
+			if advisor.RuleExists(rule.Type, db.Oracle) {
+				ruleList = append(ruleList, &advisor.SQLReviewRule{
+					Type:    rule.Type,
+					Level:   rule.Level,
+					Engine:  db.Oracle,
+					Comment: rule.Comment,
+					Payload: rule.Payload,
+				})
+			}
+			if advisor.RuleExists(rule.Type, db.MSSQL) {
+				ruleList = append(ruleList, &advisor.SQLReviewRule{
+					Type:    rule.Type,
+					Level:   rule.Level,
+					Engine:  db.MSSQL,
+					Comment: rule.Comment,
+					Payload: rule.Payload,
+				})
+			}
 		}
 	}
 
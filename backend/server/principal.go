@@ -177,6 +177,9 @@ func (s *Server) registerPrincipalRoutes(g *echo.Group) {
 			Email: principalPatch.Email,
 		}
 		newPassword := principalPatch.Password
+		if (newPassword != nil || principalPatch.RefreshKey) && c.Get(getAccessTokenContextKey()) != nil {
+			return echo.NewHTTPError(http.StatusForbidden, "Password and service key cannot be changed with a personal access token")
+		}
 		if principalPatch.Type == api.ServiceAccount && principalPatch.RefreshKey {
 			val, err := common.RandomString(20)
 			if err != nil {
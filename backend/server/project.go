@@ -22,6 +22,7 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	vcsPlugin "github.com/bytebase/bytebase/backend/plugin/vcs"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/bitbucket"
+	"github.com/bytebase/bytebase/backend/plugin/vcs/gitea"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/github"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/gitlab"
 	"github.com/bytebase/bytebase/backend/store"
@@ -709,6 +710,7 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 				Name: d.Name,
 				Spec: &store.DeploymentSpec{
 					Selector: &labelSelector,
+					Gate:     convertToStoreDeploymentGate(d.Spec.Gate),
 				},
 			})
 		}
@@ -929,6 +931,10 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 				sheetSource = api.SheetFromGitHub
 			case vcsPlugin.Bitbucket:
 				sheetSource = api.SheetFromBitbucket
+			case vcsPlugin.Gitea:
+				sheetSource = api.SheetFromGitea
+			case vcsPlugin.Git:
+				sheetSource = api.SheetFromGit
 			}
 			vscSheetType := api.SheetForSQL
 			sheetFind := &api.SheetFind{
@@ -1014,7 +1020,8 @@ func (s *Server) setupVCSSQLReviewCI(ctx context.Context, repository *api.Reposi
 	sqlReviewEndpoint := fmt.Sprintf("%s/hook/sql-review/%s", setting.ExternalUrl, repository.WebhookEndpointID)
 
 	switch repository.VCS.Type {
-	case vcsPlugin.GitHub:
+	case vcsPlugin.GitHub, vcsPlugin.Gitea:
+		// Gitea Actions is compatible with GitHub Actions and also reads the workflows in .github/workflows.
 		if err := s.setupVCSSQLReviewCIForGitHub(ctx, repository, branch, sqlReviewEndpoint); err != nil {
 			return nil, err
 		}
@@ -1314,6 +1321,23 @@ func createVCSWebhook(ctx context.Context, vcsType vcsPlugin.Type, webhookEndpoi
 		if err != nil {
 			return "", errors.Wrap(err, "failed to marshal request body for creating webhook")
 		}
+	case vcsPlugin.Gitea:
+		webhookPost := gitea.WebhookCreateOrUpdate{
+			Type: "gitea",
+			Config: gitea.WebhookConfig{
+				URL:         fmt.Sprintf("%s/hook/gitea/%s", externalURL, webhookEndpointID),
+				ContentType: "json",
+				Secret:      secretToken,
+			},
+			Events: []string{"push"},
+			Active: true,
+		}
+		webhookCreatePayload, err = json.Marshal(webhookPost)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal request body for creating webhook")
+		}
+	case vcsPlugin.Git:
+		// Generic Git servers have no webhooks, the pushes are detected by the VCS poller instead.
 	}
 	webhookID, err := vcsPlugin.Get(vcsType, vcsPlugin.ProviderConfig{}).CreateWebhook(
 		ctx,
@@ -1383,3 +1407,21 @@ func isProjectMember(principalID int, projectPolicy *store.IAMPolicyMessage) boo
 	}
 	return false
 }
+
+func convertToStoreDeploymentGate(gate *api.DeploymentGate) *store.DeploymentGate {
+	if gate == nil {
+		return nil
+	}
+	result := &store.DeploymentGate{
+		BakeTimeSeconds:   gate.BakeTimeSeconds,
+		MaxFailurePercent: gate.MaxFailurePercent,
+	}
+	if check := gate.HealthCheck; check != nil {
+		result.HealthCheck = &store.DeploymentHealthCheck{
+			Statement: check.Statement,
+			URL:       check.URL,
+			MaxValue:  check.MaxValue,
+		}
+	}
+	return result
+}
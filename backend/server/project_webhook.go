@@ -1,6 +1,8 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -33,7 +35,11 @@ func (s *Server) registerProjectWebhookRoutes(g *echo.Group) {
 
 		var apiWebhookList []*api.ProjectWebhook
 		for _, webhook := range webhookList {
-			apiWebhookList = append(apiWebhookList, webhook.ToAPIProjectWebhook())
+			apiWebhook, err := webhook.ToAPIProjectWebhook()
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to convert project webhook ID: %d", webhook.ID)).SetInternal(err)
+			}
+			apiWebhookList = append(apiWebhookList, apiWebhook)
 		}
 
 		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
@@ -66,6 +72,13 @@ func (s *Server) registerProjectWebhookRoutes(g *echo.Group) {
 		if err := jsonapi.UnmarshalPayload(c.Request().Body, hookCreate); err != nil {
 			return echo.NewHTTPError(http.StatusBadRequest, "Malformed create project webhook request").SetInternal(err)
 		}
+		filter, err := parseProjectWebhookFilter(hookCreate.Filter)
+		if err != nil {
+			return err
+		}
+		if err := validateProjectWebhookPayloadTemplate(hookCreate.Type, hookCreate.PayloadTemplate); err != nil {
+			return err
+		}
 		project, err := s.store.GetProjectByID(ctx, projectID)
 		if err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch project ID: %v", projectID)).SetInternal(err)
@@ -75,10 +88,13 @@ func (s *Server) registerProjectWebhookRoutes(g *echo.Group) {
 		}
 
 		webhook, err := s.store.CreateProjectWebhookV2(ctx, c.Get(getPrincipalIDContextKey()).(int), projectID, project.ResourceID, &store.ProjectWebhookMessage{
-			Type:         hookCreate.Type,
-			Title:        hookCreate.Name,
-			URL:          hookCreate.URL,
-			ActivityList: hookCreate.ActivityList,
+			Type:            hookCreate.Type,
+			Title:           hookCreate.Name,
+			URL:             hookCreate.URL,
+			ActivityList:    hookCreate.ActivityList,
+			Secret:          hookCreate.Secret,
+			Filter:          filter,
+			PayloadTemplate: hookCreate.PayloadTemplate,
 		})
 		if err != nil {
 			if common.ErrorCode(err) == common.Conflict {
@@ -87,8 +103,12 @@ func (s *Server) registerProjectWebhookRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create project webhook").SetInternal(err)
 		}
 
+		apiWebhook, err := webhook.ToAPIProjectWebhook()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to convert project webhook ID: %d", webhook.ID)).SetInternal(err)
+		}
 		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
-		if err := jsonapi.MarshalPayload(c.Response().Writer, webhook.ToAPIProjectWebhook()); err != nil {
+		if err := jsonapi.MarshalPayload(c.Response().Writer, apiWebhook); err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal create project webhook response").SetInternal(err)
 		}
 		return nil
@@ -116,8 +136,12 @@ func (s *Server) registerProjectWebhookRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Project webhook ID not found: %d", id))
 		}
 
+		apiWebhook, err := webhook.ToAPIProjectWebhook()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to convert project webhook ID: %d", webhook.ID)).SetInternal(err)
+		}
 		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
-		if err := jsonapi.MarshalPayload(c.Response().Writer, webhook.ToAPIProjectWebhook()); err != nil {
+		if err := jsonapi.MarshalPayload(c.Response().Writer, apiWebhook); err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal project webhook ID response: %v", id)).SetInternal(err)
 		}
 		return nil
@@ -155,11 +179,37 @@ func (s *Server) registerProjectWebhookRoutes(g *echo.Group) {
 			activityList = strings.Split(*hookPatch.ActivityList, ",")
 		}
 
-		webhook, err := s.store.UpdateProjectWebhookV2(ctx, c.Get(getPrincipalIDContextKey()).(int), project.ID, project.ResourceID, id, &store.UpdateProjectWebhookMessage{
-			Title:        hookPatch.Name,
-			URL:          hookPatch.URL,
-			ActivityList: activityList,
-		})
+		update := &store.UpdateProjectWebhookMessage{
+			Title:           hookPatch.Name,
+			URL:             hookPatch.URL,
+			ActivityList:    activityList,
+			Secret:          hookPatch.Secret,
+			Enabled:         hookPatch.Enabled,
+			PayloadTemplate: hookPatch.PayloadTemplate,
+		}
+		if hookPatch.Filter != nil {
+			if update.Filter, err = parseProjectWebhookFilter(*hookPatch.Filter); err != nil {
+				return err
+			}
+		}
+		if hookPatch.PayloadTemplate != nil {
+			webhook, err := s.store.GetProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{ID: &id})
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch project webhook ID: %v", id)).SetInternal(err)
+			}
+			if webhook == nil {
+				return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Project webhook ID not found: %d", id))
+			}
+			if err := validateProjectWebhookPayloadTemplate(webhook.Type, *hookPatch.PayloadTemplate); err != nil {
+				return err
+			}
+		}
+		if hookPatch.Enabled != nil && *hookPatch.Enabled {
+			// Start counting the consecutive failed deliveries over again after the webhook is re-enabled.
+			failureCount := 0
+			update.FailureCount = &failureCount
+		}
+		webhook, err := s.store.UpdateProjectWebhookV2(ctx, c.Get(getPrincipalIDContextKey()).(int), project.ID, project.ResourceID, id, update)
 		if err != nil {
 			if common.ErrorCode(err) == common.NotFound {
 				return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Project webhook ID not found: %d", id))
@@ -170,8 +220,12 @@ func (s *Server) registerProjectWebhookRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to change project webhook ID: %v", id)).SetInternal(err)
 		}
 
+		apiWebhook, err := webhook.ToAPIProjectWebhook()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to convert project webhook ID: %d", webhook.ID)).SetInternal(err)
+		}
 		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
-		if err := jsonapi.MarshalPayload(c.Response().Writer, webhook.ToAPIProjectWebhook()); err != nil {
+		if err := jsonapi.MarshalPayload(c.Response().Writer, apiWebhook); err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal project webhook change response: %v", id)).SetInternal(err)
 		}
 		return nil
@@ -248,17 +302,19 @@ func (s *Server) registerProjectWebhookRoutes(g *echo.Group) {
 		err = webhookPlugin.Post(
 			webhook.Type,
 			webhookPlugin.Context{
-				URL:          webhook.URL,
-				Level:        webhookPlugin.WebhookInfo,
-				ActivityType: string(api.ActivityIssueCreate),
-				Title:        fmt.Sprintf("Test webhook %q", webhook.Title),
-				Description:  "This is a test",
-				Link:         fmt.Sprintf("%s/project/%s/webhook/%s", setting.ExternalUrl, getProjectSlug(project), api.ProjectWebhookSlug(webhook.Title, webhook.ID)),
-				CreatorID:    api.SystemBotID,
-				CreatorName:  "Bytebase",
-				CreatorEmail: "support@bytebase.com",
-				CreatedTs:    time.Now().Unix(),
-				Project:      &webhookPlugin.Project{Name: project.Title},
+				URL:             webhook.URL,
+				Secret:          webhook.Secret,
+				PayloadTemplate: webhook.PayloadTemplate,
+				Level:           webhookPlugin.WebhookInfo,
+				ActivityType:    string(api.ActivityIssueCreate),
+				Title:           fmt.Sprintf("Test webhook %q", webhook.Title),
+				Description:     "This is a test",
+				Link:            fmt.Sprintf("%s/project/%s/webhook/%s", setting.ExternalUrl, getProjectSlug(project), api.ProjectWebhookSlug(webhook.Title, webhook.ID)),
+				CreatorID:       api.SystemBotID,
+				CreatorName:     "Bytebase",
+				CreatorEmail:    "support@bytebase.com",
+				CreatedTs:       time.Now().Unix(),
+				Project:         &webhookPlugin.Project{Name: project.Title},
 			},
 		)
 
@@ -272,6 +328,131 @@ func (s *Server) registerProjectWebhookRoutes(g *echo.Group) {
 		}
 		return nil
 	})
+
+	g.GET("/project/:projectID/webhook/:webhookID/delivery", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		webhook, err := s.getProjectWebhook(ctx, c.Param("projectID"), c.Param("webhookID"))
+		if err != nil {
+			return err
+		}
+
+		limit := 50
+		if limitStr := c.QueryParam("limit"); limitStr != "" {
+			if limit, err = strconv.Atoi(limitStr); err != nil || limit <= 0 {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query parameter limit is not a positive number: %s", limitStr)).SetInternal(err)
+			}
+		}
+		deliveryList, err := s.store.ListProjectWebhookDelivery(ctx, &store.FindProjectWebhookDeliveryMessage{
+			ProjectWebhookID: &webhook.ID,
+			Limit:            &limit,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch delivery list for project webhook ID: %d", webhook.ID)).SetInternal(err)
+		}
+
+		var apiDeliveryList []*api.ProjectWebhookDelivery
+		for _, delivery := range deliveryList {
+			apiDeliveryList = append(apiDeliveryList, delivery.ToAPIProjectWebhookDelivery())
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, apiDeliveryList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal project webhook delivery list response: %v", webhook.ID)).SetInternal(err)
+		}
+		return nil
+	})
+
+	g.POST("/project/:projectID/webhook/:webhookID/delivery/:deliveryID/redeliver", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		webhook, err := s.getProjectWebhook(ctx, c.Param("projectID"), c.Param("webhookID"))
+		if err != nil {
+			return err
+		}
+
+		deliveryID, err := strconv.Atoi(c.Param("deliveryID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Delivery ID is not a number: %s", c.Param("deliveryID"))).SetInternal(err)
+		}
+		delivery, err := s.store.GetProjectWebhookDelivery(ctx, &store.FindProjectWebhookDeliveryMessage{
+			ID:               &deliveryID,
+			ProjectWebhookID: &webhook.ID,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch project webhook delivery ID: %v", deliveryID)).SetInternal(err)
+		}
+		if delivery == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Project webhook delivery ID not found: %d", deliveryID))
+		}
+		if !webhook.Enabled {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Project webhook %q is disabled, enable it before redelivering", webhook.Title))
+		}
+
+		// Redelivering queues a new delivery with the same payload, so that the history of the original one is kept.
+		redelivery, err := s.store.CreateProjectWebhookDelivery(ctx, &store.ProjectWebhookDeliveryMessage{
+			ProjectWebhookID: webhook.ID,
+			Status:           api.ProjectWebhookDeliveryPending,
+			NextAttemptTs:    time.Now().Unix(),
+			Payload:          delivery.Payload,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to redeliver project webhook delivery ID: %v", deliveryID)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, redelivery.ToAPIProjectWebhookDelivery()); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal project webhook redelivery response: %v", deliveryID)).SetInternal(err)
+		}
+		return nil
+	})
+}
+
+// parseProjectWebhookFilter parses the project webhook filter in json format, the error is an echo HTTP error.
+func parseProjectWebhookFilter(filter string) (*api.ProjectWebhookFilter, error) {
+	v := &api.ProjectWebhookFilter{}
+	if filter == "" {
+		return v, nil
+	}
+	if err := json.Unmarshal([]byte(filter), v); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Malformed project webhook filter").SetInternal(err)
+	}
+	return v, nil
+}
+
+// validateProjectWebhookPayloadTemplate validates the payload template, the error is an echo HTTP error.
+func validateProjectWebhookPayloadTemplate(webhookType, payloadTemplate string) error {
+	if payloadTemplate == "" {
+		return nil
+	}
+	if webhookType != webhookPlugin.CustomWebhookType {
+		return echo.NewHTTPError(http.StatusBadRequest, "Payload template is only supported by the custom webhook")
+	}
+	if err := webhookPlugin.ValidatePayloadTemplate(payloadTemplate); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid payload template: %v", err)).SetInternal(err)
+	}
+	return nil
+}
+
+// getProjectWebhook gets the project webhook by the project ID and webhook ID path parameters, the error is an echo HTTP error.
+func (s *Server) getProjectWebhook(ctx context.Context, projectIDStr, webhookIDStr string) (*store.ProjectWebhookMessage, error) {
+	projectID, err := strconv.Atoi(projectIDStr)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Project ID is not a number: %s", projectIDStr)).SetInternal(err)
+	}
+	id, err := strconv.Atoi(webhookIDStr)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Project webhook ID is not a number: %s", webhookIDStr)).SetInternal(err)
+	}
+	webhook, err := s.store.GetProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
+		ID:        &id,
+		ProjectID: &projectID,
+	})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch project webhook ID: %v", id)).SetInternal(err)
+	}
+	if webhook == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Project webhook ID not found: %d", id))
+	}
+	return webhook, nil
 }
 
 // getProjectSlug is the slug formatter for Project.
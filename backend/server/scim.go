@@ -0,0 +1,1310 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/scim"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+// scimTokenPrefix makes the SCIM token recognizable by secret scanners.
+const scimTokenPrefix = "bbscim_"
+
+// registerSCIMRoutes registers the SCIM 2.0 provisioning API used by the identity provider.
+// The requests are authenticated by the workspace SCIM token instead of the user JWT.
+func (s *Server) registerSCIMRoutes(g *echo.Group) {
+	g.Use(s.scimAuthMiddleware)
+
+	g.GET("/ServiceProviderConfig", func(c echo.Context) error {
+		return writeSCIMResponse(c, http.StatusOK, map[string]any{
+			"schemas":        []string{scim.SchemaServiceProviderConfig},
+			"patch":          map[string]bool{"supported": true},
+			"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+			"filter":         map[string]any{"supported": true, "maxResults": 1000},
+			"changePassword": map[string]bool{"supported": false},
+			"sort":           map[string]bool{"supported": false},
+			"etag":           map[string]bool{"supported": false},
+			"authenticationSchemes": []map[string]string{
+				{"type": "oauthbearertoken", "name": "OAuth Bearer Token", "description": "Authentication with the workspace SCIM token."},
+			},
+		})
+	})
+
+	g.GET("/Users", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		filter, err := scim.ParseFilter(c.QueryParam("filter"))
+		if err != nil {
+			return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidFilter, err.Error())
+		}
+		for _, condition := range filter.Conditions {
+			if !scimUserFilterAttributes[condition.Attribute] {
+				return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidFilter, fmt.Sprintf("unsupported filter attribute %q", condition.Attribute))
+			}
+		}
+		startIndex, count, err := getSCIMPagination(c)
+		if err != nil {
+			return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidValue, err.Error())
+		}
+
+		endUser := api.EndUser
+		users, err := s.store.ListUsers(ctx, &store.FindUserMessage{Type: &endUser, ShowDeleted: true})
+		if err != nil {
+			return writeSCIMInternalError(c, errors.Wrap(err, "failed to list users"))
+		}
+		groups, err := s.store.ListSCIMGroups(ctx, &store.FindSCIMGroupMessage{})
+		if err != nil {
+			return writeSCIMInternalError(c, errors.Wrap(err, "failed to list SCIM groups"))
+		}
+		sort.Slice(users, func(i, j int) bool {
+			return users[i].ID < users[j].ID
+		})
+		resources := []any{}
+		for _, user := range users {
+			if matchSCIMUser(user, filter) {
+				resources = append(resources, convertToSCIMUser(user, groups))
+			}
+		}
+		return writeSCIMResponse(c, http.StatusOK, scim.NewListResponse(resources, startIndex, count))
+	})
+
+	g.GET("/Users/:userID", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := s.getSCIMUser(ctx, c.Param("userID"))
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		groups, err := s.store.ListSCIMGroups(ctx, &store.FindSCIMGroupMessage{MemberID: &user.ID})
+		if err != nil {
+			return writeSCIMInternalError(c, errors.Wrap(err, "failed to list SCIM groups"))
+		}
+		return writeSCIMResponse(c, http.StatusOK, convertToSCIMUser(user, groups))
+	})
+
+	g.POST("/Users", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		request := &scim.User{}
+		if err := readSCIMRequest(c, request); err != nil {
+			return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidSyntax, err.Error())
+		}
+		patch, err := getSCIMUserPatch(request)
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		if patch.email == nil {
+			return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidValue, "userName or emails is required")
+		}
+
+		user, err := s.store.GetUser(ctx, &store.FindUserMessage{Email: patch.email, ShowDeleted: true})
+		if err != nil {
+			return writeSCIMInternalError(c, errors.Wrapf(err, "failed to get user by email %q", *patch.email))
+		}
+		if user != nil && (user.Type != api.EndUser || !user.MemberDeleted) {
+			return writeSCIMError(c, http.StatusConflict, scim.ErrorTypeUniqueness, fmt.Sprintf("user %q already exists", *patch.email))
+		}
+		if user == nil {
+			password, err := common.RandomString(20)
+			if err != nil {
+				return writeSCIMInternalError(c, errors.Wrap(err, "failed to generate random password"))
+			}
+			passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+			if err != nil {
+				return writeSCIMInternalError(c, errors.Wrap(err, "failed to generate password hash"))
+			}
+			name := *patch.email
+			if patch.name != nil {
+				name = *patch.name
+			}
+			user, err = s.store.CreateUser(ctx, &store.UserMessage{
+				Name:         name,
+				Email:        *patch.email,
+				Type:         api.EndUser,
+				PasswordHash: string(passwordHash),
+			}, api.SystemBotID)
+			if err != nil {
+				return writeSCIMInternalError(c, errors.Wrapf(err, "failed to create user %q", *patch.email))
+			}
+			patch.name, patch.email = nil, nil
+			if patch.active != nil && *patch.active {
+				patch.active = nil
+			}
+		} else if patch.active == nil {
+			// The user was deactivated before, creating it again reactivates it.
+			active := true
+			patch.active = &active
+		}
+		user, err = s.updateSCIMUser(ctx, user, patch)
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		return writeSCIMResponse(c, http.StatusCreated, convertToSCIMUser(user, nil))
+	})
+
+	g.PUT("/Users/:userID", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := s.getSCIMUser(ctx, c.Param("userID"))
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		request := &scim.User{}
+		if err := readSCIMRequest(c, request); err != nil {
+			return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidSyntax, err.Error())
+		}
+		patch, err := getSCIMUserPatch(request)
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		if patch.active == nil {
+			active := true
+			patch.active = &active
+		}
+		user, err = s.updateSCIMUser(ctx, user, patch)
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		groups, err := s.store.ListSCIMGroups(ctx, &store.FindSCIMGroupMessage{MemberID: &user.ID})
+		if err != nil {
+			return writeSCIMInternalError(c, errors.Wrap(err, "failed to list SCIM groups"))
+		}
+		return writeSCIMResponse(c, http.StatusOK, convertToSCIMUser(user, groups))
+	})
+
+	g.PATCH("/Users/:userID", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := s.getSCIMUser(ctx, c.Param("userID"))
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		request := &scim.PatchRequest{}
+		if err := readSCIMRequest(c, request); err != nil {
+			return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidSyntax, err.Error())
+		}
+		patch := &scimUserPatch{}
+		for _, operation := range request.Operations {
+			if err := patch.applyOperation(operation); err != nil {
+				return writeSCIMStoreError(c, err)
+			}
+		}
+		user, err = s.updateSCIMUser(ctx, user, patch)
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		groups, err := s.store.ListSCIMGroups(ctx, &store.FindSCIMGroupMessage{MemberID: &user.ID})
+		if err != nil {
+			return writeSCIMInternalError(c, errors.Wrap(err, "failed to list SCIM groups"))
+		}
+		return writeSCIMResponse(c, http.StatusOK, convertToSCIMUser(user, groups))
+	})
+
+	// Users are never removed from Bytebase because they are referenced by issues and activities,
+	// deleting a user deactivates it and removes it from the SCIM groups.
+	g.DELETE("/Users/:userID", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := s.getSCIMUser(ctx, c.Param("userID"))
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		active := false
+		if _, err := s.updateSCIMUser(ctx, user, &scimUserPatch{active: &active}); err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		groups, err := s.store.ListSCIMGroups(ctx, &store.FindSCIMGroupMessage{MemberID: &user.ID})
+		if err != nil {
+			return writeSCIMInternalError(c, errors.Wrap(err, "failed to list SCIM groups"))
+		}
+		for _, group := range groups {
+			memberList := removeSCIMMembers(group.MemberList, []int{user.ID})
+			if _, err := s.store.UpdateSCIMGroup(ctx, &store.UpdateSCIMGroupMessage{ID: group.ID, MemberList: &memberList}); err != nil {
+				return writeSCIMInternalError(c, errors.Wrapf(err, "failed to remove user %d from SCIM group %q", user.ID, group.DisplayName))
+			}
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	g.GET("/Groups", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		filter, err := scim.ParseFilter(c.QueryParam("filter"))
+		if err != nil {
+			return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidFilter, err.Error())
+		}
+		for _, condition := range filter.Conditions {
+			if !scimGroupFilterAttributes[condition.Attribute] {
+				return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidFilter, fmt.Sprintf("unsupported filter attribute %q", condition.Attribute))
+			}
+		}
+		startIndex, count, err := getSCIMPagination(c)
+		if err != nil {
+			return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidValue, err.Error())
+		}
+		// Identity providers exclude the members when looking up groups because the list can be large.
+		excludeMembers := strings.Contains(strings.ToLower(c.QueryParam("excludedAttributes")), "members")
+
+		groups, err := s.store.ListSCIMGroups(ctx, &store.FindSCIMGroupMessage{})
+		if err != nil {
+			return writeSCIMInternalError(c, errors.Wrap(err, "failed to list SCIM groups"))
+		}
+		resources := []any{}
+		for _, group := range groups {
+			if !matchSCIMGroup(group, filter) {
+				continue
+			}
+			scimGroup, err := s.convertToSCIMGroup(ctx, group, excludeMembers)
+			if err != nil {
+				return writeSCIMInternalError(c, err)
+			}
+			resources = append(resources, scimGroup)
+		}
+		return writeSCIMResponse(c, http.StatusOK, scim.NewListResponse(resources, startIndex, count))
+	})
+
+	g.GET("/Groups/:groupID", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		group, err := s.getSCIMGroup(ctx, c.Param("groupID"))
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		excludeMembers := strings.Contains(strings.ToLower(c.QueryParam("excludedAttributes")), "members")
+		scimGroup, err := s.convertToSCIMGroup(ctx, group, excludeMembers)
+		if err != nil {
+			return writeSCIMInternalError(c, err)
+		}
+		return writeSCIMResponse(c, http.StatusOK, scimGroup)
+	})
+
+	g.POST("/Groups", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		request := &scim.Group{}
+		if err := readSCIMRequest(c, request); err != nil {
+			return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidSyntax, err.Error())
+		}
+		if request.DisplayName == "" {
+			return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidValue, "displayName is required")
+		}
+		existing, err := s.store.GetSCIMGroup(ctx, &store.FindSCIMGroupMessage{DisplayName: &request.DisplayName})
+		if err != nil {
+			return writeSCIMInternalError(c, errors.Wrapf(err, "failed to get SCIM group %q", request.DisplayName))
+		}
+		if existing != nil {
+			return writeSCIMError(c, http.StatusConflict, scim.ErrorTypeUniqueness, fmt.Sprintf("group %q already exists", request.DisplayName))
+		}
+		memberList, err := s.getSCIMMemberList(ctx, request.Members)
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+
+		group, err := s.store.CreateSCIMGroup(ctx, &store.SCIMGroupMessage{
+			ExternalID:  request.ExternalID,
+			DisplayName: request.DisplayName,
+			MemberList:  memberList,
+		})
+		if err != nil {
+			return writeSCIMInternalError(c, errors.Wrapf(err, "failed to create SCIM group %q", request.DisplayName))
+		}
+		if err := s.syncSCIMUsers(ctx, memberList); err != nil {
+			return writeSCIMInternalError(c, err)
+		}
+		scimGroup, err := s.convertToSCIMGroup(ctx, group, false /* excludeMembers */)
+		if err != nil {
+			return writeSCIMInternalError(c, err)
+		}
+		return writeSCIMResponse(c, http.StatusCreated, scimGroup)
+	})
+
+	g.PUT("/Groups/:groupID", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		group, err := s.getSCIMGroup(ctx, c.Param("groupID"))
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		request := &scim.Group{}
+		if err := readSCIMRequest(c, request); err != nil {
+			return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidSyntax, err.Error())
+		}
+		if request.DisplayName == "" {
+			return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidValue, "displayName is required")
+		}
+		memberList, err := s.getSCIMMemberList(ctx, request.Members)
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		group, err = s.updateSCIMGroup(ctx, group, &store.UpdateSCIMGroupMessage{
+			ExternalID:  &request.ExternalID,
+			DisplayName: &request.DisplayName,
+			MemberList:  &memberList,
+		})
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		scimGroup, err := s.convertToSCIMGroup(ctx, group, false /* excludeMembers */)
+		if err != nil {
+			return writeSCIMInternalError(c, err)
+		}
+		return writeSCIMResponse(c, http.StatusOK, scimGroup)
+	})
+
+	g.PATCH("/Groups/:groupID", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		group, err := s.getSCIMGroup(ctx, c.Param("groupID"))
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		request := &scim.PatchRequest{}
+		if err := readSCIMRequest(c, request); err != nil {
+			return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidSyntax, err.Error())
+		}
+		update := &store.UpdateSCIMGroupMessage{}
+		memberList := group.MemberList
+		for _, operation := range request.Operations {
+			memberList, err = s.applySCIMGroupOperation(ctx, update, memberList, operation)
+			if err != nil {
+				return writeSCIMStoreError(c, err)
+			}
+		}
+		update.MemberList = &memberList
+		if _, err := s.updateSCIMGroup(ctx, group, update); err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		// Okta and Azure AD only check the status code of group PATCH requests.
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	g.DELETE("/Groups/:groupID", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		group, err := s.getSCIMGroup(ctx, c.Param("groupID"))
+		if err != nil {
+			return writeSCIMStoreError(c, err)
+		}
+		if err := s.store.DeleteSCIMGroup(ctx, group.ID); err != nil {
+			return writeSCIMInternalError(c, errors.Wrapf(err, "failed to delete SCIM group %q", group.DisplayName))
+		}
+		if err := s.syncSCIMUsers(ctx, group.MemberList); err != nil {
+			return writeSCIMInternalError(c, err)
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+}
+
+// registerSCIMSettingRoutes registers the routes for workspace owners to manage the SCIM token and group mappings.
+func (s *Server) registerSCIMSettingRoutes(g *echo.Group) {
+	g.GET("/scim/setting", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		setting, err := s.getSCIMSetting(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get SCIM setting").SetInternal(err)
+		}
+		return c.JSON(http.StatusOK, convertToSCIMSettingResponse(setting))
+	})
+
+	g.PATCH("/scim/setting", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		if !s.licenseService.IsFeatureEnabled(api.FeatureSSO) {
+			return echo.NewHTTPError(http.StatusForbidden, api.FeatureSSO.AccessErrorMessage())
+		}
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Failed to read request body").SetInternal(err)
+		}
+		request := &scimSettingRequest{}
+		if err := json.Unmarshal(body, request); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed patch SCIM setting request").SetInternal(err)
+		}
+		if err := s.validateSCIMGroupMappingList(ctx, request.GroupMappingList); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		setting, err := s.getSCIMSetting(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get SCIM setting").SetInternal(err)
+		}
+		setting.GroupMappingList = request.GroupMappingList
+		if err := s.setSCIMSetting(ctx, setting, c.Get(getPrincipalIDContextKey()).(int)); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update SCIM setting").SetInternal(err)
+		}
+
+		// Apply the new mappings to everyone who is in a SCIM group.
+		groups, err := s.store.ListSCIMGroups(ctx, &store.FindSCIMGroupMessage{})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list SCIM groups").SetInternal(err)
+		}
+		var userIDList []int
+		for _, group := range groups {
+			userIDList = append(userIDList, group.MemberList...)
+		}
+		if err := s.syncSCIMUsers(ctx, userIDList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to apply SCIM group mappings").SetInternal(err)
+		}
+		return c.JSON(http.StatusOK, convertToSCIMSettingResponse(setting))
+	})
+
+	// The token is only returned once, generating a new one revokes the previous token.
+	g.POST("/scim/token", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		if !s.licenseService.IsFeatureEnabled(api.FeatureSSO) {
+			return echo.NewHTTPError(http.StatusForbidden, api.FeatureSSO.AccessErrorMessage())
+		}
+		random, err := common.RandomString(40)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate SCIM token").SetInternal(err)
+		}
+		token := scimTokenPrefix + random
+
+		setting, err := s.getSCIMSetting(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get SCIM setting").SetInternal(err)
+		}
+		setting.TokenHash = hashSCIMToken(token)
+		if err := s.setSCIMSetting(ctx, setting, c.Get(getPrincipalIDContextKey()).(int)); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update SCIM setting").SetInternal(err)
+		}
+		return c.JSON(http.StatusOK, map[string]string{"token": token})
+	})
+}
+
+// scimSettingRequest is the request to update the SCIM group mappings.
+type scimSettingRequest struct {
+	GroupMappingList []*api.SCIMGroupMapping `json:"groupMappingList"`
+}
+
+// scimSettingResponse is the SCIM setting returned to the client, the token hash is never exposed.
+type scimSettingResponse struct {
+	TokenConfigured  bool                    `json:"tokenConfigured"`
+	GroupMappingList []*api.SCIMGroupMapping `json:"groupMappingList"`
+}
+
+func convertToSCIMSettingResponse(setting *api.SettingWorkspaceSCIMValue) *scimSettingResponse {
+	response := &scimSettingResponse{
+		TokenConfigured:  setting.TokenHash != "",
+		GroupMappingList: setting.GroupMappingList,
+	}
+	if response.GroupMappingList == nil {
+		response.GroupMappingList = []*api.SCIMGroupMapping{}
+	}
+	return response
+}
+
+func (s *Server) scimAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		authorization := c.Request().Header.Get(echo.HeaderAuthorization)
+		if len(authorization) <= len("Bearer ") || !strings.EqualFold(authorization[:len("Bearer ")], "Bearer ") {
+			return writeSCIMError(c, http.StatusUnauthorized, "", "Missing bearer token")
+		}
+		token := strings.TrimSpace(authorization[len("Bearer "):])
+
+		setting, err := s.getSCIMSetting(ctx)
+		if err != nil {
+			return writeSCIMInternalError(c, errors.Wrap(err, "failed to get SCIM setting"))
+		}
+		if setting.TokenHash == "" || subtle.ConstantTimeCompare([]byte(hashSCIMToken(token)), []byte(setting.TokenHash)) != 1 {
+			return writeSCIMError(c, http.StatusUnauthorized, "", "Invalid bearer token")
+		}
+		if !s.licenseService.IsFeatureEnabled(api.FeatureSSO) {
+			return writeSCIMError(c, http.StatusForbidden, "", api.FeatureSSO.AccessErrorMessage())
+		}
+		return next(c)
+	}
+}
+
+func hashSCIMToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *Server) getSCIMSetting(ctx context.Context) (*api.SettingWorkspaceSCIMValue, error) {
+	settingName := api.SettingWorkspaceSCIM
+	setting, err := s.store.GetSettingV2(ctx, &store.FindSettingMessage{Name: &settingName})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get setting %s", settingName)
+	}
+	value := &api.SettingWorkspaceSCIMValue{}
+	if setting == nil || setting.Value == "" {
+		return value, nil
+	}
+	if err := json.Unmarshal([]byte(setting.Value), value); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal setting value %s", setting.Value)
+	}
+	return value, nil
+}
+
+func (s *Server) setSCIMSetting(ctx context.Context, value *api.SettingWorkspaceSCIMValue, updaterID int) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal SCIM setting")
+	}
+	if _, err := s.store.UpsertSettingV2(ctx, &store.SetSettingMessage{
+		Name:  api.SettingWorkspaceSCIM,
+		Value: string(bytes),
+	}, updaterID); err != nil {
+		return errors.Wrap(err, "failed to upsert SCIM setting")
+	}
+	return nil
+}
+
+func (s *Server) validateSCIMGroupMappingList(ctx context.Context, mappingList []*api.SCIMGroupMapping) error {
+	for _, mapping := range mappingList {
+		if mapping.Group == "" {
+			return errors.New("group is required in the group mapping")
+		}
+		if mapping.Role == "" && mapping.ProjectID == 0 {
+			return errors.Errorf("group mapping of %q must grant either a workspace role or a project role", mapping.Group)
+		}
+		if mapping.Role != "" && mapping.Role != api.Owner && mapping.Role != api.DBA && mapping.Role != api.Developer {
+			return errors.Errorf("invalid workspace role %q in group mapping of %q", mapping.Role, mapping.Group)
+		}
+		if mapping.ProjectID == 0 {
+			if mapping.ProjectRole != "" {
+				return errors.Errorf("project is required for the project role in group mapping of %q", mapping.Group)
+			}
+			continue
+		}
+		if mapping.ProjectRole == "" {
+			return errors.Errorf("project role is required in group mapping of %q", mapping.Group)
+		}
+		project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{UID: &mapping.ProjectID})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get project %d", mapping.ProjectID)
+		}
+		if project == nil || project.Deleted {
+			return errors.Errorf("project %d not found in group mapping of %q", mapping.ProjectID, mapping.Group)
+		}
+		if mapping.ProjectRole != api.Owner && mapping.ProjectRole != api.Developer {
+			role, err := s.store.GetRole(ctx, string(mapping.ProjectRole))
+			if err != nil {
+				return errors.Wrapf(err, "failed to get role %q", mapping.ProjectRole)
+			}
+			if role == nil {
+				return errors.Errorf("project role %q not found in group mapping of %q", mapping.ProjectRole, mapping.Group)
+			}
+		}
+	}
+	return nil
+}
+
+// scimProjectMembership is a project role binding managed by the SCIM group mappings.
+type scimProjectMembership struct {
+	projectID int
+	role      api.Role
+}
+
+// syncSCIMUsers reconciles the workspace roles and the mapped project memberships of the users with the SCIM groups they are in.
+func (s *Server) syncSCIMUsers(ctx context.Context, userIDList []int) error {
+	if len(userIDList) == 0 {
+		return nil
+	}
+	setting, err := s.getSCIMSetting(ctx)
+	if err != nil {
+		return err
+	}
+	if len(setting.GroupMappingList) == 0 {
+		return nil
+	}
+	synced := make(map[int]bool)
+	for _, userID := range userIDList {
+		if synced[userID] {
+			continue
+		}
+		synced[userID] = true
+		if err := s.syncSCIMUser(ctx, userID, setting.GroupMappingList); err != nil {
+			return errors.Wrapf(err, "failed to sync SCIM groups of user %d", userID)
+		}
+	}
+	return nil
+}
+
+func (s *Server) syncSCIMUser(ctx context.Context, userID int, mappingList []*api.SCIMGroupMapping) error {
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.MemberDeleted || user.Type != api.EndUser {
+		return nil
+	}
+	groups, err := s.store.ListSCIMGroups(ctx, &store.FindSCIMGroupMessage{MemberID: &userID})
+	if err != nil {
+		return err
+	}
+	inGroup := make(map[string]bool)
+	for _, group := range groups {
+		inGroup[group.DisplayName] = true
+	}
+
+	// Workspace roles are managed only if some group is mapped to a workspace role,
+	// users out of all these groups fall back to Developer.
+	manageRole := false
+	role := api.Developer
+	// Only the project role bindings appearing in the mappings are managed, the other memberships are left untouched.
+	managed := make(map[scimProjectMembership]bool)
+	desired := make(map[scimProjectMembership]bool)
+	for _, mapping := range mappingList {
+		if mapping.Role != "" {
+			manageRole = true
+			// The highest role among the groups wins.
+			if inGroup[mapping.Group] && mapping.Role.HigherThan(role) {
+				role = mapping.Role
+			}
+		}
+		if mapping.ProjectID != 0 {
+			membership := scimProjectMembership{projectID: mapping.ProjectID, role: mapping.ProjectRole}
+			managed[membership] = true
+			if inGroup[mapping.Group] {
+				desired[membership] = true
+			}
+		}
+	}
+
+	if manageRole && user.Role != role {
+		if err := s.updateSCIMUserRole(ctx, user, role); err != nil {
+			return err
+		}
+	}
+
+	projectIDList := []int{}
+	managedRoles := make(map[int][]api.Role)
+	for membership := range managed {
+		if _, ok := managedRoles[membership.projectID]; !ok {
+			projectIDList = append(projectIDList, membership.projectID)
+		}
+		managedRoles[membership.projectID] = append(managedRoles[membership.projectID], membership.role)
+	}
+	sort.Ints(projectIDList)
+	for _, projectID := range projectIDList {
+		want := make(map[api.Role]bool)
+		for _, role := range managedRoles[projectID] {
+			want[role] = desired[scimProjectMembership{projectID: projectID, role: role}]
+		}
+		if err := s.syncSCIMProjectMembership(ctx, user, projectID, want); err != nil {
+			// A broken mapping of one project should not block the provisioning.
+			log.Warn("Failed to sync SCIM project membership",
+				zap.Int("user", user.ID),
+				zap.Int("project", projectID),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (s *Server) updateSCIMUserRole(ctx context.Context, user *store.UserMessage, role api.Role) error {
+	updated, err := s.store.UpdateUserWorkspaceRole(ctx, user, role, api.SystemBotID)
+	if err != nil {
+		return err
+	}
+	if updated.Role != role {
+		log.Warn("Skip demoting the last workspace owner by SCIM group mapping", zap.Int("user", user.ID))
+	}
+	return nil
+}
+
+// syncSCIMProjectMembership adds or removes the user from the project roles, want maps a managed role to whether the user should have it.
+func (s *Server) syncSCIMProjectMembership(ctx context.Context, user *store.UserMessage, projectID int, want map[api.Role]bool) error {
+	policy, err := s.store.GetProjectPolicy(ctx, &store.GetProjectPolicyMessage{UID: &projectID})
+	if err != nil {
+		return err
+	}
+	// The cached policy is shared, build a new one instead of modifying it.
+	newPolicy := &store.IAMPolicyMessage{}
+	changed := false
+	bound := make(map[api.Role]bool)
+	for _, binding := range policy.Bindings {
+		newBinding := &store.PolicyBinding{Role: binding.Role}
+		for _, member := range binding.Members {
+			if member.ID == user.ID {
+				if wanted, ok := want[binding.Role]; ok && !wanted {
+					changed = true
+					continue
+				}
+				bound[binding.Role] = true
+			}
+			newBinding.Members = append(newBinding.Members, member)
+		}
+		if len(newBinding.Members) > 0 {
+			newPolicy.Bindings = append(newPolicy.Bindings, newBinding)
+		}
+	}
+	for role, wanted := range want {
+		if !wanted || bound[role] {
+			continue
+		}
+		changed = true
+		var binding *store.PolicyBinding
+		for _, b := range newPolicy.Bindings {
+			if b.Role == role {
+				binding = b
+				break
+			}
+		}
+		if binding == nil {
+			binding = &store.PolicyBinding{Role: role}
+			newPolicy.Bindings = append(newPolicy.Bindings, binding)
+		}
+		binding.Members = append(binding.Members, user)
+	}
+	if !changed {
+		return nil
+	}
+
+	hasOwner := false
+	for _, binding := range newPolicy.Bindings {
+		if binding.Role == api.Owner && len(binding.Members) > 0 {
+			hasOwner = true
+		}
+	}
+	if !hasOwner {
+		return errors.Errorf("project %d must have at least one owner", projectID)
+	}
+	if _, err := s.store.SetProjectIAMPolicy(ctx, newPolicy, api.SystemBotID, projectID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// scimUserPatch is the change to a user from SCIM requests, nil fields are unchanged.
+type scimUserPatch struct {
+	email  *string
+	name   *string
+	active *bool
+}
+
+func getSCIMUserPatch(user *scim.User) (*scimUserPatch, error) {
+	patch := &scimUserPatch{active: user.Active}
+	// Bytebase identifies users by email, the userName is used if no email is provided.
+	email := user.PrimaryEmail()
+	if email == "" {
+		email = user.UserName
+	}
+	if email != "" {
+		if err := patch.setEmail(email); err != nil {
+			return nil, err
+		}
+	}
+	name := user.DisplayName
+	if name == "" {
+		name = user.Name.DisplayName()
+	}
+	if name != "" {
+		patch.name = &name
+	}
+	return patch, nil
+}
+
+func (p *scimUserPatch) setEmail(email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if err := validateEmail(email); err != nil {
+		return &common.Error{Code: common.Invalid, Err: errors.Wrapf(err, "invalid email %q", email)}
+	}
+	p.email = &email
+	return nil
+}
+
+// applyOperation applies a PATCH operation to the patch.
+// The attributes that Bytebase doesn't store are ignored so that identity providers
+// sending the full profile don't fail.
+func (p *scimUserPatch) applyOperation(operation *scim.PatchOperation) error {
+	switch strings.ToLower(operation.Op) {
+	case "add", "replace":
+	default:
+		return &common.Error{Code: common.Invalid, Err: errors.Errorf("unsupported operation %q on users", operation.Op)}
+	}
+	if operation.Path == "" {
+		values := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(operation.Value, &values); err != nil {
+			return &common.Error{Code: common.Invalid, Err: errors.Wrap(err, "operation value without path must be an object")}
+		}
+		for path, value := range values {
+			if err := p.applyAttribute(path, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return p.applyAttribute(operation.Path, operation.Value)
+}
+
+func (p *scimUserPatch) applyAttribute(path string, value json.RawMessage) error {
+	attribute := strings.ToLower(path)
+	invalid := func(err error) error {
+		return &common.Error{Code: common.Invalid, Err: errors.Wrapf(err, "invalid value of %q", path)}
+	}
+	switch {
+	case attribute == "active":
+		active, err := scim.ParseBool(value)
+		if err != nil {
+			return invalid(err)
+		}
+		p.active = &active
+	case attribute == "displayname" || attribute == "name.formatted":
+		var name string
+		if err := json.Unmarshal(value, &name); err != nil {
+			return invalid(err)
+		}
+		p.name = &name
+	case attribute == "name":
+		name := &scim.Name{}
+		if err := json.Unmarshal(value, name); err != nil {
+			return invalid(err)
+		}
+		if displayName := name.DisplayName(); displayName != "" {
+			p.name = &displayName
+		}
+	case attribute == "username":
+		var userName string
+		if err := json.Unmarshal(value, &userName); err != nil {
+			return invalid(err)
+		}
+		return p.setEmail(userName)
+	case strings.HasPrefix(attribute, "emails"):
+		// Either `emails` with the list or `emails[type eq "work"].value` with the address.
+		var email string
+		if err := json.Unmarshal(value, &email); err != nil {
+			user := &scim.User{}
+			if err := json.Unmarshal(value, &user.Emails); err != nil {
+				return invalid(err)
+			}
+			email = user.PrimaryEmail()
+		}
+		if email != "" {
+			return p.setEmail(email)
+		}
+	}
+	return nil
+}
+
+func (s *Server) updateSCIMUser(ctx context.Context, user *store.UserMessage, patch *scimUserPatch) (*store.UserMessage, error) {
+	update := &store.UpdateUserMessage{}
+	changed := false
+	if patch.email != nil && *patch.email != user.Email {
+		existing, err := s.store.GetUser(ctx, &store.FindUserMessage{Email: patch.email, ShowDeleted: true})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get user by email %q", *patch.email)
+		}
+		if existing != nil {
+			return nil, &common.Error{Code: common.Conflict, Err: errors.Errorf("user %q already exists", *patch.email)}
+		}
+		update.Email, changed = patch.email, true
+	}
+	if patch.name != nil && *patch.name != user.Name {
+		update.Name, changed = patch.name, true
+	}
+	if patch.active != nil && *patch.active == user.MemberDeleted {
+		if !*patch.active && user.Role == api.Owner {
+			isLastOwner, err := s.store.IsLastWorkspaceOwner(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if isLastOwner {
+				return nil, &common.Error{Code: common.Invalid, Err: errors.New("cannot deactivate the last workspace owner")}
+			}
+		}
+		deleted := !*patch.active
+		update.Delete, changed = &deleted, true
+	}
+	if !changed {
+		return user, nil
+	}
+
+	updated, err := s.store.UpdateUser(ctx, user.ID, update, api.SystemBotID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update user %d", user.ID)
+	}
+	if update.Delete != nil && !*update.Delete {
+		// Restore the role from the groups of the reactivated user.
+		if err := s.syncSCIMUsers(ctx, []int{user.ID}); err != nil {
+			return nil, err
+		}
+		if updated, err = s.store.GetUserByID(ctx, user.ID); err != nil {
+			return nil, errors.Wrapf(err, "failed to get user %d", user.ID)
+		}
+	}
+	return updated, nil
+}
+
+func (s *Server) getSCIMUser(ctx context.Context, id string) (*store.UserMessage, error) {
+	userID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("user %q not found", id)}
+	}
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get user %d", userID)
+	}
+	// Only end users are provisioned, service accounts and the system bot are not exposed.
+	if user == nil || user.Type != api.EndUser {
+		return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("user %q not found", id)}
+	}
+	return user, nil
+}
+
+var scimUserFilterAttributes = map[string]bool{
+	"id":             true,
+	"username":       true,
+	"emails":         true,
+	"emails.value":   true,
+	"displayname":    true,
+	"name.formatted": true,
+	"active":         true,
+}
+
+func matchSCIMUser(user *store.UserMessage, filter *scim.Filter) bool {
+	for _, condition := range filter.Conditions {
+		var match bool
+		switch condition.Attribute {
+		case "id":
+			match = strconv.Itoa(user.ID) == condition.Value
+		case "username", "emails", "emails.value":
+			match = strings.EqualFold(user.Email, condition.Value)
+		case "displayname", "name.formatted":
+			match = user.Name == condition.Value
+		case "active":
+			match = strconv.FormatBool(!user.MemberDeleted) == strings.ToLower(condition.Value)
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+// convertToSCIMUser converts the user to the SCIM resource, groups are filtered by the membership.
+func convertToSCIMUser(user *store.UserMessage, groups []*store.SCIMGroupMessage) *scim.User {
+	active := !user.MemberDeleted
+	scimUser := &scim.User{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          strconv.Itoa(user.ID),
+		UserName:    user.Email,
+		Name:        &scim.Name{Formatted: user.Name},
+		DisplayName: user.Name,
+		Emails:      []scim.MultiValuedAttribute{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta:        &scim.Meta{ResourceType: "User", Location: fmt.Sprintf("%s/Users/%d", scimAPIPrefix, user.ID)},
+	}
+	for _, group := range groups {
+		for _, member := range group.MemberList {
+			if member == user.ID {
+				scimUser.Groups = append(scimUser.Groups, scim.MultiValuedAttribute{
+					Value:   strconv.Itoa(group.ID),
+					Display: group.DisplayName,
+				})
+				break
+			}
+		}
+	}
+	return scimUser
+}
+
+func (s *Server) getSCIMGroup(ctx context.Context, id string) (*store.SCIMGroupMessage, error) {
+	groupID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("group %q not found", id)}
+	}
+	group, err := s.store.GetSCIMGroup(ctx, &store.FindSCIMGroupMessage{ID: &groupID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get SCIM group %d", groupID)
+	}
+	if group == nil {
+		return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("group %q not found", id)}
+	}
+	return group, nil
+}
+
+// getSCIMMemberList converts the SCIM members to the deduplicated principal IDs, all members must be end users.
+func (s *Server) getSCIMMemberList(ctx context.Context, members []scim.MultiValuedAttribute) ([]int, error) {
+	memberList := []int{}
+	for _, member := range members {
+		user, err := s.getSCIMUser(ctx, member.Value)
+		if err != nil {
+			if common.ErrorCode(err) == common.NotFound {
+				return nil, &common.Error{Code: common.Invalid, Err: errors.Errorf("member %q is not a provisioned user", member.Value)}
+			}
+			return nil, err
+		}
+		memberList = addSCIMMembers(memberList, []int{user.ID})
+	}
+	return memberList, nil
+}
+
+func addSCIMMembers(memberList []int, added []int) []int {
+	result := append([]int{}, memberList...)
+	for _, id := range added {
+		exists := false
+		for _, member := range result {
+			if member == id {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func removeSCIMMembers(memberList []int, removed []int) []int {
+	removedMap := make(map[int]bool)
+	for _, id := range removed {
+		removedMap[id] = true
+	}
+	result := []int{}
+	for _, member := range memberList {
+		if !removedMap[member] {
+			result = append(result, member)
+		}
+	}
+	return result
+}
+
+// applySCIMGroupOperation applies a PATCH operation to the group update and returns the new member list.
+func (s *Server) applySCIMGroupOperation(ctx context.Context, update *store.UpdateSCIMGroupMessage, memberList []int, operation *scim.PatchOperation) ([]int, error) {
+	op := strings.ToLower(operation.Op)
+	if op != "add" && op != "replace" && op != "remove" {
+		return nil, &common.Error{Code: common.Invalid, Err: errors.Errorf("unsupported operation %q on groups", operation.Op)}
+	}
+	invalid := func(err error) error {
+		return &common.Error{Code: common.Invalid, Err: errors.Wrapf(err, "invalid value of %q", operation.Path)}
+	}
+
+	if operation.Path == "" {
+		if op == "remove" {
+			return nil, &common.Error{Code: common.Invalid, Err: errors.New("path is required for the remove operation")}
+		}
+		values := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(operation.Value, &values); err != nil {
+			return nil, invalid(err)
+		}
+		for path, value := range values {
+			var err error
+			memberList, err = s.applySCIMGroupOperation(ctx, update, memberList, &scim.PatchOperation{Op: op, Path: path, Value: value})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return memberList, nil
+	}
+
+	value, isMemberPath, err := scim.ParseMemberPath(operation.Path)
+	if err != nil {
+		return nil, &common.Error{Code: common.Invalid, Err: err}
+	}
+	if isMemberPath {
+		if op != "remove" {
+			return nil, &common.Error{Code: common.Invalid, Err: errors.Errorf("unsupported operation %q on %q", operation.Op, operation.Path)}
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return memberList, nil
+		}
+		return removeSCIMMembers(memberList, []int{id}), nil
+	}
+
+	switch strings.ToLower(operation.Path) {
+	case "members":
+		var members []scim.MultiValuedAttribute
+		if len(operation.Value) > 0 {
+			if err := json.Unmarshal(operation.Value, &members); err != nil {
+				return nil, invalid(err)
+			}
+		}
+		switch op {
+		case "remove":
+			if len(operation.Value) == 0 {
+				return []int{}, nil
+			}
+			var removed []int
+			for _, member := range members {
+				if id, err := strconv.Atoi(member.Value); err == nil {
+					removed = append(removed, id)
+				}
+			}
+			return removeSCIMMembers(memberList, removed), nil
+		case "add":
+			added, err := s.getSCIMMemberList(ctx, members)
+			if err != nil {
+				return nil, err
+			}
+			return addSCIMMembers(memberList, added), nil
+		default:
+			return s.getSCIMMemberList(ctx, members)
+		}
+	case "displayname":
+		var displayName string
+		if err := json.Unmarshal(operation.Value, &displayName); err != nil || displayName == "" {
+			return nil, invalid(errors.New("displayName must be a non-empty string"))
+		}
+		update.DisplayName = &displayName
+	case "externalid":
+		externalID := ""
+		if op != "remove" {
+			if err := json.Unmarshal(operation.Value, &externalID); err != nil {
+				return nil, invalid(err)
+			}
+		}
+		update.ExternalID = &externalID
+	default:
+		return nil, &common.Error{Code: common.Invalid, Err: errors.Errorf("unsupported path %q on groups", operation.Path)}
+	}
+	return memberList, nil
+}
+
+// updateSCIMGroup updates the group and syncs the users whose groups may have changed.
+func (s *Server) updateSCIMGroup(ctx context.Context, group *store.SCIMGroupMessage, update *store.UpdateSCIMGroupMessage) (*store.SCIMGroupMessage, error) {
+	update.ID = group.ID
+	if v := update.DisplayName; v != nil && *v != group.DisplayName {
+		existing, err := s.store.GetSCIMGroup(ctx, &store.FindSCIMGroupMessage{DisplayName: v})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get SCIM group %q", *v)
+		}
+		if existing != nil {
+			return nil, &common.Error{Code: common.Conflict, Err: errors.Errorf("group %q already exists", *v)}
+		}
+	}
+	updated, err := s.store.UpdateSCIMGroup(ctx, update)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update SCIM group %d", group.ID)
+	}
+	// The mappings are keyed by the group name, so a renamed group affects all its members.
+	if err := s.syncSCIMUsers(ctx, addSCIMMembers(group.MemberList, updated.MemberList)); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+var scimGroupFilterAttributes = map[string]bool{
+	"id":          true,
+	"displayname": true,
+	"externalid":  true,
+}
+
+func matchSCIMGroup(group *store.SCIMGroupMessage, filter *scim.Filter) bool {
+	for _, condition := range filter.Conditions {
+		var match bool
+		switch condition.Attribute {
+		case "id":
+			match = strconv.Itoa(group.ID) == condition.Value
+		case "displayname":
+			match = group.DisplayName == condition.Value
+		case "externalid":
+			match = group.ExternalID == condition.Value
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) convertToSCIMGroup(ctx context.Context, group *store.SCIMGroupMessage, excludeMembers bool) (*scim.Group, error) {
+	scimGroup := &scim.Group{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          strconv.Itoa(group.ID),
+		ExternalID:  group.ExternalID,
+		DisplayName: group.DisplayName,
+		Members:     []scim.MultiValuedAttribute{},
+		Meta: &scim.Meta{
+			ResourceType: "Group",
+			Created:      time.Unix(group.CreatedTs, 0).UTC().Format(time.RFC3339),
+			LastModified: time.Unix(group.UpdatedTs, 0).UTC().Format(time.RFC3339),
+			Location:     fmt.Sprintf("%s/Groups/%d", scimAPIPrefix, group.ID),
+		},
+	}
+	if excludeMembers {
+		scimGroup.Members = nil
+		return scimGroup, nil
+	}
+	for _, member := range group.MemberList {
+		user, err := s.store.GetUserByID(ctx, member)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get user %d", member)
+		}
+		if user == nil {
+			continue
+		}
+		scimGroup.Members = append(scimGroup.Members, scim.MultiValuedAttribute{
+			Value:   strconv.Itoa(user.ID),
+			Display: user.Email,
+			Ref:     fmt.Sprintf("%s/Users/%d", scimAPIPrefix, user.ID),
+		})
+	}
+	return scimGroup, nil
+}
+
+func getSCIMPagination(c echo.Context) (int, int, error) {
+	startIndex, count := 1, -1
+	if v := c.QueryParam("startIndex"); v != "" {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, errors.Errorf("startIndex is not a number: %s", v)
+		}
+		startIndex = i
+	}
+	if v := c.QueryParam("count"); v != "" {
+		i, err := strconv.Atoi(v)
+		if err != nil || i < 0 {
+			return 0, 0, errors.Errorf("count is not a non-negative number: %s", v)
+		}
+		count = i
+	}
+	return startIndex, count, nil
+}
+
+func readSCIMRequest(c echo.Context, v any) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read request body")
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return errors.Wrap(err, "malformed request body")
+	}
+	return nil
+}
+
+func writeSCIMResponse(c echo.Context, code int, v any) error {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal SCIM response").SetInternal(err)
+	}
+	return c.Blob(code, scim.ContentType, bytes)
+}
+
+func writeSCIMError(c echo.Context, code int, scimType, detail string) error {
+	return writeSCIMResponse(c, code, &scim.Error{
+		Schemas:  []string{scim.SchemaError},
+		ScimType: scimType,
+		Detail:   detail,
+		Status:   strconv.Itoa(code),
+	})
+}
+
+func writeSCIMInternalError(c echo.Context, err error) error {
+	log.Error("SCIM request failed", zap.String("path", c.Request().URL.Path), zap.Error(err))
+	return writeSCIMError(c, http.StatusInternalServerError, "", "Internal server error")
+}
+
+// writeSCIMStoreError writes the error with the status code of its common.Error code.
+func writeSCIMStoreError(c echo.Context, err error) error {
+	switch common.ErrorCode(err) {
+	case common.NotFound:
+		return writeSCIMError(c, http.StatusNotFound, "", err.Error())
+	case common.Conflict:
+		return writeSCIMError(c, http.StatusConflict, scim.ErrorTypeUniqueness, err.Error())
+	case common.Invalid:
+		return writeSCIMError(c, http.StatusBadRequest, scim.ErrorTypeInvalidValue, err.Error())
+	}
+	return writeSCIMInternalError(c, err)
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/google/jsonapi"
+	"github.com/labstack/echo/v4"
+
+	"github.com/bytebase/bytebase/backend/component/state"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func (s *Server) registerSensitiveColumnSuggestionRoutes(g *echo.Group) {
+	g.GET("/sensitive-column-suggestion", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		find := &store.FindSensitiveColumnSuggestionMessage{}
+		if databaseIDStr := c.QueryParam("database"); databaseIDStr != "" {
+			databaseID, err := strconv.Atoi(databaseIDStr)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query parameter database is not a number: %s", databaseIDStr)).SetInternal(err)
+			}
+			find.DatabaseID = &databaseID
+		}
+		if status := c.QueryParam("status"); status != "" {
+			find.Status = (*api.SensitiveColumnSuggestionStatus)(&status)
+		}
+
+		suggestionList, err := s.store.ListSensitiveColumnSuggestion(ctx, find)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch sensitive column suggestion list").SetInternal(err)
+		}
+
+		apiSuggestionList := []*api.SensitiveColumnSuggestion{}
+		for _, suggestion := range suggestionList {
+			apiSuggestionList = append(apiSuggestionList, suggestion.ToAPISensitiveColumnSuggestion())
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, apiSuggestionList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal sensitive column suggestion list response").SetInternal(err)
+		}
+		return nil
+	})
+
+	g.POST("/sensitive-column-suggestion/scan", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		if !s.licenseService.IsFeatureEnabled(api.FeatureSensitiveData) {
+			return echo.NewHTTPError(http.StatusForbidden, api.FeatureSensitiveData.AccessErrorMessage())
+		}
+		databaseIDStr := c.QueryParam("database")
+		databaseID, err := strconv.Atoi(databaseIDStr)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query parameter database is not a number: %s", databaseIDStr)).SetInternal(err)
+		}
+		database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: &databaseID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch database ID: %v", databaseID)).SetInternal(err)
+		}
+		if database == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database not found with ID %d", databaseID))
+		}
+
+		select {
+		case s.stateCfg.SensitiveColumnScanChan <- &state.SensitiveColumnScan{DatabaseUID: database.UID}:
+		default:
+			return echo.NewHTTPError(http.StatusServiceUnavailable, "Too many sensitive column scans in the queue, please try again later")
+		}
+		return c.NoContent(http.StatusAccepted)
+	})
+
+	g.POST("/sensitive-column-suggestion/accept", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		if !s.licenseService.IsFeatureEnabled(api.FeatureSensitiveData) {
+			return echo.NewHTTPError(http.StatusForbidden, api.FeatureSensitiveData.AccessErrorMessage())
+		}
+		updaterID := c.Get(getPrincipalIDContextKey()).(int)
+		suggestionList, err := s.findOpenSensitiveColumnSuggestionList(c)
+		if err != nil {
+			return err
+		}
+
+		// Add the suggested columns to the sensitive data policy of each database.
+		databaseSuggestionMap := make(map[int][]*store.SensitiveColumnSuggestionMessage)
+		for _, suggestion := range suggestionList {
+			databaseSuggestionMap[suggestion.DatabaseID] = append(databaseSuggestionMap[suggestion.DatabaseID], suggestion)
+		}
+		for databaseID, databaseSuggestionList := range databaseSuggestionMap {
+			policy, err := s.store.GetSensitiveDataPolicy(ctx, databaseID)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get sensitive data policy for database %d", databaseID)).SetInternal(err)
+			}
+			sensitiveColumns := make(map[string]bool)
+			for _, data := range policy.SensitiveDataList {
+				sensitiveColumns[fmt.Sprintf("%s.%s.%s", data.Schema, data.Table, data.Column)] = true
+			}
+			for _, suggestion := range databaseSuggestionList {
+				key := fmt.Sprintf("%s.%s.%s", suggestion.SchemaName, suggestion.TableName, suggestion.ColumnName)
+				if sensitiveColumns[key] {
+					continue
+				}
+				sensitiveColumns[key] = true
+				policy.SensitiveDataList = append(policy.SensitiveDataList, api.SensitiveData{
+					Schema: suggestion.SchemaName,
+					Table:  suggestion.TableName,
+					Column: suggestion.ColumnName,
+					Type:   suggestion.MaskType,
+				})
+			}
+			payload, err := policy.String()
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal sensitive data policy").SetInternal(err)
+			}
+			if _, err := s.store.CreatePolicyV2(ctx, &store.PolicyMessage{
+				ResourceType:      api.PolicyResourceTypeDatabase,
+				ResourceUID:       databaseID,
+				Type:              api.PolicyTypeSensitiveData,
+				Payload:           payload,
+				InheritFromParent: false,
+				Enforce:           true,
+			}, updaterID); err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to update sensitive data policy for database %d", databaseID)).SetInternal(err)
+			}
+		}
+
+		return s.updateSensitiveColumnSuggestionStatus(c, suggestionList, api.SensitiveColumnSuggestionAccepted)
+	})
+
+	g.POST("/sensitive-column-suggestion/dismiss", func(c echo.Context) error {
+		suggestionList, err := s.findOpenSensitiveColumnSuggestionList(c)
+		if err != nil {
+			return err
+		}
+		return s.updateSensitiveColumnSuggestionStatus(c, suggestionList, api.SensitiveColumnSuggestionDismissed)
+	})
+}
+
+// findOpenSensitiveColumnSuggestionList finds the OPEN suggestions in the review request body.
+func (s *Server) findOpenSensitiveColumnSuggestionList(c echo.Context) ([]*store.SensitiveColumnSuggestionMessage, error) {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Failed to read request body").SetInternal(err)
+	}
+	review := &api.SensitiveColumnSuggestionReview{}
+	if err := json.Unmarshal(body, review); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Malformed sensitive column suggestion review request").SetInternal(err)
+	}
+	if len(review.IDList) == 0 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Empty sensitive column suggestion ID list")
+	}
+
+	openStatus := api.SensitiveColumnSuggestionOpen
+	suggestionList, err := s.store.ListSensitiveColumnSuggestion(c.Request().Context(), &store.FindSensitiveColumnSuggestionMessage{
+		IDList: &review.IDList,
+		Status: &openStatus,
+	})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch sensitive column suggestion list").SetInternal(err)
+	}
+	return suggestionList, nil
+}
+
+func (s *Server) updateSensitiveColumnSuggestionStatus(c echo.Context, suggestionList []*store.SensitiveColumnSuggestionMessage, status api.SensitiveColumnSuggestionStatus) error {
+	ctx := c.Request().Context()
+	apiSuggestionList := []*api.SensitiveColumnSuggestion{}
+	for _, suggestion := range suggestionList {
+		updated, err := s.store.UpdateSensitiveColumnSuggestion(ctx, &store.UpdateSensitiveColumnSuggestionMessage{
+			ID:     suggestion.ID,
+			Status: &status,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to update sensitive column suggestion ID: %v", suggestion.ID)).SetInternal(err)
+		}
+		apiSuggestionList = append(apiSuggestionList, updated.ToAPISensitiveColumnSuggestion())
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	if err := jsonapi.MarshalPayload(c.Response().Writer, apiSuggestionList); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal sensitive column suggestion list response").SetInternal(err)
+	}
+	return nil
+}
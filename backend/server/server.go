@@ -59,14 +59,19 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/app/feishu"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	metricPlugin "github.com/bytebase/bytebase/backend/plugin/metric"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+	bbazure "github.com/bytebase/bytebase/backend/plugin/storage/azure"
+	bbfilesystem "github.com/bytebase/bytebase/backend/plugin/storage/filesystem"
 	bbs3 "github.com/bytebase/bytebase/backend/plugin/storage/s3"
 	"github.com/bytebase/bytebase/backend/resources/mongoutil"
 	"github.com/bytebase/bytebase/backend/resources/mysqlutil"
 	"github.com/bytebase/bytebase/backend/resources/postgres"
+	"github.com/bytebase/bytebase/backend/runner/accessgrant"
 	"github.com/bytebase/bytebase/backend/runner/anomaly"
 	"github.com/bytebase/bytebase/backend/runner/approval"
 	"github.com/bytebase/bytebase/backend/runner/apprun"
 	"github.com/bytebase/bytebase/backend/runner/backuprun"
+	"github.com/bytebase/bytebase/backend/runner/classifier"
 	"github.com/bytebase/bytebase/backend/runner/mail"
 	"github.com/bytebase/bytebase/backend/runner/metricreport"
 	"github.com/bytebase/bytebase/backend/runner/rollbackrun"
@@ -74,6 +79,8 @@ import (
 	"github.com/bytebase/bytebase/backend/runner/slowquerysync"
 	"github.com/bytebase/bytebase/backend/runner/taskcheck"
 	"github.com/bytebase/bytebase/backend/runner/taskrun"
+	"github.com/bytebase/bytebase/backend/runner/vcspoll"
+	"github.com/bytebase/bytebase/backend/runner/webhookrun"
 	"github.com/bytebase/bytebase/backend/store"
 	_ "github.com/bytebase/bytebase/docs/openapi" // initial the swagger doc
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -110,11 +117,15 @@ import (
 	_ "github.com/bytebase/bytebase/backend/plugin/advisor/mysql"
 	// Register postgresql advisor.
 	_ "github.com/bytebase/bytebase/backend/plugin/advisor/pg"
+	// Register standard SQL advisor for Oracle and SQL Server.
+	_ "github.com/bytebase/bytebase/backend/plugin/advisor/standard"
 
 	// Register mysql differ driver.
 	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/differ/mysql"
 	// Register postgres differ driver.
 	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/differ/pg"
+	// Register oracle and mssql differ driver.
+	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/differ/standard"
 	// Register mysql edit driver.
 	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/edit/mysql"
 	// Register postgres edit driver.
@@ -132,6 +143,8 @@ const (
 	webhookAPIPrefix = "/hook"
 	// openAPIPrefix is the API prefix for Bytebase OpenAPI.
 	openAPIPrefix = "/v1"
+	// scimAPIPrefix is the API prefix for SCIM provisioning.
+	scimAPIPrefix = "/scim/v2"
 )
 
 // Server is the Bytebase server.
@@ -145,9 +158,13 @@ type Server struct {
 	MailSender         *mail.SlowQueryWeeklyMailSender
 	BackupRunner       *backuprun.Runner
 	AnomalyScanner     *anomaly.Scanner
+	SensitiveScanner   *classifier.Scanner
 	ApplicationRunner  *apprun.Runner
 	RollbackRunner     *rollbackrun.Runner
 	ApprovalRunner     *approval.Runner
+	VCSPoller          *vcspoll.Poller
+	WebhookRunner      *webhookrun.Runner
+	AccessGrantRunner  *accessgrant.Runner
 	runnerWG           sync.WaitGroup
 
 	ActivityManager *activity.Manager
@@ -166,6 +183,8 @@ type Server struct {
 	startedTs       int64
 	secret          string
 	errorRecordRing api.ErrorRecordRing
+	// sensitiveDataSalt is the server-only salt of the HASH and FORMAT_PRESERVING mask types.
+	sensitiveDataSalt string
 
 	// MySQL utility binaries
 	mysqlBinDir string
@@ -174,7 +193,7 @@ type Server struct {
 	// Postgres utility binaries
 	pgBinDir string
 
-	s3Client       *bbs3.Client
+	storageBackend storage.Backend
 	feishuProvider *feishu.Provider
 
 	// stateCfg is the shared in-momory state within the server.
@@ -313,6 +332,7 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 	s.stateCfg = &state.State{
 		InstanceDatabaseSyncChan:       make(chan *api.Instance, 100),
 		InstanceSlowQuerySyncChan:      make(chan *api.Instance, 100),
+		SensitiveColumnScanChan:        make(chan *state.SensitiveColumnScan, 1000),
 		InstanceOutstandingConnections: make(map[int]int),
 	}
 	s.store = storeInstance
@@ -328,6 +348,7 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 		return nil, errors.Wrap(err, "failed to init config")
 	}
 	s.secret = config.secret
+	s.sensitiveDataSalt = config.sensitiveDataSalt
 
 	s.ActivityManager = activity.NewManager(storeInstance)
 	s.dbFactory = dbfactory.New(s.mysqlBinDir, s.mongoBinDir, s.pgBinDir, profile.DataDir, s.secret)
@@ -393,17 +414,11 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 	embedFrontend(e)
 	s.e = e
 
-	if profile.BackupBucket != "" {
-		credentials, err := bbs3.GetCredentialsFromFile(ctx, profile.BackupCredentialFile)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get credentials from file")
-		}
-		s3Client, err := bbs3.NewClient(ctx, profile.BackupRegion, profile.BackupBucket, credentials)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to create AWS S3 client")
-		}
-		s.s3Client = s3Client
+	storageBackend, err := newStorageBackend(ctx, &profile)
+	if err != nil {
+		return nil, err
 	}
+	s.storageBackend = storageBackend
 
 	s.MetricReporter = metricreport.NewReporter(s.store, s.licenseService, &s.profile, false)
 	if !profile.Readonly {
@@ -412,23 +427,26 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 		// TODO(p0ny): enable Feishu provider only when it is needed.
 		s.feishuProvider = feishu.NewProvider(profile.FeishuAPIURL)
 		s.ApplicationRunner = apprun.NewRunner(storeInstance, s.ActivityManager, s.feishuProvider, profile)
-		s.BackupRunner = backuprun.NewRunner(storeInstance, s.dbFactory, s.s3Client, s.stateCfg, &profile)
+		s.BackupRunner = backuprun.NewRunner(storeInstance, s.dbFactory, s.storageBackend, s.stateCfg, s.secret, &profile)
 		s.RollbackRunner = rollbackrun.NewRunner(storeInstance, s.dbFactory, s.stateCfg)
 		s.ApprovalRunner = approval.NewRunner(storeInstance, s.dbFactory, s.stateCfg, s.ActivityManager, s.licenseService)
+		s.VCSPoller = vcspoll.NewPoller(storeInstance, s.processPushEvent)
+		s.WebhookRunner = webhookrun.NewRunner(storeInstance)
+		s.AccessGrantRunner = accessgrant.NewRunner(storeInstance, s.ActivityManager)
 
 		s.MailSender = mail.NewSender(s.store, s.stateCfg)
 
-		s.TaskScheduler = taskrun.NewScheduler(storeInstance, s.ApplicationRunner, s.SchemaSyncer, s.ActivityManager, s.licenseService, s.stateCfg, profile, s.MetricReporter)
+		s.TaskScheduler = taskrun.NewScheduler(storeInstance, s.dbFactory, s.ApplicationRunner, s.SchemaSyncer, s.ActivityManager, s.licenseService, s.stateCfg, profile, s.MetricReporter)
 		s.TaskScheduler.Register(api.TaskGeneral, taskrun.NewDefaultExecutor())
 		s.TaskScheduler.Register(api.TaskDatabaseCreate, taskrun.NewDatabaseCreateExecutor(storeInstance, s.dbFactory, s.SchemaSyncer, profile))
 		s.TaskScheduler.Register(api.TaskDatabaseSchemaBaseline, taskrun.NewSchemaBaselineExecutor(storeInstance, s.dbFactory, s.ActivityManager, s.licenseService, s.stateCfg, s.SchemaSyncer, profile))
 		s.TaskScheduler.Register(api.TaskDatabaseSchemaUpdate, taskrun.NewSchemaUpdateExecutor(storeInstance, s.dbFactory, s.ActivityManager, s.licenseService, s.stateCfg, s.SchemaSyncer, profile))
 		s.TaskScheduler.Register(api.TaskDatabaseSchemaUpdateSDL, taskrun.NewSchemaUpdateSDLExecutor(storeInstance, s.dbFactory, s.ActivityManager, s.licenseService, s.stateCfg, s.SchemaSyncer, profile))
 		s.TaskScheduler.Register(api.TaskDatabaseDataUpdate, taskrun.NewDataUpdateExecutor(storeInstance, s.dbFactory, s.ActivityManager, s.licenseService, s.stateCfg, profile))
-		s.TaskScheduler.Register(api.TaskDatabaseBackup, taskrun.NewDatabaseBackupExecutor(storeInstance, s.dbFactory, s.s3Client, profile))
+		s.TaskScheduler.Register(api.TaskDatabaseBackup, taskrun.NewDatabaseBackupExecutor(storeInstance, s.dbFactory, s.storageBackend, s.secret, profile))
 		s.TaskScheduler.Register(api.TaskDatabaseSchemaUpdateGhostSync, taskrun.NewSchemaUpdateGhostSyncExecutor(storeInstance, s.stateCfg, s.secret))
 		s.TaskScheduler.Register(api.TaskDatabaseSchemaUpdateGhostCutover, taskrun.NewSchemaUpdateGhostCutoverExecutor(storeInstance, s.dbFactory, s.ActivityManager, s.licenseService, s.stateCfg, s.SchemaSyncer, profile))
-		s.TaskScheduler.Register(api.TaskDatabaseRestorePITRRestore, taskrun.NewPITRRestoreExecutor(storeInstance, s.dbFactory, s.s3Client, s.SchemaSyncer, s.stateCfg, profile))
+		s.TaskScheduler.Register(api.TaskDatabaseRestorePITRRestore, taskrun.NewPITRRestoreExecutor(storeInstance, s.dbFactory, s.storageBackend, s.SchemaSyncer, s.stateCfg, s.secret, profile))
 		s.TaskScheduler.Register(api.TaskDatabaseRestorePITRCutover, taskrun.NewPITRCutoverExecutor(storeInstance, s.dbFactory, s.SchemaSyncer, s.BackupRunner, s.ActivityManager, profile))
 
 		s.TaskCheckScheduler = taskcheck.NewScheduler(storeInstance, s.licenseService, s.stateCfg)
@@ -445,14 +463,21 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 		s.TaskCheckScheduler.Register(api.TaskCheckGhostSync, ghostSyncExecutor)
 		pitrMySQLExecutor := taskcheck.NewPITRMySQLExecutor(storeInstance, s.dbFactory)
 		s.TaskCheckScheduler.Register(api.TaskCheckPITRMySQL, pitrMySQLExecutor)
+		pitrPostgresExecutor := taskcheck.NewPITRPostgresExecutor(storeInstance, s.dbFactory, s.storageBackend, profile)
+		s.TaskCheckScheduler.Register(api.TaskCheckPITRPostgres, pitrPostgresExecutor)
 		statementTypeReportExecutor := taskcheck.NewStatementTypeReportExecutor(storeInstance)
 		s.TaskCheckScheduler.Register(api.TaskCheckDatabaseStatementTypeReport, statementTypeReportExecutor)
 		statementAffectedRowsExecutor := taskcheck.NewStatementAffectedRowsReportExecutor(storeInstance, s.dbFactory)
 		s.TaskCheckScheduler.Register(api.TaskCheckDatabaseStatementAffectedRowsReport, statementAffectedRowsExecutor)
+		statementLockImpactExecutor := taskcheck.NewStatementLockImpactReportExecutor(storeInstance)
+		s.TaskCheckScheduler.Register(api.TaskCheckDatabaseStatementLockImpactReport, statementLockImpactExecutor)
 
 		// Anomaly scanner
 		s.AnomalyScanner = anomaly.NewScanner(storeInstance, s.dbFactory, s.licenseService)
 
+		// Sensitive column scanner
+		s.SensitiveScanner = classifier.NewScanner(storeInstance, s.dbFactory, s.stateCfg, s.licenseService)
+
 		// Metric reporter
 		s.initMetricReporter()
 	}
@@ -469,7 +494,7 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 			if s.profile.Mode == common.ReleaseModeProd && !s.profile.Debug {
 				return true
 			}
-			return !common.HasPrefixes(c.Path(), internalAPIPrefix, openAPIPrefix, webhookAPIPrefix)
+			return !common.HasPrefixes(c.Path(), internalAPIPrefix, openAPIPrefix, webhookAPIPrefix, scimAPIPrefix)
 		},
 		Format: `{"time":"${time_rfc3339}",` +
 			`"method":"${method}","uri":"${uri}",` +
@@ -482,6 +507,9 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 	webhookGroup := e.Group(webhookAPIPrefix)
 	s.registerWebhookRoutes(webhookGroup)
 
+	scimGroup := e.Group(scimAPIPrefix)
+	s.registerSCIMRoutes(scimGroup)
+
 	apiGroup := e.Group(internalAPIPrefix)
 	// API JWT authentication middleware.
 	apiGroup.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -524,6 +552,9 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 	s.registerSheetRoutes(apiGroup)
 	s.registerSheetOrganizerRoutes(apiGroup)
 	s.registerAnomalyRoutes(apiGroup)
+	s.registerSensitiveColumnSuggestionRoutes(apiGroup)
+	s.registerAccessGrantRoutes(apiGroup)
+	s.registerSCIMSettingRoutes(apiGroup)
 
 	// Register healthz endpoint.
 	e.GET("/healthz", func(c echo.Context) error {
@@ -656,6 +687,41 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 	return s, nil
 }
 
+// newStorageBackend creates the storage backend for backups and binlogs.
+// It returns nil if backups are stored in the local data directory.
+func newStorageBackend(ctx context.Context, profile *config.Profile) (storage.Backend, error) {
+	switch profile.BackupStorageBackend {
+	case api.BackupStorageBackendS3:
+		credentials, err := bbs3.GetCredentialsFromFile(ctx, profile.BackupCredentialFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get credentials from file")
+		}
+		s3Client, err := bbs3.NewClient(ctx, profile.BackupRegion, profile.BackupBucket, credentials)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create AWS S3 client")
+		}
+		return s3Client, nil
+	case api.BackupStorageBackendAzure:
+		credentials, err := bbazure.GetCredentialsFromFile(profile.BackupCredentialFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get credentials from file")
+		}
+		azureClient, err := bbazure.NewClient(profile.BackupBucket, credentials)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create Azure Blob Storage client")
+		}
+		return azureClient, nil
+	case api.BackupStorageBackendFilesystem:
+		filesystemClient, err := bbfilesystem.NewClient(profile.BackupBucket)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create file system storage client")
+		}
+		return filesystemClient, nil
+	default:
+		return nil, nil
+	}
+}
+
 func (s *Server) registerOpenAPIRoutes(e *echo.Echo, ce *casbin.Enforcer, prof config.Profile) {
 	jwtMiddlewareFunc := func(next echo.HandlerFunc) echo.HandlerFunc {
 		return JWTMiddleware(openAPIPrefix, s.store, next, prof.Mode, s.secret)
@@ -692,6 +758,8 @@ type workspaceConfig struct {
 	secret string
 	// workspaceID used to initial the identify for a new workspace.
 	workspaceID string
+	// sensitiveDataSalt used to mask the sensitive data with HASH and FORMAT_PRESERVING mask types.
+	sensitiveDataSalt string
 }
 
 func (s *Server) getInitSetting(ctx context.Context, datastore *store.Store) (*workspaceConfig, error) {
@@ -735,6 +803,21 @@ func (s *Server) getInitSetting(ctx context.Context, datastore *store.Store) (*w
 	}
 	conf.workspaceID = workspaceSetting.Value
 
+	// initial sensitive data salt
+	value, err = common.RandomString(secretLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate random sensitive data salt")
+	}
+	saltSetting, _, err := datastore.CreateSettingIfNotExistV2(ctx, &store.SettingMessage{
+		Name:        api.SettingSensitiveDataSalt,
+		Value:       value,
+		Description: "Random string used to salt the masked sensitive data.",
+	}, api.SystemBotID)
+	if err != nil {
+		return nil, err
+	}
+	conf.sensitiveDataSalt = saltSetting.Value
+
 	// initial license
 	if _, _, err = datastore.CreateSettingIfNotExistV2(ctx, &store.SettingMessage{
 		Name:        api.SettingEnterpriseLicense,
@@ -778,6 +861,15 @@ func (s *Server) getInitSetting(ctx context.Context, datastore *store.Store) (*w
 		return nil, err
 	}
 
+	// initial stage gate metric allowlist setting
+	if _, _, err := datastore.CreateSettingIfNotExistV2(ctx, &store.SettingMessage{
+		Name:        api.SettingStageGateMetricAllowlist,
+		Value:       "",
+		Description: "The private address ranges allowed for the stage gate metric endpoints",
+	}, api.SystemBotID); err != nil {
+		return nil, err
+	}
+
 	// initial workspace approval setting
 	approvalSettingValue, err := protojson.Marshal(&storepb.WorkspaceApprovalSetting{})
 	if err != nil {
@@ -854,11 +946,19 @@ func (s *Server) Run(ctx context.Context, port int) error {
 		s.runnerWG.Add(1)
 		go s.AnomalyScanner.Run(ctx, &s.runnerWG)
 		s.runnerWG.Add(1)
+		go s.SensitiveScanner.Run(ctx, &s.runnerWG)
+		s.runnerWG.Add(1)
 		go s.ApplicationRunner.Run(ctx, &s.runnerWG)
 		s.runnerWG.Add(1)
 		go s.RollbackRunner.Run(ctx, &s.runnerWG)
 		s.runnerWG.Add(1)
 		go s.ApprovalRunner.Run(ctx, &s.runnerWG)
+		s.runnerWG.Add(1)
+		go s.VCSPoller.Run(ctx, &s.runnerWG)
+		s.runnerWG.Add(1)
+		go s.WebhookRunner.Run(ctx, &s.runnerWG)
+		s.runnerWG.Add(1)
+		go s.AccessGrantRunner.Run(ctx, &s.runnerWG)
 
 		s.runnerWG.Add(1)
 		go s.MetricReporter.Run(ctx, &s.runnerWG)
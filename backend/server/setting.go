@@ -26,6 +26,7 @@ var whitelistSettings = []api.SettingName{
 	api.SettingPluginOpenAIKey,
 	api.SettingPluginOpenAIEndpoint,
 	api.SettingWorkspaceMailDelivery,
+	api.SettingStageGateMetricAllowlist,
 }
 
 func (s *Server) registerSettingRoutes(g *echo.Group) {
@@ -106,6 +107,12 @@ func (s *Server) registerSettingRoutes(g *echo.Group) {
 			settingPatch.Value = string(bytes)
 		}
 
+		if settingPatch.Name == api.SettingStageGateMetricAllowlist {
+			if _, err := api.ParseStageGateMetricAllowlist(settingPatch.Value); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid stage gate metric allowlist: %v", err))
+			}
+		}
+
 		if settingPatch.Name == api.SettingAppIM {
 			var value api.SettingAppIMValue
 			if err := json.Unmarshal([]byte(settingPatch.Value), &value); err != nil {
@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/jsonapi"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -17,6 +19,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/activity"
+	"github.com/bytebase/bytebase/backend/component/state"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	metricAPI "github.com/bytebase/bytebase/backend/metric"
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
@@ -29,6 +32,7 @@ import (
 
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
 	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
 )
 
 func (s *Server) registerSQLRoutes(g *echo.Group) {
@@ -250,123 +254,19 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 		}
 		principalID := c.Get(getPrincipalIDContextKey()).(int)
 		role := c.Get(getRoleContextKey()).(api.Role)
-		var database *store.DatabaseMessage
-		if exec.DatabaseName != "" {
-			database, err = s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{EnvironmentID: &instance.EnvironmentID, InstanceID: &instance.ResourceID, DatabaseName: &exec.DatabaseName})
-			if err != nil {
-				return err
-			}
-			if database == nil {
-				return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database %q not found", exec.DatabaseName))
-			}
-			// Database Access Control
-			hasAccessRights, err := s.hasDatabaseAccessRights(ctx, principalID, role, database)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to check access control for database: %q", exec.DatabaseName)).SetInternal(err)
-			}
-			if !hasAccessRights {
-				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Malformed sql execute request, no permission to access database %q", exec.DatabaseName))
-			}
-		}
-
-		// Database Access Control for MySQL dialect.
-		// MySQL dialect can query cross the database.
-		// We need special check.
-		if instance.Engine == db.MySQL || instance.Engine == db.TiDB || instance.Engine == db.MariaDB || instance.Engine == db.OceanBase {
-			databaseList, err := parser.ExtractDatabaseList(parser.MySQL, exec.Statement)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to extract database list: %q", exec.Statement)).SetInternal(err)
-			}
-
-			if exec.DatabaseName != "" {
-				// Disallow cross-database query if specify database.
-				for _, databaseName := range databaseList {
-					upperDatabaseName := strings.ToUpper(databaseName)
-					// We allow querying information schema.
-					if upperDatabaseName == "" || upperDatabaseName == "INFORMATION_SCHEMA" {
-						continue
-					}
-					if databaseName != exec.DatabaseName {
-						return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Malformed sql execute request, specify database %q but access database %q", exec.DatabaseName, databaseName))
-					}
-				}
-			} else {
-				// Check database access rights.
-				for _, databaseName := range databaseList {
-					if databaseName == "" {
-						// We have already checked the current database access rights.
-						continue
-					}
-					accessDatabase, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{EnvironmentID: &instance.EnvironmentID, InstanceID: &instance.ResourceID, DatabaseName: &databaseName})
-					if err != nil {
-						if httpErr, ok := err.(*echo.HTTPError); ok && httpErr.Code == echo.ErrNotFound.Code {
-							// If database not found, skip.
-							continue
-						}
-						return err
-					}
-
-					hasAccessRights, err := s.hasDatabaseAccessRights(ctx, principalID, role, accessDatabase)
-					if err != nil {
-						return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to check access control for database: %q", accessDatabase.DatabaseName)).SetInternal(err)
-					}
-					if !hasAccessRights {
-						return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Malformed sql execute request, no permission to access database %q", accessDatabase.DatabaseName))
-					}
-				}
-			}
+		database, err := s.checkQueryAccess(ctx, principalID, role, getAccessTokenProjectUID(c), instance, exec.DatabaseName, exec.Statement)
+		if err != nil {
+			return err
 		}
 
 		adviceLevel := advisor.Success
 		adviceList := []advisor.Advice{}
 
 		if api.IsSQLReviewSupported(instance.Engine) && exec.DatabaseName != "" {
-			dbType, err := advisorDB.ConvertToAdvisorDBType(string(instance.Engine))
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to convert db type %v into advisor db type", instance.Engine))
-			}
-			dbSchema, err := s.store.GetDBSchema(ctx, database.UID)
+			adviceLevel, adviceList, err = s.checkSQLReviewPolicy(ctx, instance, database, environment.UID, exec.DatabaseName, exec.Statement)
 			if err != nil {
 				return err
 			}
-			// The schema isn't loaded yet, let's load it on-demand.
-			if dbSchema == nil {
-				if err := s.SchemaSyncer.SyncDatabaseSchema(ctx, database, true /* force */); err != nil {
-					return err
-				}
-			}
-			dbSchema, err = s.store.GetDBSchema(ctx, database.UID)
-			if err != nil {
-				return err
-			}
-			if dbSchema == nil {
-				return errors.Errorf("database schema %v not found", database.UID)
-			}
-
-			catalog, err := s.store.NewCatalog(ctx, database.UID, instance.Engine, advisor.SyntaxModeNormal)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create a catalog")
-			}
-
-			driver, err := s.dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, exec.DatabaseName)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get database driver").SetInternal(err)
-			}
-			defer driver.Close(ctx)
-			connection := driver.GetDB()
-			adviceLevel, adviceList, err = s.sqlCheck(
-				ctx,
-				dbType,
-				dbSchema.Metadata.CharacterSet,
-				dbSchema.Metadata.Collation,
-				environment.UID,
-				exec.Statement,
-				catalog,
-				connection,
-			)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check SQL review policy").SetInternal(err)
-			}
 
 			if adviceLevel == advisor.Error {
 				if err := s.createSQLEditorQueryActivity(ctx, c, api.ActivityError, exec.InstanceID, api.ActivitySQLEditorQueryPayload{
@@ -394,23 +294,17 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 			}
 		}
 
-		var sensitiveSchemaInfo *db.SensitiveSchemaInfo
-		switch instance.Engine {
-		case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
-			databaseList, err := parser.ExtractDatabaseList(parser.MySQL, exec.Statement)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get database list: %s", exec.Statement)).SetInternal(err)
-			}
-
-			sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, databaseList, exec.DatabaseName)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get sensitive schema info: %s", exec.Statement)).SetInternal(err)
-			}
-		case db.Postgres:
-			sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, []string{exec.DatabaseName}, exec.DatabaseName)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get sensitive schema info: %s", exec.Statement)).SetInternal(err)
-			}
+		sensitiveSchemaInfo, err := s.getSensitiveSchemaInfoForStatement(ctx, instance, exec.DatabaseName, exec.Statement)
+		if err != nil {
+			return err
+		}
+		statement, err := s.getStatementWithRowFilter(ctx, principalID, role, instance, exec.DatabaseName, exec.Statement)
+		if err != nil {
+			return err
+		}
+		runningQuery, err := s.newSQLRunningQuery(ctx, principalID, instance, environment.UID, exec.DatabaseName, exec.Statement)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get SQL query policy for environment %q", environment.ResourceID)).SetInternal(err)
 		}
 
 		start := time.Now().UnixNano()
@@ -424,7 +318,9 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 
 			// TODO(p0ny): refactor
 			if instance.Engine == db.MongoDB || instance.Engine == db.Spanner || instance.Engine == db.Redis {
-				data, err := driver.QueryConn(ctx, nil, exec.Statement, &db.QueryContext{
+				queryCtx, finish := s.startRunningQuery(ctx, runningQuery, instance.Engine, nil, nil)
+				defer finish()
+				data, err := driver.QueryConn(queryCtx, nil, statement, &db.QueryContext{
 					Limit:                 exec.Limit,
 					ReadOnly:              true,
 					CurrentDatabase:       exec.DatabaseName,
@@ -432,7 +328,7 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 					SensitiveSchemaInfo:   sensitiveSchemaInfo,
 				})
 				if err != nil {
-					return nil, err
+					return nil, convertRunningQueryError(queryCtx, runningQuery, err)
 				}
 
 				dataJSON, err := json.Marshal(data)
@@ -458,17 +354,18 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 
 			var singleSQLResults []api.SingleSQLResult
 
-			rowSet, err := driver.QueryConn(ctx, conn, exec.Statement, &db.QueryContext{
-				Limit:           exec.Limit,
-				ReadOnly:        true,
-				CurrentDatabase: exec.DatabaseName,
-				// TODO(rebelice): we cannot deal with multi-SensitiveDataMaskType now. Fix it.
+			queryCtx, finish := s.startRunningQuery(ctx, runningQuery, instance.Engine, sqlDB, conn)
+			defer finish()
+			rowSet, err := driver.QueryConn(queryCtx, conn, statement, &db.QueryContext{
+				Limit:                 exec.Limit,
+				ReadOnly:              true,
+				CurrentDatabase:       exec.DatabaseName,
 				SensitiveDataMaskType: db.SensitiveDataMaskTypeDefault,
 				SensitiveSchemaInfo:   sensitiveSchemaInfo,
 			})
 			if err != nil {
 				singleSQLResults = append(singleSQLResults, api.SingleSQLResult{
-					Error: err.Error(),
+					Error: convertRunningQueryError(queryCtx, runningQuery, err).Error(),
 				})
 				//nolint
 				return singleSQLResults, nil
@@ -585,6 +482,359 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 		return nil
 	})
 
+	g.POST("/sql/export", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		export := &api.SQLExport{}
+		if err := jsonapi.UnmarshalPayload(c.Request().Body, export); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql export request").SetInternal(err)
+		}
+
+		if export.InstanceID == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql export request, missing instanceId")
+		}
+		if len(export.Statement) == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql export request, missing sql statement")
+		}
+		contentType, extension, err := getSQLExportContentType(export.Format)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Malformed sql export request, %v", err))
+		}
+
+		instance, err := s.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &export.InstanceID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch instance ID: %v", export.InstanceID)).SetInternal(err)
+		}
+		if instance == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Instance ID not found: %d", export.InstanceID))
+		}
+		// Only export from the engines supporting sensitive data masking, so that the exported file never leaks the sensitive data.
+		switch instance.Engine {
+		case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase, db.Postgres:
+		default:
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Exporting query result is not supported for %s", instance.Engine))
+		}
+		if !parser.ValidateSQLForEditor(convertToParserEngine(instance.Engine), export.Statement) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql export request, only support SELECT sql statement")
+		}
+
+		environment, err := s.store.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{ResourceID: &instance.EnvironmentID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch environment ID: %s", instance.EnvironmentID)).SetInternal(err)
+		}
+		if environment == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Environment ID not found: %s", instance.EnvironmentID))
+		}
+		principalID := c.Get(getPrincipalIDContextKey()).(int)
+		role := c.Get(getRoleContextKey()).(api.Role)
+		exportPolicy, err := s.store.GetSQLExportPolicy(ctx, environment.UID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get SQL export policy for environment %q", environment.ResourceID)).SetInternal(err)
+		}
+		if exportPolicy.DisallowExport && role != api.Owner && role != api.DBA {
+			return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("Exporting query result is disallowed in environment %q", environment.Title))
+		}
+
+		database, err := s.checkQueryAccess(ctx, principalID, role, getAccessTokenProjectUID(c), instance, export.DatabaseName, export.Statement)
+		if err != nil {
+			return err
+		}
+		if api.IsSQLReviewSupported(instance.Engine) && export.DatabaseName != "" {
+			adviceLevel, adviceList, err := s.checkSQLReviewPolicy(ctx, instance, database, environment.UID, export.DatabaseName, export.Statement)
+			if err != nil {
+				return err
+			}
+			if adviceLevel == advisor.Error {
+				var messages []string
+				for _, advice := range adviceList {
+					if advice.Status == advisor.Error {
+						messages = append(messages, fmt.Sprintf("%s: %s", advice.Title, advice.Content))
+					}
+				}
+				errMessage := fmt.Sprintf("SQL review failed: %s", strings.Join(messages, "; "))
+				if err := s.createSQLEditorExportActivity(ctx, c, api.ActivityError, export.InstanceID, api.ActivitySQLEditorExportPayload{
+					Statement:    export.Statement,
+					Format:       export.Format,
+					InstanceID:   instance.UID,
+					DatabaseID:   database.UID,
+					DatabaseName: export.DatabaseName,
+					Error:        errMessage,
+				}); err != nil {
+					return err
+				}
+				return echo.NewHTTPError(http.StatusBadRequest, errMessage)
+			}
+		}
+		sensitiveSchemaInfo, err := s.getSensitiveSchemaInfoForStatement(ctx, instance, export.DatabaseName, export.Statement)
+		if err != nil {
+			return err
+		}
+		statement, err := s.getStatementWithRowFilter(ctx, principalID, role, instance, export.DatabaseName, export.Statement)
+		if err != nil {
+			return err
+		}
+		runningQuery, err := s.newSQLRunningQuery(ctx, principalID, instance, environment.UID, export.DatabaseName, export.Statement)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get SQL query policy for environment %q", environment.ResourceID)).SetInternal(err)
+		}
+
+		driver, err := s.dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, export.DatabaseName)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get database driver").SetInternal(err)
+		}
+		defer driver.Close(ctx)
+		conn, err := driver.GetDB().Conn(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get database connection").SetInternal(err)
+		}
+		defer conn.Close()
+
+		// The response is committed on the first write, so the headers must be set before exporting.
+		// The rows are streamed to the client without buffering the whole result set.
+		resp := c.Response()
+		resp.Header().Set(echo.HeaderContentType, contentType)
+		resp.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("export-%s.%s", time.Now().Format("20060102T150405"), extension)))
+		var rowWriter util.RowWriter
+		switch export.Format {
+		case api.SQLExportFormatCSV:
+			rowWriter = util.NewCSVRowWriter(resp)
+		case api.SQLExportFormatJSON:
+			rowWriter = util.NewJSONRowWriter(resp)
+		case api.SQLExportFormatSQL:
+			tableName := export.TableName
+			if tableName == "" {
+				tableName = "export"
+			}
+			rowWriter = util.NewSQLRowWriter(resp, instance.Engine, tableName)
+		case api.SQLExportFormatXLSX:
+			rowWriter = util.NewXLSXRowWriter(resp)
+		}
+
+		start := time.Now().UnixNano()
+		queryCtx, finish := s.startRunningQuery(ctx, runningQuery, instance.Engine, driver.GetDB(), conn)
+		rowCount, exportErr := util.Export(queryCtx, instance.Engine, conn, statement, &db.QueryContext{
+			Limit:                 exportPolicy.MaxRowCount,
+			ReadOnly:              true,
+			CurrentDatabase:       export.DatabaseName,
+			SensitiveDataMaskType: db.SensitiveDataMaskTypeDefault,
+			SensitiveSchemaInfo:   sensitiveSchemaInfo,
+		}, rowWriter)
+		exportErr = convertRunningQueryError(queryCtx, runningQuery, exportErr)
+		finish()
+
+		level := api.ActivityInfo
+		errMessage := ""
+		if exportErr != nil {
+			level = api.ActivityError
+			errMessage = exportErr.Error()
+		}
+		var databaseID int
+		if database != nil {
+			databaseID = database.UID
+		}
+		if err := s.createSQLEditorExportActivity(ctx, c, level, export.InstanceID, api.ActivitySQLEditorExportPayload{
+			Statement:    export.Statement,
+			Format:       export.Format,
+			RowCount:     rowCount,
+			DurationNs:   time.Now().UnixNano() - start,
+			InstanceID:   instance.UID,
+			DatabaseID:   databaseID,
+			DatabaseName: export.DatabaseName,
+			Error:        errMessage,
+		}); err != nil {
+			if !resp.Committed {
+				return err
+			}
+			log.Error("Failed to create activity after exporting query result", zap.Error(err))
+		}
+
+		if exportErr != nil {
+			log.Error("Failed to export query result",
+				zap.Error(exportErr),
+				zap.String("statement", export.Statement),
+				zap.Int64("row_count", rowCount),
+			)
+		}
+		return finishSQLExport(resp, exportErr)
+	})
+
+	g.POST("/sql/explain", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		explain := &api.SQLExplain{}
+		if err := jsonapi.UnmarshalPayload(c.Request().Body, explain); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql explain request").SetInternal(err)
+		}
+
+		if explain.InstanceID == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql explain request, missing instanceId")
+		}
+		if len(explain.Statement) == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql explain request, missing sql statement")
+		}
+
+		instance, err := s.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &explain.InstanceID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch instance ID: %v", explain.InstanceID)).SetInternal(err)
+		}
+		if instance == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Instance ID not found: %d", explain.InstanceID))
+		}
+		if !util.IsExplainSupported(instance.Engine) {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Explaining query plan is not supported for %s", instance.Engine))
+		}
+		if !parser.ValidateSQLForEditor(convertToParserEngine(instance.Engine), explain.Statement) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql explain request, only support SELECT sql statement")
+		}
+		// ANALYZE executes the statement, only run it on read replicas to keep the load away from the primary.
+		if explain.Analyze && !hasReadReplica(instance) {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Analyzing query plan requires a read replica as the read-only data source of instance %q", instance.Title))
+		}
+
+		environment, err := s.store.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{ResourceID: &instance.EnvironmentID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch environment ID: %s", instance.EnvironmentID)).SetInternal(err)
+		}
+		if environment == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Environment ID not found: %s", instance.EnvironmentID))
+		}
+		principalID := c.Get(getPrincipalIDContextKey()).(int)
+		role := c.Get(getRoleContextKey()).(api.Role)
+		database, err := s.checkQueryAccess(ctx, principalID, role, getAccessTokenProjectUID(c), instance, explain.DatabaseName, explain.Statement)
+		if err != nil {
+			return err
+		}
+		// The query plan and ANALYZE may reveal the filtered rows, so we explain the statement with the row filters applied.
+		statement, err := s.getStatementWithRowFilter(ctx, principalID, role, instance, explain.DatabaseName, explain.Statement)
+		if err != nil {
+			return err
+		}
+		runningQuery, err := s.newSQLRunningQuery(ctx, principalID, instance, environment.UID, explain.DatabaseName, explain.Statement)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get SQL query policy for environment %q", environment.ResourceID)).SetInternal(err)
+		}
+
+		driver, err := s.dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, explain.DatabaseName)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get database driver").SetInternal(err)
+		}
+		defer driver.Close(ctx)
+		conn, err := driver.GetDB().Conn(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get database connection").SetInternal(err)
+		}
+		defer conn.Close()
+
+		start := time.Now().UnixNano()
+		queryCtx, finish := s.startRunningQuery(ctx, runningQuery, instance.Engine, driver.GetDB(), conn)
+		plan, explainErr := util.Explain(queryCtx, instance.Engine, conn, statement, explain.Analyze)
+		explainErr = convertRunningQueryError(queryCtx, runningQuery, explainErr)
+		finish()
+
+		var adviceList []advisor.Advice
+		if explainErr == nil {
+			tableRows := map[string]int64{}
+			if database != nil {
+				dbSchema, err := s.store.GetDBSchema(ctx, database.UID)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get schema of database %q", database.DatabaseName)).SetInternal(err)
+				}
+				if dbSchema != nil {
+					tableRows = getTableRowCounts(dbSchema)
+				}
+			}
+			adviceList = checkExplainPlan(plan, tableRows)
+		}
+
+		level := api.ActivityInfo
+		errMessage := ""
+		if explainErr != nil {
+			level = api.ActivityError
+			errMessage = explainErr.Error()
+		} else {
+			for _, advice := range adviceList {
+				if advice.Status == advisor.Warn {
+					level = api.ActivityWarn
+				}
+			}
+		}
+		var databaseID int
+		if database != nil {
+			databaseID = database.UID
+		}
+		activityStatement := "EXPLAIN " + explain.Statement
+		if explain.Analyze {
+			activityStatement = "EXPLAIN ANALYZE " + explain.Statement
+		}
+		if err := s.createSQLEditorQueryActivity(ctx, c, level, explain.InstanceID, api.ActivitySQLEditorQueryPayload{
+			Statement:              activityStatement,
+			DurationNs:             time.Now().UnixNano() - start,
+			InstanceID:             instance.UID,
+			DeprecatedInstanceName: instance.Title,
+			DatabaseID:             databaseID,
+			DatabaseName:           explain.DatabaseName,
+			Error:                  errMessage,
+			AdviceList:             adviceList,
+		}); err != nil {
+			return err
+		}
+		if explainErr != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Failed to explain query plan: %v", explainErr)).SetInternal(explainErr)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, &api.SQLExplainResult{
+			Plan:       plan,
+			AdviceList: adviceList,
+		}); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal sql explain result response").SetInternal(err)
+		}
+		return nil
+	})
+
+	g.GET("/sql/query", func(c echo.Context) error {
+		principalID := c.Get(getPrincipalIDContextKey()).(int)
+		role := c.Get(getRoleContextKey()).(api.Role)
+
+		// Workspace owners and DBAs can see the running queries of all users.
+		runningQueryList := []*api.SQLRunningQuery{}
+		s.stateCfg.RunningQueries.Range(func(_, value any) bool {
+			query := value.(*state.RunningQuery).Query
+			if role == api.Owner || role == api.DBA || query.CreatorID == principalID {
+				runningQueryList = append(runningQueryList, query)
+			}
+			return true
+		})
+		sort.Slice(runningQueryList, func(i, j int) bool {
+			return runningQueryList[i].StartedTs < runningQueryList[j].StartedTs
+		})
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, runningQueryList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal running query list response").SetInternal(err)
+		}
+		return nil
+	})
+
+	g.DELETE("/sql/query/:queryID", func(c echo.Context) error {
+		queryID := c.Param("queryID")
+		principalID := c.Get(getPrincipalIDContextKey()).(int)
+		role := c.Get(getRoleContextKey()).(api.Role)
+
+		value, ok := s.stateCfg.RunningQueries.Load(queryID)
+		if !ok {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Running query not found: %s", queryID))
+		}
+		runningQuery := value.(*state.RunningQuery)
+		// Workspace owners and DBAs can cancel the running queries of all users.
+		if role != api.Owner && role != api.DBA && runningQuery.Query.CreatorID != principalID {
+			return echo.NewHTTPError(http.StatusForbidden, "Only workspace owners and DBAs can cancel the queries of other users")
+		}
+		runningQuery.Cancel()
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		c.Response().WriteHeader(http.StatusOK)
+		return nil
+	})
+
 	g.POST("/sql/execute/admin", func(c echo.Context) error {
 		ctx := c.Request().Context()
 		exec := &api.SQLExecute{}
@@ -838,6 +1088,210 @@ func (s *Server) createSQLEditorQueryActivity(ctx context.Context, c echo.Contex
 	return nil
 }
 
+func (s *Server) createSQLEditorExportActivity(ctx context.Context, c echo.Context, level api.ActivityLevel, containerID int, payload api.ActivitySQLEditorExportPayload) error {
+	activityBytes, err := json.Marshal(payload)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to construct activity payload").SetInternal(err)
+	}
+
+	activityCreate := &api.ActivityCreate{
+		CreatorID:   c.Get(getPrincipalIDContextKey()).(int),
+		Type:        api.ActivitySQLEditorExport,
+		ContainerID: containerID,
+		Level:       level,
+		Comment: fmt.Sprintf("Exported %d rows of `%q` in database %q of instance %d as %s.",
+			payload.RowCount, payload.Statement, payload.DatabaseName, payload.InstanceID, payload.Format),
+		Payload: string(activityBytes),
+	}
+
+	if _, err = s.ActivityManager.CreateActivity(ctx, activityCreate, &activity.Metadata{}); err != nil {
+		log.Warn("Failed to create activity after exporting query result",
+			zap.String("database_name", payload.DatabaseName),
+			zap.Int("instance_id", payload.InstanceID),
+			zap.String("statement", payload.Statement),
+			zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create activity").SetInternal(err)
+	}
+	return nil
+}
+
+// finishSQLExport completes the export response.
+// The status code cannot be changed once the rows start streaming, so a failure after the response is committed
+// aborts the connection. Otherwise the client would receive a truncated file that looks complete.
+func finishSQLExport(resp *echo.Response, exportErr error) error {
+	if exportErr == nil {
+		if !resp.Committed {
+			resp.WriteHeader(http.StatusOK)
+		}
+		return nil
+	}
+	if resp.Committed {
+		panic(http.ErrAbortHandler)
+	}
+	return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Failed to export query result: %v", exportErr)).SetInternal(exportErr)
+}
+
+// getSQLExportContentType returns the content type and the file extension of the export format.
+func getSQLExportContentType(format api.SQLExportFormat) (string, string, error) {
+	switch format {
+	case api.SQLExportFormatCSV:
+		return "text/csv; charset=utf-8", "csv", nil
+	case api.SQLExportFormatJSON:
+		return "application/x-ndjson; charset=utf-8", "jsonl", nil
+	case api.SQLExportFormatSQL:
+		return "application/sql; charset=utf-8", "sql", nil
+	case api.SQLExportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx", nil
+	}
+	return "", "", errors.Errorf("unsupported export format %q", format)
+}
+
+// checkQueryAccess checks if the principal can query the database and the databases accessed by the statement.
+// If the request is authenticated by a personal access token restricted to a project, only the databases in the
+// project can be queried. It returns the database if the database name is not empty.
+func (s *Server) checkQueryAccess(ctx context.Context, principalID int, role api.Role, restrictedProjectUID *int, instance *store.InstanceMessage, databaseName string, statement string) (*store.DatabaseMessage, error) {
+	var database *store.DatabaseMessage
+	var err error
+	if restrictedProjectUID != nil && databaseName == "" {
+		return nil, echo.NewHTTPError(http.StatusForbidden, "The personal access token is restricted to a project, the database must be specified")
+	}
+	if databaseName != "" {
+		database, err = s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{EnvironmentID: &instance.EnvironmentID, InstanceID: &instance.ResourceID, DatabaseName: &databaseName})
+		if err != nil {
+			return nil, err
+		}
+		if database == nil {
+			return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database %q not found", databaseName))
+		}
+		if err := s.checkAccessTokenProject(ctx, restrictedProjectUID, database); err != nil {
+			return nil, err
+		}
+		// Database Access Control
+		hasAccessRights, err := s.hasDatabaseAccessRights(ctx, principalID, role, database)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to check access control for database: %q", databaseName)).SetInternal(err)
+		}
+		if !hasAccessRights {
+			if hasAccessRights, err = s.hasAccessGrant(ctx, principalID, instance.Engine, database, statement); err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to check access grant for database: %q", databaseName)).SetInternal(err)
+			}
+		}
+		if !hasAccessRights {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Malformed sql execute request, no permission to access database %q", databaseName))
+		}
+	}
+
+	// Database Access Control for MySQL dialect.
+	// MySQL dialect can query cross the database.
+	// We need special check.
+	if instance.Engine == db.MySQL || instance.Engine == db.TiDB || instance.Engine == db.MariaDB || instance.Engine == db.OceanBase {
+		databaseList, err := parser.ExtractDatabaseList(parser.MySQL, statement)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to extract database list: %q", statement)).SetInternal(err)
+		}
+
+		if databaseName != "" {
+			// Disallow cross-database query if specify database.
+			for _, accessDatabaseName := range databaseList {
+				upperDatabaseName := strings.ToUpper(accessDatabaseName)
+				// We allow querying information schema.
+				if upperDatabaseName == "" || upperDatabaseName == "INFORMATION_SCHEMA" {
+					continue
+				}
+				if accessDatabaseName != databaseName {
+					return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Malformed sql execute request, specify database %q but access database %q", databaseName, accessDatabaseName))
+				}
+			}
+		} else {
+			// Check database access rights.
+			for _, accessDatabaseName := range databaseList {
+				if accessDatabaseName == "" {
+					// We have already checked the current database access rights.
+					continue
+				}
+				accessDatabase, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{EnvironmentID: &instance.EnvironmentID, InstanceID: &instance.ResourceID, DatabaseName: &accessDatabaseName})
+				if err != nil {
+					if httpErr, ok := err.(*echo.HTTPError); ok && httpErr.Code == echo.ErrNotFound.Code {
+						// If database not found, skip.
+						continue
+					}
+					return nil, err
+				}
+				if accessDatabase == nil {
+					continue
+				}
+				if err := s.checkAccessTokenProject(ctx, restrictedProjectUID, accessDatabase); err != nil {
+					return nil, err
+				}
+
+				hasAccessRights, err := s.hasDatabaseAccessRights(ctx, principalID, role, accessDatabase)
+				if err != nil {
+					return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to check access control for database: %q", accessDatabase.DatabaseName)).SetInternal(err)
+				}
+				if !hasAccessRights {
+					if hasAccessRights, err = s.hasAccessGrant(ctx, principalID, instance.Engine, accessDatabase, statement); err != nil {
+						return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to check access grant for database: %q", accessDatabase.DatabaseName)).SetInternal(err)
+					}
+				}
+				if !hasAccessRights {
+					return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Malformed sql execute request, no permission to access database %q", accessDatabase.DatabaseName))
+				}
+			}
+		}
+	}
+	return database, nil
+}
+
+// checkSQLReviewPolicy checks the statement against the SQL review policy of the environment.
+func (s *Server) checkSQLReviewPolicy(ctx context.Context, instance *store.InstanceMessage, database *store.DatabaseMessage, environmentID int, databaseName string, statement string) (advisor.Status, []advisor.Advice, error) {
+	dbType, err := advisorDB.ConvertToAdvisorDBType(string(instance.Engine))
+	if err != nil {
+		return advisor.Error, nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to convert db type %v into advisor db type", instance.Engine))
+	}
+	dbSchema, err := s.store.GetDBSchema(ctx, database.UID)
+	if err != nil {
+		return advisor.Error, nil, err
+	}
+	// The schema isn't loaded yet, let's load it on-demand.
+	if dbSchema == nil {
+		if err := s.SchemaSyncer.SyncDatabaseSchema(ctx, database, true /* force */); err != nil {
+			return advisor.Error, nil, err
+		}
+	}
+	dbSchema, err = s.store.GetDBSchema(ctx, database.UID)
+	if err != nil {
+		return advisor.Error, nil, err
+	}
+	if dbSchema == nil {
+		return advisor.Error, nil, errors.Errorf("database schema %v not found", database.UID)
+	}
+
+	catalog, err := s.store.NewCatalog(ctx, database.UID, instance.Engine, advisor.SyntaxModeNormal)
+	if err != nil {
+		return advisor.Error, nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to create a catalog")
+	}
+
+	driver, err := s.dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, databaseName)
+	if err != nil {
+		return advisor.Error, nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get database driver").SetInternal(err)
+	}
+	defer driver.Close(ctx)
+	adviceLevel, adviceList, err := s.sqlCheck(
+		ctx,
+		dbType,
+		dbSchema.Metadata.CharacterSet,
+		dbSchema.Metadata.Collation,
+		environmentID,
+		statement,
+		catalog,
+		driver.GetDB(),
+	)
+	if err != nil {
+		return advisor.Error, nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to check SQL review policy").SetInternal(err)
+	}
+	return adviceLevel, adviceList, nil
+}
+
 func (s *Server) sqlCheck(
 	ctx context.Context,
 	dbType advisorDB.Type,
@@ -915,8 +1369,202 @@ func checkPostgreSQLIndexHit(statement string, plan string) []advisor.Advice {
 	return nil
 }
 
+const (
+	// explainLargeTableRows is the row count of a table considered large for full scans.
+	explainLargeTableRows = 100000
+	// explainBigNestedLoopRows is the estimated number of row combinations considered big for nested loops.
+	explainBigNestedLoopRows = 1000000
+)
+
+// hasReadReplica returns true if the read-only data source of the instance connects to another server than the admin data source.
+func hasReadReplica(instance *store.InstanceMessage) bool {
+	dataSource := utils.DataSourceFromInstanceWithType(instance, api.RO)
+	adminDataSource := utils.DataSourceFromInstanceWithType(instance, api.Admin)
+	if dataSource == nil || adminDataSource == nil || dataSource.Host == "" {
+		return false
+	}
+	return dataSource.Host != adminDataSource.Host || (dataSource.Port != "" && dataSource.Port != adminDataSource.Port)
+}
+
+// getTableRowCounts returns the map from the lower case table names to the row counts in the synced schema.
+func getTableRowCounts(dbSchema *store.DBSchema) map[string]int64 {
+	tableRows := make(map[string]int64)
+	for _, schema := range dbSchema.Metadata.Schemas {
+		for _, table := range schema.Tables {
+			tableRows[strings.ToLower(table.Name)] = table.RowCount
+		}
+	}
+	return tableRows
+}
+
+// getExplainNodeRows returns the actual row count of the node if analyzed, otherwise the estimated one.
+func getExplainNodeRows(node *db.ExplainNode) float64 {
+	if node.ActualRows != nil {
+		return *node.ActualRows
+	}
+	return node.EstimatedRows
+}
+
+// checkExplainPlan returns the advice for the hot spots in the plan, i.e. full scans on large tables, filesorts and
+// nested loops over big row estimates. tableRows is used for the table sizes if the relation is found.
+func checkExplainPlan(plan *db.ExplainNode, tableRows map[string]int64) []advisor.Advice {
+	var adviceList []advisor.Advice
+	var visit func(node *db.ExplainNode)
+	visit = func(node *db.ExplainNode) {
+		if node.FullScan {
+			rows := getExplainNodeRows(node)
+			if count, ok := tableRows[strings.ToLower(node.Relation)]; ok {
+				rows = float64(count)
+			}
+			if rows >= explainLargeTableRows {
+				adviceList = append(adviceList, advisor.Advice{
+					Status:  advisor.Warn,
+					Code:    advisor.QueryPlanFullTableScan,
+					Title:   "Full scan on large table",
+					Content: fmt.Sprintf("%q scans about %.0f rows of table %q, consider adding an index for the conditions", node.NodeType, rows, node.Relation),
+				})
+			}
+		}
+		if node.Filesort {
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  advisor.Warn,
+				Code:    advisor.QueryPlanFilesort,
+				Title:   "Query uses filesort",
+				Content: fmt.Sprintf("%q sorts about %.0f rows with filesort, consider adding an index for the ORDER BY or GROUP BY columns", node.NodeType, getExplainNodeRows(node)),
+			})
+		}
+		if node.NestedLoop && len(node.Children) > 1 {
+			rows := 1.0
+			for _, child := range node.Children {
+				rows *= getExplainNodeRows(child)
+			}
+			if rows >= explainBigNestedLoopRows {
+				adviceList = append(adviceList, advisor.Advice{
+					Status:  advisor.Warn,
+					Code:    advisor.QueryPlanNestedLoop,
+					Title:   "Nested loop over big row estimates",
+					Content: fmt.Sprintf("%q joins about %.0f row combinations, consider adding an index for the join conditions", node.NodeType, rows),
+				})
+			}
+		}
+		for _, child := range node.Children {
+			visit(child)
+		}
+	}
+	visit(plan)
+
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList
+}
+
+// getSensitiveSchemaInfoForStatement gets the sensitive schema info of the databases accessed by the statement.
+func (s *Server) getSensitiveSchemaInfoForStatement(ctx context.Context, instance *store.InstanceMessage, databaseName string, statement string) (*db.SensitiveSchemaInfo, error) {
+	var sensitiveSchemaInfo *db.SensitiveSchemaInfo
+	var err error
+	switch instance.Engine {
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+		databaseList, err := parser.ExtractDatabaseList(parser.MySQL, statement)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get database list: %s", statement)).SetInternal(err)
+		}
+
+		sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, databaseList, databaseName)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get sensitive schema info: %s", statement)).SetInternal(err)
+		}
+	case db.Postgres:
+		sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, []string{databaseName}, databaseName)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get sensitive schema info: %s", statement)).SetInternal(err)
+		}
+	}
+	return sensitiveSchemaInfo, nil
+}
+
+// getStatementWithRowFilter rewrites the statement to apply the row filters of the principal in the databases accessed by the statement.
+func (s *Server) getStatementWithRowFilter(ctx context.Context, principalID int, role api.Role, instance *store.InstanceMessage, databaseName string, statement string) (string, error) {
+	databaseList := []string{databaseName}
+	switch instance.Engine {
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+		list, err := parser.ExtractDatabaseList(parser.MySQL, statement)
+		if err != nil {
+			return "", echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get database list: %s", statement)).SetInternal(err)
+		}
+		databaseList = list
+	}
+
+	var filterList []util.RowFilter
+	var viewList []util.RowFilterView
+	for _, name := range databaseList {
+		filterDatabaseName := name
+		if name == "" {
+			filterDatabaseName = databaseName
+		}
+		if filterDatabaseName == "" || isExcludeDatabase(instance.Engine, filterDatabaseName) {
+			continue
+		}
+		database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{EnvironmentID: &instance.EnvironmentID, InstanceID: &instance.ResourceID, DatabaseName: &filterDatabaseName})
+		if err != nil {
+			return "", echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch database %q", filterDatabaseName)).SetInternal(err)
+		}
+		if database == nil {
+			continue
+		}
+		policy, err := s.store.GetRowFilterPolicy(ctx, database.UID)
+		if err != nil {
+			return "", echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to find row filter policy for database %q in instance %q", filterDatabaseName, instance.Title)).SetInternal(err)
+		}
+		matched := false
+		for _, filter := range policy.RowFilterList {
+			if !filter.Match(principalID, role) {
+				continue
+			}
+			matched = true
+			filterList = append(filterList, util.RowFilter{
+				Database:   filterDatabaseName,
+				Schema:     filter.Schema,
+				Table:      filter.Table,
+				Expression: filter.Expression,
+			})
+		}
+		if !matched {
+			continue
+		}
+		// The views can read the filtered tables bypassing the row filters, so they cannot be queried.
+		dbSchema, err := s.store.GetDBSchema(ctx, database.UID)
+		if err != nil {
+			return "", echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch schema of database %q", filterDatabaseName)).SetInternal(err)
+		}
+		if dbSchema == nil {
+			return "", echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Cannot apply row filters to database %q before its schema is synced", filterDatabaseName))
+		}
+		for _, schema := range dbSchema.Metadata.Schemas {
+			for _, view := range schema.Views {
+				viewList = append(viewList, util.RowFilterView{
+					Database: filterDatabaseName,
+					Schema:   schema.Name,
+					Name:     view.Name,
+				})
+			}
+		}
+	}
+
+	result, err := util.RewriteRowFilter(instance.Engine, statement, databaseName, filterList, viewList)
+	if err != nil {
+		return "", echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Failed to apply row filters to the query: %v", err)).SetInternal(err)
+	}
+	return result, nil
+}
+
 func (s *Server) getSensitiveSchemaInfo(ctx context.Context, instance *store.InstanceMessage, databaseList []string, currentDatabase string) (*db.SensitiveSchemaInfo, error) {
-	type sensitiveDataMap map[api.SensitiveData]api.SensitiveDataMaskType
+	type sensitiveDataMap map[api.SensitiveData]db.SensitiveDataMask
 	isEmpty := true
 	result := &db.SensitiveSchemaInfo{
 		DatabaseList: []db.DatabaseSchema{},
@@ -957,7 +1605,13 @@ func (s *Server) getSensitiveSchemaInfo(ctx context.Context, instance *store.Ins
 				Schema: data.Schema,
 				Table:  data.Table,
 				Column: data.Column,
-			}] = data.Type
+			}] = db.SensitiveDataMask{
+				Type:       db.SensitiveDataMaskType(data.Type),
+				KeepLast:   data.KeepLast,
+				Salt:       s.sensitiveDataSalt,
+				RangeWidth: data.RangeWidth,
+				DateUnit:   data.DateUnit,
+			}
 		}
 
 		dbSchema, err := s.store.GetDBSchema(ctx, database.UID)
@@ -979,7 +1633,7 @@ func (s *Server) getSensitiveSchemaInfo(ctx context.Context, instance *store.Ins
 					tableSchema.Name = fmt.Sprintf("%s.%s", schema.Name, table.Name)
 				}
 				for _, column := range table.Columns {
-					_, sensitive := columnMap[api.SensitiveData{
+					mask, sensitive := columnMap[api.SensitiveData{
 						Schema: schema.Name,
 						Table:  table.Name,
 						Column: column.Name,
@@ -987,6 +1641,7 @@ func (s *Server) getSensitiveSchemaInfo(ctx context.Context, instance *store.Ins
 					tableSchema.ColumnList = append(tableSchema.ColumnList, db.ColumnInfo{
 						Name:      column.Name,
 						Sensitive: sensitive,
+						Mask:      mask,
 					})
 				}
 				databaseSchema.TableList = append(databaseSchema.TableList, tableSchema)
@@ -1035,6 +1690,21 @@ func isMySQLExcludeDatabase(database string) bool {
 	return true
 }
 
+// checkAccessTokenProject checks if the database belongs to the project the personal access token is restricted to.
+func (s *Server) checkAccessTokenProject(ctx context.Context, restrictedProjectUID *int, database *store.DatabaseMessage) error {
+	if restrictedProjectUID == nil {
+		return nil
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{ResourceID: &database.ProjectID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch project %q", database.ProjectID)).SetInternal(err)
+	}
+	if project == nil || !isProjectAllowedByAccessToken(restrictedProjectUID, project.UID) {
+		return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("The personal access token is not allowed to access database %q outside its project", database.DatabaseName))
+	}
+	return nil
+}
+
 func (s *Server) hasDatabaseAccessRights(ctx context.Context, principalID int, role api.Role, database *store.DatabaseMessage) (bool, error) {
 	// Workspace Owners and DBAs always have database access rights.
 	if role == api.Owner || role == api.DBA {
@@ -1091,3 +1761,146 @@ func (s *Server) hasDatabaseAccessRights(ctx context.Context, principalID int, r
 	}
 	return hasAccessRights, nil
 }
+
+// hasAccessGrant returns true if the principal is granted by an active query access grant to query the tables
+// accessed by the statement in the database.
+func (s *Server) hasAccessGrant(ctx context.Context, principalID int, engine db.Type, database *store.DatabaseMessage, statement string) (bool, error) {
+	now := time.Now().Unix()
+	grants, err := s.store.ListAccessGrants(ctx, &store.FindAccessGrantMessage{
+		PrincipalUID: &principalID,
+		DatabaseUID:  &database.UID,
+		StatusList:   []api.AccessGrantStatus{api.AccessGrantActive},
+		// The runner may not have expired the grant yet.
+		ExpireTsAfter: &now,
+	})
+	if err != nil {
+		return false, err
+	}
+	grantedTables := make(map[string]bool)
+	for _, grant := range grants {
+		if len(grant.TableList) == 0 {
+			return true, nil
+		}
+		for _, table := range grant.TableList {
+			grantedTables[normalizeAccessGrantTable(engine, database.DatabaseName, table)] = true
+		}
+	}
+	if len(grantedTables) == 0 {
+		return false, nil
+	}
+
+	tableList, err := parser.ExtractTableList(parser.EngineType(engine), statement)
+	if err != nil {
+		// The tables cannot be checked, e.g. unsupported engines or syntax errors.
+		log.Debug("Failed to extract table list for access grant", zap.String("statement", statement), zap.Error(err))
+		return false, nil
+	}
+	// Functions such as query_to_xml can read the tables without referencing them in the statement.
+	if err := parser.ValidateQueryFunctions(parser.EngineType(engine), statement); err != nil {
+		log.Debug("Statement calls functions not allowed for table access grant", zap.String("statement", statement), zap.Error(err))
+		return false, nil
+	}
+	for _, table := range tableList {
+		if !grantedTables[normalizeAccessGrantTable(engine, database.DatabaseName, table)] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// normalizeAccessGrantTable normalizes the table to "schema.table" format. For MySQL, the schema is the database,
+// and for Postgres, the default schema is "public".
+func normalizeAccessGrantTable(engine db.Type, databaseName string, table string) string {
+	if strings.Contains(table, ".") {
+		return table
+	}
+	if engine == db.Postgres {
+		return fmt.Sprintf("public.%s", table)
+	}
+	return fmt.Sprintf("%s.%s", databaseName, table)
+}
+
+// startRunningQuery registers the query as running and returns the context to run the query with.
+// The context is done when the query is cancelled or exceeds the max execution time, and then the statement running
+// in the session of conn is cancelled on the database server as well. conn can be nil for the engines running queries
+// without a sql.Conn, which are only cancelled by the driver.
+// The returned function must be called when the query finishes.
+func (s *Server) startRunningQuery(ctx context.Context, query *api.SQLRunningQuery, engine db.Type, sqlDB *sql.DB, conn *sql.Conn) (context.Context, func()) {
+	var queryCtx context.Context
+	var cancel context.CancelFunc
+	if query.MaxExecutionSeconds > 0 {
+		queryCtx, cancel = context.WithTimeout(ctx, time.Duration(query.MaxExecutionSeconds)*time.Second)
+	} else {
+		queryCtx, cancel = context.WithCancel(ctx)
+	}
+
+	var sessionID int64
+	if conn != nil && util.IsSessionCancelSupported(engine) {
+		id, err := util.GetSessionID(ctx, engine, conn)
+		if err != nil {
+			// The query can still be cancelled by the driver.
+			log.Warn("Failed to get session ID of the query", zap.String("query_id", query.ID), zap.Error(err))
+		} else {
+			sessionID = id
+		}
+	}
+
+	finished := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-finished:
+		case <-queryCtx.Done():
+			if sessionID == 0 {
+				return
+			}
+			// The query context is done, use a new context to cancel the statement on the database server.
+			cancelCtx, cancelTimeout := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancelTimeout()
+			if err := util.CancelSession(cancelCtx, engine, sqlDB, sessionID); err != nil {
+				log.Warn("Failed to cancel the query on the database server", zap.String("query_id", query.ID), zap.Error(err))
+			}
+		}
+	}()
+
+	s.stateCfg.RunningQueries.Store(query.ID, &state.RunningQuery{Query: query, Cancel: cancel})
+	return queryCtx, func() {
+		s.stateCfg.RunningQueries.Delete(query.ID)
+		close(finished)
+		// Wait for cancelling the statement on the database server before the driver is closed.
+		<-stopped
+		cancel()
+	}
+}
+
+// newSQLRunningQuery creates a running query with the max execution time of the environment SQL query policy.
+func (s *Server) newSQLRunningQuery(ctx context.Context, principalID int, instance *store.InstanceMessage, environmentUID int, databaseName, statement string) (*api.SQLRunningQuery, error) {
+	queryPolicy, err := s.store.GetSQLQueryPolicy(ctx, environmentUID)
+	if err != nil {
+		return nil, err
+	}
+	return &api.SQLRunningQuery{
+		ID:                  uuid.New().String(),
+		CreatorID:           principalID,
+		InstanceID:          instance.UID,
+		DatabaseName:        databaseName,
+		Statement:           statement,
+		StartedTs:           time.Now().Unix(),
+		MaxExecutionSeconds: queryPolicy.MaxExecutionSeconds,
+	}, nil
+}
+
+// convertRunningQueryError converts the error of a query whose context is done to a readable one.
+func convertRunningQueryError(ctx context.Context, query *api.SQLRunningQuery, err error) error {
+	if err == nil {
+		return nil
+	}
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return errors.Errorf("query exceeds the max execution time of %d seconds and is cancelled", query.MaxExecutionSeconds)
+	case context.Canceled:
+		return errors.New("query is cancelled")
+	}
+	return err
+}
@@ -1,8 +1,23 @@
 package server
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/labstack/echo/v4"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/component/state"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/db/util"
 	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 )
 
@@ -99,3 +114,128 @@ func TestValidateSQLSelectStatement(t *testing.T) {
 		}
 	}
 }
+
+func TestStartRunningQuery(t *testing.T) {
+	s := &Server{stateCfg: &state.State{}}
+
+	// Cancelled by the user.
+	query := &api.SQLRunningQuery{ID: "cancelled"}
+	ctx, finish := s.startRunningQuery(context.Background(), query, db.MongoDB, nil, nil)
+	value, ok := s.stateCfg.RunningQueries.Load(query.ID)
+	require.True(t, ok)
+	value.(*state.RunningQuery).Cancel()
+	<-ctx.Done()
+	require.EqualError(t, convertRunningQueryError(ctx, query, ctx.Err()), "query is cancelled")
+	finish()
+	_, ok = s.stateCfg.RunningQueries.Load(query.ID)
+	require.False(t, ok)
+
+	// Exceeds the max execution time.
+	query = &api.SQLRunningQuery{ID: "timeout", MaxExecutionSeconds: 1}
+	ctx, finish = s.startRunningQuery(context.Background(), query, db.MongoDB, nil, nil)
+	<-ctx.Done()
+	require.EqualError(t, convertRunningQueryError(ctx, query, ctx.Err()), "query exceeds the max execution time of 1 seconds and is cancelled")
+	finish()
+
+	// Finished before cancelled.
+	query = &api.SQLRunningQuery{ID: "finished"}
+	ctx, finish = s.startRunningQuery(context.Background(), query, db.MongoDB, nil, nil)
+	err := errors.New("syntax error")
+	require.Equal(t, err, convertRunningQueryError(ctx, query, err))
+	require.NoError(t, convertRunningQueryError(ctx, query, nil))
+	finish()
+	_, ok = s.stateCfg.RunningQueries.Load(query.ID)
+	require.False(t, ok)
+}
+
+func TestCheckExplainPlan(t *testing.T) {
+	plan := &db.ExplainNode{
+		NodeType: "Sort",
+		Filesort: true,
+		Children: []*db.ExplainNode{
+			{
+				NodeType:   "Nested Loop",
+				NestedLoop: true,
+				Children: []*db.ExplainNode{
+					{NodeType: "Seq Scan", Relation: "orders", EstimatedRows: 10, FullScan: true},
+					{NodeType: "Seq Scan", Relation: "small", EstimatedRows: 200000, FullScan: true},
+				},
+			},
+		},
+	}
+	adviceList := checkExplainPlan(plan, map[string]int64{"orders": 500000, "small": 10})
+	var codes []advisor.Code
+	for _, advice := range adviceList {
+		require.Equal(t, advisor.Warn, advice.Status)
+		codes = append(codes, advice.Code)
+	}
+	require.Equal(t, []advisor.Code{advisor.QueryPlanFilesort, advisor.QueryPlanNestedLoop, advisor.QueryPlanFullTableScan}, codes)
+
+	adviceList = checkExplainPlan(&db.ExplainNode{NodeType: "Index Scan", Relation: "orders", EstimatedRows: 1}, nil)
+	require.Len(t, adviceList, 1)
+	require.Equal(t, advisor.Ok, adviceList[0].Code)
+}
+
+// failingRowWriter writes the rows as lines and fails on the row failAt.
+// Each row is flushed so that the response is sent to the client before the failure.
+type failingRowWriter struct {
+	w      *echo.Response
+	failAt int
+	count  int
+}
+
+func (f *failingRowWriter) WriteHeader(columnNames []string, _ []string) error {
+	_, err := fmt.Fprintln(f.w, columnNames)
+	return err
+}
+
+func (f *failingRowWriter) WriteRow(row []any) error {
+	if f.count == f.failAt {
+		return errors.New("connection reset by peer")
+	}
+	f.count++
+	if _, err := fmt.Fprintln(f.w, row...); err != nil {
+		return err
+	}
+	f.w.Flush()
+	return nil
+}
+
+func (*failingRowWriter) Close() error {
+	return nil
+}
+
+func TestFinishSQLExport(t *testing.T) {
+	e := echo.New()
+	e.GET("/export", func(c echo.Context) error {
+		var rowWriter util.RowWriter = &failingRowWriter{w: c.Response(), failAt: 2}
+		if err := rowWriter.WriteHeader([]string{"id"}, []string{"INT"}); err != nil {
+			return finishSQLExport(c.Response(), err)
+		}
+		for i := 0; i < 5; i++ {
+			if err := rowWriter.WriteRow([]any{i}); err != nil {
+				return finishSQLExport(c.Response(), err)
+			}
+		}
+		return finishSQLExport(c.Response(), rowWriter.Close())
+	})
+	e.GET("/empty", func(c echo.Context) error {
+		return finishSQLExport(c.Response(), errors.New("syntax error"))
+	})
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	// The rows have been streamed, the client must not see a complete response.
+	resp, err := http.Get(server.URL + "/export")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	_, err = io.ReadAll(resp.Body)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	// Nothing has been written, the error is returned as the status code.
+	resp, err = http.Get(server.URL + "/empty")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -8,6 +9,7 @@ import (
 	"github.com/google/jsonapi"
 	"github.com/labstack/echo/v4"
 
+	"github.com/bytebase/bytebase/backend/component/activity"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
@@ -122,4 +124,105 @@ func (s *Server) registerStageRoutes(g *echo.Group) {
 
 		return c.String(http.StatusOK, "")
 	})
+
+	// This function resumes the later stages paused by the gate of the stage, or checks the gate again.
+	g.PATCH("/pipeline/:pipelineID/stage/:stageID/gate", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		stageID, err := strconv.Atoi(c.Param("stageID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Stage ID is not a number: %s", c.Param("stageID"))).SetInternal(err)
+		}
+		pipelineID, err := strconv.Atoi(c.Param("pipelineID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Pipeline ID is not a number: %s", c.Param("pipelineID"))).SetInternal(err)
+		}
+
+		currentPrincipalID := c.Get(getPrincipalIDContextKey()).(int)
+		stageGatePatch := &api.StageGatePatch{
+			ID:        stageID,
+			UpdaterID: currentPrincipalID,
+		}
+		if err := jsonapi.UnmarshalPayload(c.Request().Body, stageGatePatch); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed update stage gate request").SetInternal(err)
+		}
+
+		gates, err := s.store.ListStageGates(ctx, &store.FindStageGateMessage{PipelineID: &pipelineID, StageID: &stageID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to find the gate of stage %v", stageID)).SetInternal(err)
+		}
+		if len(gates) == 0 {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Stage %v has no gate", stageID))
+		}
+		gate := gates[0]
+		// A failed gate can be resumed or checked again, and a pending gate can be passed without waiting.
+		switch {
+		case gate.Status == api.StageGateFailed && (stageGatePatch.Status == api.StageGatePassed || stageGatePatch.Status == api.StageGatePending):
+		case gate.Status == api.StageGatePending && stageGatePatch.Status == api.StageGatePassed:
+		default:
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Cannot change the stage gate status from %s to %s", gate.Status, stageGatePatch.Status))
+		}
+
+		issue, err := s.store.GetIssueV2(ctx, &store.FindIssueMessage{PipelineID: &pipelineID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch issue with pipeline ID %d", pipelineID)).SetInternal(err)
+		}
+		if issue == nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Issue not found with pipeline ID %d", pipelineID))
+		}
+		if !s.TaskScheduler.CanPrincipalChangeTaskStatus(currentPrincipalID, issue) {
+			return echo.NewHTTPError(http.StatusUnauthorized, "Not allowed to change stage gate status")
+		}
+
+		detail := ""
+		if stageGatePatch.Comment != nil {
+			detail = *stageGatePatch.Comment
+		}
+		updated, err := s.store.UpdateStageGate(ctx, &store.UpdateStageGateMessage{
+			StageID:    stageID,
+			UpdaterID:  currentPrincipalID,
+			FromStatus: &gate.Status,
+			Status:     &stageGatePatch.Status,
+			Detail:     &detail,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to update the gate of stage %v", stageID)).SetInternal(err)
+		}
+		if updated == nil {
+			return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("The gate of stage %v has been changed, please refresh and try again", stageID))
+		}
+
+		if updated.Status == api.StageGatePassed {
+			var stageName string
+			stages, err := s.store.ListStageV2(ctx, pipelineID)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to find stage %v", stageID)).SetInternal(err)
+			}
+			for _, stage := range stages {
+				if stage.ID == stageID {
+					stageName = stage.Name
+				}
+			}
+			bytes, err := json.Marshal(api.ActivityPipelineStageStatusUpdatePayload{
+				StageID:               stageID,
+				StageStatusUpdateType: api.StageStatusUpdateTypeResume,
+				IssueName:             issue.Title,
+				StageName:             stageName,
+			})
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal stage status update activity payload").SetInternal(err)
+			}
+			if _, err := s.ActivityManager.CreateActivity(ctx, &api.ActivityCreate{
+				CreatorID:   currentPrincipalID,
+				ContainerID: pipelineID,
+				Type:        api.ActivityPipelineStageStatusUpdate,
+				Level:       api.ActivityInfo,
+				Payload:     string(bytes),
+				Comment:     detail,
+			}, &activity.Metadata{Issue: issue}); err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create stage status update activity").SetInternal(err)
+			}
+		}
+
+		return c.String(http.StatusOK, "")
+	})
 }
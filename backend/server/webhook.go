@@ -30,8 +30,11 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
 	advisorDB "github.com/bytebase/bytebase/backend/plugin/advisor/db"
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/parser/mybatis"
+	"github.com/bytebase/bytebase/backend/plugin/parser/mybatis/ast"
 	"github.com/bytebase/bytebase/backend/plugin/vcs"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/bitbucket"
+	"github.com/bytebase/bytebase/backend/plugin/vcs/gitea"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/github"
 	"github.com/bytebase/bytebase/backend/plugin/vcs/gitlab"
 	"github.com/bytebase/bytebase/backend/store"
@@ -315,6 +318,70 @@ func (s *Server) registerWebhookRoutes(g *echo.Group) {
 		return c.String(http.StatusOK, strings.Join(allCreatedMessages, "\n"))
 	})
 
+	g.POST("/gitea/:id", func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		// This shouldn't happen as we only set up webhook to receive push event, just in case.
+		eventType := c.Request().Header.Get("X-Gitea-Event")
+		if eventType != "push" {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid webhook event type, got %q, want %q", eventType, "push"))
+		}
+
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Failed to read webhook request").SetInternal(err)
+		}
+		var pushEvent gitea.WebhookPushEvent
+		if err := json.Unmarshal(body, &pushEvent); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed push event").SetInternal(err)
+		}
+		repositoryID := pushEvent.Repository.FullName
+
+		nonBytebaseCommitList := filterGiteaBytebaseCommit(pushEvent.Commits)
+		if len(nonBytebaseCommitList) == 0 {
+			var commitList []string
+			for _, commit := range pushEvent.Commits {
+				commitList = append(commitList, commit.ID)
+			}
+			log.Debug("all commits are created by Bytebase",
+				zap.String("repoURL", pushEvent.Repository.HTMLURL),
+				zap.String("repoName", pushEvent.Repository.FullName),
+				zap.String("commits", strings.Join(commitList, ", ")),
+			)
+			return c.String(http.StatusOK, "OK")
+		}
+		pushEvent.Commits = nonBytebaseCommitList
+
+		filter := func(repo *api.Repository) (bool, error) {
+			// Gitea signs the payload the same way as GitHub, except that the signature has no "sha256=" prefix.
+			ok, err := validateGitHubWebhookSignature256(c.Request().Header.Get("X-Gitea-Signature"), repo.WebhookSecretToken, body)
+			if err != nil {
+				return false, echo.NewHTTPError(http.StatusInternalServerError, "Failed to validate Gitea webhook signature").SetInternal(err)
+			}
+			if !ok {
+				return false, nil
+			}
+
+			return s.isWebhookEventBranch(pushEvent.Ref, repo.BranchFilter)
+		}
+		repositoryList, err := s.filterRepository(ctx, c.Param("id"), repositoryID, filter)
+		if err != nil {
+			return err
+		}
+		if len(repositoryList) == 0 {
+			log.Debug("Empty handle repo list. Ignore this push event.")
+			return c.String(http.StatusOK, "OK")
+		}
+
+		baseVCSPushEvent := pushEvent.ToVCS()
+
+		createdMessages, err := s.processPushEvent(ctx, repositoryList, baseVCSPushEvent)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, strings.Join(createdMessages, "\n"))
+	})
+
 	// id is the webhookEndpointID in repository
 	// This endpoint is generated and injected into GitHub action & GitLab CI during the VCS setup.
 	g.POST("/sql-review/:id", func(c echo.Context) error {
@@ -400,28 +467,45 @@ func (s *Server) registerWebhookRoutes(g *echo.Group) {
 		}
 
 		distinctFileList := []vcs.DistinctFileItem{}
+		mapperFileList := []vcs.DistinctFileItem{}
 		for _, prFile := range prFiles {
 			if prFile.IsDeleted {
 				continue
 			}
-			distinctFileList = append(distinctFileList, vcs.DistinctFileItem{
+			item := vcs.DistinctFileItem{
 				FileName: prFile.Path,
 				Commit: vcs.Commit{
 					ID: prFile.LastCommitID,
 				},
-			})
+			}
+			// The MyBatis mapper files usually live with the application code outside the base directory.
+			if isMyBatisMapperFile(prFile.Path) {
+				mapperFileList = append(mapperFileList, item)
+				continue
+			}
+			distinctFileList = append(distinctFileList, item)
 		}
 
 		sqlCheckAdvice := map[string][]advisor.Advice{}
+		var mu sync.Mutex
 		var wg sync.WaitGroup
 
 		repoID2FileItemList := groupFileInfoByRepo(distinctFileList, repositoryList)
+		for _, item := range mapperFileList {
+			repoID2FileItemList[repo.ID] = append(repoID2FileItemList[repo.ID], fileInfo{
+				item:       item,
+				fType:      fileTypeMyBatisMapper,
+				repository: repo,
+			})
+		}
 		for _, fileInfoListInRepo := range repoID2FileItemList {
 			for _, file := range fileInfoListInRepo {
 				wg.Add(1)
 				go func(file fileInfo) {
 					defer wg.Done()
 					adviceList, err := s.sqlAdviceForFile(ctx, file, setting.ExternalUrl)
+					mu.Lock()
+					defer mu.Unlock()
 					if err != nil {
 						log.Error(
 							"Failed to take SQL review for file",
@@ -449,7 +533,7 @@ func (s *Server) registerWebhookRoutes(g *echo.Group) {
 
 		response := &api.VCSSQLReviewResult{}
 		switch repo.VCS.Type {
-		case vcs.GitHub:
+		case vcs.GitHub, vcs.Gitea:
 			response = convertSQLAdviceToGitHubActionResult(sqlCheckAdvice)
 		case vcs.GitLab:
 			response = convertSQLAdviceToGitLabCIResult(sqlCheckAdvice)
@@ -490,9 +574,19 @@ func (s *Server) sqlAdviceForFile(
 		}, nil
 	}
 
-	// TODO(ed): findProjectDatabases doesn't support the tenant mode.
-	// We can use https://github.com/bytebase/bytebase/blob/main/server/issue.go#L691 to find databases in tenant mode project.
-	databases, err := s.findProjectDatabases(ctx, fileInfo.repository.ProjectID, fileInfo.migrationInfo.Database, fileInfo.migrationInfo.Environment)
+	var databases []*store.DatabaseMessage
+	var err error
+	if fileInfo.fType == fileTypeMyBatisMapper {
+		// The mapper files don't tell the database, so they are reviewed against the databases in the project.
+		databases, err = s.store.ListDatabases(ctx, &store.FindDatabaseMessage{ProjectID: &fileInfo.repository.Project.ResourceID})
+		if err == nil && len(databases) == 0 {
+			err = errors.Errorf("project %d does not have any database", fileInfo.repository.ProjectID)
+		}
+	} else {
+		// TODO(ed): findProjectDatabases doesn't support the tenant mode.
+		// We can use https://github.com/bytebase/bytebase/blob/main/server/issue.go#L691 to find databases in tenant mode project.
+		databases, err = s.findProjectDatabases(ctx, fileInfo.repository.ProjectID, fileInfo.migrationInfo.Database, fileInfo.migrationInfo.Environment)
+	}
 	if err != nil {
 		log.Debug(
 			"Failed to list databse migration info",
@@ -565,14 +659,20 @@ func (s *Server) sqlAdviceForFile(
 		if dbSchema == nil {
 			return nil, errors.Errorf("database schema %v not found", database.UID)
 		}
-		adviceList, err := advisor.SQLReviewCheck(fileContent, policy.RuleList, advisor.SQLReviewCheckContext{
+		checkContext := advisor.SQLReviewCheckContext{
 			Charset:   dbSchema.Metadata.CharacterSet,
 			Collation: dbSchema.Metadata.Collation,
 			DbType:    dbType,
 			Catalog:   catalog,
 			Driver:    connection,
 			Context:   ctx,
-		})
+		}
+		var adviceList []advisor.Advice
+		if fileInfo.fType == fileTypeMyBatisMapper {
+			adviceList, err = sqlReviewCheckForMyBatisMapper(fileContent, policy.RuleList, checkContext)
+		} else {
+			adviceList, err = advisor.SQLReviewCheck(fileContent, policy.RuleList, checkContext)
+		}
 		driver.Close(ctx)
 		if err != nil {
 			return nil, errors.Errorf("Failed to exec the SQL check for database %v with error: %v", database.UID, err)
@@ -592,6 +692,67 @@ func (s *Server) sqlAdviceForFile(
 	}, nil
 }
 
+// sqlReviewCheckForMyBatisMapper expands the queries in the MyBatis mapper XML into representative statements and reviews them.
+// The advice lines are the lines of the queries in the mapper XML.
+func sqlReviewCheckForMyBatisMapper(mapperXML string, ruleList []*advisor.SQLReviewRule, checkContext advisor.SQLReviewCheckContext) ([]advisor.Advice, error) {
+	node, err := mybatis.Parse(mapperXML)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse MyBatis mapper")
+	}
+	mapper, ok := node.(*ast.MapperNode)
+	if !ok {
+		// Not a mapper file, such as the MyBatis configuration.
+		return nil, nil
+	}
+	statements, err := mybatis.ExpandStatements(mapper, getPlaceholderFunc(checkContext.DbType))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to expand MyBatis mapper")
+	}
+
+	// The representative statements have placeholders instead of values, so they cannot be explained or dry run.
+	checkContext.Driver = nil
+	var result []advisor.Advice
+	for _, statement := range statements {
+		adviceList, err := advisor.SQLReviewCheck(statement.Text, ruleList, checkContext)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to review statement %q of MyBatis mapper", statement.QueryID)
+		}
+		for _, advice := range adviceList {
+			if advice.Status == advisor.Success {
+				continue
+			}
+			advice.Line = statement.Line
+			advice.Content = fmt.Sprintf("[%s] %s", statement.QueryID, advice.Content)
+			result = append(result, advice)
+		}
+	}
+	if len(result) == 0 {
+		return []advisor.Advice{
+			{
+				Status:  advisor.Success,
+				Code:    advisor.Ok,
+				Title:   "OK",
+				Content: "",
+			},
+		}, nil
+	}
+	return result, nil
+}
+
+// getPlaceholderFunc returns the placeholder of the prepared statement parameters for the database type.
+func getPlaceholderFunc(dbType advisorDB.Type) func(n int) string {
+	switch dbType {
+	case advisorDB.Postgres:
+		return func(n int) string { return fmt.Sprintf("$%d", n) }
+	case advisorDB.Oracle:
+		return func(n int) string { return fmt.Sprintf(":%d", n) }
+	case advisorDB.MSSQL:
+		return func(n int) string { return fmt.Sprintf("@p%d", n) }
+	default:
+		return func(int) string { return "?" }
+	}
+}
+
 type repositoryFilter func(*api.Repository) (bool, error)
 
 func (s *Server) filterRepository(ctx context.Context, webhookEndpointID string, pushEventRepositoryID string, filter repositoryFilter) ([]*api.Repository, error) {
@@ -839,8 +1000,15 @@ const (
 	fileTypeUnknown fileType = iota
 	fileTypeMigration
 	fileTypeSchema
+	// fileTypeMyBatisMapper is the MyBatis mapper XML file, which is only reviewed in the SQL review CI.
+	fileTypeMyBatisMapper
 )
 
+// isMyBatisMapperFile returns true if the file is a MyBatis mapper XML file, such as "UserMapper.xml".
+func isMyBatisMapperFile(fileName string) bool {
+	return strings.HasSuffix(fileName, "Mapper.xml")
+}
+
 // getFileInfo processes the file item against the candidate list of
 // repositories and returns the parsed migration information, file change type
 // and a single matched repository. It returns an error when none or multiple
@@ -1650,6 +1818,17 @@ func filterGitLabBytebaseCommit(list []gitlab.WebhookCommit) []gitlab.WebhookCom
 	return result
 }
 
+func filterGiteaBytebaseCommit(list []gitea.WebhookCommit) []gitea.WebhookCommit {
+	var result []gitea.WebhookCommit
+	for _, commit := range list {
+		if commit.Author.Name == vcs.BytebaseAuthorName && commit.Author.Email == vcs.BytebaseAuthorEmail {
+			continue
+		}
+		result = append(result, commit)
+	}
+	return result
+}
+
 func filterBitbucketBytebaseCommit(list []bitbucket.WebhookCommit) []bitbucket.WebhookCommit {
 	bytebaseRaw := fmt.Sprintf("%s <%s>", vcs.BytebaseAuthorName, vcs.BytebaseAuthorEmail)
 	var result []bitbucket.WebhookCommit
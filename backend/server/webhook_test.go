@@ -1,6 +1,8 @@
 package server
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,8 +10,12 @@ import (
 
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	advisorDB "github.com/bytebase/bytebase/backend/plugin/advisor/db"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/vcs"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
 // TODO(d): fix the double underscore "__".
@@ -338,3 +344,71 @@ func TestGetFileInfo(t *testing.T) {
 		require.EqualError(t, err, "file change should be associated with exactly one project but found project-1, project-2")
 	})
 }
+
+func TestSQLReviewCheckForMyBatisMapper(t *testing.T) {
+	mapperXML := `<?xml version="1.0" encoding="UTF-8" ?>
+<mapper namespace="com.bytebase.test.UserMapper">
+    <select id="selectUser" resultType="hashmap">
+        select id, name from users where id = #{id}
+    </select>
+    <delete id="deleteUsers">
+        delete from users
+        <where>
+            <if test="name != null">and name = #{name}</if>
+        </where>
+    </delete>
+    <select id="selectAll" resultType="hashmap">
+        select * from users
+        <choose>
+            <when test="id != null">where id = #{id}</when>
+            <otherwise>where name = #{name}</otherwise>
+        </choose>
+    </select>
+</mapper>`
+	ruleList := []*advisor.SQLReviewRule{
+		{Type: advisor.SchemaRuleStatementNoSelectAll, Level: advisor.SchemaRuleLevelError},
+		{Type: advisor.SchemaRuleStatementRequireWhere, Level: advisor.SchemaRuleLevelWarning},
+	}
+	database := &storepb.DatabaseMetadata{
+		Name: "test",
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Tables: []*storepb.TableMetadata{
+					{
+						Name: "users",
+						Columns: []*storepb.ColumnMetadata{
+							{Name: "id", Type: "int"},
+							{Name: "name", Type: "varchar(255)"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, dbType := range []advisorDB.Type{advisorDB.MySQL, advisorDB.Postgres} {
+		adviceList, err := sqlReviewCheckForMyBatisMapper(mapperXML, ruleList, advisor.SQLReviewCheckContext{
+			DbType:  dbType,
+			Catalog: &store.Catalog{Finder: catalog.NewFinder(database, &catalog.FinderContext{EngineType: dbType})},
+			Context: context.Background(),
+		})
+		require.NoError(t, err, dbType)
+
+		type finding struct {
+			code    advisor.Code
+			line    int
+			queryID string
+		}
+		var findings []finding
+		for _, advice := range adviceList {
+			require.NotEqual(t, advisor.StatementSyntaxError, advice.Code, advice.Content)
+			queryID := advice.Content[1:strings.Index(advice.Content, "]")]
+			findings = append(findings, finding{code: advice.Code, line: advice.Line, queryID: queryID})
+		}
+		// Both branches of the <choose> select all columns.
+		require.Equal(t, []finding{
+			{code: advisor.StatementSelectAll, line: 12, queryID: "selectAll"},
+			{code: advisor.StatementSelectAll, line: 12, queryID: "selectAll"},
+		}, findings, dbType)
+	}
+}
@@ -0,0 +1,283 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+// AccessGrantMessage is the store model for a time-boxed access to query a database.
+// It is the expiring binding granted to the creator of a query access issue.
+type AccessGrantMessage struct {
+	IssueUID     int
+	PrincipalUID int
+	DatabaseUID  int
+	// TableList is the tables allowed to query in "table" or "schema.table" format.
+	// All tables in the database are allowed if it's empty.
+	TableList []string
+	// Duration is how long the access lasts in seconds after the issue is approved.
+	Duration int64
+	// ExpireTs is the timestamp when the access expires, it's set when the grant becomes ACTIVE.
+	ExpireTs int64
+	Status   api.AccessGrantStatus
+	// Output only fields.
+	//
+	// ID is the unique identifier of the grant.
+	ID int
+	// CreatedTs is the timestamp when the grant is created.
+	CreatedTs int64
+	// UpdatedTs is the timestamp when the grant is updated.
+	UpdatedTs int64
+}
+
+// ToAPIAccessGrant converts an AccessGrantMessage to an api.AccessGrant.
+func (m *AccessGrantMessage) ToAPIAccessGrant() *api.AccessGrant {
+	return &api.AccessGrant{
+		ID:              m.ID,
+		CreatedTs:       m.CreatedTs,
+		UpdatedTs:       m.UpdatedTs,
+		IssueID:         m.IssueUID,
+		PrincipalID:     m.PrincipalUID,
+		DatabaseID:      m.DatabaseUID,
+		TableList:       m.TableList,
+		DurationSeconds: m.Duration,
+		ExpireTs:        m.ExpireTs,
+		Status:          m.Status,
+	}
+}
+
+// FindAccessGrantMessage is the message for finding access grants.
+type FindAccessGrantMessage struct {
+	ID           *int
+	IssueUID     *int
+	PrincipalUID *int
+	DatabaseUID  *int
+	StatusList   []api.AccessGrantStatus
+	// ExpireTsBefore finds the grants expiring before or at the timestamp.
+	ExpireTsBefore *int64
+	// ExpireTsAfter finds the grants expiring after the timestamp.
+	ExpireTsAfter *int64
+}
+
+// UpdateAccessGrantMessage is the message for updating an access grant.
+type UpdateAccessGrantMessage struct {
+	ID       int
+	Status   *api.AccessGrantStatus
+	ExpireTs *int64
+}
+
+// CreateAccessGrant creates a PENDING access grant.
+func (s *Store) CreateAccessGrant(ctx context.Context, create *AccessGrantMessage) (*AccessGrantMessage, error) {
+	if create.TableList == nil {
+		create.TableList = []string{}
+	}
+	query := `
+		INSERT INTO access_grant (
+			issue_id,
+			principal_id,
+			database_id,
+			table_list,
+			duration,
+			status
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_ts, updated_ts, expire_ts
+	`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	grant := &AccessGrantMessage{
+		IssueUID:     create.IssueUID,
+		PrincipalUID: create.PrincipalUID,
+		DatabaseUID:  create.DatabaseUID,
+		TableList:    create.TableList,
+		Duration:     create.Duration,
+		Status:       api.AccessGrantPending,
+	}
+	if err := tx.QueryRowContext(ctx, query,
+		create.IssueUID,
+		create.PrincipalUID,
+		create.DatabaseUID,
+		pq.Array(create.TableList),
+		create.Duration,
+		api.AccessGrantPending,
+	).Scan(
+		&grant.ID,
+		&grant.CreatedTs,
+		&grant.UpdatedTs,
+		&grant.ExpireTs,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return grant, nil
+}
+
+// GetAccessGrant gets an access grant.
+func (s *Store) GetAccessGrant(ctx context.Context, find *FindAccessGrantMessage) (*AccessGrantMessage, error) {
+	grants, err := s.ListAccessGrants(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(grants) == 0 {
+		return nil, nil
+	}
+	if len(grants) > 1 {
+		return nil, &common.Error{Code: common.Conflict, Err: errors.Errorf("found %d access grants with filter %+v, expect 1", len(grants), find)}
+	}
+	return grants[0], nil
+}
+
+// ListAccessGrants lists access grants.
+func (s *Store) ListAccessGrants(ctx context.Context, find *FindAccessGrantMessage) ([]*AccessGrantMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.IssueUID; v != nil {
+		where, args = append(where, fmt.Sprintf("issue_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.PrincipalUID; v != nil {
+		where, args = append(where, fmt.Sprintf("principal_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.DatabaseUID; v != nil {
+		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.StatusList; v != nil {
+		var list []string
+		for _, status := range v {
+			list, args = append(list, fmt.Sprintf("$%d", len(args)+1)), append(args, status)
+		}
+		if len(list) == 0 {
+			return nil, nil
+		}
+		where = append(where, fmt.Sprintf("status IN (%s)", strings.Join(list, ", ")))
+	}
+	if v := find.ExpireTsBefore; v != nil {
+		where, args = append(where, fmt.Sprintf("expire_ts <= $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.ExpireTsAfter; v != nil {
+		where, args = append(where, fmt.Sprintf("expire_ts > $%d", len(args)+1)), append(args, *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			created_ts,
+			updated_ts,
+			issue_id,
+			principal_id,
+			database_id,
+			table_list,
+			duration,
+			expire_ts,
+			status
+		FROM access_grant
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY id DESC`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grants []*AccessGrantMessage
+	for rows.Next() {
+		var grant AccessGrantMessage
+		if err := rows.Scan(
+			&grant.ID,
+			&grant.CreatedTs,
+			&grant.UpdatedTs,
+			&grant.IssueUID,
+			&grant.PrincipalUID,
+			&grant.DatabaseUID,
+			pq.Array(&grant.TableList),
+			&grant.Duration,
+			&grant.ExpireTs,
+			&grant.Status,
+		); err != nil {
+			return nil, err
+		}
+		grants = append(grants, &grant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return grants, nil
+}
+
+// UpdateAccessGrant updates an access grant.
+func (s *Store) UpdateAccessGrant(ctx context.Context, update *UpdateAccessGrantMessage) (*AccessGrantMessage, error) {
+	set, args := []string{}, []any{}
+	if v := update.Status; v != nil {
+		set, args = append(set, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.ExpireTs; v != nil {
+		set, args = append(set, fmt.Sprintf("expire_ts = $%d", len(args)+1)), append(args, *v)
+	}
+	if len(set) == 0 {
+		return nil, errors.New("no update field provided")
+	}
+	args = append(args, update.ID)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var grant AccessGrantMessage
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE access_grant
+		SET `+strings.Join(set, ", ")+`
+		WHERE id = $%d
+		RETURNING id, created_ts, updated_ts, issue_id, principal_id, database_id, table_list, duration, expire_ts, status
+	`, len(args)),
+		args...,
+	).Scan(
+		&grant.ID,
+		&grant.CreatedTs,
+		&grant.UpdatedTs,
+		&grant.IssueUID,
+		&grant.PrincipalUID,
+		&grant.DatabaseUID,
+		pq.Array(&grant.TableList),
+		&grant.Duration,
+		&grant.ExpireTs,
+		&grant.Status,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("access grant ID not found: %d", update.ID)}
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return &grant, nil
+}
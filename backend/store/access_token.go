@@ -0,0 +1,220 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+// AccessTokenMessage is the store model for a personal access token of a user or service account.
+type AccessTokenMessage struct {
+	PrincipalUID int
+	Title        string
+	// TokenHash is the hex encoded SHA-256 hash of the token, the token itself is never stored.
+	TokenHash string
+	ScopeList []api.AccessTokenScope
+	// ProjectUID restricts the token to the project if it's not nil.
+	ProjectUID *int
+	// ExpireTs is the timestamp when the token expires, 0 means the token never expires.
+	ExpireTs int64
+	// Output only fields.
+	//
+	// ID is the unique identifier of the token.
+	ID int
+	// CreatedTs is the timestamp when the token is created.
+	CreatedTs int64
+	// LastUsedTs is the timestamp when the token is used the last time, 0 means the token is never used.
+	LastUsedTs int64
+}
+
+// FindAccessTokenMessage is the message for finding access tokens.
+type FindAccessTokenMessage struct {
+	ID           *int
+	PrincipalUID *int
+	TokenHash    *string
+}
+
+// CreateAccessToken creates an access token.
+func (s *Store) CreateAccessToken(ctx context.Context, create *AccessTokenMessage) (*AccessTokenMessage, error) {
+	query := `
+		INSERT INTO access_token (
+			principal_id,
+			title,
+			token_hash,
+			scope_list,
+			project_id,
+			expire_ts
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_ts
+	`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	token := &AccessTokenMessage{
+		PrincipalUID: create.PrincipalUID,
+		Title:        create.Title,
+		TokenHash:    create.TokenHash,
+		ScopeList:    create.ScopeList,
+		ProjectUID:   create.ProjectUID,
+		ExpireTs:     create.ExpireTs,
+	}
+	if err := tx.QueryRowContext(ctx, query,
+		create.PrincipalUID,
+		create.Title,
+		create.TokenHash,
+		pq.Array(create.ScopeList),
+		create.ProjectUID,
+		create.ExpireTs,
+	).Scan(
+		&token.ID,
+		&token.CreatedTs,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return token, nil
+}
+
+// GetAccessToken gets an access token.
+func (s *Store) GetAccessToken(ctx context.Context, find *FindAccessTokenMessage) (*AccessTokenMessage, error) {
+	tokens, err := s.ListAccessTokens(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	if len(tokens) > 1 {
+		return nil, &common.Error{Code: common.Conflict, Err: errors.Errorf("found %d access tokens with filter %+v, expect 1", len(tokens), find)}
+	}
+	return tokens[0], nil
+}
+
+// ListAccessTokens lists access tokens.
+func (s *Store) ListAccessTokens(ctx context.Context, find *FindAccessTokenMessage) ([]*AccessTokenMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.PrincipalUID; v != nil {
+		where, args = append(where, fmt.Sprintf("principal_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.TokenHash; v != nil {
+		where, args = append(where, fmt.Sprintf("token_hash = $%d", len(args)+1)), append(args, *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			created_ts,
+			principal_id,
+			title,
+			token_hash,
+			scope_list,
+			project_id,
+			expire_ts,
+			last_used_ts
+		FROM access_token
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY id DESC`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*AccessTokenMessage
+	for rows.Next() {
+		var token AccessTokenMessage
+		var scopeList []string
+		var projectUID sql.NullInt32
+		if err := rows.Scan(
+			&token.ID,
+			&token.CreatedTs,
+			&token.PrincipalUID,
+			&token.Title,
+			&token.TokenHash,
+			pq.Array(&scopeList),
+			&projectUID,
+			&token.ExpireTs,
+			&token.LastUsedTs,
+		); err != nil {
+			return nil, err
+		}
+		for _, scope := range scopeList {
+			token.ScopeList = append(token.ScopeList, api.AccessTokenScope(scope))
+		}
+		if projectUID.Valid {
+			v := int(projectUID.Int32)
+			token.ProjectUID = &v
+		}
+		tokens = append(tokens, &token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return tokens, nil
+}
+
+// UpdateAccessTokenLastUsedTs updates the last used timestamp of an access token.
+func (s *Store) UpdateAccessTokenLastUsedTs(ctx context.Context, id int, lastUsedTs int64) error {
+	if _, err := s.db.db.ExecContext(ctx, `
+		UPDATE access_token
+		SET last_used_ts = $1
+		WHERE id = $2
+	`, lastUsedTs, id); err != nil {
+		return errors.Wrapf(err, "failed to update last used timestamp of access token %d", id)
+	}
+	return nil
+}
+
+// DeleteAccessToken deletes an access token.
+func (s *Store) DeleteAccessToken(ctx context.Context, id int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM access_token WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return &common.Error{Code: common.NotFound, Err: errors.Errorf("access token ID not found: %d", id)}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrapf(err, "failed to commit transaction")
+	}
+	return nil
+}
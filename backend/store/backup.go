@@ -339,6 +339,12 @@ type BackupMessage struct {
 	DatabaseUID int
 	// Payload is the payload of the backup.
 	Payload api.BackupPayload
+	// VerificationStatus is the result of verifying the backup by restoring it into a scratch database.
+	VerificationStatus api.BackupVerificationStatus
+	// VerificationDetail is the reason of the verification failure.
+	VerificationDetail string
+	// VerifiedTs is the timestamp when the backup is verified.
+	VerifiedTs int64
 }
 
 // ZapBackupArray is a helper to format zap.Array.
@@ -371,6 +377,9 @@ func (b *BackupMessage) ToAPIBackup() *api.Backup {
 		Path:                    b.Path,
 		Comment:                 b.Comment,
 		DatabaseID:              b.DatabaseUID,
+		VerificationStatus:      b.VerificationStatus,
+		VerificationDetail:      b.VerificationDetail,
+		VerifiedTs:              b.VerifiedTs,
 	}
 }
 
@@ -402,6 +411,10 @@ type UpdateBackupMessage struct {
 	Status  *string
 	Comment *string
 	Payload *string
+	// The verification fields are set together by the backup runner.
+	VerificationStatus *api.BackupVerificationStatus
+	VerificationDetail *string
+	VerifiedTs         *int64
 }
 
 // GetBackupSettingV2 retrieves the backup setting for the given database.
@@ -533,7 +546,7 @@ func (s *Store) CreateBackupV2(ctx context.Context, create *BackupMessage, datab
 			comment
 		)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, row_status, name, storage_backend, migration_history_version, path, created_ts, updated_ts, status, type, comment, database_id
+		RETURNING id, row_status, name, storage_backend, migration_history_version, path, created_ts, updated_ts, status, type, comment, database_id, verification_status, verification_detail, verified_ts
 	`
 	var backup BackupMessage
 	if err := tx.QueryRowContext(ctx, query,
@@ -560,6 +573,9 @@ func (s *Store) CreateBackupV2(ctx context.Context, create *BackupMessage, datab
 		&backup.BackupType,
 		&backup.Comment,
 		&backup.DatabaseUID,
+		&backup.VerificationStatus,
+		&backup.VerificationDetail,
+		&backup.VerifiedTs,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
@@ -639,6 +655,15 @@ func (s *Store) UpdateBackupV2(ctx context.Context, patch *UpdateBackupMessage)
 		}
 		set, args = append(set, fmt.Sprintf("payload = $%d", len(args)+1)), append(args, *v)
 	}
+	if v := patch.VerificationStatus; v != nil {
+		set, args = append(set, fmt.Sprintf("verification_status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := patch.VerificationDetail; v != nil {
+		set, args = append(set, fmt.Sprintf("verification_detail = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := patch.VerifiedTs; v != nil {
+		set, args = append(set, fmt.Sprintf("verified_ts = $%d", len(args)+1)), append(args, *v)
+	}
 	args = append(args, patch.UID)
 
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -653,7 +678,7 @@ func (s *Store) UpdateBackupV2(ctx context.Context, patch *UpdateBackupMessage)
 			UPDATE backup
 			SET `+strings.Join(set, ", ")+`
 			WHERE id = $%d
-			RETURNING id, row_status, created_ts, updated_ts, database_id, name, status, type, storage_backend, migration_history_version, path, comment, payload
+			RETURNING id, row_status, created_ts, updated_ts, database_id, name, status, type, storage_backend, migration_history_version, path, comment, payload, verification_status, verification_detail, verified_ts
 		`, len(args)),
 		args...,
 	).Scan(
@@ -670,6 +695,9 @@ func (s *Store) UpdateBackupV2(ctx context.Context, patch *UpdateBackupMessage)
 		&backup.Path,
 		&backup.Comment,
 		&payload,
+		&backup.VerificationStatus,
+		&backup.VerificationDetail,
+		&backup.VerifiedTs,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("backup ID not found: %d", patch.UID)}
@@ -721,7 +749,10 @@ func (*Store) listBackupImplV2(ctx context.Context, tx *Tx, find *FindBackupMess
 			type,
 			comment,
 			database_id,
-			payload
+			payload,
+			verification_status,
+			verification_detail,
+			verified_ts
 		FROM backup WHERE %s;`, strings.Join(where, " AND ")), args...)
 	if err != nil {
 		return nil, err
@@ -746,6 +777,9 @@ func (*Store) listBackupImplV2(ctx context.Context, tx *Tx, find *FindBackupMess
 			&backup.Comment,
 			&backup.DatabaseUID,
 			&payload,
+			&backup.VerificationStatus,
+			&backup.VerificationDetail,
+			&backup.VerifiedTs,
 		); err != nil {
 			return nil, err
 		}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
 
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -66,7 +67,7 @@ func (s *Store) GetDBSchema(ctx context.Context, databaseID int) (*DBSchema, err
 	return dbSchema, nil
 }
 
-// UpsertDBSchema upserts a database schema.
+// UpsertDBSchema upserts a database schema, and appends a schema snapshot if the schema is changed.
 func (s *Store) UpsertDBSchema(ctx context.Context, databaseID int, dbSchema *DBSchema, updaterID int) error {
 	metadataBytes, err := protojson.Marshal(dbSchema.Metadata)
 	if err != nil {
@@ -103,6 +104,9 @@ func (s *Store) UpsertDBSchema(ctx context.Context, databaseID int, dbSchema *DB
 	); err != nil {
 		return err
 	}
+	if err := createDBSchemaSnapshotTx(ctx, tx, databaseID, dbSchema, metadataBytes, updaterID); err != nil {
+		return errors.Wrapf(err, "failed to create schema snapshot")
+	}
 	if err := tx.Commit(); err != nil {
 		return err
 	}
@@ -0,0 +1,194 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// DBSchemaSnapshotMessage is the store model for a snapshot of the database schema.
+// The snapshots are immutable, and a new one is appended whenever the schema changes.
+type DBSchemaSnapshotMessage struct {
+	ID          int
+	DatabaseUID int
+	CreatedTs   int64
+	Hash        string
+	// Schema is nil unless FindDBSchemaSnapshotMessage.WithSchema is set, because the raw dumps can be large.
+	Schema *DBSchema
+}
+
+// ToAPIDBSchemaSnapshot converts the message to the API message.
+func (s *DBSchemaSnapshotMessage) ToAPIDBSchemaSnapshot() *api.DBSchemaSnapshot {
+	return &api.DBSchemaSnapshot{
+		ID:         s.ID,
+		CreatedTs:  s.CreatedTs,
+		DatabaseID: s.DatabaseUID,
+		Hash:       s.Hash,
+	}
+}
+
+// FindDBSchemaSnapshotMessage is the message for finding database schema snapshots.
+type FindDBSchemaSnapshotMessage struct {
+	ID          *int
+	DatabaseUID *int
+	// CreatedTsBefore finds the snapshots created at or before the timestamp.
+	CreatedTsBefore *int64
+	Limit           *int
+	WithSchema      bool
+}
+
+// GetDBSchemaSnapshot gets the latest database schema snapshot matching the find condition.
+func (s *Store) GetDBSchemaSnapshot(ctx context.Context, find *FindDBSchemaSnapshotMessage) (*DBSchemaSnapshotMessage, error) {
+	limit := 1
+	findCopy := *find
+	findCopy.Limit = &limit
+	snapshots, err := s.ListDBSchemaSnapshots(ctx, &findCopy)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+	return snapshots[0], nil
+}
+
+// ListDBSchemaSnapshots lists the database schema snapshots from the latest to the earliest.
+func (s *Store) ListDBSchemaSnapshots(ctx context.Context, find *FindDBSchemaSnapshotMessage) ([]*DBSchemaSnapshotMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.DatabaseUID; v != nil {
+		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.CreatedTsBefore; v != nil {
+		where, args = append(where, fmt.Sprintf("created_ts <= $%d", len(args)+1)), append(args, *v)
+	}
+	fields := "id, database_id, created_ts, hash"
+	if find.WithSchema {
+		fields += ", metadata, raw_dump"
+	}
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM db_schema_snapshot
+		WHERE %s
+		ORDER BY created_ts DESC, id DESC`, fields, strings.Join(where, " AND "))
+	if v := find.Limit; v != nil {
+		query += fmt.Sprintf(" LIMIT %d", *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []*DBSchemaSnapshotMessage
+	for rows.Next() {
+		snapshot := &DBSchemaSnapshotMessage{}
+		dest := []any{&snapshot.ID, &snapshot.DatabaseUID, &snapshot.CreatedTs, &snapshot.Hash}
+		var metadata, rawDump []byte
+		if find.WithSchema {
+			dest = append(dest, &metadata, &rawDump)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		if find.WithSchema {
+			var databaseMetadata storepb.DatabaseMetadata
+			decoder := protojson.UnmarshalOptions{DiscardUnknown: true}
+			if err := decoder.Unmarshal(metadata, &databaseMetadata); err != nil {
+				return nil, err
+			}
+			snapshot.Schema = &DBSchema{
+				Metadata: &databaseMetadata,
+				Schema:   rawDump,
+			}
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return snapshots, nil
+}
+
+// createDBSchemaSnapshotTx appends a snapshot of the database schema unless the schema is the same
+// as the latest snapshot.
+func createDBSchemaSnapshotTx(ctx context.Context, tx *Tx, databaseID int, dbSchema *DBSchema, metadataBytes []byte, creatorID int) error {
+	hash, err := getDBSchemaHash(dbSchema)
+	if err != nil {
+		return errors.Wrapf(err, "failed to compute the schema hash")
+	}
+	query := `
+		INSERT INTO db_schema_snapshot (
+			creator_id,
+			database_id,
+			hash,
+			metadata,
+			raw_dump
+		)
+		SELECT $1, $2, $3, $4, $5
+		WHERE NOT EXISTS (
+			SELECT 1 FROM (
+				SELECT hash FROM db_schema_snapshot WHERE database_id = $2 ORDER BY created_ts DESC, id DESC LIMIT 1
+			) latest
+			WHERE latest.hash = $3
+		)
+	`
+	if _, err := tx.ExecContext(ctx, query,
+		creatorID,
+		databaseID,
+		hash,
+		metadataBytes,
+		// Convert to string because []byte{} is null which violates db schema constraints.
+		string(dbSchema.Schema),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// getDBSchemaHash returns the hex encoded SHA-256 hash of the schema. The table statistics
+// are excluded because they change without schema changes.
+func getDBSchemaHash(dbSchema *DBSchema) (string, error) {
+	metadata, ok := proto.Clone(dbSchema.Metadata).(*storepb.DatabaseMetadata)
+	if !ok || metadata == nil {
+		metadata = &storepb.DatabaseMetadata{}
+	}
+	for _, schema := range metadata.Schemas {
+		for _, table := range schema.Tables {
+			table.RowCount = 0
+			table.DataSize = 0
+			table.IndexSize = 0
+			table.DataFree = 0
+		}
+	}
+	metadataBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(metadata)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write(metadataBytes)
+	h.Write(dbSchema.Schema)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestGetDBSchemaHash(t *testing.T) {
+	newSchema := func(rowCount int64, columnType string) *DBSchema {
+		return &DBSchema{
+			Metadata: &storepb.DatabaseMetadata{
+				Name: "db",
+				Schemas: []*storepb.SchemaMetadata{{
+					Tables: []*storepb.TableMetadata{{
+						Name:     "t",
+						RowCount: rowCount,
+						Columns:  []*storepb.ColumnMetadata{{Name: "id", Type: columnType}},
+					}},
+				}},
+			},
+			Schema: []byte("CREATE TABLE t (id " + columnType + ");"),
+		}
+	}
+
+	hash, err := getDBSchemaHash(newSchema(1, "int"))
+	require.NoError(t, err)
+
+	// The table statistics are not part of the schema.
+	sameHash, err := getDBSchemaHash(newSchema(100, "int"))
+	require.NoError(t, err)
+	require.Equal(t, hash, sameHash)
+
+	changedHash, err := getDBSchemaHash(newSchema(1, "bigint"))
+	require.NoError(t, err)
+	require.NotEqual(t, hash, changedHash)
+
+	// The statistics of the original schema are kept.
+	schema := newSchema(1, "int")
+	_, err = getDBSchemaHash(schema)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), schema.Metadata.Schemas[0].Tables[0].RowCount)
+}
@@ -49,6 +49,7 @@ func (s *Schedule) toAPIDeploymentSchedule() *api.DeploymentSchedule {
 			Name: d.Name,
 			Spec: &api.DeploymentSpec{
 				Selector: d.Spec.Selector.toAPILabelSelector(),
+				Gate:     d.Spec.Gate.ToAPIDeploymentGate(),
 			},
 		})
 	}
@@ -65,7 +66,41 @@ type Deployment struct {
 
 // DeploymentSpec is the message for deployment specification.
 type DeploymentSpec struct {
-	Selector *LabelSelector `json:"selector"`
+	Selector *LabelSelector  `json:"selector"`
+	Gate     *DeploymentGate `json:"gate,omitempty"`
+}
+
+// DeploymentGate is the message for the gate of a deployment stage.
+type DeploymentGate struct {
+	BakeTimeSeconds   int64                  `json:"bakeTimeSeconds,omitempty"`
+	MaxFailurePercent *float64               `json:"maxFailurePercent,omitempty"`
+	HealthCheck       *DeploymentHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// DeploymentHealthCheck is the message for the health check of a deployment gate.
+type DeploymentHealthCheck struct {
+	Statement string  `json:"statement,omitempty"`
+	URL       string  `json:"url,omitempty"`
+	MaxValue  float64 `json:"maxValue"`
+}
+
+// ToAPIDeploymentGate converts the message to a legacy API deployment gate.
+func (g *DeploymentGate) ToAPIDeploymentGate() *api.DeploymentGate {
+	if g == nil {
+		return nil
+	}
+	result := &api.DeploymentGate{
+		BakeTimeSeconds:   g.BakeTimeSeconds,
+		MaxFailurePercent: g.MaxFailurePercent,
+	}
+	if check := g.HealthCheck; check != nil {
+		result.HealthCheck = &api.DeploymentHealthCheck{
+			Statement: check.Statement,
+			URL:       check.URL,
+			MaxValue:  check.MaxValue,
+		}
+	}
+	return result
 }
 
 // LabelSelector is the message for label selector.
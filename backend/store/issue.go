@@ -118,7 +118,7 @@ func (s *Store) createPipelineValidateOnly(ctx context.Context, create *api.Pipe
 		ID:   id,
 		Name: create.Name,
 	}
-	for _, sc := range create.StageList {
+	for i, sc := range create.StageList {
 		id++
 		env, err := s.GetEnvironmentByID(ctx, sc.EnvironmentID)
 		if err != nil {
@@ -131,6 +131,13 @@ func (s *Store) createPipelineValidateOnly(ctx context.Context, create *api.Pipe
 			EnvironmentID: sc.EnvironmentID,
 			Environment:   env,
 		}
+		// The gate of the last stage is dropped, since there is no later stage to hold.
+		if sc.Gate != nil && i+1 < len(create.StageList) {
+			stage.Gate = &api.StageGate{
+				Status: api.StageGatePending,
+				Config: sc.Gate,
+			}
+		}
 		// We don't know IDs before inserting, so we use array index instead.
 		// indexBlockedByIndex[indexA] holds indices of the tasks that block taskList[indexA]
 		indexBlockedByIndex := make(map[int][]int)
@@ -422,6 +429,10 @@ func (s *Store) composePipeline(ctx context.Context, pipeline *PipelineMessage)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find stages for pipeline %d", pipeline.ID)
 	}
+	gateMap, err := s.getStageGateMap(ctx, pipeline.ID)
+	if err != nil {
+		return nil, err
+	}
 	var composedStages []*api.Stage
 	for _, stage := range stages {
 		environment, err := s.GetEnvironmentByID(ctx, stage.EnvironmentID)
@@ -435,6 +446,9 @@ func (s *Store) composePipeline(ctx context.Context, pipeline *PipelineMessage)
 			Environment:   environment,
 			Name:          stage.Name,
 		}
+		if gate, ok := gateMap[stage.ID]; ok {
+			composedStage.Gate = gate.ToAPIStageGate()
+		}
 		for _, composedTask := range composedTasks {
 			if composedTask.StageID == stage.ID {
 				composedStage.TaskList = append(composedStage.TaskList, composedTask)
@@ -459,6 +473,10 @@ func (s *Store) composeSimplePipeline(ctx context.Context, pipeline *PipelineMes
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find stage list")
 	}
+	gateMap, err := s.getStageGateMap(ctx, pipeline.ID)
+	if err != nil {
+		return nil, err
+	}
 	var composedStages []*api.Stage
 	for _, stage := range stages {
 		environment, err := s.GetEnvironmentByID(ctx, stage.EnvironmentID)
@@ -472,6 +490,9 @@ func (s *Store) composeSimplePipeline(ctx context.Context, pipeline *PipelineMes
 			Environment:   environment,
 			PipelineID:    stage.PipelineID,
 		}
+		if gate, ok := gateMap[stage.ID]; ok {
+			composedStage.Gate = gate.ToAPIStageGate()
+		}
 
 		for _, task := range tasks {
 			if task.StageID == stage.ID {
@@ -507,6 +528,8 @@ type IssueMessage struct {
 	CreatedTime time.Time
 	Updater     *UserMessage
 	UpdatedTime time.Time
+	// RiskLevel is the highest risk level of the tasks, it's found by the approval runner.
+	RiskLevel int64
 
 	// Internal fields.
 	projectUID     int
@@ -527,6 +550,7 @@ type UpdateIssueMessage struct {
 	NeedAttention *bool
 	Payload       *string
 	Subscribers   *[]*UserMessage
+	RiskLevel     *int64
 }
 
 // FindIssueMessage is the message to find issues.
@@ -679,6 +703,9 @@ func (s *Store) UpdateIssueV2(ctx context.Context, uid int, patch *UpdateIssueMe
 	if v := patch.Payload; v != nil {
 		set, args = append(set, fmt.Sprintf("payload = $%d", len(args)+1)), append(args, *v)
 	}
+	if v := patch.RiskLevel; v != nil {
+		set, args = append(set, fmt.Sprintf("risk_level = $%d", len(args)+1)), append(args, *v)
+	}
 	args = append(args, uid)
 
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -855,6 +882,7 @@ func (s *Store) ListIssueV2(ctx context.Context, find *FindIssueMessage) ([]*Iss
 			issue.assignee_id,
 			issue.assignee_need_attention,
 			issue.payload,
+			issue.risk_level,
 			ARRAY_AGG (
 				issue_subscriber.subscriber_id
 			) subscribers
@@ -888,6 +916,7 @@ func (s *Store) ListIssueV2(ctx context.Context, find *FindIssueMessage) ([]*Iss
 			&issue.assigneeUID,
 			&issue.NeedAttention,
 			&issue.Payload,
+			&issue.RiskLevel,
 			pq.Array(&subscribers),
 		); err != nil {
 			return nil, err
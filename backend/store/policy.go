@@ -138,6 +138,26 @@ func (s *Store) GetSlowQueryPolicy(ctx context.Context, resourceType api.PolicyR
 	return api.UnmarshalSlowQueryPolicy(policy.Payload)
 }
 
+// GetSQLExportPolicy will get the SQL export policy for environment ID.
+func (s *Store) GetSQLExportPolicy(ctx context.Context, environmentID int) (*api.SQLExportPolicy, error) {
+	resourceType := api.PolicyResourceTypeEnvironment
+	pType := api.PolicyTypeSQLExport
+	policy, err := s.GetPolicyV2(ctx, &FindPolicyMessage{
+		ResourceType: &resourceType,
+		ResourceUID:  &environmentID,
+		Type:         &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil || !policy.Enforce {
+		return &api.SQLExportPolicy{}, nil
+	}
+
+	return api.UnmarshalSQLExportPolicy(policy.Payload)
+}
+
 // PolicyMessage is the mssage for policy.
 type PolicyMessage struct {
 	ResourceUID       int
@@ -441,3 +461,63 @@ func (*Store) listPolicyImplV2(ctx context.Context, tx *Tx, find *FindPolicyMess
 	}
 	return policyList, nil
 }
+
+// GetSQLQueryPolicy will get the SQL query policy for environment ID.
+func (s *Store) GetSQLQueryPolicy(ctx context.Context, environmentID int) (*api.SQLQueryPolicy, error) {
+	resourceType := api.PolicyResourceTypeEnvironment
+	pType := api.PolicyTypeSQLQuery
+	policy, err := s.GetPolicyV2(ctx, &FindPolicyMessage{
+		ResourceType: &resourceType,
+		ResourceUID:  &environmentID,
+		Type:         &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil || !policy.Enforce {
+		return &api.SQLQueryPolicy{}, nil
+	}
+
+	return api.UnmarshalSQLQueryPolicy(policy.Payload)
+}
+
+// GetRowFilterPolicy will get the row filter policy for database ID.
+func (s *Store) GetRowFilterPolicy(ctx context.Context, databaseID int) (*api.RowFilterPolicy, error) {
+	resourceType := api.PolicyResourceTypeDatabase
+	pType := api.PolicyTypeRowFilter
+	policy, err := s.GetPolicyV2(ctx, &FindPolicyMessage{
+		ResourceType: &resourceType,
+		ResourceUID:  &databaseID,
+		Type:         &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil || !policy.Enforce {
+		return &api.RowFilterPolicy{}, nil
+	}
+
+	return api.UnmarshalRowFilterPolicy(policy.Payload)
+}
+
+// GetTableGrowthPolicyByEnvID will get the table growth policy for an environment.
+func (s *Store) GetTableGrowthPolicyByEnvID(ctx context.Context, environmentID int) (*api.TableGrowthPolicy, error) {
+	resourceType := api.PolicyResourceTypeEnvironment
+	pType := api.PolicyTypeTableGrowth
+	policy, err := s.GetPolicyV2(ctx, &FindPolicyMessage{
+		ResourceType: &resourceType,
+		ResourceUID:  &environmentID,
+		Type:         &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil || !policy.Enforce {
+		return &api.TableGrowthPolicy{}, nil
+	}
+
+	return api.UnmarshalTableGrowthPolicy(policy.Payload)
+}
@@ -348,6 +348,34 @@ func (s *Store) CreateUser(ctx context.Context, create *UserMessage, creatorID i
 	return user, nil
 }
 
+// IsLastWorkspaceOwner returns true if there is at most one active end user with the workspace Owner role.
+func (s *Store) IsLastWorkspaceOwner(ctx context.Context) (bool, error) {
+	owner, endUser := api.Owner, api.EndUser
+	owners, err := s.ListUsers(ctx, &FindUserMessage{Role: &owner, Type: &endUser})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list workspace owners")
+	}
+	return len(owners) <= 1, nil
+}
+
+// UpdateUserWorkspaceRole updates the workspace role of the user synced from the groups of an identity provider, such as LDAP and SCIM.
+// It never demotes the last workspace owner to avoid locking the workspace out, and returns the user unchanged instead.
+func (s *Store) UpdateUserWorkspaceRole(ctx context.Context, user *UserMessage, role api.Role, updaterID int) (*UserMessage, error) {
+	if user.Role == role {
+		return user, nil
+	}
+	if user.Role == api.Owner {
+		isLastOwner, err := s.IsLastWorkspaceOwner(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if isLastOwner {
+			return user, nil
+		}
+	}
+	return s.UpdateUser(ctx, user.ID, &UpdateUserMessage{Role: &role}, updaterID)
+}
+
 // UpdateUser updates a user.
 func (s *Store) UpdateUser(ctx context.Context, userID int, patch *UpdateUserMessage, updaterID int) (*UserMessage, error) {
 	if userID == api.SystemBotID {
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -23,23 +24,42 @@ type ProjectWebhookMessage struct {
 	URL string
 	// ActivityList is the list of activities that the webhook is interested in.
 	ActivityList []string
+	// Secret is used to sign the payload of the custom webhook.
+	Secret string
+	// Filter is the filter of the events in addition to the ActivityList.
+	Filter *api.ProjectWebhookFilter
+	// PayloadTemplate is the Go template of the custom webhook request body.
+	PayloadTemplate string
 	// Output only fields.
 	//
 	// ID is the unique identifier of the project webhook.
 	ID        int
 	ProjectID int
+	// Enabled is false after the webhook is disabled for too many consecutive failed deliveries.
+	Enabled bool
+	// FailureCount is the number of consecutive failed deliveries.
+	FailureCount int
 }
 
 // ToAPIProjectWebhook converts a ProjectWebhookMessage to an api.ProjectWebhook.
-func (p *ProjectWebhookMessage) ToAPIProjectWebhook() *api.ProjectWebhook {
-	return &api.ProjectWebhook{
-		ID:           p.ID,
-		ProjectID:    p.ProjectID,
-		Type:         p.Type,
-		URL:          p.URL,
-		ActivityList: p.ActivityList,
-		Name:         p.Title,
+func (p *ProjectWebhookMessage) ToAPIProjectWebhook() (*api.ProjectWebhook, error) {
+	filter, err := marshalProjectWebhookFilter(p.Filter)
+	if err != nil {
+		return nil, err
 	}
+	return &api.ProjectWebhook{
+		ID:              p.ID,
+		ProjectID:       p.ProjectID,
+		Type:            p.Type,
+		URL:             p.URL,
+		ActivityList:    p.ActivityList,
+		Name:            p.Title,
+		HasSecret:       p.Secret != "",
+		Enabled:         p.Enabled,
+		FailureCount:    p.FailureCount,
+		Filter:          filter,
+		PayloadTemplate: p.PayloadTemplate,
+	}, nil
 }
 
 // UpdateProjectWebhookMessage is the message for updating project webhooks.
@@ -50,6 +70,16 @@ type UpdateProjectWebhookMessage struct {
 	URL *string
 	// ActivityList is the list of activities that the webhook is interested in.
 	ActivityList []string
+	// Secret is used to sign the payload of the custom webhook.
+	Secret *string
+	// Enabled enables or disables the webhook.
+	Enabled *bool
+	// FailureCount is the number of consecutive failed deliveries.
+	FailureCount *int
+	// Filter is the filter of the events in addition to the ActivityList.
+	Filter *api.ProjectWebhookFilter
+	// PayloadTemplate is the Go template of the custom webhook request body.
+	PayloadTemplate *string
 }
 
 // FindProjectWebhookMessage is the message for finding project webhooks,
@@ -71,13 +101,21 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 			type,
 			name,
 			url,
-			activity_list
+			activity_list,
+			secret,
+			filter,
+			payload_template
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, project_id, type, name, url, activity_list
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, project_id, type, name, url, activity_list, secret, enabled, failure_count, filter, payload_template
 	`
 	var projectWebhook ProjectWebhookMessage
 	var txtArray pgtype.TextArray
+	var filter string
+	createFilter, err := marshalProjectWebhookFilter(create.Filter)
+	if err != nil {
+		return nil, err
+	}
 
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -92,6 +130,9 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 		create.Title,
 		create.URL,
 		create.ActivityList,
+		create.Secret,
+		createFilter,
+		create.PayloadTemplate,
 	).Scan(
 		&projectWebhook.ID,
 		&projectWebhook.ProjectID,
@@ -99,6 +140,11 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 		&projectWebhook.Title,
 		&projectWebhook.URL,
 		&txtArray,
+		&projectWebhook.Secret,
+		&projectWebhook.Enabled,
+		&projectWebhook.FailureCount,
+		&filter,
+		&projectWebhook.PayloadTemplate,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
@@ -108,6 +154,9 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 	if err := txtArray.AssignTo(&projectWebhook.ActivityList); err != nil {
 		return nil, err
 	}
+	if projectWebhook.Filter, err = unmarshalProjectWebhookFilter(filter); err != nil {
+		return nil, err
+	}
 	if err := tx.Commit(); err != nil {
 		return nil, errors.Wrapf(err, "failed to commit transaction")
 	}
@@ -178,17 +227,37 @@ func (s *Store) UpdateProjectWebhookV2(ctx context.Context, principalUID int, pr
 	if v := update.ActivityList; v != nil {
 		set, args = append(set, fmt.Sprintf("activity_list = $%d", len(args)+1)), append(args, v)
 	}
+	if v := update.Secret; v != nil {
+		set, args = append(set, fmt.Sprintf("secret = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.Enabled; v != nil {
+		set, args = append(set, fmt.Sprintf("enabled = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.FailureCount; v != nil {
+		set, args = append(set, fmt.Sprintf("failure_count = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.Filter; v != nil {
+		filter, err := marshalProjectWebhookFilter(v)
+		if err != nil {
+			return nil, err
+		}
+		set, args = append(set, fmt.Sprintf("filter = $%d", len(args)+1)), append(args, filter)
+	}
+	if v := update.PayloadTemplate; v != nil {
+		set, args = append(set, fmt.Sprintf("payload_template = $%d", len(args)+1)), append(args, *v)
+	}
 
 	args = append(args, projectWebhookID)
 
 	var projectWebhook ProjectWebhookMessage
 	var txtArray pgtype.TextArray
+	var filter string
 	// Execute update query with RETURNING.
 	if err := tx.QueryRowContext(ctx, fmt.Sprintf(`
 	UPDATE project_webhook
 	SET `+strings.Join(set, ", ")+`
 	WHERE id = $%d
-	RETURNING id, project_id, type, name, url, activity_list
+	RETURNING id, project_id, type, name, url, activity_list, secret, enabled, failure_count, filter, payload_template
 `, len(args)),
 		args...,
 	).Scan(
@@ -198,6 +267,11 @@ func (s *Store) UpdateProjectWebhookV2(ctx context.Context, principalUID int, pr
 		&projectWebhook.Title,
 		&projectWebhook.URL,
 		&txtArray,
+		&projectWebhook.Secret,
+		&projectWebhook.Enabled,
+		&projectWebhook.FailureCount,
+		&filter,
+		&projectWebhook.PayloadTemplate,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("project hook ID not found: %d", projectWebhookID)}
@@ -207,6 +281,9 @@ func (s *Store) UpdateProjectWebhookV2(ctx context.Context, principalUID int, pr
 	if err := txtArray.AssignTo(&projectWebhook.ActivityList); err != nil {
 		return nil, err
 	}
+	if projectWebhook.Filter, err = unmarshalProjectWebhookFilter(filter); err != nil {
+		return nil, err
+	}
 
 	if err := tx.Commit(); err != nil {
 		return nil, errors.Wrapf(err, "failed to commit transaction")
@@ -253,10 +330,16 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 	rows, err := tx.QueryContext(ctx, `
 		SELECT
 			id,
+			project_id,
 			type,
 			name,
 			url,
-			activity_list
+			activity_list,
+			secret,
+			enabled,
+			failure_count,
+			filter,
+			payload_template
 		FROM project_webhook
 		WHERE `+strings.Join(where, " AND "),
 		args...,
@@ -270,13 +353,20 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 	for rows.Next() {
 		var projectWebhook ProjectWebhookMessage
 		var txtArray pgtype.TextArray
+		var filter string
 
 		if err := rows.Scan(
 			&projectWebhook.ID,
+			&projectWebhook.ProjectID,
 			&projectWebhook.Type,
 			&projectWebhook.Title,
 			&projectWebhook.URL,
 			&txtArray,
+			&projectWebhook.Secret,
+			&projectWebhook.Enabled,
+			&projectWebhook.FailureCount,
+			&filter,
+			&projectWebhook.PayloadTemplate,
 		); err != nil {
 			return nil, err
 		}
@@ -284,6 +374,9 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 		if err := txtArray.AssignTo(&projectWebhook.ActivityList); err != nil {
 			return nil, err
 		}
+		if projectWebhook.Filter, err = unmarshalProjectWebhookFilter(filter); err != nil {
+			return nil, err
+		}
 
 		if v := find.ActivityType; v != nil {
 			for _, activity := range projectWebhook.ActivityList {
@@ -302,3 +395,22 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 
 	return projectWebhooks, nil
 }
+
+func marshalProjectWebhookFilter(filter *api.ProjectWebhookFilter) (string, error) {
+	if filter == nil {
+		return "{}", nil
+	}
+	bytes, err := json.Marshal(filter)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal project webhook filter")
+	}
+	return string(bytes), nil
+}
+
+func unmarshalProjectWebhookFilter(filter string) (*api.ProjectWebhookFilter, error) {
+	var v api.ProjectWebhookFilter
+	if err := json.Unmarshal([]byte(filter), &v); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal project webhook filter")
+	}
+	return &v, nil
+}
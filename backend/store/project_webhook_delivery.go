@@ -0,0 +1,274 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+// ProjectWebhookDeliveryMessage is the store model for a delivery of a project webhook.
+type ProjectWebhookDeliveryMessage struct {
+	// ProjectWebhookID is the ID of the project webhook to deliver to.
+	ProjectWebhookID int
+	// Status is the status of the delivery.
+	Status api.ProjectWebhookDeliveryStatus
+	// NextAttemptTs is the timestamp after which the pending delivery will be attempted.
+	NextAttemptTs int64
+	// Payload is the webhook context in json format.
+	Payload string
+	// Output only fields.
+	//
+	// ID is the unique identifier of the delivery.
+	ID int
+	// CreatedTs is the timestamp when the delivery is created.
+	CreatedTs int64
+	// UpdatedTs is the timestamp when the delivery is updated.
+	UpdatedTs int64
+	// Attempt is the number of the attempts made.
+	Attempt int
+	// LastError is the error of the last failed attempt.
+	LastError string
+}
+
+// ToAPIProjectWebhookDelivery converts a ProjectWebhookDeliveryMessage to an api.ProjectWebhookDelivery.
+func (d *ProjectWebhookDeliveryMessage) ToAPIProjectWebhookDelivery() *api.ProjectWebhookDelivery {
+	return &api.ProjectWebhookDelivery{
+		ID:               d.ID,
+		CreatedTs:        d.CreatedTs,
+		UpdatedTs:        d.UpdatedTs,
+		ProjectWebhookID: d.ProjectWebhookID,
+		Status:           d.Status,
+		Attempt:          d.Attempt,
+		NextAttemptTs:    d.NextAttemptTs,
+		LastError:        d.LastError,
+		Payload:          d.Payload,
+	}
+}
+
+// FindProjectWebhookDeliveryMessage is the message for finding project webhook deliveries.
+// The deliveries are returned in the descending order of ID.
+type FindProjectWebhookDeliveryMessage struct {
+	ID               *int
+	ProjectWebhookID *int
+	Status           *api.ProjectWebhookDeliveryStatus
+	// NextAttemptTsBefore finds the deliveries whose next attempt is due at the timestamp.
+	NextAttemptTsBefore *int64
+	// OrderByNextAttemptTs lists the deliveries in the ascending order of the next attempt, so that the earliest due ones come first.
+	// Otherwise, the deliveries are listed in the descending order of ID.
+	OrderByNextAttemptTs bool
+	Limit                *int
+}
+
+// UpdateProjectWebhookDeliveryMessage is the message for updating a project webhook delivery.
+type UpdateProjectWebhookDeliveryMessage struct {
+	ID            int
+	Status        *api.ProjectWebhookDeliveryStatus
+	Attempt       *int
+	NextAttemptTs *int64
+	LastError     *string
+}
+
+// CreateProjectWebhookDelivery creates a project webhook delivery.
+func (s *Store) CreateProjectWebhookDelivery(ctx context.Context, create *ProjectWebhookDeliveryMessage) (*ProjectWebhookDeliveryMessage, error) {
+	if create.Payload == "" {
+		create.Payload = "{}"
+	}
+	query := `
+		INSERT INTO project_webhook_delivery (
+			project_webhook_id,
+			status,
+			next_attempt_ts,
+			payload
+		)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_ts, updated_ts, project_webhook_id, status, attempt, next_attempt_ts, last_error, payload
+	`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var delivery ProjectWebhookDeliveryMessage
+	if err := tx.QueryRowContext(ctx, query,
+		create.ProjectWebhookID,
+		create.Status,
+		create.NextAttemptTs,
+		create.Payload,
+	).Scan(
+		&delivery.ID,
+		&delivery.CreatedTs,
+		&delivery.UpdatedTs,
+		&delivery.ProjectWebhookID,
+		&delivery.Status,
+		&delivery.Attempt,
+		&delivery.NextAttemptTs,
+		&delivery.LastError,
+		&delivery.Payload,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return &delivery, nil
+}
+
+// GetProjectWebhookDelivery gets a project webhook delivery.
+func (s *Store) GetProjectWebhookDelivery(ctx context.Context, find *FindProjectWebhookDeliveryMessage) (*ProjectWebhookDeliveryMessage, error) {
+	deliveries, err := s.ListProjectWebhookDelivery(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+	if len(deliveries) > 1 {
+		return nil, &common.Error{Code: common.Conflict, Err: errors.Errorf("found %d project webhook deliveries with filter %+v, expect 1", len(deliveries), find)}
+	}
+	return deliveries[0], nil
+}
+
+// ListProjectWebhookDelivery lists project webhook deliveries.
+func (s *Store) ListProjectWebhookDelivery(ctx context.Context, find *FindProjectWebhookDeliveryMessage) ([]*ProjectWebhookDeliveryMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.ProjectWebhookID; v != nil {
+		where, args = append(where, fmt.Sprintf("project_webhook_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Status; v != nil {
+		where, args = append(where, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.NextAttemptTsBefore; v != nil {
+		where, args = append(where, fmt.Sprintf("next_attempt_ts <= $%d", len(args)+1)), append(args, *v)
+	}
+	orderBy := "id DESC"
+	if find.OrderByNextAttemptTs {
+		orderBy = "next_attempt_ts, id"
+	}
+	query := `
+		SELECT
+			id,
+			created_ts,
+			updated_ts,
+			project_webhook_id,
+			status,
+			attempt,
+			next_attempt_ts,
+			last_error,
+			payload
+		FROM project_webhook_delivery
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY ` + orderBy
+	if v := find.Limit; v != nil {
+		query += fmt.Sprintf(" LIMIT %d", *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*ProjectWebhookDeliveryMessage
+	for rows.Next() {
+		var delivery ProjectWebhookDeliveryMessage
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.CreatedTs,
+			&delivery.UpdatedTs,
+			&delivery.ProjectWebhookID,
+			&delivery.Status,
+			&delivery.Attempt,
+			&delivery.NextAttemptTs,
+			&delivery.LastError,
+			&delivery.Payload,
+		); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return deliveries, nil
+}
+
+// UpdateProjectWebhookDelivery updates a project webhook delivery.
+func (s *Store) UpdateProjectWebhookDelivery(ctx context.Context, update *UpdateProjectWebhookDeliveryMessage) (*ProjectWebhookDeliveryMessage, error) {
+	set, args := []string{}, []any{}
+	if v := update.Status; v != nil {
+		set, args = append(set, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.Attempt; v != nil {
+		set, args = append(set, fmt.Sprintf("attempt = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.NextAttemptTs; v != nil {
+		set, args = append(set, fmt.Sprintf("next_attempt_ts = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.LastError; v != nil {
+		set, args = append(set, fmt.Sprintf("last_error = $%d", len(args)+1)), append(args, *v)
+	}
+	if len(set) == 0 {
+		return nil, errors.New("no update field provided")
+	}
+	args = append(args, update.ID)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var delivery ProjectWebhookDeliveryMessage
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE project_webhook_delivery
+		SET `+strings.Join(set, ", ")+`
+		WHERE id = $%d
+		RETURNING id, created_ts, updated_ts, project_webhook_id, status, attempt, next_attempt_ts, last_error, payload
+	`, len(args)),
+		args...,
+	).Scan(
+		&delivery.ID,
+		&delivery.CreatedTs,
+		&delivery.UpdatedTs,
+		&delivery.ProjectWebhookID,
+		&delivery.Status,
+		&delivery.Attempt,
+		&delivery.NextAttemptTs,
+		&delivery.LastError,
+		&delivery.Payload,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("project webhook delivery ID not found: %d", update.ID)}
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return &delivery, nil
+}
@@ -0,0 +1,251 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+)
+
+// SCIMGroupMessage is the store model for a group provisioned by the identity provider through SCIM.
+type SCIMGroupMessage struct {
+	// ExternalID is the identifier of the group in the identity provider.
+	ExternalID  string
+	DisplayName string
+	// MemberList is the principal IDs of the group members.
+	MemberList []int
+	// Output only fields.
+	//
+	// ID is the unique identifier of the group.
+	ID int
+	// CreatedTs is the timestamp when the group is created.
+	CreatedTs int64
+	// UpdatedTs is the timestamp when the group is updated.
+	UpdatedTs int64
+}
+
+// FindSCIMGroupMessage is the message for finding SCIM groups.
+type FindSCIMGroupMessage struct {
+	ID          *int
+	ExternalID  *string
+	DisplayName *string
+	// MemberID finds the groups containing the principal.
+	MemberID *int
+}
+
+// UpdateSCIMGroupMessage is the message for updating a SCIM group.
+type UpdateSCIMGroupMessage struct {
+	ID          int
+	ExternalID  *string
+	DisplayName *string
+	MemberList  *[]int
+}
+
+// CreateSCIMGroup creates a SCIM group.
+func (s *Store) CreateSCIMGroup(ctx context.Context, create *SCIMGroupMessage) (*SCIMGroupMessage, error) {
+	if create.MemberList == nil {
+		create.MemberList = []int{}
+	}
+	query := `
+		INSERT INTO scim_group (
+			external_id,
+			display_name,
+			member_list
+		)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_ts, updated_ts
+	`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	group := &SCIMGroupMessage{
+		ExternalID:  create.ExternalID,
+		DisplayName: create.DisplayName,
+		MemberList:  create.MemberList,
+	}
+	if err := tx.QueryRowContext(ctx, query,
+		create.ExternalID,
+		create.DisplayName,
+		pq.Array(create.MemberList),
+	).Scan(
+		&group.ID,
+		&group.CreatedTs,
+		&group.UpdatedTs,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return group, nil
+}
+
+// GetSCIMGroup gets a SCIM group.
+func (s *Store) GetSCIMGroup(ctx context.Context, find *FindSCIMGroupMessage) (*SCIMGroupMessage, error) {
+	groups, err := s.ListSCIMGroups(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	if len(groups) > 1 {
+		return nil, &common.Error{Code: common.Conflict, Err: errors.Errorf("found %d SCIM groups with filter %+v, expect 1", len(groups), find)}
+	}
+	return groups[0], nil
+}
+
+// ListSCIMGroups lists SCIM groups.
+func (s *Store) ListSCIMGroups(ctx context.Context, find *FindSCIMGroupMessage) ([]*SCIMGroupMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.ExternalID; v != nil {
+		where, args = append(where, fmt.Sprintf("external_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.DisplayName; v != nil {
+		where, args = append(where, fmt.Sprintf("display_name = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.MemberID; v != nil {
+		where, args = append(where, fmt.Sprintf("$%d = ANY(member_list)", len(args)+1)), append(args, *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			created_ts,
+			updated_ts,
+			external_id,
+			display_name,
+			member_list
+		FROM scim_group
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY id ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []*SCIMGroupMessage
+	for rows.Next() {
+		var group SCIMGroupMessage
+		var memberList pq.Int64Array
+		if err := rows.Scan(
+			&group.ID,
+			&group.CreatedTs,
+			&group.UpdatedTs,
+			&group.ExternalID,
+			&group.DisplayName,
+			&memberList,
+		); err != nil {
+			return nil, err
+		}
+		for _, member := range memberList {
+			group.MemberList = append(group.MemberList, int(member))
+		}
+		groups = append(groups, &group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return groups, nil
+}
+
+// UpdateSCIMGroup updates a SCIM group.
+func (s *Store) UpdateSCIMGroup(ctx context.Context, update *UpdateSCIMGroupMessage) (*SCIMGroupMessage, error) {
+	set, args := []string{}, []any{}
+	if v := update.ExternalID; v != nil {
+		set, args = append(set, fmt.Sprintf("external_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.DisplayName; v != nil {
+		set, args = append(set, fmt.Sprintf("display_name = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.MemberList; v != nil {
+		memberList := *v
+		if memberList == nil {
+			memberList = []int{}
+		}
+		set, args = append(set, fmt.Sprintf("member_list = $%d", len(args)+1)), append(args, pq.Array(memberList))
+	}
+	if len(set) == 0 {
+		return nil, errors.New("no update field provided")
+	}
+	args = append(args, update.ID)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var group SCIMGroupMessage
+	var memberList pq.Int64Array
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE scim_group
+		SET `+strings.Join(set, ", ")+`
+		WHERE id = $%d
+		RETURNING id, created_ts, updated_ts, external_id, display_name, member_list
+	`, len(args)),
+		args...,
+	).Scan(
+		&group.ID,
+		&group.CreatedTs,
+		&group.UpdatedTs,
+		&group.ExternalID,
+		&group.DisplayName,
+		&memberList,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("SCIM group ID not found: %d", update.ID)}
+		}
+		return nil, err
+	}
+	for _, member := range memberList {
+		group.MemberList = append(group.MemberList, int(member))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return &group, nil
+}
+
+// DeleteSCIMGroup deletes a SCIM group.
+func (s *Store) DeleteSCIMGroup(ctx context.Context, id int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM scim_group WHERE id = $1`, id); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrapf(err, "failed to commit transaction")
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+// SensitiveColumnSuggestionMessage is the store model for a sensitive column found by the classification scanner.
+type SensitiveColumnSuggestionMessage struct {
+	DatabaseID     int
+	SchemaName     string
+	TableName      string
+	ColumnName     string
+	Classification api.SensitiveColumnClassification
+	// Confidence is the confidence of the classification in [0, 1].
+	Confidence float64
+	// MaskType is the mask type suggested for the column.
+	MaskType api.SensitiveDataMaskType
+	// Reason explains how the classification is found.
+	Reason string
+	Status api.SensitiveColumnSuggestionStatus
+	// Output only fields.
+	//
+	// ID is the unique identifier of the suggestion.
+	ID int
+	// CreatedTs is the timestamp when the suggestion is created.
+	CreatedTs int64
+	// UpdatedTs is the timestamp when the suggestion is updated.
+	UpdatedTs int64
+}
+
+// ToAPISensitiveColumnSuggestion converts a SensitiveColumnSuggestionMessage to an api.SensitiveColumnSuggestion.
+func (m *SensitiveColumnSuggestionMessage) ToAPISensitiveColumnSuggestion() *api.SensitiveColumnSuggestion {
+	return &api.SensitiveColumnSuggestion{
+		ID:             m.ID,
+		CreatedTs:      m.CreatedTs,
+		UpdatedTs:      m.UpdatedTs,
+		DatabaseID:     m.DatabaseID,
+		Schema:         m.SchemaName,
+		Table:          m.TableName,
+		Column:         m.ColumnName,
+		Classification: m.Classification,
+		Confidence:     m.Confidence,
+		MaskType:       m.MaskType,
+		Reason:         m.Reason,
+		Status:         m.Status,
+	}
+}
+
+// FindSensitiveColumnSuggestionMessage is the message for finding sensitive column suggestions.
+// The suggestions are returned in the descending order of confidence.
+type FindSensitiveColumnSuggestionMessage struct {
+	IDList     *[]int
+	DatabaseID *int
+	Status     *api.SensitiveColumnSuggestionStatus
+	Limit      *int
+}
+
+// UpdateSensitiveColumnSuggestionMessage is the message for updating a sensitive column suggestion.
+type UpdateSensitiveColumnSuggestionMessage struct {
+	ID     int
+	Status *api.SensitiveColumnSuggestionStatus
+}
+
+// UpsertSensitiveColumnSuggestion creates a sensitive column suggestion, or updates the classification of the existing OPEN suggestion for the same column.
+// The ACCEPTED and DISMISSED suggestions are kept as is, so that the reviewed columns are not suggested again.
+func (s *Store) UpsertSensitiveColumnSuggestion(ctx context.Context, upsert *SensitiveColumnSuggestionMessage) error {
+	query := `
+		INSERT INTO sensitive_column_suggestion (
+			database_id,
+			schema_name,
+			table_name,
+			column_name,
+			classification,
+			confidence,
+			mask_type,
+			reason,
+			status
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT(database_id, schema_name, table_name, column_name) DO UPDATE SET
+			classification = EXCLUDED.classification,
+			confidence = EXCLUDED.confidence,
+			mask_type = EXCLUDED.mask_type,
+			reason = EXCLUDED.reason
+		WHERE sensitive_column_suggestion.status = 'OPEN'
+	`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, query,
+		upsert.DatabaseID,
+		upsert.SchemaName,
+		upsert.TableName,
+		upsert.ColumnName,
+		upsert.Classification,
+		upsert.Confidence,
+		upsert.MaskType,
+		upsert.Reason,
+		api.SensitiveColumnSuggestionOpen,
+	); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrapf(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// ListSensitiveColumnSuggestion lists sensitive column suggestions.
+func (s *Store) ListSensitiveColumnSuggestion(ctx context.Context, find *FindSensitiveColumnSuggestionMessage) ([]*SensitiveColumnSuggestionMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.IDList; v != nil {
+		var list []string
+		for _, id := range *v {
+			list, args = append(list, fmt.Sprintf("$%d", len(args)+1)), append(args, id)
+		}
+		if len(list) == 0 {
+			return nil, nil
+		}
+		where = append(where, fmt.Sprintf("id IN (%s)", strings.Join(list, ", ")))
+	}
+	if v := find.DatabaseID; v != nil {
+		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Status; v != nil {
+		where, args = append(where, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	query := `
+		SELECT
+			id,
+			created_ts,
+			updated_ts,
+			database_id,
+			schema_name,
+			table_name,
+			column_name,
+			classification,
+			confidence,
+			mask_type,
+			reason,
+			status
+		FROM sensitive_column_suggestion
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY confidence DESC, id ASC`
+	if v := find.Limit; v != nil {
+		query += fmt.Sprintf(" LIMIT %d", *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suggestions []*SensitiveColumnSuggestionMessage
+	for rows.Next() {
+		var suggestion SensitiveColumnSuggestionMessage
+		if err := rows.Scan(
+			&suggestion.ID,
+			&suggestion.CreatedTs,
+			&suggestion.UpdatedTs,
+			&suggestion.DatabaseID,
+			&suggestion.SchemaName,
+			&suggestion.TableName,
+			&suggestion.ColumnName,
+			&suggestion.Classification,
+			&suggestion.Confidence,
+			&suggestion.MaskType,
+			&suggestion.Reason,
+			&suggestion.Status,
+		); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, &suggestion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return suggestions, nil
+}
+
+// UpdateSensitiveColumnSuggestion updates a sensitive column suggestion.
+func (s *Store) UpdateSensitiveColumnSuggestion(ctx context.Context, update *UpdateSensitiveColumnSuggestionMessage) (*SensitiveColumnSuggestionMessage, error) {
+	set, args := []string{}, []any{}
+	if v := update.Status; v != nil {
+		set, args = append(set, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	if len(set) == 0 {
+		return nil, errors.New("no update field provided")
+	}
+	args = append(args, update.ID)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var suggestion SensitiveColumnSuggestionMessage
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE sensitive_column_suggestion
+		SET `+strings.Join(set, ", ")+`
+		WHERE id = $%d
+		RETURNING id, created_ts, updated_ts, database_id, schema_name, table_name, column_name, classification, confidence, mask_type, reason, status
+	`, len(args)),
+		args...,
+	).Scan(
+		&suggestion.ID,
+		&suggestion.CreatedTs,
+		&suggestion.UpdatedTs,
+		&suggestion.DatabaseID,
+		&suggestion.SchemaName,
+		&suggestion.TableName,
+		&suggestion.ColumnName,
+		&suggestion.Classification,
+		&suggestion.Confidence,
+		&suggestion.MaskType,
+		&suggestion.Reason,
+		&suggestion.Status,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("sensitive column suggestion ID not found: %d", update.ID)}
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return &suggestion, nil
+}
@@ -0,0 +1,236 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+// StageGateMessage is the store model for the gate of a stage.
+// The tasks of the later stages in the pipeline don't run until the gate passes.
+type StageGateMessage struct {
+	PipelineID int
+	StageID    int
+	Status     api.StageGateStatus
+	// Config is copied from the deployment config when the pipeline is created,
+	// so that changing the deployment config doesn't affect the ongoing rollouts.
+	Config *DeploymentGate
+	// Detail is the reason why the gate failed or was resumed.
+	Detail string
+
+	// Output only fields.
+	ID        int
+	UpdaterID int
+	UpdatedTs int64
+}
+
+// ToAPIStageGate converts the message to a legacy API stage gate.
+func (g *StageGateMessage) ToAPIStageGate() *api.StageGate {
+	return &api.StageGate{
+		Status:    g.Status,
+		Config:    g.Config.ToAPIDeploymentGate(),
+		Detail:    g.Detail,
+		UpdatedTs: g.UpdatedTs,
+	}
+}
+
+// FindStageGateMessage is the message for finding stage gates.
+type FindStageGateMessage struct {
+	PipelineID *int
+	StageID    *int
+	Status     *api.StageGateStatus
+}
+
+// UpdateStageGateMessage is the message for updating a stage gate.
+type UpdateStageGateMessage struct {
+	StageID   int
+	UpdaterID int
+	// FromStatus updates the gate only if it's in the status, so that concurrent updates don't override each other.
+	FromStatus *api.StageGateStatus
+
+	Status *api.StageGateStatus
+	Detail *string
+}
+
+// CreateStageGates creates the stage gates.
+func (s *Store) CreateStageGates(ctx context.Context, creates []*StageGateMessage, creatorID int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	for _, create := range creates {
+		config, err := json.Marshal(create.Config)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal the gate config of stage %d", create.StageID)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO stage_gate (
+				updater_id,
+				pipeline_id,
+				stage_id,
+				status,
+				config
+			)
+			VALUES ($1, $2, $3, $4, $5)
+		`,
+			creatorID,
+			create.PipelineID,
+			create.StageID,
+			create.Status,
+			config,
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrapf(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// ListStageGates lists the stage gates in the ascending order of the stage ID.
+func (s *Store) ListStageGates(ctx context.Context, find *FindStageGateMessage) ([]*StageGateMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.PipelineID; v != nil {
+		where, args = append(where, fmt.Sprintf("pipeline_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.StageID; v != nil {
+		where, args = append(where, fmt.Sprintf("stage_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Status; v != nil {
+		where, args = append(where, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			updater_id,
+			updated_ts,
+			pipeline_id,
+			stage_id,
+			status,
+			config,
+			detail
+		FROM stage_gate
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY stage_id`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var gates []*StageGateMessage
+	for rows.Next() {
+		var gate StageGateMessage
+		var config []byte
+		if err := rows.Scan(
+			&gate.ID,
+			&gate.UpdaterID,
+			&gate.UpdatedTs,
+			&gate.PipelineID,
+			&gate.StageID,
+			&gate.Status,
+			&config,
+			&gate.Detail,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(config, &gate.Config); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal the gate config of stage %d", gate.StageID)
+		}
+		gates = append(gates, &gate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return gates, nil
+}
+
+// UpdateStageGate updates the gate of a stage. It returns nil if no gate matches the stage ID and the FromStatus.
+func (s *Store) UpdateStageGate(ctx context.Context, update *UpdateStageGateMessage) (*StageGateMessage, error) {
+	set, args := []string{"updater_id = $1"}, []any{update.UpdaterID}
+	if v := update.Status; v != nil {
+		set, args = append(set, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.Detail; v != nil {
+		set, args = append(set, fmt.Sprintf("detail = $%d", len(args)+1)), append(args, *v)
+	}
+	where := []string{}
+	where, args = append(where, fmt.Sprintf("stage_id = $%d", len(args)+1)), append(args, update.StageID)
+	if v := update.FromStatus; v != nil {
+		where, args = append(where, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var gate StageGateMessage
+	var config []byte
+	if err := tx.QueryRowContext(ctx, `
+		UPDATE stage_gate
+		SET `+strings.Join(set, ", ")+`
+		WHERE `+strings.Join(where, " AND ")+`
+		RETURNING id, updater_id, updated_ts, pipeline_id, stage_id, status, config, detail
+	`,
+		args...,
+	).Scan(
+		&gate.ID,
+		&gate.UpdaterID,
+		&gate.UpdatedTs,
+		&gate.PipelineID,
+		&gate.StageID,
+		&gate.Status,
+		&config,
+		&gate.Detail,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(config, &gate.Config); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the gate config of stage %d", gate.StageID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return &gate, nil
+}
+
+// getStageGateMap returns the gates of the stages in the pipeline keyed by the stage ID.
+func (s *Store) getStageGateMap(ctx context.Context, pipelineID int) (map[int]*StageGateMessage, error) {
+	gates, err := s.ListStageGates(ctx, &FindStageGateMessage{PipelineID: &pipelineID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find stage gates for pipeline %d", pipelineID)
+	}
+	gateMap := make(map[int]*StageGateMessage)
+	for _, gate := range gates {
+		gateMap[gate.StageID] = gate
+	}
+	return gateMap, nil
+}
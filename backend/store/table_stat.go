@@ -0,0 +1,195 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	// tableStatInterval is the minimal interval between two recordings of the table stats of a database.
+	// The schema syncer runs more often, and recording every sync would bloat the series without adding value for capacity planning.
+	tableStatInterval = time.Hour
+	// tableStatRetention is how long the table stats are kept.
+	tableStatRetention = 90 * 24 * time.Hour
+)
+
+// TableStatMessage is the store model for the statistics of a table at a point in time.
+type TableStatMessage struct {
+	ID          int64
+	DatabaseUID int
+	CreatedTs   int64
+	SchemaName  string
+	TableName   string
+	RowCount    int64
+	DataSize    int64
+	IndexSize   int64
+	DataFree    int64
+}
+
+// ToAPITableStat converts the message to the API message.
+func (s *TableStatMessage) ToAPITableStat() *api.TableStat {
+	return &api.TableStat{
+		ID:         s.ID,
+		CreatedTs:  s.CreatedTs,
+		DatabaseID: s.DatabaseUID,
+		SchemaName: s.SchemaName,
+		TableName:  s.TableName,
+		RowCount:   s.RowCount,
+		DataSize:   s.DataSize,
+		IndexSize:  s.IndexSize,
+		DataFree:   s.DataFree,
+	}
+}
+
+// FindTableStatMessage is the message for finding table stats.
+type FindTableStatMessage struct {
+	DatabaseUID *int
+	SchemaName  *string
+	TableName   *string
+	// CreatedTsAfter finds the stats created at or after the timestamp.
+	CreatedTsAfter *int64
+}
+
+// ListTableStats lists the table stats from the earliest to the latest.
+func (s *Store) ListTableStats(ctx context.Context, find *FindTableStatMessage) ([]*TableStatMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.DatabaseUID; v != nil {
+		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.SchemaName; v != nil {
+		where, args = append(where, fmt.Sprintf("schema_name = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.TableName; v != nil {
+		where, args = append(where, fmt.Sprintf("table_name = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.CreatedTsAfter; v != nil {
+		where, args = append(where, fmt.Sprintf("created_ts >= $%d", len(args)+1)), append(args, *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+		SELECT
+			id,
+			database_id,
+			created_ts,
+			schema_name,
+			table_name,
+			row_count,
+			data_size,
+			index_size,
+			data_free
+		FROM table_stat
+		WHERE %s
+		ORDER BY created_ts, id`, strings.Join(where, " AND ")),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []*TableStatMessage
+	for rows.Next() {
+		stat := &TableStatMessage{}
+		if err := rows.Scan(
+			&stat.ID,
+			&stat.DatabaseUID,
+			&stat.CreatedTs,
+			&stat.SchemaName,
+			&stat.TableName,
+			&stat.RowCount,
+			&stat.DataSize,
+			&stat.IndexSize,
+			&stat.DataFree,
+		); err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return stats, nil
+}
+
+// CreateTableStats records the stats of the tables in the database metadata.
+// It's a no-op if the stats of the database have been recorded within tableStatInterval,
+// and the stats older than tableStatRetention are purged.
+func (s *Store) CreateTableStats(ctx context.Context, databaseUID int, metadata *storepb.DatabaseMetadata) error {
+	if metadata == nil {
+		return nil
+	}
+	now := time.Now()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var latestTs sql.NullInt64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT MAX(created_ts) FROM table_stat WHERE database_id = $1
+	`, databaseUID).Scan(&latestTs); err != nil {
+		return err
+	}
+	if latestTs.Valid && now.Sub(time.Unix(latestTs.Int64, 0)) < tableStatInterval {
+		return nil
+	}
+
+	for _, schema := range metadata.Schemas {
+		for _, table := range schema.Tables {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO table_stat (
+					created_ts,
+					database_id,
+					schema_name,
+					table_name,
+					row_count,
+					data_size,
+					index_size,
+					data_free
+				)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			`,
+				now.Unix(),
+				databaseUID,
+				schema.Name,
+				table.Name,
+				table.RowCount,
+				table.DataSize,
+				table.IndexSize,
+				table.DataFree,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM table_stat WHERE database_id = $1 AND created_ts < $2
+	`, databaseUID, now.Add(-tableStatRetention).Unix()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrapf(err, "failed to commit transaction")
+	}
+	return nil
+}
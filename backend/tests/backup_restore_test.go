@@ -107,6 +107,58 @@ func TestRetentionPolicy(t *testing.T) {
 	a.Equal(true, os.IsNotExist(err))
 }
 
+func TestBackupVerification(t *testing.T) {
+	t.Parallel()
+	a := require.New(t)
+	ctx := context.Background()
+	ctl := &controller{}
+	_, _, database, backup, _, cleanFn := setUpForBackupTest(ctx, t, ctl, &config{
+		vcsProviderCreator: fake.NewGitLab,
+		backupVerification: true,
+	})
+	defer cleanFn()
+
+	metaDB, err := sql.Open("pgx", ctl.profile.PgURL)
+	a.NoError(err)
+	defer metaDB.Close()
+	// Only the latest backups of the databases with automatic backup enabled are verified.
+	// TODO(d): clean-up the hack.
+	_, err = metaDB.ExecContext(ctx, fmt.Sprintf("UPDATE backup_setting SET enabled=true WHERE database_id=%d;", database.ID))
+	a.NoError(err)
+
+	// The backup runner restores the backup into a scratch database and records the result on the backup.
+	verified, err := ctl.waitBackupVerified(database.ID, backup.ID)
+	a.NoError(err)
+	a.Equal(api.BackupVerificationPassed, verified.VerificationStatus, verified.VerificationDetail)
+	a.NotZero(verified.VerifiedTs)
+
+	// A truncated backup fails the verification.
+	// Pause the verification until the backup is truncated.
+	_, err = metaDB.ExecContext(ctx, fmt.Sprintf("UPDATE backup_setting SET enabled=false WHERE database_id=%d;", database.ID))
+	a.NoError(err)
+	corrupted, err := ctl.createBackup(api.BackupCreate{
+		DatabaseID:     database.ID,
+		Name:           "corrupted-backup",
+		Type:           api.BackupTypeManual,
+		StorageBackend: api.BackupStorageBackendLocal,
+	})
+	a.NoError(err)
+	err = ctl.waitBackup(database.ID, corrupted.ID)
+	a.NoError(err)
+	backupFilePath := filepath.Join(ctl.profile.DataDir, "backup", "db", fmt.Sprintf("%d", database.ID), fmt.Sprintf("%s.sql", corrupted.Name))
+	stat, err := os.Stat(backupFilePath)
+	a.NoError(err)
+	err = os.Truncate(backupFilePath, stat.Size()/2)
+	a.NoError(err)
+	_, err = metaDB.ExecContext(ctx, fmt.Sprintf("UPDATE backup_setting SET enabled=true WHERE database_id=%d;", database.ID))
+	a.NoError(err)
+
+	verified, err = ctl.waitBackupVerified(database.ID, corrupted.ID)
+	a.NoError(err)
+	a.Equal(api.BackupVerificationFailed, verified.VerificationStatus)
+	a.NotEmpty(verified.VerificationDetail)
+}
+
 // TestPITRGeneral tests for the general PITR cases:
 // 1. buggy application.
 // 2. bad schema migration.
@@ -407,13 +459,17 @@ func createPITRIssue(ctl *controller, project *api.Project, pitrContext api.PITR
 }
 
 func setUpForPITRTest(ctx context.Context, t *testing.T, ctl *controller) (*api.Project, *sql.DB, *api.Database, *api.Backup, int, func()) {
-	a := require.New(t)
-
-	dataDir := t.TempDir()
-	err := ctl.StartServerWithExternalPg(ctx, &config{
-		dataDir:            dataDir,
+	return setUpForBackupTest(ctx, t, ctl, &config{
 		vcsProviderCreator: fake.NewGitLab,
 	})
+}
+
+// setUpForBackupTest starts the server with the config, and creates a MySQL database with a manual backup.
+func setUpForBackupTest(ctx context.Context, t *testing.T, ctl *controller, config *config) (*api.Project, *sql.DB, *api.Database, *api.Backup, int, func()) {
+	a := require.New(t)
+
+	config.dataDir = t.TempDir()
+	err := ctl.StartServerWithExternalPg(ctx, config)
 	a.NoError(err)
 	err = ctl.setLicense()
 	a.NoError(err)
@@ -366,6 +366,35 @@ func (ctl *controller) waitBackup(databaseID, backupID int) error {
 	return errors.Errorf("failed to wait for backup as this condition should never be reached")
 }
 
+// waitBackupVerified waits for a backup to be verified by the backup runner.
+func (ctl *controller) waitBackupVerified(databaseID, backupID int) (*api.Backup, error) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	log.Debug("Waiting for backup verification.", zap.Int("id", backupID))
+	for range ticker.C {
+		backups, err := ctl.listBackups(databaseID)
+		if err != nil {
+			return nil, err
+		}
+		var backup *api.Backup
+		for _, b := range backups {
+			if b.ID == backupID {
+				backup = b
+				break
+			}
+		}
+		if backup == nil {
+			return nil, errors.Errorf("backup %d for database %d not found", backupID, databaseID)
+		}
+		if backup.VerificationStatus != api.BackupVerificationUnverified {
+			return backup, nil
+		}
+	}
+	// Ideally, this should never happen because the ticker will not stop till the backup is verified.
+	return nil, errors.Errorf("failed to wait for backup verification as this condition should never be reached")
+}
+
 // waitBackupArchived waits for a backup to be archived.
 func (ctl *controller) waitBackupArchived(databaseID, backupID int) error {
 	ticker := time.NewTicker(100 * time.Millisecond)
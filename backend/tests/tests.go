@@ -184,6 +184,7 @@ type config struct {
 	feishuProverdierCreator fake.FeishuProviderCreator
 	readOnly                bool
 	skipOnboardingData      bool
+	backupVerification      bool
 }
 
 var (
@@ -249,6 +250,7 @@ func (ctl *controller) StartServerWithExternalPg(ctx context.Context, config *co
 	pgURL := fmt.Sprintf("postgresql://%s@:%d/%s?host=%s", externalPgUser, externalPgPort, databaseName, common.GetPostgresSocketDir())
 	serverPort := getTestPort()
 	profile := getTestProfileWithExternalPg(config.dataDir, resourceDir, serverPort, externalPgUser, pgURL, ctl.feishuProvider.APIURL(ctl.feishuURL), config.skipOnboardingData)
+	profile.BackupVerification = config.backupVerification
 	server, err := server.NewServer(ctx, profile)
 	if err != nil {
 		return err
@@ -1,7 +1,12 @@
 import { type Ref, computed } from "vue";
 import type { SelectOption } from "naive-ui";
 
-import { ConditionExpr, Factor, SQLTypeList } from "@/plugins/cel";
+import {
+  ConditionExpr,
+  Factor,
+  LockLevelList,
+  SQLTypeList,
+} from "@/plugins/cel";
 import { useExprEditorContext } from "../context";
 import { useCurrentUser, useEnvironmentStore, useProjectStore } from "@/store";
 import { engineName, PresetRiskLevelList, SupportedSourceList } from "@/types";
@@ -69,6 +74,13 @@ export const useSelectOptions = (expr: Ref<ConditionExpr>) => {
     return [];
   };
 
+  const getLockLevelOptions = () => {
+    return LockLevelList.map<SelectOption>((v) => ({
+      label: v,
+      value: v,
+    }));
+  };
+
   const options = computed(() => {
     const factor = expr.value.args[0];
     if (factor === "environment_id") {
@@ -89,6 +101,9 @@ export const useSelectOptions = (expr: Ref<ConditionExpr>) => {
     if (factor === "sql_type") {
       return getSQLTypeOptions();
     }
+    if (factor === "lock_level") {
+      return getLockLevelOptions();
+    }
     return [];
   });
 
@@ -100,6 +115,7 @@ export const factorSupportDropdown = (factor: Factor): boolean => {
     "environment_id",
     "db_engine",
     "sql_type",
+    "lock_level",
     "level",
     "source",
   ];
@@ -1,5 +1,11 @@
 import PITRRestoreButton from "./PITRRestoreButton.vue";
 import SQLEditorButton from "./SQLEditorButton.vue";
+import RequestQueryAccessButton from "./RequestQueryAccessButton.vue";
 import { DatabaseSettingsPanel } from "./Settings";
 
-export { PITRRestoreButton, SQLEditorButton, DatabaseSettingsPanel };
+export {
+  PITRRestoreButton,
+  SQLEditorButton,
+  RequestQueryAccessButton,
+  DatabaseSettingsPanel,
+};
@@ -1,6 +1,12 @@
 import { uniq, without } from "lodash-es";
 
-export const NumberFactorList = ["affected_rows", "level", "source"] as const;
+export const NumberFactorList = [
+  "affected_rows",
+  "table_rows",
+  "lock_seconds",
+  "level",
+  "source",
+] as const;
 export type NumberFactor = typeof NumberFactorList[number];
 
 export const StringFactorList = [
@@ -9,6 +15,7 @@ export const StringFactorList = [
   "database_name",
   "db_engine",
   "sql_type",
+  "lock_level",
 ] as const;
 export type StringFactor = typeof StringFactorList[number];
 
@@ -18,11 +25,17 @@ export type HighLevelFactor = typeof HighLevelFactorList[number];
 export type Factor = NumberFactor | StringFactor | HighLevelFactor;
 
 export const FactorList = {
-  DDL: uniq([...HighLevelFactorList, ...StringFactorList]),
+  DDL: uniq([
+    ...HighLevelFactorList,
+    "table_rows",
+    "lock_seconds",
+    ...StringFactorList,
+  ]),
   DML: uniq([...HighLevelFactorList, ...NumberFactorList, ...StringFactorList]),
   CreateDatabase: without(
     [...HighLevelFactorList, ...StringFactorList],
-    "sql_type"
+    "sql_type",
+    "lock_level"
   ),
 };
 
@@ -49,6 +49,8 @@ export const isStringOperator = (op: Operator): op is StringOperator => {
 /// Define supported operators for each factor
 export const OperatorList: Record<Factor, Operator[]> = {
   affected_rows: uniq([...EqualityOperatorList, ...CompareOperatorList]),
+  table_rows: uniq([...EqualityOperatorList, ...CompareOperatorList]),
+  lock_seconds: uniq([...EqualityOperatorList, ...CompareOperatorList]),
 
   level: uniq([...EqualityOperatorList, ...CollectionOperatorList]),
   source: uniq([...EqualityOperatorList, ...CollectionOperatorList]),
@@ -74,6 +76,7 @@ export const OperatorList: Record<Factor, Operator[]> = {
     ...CollectionOperatorList,
     ...StringOperatorList,
   ]),
+  lock_level: uniq([...EqualityOperatorList, ...CollectionOperatorList]),
 };
 
 export const getOperatorListByFactor = (factor: Factor) => {
@@ -73,3 +73,19 @@ export const SQLTypeList = {
 
 export type SQLTypeDDL = typeof SQLTypeList.DDL[number];
 export type SQLTypeDML = typeof SQLTypeList.DML[number];
+
+// The table lock modes reported by the lock impact check.
+// PostgreSQL: https://www.postgresql.org/docs/current/explicit-locking.html#LOCKING-TABLES
+// MySQL: https://dev.mysql.com/doc/refman/8.0/en/alter-table.html#alter-table-concurrency
+export const LockLevelList = [
+  "ACCESS_SHARE",
+  "ROW_SHARE",
+  "ROW_EXCLUSIVE",
+  "SHARE_UPDATE_EXCLUSIVE",
+  "SHARE",
+  "SHARE_ROW_EXCLUSIVE",
+  "EXCLUSIVE",
+  "ACCESS_EXCLUSIVE",
+  "NONE",
+  "SHARED",
+] as const;
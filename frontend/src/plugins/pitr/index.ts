@@ -17,12 +17,15 @@ import { useI18n } from "vue-i18n";
 import { semverCompare } from "@/utils";
 
 export const MIN_PITR_SUPPORT_MYSQL_VERSION = "8.0.0";
+export const MIN_PITR_SUPPORT_POSTGRES_VERSION = "12.0.0";
 
 export const isPITRAvailableOnInstance = (instance: Instance): boolean => {
   const { engine, engineVersion } = instance;
   return (
-    engine === "MYSQL" &&
-    semverCompare(engineVersion, MIN_PITR_SUPPORT_MYSQL_VERSION)
+    (engine === "MYSQL" &&
+      semverCompare(engineVersion, MIN_PITR_SUPPORT_MYSQL_VERSION)) ||
+    (engine === "POSTGRES" &&
+      semverCompare(engineVersion, MIN_PITR_SUPPORT_POSTGRES_VERSION))
   );
 };
 
@@ -39,11 +42,7 @@ export const usePITRLogic = (database: Ref<Database>) => {
   );
 
   const pitrAvailable = computed((): { result: boolean; message: string } => {
-    const { engine, engineVersion } = database.value.instance;
-    if (
-      engine === "MYSQL" &&
-      semverCompare(engineVersion, MIN_PITR_SUPPORT_MYSQL_VERSION)
-    ) {
+    if (isPITRAvailableOnInstance(database.value.instance)) {
       if (doneBackupList.value.length > 0) {
         return { result: true, message: "ok" };
       }
@@ -54,10 +53,16 @@ export const usePITRLogic = (database: Ref<Database>) => {
     }
     return {
       result: false,
-      message: t("database.pitr.minimum-supported-engine-and-version", {
-        engine: "MySQL",
-        min_version: MIN_PITR_SUPPORT_MYSQL_VERSION,
-      }),
+      message: [
+        t("database.pitr.minimum-supported-engine-and-version", {
+          engine: "MySQL",
+          min_version: MIN_PITR_SUPPORT_MYSQL_VERSION,
+        }),
+        t("database.pitr.minimum-supported-engine-and-version", {
+          engine: "PostgreSQL",
+          min_version: MIN_PITR_SUPPORT_POSTGRES_VERSION,
+        }),
+      ].join(" / "),
     };
   });
 
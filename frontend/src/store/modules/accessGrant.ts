@@ -0,0 +1,57 @@
+import { defineStore } from "pinia";
+import QueryString from "qs";
+import axios from "axios";
+import {
+  AccessGrant,
+  AccessGrantFind,
+  ResourceObject,
+  ResourceObjects,
+  ResponseWithData,
+  UNKNOWN_ID,
+} from "@/types";
+
+function convert(grant: ResourceObject): AccessGrant {
+  const attrs = grant.attributes as Omit<AccessGrant, "id">;
+  return {
+    ...attrs,
+    tableList: attrs.tableList ?? [],
+    id: parseInt(grant.id),
+  };
+}
+
+export const useAccessGrantStore = defineStore("accessGrant", {
+  actions: {
+    async fetchAccessGrantList(find: AccessGrantFind) {
+      const query: Record<string, any> = {};
+      if (find.principalId && find.principalId !== UNKNOWN_ID) {
+        query.principal = find.principalId;
+      }
+      if (find.databaseId && find.databaseId !== UNKNOWN_ID) {
+        query.database = find.databaseId;
+      }
+      if (find.issueId && find.issueId !== UNKNOWN_ID) {
+        query.issue = find.issueId;
+      }
+      if (find.status) {
+        query.status = find.status;
+      }
+      const url = `/api/access-grant?${QueryString.stringify(query)}`;
+      const responseData = (await axios.get(url))
+        .data as ResponseWithData<ResourceObjects>;
+      return responseData.data.map(convert);
+    },
+    async revokeAccessGrant(id: number) {
+      const responseData = (
+        await axios.patch(`/api/access-grant/${id}`, {
+          data: {
+            type: "accessGrantPatch",
+            attributes: {
+              status: "REVOKED",
+            },
+          },
+        })
+      ).data as ResponseWithData<ResourceObject>;
+      return convert(responseData.data);
+    },
+  },
+});
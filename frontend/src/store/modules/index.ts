@@ -51,6 +51,9 @@ export * from "./risk";
 export * from "./workspaceApprovalSetting";
 export * from "./review";
 export * from "./slowQuery";
+export * from "./sensitiveColumnSuggestion";
+export * from "./accessGrant";
+export * from "./scim";
 export * from "./role";
 export * from "./v1/projectIamPolicy";
 export * from "./v1/databaseSecret";
@@ -0,0 +1,40 @@
+import { defineStore } from "pinia";
+import axios from "axios";
+import { SCIMGroupMapping, SCIMSetting } from "@/types";
+
+interface SCIMState {
+  setting: SCIMSetting;
+}
+
+export const useSCIMStore = defineStore("scim", {
+  state: (): SCIMState => ({
+    setting: {
+      tokenConfigured: false,
+      groupMappingList: [],
+    },
+  }),
+  actions: {
+    async fetchSetting() {
+      const setting = (await axios.get("/api/scim/setting"))
+        .data as SCIMSetting;
+      this.setting = setting;
+      return setting;
+    },
+    async updateGroupMappingList(groupMappingList: SCIMGroupMapping[]) {
+      const setting = (
+        await axios.patch("/api/scim/setting", { groupMappingList })
+      ).data as SCIMSetting;
+      this.setting = setting;
+      return setting;
+    },
+    // generateToken revokes the previous token and returns the new one, which
+    // cannot be retrieved again.
+    async generateToken() {
+      const { token } = (await axios.post("/api/scim/token")).data as {
+        token: string;
+      };
+      this.setting.tokenConfigured = true;
+      return token;
+    },
+  },
+});
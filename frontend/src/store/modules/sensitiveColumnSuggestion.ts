@@ -0,0 +1,69 @@
+import { defineStore } from "pinia";
+import QueryString from "qs";
+import axios from "axios";
+import {
+  DatabaseId,
+  ResourceObject,
+  ResourceObjects,
+  ResponseWithData,
+  SensitiveColumnSuggestion,
+  SensitiveColumnSuggestionFind,
+  UNKNOWN_ID,
+} from "@/types";
+import { usePolicyStore } from "./policy";
+
+function convert(suggestion: ResourceObject): SensitiveColumnSuggestion {
+  return {
+    ...(suggestion.attributes as Omit<SensitiveColumnSuggestion, "id">),
+    id: parseInt(suggestion.id),
+  };
+}
+
+export const useSensitiveColumnSuggestionStore = defineStore(
+  "sensitiveColumnSuggestion",
+  {
+    actions: {
+      async fetchSuggestionList(find: SensitiveColumnSuggestionFind) {
+        const query: Record<string, any> = {};
+        if (find.databaseId && find.databaseId !== UNKNOWN_ID) {
+          query.database = find.databaseId;
+        }
+        if (find.status) {
+          query.status = find.status;
+        }
+        const url = `/api/sensitive-column-suggestion?${QueryString.stringify(
+          query
+        )}`;
+        const responseData = (await axios.get(url))
+          .data as ResponseWithData<ResourceObjects>;
+        return responseData.data.map(convert);
+      },
+      async acceptSuggestionList(idList: number[]) {
+        const responseData = (
+          await axios.post("/api/sensitive-column-suggestion/accept", {
+            idList,
+          })
+        ).data as ResponseWithData<ResourceObjects>;
+        // The accepted columns are added to the sensitive data policies.
+        await usePolicyStore().fetchPolicyListByResourceTypeAndPolicyType(
+          "database",
+          "bb.policy.sensitive-data"
+        );
+        return responseData.data.map(convert);
+      },
+      async dismissSuggestionList(idList: number[]) {
+        const responseData = (
+          await axios.post("/api/sensitive-column-suggestion/dismiss", {
+            idList,
+          })
+        ).data as ResponseWithData<ResourceObjects>;
+        return responseData.data.map(convert);
+      },
+      async scanDatabase(databaseId: DatabaseId) {
+        await axios.post(
+          `/api/sensitive-column-suggestion/scan?database=${databaseId}`
+        );
+      },
+    },
+  }
+);
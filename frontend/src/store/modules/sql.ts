@@ -11,6 +11,10 @@ import {
   Advice,
   SingleSQLResult,
   Attributes,
+  ExportInfo,
+  SQLRunningQuery,
+  ExplainInfo,
+  SQLExplainResult,
 } from "@/types";
 import { useDatabaseStore } from "./database";
 import { useInstanceStore } from "./instance";
@@ -142,6 +146,51 @@ export const useSQLStore = defineStore("sql", {
 
       return resultSet;
     },
+    async exportQuery(exportInfo: ExportInfo): Promise<Blob> {
+      const res = await axios.post(
+        `/api/sql/export`,
+        {
+          data: {
+            type: "sqlExport",
+            attributes: exportInfo,
+          },
+        },
+        {
+          responseType: "blob",
+        }
+      );
+      return res.data;
+    },
+    async explainQuery(explainInfo: ExplainInfo): Promise<SQLExplainResult> {
+      const res = (
+        await axios.post(
+          `/api/sql/explain`,
+          {
+            data: {
+              type: "sqlExplain",
+              attributes: explainInfo,
+            },
+          },
+          {
+            timeout: INSTANCE_OPERATION_TIMEOUT,
+          }
+        )
+      ).data;
+      return res.data.attributes as SQLExplainResult;
+    },
+    async fetchRunningQueryList(): Promise<SQLRunningQuery[]> {
+      const data = (await axios.get(`/api/sql/query`)).data
+        .data as ResourceObject[];
+      return data.map((item) => {
+        return {
+          ...(item.attributes as Omit<SQLRunningQuery, "id">),
+          id: item.id,
+        };
+      });
+    },
+    async cancelRunningQuery(queryId: string) {
+      await axios.delete(`/api/sql/query/${queryId}`);
+    },
     async adminQuery(queryInfo: QueryInfo): Promise<SQLResultSet> {
       const res = (
         await axios.post(
@@ -11,6 +11,7 @@ import {
   ResourceObject,
   Stage,
   StageAllTaskStatusPatch,
+  StageGatePatch,
   StageId,
   Task,
   TaskCheckRun,
@@ -272,6 +273,25 @@ export const useTaskStore = defineStore("task", {
 
       useIssueStore().fetchIssueById(issue.id);
     },
+    async patchStageGate({
+      issue,
+      stage,
+      patch,
+    }: {
+      issue: Issue;
+      stage: Stage;
+      patch: StageGatePatch;
+    }) {
+      const { pipeline } = stage;
+      await axios.patch(`/api/pipeline/${pipeline.id}/stage/${stage.id}/gate`, {
+        data: {
+          type: "stageGatePatch",
+          attributes: patch,
+        },
+      });
+
+      useIssueStore().fetchIssueById(issue.id);
+    },
     async patchTask({
       issueId,
       pipelineId,
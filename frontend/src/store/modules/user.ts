@@ -1,6 +1,7 @@
 import { defineStore } from "pinia";
 import { authServiceClient } from "@/grpcweb";
 import {
+  AccessToken,
   UpdateUserRequest,
   User,
   userRoleToJSON,
@@ -83,6 +84,25 @@ export const useUserStore = defineStore("user", {
         (user) => user.email === email
       );
     },
+    async fetchAccessTokenList(userName: string) {
+      const { accessTokens } = await authServiceClient.listAccessTokens({
+        parent: userName,
+      });
+      return accessTokens;
+    },
+    // createAccessToken returns the created token with the secret, which
+    // cannot be retrieved again.
+    async createAccessToken(userName: string, accessToken: AccessToken) {
+      return await authServiceClient.createAccessToken({
+        parent: userName,
+        accessToken,
+      });
+    },
+    async deleteAccessToken(name: string) {
+      await authServiceClient.deleteAccessToken({
+        name,
+      });
+    },
   },
 });
 
@@ -0,0 +1,34 @@
+import { DatabaseId, IssueId, PrincipalId } from "./id";
+
+export type AccessGrantStatus = "PENDING" | "ACTIVE" | "EXPIRED" | "REVOKED";
+
+// The longest query access can be requested for, 7 days.
+export const ACCESS_GRANT_MAX_DURATION_SECONDS = 7 * 24 * 60 * 60;
+
+export type AccessGrant = {
+  id: number;
+
+  // Standard fields
+  createdTs: number;
+  updatedTs: number;
+
+  // Related fields
+  issueId: IssueId;
+  principalId: PrincipalId;
+  databaseId: DatabaseId;
+
+  // Domain specific fields
+  // Allows all tables in the database if empty.
+  tableList: string[];
+  durationSeconds: number;
+  // expireTs is set once the grant becomes ACTIVE.
+  expireTs: number;
+  status: AccessGrantStatus;
+};
+
+export type AccessGrantFind = {
+  principalId?: PrincipalId;
+  databaseId?: DatabaseId;
+  issueId?: IssueId;
+  status?: AccessGrantStatus;
+};
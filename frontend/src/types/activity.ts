@@ -46,7 +46,9 @@ export type ProjectActivityType =
 
 export type DatabaseActivityType = "bb.database.recovery.pitr.done";
 
-export type SQLEditorActivityType = "bb.sql-editor.query";
+export type SQLEditorActivityType =
+  | "bb.sql-editor.query"
+  | "bb.sql-editor.export";
 
 export type ActivityType =
   | IssueActivityType
@@ -15,8 +15,10 @@ export type AnomalyType =
   | "bb.anomaly.instance.migration-schema"
   | "bb.anomaly.database.backup.policy-violation"
   | "bb.anomaly.database.backup.missing"
+  | "bb.anomaly.database.backup.unrestorable"
   | "bb.anomaly.database.connection"
-  | "bb.anomaly.database.schema.drift";
+  | "bb.anomaly.database.schema.drift"
+  | "bb.anomaly.database.table.growth";
 
 export type AnomalyInstanceConnectionPayload = {
   detail: string;
@@ -33,6 +35,13 @@ export type AnomalyDatabaseBackupMissingPayload = {
   lastBackupTs: number;
 };
 
+export type AnomalyDatabaseBackupUnrestorablePayload = {
+  backupId: number;
+  backupName: string;
+  verifiedTs: number;
+  detail: string;
+};
+
 export type AnomalyDatabaseConnectionPayload = {
   detail: string;
 };
@@ -43,11 +52,27 @@ export type AnomalyDatabaseSchemaDriftPayload = {
   actual: string;
 };
 
+export type AnomalyTableGrowth = {
+  schema: string;
+  table: string;
+  // The sum of the data and index sizes in bytes.
+  size: number;
+  dailyGrowthPercent: number;
+};
+
+export type AnomalyDatabaseTableGrowthPayload = {
+  maxDailyGrowthPercent: number;
+  maxTableSize: number;
+  tableList: AnomalyTableGrowth[];
+};
+
 export type AnomalyPayload =
   | AnomalyDatabaseBackupPolicyViolationPayload
   | AnomalyDatabaseBackupMissingPayload
+  | AnomalyDatabaseBackupUnrestorablePayload
   | AnomalyDatabaseConnectionPayload
-  | AnomalyDatabaseSchemaDriftPayload;
+  | AnomalyDatabaseSchemaDriftPayload
+  | AnomalyDatabaseTableGrowthPayload;
 
 export type AnomalySeverity = "MEDIUM" | "HIGH" | "CRITICAL";
 
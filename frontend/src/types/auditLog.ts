@@ -15,6 +15,7 @@ export enum AuditActivityType {
 
   // SQL Editor related.
   SQLEditorQuery = "bb.sql-editor.query",
+  SQLEditorExport = "bb.sql-editor.export",
 }
 
 export enum AuditActivityLevel {
@@ -38,6 +39,7 @@ export const AuditActivityTypeI18nNameMap: Record<AuditActivityType, string> = {
   [AuditActivityType.ProjectMemberRoleUpdate]:
     "audit-log.type.project.member-role-update",
   [AuditActivityType.SQLEditorQuery]: "audit-log.type.sql-editor-query",
+  [AuditActivityType.SQLEditorExport]: "audit-log.type.sql-editor-export",
 };
 
 export type AuditLog = {
@@ -4,6 +4,8 @@ export type BackupStatus = "PENDING_CREATE" | "DONE" | "FAILED";
 
 export type BackupType = "MANUAL" | "AUTOMATIC" | "PITR";
 
+export type BackupVerificationStatus = "UNVERIFIED" | "PASSED" | "FAILED";
+
 export type BackupStorageBackend = "LOCAL" | "S3" | "GCS";
 
 // Backup
@@ -23,6 +25,10 @@ export type Backup = {
   migrationHistoryVersion: string;
   path: string;
   comment: string;
+  // The verification fields are filled when the backup is restored into a scratch database.
+  verificationStatus: BackupVerificationStatus;
+  verificationDetail: string;
+  verifiedTs: number;
 };
 
 export type BackupCreate = {
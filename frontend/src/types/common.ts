@@ -206,6 +206,11 @@ const makeUnknown = (type: ResourceType) => {
     name: "",
     url: "",
     activityList: [],
+    hasSecret: false,
+    enabled: true,
+    failureCount: 0,
+    filter: "{}",
+    payloadTemplate: "",
   };
 
   const UNKNOWN_PROJECT_MEMBER: ProjectMember = {
@@ -565,6 +570,11 @@ const makeEmpty = (type: ResourceType) => {
     name: "",
     url: "",
     activityList: [],
+    hasSecret: false,
+    enabled: true,
+    failureCount: 0,
+    filter: "{}",
+    payloadTemplate: "",
   };
 
   const EMPTY_PROJECT_MEMBER: ProjectMember = {
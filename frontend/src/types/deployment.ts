@@ -23,6 +23,22 @@ export type Deployment = {
 
 export type DeploymentSpec = {
   selector: LabelSelector;
+  gate?: DeploymentGate;
+};
+
+// DeploymentGate holds the tasks of the later stages until the stage succeeds.
+export type DeploymentGate = {
+  bakeTimeSeconds: number;
+  // The gate skips the failed tasks if the failed databases don't exceed the percent.
+  maxFailurePercent?: number;
+  healthCheck?: DeploymentHealthCheck;
+};
+
+// Exactly one of statement and url is set.
+export type DeploymentHealthCheck = {
+  statement: string;
+  url: string;
+  maxValue: number;
 };
 
 export type LabelSelector = {
@@ -53,3 +53,6 @@ export * from "./risk";
 export * from "./workspaceApprovalSetting";
 export * from "./review";
 export * from "./slowQuery";
+export * from "./sensitiveColumnSuggestion";
+export * from "./accessGrant";
+export * from "./scim";
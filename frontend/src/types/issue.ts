@@ -22,7 +22,8 @@ type IssueTypeDatabase =
   | "bb.issue.database.data.update"
   | "bb.issue.database.rollback"
   | "bb.issue.database.schema.update.ghost"
-  | "bb.issue.database.restore.pitr";
+  | "bb.issue.database.restore.pitr"
+  | "bb.issue.database.query-access";
 
 type IssueTypeDataSource = "bb.issue.data-source.request";
 
@@ -81,6 +82,13 @@ export type PITRContext = {
   createDatabaseContext?: CreateDatabaseContext;
 };
 
+export type QueryAccessContext = {
+  databaseId: DatabaseId;
+  // Allows all tables in the database if empty.
+  tableList: string[];
+  durationSeconds: number;
+};
+
 // eslint-disable-next-line @typescript-eslint/ban-types
 export type EmptyContext = {};
 
@@ -88,6 +96,7 @@ export type IssueCreateContext =
   | CreateDatabaseContext
   | MigrationContext
   | PITRContext
+  | QueryAccessContext
   | EmptyContext;
 
 export type IssuePayload = { [key: string]: any };
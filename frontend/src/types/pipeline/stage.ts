@@ -1,5 +1,6 @@
 // The database belongs to an instance which in turns belongs to an environment.
 
+import { DeploymentGate } from "../deployment";
 import { Environment } from "../environment";
 import { EnvironmentId, StageId } from "../id";
 import { Pipeline } from "./pipeline";
@@ -16,6 +17,21 @@ export type Stage = {
 
   // Domain specific fields
   name: string;
+  gate?: StageGate;
+};
+
+export type StageGateStatus = "PENDING" | "PASSED" | "FAILED";
+
+export type StageGate = {
+  status: StageGateStatus;
+  config?: DeploymentGate;
+  detail: string;
+  updatedTs: number;
+};
+
+export type StageGatePatch = {
+  status: StageGateStatus;
+  comment?: string;
 };
 
 export type StageCreate = {
@@ -36,4 +52,4 @@ export type StageAllTaskStatusPatch = {
   updatedTs: number;
 };
 
-export type StageStatusUpdateType = "BEGIN" | "END";
+export type StageStatusUpdateType = "BEGIN" | "END" | "PAUSE" | "RESUME";
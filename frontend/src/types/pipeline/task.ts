@@ -266,8 +266,10 @@ export type TaskCheckType =
   | "bb.task-check.database.ghost.sync"
   | "bb.task-check.issue.lgtm"
   | "bb.task-check.pitr.mysql"
+  | "bb.task-check.pitr.postgres"
   | "bb.task-check.database.statement.type.report"
-  | "bb.task-check.database.statement.affected-rows.report";
+  | "bb.task-check.database.statement.affected-rows.report"
+  | "bb.task-check.database.statement.lock-impact.report";
 
 export type TaskCheckStatus = "SUCCESS" | "WARN" | "ERROR";
 
@@ -3,6 +3,8 @@ import {
   Environment,
   IssueType,
   PolicyId,
+  PrincipalId,
+  RoleType,
   RuleType,
   RuleLevel,
   SubsetOf,
@@ -15,7 +17,11 @@ export type PolicyType =
   | "bb.policy.environment-tier"
   | "bb.policy.sensitive-data"
   | "bb.policy.access-control"
-  | "bb.policy.slow-query";
+  | "bb.policy.slow-query"
+  | "bb.policy.sql-export"
+  | "bb.policy.sql-query"
+  | "bb.policy.row-filter"
+  | "bb.policy.table-growth";
 
 export type PipelineApprovalPolicyValue =
   | "MANUAL_APPROVAL_NEVER"
@@ -72,13 +78,34 @@ export type AssigneeGroup = {
   value: AssigneeGroupValue;
 };
 
-export type SensitiveDataMaskType = "DEFAULT";
+export type SensitiveDataMaskType =
+  | "DEFAULT"
+  | "PARTIAL"
+  | "HASH"
+  | "RANGE"
+  | "EMAIL"
+  | "FORMAT_PRESERVING";
+
+export const SensitiveDataMaskTypeList: SensitiveDataMaskType[] = [
+  "DEFAULT",
+  "PARTIAL",
+  "HASH",
+  "RANGE",
+  "EMAIL",
+  "FORMAT_PRESERVING",
+];
 
 export type SensitiveData = {
   schema: string;
   table: string;
   column: string;
   maskType: SensitiveDataMaskType;
+  // The parameters of the mask type, the backend uses the defaults if omitted.
+  // keepLast is for PARTIAL, defaults to 4.
+  keepLast?: number;
+  // rangeWidth and dateUnit are for RANGE, default to 10 and MONTH.
+  rangeWidth?: number;
+  dateUnit?: "YEAR" | "MONTH" | "DAY";
 };
 
 export type SensitiveDataPolicyPayload = {
@@ -97,6 +124,40 @@ export type SlowQueryPolicyPayload = {
   active: boolean;
 };
 
+export type SQLExportPolicyPayload = {
+  // Workspace owners and DBAs can always export query results.
+  disallowExport: boolean;
+  // No limit if it's 0.
+  maxRowCount: number;
+};
+
+export type SQLQueryPolicyPayload = {
+  // No limit if it's 0.
+  maxExecutionSeconds: number;
+};
+
+export type TableGrowthPolicyPayload = {
+  // No limit if it's 0.
+  maxDailyGrowthPercent: number;
+  // In bytes, no limit if it's 0.
+  maxTableSize: number;
+};
+
+export type RowFilter = {
+  // Only used by PostgreSQL, defaults to "public".
+  schema: string;
+  table: string;
+  // The SQL predicate of the visible rows, such as "region = 'EU'".
+  expression: string;
+  // A filter applies to either the workspace role or the principal.
+  role?: RoleType;
+  principalId?: PrincipalId;
+};
+
+export type RowFilterPolicyPayload = {
+  rowFilterList: RowFilter[];
+};
+
 export type PolicyPayload =
   | PipelineApprovalPolicyPayload
   | BackupPlanPolicyPayload
@@ -104,7 +165,11 @@ export type PolicyPayload =
   | EnvironmentTierPolicyPayload
   | SensitiveDataPolicyPayload
   | AccessControlPolicyPayload
-  | SlowQueryPolicyPayload;
+  | SlowQueryPolicyPayload
+  | SQLExportPolicyPayload
+  | SQLQueryPolicyPayload
+  | RowFilterPolicyPayload
+  | TableGrowthPolicyPayload;
 
 export type PolicyResourceType =
   | ""
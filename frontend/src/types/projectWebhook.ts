@@ -1,5 +1,7 @@
 import { ActivityType } from "./activity";
-import { MemberId, ProjectId } from "./id";
+import { MemberId, ProjectId, ProjectWebhookId } from "./id";
+import { IssueType } from "./issue";
+import { TaskStatus } from "./pipeline";
 import { t } from "../plugins/i18n";
 
 type ProjectWebhookTypeItem = {
@@ -100,6 +102,25 @@ export type ProjectWebhook = {
   name: string;
   url: string;
   activityList: ActivityType[];
+  // Whether the payload of the custom webhook is signed, the secret itself is never returned.
+  hasSecret: boolean;
+  // The webhook is disabled after too many consecutive failed deliveries.
+  enabled: boolean;
+  failureCount: number;
+  // ProjectWebhookFilter in JSON format.
+  filter: string;
+  // Go text/template rendering the payload, only for the custom webhook.
+  payloadTemplate: string;
+};
+
+export type ProjectWebhookFilter = {
+  environmentList?: string[];
+  // In the format of "key=value".
+  databaseLabelList?: string[];
+  issueTypeList?: IssueType[];
+  // Only applies to the task status update events.
+  taskStatusList?: TaskStatus[];
+  minRiskLevel?: number;
 };
 
 export type ProjectWebhookCreate = {
@@ -108,6 +129,9 @@ export type ProjectWebhookCreate = {
   name: string;
   url: string;
   activityList: ActivityType[];
+  secret?: string;
+  filter?: string;
+  payloadTemplate?: string;
 };
 
 export type ProjectWebhookPatch = {
@@ -116,8 +140,32 @@ export type ProjectWebhookPatch = {
   url?: string;
   // Comma separated list. Server doesn't support deserialize into pointer to string array (*[]string in Golang)
   activityList?: string;
+  secret?: string;
+  enabled?: boolean;
+  filter?: string;
+  payloadTemplate?: string;
 };
 
 export type ProjectWebhookTestResult = {
   error?: string;
 };
+
+export type ProjectWebhookDeliveryStatus = "PENDING" | "SUCCESS" | "FAILED";
+
+export type ProjectWebhookDelivery = {
+  id: number;
+
+  // Standard fields
+  createdTs: number;
+  updatedTs: number;
+
+  // Related fields
+  projectWebhookId: ProjectWebhookId;
+
+  // Domain specific fields
+  status: ProjectWebhookDeliveryStatus;
+  attempt: number;
+  nextAttemptTs: number;
+  lastError: string;
+  payload: string;
+};
@@ -7,6 +7,7 @@ export enum IdentityProviderType {
   IDENTITY_PROVIDER_TYPE_UNSPECIFIED = 0,
   OAUTH2 = 1,
   OIDC = 2,
+  LDAP = 3,
   UNRECOGNIZED = -1,
 }
 
@@ -21,6 +22,9 @@ export function identityProviderTypeFromJSON(object: any): IdentityProviderType
     case 2:
     case "OIDC":
       return IdentityProviderType.OIDC;
+    case 3:
+    case "LDAP":
+      return IdentityProviderType.LDAP;
     case -1:
     case "UNRECOGNIZED":
     default:
@@ -36,6 +40,8 @@ export function identityProviderTypeToJSON(object: IdentityProviderType): string
       return "OAUTH2";
     case IdentityProviderType.OIDC:
       return "OIDC";
+    case IdentityProviderType.LDAP:
+      return "LDAP";
     case IdentityProviderType.UNRECOGNIZED:
     default:
       return "UNRECOGNIZED";
@@ -45,6 +51,7 @@ export function identityProviderTypeToJSON(object: IdentityProviderType): string
 export interface IdentityProviderConfig {
   oauth2Config?: OAuth2IdentityProviderConfig | undefined;
   oidcConfig?: OIDCIdentityProviderConfig | undefined;
+  ldapConfig?: LDAPIdentityProviderConfig | undefined;
 }
 
 /** OAuth2IdentityProviderConfig is the structure for OAuth2 identity provider config. */
@@ -66,6 +73,49 @@ export interface OIDCIdentityProviderConfig {
   fieldMapping?: FieldMapping;
 }
 
+/** LDAPIdentityProviderConfig is the structure for LDAP identity provider config. */
+export interface LDAPIdentityProviderConfig {
+  /** Host is the hostname or IP address of the LDAP server, e.g. "ldap.example.com". */
+  host: string;
+  /** Port is the port number of the LDAP server, e.g. 389 for plain LDAP and StartTLS, and 636 for LDAPS. */
+  port: number;
+  /** SkipTlsVerify controls whether to skip the TLS certificate verification. */
+  skipTlsVerify: boolean;
+  /** BindDn is the DN of the service account used to search for users, e.g. "cn=admin,dc=example,dc=com". */
+  bindDn: string;
+  /** BindPassword is the password of the service account. */
+  bindPassword: string;
+  /** BaseDn is the base DN to search for users, e.g. "ou=users,dc=example,dc=com". */
+  baseDn: string;
+  /**
+   * UserFilter is the filter to search for the user logging in, "%s" is replaced by the escaped username,
+   * e.g. "(uid=%s)" for OpenLDAP and "(sAMAccountName=%s)" for Active Directory.
+   */
+  userFilter: string;
+  /**
+   * SecurityProtocol is the protocol to secure the connection, either "starttls" or "ldaps".
+   * The connection is not encrypted if it's empty.
+   */
+  securityProtocol: string;
+  /** FieldMapping maps the LDAP attributes of the user entry to the Bytebase user. */
+  fieldMapping?: FieldMapping;
+  /** GroupAttribute is the attribute of the user entry listing the groups of the user. Defaults to "memberOf". */
+  groupAttribute: string;
+  /**
+   * GroupRoleMappings grants the workspace roles to the members of the groups.
+   * The highest role wins if the user is in several groups, and users out of all the groups get the Developer role.
+   */
+  groupRoleMappings: LDAPGroupRoleMapping[];
+}
+
+/** LDAPGroupRoleMapping maps an LDAP group to a workspace role. */
+export interface LDAPGroupRoleMapping {
+  /** Group is the DN or the common name of the group, case insensitive. */
+  group: string;
+  /** Role is the workspace role, one of "OWNER", "DBA" and "DEVELOPER". */
+  role: string;
+}
+
 /**
  * FieldMapping saves the field names from user info API of identity provider.
  * As we save all raw json string of user info response data into `principal.idp_user_info`,
@@ -95,7 +145,7 @@ export interface IdentityProviderUserInfo {
 }
 
 function createBaseIdentityProviderConfig(): IdentityProviderConfig {
-  return { oauth2Config: undefined, oidcConfig: undefined };
+  return { oauth2Config: undefined, oidcConfig: undefined, ldapConfig: undefined };
 }
 
 export const IdentityProviderConfig = {
@@ -106,6 +156,9 @@ export const IdentityProviderConfig = {
     if (message.oidcConfig !== undefined) {
       OIDCIdentityProviderConfig.encode(message.oidcConfig, writer.uint32(18).fork()).ldelim();
     }
+    if (message.ldapConfig !== undefined) {
+      LDAPIdentityProviderConfig.encode(message.ldapConfig, writer.uint32(26).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -130,6 +183,13 @@ export const IdentityProviderConfig = {
 
           message.oidcConfig = OIDCIdentityProviderConfig.decode(reader, reader.uint32());
           continue;
+        case 3:
+          if (tag != 26) {
+            break;
+          }
+
+          message.ldapConfig = LDAPIdentityProviderConfig.decode(reader, reader.uint32());
+          continue;
       }
       if ((tag & 7) == 4 || tag == 0) {
         break;
@@ -143,6 +203,7 @@ export const IdentityProviderConfig = {
     return {
       oauth2Config: isSet(object.oauth2Config) ? OAuth2IdentityProviderConfig.fromJSON(object.oauth2Config) : undefined,
       oidcConfig: isSet(object.oidcConfig) ? OIDCIdentityProviderConfig.fromJSON(object.oidcConfig) : undefined,
+      ldapConfig: isSet(object.ldapConfig) ? LDAPIdentityProviderConfig.fromJSON(object.ldapConfig) : undefined,
     };
   },
 
@@ -152,6 +213,8 @@ export const IdentityProviderConfig = {
       (obj.oauth2Config = message.oauth2Config ? OAuth2IdentityProviderConfig.toJSON(message.oauth2Config) : undefined);
     message.oidcConfig !== undefined &&
       (obj.oidcConfig = message.oidcConfig ? OIDCIdentityProviderConfig.toJSON(message.oidcConfig) : undefined);
+    message.ldapConfig !== undefined &&
+      (obj.ldapConfig = message.ldapConfig ? LDAPIdentityProviderConfig.toJSON(message.ldapConfig) : undefined);
     return obj;
   },
 
@@ -167,6 +230,9 @@ export const IdentityProviderConfig = {
     message.oidcConfig = (object.oidcConfig !== undefined && object.oidcConfig !== null)
       ? OIDCIdentityProviderConfig.fromPartial(object.oidcConfig)
       : undefined;
+    message.ldapConfig = (object.ldapConfig !== undefined && object.ldapConfig !== null)
+      ? LDAPIdentityProviderConfig.fromPartial(object.ldapConfig)
+      : undefined;
     return message;
   },
 };
@@ -422,6 +488,286 @@ export const OIDCIdentityProviderConfig = {
   },
 };
 
+function createBaseLDAPIdentityProviderConfig(): LDAPIdentityProviderConfig {
+  return {
+    host: "",
+    port: 0,
+    skipTlsVerify: false,
+    bindDn: "",
+    bindPassword: "",
+    baseDn: "",
+    userFilter: "",
+    securityProtocol: "",
+    fieldMapping: undefined,
+    groupAttribute: "",
+    groupRoleMappings: [],
+  };
+}
+
+export const LDAPIdentityProviderConfig = {
+  encode(message: LDAPIdentityProviderConfig, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.host !== "") {
+      writer.uint32(10).string(message.host);
+    }
+    if (message.port !== 0) {
+      writer.uint32(16).int32(message.port);
+    }
+    if (message.skipTlsVerify === true) {
+      writer.uint32(24).bool(message.skipTlsVerify);
+    }
+    if (message.bindDn !== "") {
+      writer.uint32(34).string(message.bindDn);
+    }
+    if (message.bindPassword !== "") {
+      writer.uint32(42).string(message.bindPassword);
+    }
+    if (message.baseDn !== "") {
+      writer.uint32(50).string(message.baseDn);
+    }
+    if (message.userFilter !== "") {
+      writer.uint32(58).string(message.userFilter);
+    }
+    if (message.securityProtocol !== "") {
+      writer.uint32(66).string(message.securityProtocol);
+    }
+    if (message.fieldMapping !== undefined) {
+      FieldMapping.encode(message.fieldMapping, writer.uint32(74).fork()).ldelim();
+    }
+    if (message.groupAttribute !== "") {
+      writer.uint32(82).string(message.groupAttribute);
+    }
+    for (const v of message.groupRoleMappings) {
+      LDAPGroupRoleMapping.encode(v!, writer.uint32(90).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): LDAPIdentityProviderConfig {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseLDAPIdentityProviderConfig();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag != 10) {
+            break;
+          }
+
+          message.host = reader.string();
+          continue;
+        case 2:
+          if (tag != 16) {
+            break;
+          }
+
+          message.port = reader.int32();
+          continue;
+        case 3:
+          if (tag != 24) {
+            break;
+          }
+
+          message.skipTlsVerify = reader.bool();
+          continue;
+        case 4:
+          if (tag != 34) {
+            break;
+          }
+
+          message.bindDn = reader.string();
+          continue;
+        case 5:
+          if (tag != 42) {
+            break;
+          }
+
+          message.bindPassword = reader.string();
+          continue;
+        case 6:
+          if (tag != 50) {
+            break;
+          }
+
+          message.baseDn = reader.string();
+          continue;
+        case 7:
+          if (tag != 58) {
+            break;
+          }
+
+          message.userFilter = reader.string();
+          continue;
+        case 8:
+          if (tag != 66) {
+            break;
+          }
+
+          message.securityProtocol = reader.string();
+          continue;
+        case 9:
+          if (tag != 74) {
+            break;
+          }
+
+          message.fieldMapping = FieldMapping.decode(reader, reader.uint32());
+          continue;
+        case 10:
+          if (tag != 82) {
+            break;
+          }
+
+          message.groupAttribute = reader.string();
+          continue;
+        case 11:
+          if (tag != 90) {
+            break;
+          }
+
+          message.groupRoleMappings.push(LDAPGroupRoleMapping.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) == 4 || tag == 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): LDAPIdentityProviderConfig {
+    return {
+      host: isSet(object.host) ? String(object.host) : "",
+      port: isSet(object.port) ? Number(object.port) : 0,
+      skipTlsVerify: isSet(object.skipTlsVerify) ? Boolean(object.skipTlsVerify) : false,
+      bindDn: isSet(object.bindDn) ? String(object.bindDn) : "",
+      bindPassword: isSet(object.bindPassword) ? String(object.bindPassword) : "",
+      baseDn: isSet(object.baseDn) ? String(object.baseDn) : "",
+      userFilter: isSet(object.userFilter) ? String(object.userFilter) : "",
+      securityProtocol: isSet(object.securityProtocol) ? String(object.securityProtocol) : "",
+      fieldMapping: isSet(object.fieldMapping) ? FieldMapping.fromJSON(object.fieldMapping) : undefined,
+      groupAttribute: isSet(object.groupAttribute) ? String(object.groupAttribute) : "",
+      groupRoleMappings: Array.isArray(object?.groupRoleMappings)
+        ? object.groupRoleMappings.map((e: any) => LDAPGroupRoleMapping.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: LDAPIdentityProviderConfig): unknown {
+    const obj: any = {};
+    message.host !== undefined && (obj.host = message.host);
+    message.port !== undefined && (obj.port = Math.round(message.port));
+    message.skipTlsVerify !== undefined && (obj.skipTlsVerify = message.skipTlsVerify);
+    message.bindDn !== undefined && (obj.bindDn = message.bindDn);
+    message.bindPassword !== undefined && (obj.bindPassword = message.bindPassword);
+    message.baseDn !== undefined && (obj.baseDn = message.baseDn);
+    message.userFilter !== undefined && (obj.userFilter = message.userFilter);
+    message.securityProtocol !== undefined && (obj.securityProtocol = message.securityProtocol);
+    message.fieldMapping !== undefined &&
+      (obj.fieldMapping = message.fieldMapping ? FieldMapping.toJSON(message.fieldMapping) : undefined);
+    message.groupAttribute !== undefined && (obj.groupAttribute = message.groupAttribute);
+    if (message.groupRoleMappings) {
+      obj.groupRoleMappings = message.groupRoleMappings.map((e) => e ? LDAPGroupRoleMapping.toJSON(e) : undefined);
+    } else {
+      obj.groupRoleMappings = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<LDAPIdentityProviderConfig>): LDAPIdentityProviderConfig {
+    return LDAPIdentityProviderConfig.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<LDAPIdentityProviderConfig>): LDAPIdentityProviderConfig {
+    const message = createBaseLDAPIdentityProviderConfig();
+    message.host = object.host ?? "";
+    message.port = object.port ?? 0;
+    message.skipTlsVerify = object.skipTlsVerify ?? false;
+    message.bindDn = object.bindDn ?? "";
+    message.bindPassword = object.bindPassword ?? "";
+    message.baseDn = object.baseDn ?? "";
+    message.userFilter = object.userFilter ?? "";
+    message.securityProtocol = object.securityProtocol ?? "";
+    message.fieldMapping = (object.fieldMapping !== undefined && object.fieldMapping !== null)
+      ? FieldMapping.fromPartial(object.fieldMapping)
+      : undefined;
+    message.groupAttribute = object.groupAttribute ?? "";
+    message.groupRoleMappings = object.groupRoleMappings?.map((e) => LDAPGroupRoleMapping.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseLDAPGroupRoleMapping(): LDAPGroupRoleMapping {
+  return { group: "", role: "" };
+}
+
+export const LDAPGroupRoleMapping = {
+  encode(message: LDAPGroupRoleMapping, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.group !== "") {
+      writer.uint32(10).string(message.group);
+    }
+    if (message.role !== "") {
+      writer.uint32(18).string(message.role);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): LDAPGroupRoleMapping {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseLDAPGroupRoleMapping();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag != 10) {
+            break;
+          }
+
+          message.group = reader.string();
+          continue;
+        case 2:
+          if (tag != 18) {
+            break;
+          }
+
+          message.role = reader.string();
+          continue;
+      }
+      if ((tag & 7) == 4 || tag == 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): LDAPGroupRoleMapping {
+    return {
+      group: isSet(object.group) ? String(object.group) : "",
+      role: isSet(object.role) ? String(object.role) : "",
+    };
+  },
+
+  toJSON(message: LDAPGroupRoleMapping): unknown {
+    const obj: any = {};
+    message.group !== undefined && (obj.group = message.group);
+    message.role !== undefined && (obj.role = message.role);
+    return obj;
+  },
+
+  create(base?: DeepPartial<LDAPGroupRoleMapping>): LDAPGroupRoleMapping {
+    return LDAPGroupRoleMapping.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<LDAPGroupRoleMapping>): LDAPGroupRoleMapping {
+    const message = createBaseLDAPGroupRoleMapping();
+    message.group = object.group ?? "";
+    message.role = object.role ?? "";
+    return message;
+  },
+};
+
 function createBaseFieldMapping(): FieldMapping {
   return { identifier: "", displayName: "", email: "" };
 }
@@ -3,10 +3,66 @@ import type { CallContext, CallOptions } from "nice-grpc-common";
 import * as _m0 from "protobufjs/minimal";
 import { Empty } from "../google/protobuf/empty";
 import { FieldMask } from "../google/protobuf/field_mask";
+import { Timestamp } from "../google/protobuf/timestamp";
 import { State, stateFromJSON, stateToJSON } from "./common";
 
 export const protobufPackage = "bytebase.v1";
 
+export enum AccessTokenScope {
+  ACCESS_TOKEN_SCOPE_UNSPECIFIED = 0,
+  /** ACCESS_TOKEN_SCOPE_READ_ONLY - ACCESS_TOKEN_SCOPE_READ_ONLY allows the read-only operations. */
+  ACCESS_TOKEN_SCOPE_READ_ONLY = 1,
+  /** ACCESS_TOKEN_SCOPE_ISSUE_CREATE - ACCESS_TOKEN_SCOPE_ISSUE_CREATE allows creating issues and the sheets used by the issues. */
+  ACCESS_TOKEN_SCOPE_ISSUE_CREATE = 2,
+  /** ACCESS_TOKEN_SCOPE_SQL_QUERY - ACCESS_TOKEN_SCOPE_SQL_QUERY allows running queries in the SQL editor. */
+  ACCESS_TOKEN_SCOPE_SQL_QUERY = 3,
+  /** ACCESS_TOKEN_SCOPE_ADMIN - ACCESS_TOKEN_SCOPE_ADMIN allows all the operations the user is permitted to. */
+  ACCESS_TOKEN_SCOPE_ADMIN = 4,
+  UNRECOGNIZED = -1,
+}
+
+export function accessTokenScopeFromJSON(object: any): AccessTokenScope {
+  switch (object) {
+    case 0:
+    case "ACCESS_TOKEN_SCOPE_UNSPECIFIED":
+      return AccessTokenScope.ACCESS_TOKEN_SCOPE_UNSPECIFIED;
+    case 1:
+    case "ACCESS_TOKEN_SCOPE_READ_ONLY":
+      return AccessTokenScope.ACCESS_TOKEN_SCOPE_READ_ONLY;
+    case 2:
+    case "ACCESS_TOKEN_SCOPE_ISSUE_CREATE":
+      return AccessTokenScope.ACCESS_TOKEN_SCOPE_ISSUE_CREATE;
+    case 3:
+    case "ACCESS_TOKEN_SCOPE_SQL_QUERY":
+      return AccessTokenScope.ACCESS_TOKEN_SCOPE_SQL_QUERY;
+    case 4:
+    case "ACCESS_TOKEN_SCOPE_ADMIN":
+      return AccessTokenScope.ACCESS_TOKEN_SCOPE_ADMIN;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return AccessTokenScope.UNRECOGNIZED;
+  }
+}
+
+export function accessTokenScopeToJSON(object: AccessTokenScope): string {
+  switch (object) {
+    case AccessTokenScope.ACCESS_TOKEN_SCOPE_UNSPECIFIED:
+      return "ACCESS_TOKEN_SCOPE_UNSPECIFIED";
+    case AccessTokenScope.ACCESS_TOKEN_SCOPE_READ_ONLY:
+      return "ACCESS_TOKEN_SCOPE_READ_ONLY";
+    case AccessTokenScope.ACCESS_TOKEN_SCOPE_ISSUE_CREATE:
+      return "ACCESS_TOKEN_SCOPE_ISSUE_CREATE";
+    case AccessTokenScope.ACCESS_TOKEN_SCOPE_SQL_QUERY:
+      return "ACCESS_TOKEN_SCOPE_SQL_QUERY";
+    case AccessTokenScope.ACCESS_TOKEN_SCOPE_ADMIN:
+      return "ACCESS_TOKEN_SCOPE_ADMIN";
+    case AccessTokenScope.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
 export enum UserType {
   USER_TYPE_UNSPECIFIED = 0,
   USER = 1,
@@ -245,6 +301,60 @@ export interface User {
   recoveryCodes: string[];
 }
 
+export interface ListAccessTokensRequest {
+  /**
+   * The parent resource of the access tokens.
+   * Format: users/{user}
+   */
+  parent: string;
+}
+
+export interface ListAccessTokensResponse {
+  /** The access tokens of the user. */
+  accessTokens: AccessToken[];
+}
+
+export interface CreateAccessTokenRequest {
+  /**
+   * The parent resource of the access token.
+   * Format: users/{user}
+   */
+  parent: string;
+  /** The access token to create. */
+  accessToken?: AccessToken;
+}
+
+export interface DeleteAccessTokenRequest {
+  /**
+   * The name of the access token to delete.
+   * Format: users/{user}/accessTokens/{access_token}
+   */
+  name: string;
+}
+
+export interface AccessToken {
+  /**
+   * The name of the access token.
+   * Format: users/{user}/accessTokens/{access_token}. {access_token} is a system-generated unique ID.
+   */
+  name: string;
+  title: string;
+  /** The scopes of the token. Every scope allows the read-only operations. */
+  scopes: AccessTokenScope[];
+  /**
+   * The project the token is restricted to. The token is not restricted if it's empty.
+   * Format: projects/{project}
+   */
+  project: string;
+  /** The expiration time of the token. The token never expires if it's not set. */
+  expireTime?: Date;
+  createTime?: Date;
+  /** The last time the token is used to authenticate a request. */
+  lastUsedTime?: Date;
+  /** The token is only returned when the access token is created. */
+  token: string;
+}
+
 function createBaseGetUserRequest(): GetUserRequest {
   return { name: "" };
 }
@@ -1404,6 +1514,429 @@ export const User = {
   },
 };
 
+function createBaseListAccessTokensRequest(): ListAccessTokensRequest {
+  return { parent: "" };
+}
+
+export const ListAccessTokensRequest = {
+  encode(message: ListAccessTokensRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.parent !== "") {
+      writer.uint32(10).string(message.parent);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListAccessTokensRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListAccessTokensRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag != 10) {
+            break;
+          }
+
+          message.parent = reader.string();
+          continue;
+      }
+      if ((tag & 7) == 4 || tag == 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListAccessTokensRequest {
+    return { parent: isSet(object.parent) ? String(object.parent) : "" };
+  },
+
+  toJSON(message: ListAccessTokensRequest): unknown {
+    const obj: any = {};
+    message.parent !== undefined && (obj.parent = message.parent);
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListAccessTokensRequest>): ListAccessTokensRequest {
+    return ListAccessTokensRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<ListAccessTokensRequest>): ListAccessTokensRequest {
+    const message = createBaseListAccessTokensRequest();
+    message.parent = object.parent ?? "";
+    return message;
+  },
+};
+
+function createBaseListAccessTokensResponse(): ListAccessTokensResponse {
+  return { accessTokens: [] };
+}
+
+export const ListAccessTokensResponse = {
+  encode(message: ListAccessTokensResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.accessTokens) {
+      AccessToken.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListAccessTokensResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListAccessTokensResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag != 10) {
+            break;
+          }
+
+          message.accessTokens.push(AccessToken.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) == 4 || tag == 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListAccessTokensResponse {
+    return {
+      accessTokens: Array.isArray(object?.accessTokens)
+        ? object.accessTokens.map((e: any) => AccessToken.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: ListAccessTokensResponse): unknown {
+    const obj: any = {};
+    if (message.accessTokens) {
+      obj.accessTokens = message.accessTokens.map((e) => e ? AccessToken.toJSON(e) : undefined);
+    } else {
+      obj.accessTokens = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListAccessTokensResponse>): ListAccessTokensResponse {
+    return ListAccessTokensResponse.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<ListAccessTokensResponse>): ListAccessTokensResponse {
+    const message = createBaseListAccessTokensResponse();
+    message.accessTokens = object.accessTokens?.map((e) => AccessToken.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseCreateAccessTokenRequest(): CreateAccessTokenRequest {
+  return { parent: "", accessToken: undefined };
+}
+
+export const CreateAccessTokenRequest = {
+  encode(message: CreateAccessTokenRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.parent !== "") {
+      writer.uint32(10).string(message.parent);
+    }
+    if (message.accessToken !== undefined) {
+      AccessToken.encode(message.accessToken, writer.uint32(18).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CreateAccessTokenRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateAccessTokenRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag != 10) {
+            break;
+          }
+
+          message.parent = reader.string();
+          continue;
+        case 2:
+          if (tag != 18) {
+            break;
+          }
+
+          message.accessToken = AccessToken.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) == 4 || tag == 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateAccessTokenRequest {
+    return {
+      parent: isSet(object.parent) ? String(object.parent) : "",
+      accessToken: isSet(object.accessToken) ? AccessToken.fromJSON(object.accessToken) : undefined,
+    };
+  },
+
+  toJSON(message: CreateAccessTokenRequest): unknown {
+    const obj: any = {};
+    message.parent !== undefined && (obj.parent = message.parent);
+    message.accessToken !== undefined &&
+      (obj.accessToken = message.accessToken ? AccessToken.toJSON(message.accessToken) : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<CreateAccessTokenRequest>): CreateAccessTokenRequest {
+    return CreateAccessTokenRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<CreateAccessTokenRequest>): CreateAccessTokenRequest {
+    const message = createBaseCreateAccessTokenRequest();
+    message.parent = object.parent ?? "";
+    message.accessToken = (object.accessToken !== undefined && object.accessToken !== null)
+      ? AccessToken.fromPartial(object.accessToken)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseDeleteAccessTokenRequest(): DeleteAccessTokenRequest {
+  return { name: "" };
+}
+
+export const DeleteAccessTokenRequest = {
+  encode(message: DeleteAccessTokenRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DeleteAccessTokenRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDeleteAccessTokenRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag != 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) == 4 || tag == 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DeleteAccessTokenRequest {
+    return { name: isSet(object.name) ? String(object.name) : "" };
+  },
+
+  toJSON(message: DeleteAccessTokenRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  create(base?: DeepPartial<DeleteAccessTokenRequest>): DeleteAccessTokenRequest {
+    return DeleteAccessTokenRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<DeleteAccessTokenRequest>): DeleteAccessTokenRequest {
+    const message = createBaseDeleteAccessTokenRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseAccessToken(): AccessToken {
+  return {
+    name: "",
+    title: "",
+    scopes: [],
+    project: "",
+    expireTime: undefined,
+    createTime: undefined,
+    lastUsedTime: undefined,
+    token: "",
+  };
+}
+
+export const AccessToken = {
+  encode(message: AccessToken, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.title !== "") {
+      writer.uint32(18).string(message.title);
+    }
+    writer.uint32(26).fork();
+    for (const v of message.scopes) {
+      writer.int32(v);
+    }
+    writer.ldelim();
+    if (message.project !== "") {
+      writer.uint32(34).string(message.project);
+    }
+    if (message.expireTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.expireTime), writer.uint32(42).fork()).ldelim();
+    }
+    if (message.createTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.createTime), writer.uint32(50).fork()).ldelim();
+    }
+    if (message.lastUsedTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.lastUsedTime), writer.uint32(58).fork()).ldelim();
+    }
+    if (message.token !== "") {
+      writer.uint32(66).string(message.token);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): AccessToken {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAccessToken();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag != 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag != 18) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 3:
+          if (tag == 24) {
+            message.scopes.push(reader.int32() as any);
+            continue;
+          }
+
+          if (tag == 26) {
+            const end2 = reader.uint32() + reader.pos;
+            while (reader.pos < end2) {
+              message.scopes.push(reader.int32() as any);
+            }
+
+            continue;
+          }
+
+          break;
+        case 4:
+          if (tag != 34) {
+            break;
+          }
+
+          message.project = reader.string();
+          continue;
+        case 5:
+          if (tag != 42) {
+            break;
+          }
+
+          message.expireTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 6:
+          if (tag != 50) {
+            break;
+          }
+
+          message.createTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 7:
+          if (tag != 58) {
+            break;
+          }
+
+          message.lastUsedTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 8:
+          if (tag != 66) {
+            break;
+          }
+
+          message.token = reader.string();
+          continue;
+      }
+      if ((tag & 7) == 4 || tag == 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): AccessToken {
+    return {
+      name: isSet(object.name) ? String(object.name) : "",
+      title: isSet(object.title) ? String(object.title) : "",
+      scopes: Array.isArray(object?.scopes) ? object.scopes.map((e: any) => accessTokenScopeFromJSON(e)) : [],
+      project: isSet(object.project) ? String(object.project) : "",
+      expireTime: isSet(object.expireTime) ? fromJsonTimestamp(object.expireTime) : undefined,
+      createTime: isSet(object.createTime) ? fromJsonTimestamp(object.createTime) : undefined,
+      lastUsedTime: isSet(object.lastUsedTime) ? fromJsonTimestamp(object.lastUsedTime) : undefined,
+      token: isSet(object.token) ? String(object.token) : "",
+    };
+  },
+
+  toJSON(message: AccessToken): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.title !== undefined && (obj.title = message.title);
+    if (message.scopes) {
+      obj.scopes = message.scopes.map((e) => accessTokenScopeToJSON(e));
+    } else {
+      obj.scopes = [];
+    }
+    message.project !== undefined && (obj.project = message.project);
+    message.expireTime !== undefined && (obj.expireTime = message.expireTime.toISOString());
+    message.createTime !== undefined && (obj.createTime = message.createTime.toISOString());
+    message.lastUsedTime !== undefined && (obj.lastUsedTime = message.lastUsedTime.toISOString());
+    message.token !== undefined && (obj.token = message.token);
+    return obj;
+  },
+
+  create(base?: DeepPartial<AccessToken>): AccessToken {
+    return AccessToken.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<AccessToken>): AccessToken {
+    const message = createBaseAccessToken();
+    message.name = object.name ?? "";
+    message.title = object.title ?? "";
+    message.scopes = object.scopes?.map((e) => e) || [];
+    message.project = object.project ?? "";
+    message.expireTime = object.expireTime ?? undefined;
+    message.createTime = object.createTime ?? undefined;
+    message.lastUsedTime = object.lastUsedTime ?? undefined;
+    message.token = object.token ?? "";
+    return message;
+  },
+};
+
 export type AuthServiceDefinition = typeof AuthServiceDefinition;
 export const AuthServiceDefinition = {
   name: "AuthService",
@@ -1655,6 +2188,199 @@ export const AuthServiceDefinition = {
         },
       },
     },
+    listAccessTokens: {
+      name: "ListAccessTokens",
+      requestType: ListAccessTokensRequest,
+      requestStream: false,
+      responseType: ListAccessTokensResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([6, 112, 97, 114, 101, 110, 116])],
+          578365826: [
+            new Uint8Array([
+              35,
+              18,
+              33,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              61,
+              117,
+              115,
+              101,
+              114,
+              115,
+              47,
+              42,
+              125,
+              47,
+              97,
+              99,
+              99,
+              101,
+              115,
+              115,
+              84,
+              111,
+              107,
+              101,
+              110,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
+    createAccessToken: {
+      name: "CreateAccessToken",
+      requestType: CreateAccessTokenRequest,
+      requestStream: false,
+      responseType: AccessToken,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [
+            new Uint8Array([
+              19,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              44,
+              97,
+              99,
+              99,
+              101,
+              115,
+              115,
+              95,
+              116,
+              111,
+              107,
+              101,
+              110,
+            ]),
+          ],
+          578365826: [
+            new Uint8Array([
+              49,
+              58,
+              12,
+              97,
+              99,
+              99,
+              101,
+              115,
+              115,
+              95,
+              116,
+              111,
+              107,
+              101,
+              110,
+              34,
+              33,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              61,
+              117,
+              115,
+              101,
+              114,
+              115,
+              47,
+              42,
+              125,
+              47,
+              97,
+              99,
+              99,
+              101,
+              115,
+              115,
+              84,
+              111,
+              107,
+              101,
+              110,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
+    deleteAccessToken: {
+      name: "DeleteAccessToken",
+      requestType: DeleteAccessTokenRequest,
+      requestStream: false,
+      responseType: Empty,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([4, 110, 97, 109, 101])],
+          578365826: [
+            new Uint8Array([
+              35,
+              42,
+              33,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              117,
+              115,
+              101,
+              114,
+              115,
+              47,
+              42,
+              47,
+              97,
+              99,
+              99,
+              101,
+              115,
+              115,
+              84,
+              111,
+              107,
+              101,
+              110,
+              115,
+              47,
+              42,
+              125,
+            ]),
+          ],
+        },
+      },
+    },
   },
 } as const;
 
@@ -1667,6 +2393,18 @@ export interface AuthServiceImplementation<CallContextExt = {}> {
   undeleteUser(request: UndeleteUserRequest, context: CallContext & CallContextExt): Promise<DeepPartial<User>>;
   login(request: LoginRequest, context: CallContext & CallContextExt): Promise<DeepPartial<LoginResponse>>;
   logout(request: LogoutRequest, context: CallContext & CallContextExt): Promise<DeepPartial<Empty>>;
+  listAccessTokens(
+    request: ListAccessTokensRequest,
+    context: CallContext & CallContextExt,
+  ): Promise<DeepPartial<ListAccessTokensResponse>>;
+  createAccessToken(
+    request: CreateAccessTokenRequest,
+    context: CallContext & CallContextExt,
+  ): Promise<DeepPartial<AccessToken>>;
+  deleteAccessToken(
+    request: DeleteAccessTokenRequest,
+    context: CallContext & CallContextExt,
+  ): Promise<DeepPartial<Empty>>;
 }
 
 export interface AuthServiceClient<CallOptionsExt = {}> {
@@ -1678,6 +2416,18 @@ export interface AuthServiceClient<CallOptionsExt = {}> {
   undeleteUser(request: DeepPartial<UndeleteUserRequest>, options?: CallOptions & CallOptionsExt): Promise<User>;
   login(request: DeepPartial<LoginRequest>, options?: CallOptions & CallOptionsExt): Promise<LoginResponse>;
   logout(request: DeepPartial<LogoutRequest>, options?: CallOptions & CallOptionsExt): Promise<Empty>;
+  listAccessTokens(
+    request: DeepPartial<ListAccessTokensRequest>,
+    options?: CallOptions & CallOptionsExt,
+  ): Promise<ListAccessTokensResponse>;
+  createAccessToken(
+    request: DeepPartial<CreateAccessTokenRequest>,
+    options?: CallOptions & CallOptionsExt,
+  ): Promise<AccessToken>;
+  deleteAccessToken(
+    request: DeepPartial<DeleteAccessTokenRequest>,
+    options?: CallOptions & CallOptionsExt,
+  ): Promise<Empty>;
 }
 
 type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
@@ -1687,6 +2437,28 @@ export type DeepPartial<T> = T extends Builtin ? T
   : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
   : Partial<T>;
 
+function toTimestamp(date: Date): Timestamp {
+  const seconds = date.getTime() / 1_000;
+  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  return { seconds, nanos };
+}
+
+function fromTimestamp(t: Timestamp): Date {
+  let millis = t.seconds * 1_000;
+  millis += t.nanos / 1_000_000;
+  return new Date(millis);
+}
+
+function fromJsonTimestamp(o: any): Date {
+  if (o instanceof Date) {
+    return o;
+  } else if (typeof o === "string") {
+    return new Date(o);
+  } else {
+    return fromTimestamp(Timestamp.fromJSON(o));
+  }
+}
+
 function isSet(value: any): boolean {
   return value !== null && value !== undefined;
 }
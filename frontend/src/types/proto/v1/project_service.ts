@@ -3,6 +3,7 @@ import type { CallContext, CallOptions } from "nice-grpc-common";
 import * as _m0 from "protobufjs/minimal";
 import { Empty } from "../google/protobuf/empty";
 import { FieldMask } from "../google/protobuf/field_mask";
+import { Timestamp } from "../google/protobuf/timestamp";
 import { State, stateFromJSON, stateToJSON } from "./common";
 import { ProjectGitOpsInfo } from "./externalvs_service";
 
@@ -467,6 +468,100 @@ export interface TestWebhookResponse {
   error: string;
 }
 
+export interface ListWebhookDeliveriesRequest {
+  /**
+   * The name of the project which owns the webhook.
+   * Format: projects/{project}
+   */
+  project: string;
+  /** The webhook to list the deliveries of. Identified by its url. */
+  webhook?: Webhook;
+  /**
+   * The maximum number of deliveries to return, the latest deliveries are returned first.
+   * If unspecified, at most 50 deliveries will be returned.
+   */
+  pageSize: number;
+}
+
+export interface ListWebhookDeliveriesResponse {
+  /** The deliveries of the webhook. */
+  deliveries: WebhookDelivery[];
+}
+
+export interface RedeliverWebhookRequest {
+  /**
+   * The name of the project which owns the webhook.
+   * Format: projects/{project}
+   */
+  project: string;
+  /** The webhook to redeliver. Identified by its url. */
+  webhook?: Webhook;
+  /**
+   * The id of the delivery to redeliver.
+   * A new delivery with the same payload is queued, and the original one is kept.
+   */
+  deliveryId: number;
+}
+
+export interface WebhookDelivery {
+  /** The id of the delivery. */
+  id: number;
+  status: WebhookDelivery_Status;
+  /** The number of the attempts made. */
+  attempt: number;
+  /** The error of the last failed attempt. */
+  lastError: string;
+  createTime?: Date;
+  updateTime?: Date;
+}
+
+export enum WebhookDelivery_Status {
+  STATUS_UNSPECIFIED = 0,
+  /** PENDING - The delivery is waiting for the next attempt. */
+  PENDING = 1,
+  SUCCESS = 2,
+  /** FAILED - All the attempts of the delivery have failed. */
+  FAILED = 3,
+  UNRECOGNIZED = -1,
+}
+
+export function webhookDelivery_StatusFromJSON(object: any): WebhookDelivery_Status {
+  switch (object) {
+    case 0:
+    case "STATUS_UNSPECIFIED":
+      return WebhookDelivery_Status.STATUS_UNSPECIFIED;
+    case 1:
+    case "PENDING":
+      return WebhookDelivery_Status.PENDING;
+    case 2:
+    case "SUCCESS":
+      return WebhookDelivery_Status.SUCCESS;
+    case 3:
+    case "FAILED":
+      return WebhookDelivery_Status.FAILED;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return WebhookDelivery_Status.UNRECOGNIZED;
+  }
+}
+
+export function webhookDelivery_StatusToJSON(object: WebhookDelivery_Status): string {
+  switch (object) {
+    case WebhookDelivery_Status.STATUS_UNSPECIFIED:
+      return "STATUS_UNSPECIFIED";
+    case WebhookDelivery_Status.PENDING:
+      return "PENDING";
+    case WebhookDelivery_Status.SUCCESS:
+      return "SUCCESS";
+    case WebhookDelivery_Status.FAILED:
+      return "FAILED";
+    case WebhookDelivery_Status.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
 export interface Webhook {
   /** type is the type of the webhook. */
   type: Webhook_Type;
@@ -2344,6 +2439,365 @@ export const TestWebhookResponse = {
   },
 };
 
+function createBaseListWebhookDeliveriesRequest(): ListWebhookDeliveriesRequest {
+  return { project: "", webhook: undefined, pageSize: 0 };
+}
+
+export const ListWebhookDeliveriesRequest = {
+  encode(message: ListWebhookDeliveriesRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.project !== "") {
+      writer.uint32(10).string(message.project);
+    }
+    if (message.webhook !== undefined) {
+      Webhook.encode(message.webhook, writer.uint32(18).fork()).ldelim();
+    }
+    if (message.pageSize !== 0) {
+      writer.uint32(24).int32(message.pageSize);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListWebhookDeliveriesRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListWebhookDeliveriesRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag != 10) {
+            break;
+          }
+
+          message.project = reader.string();
+          continue;
+        case 2:
+          if (tag != 18) {
+            break;
+          }
+
+          message.webhook = Webhook.decode(reader, reader.uint32());
+          continue;
+        case 3:
+          if (tag != 24) {
+            break;
+          }
+
+          message.pageSize = reader.int32();
+          continue;
+      }
+      if ((tag & 7) == 4 || tag == 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListWebhookDeliveriesRequest {
+    return {
+      project: isSet(object.project) ? String(object.project) : "",
+      webhook: isSet(object.webhook) ? Webhook.fromJSON(object.webhook) : undefined,
+      pageSize: isSet(object.pageSize) ? Number(object.pageSize) : 0,
+    };
+  },
+
+  toJSON(message: ListWebhookDeliveriesRequest): unknown {
+    const obj: any = {};
+    message.project !== undefined && (obj.project = message.project);
+    message.webhook !== undefined && (obj.webhook = message.webhook ? Webhook.toJSON(message.webhook) : undefined);
+    message.pageSize !== undefined && (obj.pageSize = Math.round(message.pageSize));
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListWebhookDeliveriesRequest>): ListWebhookDeliveriesRequest {
+    return ListWebhookDeliveriesRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<ListWebhookDeliveriesRequest>): ListWebhookDeliveriesRequest {
+    const message = createBaseListWebhookDeliveriesRequest();
+    message.project = object.project ?? "";
+    message.webhook = (object.webhook !== undefined && object.webhook !== null)
+      ? Webhook.fromPartial(object.webhook)
+      : undefined;
+    message.pageSize = object.pageSize ?? 0;
+    return message;
+  },
+};
+
+function createBaseListWebhookDeliveriesResponse(): ListWebhookDeliveriesResponse {
+  return { deliveries: [] };
+}
+
+export const ListWebhookDeliveriesResponse = {
+  encode(message: ListWebhookDeliveriesResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.deliveries) {
+      WebhookDelivery.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListWebhookDeliveriesResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListWebhookDeliveriesResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag != 10) {
+            break;
+          }
+
+          message.deliveries.push(WebhookDelivery.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) == 4 || tag == 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListWebhookDeliveriesResponse {
+    return {
+      deliveries: Array.isArray(object?.deliveries)
+        ? object.deliveries.map((e: any) => WebhookDelivery.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: ListWebhookDeliveriesResponse): unknown {
+    const obj: any = {};
+    if (message.deliveries) {
+      obj.deliveries = message.deliveries.map((e) => e ? WebhookDelivery.toJSON(e) : undefined);
+    } else {
+      obj.deliveries = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListWebhookDeliveriesResponse>): ListWebhookDeliveriesResponse {
+    return ListWebhookDeliveriesResponse.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<ListWebhookDeliveriesResponse>): ListWebhookDeliveriesResponse {
+    const message = createBaseListWebhookDeliveriesResponse();
+    message.deliveries = object.deliveries?.map((e) => WebhookDelivery.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseRedeliverWebhookRequest(): RedeliverWebhookRequest {
+  return { project: "", webhook: undefined, deliveryId: 0 };
+}
+
+export const RedeliverWebhookRequest = {
+  encode(message: RedeliverWebhookRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.project !== "") {
+      writer.uint32(10).string(message.project);
+    }
+    if (message.webhook !== undefined) {
+      Webhook.encode(message.webhook, writer.uint32(18).fork()).ldelim();
+    }
+    if (message.deliveryId !== 0) {
+      writer.uint32(24).int32(message.deliveryId);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RedeliverWebhookRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRedeliverWebhookRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag != 10) {
+            break;
+          }
+
+          message.project = reader.string();
+          continue;
+        case 2:
+          if (tag != 18) {
+            break;
+          }
+
+          message.webhook = Webhook.decode(reader, reader.uint32());
+          continue;
+        case 3:
+          if (tag != 24) {
+            break;
+          }
+
+          message.deliveryId = reader.int32();
+          continue;
+      }
+      if ((tag & 7) == 4 || tag == 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RedeliverWebhookRequest {
+    return {
+      project: isSet(object.project) ? String(object.project) : "",
+      webhook: isSet(object.webhook) ? Webhook.fromJSON(object.webhook) : undefined,
+      deliveryId: isSet(object.deliveryId) ? Number(object.deliveryId) : 0,
+    };
+  },
+
+  toJSON(message: RedeliverWebhookRequest): unknown {
+    const obj: any = {};
+    message.project !== undefined && (obj.project = message.project);
+    message.webhook !== undefined && (obj.webhook = message.webhook ? Webhook.toJSON(message.webhook) : undefined);
+    message.deliveryId !== undefined && (obj.deliveryId = Math.round(message.deliveryId));
+    return obj;
+  },
+
+  create(base?: DeepPartial<RedeliverWebhookRequest>): RedeliverWebhookRequest {
+    return RedeliverWebhookRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RedeliverWebhookRequest>): RedeliverWebhookRequest {
+    const message = createBaseRedeliverWebhookRequest();
+    message.project = object.project ?? "";
+    message.webhook = (object.webhook !== undefined && object.webhook !== null)
+      ? Webhook.fromPartial(object.webhook)
+      : undefined;
+    message.deliveryId = object.deliveryId ?? 0;
+    return message;
+  },
+};
+
+function createBaseWebhookDelivery(): WebhookDelivery {
+  return { id: 0, status: 0, attempt: 0, lastError: "", createTime: undefined, updateTime: undefined };
+}
+
+export const WebhookDelivery = {
+  encode(message: WebhookDelivery, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.id !== 0) {
+      writer.uint32(8).int32(message.id);
+    }
+    if (message.status !== 0) {
+      writer.uint32(16).int32(message.status);
+    }
+    if (message.attempt !== 0) {
+      writer.uint32(24).int32(message.attempt);
+    }
+    if (message.lastError !== "") {
+      writer.uint32(34).string(message.lastError);
+    }
+    if (message.createTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.createTime), writer.uint32(42).fork()).ldelim();
+    }
+    if (message.updateTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.updateTime), writer.uint32(50).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): WebhookDelivery {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseWebhookDelivery();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag != 8) {
+            break;
+          }
+
+          message.id = reader.int32();
+          continue;
+        case 2:
+          if (tag != 16) {
+            break;
+          }
+
+          message.status = reader.int32() as any;
+          continue;
+        case 3:
+          if (tag != 24) {
+            break;
+          }
+
+          message.attempt = reader.int32();
+          continue;
+        case 4:
+          if (tag != 34) {
+            break;
+          }
+
+          message.lastError = reader.string();
+          continue;
+        case 5:
+          if (tag != 42) {
+            break;
+          }
+
+          message.createTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 6:
+          if (tag != 50) {
+            break;
+          }
+
+          message.updateTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) == 4 || tag == 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): WebhookDelivery {
+    return {
+      id: isSet(object.id) ? Number(object.id) : 0,
+      status: isSet(object.status) ? webhookDelivery_StatusFromJSON(object.status) : 0,
+      attempt: isSet(object.attempt) ? Number(object.attempt) : 0,
+      lastError: isSet(object.lastError) ? String(object.lastError) : "",
+      createTime: isSet(object.createTime) ? fromJsonTimestamp(object.createTime) : undefined,
+      updateTime: isSet(object.updateTime) ? fromJsonTimestamp(object.updateTime) : undefined,
+    };
+  },
+
+  toJSON(message: WebhookDelivery): unknown {
+    const obj: any = {};
+    message.id !== undefined && (obj.id = Math.round(message.id));
+    message.status !== undefined && (obj.status = webhookDelivery_StatusToJSON(message.status));
+    message.attempt !== undefined && (obj.attempt = Math.round(message.attempt));
+    message.lastError !== undefined && (obj.lastError = message.lastError);
+    message.createTime !== undefined && (obj.createTime = message.createTime.toISOString());
+    message.updateTime !== undefined && (obj.updateTime = message.updateTime.toISOString());
+    return obj;
+  },
+
+  create(base?: DeepPartial<WebhookDelivery>): WebhookDelivery {
+    return WebhookDelivery.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<WebhookDelivery>): WebhookDelivery {
+    const message = createBaseWebhookDelivery();
+    message.id = object.id ?? 0;
+    message.status = object.status ?? 0;
+    message.attempt = object.attempt ?? 0;
+    message.lastError = object.lastError ?? "";
+    message.createTime = object.createTime ?? undefined;
+    message.updateTime = object.updateTime ?? undefined;
+    return message;
+  },
+};
+
 function createBaseWebhook(): Webhook {
   return { type: 0, title: "", url: "", notificationTypes: [] };
 }
@@ -3676,6 +4130,135 @@ export const ProjectServiceDefinition = {
         },
       },
     },
+    listWebhookDeliveries: {
+      name: "ListWebhookDeliveries",
+      requestType: ListWebhookDeliveriesRequest,
+      requestStream: false,
+      responseType: ListWebhookDeliveriesResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          578365826: [
+            new Uint8Array([
+              51,
+              58,
+              1,
+              42,
+              34,
+              46,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              125,
+              58,
+              108,
+              105,
+              115,
+              116,
+              87,
+              101,
+              98,
+              104,
+              111,
+              111,
+              107,
+              68,
+              101,
+              108,
+              105,
+              118,
+              101,
+              114,
+              105,
+              101,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
+    redeliverWebhook: {
+      name: "RedeliverWebhook",
+      requestType: RedeliverWebhookRequest,
+      requestStream: false,
+      responseType: WebhookDelivery,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          578365826: [
+            new Uint8Array([
+              46,
+              58,
+              1,
+              42,
+              34,
+              41,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              125,
+              58,
+              114,
+              101,
+              100,
+              101,
+              108,
+              105,
+              118,
+              101,
+              114,
+              87,
+              101,
+              98,
+              104,
+              111,
+              111,
+              107,
+            ]),
+          ],
+        },
+      },
+    },
     setProjectGitOpsInfo: {
       name: "SetProjectGitOpsInfo",
       requestType: SetProjectGitOpsInfoRequest,
@@ -3818,6 +4401,14 @@ export interface ProjectServiceImplementation<CallContextExt = {}> {
     request: TestWebhookRequest,
     context: CallContext & CallContextExt,
   ): Promise<DeepPartial<TestWebhookResponse>>;
+  listWebhookDeliveries(
+    request: ListWebhookDeliveriesRequest,
+    context: CallContext & CallContextExt,
+  ): Promise<DeepPartial<ListWebhookDeliveriesResponse>>;
+  redeliverWebhook(
+    request: RedeliverWebhookRequest,
+    context: CallContext & CallContextExt,
+  ): Promise<DeepPartial<WebhookDelivery>>;
   setProjectGitOpsInfo(
     request: SetProjectGitOpsInfoRequest,
     context: CallContext & CallContextExt,
@@ -3858,6 +4449,14 @@ export interface ProjectServiceClient<CallOptionsExt = {}> {
     request: DeepPartial<TestWebhookRequest>,
     options?: CallOptions & CallOptionsExt,
   ): Promise<TestWebhookResponse>;
+  listWebhookDeliveries(
+    request: DeepPartial<ListWebhookDeliveriesRequest>,
+    options?: CallOptions & CallOptionsExt,
+  ): Promise<ListWebhookDeliveriesResponse>;
+  redeliverWebhook(
+    request: DeepPartial<RedeliverWebhookRequest>,
+    options?: CallOptions & CallOptionsExt,
+  ): Promise<WebhookDelivery>;
   setProjectGitOpsInfo(
     request: DeepPartial<SetProjectGitOpsInfoRequest>,
     options?: CallOptions & CallOptionsExt,
@@ -3875,6 +4474,28 @@ export type DeepPartial<T> = T extends Builtin ? T
   : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
   : Partial<T>;
 
+function toTimestamp(date: Date): Timestamp {
+  const seconds = date.getTime() / 1_000;
+  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  return { seconds, nanos };
+}
+
+function fromTimestamp(t: Timestamp): Date {
+  let millis = t.seconds * 1_000;
+  millis += t.nanos / 1_000_000;
+  return new Date(millis);
+}
+
+function fromJsonTimestamp(o: any): Date {
+  if (o instanceof Date) {
+    return o;
+  } else if (typeof o === "string") {
+    return new Date(o);
+  } else {
+    return fromTimestamp(Timestamp.fromJSON(o));
+  }
+}
+
 function isSet(value: any): boolean {
   return value !== null && value !== undefined;
 }
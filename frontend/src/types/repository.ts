@@ -111,6 +111,11 @@ export function baseDirectoryWebUrl(
     if (!isEmpty(repository.baseDirectory)) {
       url += `/${repository.baseDirectory}`;
     }
+  } else if (repository.vcs.type == "GITEA") {
+    url = `${repository.webUrl}/src/branch/${repository.branchFilter}`;
+    if (!isEmpty(repository.baseDirectory)) {
+      url += `/${repository.baseDirectory}`;
+    }
   }
   if (url) {
     // Replace the patterns in the filePathTemplate if possible.
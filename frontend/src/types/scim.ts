@@ -0,0 +1,18 @@
+import { ProjectId } from "./id";
+import { RoleType } from "./member";
+import { ProjectRoleType } from "./project";
+
+// SCIMGroupMapping grants the members of a SCIM group, identified by its
+// display name, a workspace role and/or a project membership.
+export type SCIMGroupMapping = {
+  group: string;
+  role?: RoleType;
+  projectId?: ProjectId;
+  projectRole?: ProjectRoleType;
+};
+
+export type SCIMSetting = {
+  // The token itself is only returned once when it's generated.
+  tokenConfigured: boolean;
+  groupMappingList: SCIMGroupMapping[];
+};
@@ -0,0 +1,37 @@
+import { DatabaseId } from "./id";
+import { SensitiveDataMaskType } from "./policy";
+
+export type SensitiveColumnClassification =
+  | "EMAIL"
+  | "PHONE"
+  | "NATIONAL_ID"
+  | "CREDIT_CARD";
+
+export type SensitiveColumnSuggestionStatus = "OPEN" | "ACCEPTED" | "DISMISSED";
+
+export type SensitiveColumnSuggestion = {
+  id: number;
+
+  // Standard fields
+  createdTs: number;
+  updatedTs: number;
+
+  // Related fields
+  databaseId: DatabaseId;
+
+  // Domain specific fields
+  schema: string;
+  table: string;
+  column: string;
+  classification: SensitiveColumnClassification;
+  // confidence is in [0, 1].
+  confidence: number;
+  maskType: SensitiveDataMaskType;
+  reason: string;
+  status: SensitiveColumnSuggestionStatus;
+};
+
+export type SensitiveColumnSuggestionFind = {
+  databaseId?: DatabaseId;
+  status?: SensitiveColumnSuggestionStatus;
+};
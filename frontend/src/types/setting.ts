@@ -7,7 +7,8 @@ export type SettingName =
   | "bb.workspace.profile"
   | "bb.workspace.approval"
   | "bb.plugin.openai.key"
-  | "bb.plugin.openai.endpoint";
+  | "bb.plugin.openai.endpoint"
+  | "bb.workspace.stage-gate-metric-allowlist";
 
 export type Setting = {
   id: SettingId;
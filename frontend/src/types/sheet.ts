@@ -17,6 +17,8 @@ export type SheetSource =
   | "GITLAB"
   | "GITHUB"
   | "BITBUCKET"
+  | "GITEA"
+  | "GIT"
   | "BYTEBASE_ARTIFACT";
 
 export type SheetType = "SQL";
@@ -1,5 +1,5 @@
 import { EngineType } from "./instance";
-import { InstanceId } from "./id";
+import { InstanceId, PrincipalId } from "./id";
 import { Advice } from "./sqlAdvice";
 
 export type ConnectionInfo = {
@@ -33,6 +33,58 @@ export type QueryInfo = {
   limit?: number;
 };
 
+export type ExplainInfo = Omit<QueryInfo, "limit"> & {
+  // Executes the statement to collect the actual rows and time, only allowed
+  // for the instances with a read replica.
+  analyze: boolean;
+};
+
+// ExplainNode is a node of the engine-independent query plan tree.
+export type ExplainNode = {
+  nodeType: string;
+  relation?: string;
+  index?: string;
+  estimatedRows: number;
+  estimatedCost: number;
+  // Only available when the plan is analyzed.
+  actualRows?: number;
+  actualTimeMs?: number;
+  fullScan: boolean;
+  filesort: boolean;
+  nestedLoop: boolean;
+  detail?: string;
+  children?: ExplainNode[];
+};
+
+export type SQLExplainResult = {
+  plan: ExplainNode;
+  adviceList: Advice[];
+};
+
+export type SQLRunningQuery = {
+  id: string;
+
+  // Standard fields
+  creatorId: PrincipalId;
+
+  // Domain specific fields
+  instanceId: InstanceId;
+  databaseName: string;
+  statement: string;
+  startedTs: number;
+  // No limit if it's 0.
+  maxExecutionSeconds: number;
+};
+
+// CSV, JSON Lines, SQL INSERT statements and Excel workbook.
+export type SQLExportFormat = "CSV" | "JSON" | "SQL" | "XLSX";
+
+export type ExportInfo = Omit<QueryInfo, "limit"> & {
+  format: SQLExportFormat;
+  // The table name in the INSERT statements of the SQL format.
+  tableName?: string;
+};
+
 // TODO(Jim): not used yet
 export type SingleSQLResult = {
   // [columnNames: string[], types: string[], data: any[][], sensitive?: boolean[]]
@@ -9,7 +9,12 @@ import sqlReviewProdTemplate from "./sql-review.prod.yaml";
 import sqlReviewDevTemplate from "./sql-review.dev.yaml";
 
 // The engine type for rule template
-export type SchemaRuleEngineType = "MYSQL" | "POSTGRES" | "TIDB";
+export type SchemaRuleEngineType =
+  | "MYSQL"
+  | "POSTGRES"
+  | "TIDB"
+  | "ORACLE"
+  | "MSSQL";
 
 // The category type for rule template
 export type CategoryType =
@@ -145,7 +150,8 @@ export type RuleType =
   | "index.total-number-limit"
   | "index.primary-key-type-allowlist"
   | "index.create-concurrently"
-  | "index.pk-type-limit";
+  | "index.pk-type-limit"
+  | "custom";
 
 // The naming format rule payload.
 // Used by the backend.
@@ -173,6 +179,14 @@ interface NumberLimitPayload {
   number: number;
 }
 
+// The custom rule payload.
+// Used by the backend.
+interface CustomRulePayload {
+  title: string;
+  expression: string;
+  message: string;
+}
+
 // The SchemaPolicyRule stores the rule configuration by users.
 // Used by the backend
 export interface SchemaPolicyRule {
@@ -182,7 +196,8 @@ export interface SchemaPolicyRule {
     | NamingFormatPayload
     | StringArrayLimitPayload
     | CommentFormatPayload
-    | NumberLimitPayload;
+    | NumberLimitPayload
+    | CustomRulePayload;
   comment: string;
 }
 
@@ -541,6 +556,13 @@ export const convertPolicyRuleToRuleTemplate = (
 
 // The convertRuleTemplateToPolicyRule will convert rule template to review policy rule for backend useage.
 // Will throw exception if we don't implement the payload handler for specific type of rule.
+// Custom rules are not editable in the rule templates, keep them as is.
+export const customRuleListOfPolicy = (
+  policy: SQLReviewPolicy | undefined
+): SchemaPolicyRule[] => {
+  return (policy?.ruleList ?? []).filter((rule) => rule.type === "custom");
+};
+
 export const convertRuleTemplateToPolicyRule = (
   rule: RuleTemplate
 ): SchemaPolicyRule => {
@@ -2,7 +2,7 @@ import { VCSId } from "./id";
 
 // Backend uses the same ENUM for GitLab/GitHub SaaS and self-hosted. Because they are based on the
 // same codebase.
-export type VCSType = "GITLAB" | "GITHUB" | "BITBUCKET";
+export type VCSType = "GITLAB" | "GITHUB" | "BITBUCKET" | "GITEA" | "GIT";
 
 // When configuring the VCS, we split the SaaS and self-hosted into two types to present optimal UX.
 export type VCSUIType =
@@ -1,4 +1,8 @@
-import type { DeploymentConfig, DeploymentSpec } from "@/types";
+import type {
+  DeploymentConfig,
+  DeploymentGate,
+  DeploymentSpec,
+} from "@/types";
 import { PRESET_DB_NAME_TEMPLATE_PLACEHOLDERS } from "./label";
 
 export const validateDeploymentConfig = (
@@ -45,6 +49,35 @@ export const validateDeploymentSpec = (
       return "deployment-config.error.values-required";
     }
   }
+  if (deployment.gate) {
+    return validateDeploymentGate(deployment.gate);
+  }
+  return undefined;
+};
+
+export const validateDeploymentGate = (
+  gate: DeploymentGate
+): string | undefined => {
+  if (gate.bakeTimeSeconds < 0) {
+    return "deployment-config.error.gate-bake-time-invalid";
+  }
+  const percent = gate.maxFailurePercent;
+  if (
+    percent !== undefined &&
+    percent !== null &&
+    (percent < 0 || percent > 100)
+  ) {
+    return "deployment-config.error.gate-max-failure-percent-invalid";
+  }
+  const healthCheck = gate.healthCheck;
+  if (healthCheck) {
+    if (!healthCheck.statement.trim() === !healthCheck.url.trim()) {
+      return "deployment-config.error.gate-health-check-required";
+    }
+    if (healthCheck.url && !/^https?:\/\/[^/]+/.test(healthCheck.url)) {
+      return "deployment-config.error.gate-health-check-url-invalid";
+    }
+  }
   return undefined;
 };
 
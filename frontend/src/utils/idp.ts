@@ -10,6 +10,8 @@ export const identityProviderTypeToString = (
     return "OAuth 2.0";
   } else if (type === IdentityProviderType.OIDC) {
     return "OIDC";
+  } else if (type === IdentityProviderType.LDAP) {
+    return "LDAP";
   } else {
     throw new Error(`identity provider type ${type} not found`);
   }
@@ -93,6 +93,7 @@ export const buildIssueLinkWithTask = (
 export const HiddenCheckTypes = new Set<TaskCheckType>([
   "bb.task-check.database.statement.type.report",
   "bb.task-check.database.statement.affected-rows.report",
+  "bb.task-check.database.statement.lock-impact.report",
 ]);
 
 export type TaskCheckRunSummary = {
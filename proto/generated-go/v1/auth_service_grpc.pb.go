@@ -20,14 +20,17 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	AuthService_GetUser_FullMethodName      = "/bytebase.v1.AuthService/GetUser"
-	AuthService_ListUsers_FullMethodName    = "/bytebase.v1.AuthService/ListUsers"
-	AuthService_CreateUser_FullMethodName   = "/bytebase.v1.AuthService/CreateUser"
-	AuthService_UpdateUser_FullMethodName   = "/bytebase.v1.AuthService/UpdateUser"
-	AuthService_DeleteUser_FullMethodName   = "/bytebase.v1.AuthService/DeleteUser"
-	AuthService_UndeleteUser_FullMethodName = "/bytebase.v1.AuthService/UndeleteUser"
-	AuthService_Login_FullMethodName        = "/bytebase.v1.AuthService/Login"
-	AuthService_Logout_FullMethodName       = "/bytebase.v1.AuthService/Logout"
+	AuthService_GetUser_FullMethodName           = "/bytebase.v1.AuthService/GetUser"
+	AuthService_ListUsers_FullMethodName         = "/bytebase.v1.AuthService/ListUsers"
+	AuthService_CreateUser_FullMethodName        = "/bytebase.v1.AuthService/CreateUser"
+	AuthService_UpdateUser_FullMethodName        = "/bytebase.v1.AuthService/UpdateUser"
+	AuthService_DeleteUser_FullMethodName        = "/bytebase.v1.AuthService/DeleteUser"
+	AuthService_UndeleteUser_FullMethodName      = "/bytebase.v1.AuthService/UndeleteUser"
+	AuthService_Login_FullMethodName             = "/bytebase.v1.AuthService/Login"
+	AuthService_Logout_FullMethodName            = "/bytebase.v1.AuthService/Logout"
+	AuthService_ListAccessTokens_FullMethodName  = "/bytebase.v1.AuthService/ListAccessTokens"
+	AuthService_CreateAccessToken_FullMethodName = "/bytebase.v1.AuthService/CreateAccessToken"
+	AuthService_DeleteAccessToken_FullMethodName = "/bytebase.v1.AuthService/DeleteAccessToken"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -42,6 +45,13 @@ type AuthServiceClient interface {
 	UndeleteUser(ctx context.Context, in *UndeleteUserRequest, opts ...grpc.CallOption) (*User, error)
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListAccessTokens lists the personal access tokens of a user or service account.
+	ListAccessTokens(ctx context.Context, in *ListAccessTokensRequest, opts ...grpc.CallOption) (*ListAccessTokensResponse, error)
+	// CreateAccessToken creates a personal access token for a user or service account.
+	// The token is returned in the response only once.
+	CreateAccessToken(ctx context.Context, in *CreateAccessTokenRequest, opts ...grpc.CallOption) (*AccessToken, error)
+	// DeleteAccessToken revokes a personal access token.
+	DeleteAccessToken(ctx context.Context, in *DeleteAccessTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type authServiceClient struct {
@@ -124,6 +134,33 @@ func (c *authServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts
 	return out, nil
 }
 
+func (c *authServiceClient) ListAccessTokens(ctx context.Context, in *ListAccessTokensRequest, opts ...grpc.CallOption) (*ListAccessTokensResponse, error) {
+	out := new(ListAccessTokensResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListAccessTokens_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CreateAccessToken(ctx context.Context, in *CreateAccessTokenRequest, opts ...grpc.CallOption) (*AccessToken, error) {
+	out := new(AccessToken)
+	err := c.cc.Invoke(ctx, AuthService_CreateAccessToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DeleteAccessToken(ctx context.Context, in *DeleteAccessTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_DeleteAccessToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations must embed UnimplementedAuthServiceServer
 // for forward compatibility
@@ -136,6 +173,13 @@ type AuthServiceServer interface {
 	UndeleteUser(context.Context, *UndeleteUserRequest) (*User, error)
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 	Logout(context.Context, *LogoutRequest) (*emptypb.Empty, error)
+	// ListAccessTokens lists the personal access tokens of a user or service account.
+	ListAccessTokens(context.Context, *ListAccessTokensRequest) (*ListAccessTokensResponse, error)
+	// CreateAccessToken creates a personal access token for a user or service account.
+	// The token is returned in the response only once.
+	CreateAccessToken(context.Context, *CreateAccessTokenRequest) (*AccessToken, error)
+	// DeleteAccessToken revokes a personal access token.
+	DeleteAccessToken(context.Context, *DeleteAccessTokenRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedAuthServiceServer()
 }
 
@@ -167,6 +211,15 @@ func (UnimplementedAuthServiceServer) Login(context.Context, *LoginRequest) (*Lo
 func (UnimplementedAuthServiceServer) Logout(context.Context, *LogoutRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
 }
+func (UnimplementedAuthServiceServer) ListAccessTokens(context.Context, *ListAccessTokensRequest) (*ListAccessTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessTokens not implemented")
+}
+func (UnimplementedAuthServiceServer) CreateAccessToken(context.Context, *CreateAccessTokenRequest) (*AccessToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccessToken not implemented")
+}
+func (UnimplementedAuthServiceServer) DeleteAccessToken(context.Context, *DeleteAccessTokenRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccessToken not implemented")
+}
 func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
 
 // UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -324,6 +377,60 @@ func _AuthService_Logout_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_ListAccessTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListAccessTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListAccessTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListAccessTokens(ctx, req.(*ListAccessTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CreateAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccessTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CreateAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CreateAccessToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CreateAccessToken(ctx, req.(*CreateAccessTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DeleteAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccessTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DeleteAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DeleteAccessToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DeleteAccessToken(ctx, req.(*DeleteAccessTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -363,6 +470,18 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Logout",
 			Handler:    _AuthService_Logout_Handler,
 		},
+		{
+			MethodName: "ListAccessTokens",
+			Handler:    _AuthService_ListAccessTokens_Handler,
+		},
+		{
+			MethodName: "CreateAccessToken",
+			Handler:    _AuthService_CreateAccessToken_Handler,
+		},
+		{
+			MethodName: "DeleteAccessToken",
+			Handler:    _AuthService_DeleteAccessToken_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1/auth_service.proto",